@@ -0,0 +1,306 @@
+// Package cache keeps employee lookups in Redis, in front of the store holding the employees, so that reads repeated
+// across requests and instances are served without querying the store.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"WebMVCEmployees/logging"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+	"WebMVCEmployees/services"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// keyPrefix namespaces the keys of the cache in Redis.
+const keyPrefix = "employees:"
+
+// generationKey counts the writes to the employees. Every entry records the count it was read at, so a write makes
+// every entry cached before it stale at once, on every instance sharing the Redis server.
+const generationKey = keyPrefix + "generation"
+
+// EmployeeStore is a read-through cache in front of an EmployeeStore. Employees found by email or criteria and lists of
+// employees are kept in Redis for TTL; every write through the store invalidates them all. Reads inside a transaction
+// go to the store, so they see the writes of the transaction. When Redis fails, reads fall back to the store; a write
+// whose invalidation fails is logged, and the employees it changed may be served stale until their entries expire.
+type EmployeeStore struct {
+	Store  services.EmployeeStore
+	Client *redis.Client
+	TTL    time.Duration
+}
+
+// NewEmployeeStore caches the lookups of store in the Redis of client for ttl.
+func NewEmployeeStore(store services.EmployeeStore, client *redis.Client, ttl time.Duration) *EmployeeStore {
+	return &EmployeeStore{Store: store, Client: client, TTL: ttl}
+}
+
+// entry is a cached lookup. It is encoded in BSON, which keeps the fields of employees hidden from JSON.
+type entry struct {
+	Generation int64             `bson:"generation"`
+	Employees  []models.Employee `bson:"employees"`
+}
+
+// transaction marks the contexts of transactions, whose reads bypass the cache.
+type transaction struct{}
+
+// key returns the key caching the lookup of kind with the arguments, or false when they cannot be encoded.
+// json.Marshal sorts map keys, so equal arguments get the same key.
+func key(kind string, args ...any) (string, bool) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(data)
+	return keyPrefix + kind + ":" + hex.EncodeToString(sum[:]), true
+}
+
+// cached returns the employees cached under the key of kind and args, loading and caching them on a miss. Lookups
+// failing, such as those finding no employee, are not cached.
+func (s *EmployeeStore) cached(ctx context.Context, kind string, args []any, load func() ([]models.Employee, error)) ([]models.Employee, error) {
+	k, ok := key(kind, args...)
+	if !ok || ctx.Value(transaction{}) != nil {
+		return load()
+	}
+	// The generation and the entry are read together; the entry is current only when read at this generation.
+	values, err := s.Client.MGet(ctx, generationKey, k).Result()
+	if err != nil {
+		return load()
+	}
+	var generation int64
+	if v, ok := values[0].(string); ok {
+		if generation, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return load()
+		}
+	}
+	if v, ok := values[1].(string); ok {
+		var e entry
+		if bson.Unmarshal([]byte(v), &e) == nil && e.Generation == generation {
+			if e.Employees == nil {
+				e.Employees = []models.Employee{}
+			}
+			return e.Employees, nil
+		}
+	}
+
+	employees, err := load()
+	if err != nil {
+		return nil, err
+	}
+	// Tagged with the generation read before loading, the entry is stale as soon as a write follows the load.
+	if data, err := bson.Marshal(entry{Generation: generation, Employees: employees}); err == nil {
+		s.Client.Set(ctx, k, data, s.TTL)
+	}
+	return employees, nil
+}
+
+// invalidate makes every cached entry stale. It runs even when ctx is done, since the write it follows may have
+// applied.
+func (s *EmployeeStore) invalidate(ctx context.Context) {
+	if err := s.Client.Incr(context.WithoutCancel(ctx), generationKey).Err(); err != nil {
+		logging.FromContext(ctx).Error("Failed to invalidate the employee cache", "error", err)
+	}
+}
+
+// Create inserts a new employee.
+func (s *EmployeeStore) Create(ctx context.Context, emp models.Employee) error {
+	defer s.invalidate(ctx)
+	return s.Store.Create(ctx, emp)
+}
+
+// InsertMany inserts new employees; see services.EmployeeStore.
+func (s *EmployeeStore) InsertMany(ctx context.Context, emps []models.Employee, ordered bool) error {
+	defer s.invalidate(ctx)
+	return s.Store.InsertMany(ctx, emps, ordered)
+}
+
+// FindByEmail returns the employee with the email unless it is soft-deleted.
+func (s *EmployeeStore) FindByEmail(ctx context.Context, email string) (models.Employee, error) {
+	employees, err := s.cached(ctx, "email", []any{email}, func() ([]models.Employee, error) {
+		emp, err := s.Store.FindByEmail(ctx, email)
+		return []models.Employee{emp}, err
+	})
+	if err != nil {
+		return models.Employee{}, err
+	}
+	return employees[0], nil
+}
+
+// FindWithCredentials returns the first employee matching the criteria, password included, from the store:
+// credentials are never cached.
+func (s *EmployeeStore) FindWithCredentials(ctx context.Context, criteria repository.EmployeeCriteria) (models.Employee, error) {
+	return s.Store.FindWithCredentials(ctx, criteria)
+}
+
+// FindOne returns the first employee matching the criteria.
+func (s *EmployeeStore) FindOne(ctx context.Context, criteria repository.EmployeeCriteria) (models.Employee, error) {
+	employees, err := s.cached(ctx, "one", []any{criteria}, func() ([]models.Employee, error) {
+		emp, err := s.Store.FindOne(ctx, criteria)
+		return []models.Employee{emp}, err
+	})
+	if err != nil {
+		return models.Employee{}, err
+	}
+	return employees[0], nil
+}
+
+// List returns a page of the employees matching the criteria.
+func (s *EmployeeStore) List(ctx context.Context, criteria repository.EmployeeCriteria, page repository.Page) ([]models.Employee, error) {
+	return s.cached(ctx, "list", []any{criteria, page}, func() ([]models.Employee, error) {
+		return s.Store.List(ctx, criteria, page)
+	})
+}
+
+// Count returns the number of employees matching the criteria.
+func (s *EmployeeStore) Count(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error) {
+	return s.Store.Count(ctx, criteria)
+}
+
+// ManagerChain returns the first employee matching the criteria followed by the managers above them; see
+// services.EmployeeStore.
+func (s *EmployeeStore) ManagerChain(ctx context.Context, criteria repository.EmployeeCriteria, maxDepth int) ([]models.Employee, error) {
+	return s.Store.ManagerChain(ctx, criteria, maxDepth)
+}
+
+// Reports returns the reports of the first employee matching the criteria; see services.EmployeeStore.
+func (s *EmployeeStore) Reports(ctx context.Context, criteria repository.EmployeeCriteria, maxDepth int, page repository.Page) ([]repository.Report, error) {
+	return s.Store.Reports(ctx, criteria, maxDepth, page)
+}
+
+// CountReports counts the reports of the first employee matching the criteria.
+func (s *EmployeeStore) CountReports(ctx context.Context, criteria repository.EmployeeCriteria) (models.ReportCounts, error) {
+	return s.Store.CountReports(ctx, criteria)
+}
+
+// Anniversaries returns the work anniversaries of the employees matching the criteria; see services.EmployeeStore.
+func (s *EmployeeStore) Anniversaries(ctx context.Context, criteria repository.EmployeeCriteria, month time.Month, year int, page repository.Page) ([]models.Anniversary, error) {
+	return s.Store.Anniversaries(ctx, criteria, month, year, page)
+}
+
+// HeadcountByCostCenter counts the employees matching the criteria charged to each cost center.
+func (s *EmployeeStore) HeadcountByCostCenter(ctx context.Context, criteria repository.EmployeeCriteria) ([]models.CostCenterHeadcount, error) {
+	return s.Store.HeadcountByCostCenter(ctx, criteria)
+}
+
+// Workforce breaks the employees matching the criteria down; see services.EmployeeStore.
+func (s *EmployeeStore) Workforce(ctx context.Context, criteria repository.EmployeeCriteria, now time.Time) (repository.Workforce, error) {
+	return s.Store.Workforce(ctx, criteria, now)
+}
+
+// SetManager sets or removes the manager of the employees matching the criteria.
+func (s *EmployeeStore) SetManager(ctx context.Context, criteria repository.EmployeeCriteria, manager string) (int64, error) {
+	defer s.invalidate(ctx)
+	return s.Store.SetManager(ctx, criteria, manager)
+}
+
+// AddRoles adds the roles to the employees matching the criteria.
+func (s *EmployeeStore) AddRoles(ctx context.Context, criteria repository.EmployeeCriteria, roles []string) (int64, error) {
+	defer s.invalidate(ctx)
+	return s.Store.AddRoles(ctx, criteria, roles)
+}
+
+// GrantTemporaryRole grants the role of the grant to the first employee matching the criteria.
+func (s *EmployeeStore) GrantTemporaryRole(ctx context.Context, criteria repository.EmployeeCriteria, grant models.RoleGrant) (models.Employee, error) {
+	defer s.invalidate(ctx)
+	return s.Store.GrantTemporaryRole(ctx, criteria, grant)
+}
+
+// RevokeExpiredRole removes the expired role from the employees matching the criteria.
+func (s *EmployeeStore) RevokeExpiredRole(ctx context.Context, criteria repository.EmployeeCriteria, role string, now time.Time) (int64, error) {
+	defer s.invalidate(ctx)
+	return s.Store.RevokeExpiredRole(ctx, criteria, role, now)
+}
+
+// SetDelegation sets the delegation of the first employee matching the criteria.
+func (s *EmployeeStore) SetDelegation(ctx context.Context, criteria repository.EmployeeCriteria, delegation models.Delegation) (models.Employee, error) {
+	defer s.invalidate(ctx)
+	return s.Store.SetDelegation(ctx, criteria, delegation)
+}
+
+// RemoveDelegation removes the delegation of the employees matching the criteria.
+func (s *EmployeeStore) RemoveDelegation(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error) {
+	defer s.invalidate(ctx)
+	return s.Store.RemoveDelegation(ctx, criteria)
+}
+
+// SetStatus applies the status transition to the first employee matching the criteria.
+func (s *EmployeeStore) SetStatus(ctx context.Context, criteria repository.EmployeeCriteria, transition models.StatusTransition) (models.Employee, error) {
+	defer s.invalidate(ctx)
+	return s.Store.SetStatus(ctx, criteria, transition)
+}
+
+// AddDottedLine adds the manager to the dotted lines of the employees matching the criteria.
+func (s *EmployeeStore) AddDottedLine(ctx context.Context, criteria repository.EmployeeCriteria, manager string) (int64, error) {
+	defer s.invalidate(ctx)
+	return s.Store.AddDottedLine(ctx, criteria, manager)
+}
+
+// RemoveDottedLine removes the manager from the dotted lines of the employees matching the criteria.
+func (s *EmployeeStore) RemoveDottedLine(ctx context.Context, criteria repository.EmployeeCriteria, manager string) (int64, error) {
+	defer s.invalidate(ctx)
+	return s.Store.RemoveDottedLine(ctx, criteria, manager)
+}
+
+// ChangeEmail changes the email of the first employee matching the criteria.
+func (s *EmployeeStore) ChangeEmail(ctx context.Context, criteria repository.EmployeeCriteria, email string) (bool, error) {
+	defer s.invalidate(ctx)
+	return s.Store.ChangeEmail(ctx, criteria, email)
+}
+
+// AssignID sets the internal ID of the first employee matching the criteria.
+func (s *EmployeeStore) AssignID(ctx context.Context, criteria repository.EmployeeCriteria, id string) (bool, error) {
+	defer s.invalidate(ctx)
+	return s.Store.AssignID(ctx, criteria, id)
+}
+
+// DeriveDisplayNames sets the missing display names of the employees matching the criteria.
+func (s *EmployeeStore) DeriveDisplayNames(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error) {
+	defer s.invalidate(ctx)
+	return s.Store.DeriveDisplayNames(ctx, criteria)
+}
+
+// SoftDelete marks the employees matching the criteria as deleted at the time.
+func (s *EmployeeStore) SoftDelete(ctx context.Context, criteria repository.EmployeeCriteria, at time.Time) (int64, error) {
+	defer s.invalidate(ctx)
+	return s.Store.SoftDelete(ctx, criteria, at)
+}
+
+// Restore clears the deletion mark of the first employee matching the criteria.
+func (s *EmployeeStore) Restore(ctx context.Context, criteria repository.EmployeeCriteria) (models.Employee, error) {
+	defer s.invalidate(ctx)
+	return s.Store.Restore(ctx, criteria)
+}
+
+// Delete removes the employees matching the criteria for good.
+func (s *EmployeeStore) Delete(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error) {
+	defer s.invalidate(ctx)
+	return s.Store.Delete(ctx, criteria)
+}
+
+// ReplaceEmployee replaces the employee having emp's email with emp; see repository.EmployeeRepository.
+func (s *EmployeeStore) ReplaceEmployee(ctx context.Context, emp models.Employee, version int64, upsert bool) (bool, error) {
+	defer s.invalidate(ctx)
+	return s.Store.ReplaceEmployee(ctx, emp, version, upsert)
+}
+
+// InTransaction runs fn in a transaction of the store. The writes of fn invalidate the cache before the transaction
+// commits, when other reads still see the employees as they were, so the cache is invalidated again once it ends.
+func (s *EmployeeStore) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	defer s.invalidate(ctx)
+	return s.Store.InTransaction(ctx, func(ctx context.Context) error {
+		return fn(context.WithValue(ctx, transaction{}, true))
+	})
+}
+
+// MoveEmailReferences points the references to the employee with the old email at the new email; see
+// services.EmployeeStore.
+func (s *EmployeeStore) MoveEmailReferences(ctx context.Context, criteria repository.EmployeeCriteria, from, to string) error {
+	defer s.invalidate(ctx)
+	return s.Store.MoveEmailReferences(ctx, criteria, from, to)
+}
@@ -0,0 +1,103 @@
+// Package clients serves the generated API client bundles produced by `make clients`.
+//
+// The build target writes one zip per language into the bundle directory together with
+// the openapi.json the bundles were generated from. A bundle is only served while that
+// document matches the OpenAPI document of the running server, so consumers never
+// download a client for a different API version.
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SpecFile is the name of the OpenAPI document stored next to the bundles.
+const SpecFile = "openapi.json"
+
+var (
+	// ErrNotFound is returned when no bundle exists for a language.
+	ErrNotFound = errors.New("client bundle not found")
+	// ErrStale is returned when the bundles were generated from a different OpenAPI document.
+	ErrStale = errors.New("client bundles were generated from a different API version")
+)
+
+// Bundle describes a generated client bundle.
+type Bundle struct {
+	Language string `json:"language" xml:"language" example:"typescript"`
+	Size     int64  `json:"size" xml:"size" example:"48213"`
+	// Current is true when the bundle matches the OpenAPI document of the running server.
+	Current bool `json:"current" xml:"current" example:"true"`
+}
+
+// Catalog lists and locates client bundles in a directory.
+type Catalog struct {
+	// Dir is the directory holding <language>.zip bundles and openapi.json.
+	Dir string
+	// Spec returns the OpenAPI document served by the running server.
+	Spec func() string
+}
+
+// NewCatalog creates a Catalog for the bundles in dir.
+func NewCatalog(dir string, spec func() string) *Catalog {
+	return &Catalog{
+		Dir:  dir,
+		Spec: spec,
+	}
+}
+
+// List returns the available bundles sorted by language. A missing directory yields an empty list.
+func (c *Catalog) List() ([]Bundle, error) {
+	matches, err := filepath.Glob(filepath.Join(c.Dir, "*.zip"))
+	if err != nil {
+		return nil, err
+	}
+	current := c.current()
+	bundles := make([]Bundle, 0, len(matches))
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, Bundle{
+			Language: strings.TrimSuffix(filepath.Base(match), ".zip"),
+			Size:     info.Size(),
+			Current:  current,
+		})
+	}
+	sort.Slice(bundles, func(i, j int) bool { return bundles[i].Language < bundles[j].Language })
+	return bundles, nil
+}
+
+// Path returns the file path of the bundle for a language, provided it matches the running server.
+func (c *Catalog) Path(language string) (string, error) {
+	if language == "" || strings.ContainsAny(language, `/\.`) {
+		return "", ErrNotFound
+	}
+	path := filepath.Join(c.Dir, language+".zip")
+	if _, err := os.Stat(path); err != nil {
+		return "", ErrNotFound
+	}
+	if !c.current() {
+		return "", ErrStale
+	}
+	return path, nil
+}
+
+// current reports whether the stored OpenAPI document matches the live one.
+// Both documents are compared semantically so formatting differences do not matter.
+func (c *Catalog) current() bool {
+	stored, err := os.ReadFile(filepath.Join(c.Dir, SpecFile))
+	if err != nil {
+		return false
+	}
+	var storedDoc, liveDoc interface{}
+	if json.Unmarshal(stored, &storedDoc) != nil || json.Unmarshal([]byte(c.Spec()), &liveDoc) != nil {
+		return false
+	}
+	return reflect.DeepEqual(storedDoc, liveDoc)
+}
@@ -0,0 +1,39 @@
+// Command healthcheck probes the server for the healthchecks of docker compose, whose distroless image has neither a
+// shell nor curl. It exits with 0 when the server answers the probe with 200, and with 1 otherwise.
+//
+// Usage:
+//
+//	healthcheck [url]
+//
+// The URL defaults to the readiness probe of the server in the same container, http://localhost:8080/readyz.
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	url := "http://localhost:8080/readyz"
+	if len(os.Args) > 2 {
+		fmt.Fprintln(os.Stderr, "usage: healthcheck [url]")
+		os.Exit(2)
+	}
+	if len(os.Args) == 2 {
+		url = os.Args[1]
+	}
+
+	client := http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		fmt.Fprintf(os.Stderr, "%s answered %s\n", url, resp.Status)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,98 @@
+// Command migrate applies the pending MongoDB migrations, or lists the migrations with when each was applied, for
+// deployments setting MIGRATE_ON_STARTUP=false to migrate in a release step of their own. It reads the configuration
+// of the server, from the same flags, environment variables, .env file and configuration file.
+//
+// Usage:
+//
+//	migrate [--config file] [flags] [up|status]
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"WebMVCEmployees/config"
+	"WebMVCEmployees/logging"
+	"WebMVCEmployees/repository"
+)
+
+// fatal logs the error that keeps the migrations from running, and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+func main() {
+	flags := config.NewFlagSet("migrate")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: migrate [flags] [up|status]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+	command := "up"
+	if flags.NArg() > 0 {
+		command = flags.Arg(0)
+	}
+	if flags.NArg() > 1 || (command != "up" && command != "status") {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	// Every setting missing or invalid is reported at once.
+	cfg, err := config.Load(flags)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	// Log like the server, at the level and in the format configured.
+	level, _ := logging.ParseLevel(cfg.Log.Level)
+	logger, _ := logging.New(os.Stderr, cfg.Log.Format, level)
+	slog.SetDefault(logger)
+	if cfg.Storage != "mongo" {
+		slog.Error("Employees are not stored in MongoDB, which migrate applies to", "storage", cfg.Storage)
+		os.Exit(1)
+	}
+
+	client, _, cancel, err := config.ConnectMongo(cfg.Mongo)
+	if err != nil {
+		fatal("Failed to connect to MongoDB", err)
+	}
+	defer cancel()
+	defer client.Disconnect(context.Background())
+
+	// Interrupting cancels the migration running, which stays pending, and skips the others.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	migrator := repository.NewMongoMigrator(client.Database(cfg.Mongo.DB).Collection(cfg.Mongo.Collection))
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, version := range applied {
+			fmt.Println("applied", version)
+		}
+		if err != nil {
+			fatal("Failed to migrate MongoDB", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fatal("Failed to read the applied migrations", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if !status.AppliedAt.IsZero() {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%-40s %-25s %s\n", status.Version, appliedAt, status.Description)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+// Command openapi prints the OpenAPI document served by the API, as used to generate client bundles.
+package main
+
+import (
+	"fmt"
+
+	"WebMVCEmployees/docs"
+)
+
+func main() {
+	fmt.Println(docs.SwaggerInfo.ReadDoc())
+}
@@ -2,128 +2,642 @@ package main
 
 import (
 	"context"
-	"log"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Embed the time zone database, which slim images lack, to validate and apply time zones.
 
+	"WebMVCEmployees/cache"
+	"WebMVCEmployees/clients"
 	"WebMVCEmployees/config"
 	"WebMVCEmployees/controllers"
+	"WebMVCEmployees/docs"
+	"WebMVCEmployees/events"
+	"WebMVCEmployees/health"
+	"WebMVCEmployees/inflight"
+	"WebMVCEmployees/logging"
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/readonly"
 	"WebMVCEmployees/repository"
 	"WebMVCEmployees/router"
+	"WebMVCEmployees/scheduler"
+	"WebMVCEmployees/seed"
 	"WebMVCEmployees/services"
+	"WebMVCEmployees/slo"
+	"WebMVCEmployees/tenant"
+	"WebMVCEmployees/tracing"
 
-	docker "github.com/docker/docker/client" // import the official Docker client package
-	"github.com/joho/godotenv"               // load env variables from a .env file
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
 )
 
-// checkDocker pings the Docker daemon to verify it's running.
-func checkDocker() error {
-	cli, err := docker.NewClientWithOpts(docker.FromEnv, docker.WithAPIVersionNegotiation())
-	if err != nil {
-		return err
-	}
-	_, err = cli.Ping(context.Background())
-	return err
+// fatal logs the error that keeps the server from running, and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
 
 func main() {
-	// Validate that Docker is running.
-	dockerized := os.Getenv("DOCKERIZED")
-	if dockerized != "true" {
-		// Load environment variables from a .env file.
-		if err := godotenv.Load(".env.development"); err != nil {
-			log.Println("No .env.development file found, continuing with system environment variables")
+	// Read the configuration from the flags, the environment and the configuration file; see config.Config.
+	flags := config.NewFlagSet(os.Args[0])
+	flags.Parse(os.Args[1:])
+	// Every setting missing or invalid is reported at once.
+	cfg, err := config.Load(flags)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// Log structured lines from the lowest level configured: JSON in production, key=value pairs on the console.
+	// Set as the default, the logger also receives what the standard log package is given.
+	// The level can be changed at runtime with PUT /admin/loglevel, and toggled to debug and back with SIGUSR1.
+	baseLevel, _ := logging.ParseLevel(cfg.Log.Level)
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(baseLevel)
+	logger, _ := logging.New(os.Stderr, cfg.Log.Format, logLevel)
+	slog.SetDefault(logger)
+	logging.ToggleDebugOnSignal(logLevel, baseLevel)
+
+	// With OTEL_EXPORTER_OTLP_ENDPOINT set, the requests and the MongoDB commands they run are traced, and their log
+	// lines carry the IDs of the trace and span, to follow a request in Jaeger or Tempo.
+	shutdownTracing := func(context.Context) error { return nil }
+	if cfg.Tracing.Endpoint != "" {
+		if shutdownTracing, err = tracing.Setup(context.Background(), cfg.Tracing.Endpoint, cfg.Tracing.ServiceName, cfg.Tracing.SampleRatio); err != nil {
+			fatal("Failed to set up tracing", err)
+		}
+		slog.Info("Exporting traces", "endpoint", cfg.Tracing.Endpoint, "sample_ratio", cfg.Tracing.SampleRatio)
+	}
+
+	// Employees stored outside MongoDB need neither Docker nor the MongoDB container. Outside a container, the
+	// MongoDB container is started through Docker unless it is running, and stopped on shutdown if it was not.
+	var mongoContainer *config.MongoContainer
+	if !cfg.Dockerized && cfg.Storage == "mongo" {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		if mongoContainer, err = config.StartMongoContainer(ctx); err != nil {
+			fatal("Failed to start the MongoDB container", err)
 		}
-		// validate docker is running
-		if err := checkDocker(); err != nil {
-			log.Println("Docker does not appear to be running.")
+		cancel()
+	}
+
+	// A read-only replica instance serves GET endpoints only and reads from secondaries.
+	readOnlyReplica := cfg.ReadOnlyReplica
+	if readOnlyReplica {
+		slog.Info("Running as a read-only replica: mutations are disabled")
+	}
+
+	// Count in-flight requests and MongoDB commands so operators can tell when the instance has drained.
+	inFlight := inflight.NewTracker()
+	prometheus.MustRegister(inFlight)
+
+	// The readiness probe checks the database employees are stored in, each check failing after READY_TIMEOUT.
+	checker := health.NewChecker(cfg.Server.ReadyTimeout)
+
+	// Storage selects where employees are stored: in MongoDB by default, in PostgreSQL at POSTGRES_URL with
+	// STORAGE=postgres, in the SQLite file at SQLITE_PATH with STORAGE=sqlite, or in memory with STORAGE=memory, for
+	// demos, losing them on shutdown. Outside MongoDB, the subsystems keeping their records in other collections, such
+	// as notes, leave and the audit log, are unavailable.
+	var client *mongo.Client
+	var mongoDB string
+	var repo *repository.EmployeeRepository
+	var pool *pgxpool.Pool
+	var sqliteDB *sql.DB
+	var store services.EmployeeStore
+	var routerOptions []router.Option
+	switch cfg.Storage {
+	case "memory":
+		slog.Warn("Storing employees in memory; they will be lost on shutdown")
+		store = repository.NewMemoryEmployeeRepository()
+	case "postgres":
+		if pool, err = config.ConnectPostgres(cfg.Postgres.URL); err != nil {
+			fatal("Failed to connect to PostgreSQL", err)
 		}
-		// Start the MongoDB container using docker-compose if it's not running.
-		if err := config.StartContainers(); err != nil {
-			log.Fatal("Failed to start MongoDB container:", err)
+		postgresRepo, err := repository.NewPostgresEmployeeRepository(pool)
+		if err != nil {
+			fatal("Failed to migrate the PostgreSQL schema", err)
 		}
-	} else {
-		// Load environment variables from a .env file.
-		if err := godotenv.Load(".env.docker"); err != nil {
-			log.Println("No .env.development file found, continuing with system environment variables")
+		checker.Add("postgres", pool.Ping)
+		slog.Info("Storing employees in PostgreSQL")
+		store = postgresRepo
+	case "sqlite":
+		if sqliteDB, err = config.ConnectSQLite(cfg.SQLite.Path); err != nil {
+			fatal("Failed to open the SQLite database", err)
 		}
+		sqliteRepo, err := repository.NewSQLiteEmployeeRepository(sqliteDB)
+		if err != nil {
+			fatal("Failed to migrate the SQLite schema", err)
+		}
+		checker.Add("sqlite", sqliteDB.PingContext)
+		slog.Info("Storing employees in SQLite", "path", cfg.SQLite.Path)
+		store = sqliteRepo
+	case "mongo":
+		client, mongoDB, repo, routerOptions = connectMongo(cfg, inFlight, checker)
+		store = repo
+	}
+
+	// With REDIS_URL set, employee lookups are cached in Redis for REDIS_CACHE_TTL, a minute by default; writes
+	// invalidate them.
+	var redisClient *redis.Client
+	if cfg.Redis.URL != "" {
+		if redisClient, err = config.ConnectRedis(cfg.Redis.URL); err != nil {
+			fatal("Failed to connect to Redis", err)
+		}
+		slog.Info("Caching employee lookups in Redis", "ttl", cfg.Redis.CacheTTL)
+		store = cache.NewEmployeeStore(store, redisClient, cfg.Redis.CacheTTL)
 	}
 
-	// Retrieve configuration values from environment variables.
-	mongoURL := os.Getenv("MONGO_URL")
-	if mongoURL == "" {
-		log.Fatal("MONGO_URL environment variable not set")
+	// With TENANT_HEADER set, e.g. to X-Tenant-ID, each request is served for the tenant whose ID the header carries
+	// and reaches the employees of that tenant only. Scoped in front of the cache, lookups are cached per tenant. The
+	// subsystems keeping their records in other collections, and the audit log, keep those of each tenant in a
+	// database of the tenant. The /admin routes, administering the whole deployment, are only open to the admins among
+	// the employees of OPERATOR_TENANT, those of no tenant by default.
+	tenantHeader := cfg.TenantHeader
+	if tenantHeader != "" {
+		slog.Info("Serving each request for the tenant named by a header", "header", tenantHeader)
+		store = tenant.NewEmployeeStore(store)
+		routerOptions = append(routerOptions, router.WithTenants(tenantHeader, cfg.OperatorTenant))
 	}
-	mongoDB := os.Getenv("MONGO_DB")
-	if mongoDB == "" {
-		log.Fatal("MONGO_DB environment variable not set")
+
+	// Create the EmployeeService using the store, recording changes in the audit log when employees are in MongoDB.
+	empService := services.NewEmployeeService(store)
+	var auditRepo *repository.AuditRepository
+	if client != nil {
+		auditRepo = repository.NewAuditRepository(client, mongoDB)
+		empService.Audit = auditRepo
 	}
-	mongoCollection := os.Getenv("MONGO_COLLECTION")
-	if mongoCollection == "" {
-		log.Fatal("MONGO_COLLECTION environment variable not set")
+	// Restrict employee metadata to the listed keys, e.g. "badgeNumber,parkingSpot".
+	empService.MetadataKeys = cfg.Employees.MetadataKeys
+	// Restrict the cost centers employees are charged to, e.g. "CC-1100,CC-1200".
+	empService.CostCenters = cfg.Employees.CostCenters
+	// Only employees holding one of the listed roles may manage others, e.g. "Manager,Director".
+	empService.ManagerRoles = cfg.Employees.ManagerRoles
+	// Sort employee lists in the rules of a language, e.g. "en" or "he", ignoring case unless SORT_COLLATION_STRENGTH
+	// says otherwise: 1 also ignores accents, and 3 tells case apart.
+	if cfg.Employees.SortCollation != "" {
+		empService.Collation = &options.Collation{Locale: cfg.Employees.SortCollation, Strength: cfg.Employees.SortCollationStrength}
 	}
 
-	// Connect to MongoDB using our config method.
-	client, _, cancel, err := config.ConnectMongo(mongoURL)
-	if err != nil {
-		log.Fatal(err)
+	// Employees stored before internal IDs and display names were introduced receive them at startup.
+	if !readOnlyReplica {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		assigned, err := empService.AssignMissingIDs(ctx, time.Now().UTC())
+		if err != nil {
+			fatal("Failed to assign employee IDs", err)
+		}
+		if assigned > 0 {
+			slog.Info("Assigned internal IDs to employees", "count", assigned)
+		}
+		derived, err := empService.DeriveDisplayNames(ctx)
+		cancel()
+		if err != nil {
+			fatal("Failed to derive employee display names", err)
+		}
+		if derived > 0 {
+			slog.Info("Derived display names of employees", "count", derived)
+		}
 	}
-	defer cancel()
 
-	// Initialize the EmployeeRepository.
-	repo, err := repository.NewEmployeeRepository(client, mongoDB, mongoCollection)
-	if err != nil {
-		log.Fatal("Failed to create employee repository:", err)
+	// The scheduler runs background maintenance jobs; replicas never write, so they run none.
+	sched := scheduler.New()
+	if !readOnlyReplica {
+		sched.Every("revoke-expired-roles", cfg.Jobs.RoleGrantSweepInterval, func(ctx context.Context) error {
+			revoked, err := empService.RevokeExpiredRoles(ctx, time.Now().UTC())
+			if revoked > 0 {
+				slog.Info("Revoked expired temporary roles", "count", revoked)
+			}
+			return err
+		})
+
+		// With TRASH_RETENTION set, e.g. to 720h, employees soft-deleted longer ago are purged for good, checked
+		// every TRASH_PURGE_INTERVAL, an hour by default. Each purge is recorded in the audit log.
+		if retention := cfg.Jobs.TrashRetention; retention > 0 {
+			purgeInterval := cfg.Jobs.TrashPurgeInterval
+			slog.Info("Purging deleted employees", "retention", retention, "interval", purgeInterval)
+			sched.Every("purge-trash", purgeInterval, func(ctx context.Context) error {
+				now := time.Now().UTC()
+				purged, err := empService.PurgeDeletedEmployees(ctx, now.Add(-retention), now)
+				if purged > 0 {
+					slog.Info("Purged deleted employees", "count", purged, "retention", retention)
+				}
+				return err
+			})
+		}
 	}
+	sched.Start()
 
-	// Create the EmployeeService using the repository.
-	empService := services.NewEmployeeService(repo)
+	// Changes to the employees in MongoDB are read from a change stream and published to in-process subscribers.
+	// Each instance resumes from the position it saved as CHANGE_STREAM_CONSUMER, its host name by default;
+	// replicas never write, so they save no position and watch no changes.
+	var watcher *events.Watcher
+	if client != nil && !readOnlyReplica {
+		consumer := cfg.Jobs.ChangeStreamConsumer
+		if consumer == "" {
+			if consumer, err = os.Hostname(); err != nil {
+				fatal("Failed to name the change stream consumer", err)
+			}
+		}
+		changes := events.NewHub()
+		prometheus.MustRegister(events.NewMetrics(changes))
+		watcher = events.NewWatcher(repo.Collection, repository.NewResumeTokenRepository(client, mongoDB), consumer, changes)
+		watcher.Start()
+	}
 
 	// Create the EmployeeController by passing the EmployeeService.
 	empController := controllers.NewEmployeeController(empService)
+	empController.CreatedWithLocation = cfg.Server.CreateReturnsCreated
+
+	// The other subsystems keep their records in MongoDB, so they are only served when employees are stored there.
+	if client != nil {
+		routerOptions = append(routerOptions, mongoSubsystems(cfg, client, mongoDB, empService)...)
+	}
+
+	// SEED_EMPLOYEES loads the employees of a fixture, "demo" for the one embedded or the path of a JSON or CSV file,
+	// once the catalogs validating them are set up. Employees already stored are skipped, so restarts seed nothing.
+	if source := cfg.SeedEmployees; source != "" {
+		emps, err := seed.Load(source)
+		if err != nil {
+			fatal("Failed to read the seed employees", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		created, skipped, err := empService.SeedEmployees(ctx, emps)
+		cancel()
+		if err != nil {
+			fatal("Failed to seed employees", err)
+		}
+		slog.Info("Seeded employees", "source", source, "created", created, "skipped", skipped)
+	}
+
+	// Load the per-route SLOs; routes without an objective are not tracked.
+	objectives, err := slo.LoadObjectives(cfg.SLOConfig)
+	if err != nil {
+		slog.Warn("SLO tracking disabled", "error", err)
+	}
+	sloTracker := slo.NewTracker(objectives)
+	prometheus.MustRegister(sloTracker)
+
+	// Serve the client bundles generated by `make clients`.
+	clientCatalog := clients.NewCatalog(cfg.ClientsDir, docs.SwaggerInfo.ReadDoc)
+
+	// In read-only mode, mutations are rejected with 503 while reads are served, such as during a migration or an
+	// incident. READ_ONLY_MODE=true starts the instance in it, explaining why with READ_ONLY_MODE_REASON; PUT
+	// /admin/read-only switches it at runtime.
+	readOnlyMode := readonly.NewSwitch()
+	if cfg.ReadOnlyMode.Enabled {
+		readOnlyMode.Enable(cfg.ReadOnlyMode.Reason)
+		slog.Warn("Starting in read-only mode: mutations are rejected until it is switched off")
+	}
+
+	// Create the AdminController for the operational endpoints.
+	adminController := controllers.NewAdminController(sloTracker, clientCatalog, auditRepo, inFlight, readOnlyMode)
+	adminController.LogLevel = logLevel
+
+	// Unknown request fields are rejected unless lenient decoding is requested for older clients.
+	negotiate.StrictDecoding = cfg.Server.StrictDecoding
+
+	// Reject oversized request bodies, e.g. abusive bulk imports.
+	routerOptions = append(routerOptions, router.WithBodyLimit(cfg.Server.MaxBodyBytes))
+
+	// Compress large responses unless disabled; the threshold and media types can be tuned.
+	if cfg.Compression.Enabled {
+		routerOptions = append(routerOptions, router.WithCompression(middleware.CompressionConfig{
+			MinSize:      cfg.Compression.MinSize,
+			ContentTypes: cfg.Compression.ContentTypes,
+			Brotli:       cfg.Compression.Brotli,
+		}))
+	}
+
+	// SERVER_ADDR is the address to listen on, ":8080" by default: "127.0.0.1:8080" accepts local connections only,
+	// and port 0, as in "127.0.0.1:0", a free port chosen by the system, which is logged once listening. Validated with
+	// the configuration, it parses.
+	addr, _ := router.ParseAddr(cfg.Server.Addr)
 
 	// Setup the server using our helper function.
-	srv := router.SetupServer(empController)
+	if cfg.Tracing.Endpoint != "" {
+		routerOptions = append(routerOptions, router.WithTracing(cfg.Tracing.ServiceName))
+	}
+	routerOptions = append(routerOptions, router.WithHealth(controllers.NewHealthController(checker)))
+	routerOptions = append(routerOptions, router.WithAccessLogExclude(cfg.Log.AccessExclude...), router.WithSLO(sloTracker), router.WithInFlight(inFlight), router.WithAdmin(adminController), router.WithReadOnlyMode(readOnlyMode))
+	srv := router.SetupServer(addr, empController, routerOptions...)
 
 	// Channel to listen for interrupt or termination signals.
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
+	// Listen before serving, so the address actually bound, port included, can be reported.
+	listener, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		fatal("Failed to listen on "+srv.Addr, err)
+	}
+
 	// Start server in a goroutine.
 	go func() {
-		log.Println("Server is running on port 8080...")
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server error: %s", err)
+		slog.Info("Server is listening", "addr", listener.Addr().String())
+		if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+			fatal("Server error", err)
 		}
 	}()
 
 	// Block until a shutdown signal is received.
 	<-quit
-	log.Println("Shutting down server...")
+	slog.Info("Shutting down server")
 
 	// Create a context with timeout for the shutdown process.
 	ctxShutdown, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
 	if err := srv.Shutdown(ctxShutdown); err != nil {
-		log.Fatalf("Server forced to shutdown: %s", err)
+		fatal("Server forced to shutdown", err)
+	}
+	sched.Stop()
+	if watcher != nil {
+		watcher.Stop()
 	}
 
 	// Disconnect from MongoDB and stop the container.
 	bgCtx, bgCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer bgCancel()
 
-	// Clean up the MongoDB database before disconnecting.
-	err = config.CleanMongoDB(client, mongoDB, bgCtx)
+	// Clean up the MongoDB database before disconnecting; replicas must never drop data.
+	if client != nil {
+		if !readOnlyReplica {
+			err = config.CleanMongoDB(client, mongoDB, bgCtx)
+			if err != nil {
+				slog.Error("Failed to clean MongoDB", "error", err)
+			}
+		}
+
+		if err := client.Disconnect(bgCtx); err != nil {
+			fatal("Failed to disconnect from MongoDB", err)
+		}
+	}
+	if mongoContainer != nil {
+		if err := mongoContainer.Stop(bgCtx); err != nil {
+			slog.Error("Failed to stop the MongoDB container", "error", err)
+		}
+	}
+	if pool != nil {
+		pool.Close()
+	}
+	if sqliteDB != nil {
+		if err := sqliteDB.Close(); err != nil {
+			slog.Error("Failed to close the SQLite database", "error", err)
+		}
+	}
+	if redisClient != nil {
+		if err := redisClient.Close(); err != nil {
+			slog.Error("Failed to close the Redis client", "error", err)
+		}
+	}
+	// Export the spans still buffered.
+	if err := shutdownTracing(bgCtx); err != nil {
+		slog.Error("Failed to flush the traces", "error", err)
+	}
+
+	slog.Info("Server exiting gracefully")
+}
+
+// connectMongo connects to the MongoDB of the configuration and opens the employee collection, along with the
+// idempotency keys unless the instance is a read-only replica, and adds the readiness checks of the connection to
+// checker. It returns the client, the database name, the employee repository and the router options the connection
+// enables.
+func connectMongo(cfg *config.Config, inFlight *inflight.Tracker, checker *health.Checker) (*mongo.Client, string, *repository.EmployeeRepository, []router.Option) {
+	readOnlyReplica := cfg.ReadOnlyReplica
+	mongoDB := cfg.Mongo.DB
+	mongoCollection := cfg.Mongo.Collection
+
+	// A read-only replica reads from secondaries unless MONGO_READ_PREFERENCE says otherwise.
+	var clientOptions []*options.ClientOptions
+	if readOnlyReplica && cfg.Mongo.ReadPreference == "" {
+		clientOptions = append(clientOptions, options.Client().SetReadPreference(readpref.SecondaryPreferred()))
+	}
+	monitors := []*event.CommandMonitor{inFlight.CommandMonitor()}
+
+	// In debug mode, log the query plans of a sample of the queries and flag collection scans.
+	var explainer *repository.QueryExplainer
+	if sampleRate := cfg.Mongo.QueryExplainSampleRate; sampleRate > 0 {
+		slog.Info("Explaining a sample of the queries", "rate", sampleRate)
+		explainer = repository.NewQueryExplainer(sampleRate)
+		monitors = append(monitors, explainer.CommandMonitor())
+	}
+	if cfg.Tracing.Endpoint != "" {
+		monitors = append(monitors, tracing.CommandMonitor())
+	}
+	clientOptions = append(clientOptions, options.Client().SetMonitor(config.CombineMonitors(monitors...)))
+
+	// Connect to MongoDB using our config method.
+	client, _, cancel, err := config.ConnectMongo(cfg.Mongo, clientOptions...)
+	if err != nil {
+		fatal("Failed to connect to MongoDB", err)
+	}
+	defer cancel()
+	if explainer != nil {
+		explainer.Attach(client)
+	}
+
+	var repo *repository.EmployeeRepository
+	var routerOptions []router.Option
+	if readOnlyReplica {
+		// Replicas never write, so they neither create indexes nor store idempotency keys.
+		repo = repository.NewReadOnlyEmployeeRepository(client, mongoDB, mongoCollection)
+		routerOptions = append(routerOptions, router.WithReadOnlyReplica())
+	} else {
+		// Initialize the EmployeeRepository.
+		repo, err = repository.NewEmployeeRepository(client, mongoDB, mongoCollection)
+		if err != nil {
+			fatal("Failed to create employee repository", err)
+		}
+
+		// Apply the pending migrations, unless MIGRATE_ON_STARTUP=false leaves them to the migrate command. Instances
+		// starting together wait for each other, longer than the lock lease of one that crashed midway.
+		migrator := repository.NewMongoMigrator(repo.Collection)
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+		if !cfg.Mongo.MigrateOnStartup {
+			pending, err := migrator.Pending(ctx)
+			if err != nil {
+				fatal("Failed to read the applied migrations", err)
+			}
+			if len(pending) > 0 {
+				slog.Warn("MongoDB migrations are pending; apply them with the migrate command", "pending", pending)
+			}
+		} else {
+			applied, err := migrator.Up(ctx)
+			for _, version := range applied {
+				slog.Info("Applied MongoDB migration", "version", version)
+			}
+			if err != nil {
+				fatal("Failed to migrate MongoDB", err)
+			}
+		}
+		cancel()
+
+		// Initialize the IdempotencyRepository used to replay retried create requests.
+		idempotencyRepo, err := repository.NewIdempotencyRepository(client, mongoDB, cfg.Mongo.IdempotencyTTL)
+		if err != nil {
+			fatal("Failed to create idempotency repository", err)
+		}
+		routerOptions = append(routerOptions, router.WithIdempotency(idempotencyRepo))
+	}
+
+	// The instance is ready while MongoDB answers and once every migration is applied, by an instance or by the
+	// migrate command.
+	migrator := repository.NewMongoMigrator(repo.Collection)
+	checker.Add("mongo", func(ctx context.Context) error { return client.Ping(ctx, nil) })
+	checker.Add("migrations", func(ctx context.Context) error {
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("migrations pending: %s", strings.Join(pending, ", "))
+		}
+		return nil
+	})
+	// Employee operations failing on transient errors run up to MONGO_RETRY_ATTEMPTS times; 1 disables retries.
+	repo.Retry.Attempts = cfg.Mongo.RetryAttempts
+	// Bulk creation and seeding insert MONGO_BATCH_SIZE employees per round trip.
+	repo.BatchSize = cfg.Mongo.BatchSize
+	return client, mongoDB, repo, routerOptions
+}
+
+// mongoSubsystems sets up the catalogs validating employees and the subsystems keeping their records in MongoDB next
+// to the employees, and returns the router options serving them.
+func mongoSubsystems(cfg *config.Config, client *mongo.Client, mongoDB string, empService *services.EmployeeService) []router.Option {
+	// Employees may only reference existing departments.
+	departmentRepo := repository.NewDepartmentRepository(client, mongoDB)
+	empService.Departments = departmentRepo
+	// Parents in the roles catalog imply roles.
+	roleRepo := repository.NewRoleRepository(client, mongoDB)
+	empService.RoleCatalog = roleRepo
+	// Employees may only hold titles and grades of the titles catalog.
+	titleRepo := repository.NewTitleRepository(client, mongoDB)
+	empService.Titles = titleRepo
+	// Employees may only work from existing locations.
+	locationRepo := repository.NewLocationRepository(client, mongoDB)
+	empService.Locations = locationRepo
+
+	var routerOptions []router.Option
+
+	// Create the OrgChartController for snapshotting and diffing the reporting structure.
+	orgChartService := services.NewOrgChartService(empService.Store, repository.NewOrgSnapshotRepository(client, mongoDB))
+	routerOptions = append(routerOptions, router.WithOrgChart(controllers.NewOrgChartController(orgChartService)))
+
+	// Create the PhotoController storing profile photos in GridFS.
+	photoService := services.NewPhotoService(empService.Store, repository.NewPhotoRepository(client, mongoDB))
+	routerOptions = append(routerOptions, router.WithPhotos(controllers.NewPhotoController(photoService)))
+
+	// Create the DocumentController storing employee documents in GridFS. Download links are signed with
+	// DOCUMENT_URL_SECRET, which replicas must share; without it a random secret is used until the next restart.
+	documentRepo, err := repository.NewDocumentRepository(client, mongoDB)
+	if err != nil {
+		fatal("Failed to create document repository", err)
+	}
+	documentSecret := []byte(cfg.Documents.URLSecret)
+	if len(documentSecret) == 0 {
+		slog.Warn("DOCUMENT_URL_SECRET is not set; document download links will not survive a restart")
+		documentSecret = make([]byte, 32)
+		if _, err := rand.Read(documentSecret); err != nil {
+			fatal("Failed to generate a document URL secret", err)
+		}
+	}
+	documentService := services.NewDocumentService(empService.Store, documentRepo, documentSecret)
+	documentService.MaxBytes = cfg.Documents.MaxBytes
+	documentService.URLTTL = cfg.Documents.URLTTL
+	routerOptions = append(routerOptions, router.WithDocuments(controllers.NewDocumentController(documentService)))
+
+	// Create the NoteController for the HR notes attached to employees; only HR and admins read and write them.
+	noteRepo, err := repository.NewNoteRepository(client, mongoDB)
 	if err != nil {
-		log.Printf("Error cleaning MongoDB: %v", err)
+		fatal("Failed to create note repository", err)
 	}
+	noteService := services.NewNoteService(empService.Store, noteRepo)
+	routerOptions = append(routerOptions, router.WithNotes(controllers.NewNoteController(noteService),
+		middleware.RequireRoles(empService, "HR", middleware.AdminRole)))
 
-	if err := config.DisconnectMongo(client, bgCtx); err != nil {
-		log.Fatal("Error during disconnecting MongoDB:", err)
+	// Create the CertificationController for the certifications employees hold.
+	certificationRepo, err := repository.NewCertificationRepository(client, mongoDB)
+	if err != nil {
+		fatal("Failed to create certification repository", err)
 	}
+	certificationService := services.NewCertificationService(empService.Store, certificationRepo)
+	routerOptions = append(routerOptions, router.WithCertifications(controllers.NewCertificationController(certificationService)))
+
+	// Create the DepartmentController for the departments employees belong to.
+	departmentService := services.NewDepartmentService(empService.Store, departmentRepo)
+	routerOptions = append(routerOptions, router.WithDepartments(controllers.NewDepartmentController(departmentService)))
+
+	// Create the RoleController for the roles catalog.
+	roleService := services.NewRoleService(roleRepo)
+	routerOptions = append(routerOptions, router.WithRoles(controllers.NewRoleController(roleService)))
+
+	// Create the TitleController for the job titles catalog.
+	titleService := services.NewTitleService(empService.Store, titleRepo)
+	routerOptions = append(routerOptions, router.WithTitles(controllers.NewTitleController(titleService)))
+
+	// Create the LocationController for the offices employees work from.
+	locationService := services.NewLocationService(empService.Store, locationRepo)
+	routerOptions = append(routerOptions, router.WithLocations(controllers.NewLocationController(locationService)))
 
-	log.Println("Server exiting gracefully.")
+	// Create the CompensationController; salaries are only available to the roles in COMPENSATION_ROLES.
+	compensationRepo, err := repository.NewCompensationRepository(client, mongoDB)
+	if err != nil {
+		fatal("Failed to create compensation repository", err)
+	}
+	compensationService := services.NewCompensationService(empService.Store, compensationRepo)
+	routerOptions = append(routerOptions, router.WithCompensation(controllers.NewCompensationController(compensationService),
+		middleware.RequireRoles(empService, cfg.Compensation.Roles...)))
+
+	// Create the LeaveController for time off; LEAVE_ALLOWANCE_DAYS sets the yearly allowance of each employee.
+	leaveRepo, err := repository.NewLeaveRepository(client, mongoDB)
+	if err != nil {
+		fatal("Failed to create leave repository", err)
+	}
+	leaveService := services.NewLeaveService(empService, leaveRepo)
+	leaveService.Allowance = cfg.Leave.AllowanceDays
+	routerOptions = append(routerOptions, router.WithLeave(controllers.NewLeaveController(leaveService),
+		middleware.RequireSignIn(empService)))
+
+	// Create the AttendanceController for checking in and out.
+	attendanceRepo, err := repository.NewAttendanceRepository(client, mongoDB)
+	if err != nil {
+		fatal("Failed to create attendance repository", err)
+	}
+	attendanceService := services.NewAttendanceService(empService.Store, attendanceRepo)
+	routerOptions = append(routerOptions, router.WithAttendance(controllers.NewAttendanceController(attendanceService)))
+
+	// Create the ReviewController; besides the employee and their manager, the roles in REVIEW_READER_ROLES read reviews.
+	reviewRepo, err := repository.NewReviewRepository(client, mongoDB)
+	if err != nil {
+		fatal("Failed to create review repository", err)
+	}
+	reviewService := services.NewReviewService(empService, reviewRepo)
+	reviewService.ReaderRoles = cfg.Reviews.ReaderRoles
+	routerOptions = append(routerOptions, router.WithReviews(controllers.NewReviewController(reviewService),
+		middleware.RequireSignIn(empService)))
+
+	// Create the EmergencyContactController; besides the employee, the roles in EMERGENCY_CONTACT_ROLES access contacts.
+	contactService := services.NewEmergencyContactService(empService.Store, repository.NewEmergencyContactRepository(client, mongoDB))
+	contactService.AccessRoles = cfg.EmergencyContacts.Roles
+	routerOptions = append(routerOptions, router.WithEmergencyContacts(controllers.NewEmergencyContactController(contactService),
+		middleware.RequireSignIn(empService)))
+
+	// Create the TeamController for teams and their membership.
+	teamRepo, err := repository.NewTeamRepository(client, mongoDB)
+	if err != nil {
+		fatal("Failed to create team repository", err)
+	}
+	teamService := services.NewTeamService(empService.Store, teamRepo)
+	routerOptions = append(routerOptions, router.WithTeams(controllers.NewTeamController(teamService)))
+	return routerOptions
 }
@@ -0,0 +1,6 @@
+package main
+
+// Compiled-in plugins register their hooks with hooks.Default from init().
+// Enable a plugin for this deployment by adding a blank import of its package here, e.g.
+//
+//	import _ "example.com/acme/employee-plugins/badges"
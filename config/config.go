@@ -0,0 +1,653 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"WebMVCEmployees/logging"
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/repository"
+	"WebMVCEmployees/router"
+	"WebMVCEmployees/services"
+	"WebMVCEmployees/tenant"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/joho/godotenv"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+	"golang.org/x/text/language"
+)
+
+// Config is the configuration of the server, read once at startup by Load. Each setting is read, from the highest
+// priority down, from its command-line flag, its environment variable, the configuration file, or else its default;
+// see settings for the name of each.
+type Config struct {
+	// Dockerized is true when the server runs in a container, where MongoDB is not started with docker compose.
+	Dockerized bool `mapstructure:"dockerized"`
+	// Storage is where employees are stored: mongo, postgres, sqlite or memory.
+	Storage string `mapstructure:"storage"`
+	// TenantHeader names the request header carrying the tenant of each request; empty serves a single organization.
+	TenantHeader string `mapstructure:"tenant_header"`
+	// OperatorTenant is the tenant of the employees administering the deployment through /admin when TenantHeader is
+	// set; empty for the employees of no tenant.
+	OperatorTenant string `mapstructure:"operator_tenant"`
+	// ReadOnlyReplica serves reads only, from MongoDB secondaries.
+	ReadOnlyReplica bool `mapstructure:"read_only_replica"`
+	// SeedEmployees is the fixture of employees loaded at startup, "demo" or the path of a JSON or CSV file.
+	SeedEmployees string `mapstructure:"seed_employees"`
+	// SLOConfig is the path of the route objectives.
+	SLOConfig string `mapstructure:"slo_config"`
+	// ClientsDir is the directory of the client bundles generated by `make clients`.
+	ClientsDir string `mapstructure:"clients_dir"`
+
+	Log               LogConfig               `mapstructure:"log"`
+	Tracing           TracingConfig           `mapstructure:"tracing"`
+	ReadOnlyMode      ReadOnlyModeConfig      `mapstructure:"read_only_mode"`
+	Server            ServerConfig            `mapstructure:"server"`
+	Compression       CompressionConfig       `mapstructure:"compression"`
+	Mongo             MongoConfig             `mapstructure:"mongo"`
+	Postgres          PostgresConfig          `mapstructure:"postgres"`
+	SQLite            SQLiteConfig            `mapstructure:"sqlite"`
+	Redis             RedisConfig             `mapstructure:"redis"`
+	Employees         EmployeesConfig         `mapstructure:"employees"`
+	Jobs              JobsConfig              `mapstructure:"jobs"`
+	Documents         DocumentsConfig         `mapstructure:"documents"`
+	Compensation      CompensationConfig      `mapstructure:"compensation"`
+	Leave             LeaveConfig             `mapstructure:"leave"`
+	Reviews           ReviewsConfig           `mapstructure:"reviews"`
+	EmergencyContacts EmergencyContactsConfig `mapstructure:"emergency_contacts"`
+}
+
+// LogConfig configures the logs.
+type LogConfig struct {
+	// Level is the lowest level logged: debug, info, warn or error.
+	Level string `mapstructure:"level"`
+	// Format is logging.FormatJSON or logging.FormatConsole; Load defaults it to JSON in a container, and to the
+	// console elsewhere.
+	Format string `mapstructure:"format"`
+	// AccessExclude lists the paths whose requests are left out of the access log.
+	AccessExclude []string `mapstructure:"access_exclude"`
+}
+
+// TracingConfig configures the OpenTelemetry traces; an empty Endpoint traces nothing.
+type TracingConfig struct {
+	// Endpoint is the URL of the OTLP/HTTP collector the traces are exported to, such as http://localhost:4318.
+	Endpoint    string  `mapstructure:"endpoint"`
+	ServiceName string  `mapstructure:"service_name"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// ReadOnlyModeConfig is the read-only mode the server starts in.
+type ReadOnlyModeConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Reason  string `mapstructure:"reason"`
+}
+
+// ServerConfig configures the HTTP server.
+type ServerConfig struct {
+	// Addr is the address to listen on, as accepted by router.ParseAddr.
+	Addr                 string `mapstructure:"addr"`
+	MaxBodyBytes         int64  `mapstructure:"max_body_bytes"`
+	StrictDecoding       bool   `mapstructure:"strict_decoding"`
+	CreateReturnsCreated bool   `mapstructure:"create_returns_created"`
+	// ReadyTimeout bounds the checks of the readiness probe.
+	ReadyTimeout time.Duration `mapstructure:"ready_timeout"`
+}
+
+// CompressionConfig configures the compression of responses.
+type CompressionConfig struct {
+	Enabled      bool     `mapstructure:"enabled"`
+	MinSize      int      `mapstructure:"min_size"`
+	ContentTypes []string `mapstructure:"content_types"`
+	Brotli       bool     `mapstructure:"brotli"`
+}
+
+// MongoConfig configures the connection to MongoDB and the employee collection. The connection settings left unset,
+// nil or zero, keep the value of the URL, or else the driver default.
+type MongoConfig struct {
+	URL                    string        `mapstructure:"url"`
+	ConnectTimeout         time.Duration `mapstructure:"connect_timeout"`
+	DB                     string        `mapstructure:"db"`
+	Collection             string        `mapstructure:"collection"`
+	MaxPoolSize            *uint64       `mapstructure:"max_pool_size"`
+	MinPoolSize            *uint64       `mapstructure:"min_pool_size"`
+	MaxConnIdleTime        time.Duration `mapstructure:"max_conn_idle_time"`
+	ServerSelectionTimeout time.Duration `mapstructure:"server_selection_timeout"`
+	ReadPreference         string        `mapstructure:"read_preference"`
+	MaxStaleness           time.Duration `mapstructure:"max_staleness"`
+	ReadConcern            string        `mapstructure:"read_concern"`
+	WriteConcern           string        `mapstructure:"write_concern"`
+	RetryWrites            *bool         `mapstructure:"retry_writes"`
+	RetryAttempts          int           `mapstructure:"retry_attempts"`
+	BatchSize              int           `mapstructure:"batch_size"`
+	MigrateOnStartup       bool          `mapstructure:"migrate_on_startup"`
+	IdempotencyTTL         time.Duration `mapstructure:"idempotency_ttl"`
+	QueryExplainSampleRate float64       `mapstructure:"query_explain_sample_rate"`
+}
+
+// PostgresConfig configures the PostgreSQL store.
+type PostgresConfig struct {
+	URL string `mapstructure:"url"`
+}
+
+// SQLiteConfig configures the SQLite store.
+type SQLiteConfig struct {
+	Path string `mapstructure:"path"`
+}
+
+// RedisConfig configures the cache of employee lookups; an empty URL caches nothing.
+type RedisConfig struct {
+	URL      string        `mapstructure:"url"`
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+}
+
+// EmployeesConfig restricts the employees stored and how they are listed.
+type EmployeesConfig struct {
+	MetadataKeys          []string `mapstructure:"metadata_keys"`
+	CostCenters           []string `mapstructure:"cost_centers"`
+	ManagerRoles          []string `mapstructure:"manager_roles"`
+	SortCollation         string   `mapstructure:"sort_collation"`
+	SortCollationStrength int      `mapstructure:"sort_collation_strength"`
+}
+
+// JobsConfig configures the background jobs.
+type JobsConfig struct {
+	RoleGrantSweepInterval time.Duration `mapstructure:"role_grant_sweep_interval"`
+	// TrashRetention is how long soft-deleted employees are kept before they are purged; zero keeps them.
+	TrashRetention       time.Duration `mapstructure:"trash_retention"`
+	TrashPurgeInterval   time.Duration `mapstructure:"trash_purge_interval"`
+	ChangeStreamConsumer string        `mapstructure:"change_stream_consumer"`
+}
+
+// DocumentsConfig configures the employee documents.
+type DocumentsConfig struct {
+	URLSecret string        `mapstructure:"url_secret"`
+	MaxBytes  int64         `mapstructure:"max_bytes"`
+	URLTTL    time.Duration `mapstructure:"url_ttl"`
+}
+
+// CompensationConfig configures access to salaries.
+type CompensationConfig struct {
+	Roles []string `mapstructure:"roles"`
+}
+
+// LeaveConfig configures time off.
+type LeaveConfig struct {
+	AllowanceDays int `mapstructure:"allowance_days"`
+}
+
+// ReviewsConfig configures access to performance reviews.
+type ReviewsConfig struct {
+	ReaderRoles []string `mapstructure:"reader_roles"`
+}
+
+// EmergencyContactsConfig configures access to emergency contacts.
+type EmergencyContactsConfig struct {
+	Roles []string `mapstructure:"roles"`
+}
+
+// setting is a configuration setting: its key in the configuration file, whose dots nest it, the environment
+// variable and the flag setting it too, and its default.
+type setting struct {
+	key   string
+	env   string
+	def   any
+	usage string
+}
+
+// settings lists every setting of Config. Their environment variables keep the names the server always read.
+var settings = []setting{
+	{"dockerized", "DOCKERIZED", false, "the server runs in a container"},
+	{"storage", "STORAGE", "mongo", "where employees are stored: mongo, postgres, sqlite or memory"},
+	{"tenant_header", "TENANT_HEADER", "", "request header naming the tenant of each request, e.g. X-Tenant-ID"},
+	{"operator_tenant", "OPERATOR_TENANT", "", "tenant of the admins of the whole deployment; empty for the employees of no tenant"},
+	{"read_only_replica", "READ_ONLY_REPLICA", false, "serve reads only, from MongoDB secondaries"},
+	{"seed_employees", "SEED_EMPLOYEES", "", `employees loaded at startup: "demo" or the path of a JSON or CSV file`},
+	{"slo_config", "SLO_CONFIG", "slo.json", "path of the route objectives"},
+	{"clients_dir", "CLIENTS_DIR", "build/clients", "directory of the generated client bundles"},
+	{"log.level", "LOG_LEVEL", "info", "lowest level logged: debug, info, warn or error"},
+	{"log.format", "LOG_FORMAT", "", "format of the logs: json, the default in a container, or console"},
+	{"log.access_exclude", "ACCESS_LOG_EXCLUDE", []string{"/healthz", "/readyz", "/metrics"}, "paths left out of the access log, comma-separated"},
+	{"tracing.endpoint", "OTEL_EXPORTER_OTLP_ENDPOINT", "", "URL of the OTLP/HTTP collector traces are exported to, e.g. http://localhost:4318; empty traces nothing"},
+	{"tracing.service_name", "OTEL_SERVICE_NAME", "webmvc-employees", "service name of the traces"},
+	{"tracing.sample_ratio", "TRACING_SAMPLE_RATIO", 1.0, "ratio of the traces started by the server that are exported, between 0 and 1"},
+	{"read_only_mode.enabled", "READ_ONLY_MODE", false, "start in read-only mode, rejecting mutations"},
+	{"read_only_mode.reason", "READ_ONLY_MODE_REASON", "", "why the server starts in read-only mode"},
+
+	{"server.addr", "SERVER_ADDR", router.DefaultAddr, "address to listen on, e.g. 127.0.0.1:8080, or port 0 for any free port"},
+	{"server.max_body_bytes", "MAX_BODY_BYTES", int64(middleware.DefaultBodyLimit), "largest request body accepted, in bytes"},
+	{"server.strict_decoding", "STRICT_DECODING", true, "reject request bodies with unknown fields"},
+	{"server.create_returns_created", "CREATE_RETURNS_CREATED", false, "answer creations with 201 and a Location header"},
+	{"server.ready_timeout", "READY_TIMEOUT", 2 * time.Second, "time the readiness probe waits for the database before failing"},
+
+	{"compression.enabled", "COMPRESSION", true, "compress large responses (on or off)"},
+	{"compression.min_size", "COMPRESSION_MIN_SIZE", middleware.DefaultCompressionConfig().MinSize, "smallest response compressed, in bytes"},
+	{"compression.content_types", "COMPRESSION_CONTENT_TYPES", middleware.DefaultCompressionConfig().ContentTypes, "media types compressed, comma-separated"},
+	{"compression.brotli", "COMPRESSION_BROTLI", true, "compress with brotli for clients accepting it"},
+
+	{"mongo.url", "MONGO_URL", "", "MongoDB connection URL"},
+	{"mongo.connect_timeout", "MONGO_CONNECT_TIMEOUT", DefaultConnectTimeout, "longest wait on startup for MongoDB to answer, e.g. 2m"},
+	{"mongo.db", "MONGO_DB", "", "MongoDB database"},
+	{"mongo.collection", "MONGO_COLLECTION", "", "MongoDB collection of the employees"},
+	{"mongo.max_pool_size", "MONGO_MAX_POOL_SIZE", nil, "most connections to each MongoDB server"},
+	{"mongo.min_pool_size", "MONGO_MIN_POOL_SIZE", nil, "fewest connections kept to each MongoDB server"},
+	{"mongo.max_conn_idle_time", "MONGO_MAX_CONN_IDLE_TIME", time.Duration(0), "closes MongoDB connections idle for longer, e.g. 5m"},
+	{"mongo.server_selection_timeout", "MONGO_SERVER_SELECTION_TIMEOUT", time.Duration(0), "longest wait for a MongoDB server, e.g. 5s"},
+	{"mongo.read_preference", "MONGO_READ_PREFERENCE", "", "members reads go to: primary, primaryPreferred, secondary, secondaryPreferred or nearest"},
+	{"mongo.max_staleness", "MONGO_MAX_STALENESS", time.Duration(0), "skips secondaries lagging further behind, e.g. 90s"},
+	{"mongo.read_concern", "MONGO_READ_CONCERN", "", "read concern: local or majority"},
+	{"mongo.write_concern", "MONGO_WRITE_CONCERN", "", "write concern: majority or a number of members"},
+	{"mongo.retry_writes", "MONGO_RETRY_WRITES", nil, "retry writes failing on transient errors in the driver"},
+	{"mongo.retry_attempts", "MONGO_RETRY_ATTEMPTS", repository.DefaultRetryPolicy.Attempts, "times employee operations run on transient errors; 1 disables retries"},
+	{"mongo.batch_size", "MONGO_BATCH_SIZE", repository.DefaultBatchSize, "employees inserted per round trip"},
+	{"mongo.migrate_on_startup", "MIGRATE_ON_STARTUP", true, "apply the pending migrations on startup"},
+	{"mongo.idempotency_ttl", "IDEMPOTENCY_TTL", 24 * time.Hour, "how long idempotency keys are kept"},
+	{"mongo.query_explain_sample_rate", "QUERY_EXPLAIN_SAMPLE_RATE", 0.0, "share of the queries whose plan is logged, from 0 to 1"},
+
+	{"postgres.url", "POSTGRES_URL", "", "PostgreSQL connection URL"},
+	{"sqlite.path", "SQLITE_PATH", "employees.db", "path of the SQLite database"},
+	{"redis.url", "REDIS_URL", "", "Redis URL caching employee lookups"},
+	{"redis.cache_ttl", "REDIS_CACHE_TTL", time.Minute, "how long employee lookups are cached"},
+
+	{"employees.metadata_keys", "EMPLOYEE_METADATA_KEYS", []string(nil), "metadata keys employees may have, comma-separated"},
+	{"employees.cost_centers", "COST_CENTERS", []string(nil), "cost centers employees may be charged to, comma-separated"},
+	{"employees.manager_roles", "MANAGER_ROLES", []string(nil), "roles allowed to manage others, comma-separated"},
+	{"employees.sort_collation", "SORT_COLLATION", "", "language whose rules sort employee lists, e.g. en"},
+	{"employees.sort_collation_strength", "SORT_COLLATION_STRENGTH", 2, "collation strength, from 1 to 5"},
+
+	{"jobs.role_grant_sweep_interval", "ROLE_GRANT_SWEEP_INTERVAL", time.Minute, "how often expired role grants are revoked"},
+	{"jobs.trash_retention", "TRASH_RETENTION", time.Duration(0), "purges employees deleted longer ago, e.g. 720h"},
+	{"jobs.trash_purge_interval", "TRASH_PURGE_INTERVAL", time.Hour, "how often deleted employees are purged"},
+	{"jobs.change_stream_consumer", "CHANGE_STREAM_CONSUMER", "", "name saving the change stream position, the host name by default"},
+
+	{"documents.url_secret", "DOCUMENT_URL_SECRET", "", "secret signing document download links"},
+	{"documents.max_bytes", "DOCUMENT_MAX_BYTES", services.DefaultMaxDocumentBytes, "largest document accepted, in bytes"},
+	{"documents.url_ttl", "DOCUMENT_URL_TTL", services.DefaultDocumentURLTTL, "how long document download links last"},
+	{"compensation.roles", "COMPENSATION_ROLES", []string{"HR", "Admin"}, "roles reading and setting salaries, comma-separated"},
+	{"leave.allowance_days", "LEAVE_ALLOWANCE_DAYS", services.DefaultLeaveAllowance, "days off each employee gets per year"},
+	{"reviews.reader_roles", "REVIEW_READER_ROLES", []string{"HR", "Admin"}, "roles reading every review, comma-separated"},
+	{"emergency_contacts.roles", "EMERGENCY_CONTACT_ROLES", []string{"HR"}, "roles accessing emergency contacts, comma-separated"},
+}
+
+// flagName returns the command-line flag of the setting with the key, such as --mongo-url for mongo.url.
+func flagName(key string) string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(key)
+}
+
+// NewFlagSet returns the command-line flags of the program: --config, naming a YAML or TOML configuration file, and
+// one flag per setting.
+func NewFlagSet(name string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet(name, pflag.ExitOnError)
+	flags.String("config", "", "path of a YAML or TOML configuration file (env CONFIG_FILE)")
+	for _, s := range settings {
+		flags.String(flagName(s.key), formatDefault(s.def), s.usage+" (env "+s.env+")")
+	}
+	return flags
+}
+
+// formatDefault formats the default of a setting as its flag would be given.
+func formatDefault(def any) string {
+	switch def := def.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(def, ",")
+	default:
+		return fmt.Sprint(def)
+	}
+}
+
+// Load reads the configuration from the flags parsed, the environment and the configuration file, and validates it
+// as a whole: every setting missing, failing to decode or invalid is reported at once, in a *ValidationError.
+// The environment is completed first with the variables of .env.docker in a container, DOCKERIZED=true, and of
+// .env.development elsewhere, when the file exists.
+func Load(flags *pflag.FlagSet) (*Config, error) {
+	envFile := ".env.development"
+	if os.Getenv("DOCKERIZED") == "true" {
+		envFile = ".env.docker"
+	}
+	if err := godotenv.Load(envFile); err != nil {
+		slog.Info("No .env file found, continuing with system environment variables", "file", envFile)
+	}
+
+	v := viper.New()
+	for _, s := range settings {
+		if s.def != nil {
+			v.SetDefault(s.key, s.def)
+		}
+		if err := v.BindEnv(s.key, s.env); err != nil {
+			return nil, err
+		}
+	}
+	file, _ := flags.GetString("config")
+	if file == "" {
+		file = os.Getenv("CONFIG_FILE")
+	}
+	if file != "" {
+		v.SetConfigFile(file)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read the configuration file %s: %w", file, err)
+		}
+	}
+	// Only the flags given override the other sources; the defaults they show are those of the settings.
+	for _, s := range settings {
+		if flag := flags.Lookup(flagName(s.key)); flag != nil && flag.Changed {
+			v.Set(s.key, flag.Value.String())
+		}
+	}
+
+	// Each setting is decoded on its own, so that every value failing to decode is reported along with the others.
+	var cfg Config
+	report := &ValidationError{}
+	for _, s := range settings {
+		raw := v.Get(s.key)
+		if raw == nil {
+			continue
+		}
+		target := field(&cfg, s.key)
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.ComposeDecodeHookFunc(mapstructure.StringToTimeDurationHookFunc(), stringToList, onOffToBool),
+			WeaklyTypedInput: true,
+			Result:           target.Addr().Interface(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := decoder.Decode(raw); err != nil {
+			target.Set(reflect.Zero(target.Type()))
+			report.add(s, formatValue(reflect.ValueOf(raw)), expectedType(target.Type()))
+		}
+	}
+	if cfg.Log.Format == "" {
+		cfg.Log.Format = logging.FormatConsole
+		if cfg.Dockerized {
+			cfg.Log.Format = logging.FormatJSON
+		}
+	}
+	cfg.validate(report)
+	if len(report.Problems) > 0 {
+		return nil, report
+	}
+	return &cfg, nil
+}
+
+// field returns the field of cfg holding the setting with the key.
+func field(cfg *Config, key string) reflect.Value {
+	v := reflect.ValueOf(cfg).Elem()
+	for _, name := range strings.Split(key, ".") {
+		i := 0
+		for i < v.NumField() && v.Type().Field(i).Tag.Get("mapstructure") != name {
+			i++
+		}
+		if i == v.NumField() {
+			panic("config: no field holds the setting " + key)
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// expectedType describes the values a field of type t accepts.
+func expectedType(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == reflect.TypeOf(time.Duration(0)):
+		return "a duration, such as 30s, 5m or 1h"
+	case t.Kind() == reflect.Bool:
+		return "true or false"
+	case t.Kind() == reflect.Uint64:
+		return "a non-negative whole number"
+	case t.Kind() == reflect.Int, t.Kind() == reflect.Int64:
+		return "a whole number"
+	case t.Kind() == reflect.Float64:
+		return "a number"
+	case t.Kind() == reflect.Slice:
+		return "a comma-separated list"
+	default:
+		return "a string"
+	}
+}
+
+// formatValue formats the value of a setting as it would be given.
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice {
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// Problem is a setting of the configuration that is missing or invalid.
+type Problem struct {
+	// Setting is the environment variable of the setting, Flag its command-line flag and Key its key in the
+	// configuration file.
+	Setting string
+	Flag    string
+	Key     string
+	// Value is the value given, empty when the setting is missing.
+	Value string
+	// Expected describes the values accepted.
+	Expected string
+}
+
+// ValidationError reports every setting of the configuration that is missing or invalid.
+type ValidationError struct {
+	Problems []Problem
+}
+
+// Error lists the problems, one per line, with the values each setting expects.
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	if len(e.Problems) == 1 {
+		b.WriteString("1 configuration setting is missing or invalid:")
+	} else {
+		fmt.Fprintf(&b, "%d configuration settings are missing or invalid:", len(e.Problems))
+	}
+	for _, p := range e.Problems {
+		given := "not set"
+		if p.Value != "" {
+			given = fmt.Sprintf("%q is invalid", p.Value)
+		}
+		fmt.Fprintf(&b, "\n  %s (--%s, %s in the file): %s; expected %s", p.Setting, p.Flag, p.Key, given, p.Expected)
+	}
+	return b.String()
+}
+
+// add reports the setting with the value, unless it is reported already, as when its value failed to decode.
+func (e *ValidationError) add(s setting, value, expected string) {
+	if slices.ContainsFunc(e.Problems, func(p Problem) bool { return p.Key == s.key }) {
+		return
+	}
+	e.Problems = append(e.Problems, Problem{Setting: s.env, Flag: flagName(s.key), Key: s.key, Value: value, Expected: expected})
+}
+
+// stringToList decodes comma-separated strings, such as "HR, Admin", into lists, leaving out empty items.
+func stringToList(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf([]string(nil)) {
+		return data, nil
+	}
+	var list []string
+	for _, item := range strings.Split(data.(string), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list, nil
+}
+
+// onOffToBool decodes on and off as true and false, as COMPRESSION=off has always been written.
+func onOffToBool(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Bool {
+		return data, nil
+	}
+	switch strings.ToLower(data.(string)) {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	}
+	return data, nil
+}
+
+// Validate reports every setting of the configuration that is missing or invalid, in a *ValidationError.
+func (c *Config) Validate() error {
+	report := &ValidationError{}
+	c.validate(report)
+	if len(report.Problems) > 0 {
+		return report
+	}
+	return nil
+}
+
+// validate adds the settings of the configuration that are missing or invalid to report.
+func (c *Config) validate(report *ValidationError) {
+	invalid := func(key, expected string) {
+		i := slices.IndexFunc(settings, func(s setting) bool { return s.key == key })
+		report.add(settings[i], formatValue(field(c, key)), expected)
+	}
+
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		invalid("log.level", "debug, info, warn or error")
+	}
+	if c.Log.Format != "" && c.Log.Format != logging.FormatJSON && c.Log.Format != logging.FormatConsole {
+		invalid("log.format", "json or console")
+	}
+
+	if c.Tracing.Endpoint != "" {
+		if u, err := url.Parse(c.Tracing.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalid("tracing.endpoint", "an http or https URL, such as http://localhost:4318")
+		}
+		if c.Tracing.ServiceName == "" {
+			invalid("tracing.service_name", "the name of the service")
+		}
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			invalid("tracing.sample_ratio", "a number between 0 and 1")
+		}
+	}
+
+	switch c.Storage {
+	case "mongo":
+		if c.Mongo.URL == "" {
+			invalid("mongo.url", "a MongoDB connection URL, such as mongodb://localhost:27017")
+		}
+		if c.Mongo.ConnectTimeout <= 0 {
+			invalid("mongo.connect_timeout", "a positive duration, such as 1m")
+		}
+		if c.Mongo.DB == "" {
+			invalid("mongo.db", "the name of a MongoDB database")
+		}
+		if c.Mongo.Collection == "" {
+			invalid("mongo.collection", "the name of a MongoDB collection")
+		}
+	case "postgres":
+		if c.Postgres.URL == "" {
+			invalid("postgres.url", "a PostgreSQL connection URL, such as postgres://localhost:5432/employees")
+		}
+	case "sqlite", "memory":
+	default:
+		invalid("storage", "mongo, postgres, sqlite or memory")
+	}
+	if c.OperatorTenant != "" && (c.TenantHeader == "" || !tenant.Valid(c.OperatorTenant)) {
+		invalid("operator_tenant", "a tenant ID, and no value unless TENANT_HEADER is set")
+	}
+	if c.ReadOnlyReplica && c.Storage != "mongo" {
+		invalid("read_only_replica", "false unless STORAGE is mongo")
+	}
+	if c.ReadOnlyReplica && c.SeedEmployees != "" {
+		invalid("seed_employees", "no value on a read-only replica")
+	}
+
+	if _, err := router.ParseAddr(c.Server.Addr); err != nil {
+		invalid("server.addr", "a port or a host and port, such as 8080 or 127.0.0.1:8080")
+	}
+	if c.Server.MaxBodyBytes <= 0 {
+		invalid("server.max_body_bytes", "a positive number of bytes")
+	}
+	if c.Server.ReadyTimeout <= 0 {
+		invalid("server.ready_timeout", "a positive duration, such as 2s")
+	}
+	if c.Compression.MinSize < 0 {
+		invalid("compression.min_size", "a non-negative number of bytes")
+	}
+
+	if c.Storage == "mongo" {
+		if c.Mongo.ReadPreference != "" {
+			if mode, err := readpref.ModeFromString(c.Mongo.ReadPreference); err != nil {
+				invalid("mongo.read_preference", "primary, primaryPreferred, secondary, secondaryPreferred or nearest")
+			} else if c.Mongo.MaxStaleness != 0 && mode == readpref.PrimaryMode {
+				invalid("mongo.max_staleness", "no value with the primary read preference")
+			}
+		} else if c.Mongo.MaxStaleness != 0 {
+			invalid("mongo.max_staleness", "no value unless MONGO_READ_PREFERENCE is set")
+		}
+		if c.Mongo.ReadConcern != "" && c.Mongo.ReadConcern != "local" && c.Mongo.ReadConcern != "majority" {
+			invalid("mongo.read_concern", "local or majority")
+		}
+		if w, err := strconv.Atoi(c.Mongo.WriteConcern); c.Mongo.WriteConcern != "" && c.Mongo.WriteConcern != "majority" && (err != nil || w < 1) {
+			invalid("mongo.write_concern", "majority or a positive number of members")
+		}
+		if c.Mongo.RetryAttempts < 1 {
+			invalid("mongo.retry_attempts", "a number of attempts of at least 1")
+		}
+		if c.Mongo.BatchSize <= 0 {
+			invalid("mongo.batch_size", "a positive number of employees")
+		}
+		if c.Mongo.IdempotencyTTL <= 0 {
+			invalid("mongo.idempotency_ttl", "a positive duration, such as 24h")
+		}
+		if c.Mongo.QueryExplainSampleRate < 0 || c.Mongo.QueryExplainSampleRate > 1 {
+			invalid("mongo.query_explain_sample_rate", "a number between 0 and 1")
+		}
+	}
+	if c.Redis.URL != "" && c.Redis.CacheTTL <= 0 {
+		invalid("redis.cache_ttl", "a positive duration, such as 1m")
+	}
+
+	if c.Employees.SortCollation != "" {
+		if _, err := language.Parse(c.Employees.SortCollation); err != nil {
+			invalid("employees.sort_collation", "a language tag, such as en or he")
+		}
+		if c.Employees.SortCollationStrength < 1 || c.Employees.SortCollationStrength > 5 {
+			invalid("employees.sort_collation_strength", "a number from 1 to 5")
+		}
+	}
+
+	if c.Jobs.RoleGrantSweepInterval <= 0 {
+		invalid("jobs.role_grant_sweep_interval", "a positive duration, such as 1m")
+	}
+	if c.Jobs.TrashRetention < 0 {
+		invalid("jobs.trash_retention", "a positive duration, such as 720h")
+	}
+	if c.Jobs.TrashPurgeInterval <= 0 {
+		invalid("jobs.trash_purge_interval", "a positive duration, such as 1h")
+	}
+
+	if c.Documents.MaxBytes <= 0 {
+		invalid("documents.max_bytes", "a positive number of bytes")
+	}
+	if c.Documents.URLTTL <= 0 {
+		invalid("documents.url_ttl", "a positive duration, such as 15m")
+	}
+	if c.Leave.AllowanceDays < 0 {
+		invalid("leave.allowance_days", "a non-negative number of days")
+	}
+}
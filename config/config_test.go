@@ -0,0 +1,154 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// clearEnv unsets the variable of every setting for the test, so that only those the test sets are read.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	// An empty variable counts as unset.
+	t.Setenv("CONFIG_FILE", "")
+	for _, s := range settings {
+		t.Setenv(s.env, "")
+	}
+}
+
+// writeConfigFile writes a configuration file named name, whose extension gives its format, and returns its path.
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		env   string
+		flag  string
+		level string
+	}{
+		{name: "default", level: "info"},
+		{name: "file", file: "warn", level: "warn"},
+		{name: "env", env: "error", level: "error"},
+		{name: "flag", flag: "debug", level: "debug"},
+		{name: "env over file", file: "warn", env: "error", level: "error"},
+		{name: "flag over file", file: "warn", flag: "debug", level: "debug"},
+		{name: "flag over env", env: "error", flag: "debug", level: "debug"},
+		{name: "flag over env and file", file: "warn", env: "error", flag: "debug", level: "debug"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("STORAGE", "memory")
+			var args []string
+			if tt.file != "" {
+				args = append(args, "--config", writeConfigFile(t, "config.yaml", "log:\n  level: "+tt.file+"\n"))
+			}
+			if tt.env != "" {
+				t.Setenv("LOG_LEVEL", tt.env)
+			}
+			if tt.flag != "" {
+				args = append(args, "--log-level", tt.flag)
+			}
+			flags := NewFlagSet("test")
+			if err := flags.Parse(args); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := Load(flags)
+			if err != nil {
+				t.Fatalf("expected the configuration to load, got %v", err)
+			}
+			if cfg.Log.Level != tt.level {
+				t.Errorf("expected log level %q, got %q", tt.level, cfg.Log.Level)
+			}
+		})
+	}
+}
+
+func TestLoad_FileFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"config.yaml", "storage: memory\nserver:\n  addr: 127.0.0.1:9090\n  ready_timeout: 5s\nemployees:\n  cost_centers: [R&D, Sales]\n"},
+		{"config.toml", "storage = \"memory\"\n[server]\naddr = \"127.0.0.1:9090\"\nready_timeout = \"5s\"\n[employees]\ncost_centers = \"R&D, Sales\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("CONFIG_FILE", writeConfigFile(t, tt.name, tt.content))
+
+			cfg, err := Load(NewFlagSet("test"))
+			if err != nil {
+				t.Fatalf("expected the configuration to load, got %v", err)
+			}
+			if cfg.Storage != "memory" || cfg.Server.Addr != "127.0.0.1:9090" || cfg.Server.ReadyTimeout.String() != "5s" {
+				t.Errorf("expected the settings of the file, got storage %q, addr %q, ready timeout %s",
+					cfg.Storage, cfg.Server.Addr, cfg.Server.ReadyTimeout)
+			}
+			if !slices.Equal(cfg.Employees.CostCenters, []string{"R&D", "Sales"}) {
+				t.Errorf("expected cost centers [R&D Sales], got %v", cfg.Employees.CostCenters)
+			}
+		})
+	}
+}
+
+func TestLoad_ReportsEveryProblem(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("STORAGE", "mongo")
+	t.Setenv("LOG_LEVEL", "loud")
+	t.Setenv("MONGO_CONNECT_TIMEOUT", "soon")
+	t.Setenv("SERVER_ADDR", "localhost:http-alt")
+	t.Setenv("COMPRESSION", "maybe")
+	flags := NewFlagSet("test")
+	if err := flags.Parse([]string{"--mongo-db", "employees"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Load(flags)
+	var report *ValidationError
+	if !errors.As(err, &report) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+	want := map[string]string{
+		"log.level":             "loud",
+		"mongo.connect_timeout": "soon",
+		"server.addr":           "localhost:http-alt",
+		"compression.enabled":   "maybe",
+		"mongo.url":             "",
+		"mongo.collection":      "",
+	}
+	if len(report.Problems) != len(want) {
+		t.Errorf("expected %d problems, got %d:\n%v", len(want), len(report.Problems), err)
+	}
+	for _, p := range report.Problems {
+		value, ok := want[p.Key]
+		if !ok {
+			t.Errorf("unexpected problem with %s", p.Key)
+			continue
+		}
+		if p.Value != value {
+			t.Errorf("expected %s to report the value %q, got %q", p.Key, value, p.Value)
+		}
+		if p.Expected == "" {
+			t.Errorf("expected %s to describe the values accepted", p.Key)
+		}
+		if !strings.Contains(err.Error(), p.Setting+" (--"+p.Flag+", "+p.Key+" in the file)") {
+			t.Errorf("expected the report to list %s, got:\n%v", p.Setting, err)
+		}
+	}
+	if !strings.HasPrefix(err.Error(), "6 configuration settings are missing or invalid:") {
+		t.Errorf("expected the report to count the problems, got:\n%v", err)
+	}
+}
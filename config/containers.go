@@ -0,0 +1,165 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	docker "github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+)
+
+// The MongoDB container run outside a container, by the server and by the tests. Its root user matches the MONGO_URL
+// of .env.development.
+const (
+	mongoContainerName = "webmvc_employees_mongodb"
+	mongoImage         = "mongo:latest"
+	mongoPort          = "27017/tcp"
+	mongoRootUser      = "root"
+	mongoRootPassword  = "example"
+)
+
+// containerPollInterval is how often StartMongoContainer checks whether the container is healthy.
+const containerPollInterval = 500 * time.Millisecond
+
+// MongoContainer is the MongoDB container, managed through the Docker API rather than docker compose, so that it
+// runs wherever Docker does, Windows and CI included.
+type MongoContainer struct {
+	docker *docker.Client
+	id     string
+	// started is true when the container was not running before StartMongoContainer, which Stop then stops.
+	started bool
+}
+
+// StartMongoContainer starts the MongoDB container, listening on localhost:27017, and waits until MongoDB answers
+// within it or ctx is done. A container already running, such as one started by docker compose, is used as is. A
+// stopped one is started again, keeping its data. Otherwise the image is pulled if needed and a container created,
+// which Docker removes once stopped.
+func StartMongoContainer(ctx context.Context) (*MongoContainer, error) {
+	cli, err := docker.NewClientWithOpts(docker.FromEnv, docker.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := cli.Ping(ctx); err != nil {
+		cli.Close()
+		return nil, fmt.Errorf("the Docker daemon is not reachable: %w", err)
+	}
+	c := &MongoContainer{docker: cli}
+	if err := c.start(ctx); err != nil {
+		cli.Close()
+		return nil, err
+	}
+	if err := c.waitHealthy(ctx); err != nil {
+		c.Stop(context.WithoutCancel(ctx))
+		return nil, err
+	}
+	return c, nil
+}
+
+// start starts the container, creating it first if there is none.
+func (c *MongoContainer) start(ctx context.Context) error {
+	existing, err := c.docker.ContainerInspect(ctx, mongoContainerName)
+	switch {
+	case err == nil && existing.State.Running:
+		slog.Info("MongoDB container is already running", "container", mongoContainerName)
+		c.id = existing.ID
+		return nil
+	case err == nil:
+		slog.Info("Starting the MongoDB container", "container", mongoContainerName)
+		c.id = existing.ID
+	case docker.IsErrNotFound(err):
+		if c.id, err = c.create(ctx); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+	if err := c.docker.ContainerStart(ctx, c.id, container.StartOptions{}); err != nil {
+		return err
+	}
+	c.started = true
+	return nil
+}
+
+// create creates the container, pulling the image first if it is missing, and returns its ID.
+func (c *MongoContainer) create(ctx context.Context) (string, error) {
+	if _, err := c.docker.ImageInspect(ctx, mongoImage); docker.IsErrNotFound(err) {
+		slog.Info("Pulling the MongoDB image", "image", mongoImage)
+		progress, err := c.docker.ImagePull(ctx, mongoImage, image.PullOptions{})
+		if err != nil {
+			return "", err
+		}
+		// The pull goes on while its progress is read.
+		_, err = io.Copy(io.Discard, progress)
+		progress.Close()
+		if err != nil {
+			return "", err
+		}
+	} else if err != nil {
+		return "", err
+	}
+
+	slog.Info("Creating the MongoDB container", "container", mongoContainerName, "image", mongoImage)
+	created, err := c.docker.ContainerCreate(ctx, &container.Config{
+		Image: mongoImage,
+		Env: []string{
+			"MONGO_INITDB_ROOT_USERNAME=" + mongoRootUser,
+			"MONGO_INITDB_ROOT_PASSWORD=" + mongoRootPassword,
+		},
+		ExposedPorts: nat.PortSet{mongoPort: {}},
+		Healthcheck: &container.HealthConfig{
+			Test:          []string{"CMD", "mongosh", "--quiet", "--eval", "db.adminCommand('ping')"},
+			Interval:      5 * time.Second,
+			Timeout:       5 * time.Second,
+			StartPeriod:   time.Minute,
+			StartInterval: time.Second,
+			Retries:       3,
+		},
+	}, &container.HostConfig{
+		PortBindings: nat.PortMap{mongoPort: {{HostIP: "127.0.0.1", HostPort: "27017"}}},
+		AutoRemove:   true,
+	}, nil, nil, mongoContainerName)
+	if err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// waitHealthy waits until the healthcheck of the container passes. Containers without one, such as those created by
+// docker compose, are taken as healthy once running; ConnectMongo waits for MongoDB to answer anyway.
+func (c *MongoContainer) waitHealthy(ctx context.Context) error {
+	for {
+		state, err := c.docker.ContainerInspect(ctx, c.id)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !state.State.Running:
+			return fmt.Errorf("the MongoDB container stopped: %s", state.State.Status)
+		case state.State.Health == nil || state.State.Health.Status == container.Healthy:
+			return nil
+		case state.State.Health.Status == container.Unhealthy:
+			return errors.New("the MongoDB container is unhealthy")
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the MongoDB container to be healthy: %w", ctx.Err())
+		case <-time.After(containerPollInterval):
+		}
+	}
+}
+
+// Stop stops the container if StartMongoContainer started it, leaving running a container it found running.
+func (c *MongoContainer) Stop(ctx context.Context) error {
+	defer c.docker.Close()
+	if !c.started {
+		return nil
+	}
+	slog.Info("Stopping the MongoDB container", "container", mongoContainerName)
+	return c.docker.ContainerStop(ctx, c.id, container.StopOptions{})
+}
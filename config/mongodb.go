@@ -2,163 +2,202 @@ package config
 
 import (
 	"context"
-	"log"
-	"os"
-	"os/exec"
-	"strings"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
 	"time"
 
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/event"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+	"go.mongodb.org/mongo-driver/v2/mongo/writeconcern"
 )
 
-// isContainerRunning checks if a Docker container with the given name is running.
-func IsContainerRunning(containerName string) (bool, error) {
-	// Run "docker ps" filtering by container name.
-	cmd := exec.Command("docker", "ps", "--filter", "name="+containerName, "--format", "{{.Names}}")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return false, err
+// PoolOptions returns the settings of the connection pool: MaxPoolSize and MinPoolSize bound the number of
+// connections to each server, MaxConnIdleTime closes connections idle for longer, and ServerSelectionTimeout bounds the
+// wait for a server able to run an operation.
+func (c MongoConfig) PoolOptions() *options.ClientOptions {
+	opts := options.Client()
+	if c.MaxPoolSize != nil {
+		opts.SetMaxPoolSize(*c.MaxPoolSize)
 	}
-	// If the output contains the containerName, it's running.
-	return strings.Contains(string(output), containerName), nil
-}
-func ConnectMongo(uri string) (*mongo.Client, context.Context, context.CancelFunc, error) {
-	// Create a context with a 10-second timeout for operations.
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-
-	// Apply the URI to the client options.
-	clientOptions := options.Client().ApplyURI(uri)
-
-	// Connect to MongoDB using the client options.
-	client, err := mongo.Connect(clientOptions)
-	if err != nil {
-		// Cancel the context to release resources before returning the error.
-		cancel()
-		return nil, nil, nil, err
+	if c.MinPoolSize != nil {
+		opts.SetMinPoolSize(*c.MinPoolSize)
 	}
-
-	// Return the client, context, and cancel function.
-	return client, ctx, cancel, nil
+	if c.MaxConnIdleTime != 0 {
+		opts.SetMaxConnIdleTime(c.MaxConnIdleTime)
+	}
+	if c.ServerSelectionTimeout != 0 {
+		opts.SetServerSelectionTimeout(c.ServerSelectionTimeout)
+	}
+	return opts
 }
 
-// DisconnectMongo disconnects the MongoDB client after cleaning the database and stopping containers.
-func DisconnectMongo(client *mongo.Client, ctx context.Context) error {
-	// Disconnect from MongoDB.
-	if err := client.Disconnect(ctx); err != nil {
-		return err
+// ReplicaOptions returns how operations use the members of a replica set: ReadPreference picks the members reads go
+// to, one of primary, primaryPreferred, secondary, secondaryPreferred and nearest, and MaxStaleness skips secondaries
+// lagging further behind; ReadConcern, local or majority, sets the read concern and WriteConcern, majority or a
+// number of members, the write concern; RetryWrites enables or disables retryable writes.
+func (c MongoConfig) ReplicaOptions() (*options.ClientOptions, error) {
+	opts := options.Client()
+	if c.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(c.ReadPreference)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MONGO_READ_PREFERENCE: %w", err)
+		}
+		var prefOptions []readpref.Option
+		if c.MaxStaleness != 0 {
+			prefOptions = append(prefOptions, readpref.WithMaxStaleness(c.MaxStaleness))
+		}
+		pref, err := readpref.New(mode, prefOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MONGO_READ_PREFERENCE: %w", err)
+		}
+		opts.SetReadPreference(pref)
+	} else if c.MaxStaleness != 0 {
+		return nil, errors.New("MONGO_MAX_STALENESS requires MONGO_READ_PREFERENCE")
 	}
-	dockerized := os.Getenv("DOCKERIZED")
-	if dockerized != "true" {
-		if err := StopContainers(); err != nil {
-			return err
+	switch c.ReadConcern {
+	case "":
+	case "local":
+		opts.SetReadConcern(readconcern.Local())
+	case "majority":
+		opts.SetReadConcern(readconcern.Majority())
+	default:
+		return nil, fmt.Errorf("invalid MONGO_READ_CONCERN: %q is neither local nor majority", c.ReadConcern)
+	}
+	if c.WriteConcern == "majority" {
+		opts.SetWriteConcern(writeconcern.Majority())
+	} else if c.WriteConcern != "" {
+		// Unacknowledged writes are refused: the repository reads the result of every write.
+		w, err := strconv.Atoi(c.WriteConcern)
+		if err != nil || w < 1 {
+			return nil, fmt.Errorf("invalid MONGO_WRITE_CONCERN: %q is neither majority nor a positive number", c.WriteConcern)
 		}
+		opts.SetWriteConcern(&writeconcern.WriteConcern{W: w})
 	}
-	return nil
+	if c.RetryWrites != nil {
+		opts.SetRetryWrites(*c.RetryWrites)
+	}
+	return opts, nil
 }
 
-func CleanMongoDB(client *mongo.Client, dbName string, ctx context.Context) error {
-	log.Println("Cleaning up MongoDB database:", dbName)
-	dropCtx, cancel := context.WithTimeout(ctx, 30*time.Second) // Increased timeout
-	defer cancel()
-	err := client.Database(dbName).Drop(dropCtx)
+// DefaultConnectTimeout is how long ConnectMongo waits for MongoDB to answer when MongoConfig.ConnectTimeout is zero.
+const DefaultConnectTimeout = time.Minute
+
+// pingTimeout bounds each ping of waitForMongo, so that a MongoDB not accepting connections yet is pinged again
+// rather than waited for until the server selection times out.
+const pingTimeout = 2 * time.Second
+
+// initialPingBackoff is the delay after the first failed ping of waitForMongo, doubled after each further one.
+const initialPingBackoff = 250 * time.Millisecond
+
+// maxPingBackoff caps the delay between the pings of waitForMongo.
+const maxPingBackoff = 5 * time.Second
+
+// ConnectMongo connects to the MongoDB at the URL of cfg, with its PoolOptions and ReplicaOptions. Any extra client
+// options are applied after them. It returns once MongoDB answers, waiting for it for up to cfg.ConnectTimeout, as
+// when its container was just started, along with a context for the first operations, which expires 10 seconds later.
+func ConnectMongo(cfg MongoConfig, opts ...*options.ClientOptions) (*mongo.Client, context.Context, context.CancelFunc, error) {
+	replicaOptions, err := cfg.ReplicaOptions()
 	if err != nil {
-		log.Printf("Error dropping database %s: %v", dbName, err)
+		return nil, nil, nil, err
 	}
-	return err
-}
 
-// startMongoContainer starts the MongoDB container using docker compose if it's not already running.
-func StartMongoContainer() error {
-	const containerName = "webmvc_employees_mongodb"
-	running, err := IsContainerRunning(containerName)
+	// Apply the URI to the client options.
+	clientOptions := options.Client().ApplyURI(cfg.URL)
+
+	// Connect to MongoDB using the client options.
+	client, err := mongo.Connect(append([]*options.ClientOptions{clientOptions, cfg.PoolOptions(), replicaOptions}, opts...)...)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
-	if running {
-		log.Println("MongoDB container is already running.")
-		return nil
+	ping := func(ctx context.Context) error { return client.Ping(ctx, nil) }
+	if err := waitForMongo(ping, cfg.ConnectTimeout); err != nil {
+		client.Disconnect(context.Background())
+		return nil, nil, nil, err
 	}
 
-	log.Println("Starting MongoDB container via docker compose...")
-	// Add -f flag to specify the compose file
-	cmd := exec.Command(
-		"docker", "compose",
-		"-f", "docker-compose.exetuable.yml", // <-- Added this line
-		"up", "-d", "mongodb",
-	)
-	cmd.Dir = "."
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		log.Println("Error starting docker compose:", string(output))
-		return err
-	}
-	return nil
+	// The wait may take most of a minute, so the context only starts once MongoDB answers.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return client, ctx, cancel, nil
 }
 
-// stopMongoContainer stops the MongoDB container using docker compose.
-func StopMongoContainer() error {
-	log.Println("Stopping MongoDB container via docker compose...")
-	cmd := exec.Command(
-		"docker", "compose",
-		"-f", "docker-compose.exetuable.yml", // <-- Added this line
-		"down",
-	)
-	cmd.Dir = "."
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		log.Println("Error stopping docker compose:", string(output))
-		return err
+// waitForMongo calls ping until MongoDB answers, waiting longer after each failed ping, for at most timeout, or
+// DefaultConnectTimeout when zero.
+func waitForMongo(ping func(ctx context.Context) error, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = DefaultConnectTimeout
 	}
-	return nil
-}
-
-func StartContainers() error {
-	log.Println("Starting containers via docker compose...")
-	cmd := exec.Command(
-		"docker", "compose",
-		"-f", "docker-compose.exetuable.yml", // <-- Added this line
-		"up", "-d",
-	)
-	cmd.Dir = "."
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		log.Println("Error starting docker compose:", string(output))
-		return err
+	deadline := time.Now().Add(timeout)
+	backoff := initialPingBackoff
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), min(pingTimeout, time.Until(deadline)))
+		err := ping(ctx)
+		cancel()
+		if err == nil {
+			if attempt > 1 {
+				slog.Info("MongoDB is reachable", "attempts", attempt)
+			}
+			return nil
+		}
+		wait := min(backoff, time.Until(deadline))
+		if wait <= 0 {
+			return fmt.Errorf("MongoDB did not answer within %s: %w", timeout, err)
+		}
+		slog.Warn("MongoDB is not reachable yet, retrying", "attempt", attempt, "retry_in", wait.Round(time.Millisecond), "error", err)
+		time.Sleep(wait)
+		backoff = min(2*backoff, maxPingBackoff)
 	}
-	return nil
 }
 
-// StopContainers stops all containers defined in the docker-compose.yml file using docker compose.
-func StopContainers() error {
-	log.Println("Stopping containers via docker compose...")
-	cmd := exec.Command(
-		"docker", "compose",
-		"-f", "docker-compose.exetuable.yml", // <-- Added this line
-		"stop",
-	)
-	cmd.Dir = "."
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		log.Println("Error stopping docker compose:", string(output))
-		return err
+// CombineMonitors returns a command monitor that forwards every event to each of the given monitors,
+// since a client accepts only one.
+func CombineMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				if m.Started != nil {
+					m.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				if m.Failed != nil {
+					m.Failed(ctx, e)
+				}
+			}
+		},
 	}
-	return nil
 }
 
-// CleanupContainers stops and removes all containers defined in your docker-compose file using docker compose.
-func CleanupContainers() error {
-	log.Println("Cleaning up containers via docker compose (down)...")
-	cmd := exec.Command(
-		"docker", "compose",
-		"-f", "docker-compose.exetuable.yml", // <-- Added this line
-		"down",
-	)
-	cmd.Dir = "."
-	output, err := cmd.CombinedOutput()
+func CleanMongoDB(client *mongo.Client, dbName string, ctx context.Context) error {
+	slog.Info("Cleaning up MongoDB database", "database", dbName)
+	dropCtx, cancel := context.WithTimeout(ctx, 30*time.Second) // Increased timeout
+	defer cancel()
+	// The databases of the tenants go with the database of the deployment.
+	tenants, err := repository.TenantDatabases(dropCtx, client, dbName)
 	if err != nil {
-		log.Println("Error cleaning up containers:", string(output))
+		slog.Error("Failed to list the MongoDB databases of the tenants", "database", dbName, "error", err)
 		return err
 	}
+	for _, name := range append(tenants, dbName) {
+		if err = client.Database(name).Drop(dropCtx); err != nil {
+			slog.Error("Failed to drop the MongoDB database", "database", name, "error", err)
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitForMongo_BacksOffUntilAnswered(t *testing.T) {
+	refused := errors.New("connection refused")
+	var calls []time.Time
+	ping := func(ctx context.Context) error {
+		calls = append(calls, time.Now())
+		if len(calls) <= 3 {
+			return refused
+		}
+		return nil
+	}
+
+	if err := waitForMongo(ping, 10*time.Second); err != nil {
+		t.Fatalf("expected MongoDB to be reached, got %v", err)
+	}
+	if len(calls) != 4 {
+		t.Fatalf("expected 4 pings, got %d", len(calls))
+	}
+	// The delay doubles after each failed ping.
+	for i, want := range []time.Duration{initialPingBackoff, 2 * initialPingBackoff, 4 * initialPingBackoff} {
+		if got := calls[i+1].Sub(calls[i]); got < want || got > want+200*time.Millisecond {
+			t.Errorf("expected ping %d about %s after the previous one, got %s", i+2, want, got)
+		}
+	}
+}
+
+func TestWaitForMongo_GivesUpAtDeadline(t *testing.T) {
+	refused := errors.New("connection refused")
+	timeout := 700 * time.Millisecond
+	start := time.Now()
+	deadline := start.Add(timeout)
+	pings := 0
+	ping := func(ctx context.Context) error {
+		pings++
+		if pingDeadline, ok := ctx.Deadline(); !ok || pingDeadline.After(deadline.Add(10*time.Millisecond)) {
+			t.Errorf("expected ping %d to end by the deadline, got %v", pings, pingDeadline)
+		}
+		return refused
+	}
+
+	err := waitForMongo(ping, timeout)
+	elapsed := time.Since(start)
+	if !errors.Is(err, refused) {
+		t.Fatalf("expected the last ping error, got %v", err)
+	}
+	if elapsed < timeout || elapsed > timeout+200*time.Millisecond {
+		t.Errorf("expected to give up after %s, took %s", timeout, elapsed)
+	}
+	// Pings at 0, 250ms and 700ms: the last wait is cut short by the deadline.
+	if pings != 3 {
+		t.Errorf("expected 3 pings, got %d", pings)
+	}
+}
+
+func TestWaitForMongo_BoundsEachPing(t *testing.T) {
+	// A server accepting connections but not answering must not hold the wait past its deadline.
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	start := time.Now()
+	err := waitForMongo(hang, 300*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the ping to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to give up after 300ms, took %s", elapsed)
+	}
+}
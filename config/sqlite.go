@@ -0,0 +1,32 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"time"
+
+	_ "modernc.org/sqlite" // register the sqlite driver, a translation of SQLite to Go needing no cgo
+)
+
+// ConnectSQLite opens the SQLite database file at path, creating it when missing. Transactions take the write lock
+// when they begin, so that concurrent ones wait for each other, up to five seconds, rather than fail midway; the
+// write-ahead log lets reads proceed meanwhile.
+func ConnectSQLite(path string) (*sql.DB, error) {
+	params := url.Values{
+		"_txlock": {"immediate"},
+		"_pragma": {"busy_timeout(5000)", "journal_mode(WAL)"},
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
@@ -0,0 +1,256 @@
+package controllers
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"WebMVCEmployees/clients"
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/inflight"
+	"WebMVCEmployees/logging"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/readonly"
+	"WebMVCEmployees/repository"
+	"WebMVCEmployees/slo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminController handles operational endpoints under /admin.
+type AdminController struct {
+	SLO     *slo.Tracker
+	Clients *clients.Catalog
+	// Audit lists the audit log. It is nil when nothing is audited.
+	Audit    *repository.AuditRepository
+	InFlight *inflight.Tracker
+	ReadOnly *readonly.Switch
+	// LogLevel is the lowest level logged, changed at runtime through /admin/loglevel. It is nil when the level is
+	// fixed.
+	LogLevel *slog.LevelVar
+}
+
+// NewAdminController creates a new AdminController.
+func NewAdminController(tracker *slo.Tracker, catalog *clients.Catalog, audit *repository.AuditRepository, inFlight *inflight.Tracker, readOnly *readonly.Switch) *AdminController {
+	return &AdminController{
+		SLO:      tracker,
+		Clients:  catalog,
+		Audit:    audit,
+		InFlight: inFlight,
+		ReadOnly: readOnly,
+	}
+}
+
+// SLOHandler handles GET /admin/slo
+// @Summary Report per-route SLO compliance
+// @Description Returns every route objective with its compliance and error budget burn rates over the 5m and 1h windows.
+// Routes burning their budget faster than allowed over the last hour are flagged as out of budget.
+// @Tags admin
+// @Produce json,xml
+// @Security BasicAuth
+// @Success 200 {array} slo.RouteStatus
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Router /admin/slo [get]
+func (c *AdminController) SLOHandler(ctx *gin.Context) {
+	negotiate.Render(ctx, http.StatusOK, c.SLO.Status())
+}
+
+// ListClientsHandler handles GET /admin/clients
+// @Summary List generated API client bundles
+// @Description Lists the TypeScript and Java client bundles produced by `make clients`.
+// A bundle is current when it was generated from the OpenAPI document served by this instance.
+// @Tags admin
+// @Produce json,xml
+// @Security BasicAuth
+// @Success 200 {array} clients.Bundle
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/clients [get]
+func (c *AdminController) ListClientsHandler(ctx *gin.Context) {
+	bundles, err := c.Clients.List()
+	if err != nil {
+		respondError(ctx, http.StatusInternalServerError, errors.CodeInternal, "Failed to list client bundles")
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, bundles)
+}
+
+// DownloadClientHandler handles GET /admin/clients/:language
+// @Summary Download a generated API client bundle
+// @Description Downloads the zipped client bundle for a language (e.g. typescript, java).
+// Bundles generated from a different OpenAPI document than the one served are refused with 409.
+// @Tags admin
+// @Produce application/zip
+// @Security BasicAuth
+// @Param language path string true "Client language"
+// @Success 200 {file} file
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Router /admin/clients/{language} [get]
+func (c *AdminController) DownloadClientHandler(ctx *gin.Context) {
+	language := ctx.Param("language")
+	path, err := c.Clients.Path(language)
+	switch {
+	case stderrors.Is(err, clients.ErrNotFound):
+		respondError(ctx, http.StatusNotFound, errors.CodeClientNotFound, "No client bundle for language "+language)
+		return
+	case stderrors.Is(err, clients.ErrStale):
+		respondError(ctx, http.StatusConflict, errors.CodeClientStale, "Client bundles are out of date; regenerate them with `make clients`")
+		return
+	case err != nil:
+		respondError(ctx, http.StatusInternalServerError, errors.CodeInternal, err.Error())
+		return
+	}
+	ctx.FileAttachment(path, language+"-client.zip")
+}
+
+// AuditLogHandler handles GET /admin/audit
+// @Summary List the audit log
+// @Description Returns recorded changes, newest first, optionally for a single employee.
+// Nothing is recorded when employees are stored in memory, so the log is always empty.
+// @Tags admin
+// @Produce json,xml,application/msgpack
+// @Security BasicAuth
+// @Param employee query string false "Employee email"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.AuditEntry
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Router /admin/audit [get]
+func (c *AdminController) AuditLogHandler(ctx *gin.Context) {
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	// Without an audit log, such as when employees are stored in memory, there is nothing to list.
+	if c.Audit == nil {
+		negotiate.RenderList(ctx, http.StatusOK, []models.AuditEntry{})
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	entries, err := c.Audit.List(cx, ctx.Query("employee"), page, size)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, entries)
+}
+
+// InFlightHandler handles GET /admin/inflight
+// @Summary Report work in progress
+// @Description Returns the HTTP requests in progress per route and the MongoDB commands awaiting a reply.
+// An instance reporting no requests and no operations has drained and can be rolled safely.
+// The same counts are exported as the http_requests_in_flight and mongo_operations_in_flight gauges.
+// @Tags admin
+// @Produce json,xml
+// @Security BasicAuth
+// @Success 200 {object} inflight.Report
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Router /admin/inflight [get]
+func (c *AdminController) InFlightHandler(ctx *gin.Context) {
+	negotiate.Render(ctx, http.StatusOK, c.InFlight.Report())
+}
+
+// GetReadOnlyModeHandler handles GET /admin/read-only
+// @Summary Report the read-only mode
+// @Description Reports whether the instance rejects mutations, why, and since when.
+// @Tags admin
+// @Produce json,xml
+// @Security BasicAuth
+// @Success 200 {object} readonly.State
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Router /admin/read-only [get]
+func (c *AdminController) GetReadOnlyModeHandler(ctx *gin.Context) {
+	negotiate.Render(ctx, http.StatusOK, c.ReadOnly.State())
+}
+
+// SetReadOnlyModeHandler handles PUT /admin/read-only
+// @Summary Switch the read-only mode on or off
+// @Description While the mode is on, the instance serves reads but rejects every mutation with 503 and READ_ONLY_MODE.
+// The error explains why with the reason given, such as a migration or an incident in progress.
+// The mode applies to this instance only and is off again after a restart, unless READ_ONLY_MODE is set.
+// @Tags admin
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param request body models.ReadOnlyModeRequest true "Read-only mode"
+// @Success 200 {object} readonly.State
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Router /admin/read-only [put]
+func (c *AdminController) SetReadOnlyModeHandler(ctx *gin.Context) {
+	var req models.ReadOnlyModeRequest
+	if err := negotiate.Bind(ctx, &req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	if req.Enabled {
+		c.ReadOnly.Enable(strings.TrimSpace(req.Reason))
+	} else {
+		c.ReadOnly.Disable()
+	}
+	negotiate.Render(ctx, http.StatusOK, c.ReadOnly.State())
+}
+
+// GetLogLevelHandler handles GET /admin/loglevel
+// @Summary Report the log level
+// @Description Reports the lowest level the instance logs: debug, info, warn or error.
+// @Tags admin
+// @Produce json,xml
+// @Security BasicAuth
+// @Success 200 {object} models.LogLevel
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Router /admin/loglevel [get]
+func (c *AdminController) GetLogLevelHandler(ctx *gin.Context) {
+	negotiate.Render(ctx, http.StatusOK, models.LogLevel{Level: strings.ToLower(c.LogLevel.Level().String())})
+}
+
+// SetLogLevelHandler handles PUT /admin/loglevel
+// @Summary Change the log level
+// @Description Changes the lowest level the instance logs, such as to debug during an incident, without a restart.
+// The level applies to this instance only and is back to LOG_LEVEL after a restart.
+// @Tags admin
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param request body models.LogLevel true "Log level"
+// @Success 200 {object} models.LogLevel
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Router /admin/loglevel [put]
+func (c *AdminController) SetLogLevelHandler(ctx *gin.Context) {
+	var req models.LogLevel
+	if err := negotiate.Bind(ctx, &req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	level, err := logging.ParseLevel(strings.TrimSpace(req.Level))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidLogLevel, "Invalid log level; use debug, info, warn or error")
+		return
+	}
+	previous := c.LogLevel.Level()
+	c.LogLevel.Set(level)
+	logging.FromContext(ctx.Request.Context()).Warn("Log level changed", "from", previous, "to", level)
+	negotiate.Render(ctx, http.StatusOK, models.LogLevel{Level: strings.ToLower(level.String())})
+}
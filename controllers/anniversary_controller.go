@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAnniversariesHandler handles GET /reports/anniversaries?month={month}
+// @Summary List work anniversaries
+// @Description Returns the employees whose hire-date anniversary falls in the month of the current year, with the
+// years of service they complete, ordered by day. Employees hired this year, without a hire date or terminated
+// are left out.
+// @Tags reports
+// @Produce json,xml,application/msgpack
+// @Param month query string false "Month, from 01 to 12; defaults to the current month" example(06)
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.Anniversary
+// @Failure 400 {object} models.ErrorResponse
+// @Router /reports/anniversaries [get]
+func (c *EmployeeController) GetAnniversariesHandler(ctx *gin.Context) {
+	now := time.Now().UTC()
+	var problems queryProblems
+	month := now.Month()
+	if v, ok := ctx.GetQuery("month"); ok {
+		if m, err := strconv.Atoi(v); err != nil || m < 1 || m > 12 {
+			problems.add(errors.CodeInvalidQuery, "month must be a number from 01 to 12")
+		} else {
+			month = time.Month(m)
+		}
+	}
+	page, size := readPagination(ctx, &problems)
+	if err := problems.err(); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	anniversaries, err := c.Service.GetAnniversaries(cx, month, now, page, size)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, anniversaries)
+}
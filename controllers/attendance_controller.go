@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttendanceController handles HTTP requests for attendance: checking in and out, and summaries of the time worked.
+type AttendanceController struct {
+	Service *services.AttendanceService
+}
+
+// NewAttendanceController creates a new AttendanceController.
+func NewAttendanceController(s *services.AttendanceService) *AttendanceController {
+	return &AttendanceController{
+		Service: s,
+	}
+}
+
+// CheckInHandler handles POST /employees/{employeeEmail}/checkin
+// @Summary Check in
+// @Description Opens an attendance record of the employee at the current time.
+// @Tags attendance
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Success 201 {object} models.AttendanceRecord
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The employee is already checked in"
+// @Router /employees/{employeeEmail}/checkin [post]
+func (c *AttendanceController) CheckInHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	record, err := c.Service.CheckIn(cx, ctx.Param("employeeEmail"), time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, record)
+}
+
+// CheckOutHandler handles POST /employees/{employeeEmail}/checkout
+// @Summary Check out
+// @Description Closes the open attendance record of the employee at the current time.
+// @Tags attendance
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {object} models.AttendanceRecord
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The employee is not checked in"
+// @Router /employees/{employeeEmail}/checkout [post]
+func (c *AttendanceController) CheckOutHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	record, err := c.Service.CheckOut(cx, ctx.Param("employeeEmail"), time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, record)
+}
+
+// GetAttendanceHandler handles GET /employees/{employeeEmail}/attendance?period={period}&date={date}
+// @Summary Summarize an employee's attendance
+// @Description Returns the time the employee worked on each day of the day or week containing date. Records count
+// toward the day, in UTC, they were checked in on; a record still open counts up to now.
+// @Tags attendance
+// @Produce json,xml,application/msgpack
+// @Param employeeEmail path string true "Employee email"
+// @Param period query string false "Summary period; weeks start on Monday" Enums(day,week) default(day)
+// @Param date query string false "A day of the period, formatted as YYYY-MM-DD; defaults to today"
+// @Success 200 {object} models.AttendanceSummary
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/attendance [get]
+func (c *AttendanceController) GetAttendanceHandler(ctx *gin.Context) {
+	now := time.Now().UTC()
+	period, date, err := bindAttendancePeriod(ctx, now)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	summary, err := c.Service.GetAttendanceSummary(cx, ctx.Param("employeeEmail"), period, date, now)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, summary)
+}
+
+// GetTeamAttendanceHandler handles GET /employees/{employeeEmail}/team/attendance?period={period}&date={date}
+// @Summary Summarize a manager's team attendance
+// @Description Returns the attendance summaries of a page of the manager's direct reports, ordered by email.
+// Terminated employees are left out.
+// @Tags attendance
+// @Produce json,xml,application/msgpack
+// @Param employeeEmail path string true "Manager email"
+// @Param period query string false "Summary period; weeks start on Monday" Enums(day,week) default(day)
+// @Param date query string false "A day of the period, formatted as YYYY-MM-DD; defaults to today"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.AttendanceSummary
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/team/attendance [get]
+func (c *AttendanceController) GetTeamAttendanceHandler(ctx *gin.Context) {
+	now := time.Now().UTC()
+	period, date, err := bindAttendancePeriod(ctx, now)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	summaries, err := c.Service.GetTeamAttendance(cx, ctx.Param("employeeEmail"), period, date, now, page, size)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, summaries)
+}
+
+// bindAttendancePeriod reads the period and date parameters of the attendance summaries, defaulting to today.
+func bindAttendancePeriod(ctx *gin.Context, now time.Time) (string, time.Time, error) {
+	var problems queryProblems
+	period := ctx.DefaultQuery("period", models.AttendanceDaily)
+	if period != models.AttendanceDaily && period != models.AttendanceWeekly {
+		problems.add(errors.CodeInvalidQuery, "period must be day or week")
+	}
+	date := now
+	if v, ok := ctx.GetQuery("date"); ok {
+		var err error
+		if date, err = time.Parse(time.DateOnly, v); err != nil {
+			problems.add(errors.CodeInvalidQuery, "date must be formatted as YYYY-MM-DD")
+		}
+	}
+	return period, date, problems.err()
+}
@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultExpiryWindow is how far ahead GET /certifications/expiring looks when within is not given.
+const defaultExpiryWindow = 90 * 24 * time.Hour
+
+// CertificationController handles HTTP requests for the certifications held by employees.
+type CertificationController struct {
+	Service *services.CertificationService
+}
+
+// NewCertificationController creates a new CertificationController.
+func NewCertificationController(s *services.CertificationService) *CertificationController {
+	return &CertificationController{
+		Service: s,
+	}
+}
+
+// AddCertificationHandler handles POST /employees/{employeeEmail}/certifications
+// @Summary Add a certification to an employee
+// @Description Records a certification held by the employee, with the day it expires unless it never does.
+// @Tags certifications
+// @Accept json,xml
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Param certification body models.CertificationRequest true "Name, issuer and expiry"
+// @Success 201 {object} models.Certification
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/certifications [post]
+func (c *CertificationController) AddCertificationHandler(ctx *gin.Context) {
+	var req models.CertificationRequest
+	if err := negotiate.Bind(ctx, &req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	cert, err := c.Service.AddCertification(cx, ctx.Param("employeeEmail"), req, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, cert)
+}
+
+// ListCertificationsHandler handles GET /employees/{employeeEmail}/certifications?page={page}&size={size}
+// @Summary List an employee's certifications
+// @Description Returns a page of the certifications of the employee: those that never expire, then the others by expiry day.
+// @Tags certifications
+// @Produce json,xml,application/msgpack
+// @Param employeeEmail path string true "Employee email"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.Certification
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/certifications [get]
+func (c *CertificationController) ListCertificationsHandler(ctx *gin.Context) {
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	certs, err := c.Service.ListCertifications(cx, ctx.Param("employeeEmail"), page, size)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, certs)
+}
+
+// UpdateCertificationHandler handles PUT /employees/{employeeEmail}/certifications/{certificationId}
+// @Summary Replace a certification
+// @Description Replaces one of the certifications of the employee, typically with the expiry of its renewal.
+// @Tags certifications
+// @Accept json,xml
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Param certificationId path string true "Certification identifier"
+// @Param certification body models.CertificationRequest true "Name, issuer and expiry"
+// @Success 200 {object} models.Certification
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/certifications/{certificationId} [put]
+func (c *CertificationController) UpdateCertificationHandler(ctx *gin.Context) {
+	var req models.CertificationRequest
+	if err := negotiate.Bind(ctx, &req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	cert, err := c.Service.UpdateCertification(cx, ctx.Param("employeeEmail"), ctx.Param("certificationId"), req, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, cert)
+}
+
+// DeleteCertificationHandler handles DELETE /employees/{employeeEmail}/certifications/{certificationId}
+// @Summary Delete a certification
+// @Description Removes one of the certifications of the employee.
+// @Tags certifications
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Param certificationId path string true "Certification identifier"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/certifications/{certificationId} [delete]
+func (c *CertificationController) DeleteCertificationHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Service.DeleteCertification(cx, ctx.Param("employeeEmail"), ctx.Param("certificationId")); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Certification deleted"})
+}
+
+// ListExpiringCertificationsHandler handles GET /certifications/expiring?within={within}&page={page}&size={size}
+// @Summary List expiring certifications
+// @Description Returns a page of the certifications of all employees that expire within the given time, soonest first.
+// Certifications that have already expired are included, since they are due for renewal as well.
+// @Tags certifications
+// @Produce json,xml,application/msgpack
+// @Param within query string false "How far ahead to look, in days (90d) or as a duration (12h)" default(90d)
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.Certification
+// @Failure 400 {object} models.ErrorResponse
+// @Router /certifications/expiring [get]
+func (c *CertificationController) ListExpiringCertificationsHandler(ctx *gin.Context) {
+	within := defaultExpiryWindow
+	if v, ok := ctx.GetQuery("within"); ok {
+		window, err := parseAge(v)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "within must be a number of days such as 90d or a duration such as 12h")
+			return
+		}
+		within = window
+	}
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	certs, err := c.Service.GetExpiringCertifications(cx, within, time.Now().UTC(), page, size)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, certs)
+}
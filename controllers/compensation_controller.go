@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompensationController handles HTTP requests for the salaries of employees.
+type CompensationController struct {
+	Service *services.CompensationService
+}
+
+// NewCompensationController creates a new CompensationController.
+func NewCompensationController(s *services.CompensationService) *CompensationController {
+	return &CompensationController{
+		Service: s,
+	}
+}
+
+// SetCompensationHandler handles PUT /employees/{employeeEmail}/compensation
+// @Summary Set the salary of an employee
+// @Description Records the salary of the employee from the effective date, replacing the salary recorded for the
+// same date; earlier salaries stay in the history. Only callers holding an HR or admin role may call it.
+// @Tags compensation
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param compensation body models.CompensationRequest true "Salary, currency and effective date"
+// @Success 200 {object} models.Compensation
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/compensation [put]
+func (c *CompensationController) SetCompensationHandler(ctx *gin.Context) {
+	var req models.CompensationRequest
+	if err := negotiate.Bind(ctx, &req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	compensation, err := c.Service.SetCompensation(cx, ctx.Param("employeeEmail"), req, ctx.GetString(middleware.CallerKey), time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, compensation)
+}
+
+// GetCompensationHandler handles GET /employees/{employeeEmail}/compensation
+// @Summary Get the salary of an employee
+// @Description Returns the salary in effect today and every salary recorded for the employee. Only callers holding
+// an HR or admin role may call it.
+// @Tags compensation
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {object} models.Compensation
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/compensation [get]
+func (c *CompensationController) GetCompensationHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	compensation, err := c.Service.GetCompensation(cx, ctx.Param("employeeEmail"), time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, compensation)
+}
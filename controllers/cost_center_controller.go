@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetHeadcountByCostCenterHandler handles GET /reports/headcount-by-costcenter
+// @Summary Count employees per cost center
+// @Description Returns how many employees are charged to each cost center, ordered by cost center, for finance.
+// Employees charged to none are counted under an empty cost center, and configured cost centers nobody is charged
+// to are listed with a headcount of 0. Terminated and deleted employees are not counted.
+// @Tags reports
+// @Produce json,xml,application/msgpack
+// @Success 200 {array} models.CostCenterHeadcount
+// @Router /reports/headcount-by-costcenter [get]
+func (c *EmployeeController) GetHeadcountByCostCenterHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	headcounts, err := c.Service.GetHeadcountByCostCenter(cx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, headcounts)
+}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DepartmentController handles HTTP requests for the departments employees belong to.
+type DepartmentController struct {
+	Service *services.DepartmentService
+}
+
+// NewDepartmentController creates a new DepartmentController.
+func NewDepartmentController(s *services.DepartmentService) *DepartmentController {
+	return &DepartmentController{
+		Service: s,
+	}
+}
+
+// CreateDepartmentHandler handles POST /departments
+// @Summary Create a department
+// @Description Creates a department employees can then reference by name in their department field.
+// @Tags departments
+// @Accept json,xml
+// @Produce json,xml
+// @Param department body models.Department true "Department"
+// @Success 201 {object} models.Department
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "A department with this name already exists"
+// @Router /departments [post]
+func (c *DepartmentController) CreateDepartmentHandler(ctx *gin.Context) {
+	var dept models.Department
+	if err := negotiate.Bind(ctx, &dept); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	created, err := c.Service.CreateDepartment(cx, dept)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, created)
+}
+
+// ListDepartmentsHandler handles GET /departments?page={page}&size={size}
+// @Summary List departments
+// @Description Returns a page of the departments ordered by name.
+// @Tags departments
+// @Produce json,xml,application/msgpack
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.Department
+// @Failure 400 {object} models.ErrorResponse
+// @Router /departments [get]
+func (c *DepartmentController) ListDepartmentsHandler(ctx *gin.Context) {
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	departments, err := c.Service.ListDepartments(cx, page, size)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, departments)
+}
+
+// GetDepartmentHandler handles GET /departments/{name}
+// @Summary Get a department
+// @Tags departments
+// @Produce json,xml,application/msgpack
+// @Param name path string true "Department name"
+// @Success 200 {object} models.Department
+// @Failure 404 {object} models.ErrorResponse
+// @Router /departments/{name} [get]
+func (c *DepartmentController) GetDepartmentHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	dept, err := c.Service.GetDepartment(cx, ctx.Param("name"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, dept)
+}
+
+// UpdateDepartmentHandler handles PUT /departments/{name}
+// @Summary Update a department
+// @Description Replaces the description of the department. The name cannot be changed, since employees reference it.
+// @Tags departments
+// @Accept json,xml
+// @Produce json,xml
+// @Param name path string true "Department name"
+// @Param department body models.Department true "Department"
+// @Success 200 {object} models.Department
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /departments/{name} [put]
+func (c *DepartmentController) UpdateDepartmentHandler(ctx *gin.Context) {
+	var dept models.Department
+	if err := negotiate.Bind(ctx, &dept); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	updated, err := c.Service.UpdateDepartment(cx, ctx.Param("name"), dept)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, updated)
+}
+
+// DeleteDepartmentHandler handles DELETE /departments/{name}
+// @Summary Delete a department
+// @Description Removes a department that no employee belongs to.
+// @Tags departments
+// @Produce json,xml
+// @Param name path string true "Department name"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "Employees still belong to the department"
+// @Router /departments/{name} [delete]
+func (c *DepartmentController) DeleteDepartmentHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Service.DeleteDepartment(cx, ctx.Param("name")); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Department deleted"})
+}
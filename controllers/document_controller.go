@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxDocumentName is the longest document name accepted, in characters.
+const maxDocumentName = 255
+
+// DocumentController handles HTTP requests for the documents attached to employees.
+type DocumentController struct {
+	Service *services.DocumentService
+}
+
+// NewDocumentController creates a new DocumentController.
+func NewDocumentController(s *services.DocumentService) *DocumentController {
+	return &DocumentController{
+		Service: s,
+	}
+}
+
+// UploadDocumentHandler handles POST /employees/{employeeEmail}/documents?name={name}&category={category}
+// @Summary Upload a document of an employee
+// @Description Stores the request body as a document of the employee, such as a signed contract or a scanned ID.
+// PDF, JPEG and PNG files up to the configured size (1 MiB by default) are accepted; the type is detected from the
+// content. The response carries a signed download link.
+// @Tags documents
+// @Accept application/pdf,image/jpeg,image/png
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Param name query string true "File name, without slashes"
+// @Param category query string false "Document category" Enums(contract,id,other) default(other)
+// @Param document body string true "File data"
+// @Success 201 {object} models.Document
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/documents [post]
+func (c *DocumentController) UploadDocumentHandler(ctx *gin.Context) {
+	var problems queryProblems
+	name := strings.TrimSpace(ctx.Query("name"))
+	if name == "" || len([]rune(name)) > maxDocumentName || strings.ContainsAny(name, `/\`) {
+		problems.add(errors.CodeInvalidQuery, "name must be a file name of 1 to 255 characters without slashes")
+	}
+	category := ctx.DefaultQuery("category", models.DocumentOther)
+	if !slices.Contains(services.DocumentCategories, category) {
+		problems.add(errors.CodeInvalidQuery, "category must be one of "+strings.Join(services.DocumentCategories, ", "))
+	}
+	if err := problems.err(); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	// Read one byte past the limit so oversized documents are detected without buffering them whole.
+	data, err := io.ReadAll(io.LimitReader(ctx.Request.Body, c.Service.MaxBytes+1))
+	if err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	doc, err := c.Service.UploadDocument(cx, ctx.Param("employeeEmail"), name, category, data, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, doc)
+}
+
+// ListDocumentsHandler handles GET /employees/{employeeEmail}/documents?page={page}&size={size}
+// @Summary List the documents of an employee
+// @Description Returns a page of the employee's documents, newest first, each with a fresh signed download link.
+// @Tags documents
+// @Produce json,xml,application/msgpack
+// @Param employeeEmail path string true "Employee email"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.Document
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/documents [get]
+func (c *DocumentController) ListDocumentsHandler(ctx *gin.Context) {
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	docs, err := c.Service.ListDocuments(cx, ctx.Param("employeeEmail"), page, size, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, docs)
+}
+
+// DownloadDocumentHandler handles GET /documents/{documentId}/download?expires={expires}&signature={signature}
+// @Summary Download a document
+// @Description Returns the content of a document through a signed link from the upload or listing responses.
+// The link needs no credentials but stops working once it expires.
+// @Tags documents
+// @Produce application/pdf,image/jpeg,image/png
+// @Param documentId path string true "Document ID"
+// @Param expires query int true "Expiry of the link, in seconds since the Unix epoch"
+// @Param signature query string true "Signature of the link"
+// @Success 200 {file} file "File data"
+// @Failure 403 {object} models.ErrorResponse "The link is invalid or expired"
+// @Failure 404 {object} models.ErrorResponse
+// @Router /documents/{documentId}/download [get]
+func (c *DocumentController) DownloadDocumentHandler(ctx *gin.Context) {
+	// A link without a readable expiry cannot carry a valid signature.
+	expires, err := strconv.ParseInt(ctx.Query("expires"), 10, 64)
+	if err != nil {
+		respondError(ctx, http.StatusForbidden, errors.CodeDocumentLinkInvalid, "download link is invalid")
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	doc, data, err := c.Service.DownloadDocument(cx, ctx.Param("documentId"), expires, ctx.Query("signature"), time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": doc.Name}))
+	ctx.Header("Cache-Control", "private, no-store")
+	ctx.Data(http.StatusOK, doc.ContentType, data)
+}
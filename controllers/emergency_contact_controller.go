@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmergencyContactController handles HTTP requests for the emergency contacts of employees.
+type EmergencyContactController struct {
+	Service *services.EmergencyContactService
+}
+
+// NewEmergencyContactController creates a new EmergencyContactController.
+func NewEmergencyContactController(s *services.EmergencyContactService) *EmergencyContactController {
+	return &EmergencyContactController{
+		Service: s,
+	}
+}
+
+// SetEmergencyContactsHandler handles PUT /employees/{employeeEmail}/emergency-contacts
+// @Summary Replace the emergency contacts of an employee
+// @Description Replaces the emergency contacts of the employee with up to 5 contacts. Only the employee and callers
+// holding one of the emergency contact roles may call it.
+// @Tags emergency-contacts
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param contacts body models.EmergencyContactsRequest true "Emergency contacts"
+// @Success 200 {object} models.EmergencyContacts
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse "The caller may not access the contacts"
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/emergency-contacts [put]
+func (c *EmergencyContactController) SetEmergencyContactsHandler(ctx *gin.Context) {
+	var req models.EmergencyContactsRequest
+	if err := negotiate.Bind(ctx, &req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	roles := ctx.GetStringSlice(middleware.CallerRolesKey)
+	contacts, err := c.Service.SetEmergencyContacts(cx, ctx.Param("employeeEmail"), ctx.GetString(middleware.CallerKey), roles, req, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, contacts)
+}
+
+// GetEmergencyContactsHandler handles GET /employees/{employeeEmail}/emergency-contacts
+// @Summary Get the emergency contacts of an employee
+// @Description Returns the emergency contacts of the employee, most preferred first. Only the employee and callers
+// holding one of the emergency contact roles may call it.
+// @Tags emergency-contacts
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {object} models.EmergencyContacts
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse "The caller may not access the contacts"
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/emergency-contacts [get]
+func (c *EmergencyContactController) GetEmergencyContactsHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	roles := ctx.GetStringSlice(middleware.CallerRolesKey)
+	contacts, err := c.Service.GetEmergencyContacts(cx, ctx.Param("employeeEmail"), ctx.GetString(middleware.CallerKey), roles)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, contacts)
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bulkMode returns the error handling mode requested through the "mode" query parameter.
+func bulkMode(ctx *gin.Context) string {
+	return ctx.DefaultQuery("mode", models.BulkModeContinue)
+}
+
+// respondBulk writes a bulk report: 200 when every item succeeded, 207 Multi-Status otherwise.
+func respondBulk(ctx *gin.Context, resp models.BulkResponse) {
+	status := http.StatusOK
+	if resp.Failed > 0 || resp.Skipped > 0 {
+		status = http.StatusMultiStatus
+	}
+	negotiate.Render(ctx, status, resp)
+}
+
+// BulkCreateEmployeesHandler handles POST /employees/bulk?mode={continue|abort}
+// @Summary Create employees in bulk
+// @Description Creates every employee in the list and reports the outcome of each item.
+// With mode=abort, processing stops at the first failure and the remaining items are skipped;
+// items created before the failure are kept. Only admins may create employees with roles.
+// @Tags employees
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employees body []models.NewEmployeeBoundary true "Employees to create"
+// @Param mode query string false "Error handling mode" Enums(continue,abort) default(continue)
+// @Param Idempotency-Key header string false "Key identifying retries of the same request; the first response is replayed"
+// @Success 200 {object} models.BulkResponse "All items succeeded"
+// @Success 207 {object} models.BulkResponse "Some items failed or were skipped"
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse "An employee has roles and the caller is not signed in"
+// @Failure 403 {object} models.ErrorResponse "An employee has roles and the caller is not an admin"
+// @Router /employees/bulk [post]
+func (c *EmployeeController) BulkCreateEmployeesHandler(ctx *gin.Context) {
+	var bodies []models.NewEmployeeBoundary
+	if err := negotiate.Bind(ctx, &bodies); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	emps := make([]models.Employee, len(bodies))
+	for i, body := range bodies {
+		emps[i] = body.ToEmployee()
+	}
+	if slices.ContainsFunc(emps, func(emp models.Employee) bool { return len(emp.Roles) > 0 }) &&
+		!middleware.Authorize(ctx, c.Service, middleware.AdminRole) {
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	resp, err := c.Service.BulkCreateEmployees(cx, emps, bulkMode(ctx))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	respondBulk(ctx, resp)
+}
+
+// BulkAssignRolesHandler handles POST /employees/roles/bulk?mode={continue|abort}
+// @Summary Assign roles in bulk
+// @Description Adds roles to each listed employee and reports the outcome of each item. Only admins may call it.
+// @Tags employees
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param assignments body []models.RoleAssignment true "Role assignments"
+// @Param mode query string false "Error handling mode" Enums(continue,abort) default(continue)
+// @Param Idempotency-Key header string false "Key identifying retries of the same request; the first response is replayed"
+// @Success 200 {object} models.BulkResponse "All items succeeded"
+// @Success 207 {object} models.BulkResponse "Some items failed or were skipped"
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Router /employees/roles/bulk [post]
+func (c *EmployeeController) BulkAssignRolesHandler(ctx *gin.Context) {
+	var assignments []models.RoleAssignment
+	if err := negotiate.Bind(ctx, &assignments); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	resp, err := c.Service.BulkAssignRoles(cx, assignments, bulkMode(ctx))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	respondBulk(ctx, resp)
+}
+
+// BulkSetManagersHandler handles PUT /employees/manager/bulk?mode={continue|abort}
+// @Summary Assign managers in bulk
+// @Description Sets the manager of each listed employee and reports the outcome of each item. Only admins may call it.
+// @Tags employees
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param assignments body []models.ManagerAssignment true "Manager assignments"
+// @Param mode query string false "Error handling mode" Enums(continue,abort) default(continue)
+// @Param Idempotency-Key header string false "Key identifying retries of the same request; the first response is replayed"
+// @Success 200 {object} models.BulkResponse "All items succeeded"
+// @Success 207 {object} models.BulkResponse "Some items failed or were skipped"
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Router /employees/manager/bulk [put]
+func (c *EmployeeController) BulkSetManagersHandler(ctx *gin.Context) {
+	var assignments []models.ManagerAssignment
+	if err := negotiate.Bind(ctx, &assignments); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	resp, err := c.Service.BulkSetManagers(cx, assignments, bulkMode(ctx))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	respondBulk(ctx, resp)
+}
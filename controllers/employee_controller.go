@@ -2,12 +2,18 @@ package controllers
 
 import (
 	"context"
+	stderrors "errors"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"WebMVCEmployees/errors"
+	"WebMVCEmployees/logging"
+	"WebMVCEmployees/middleware"
 	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
 	"WebMVCEmployees/services"
 
 	"github.com/gin-gonic/gin"
@@ -16,6 +22,9 @@ import (
 // EmployeeController handles HTTP requests for employee resources.
 type EmployeeController struct {
 	Service *services.EmployeeService
+	// CreatedWithLocation makes POST /employees answer 201 with a Location header instead of 200.
+	// It is off by default for clients that still expect 200.
+	CreatedWithLocation bool
 }
 
 // NewEmployeeController creates a new EmployeeController.
@@ -27,50 +36,157 @@ func NewEmployeeController(s *services.EmployeeService) *EmployeeController {
 
 // CreateEmployeeHandler handles POST /employees
 // @Summary Create a new employee
-// @Description Accepts employee details in JSON, validates and stores the employee.
+// @Description Accepts employee details in JSON, validates and stores the employee. Only admins may create an employee
+// with roles.
 // @Tags employees
-// @Accept json
-// @Produce json
-// @Param employee body models.Employee true "Employee details"
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employee body models.NewEmployeeBoundary true "Employee details"
+// @Param Idempotency-Key header string false "Key identifying retries of the same request; the first response is replayed"
 // @Success 200 {object} models.EmployeeResponse
+// @Success 201 {object} models.EmployeeResponse "Created, when the server runs with CREATE_RETURNS_CREATED=true"
+// @Header 201 {string} Location "URL of the new employee"
+// @Failure 401 {object} models.ErrorResponse "The employee has roles and the caller is not signed in"
+// @Failure 403 {object} models.ErrorResponse "The employee has roles and the caller is not an admin"
+// @Failure 422 {object} models.ErrorResponse "The manager does not hold a manager role"
 // @Router /employees [post]
 func (c *EmployeeController) CreateEmployeeHandler(ctx *gin.Context) {
-	var emp models.Employee
-	if err := ctx.ShouldBindJSON(&emp); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+	var body models.NewEmployeeBoundary
+	if err := negotiate.Bind(ctx, &body); err != nil {
+		respondBindError(ctx, err)
 		return
 	}
 
 	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	createdEmp, err := c.Service.CreateEmployee(cx, emp)
+	// Roles decide what callers may do, so only admins grant them.
+	if len(body.Roles) > 0 && !middleware.Authorize(ctx, c.Service, middleware.AdminRole) {
+		return
+	}
+
+	createdEmp, err := c.Service.CreateEmployee(cx, body.ToEmployee())
 	if err != nil {
-		if httpErr, ok := err.(*errors.HTTPError); ok {
-			ctx.JSON(httpErr.Code, gin.H{"error": httpErr.Msg})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		}
+		handleError(ctx, err)
+		return
+	}
+
+	if c.CreatedWithLocation {
+		ctx.Header("Location", "/employees/"+url.PathEscape(createdEmp.Email))
+		negotiate.Render(ctx, http.StatusCreated, models.NewEmployeeResponse(createdEmp))
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, models.NewEmployeeResponse(createdEmp))
+}
+
+// ReplaceEmployeeHandler handles PUT /employees/{employeeEmail}?upsert={upsert}
+// @Summary Replace an employee, or create it with upsert
+// @Description Replaces the stored employee with the body. With upsert=true, an employee that does not exist yet is created,
+// which lets sync jobs push records without checking whether they exist. Temporary roles and delegations are kept.
+// Only admins may change the roles of the employee; only the employee, signed in, and admins may change its password;
+// only the current manager of the employee, signed in, and admins may change its manager.
+// @Tags employees
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param employee body models.NewEmployeeBoundary true "Employee details; the email may be omitted"
+// @Param upsert query bool false "Create the employee when it does not exist" default(false)
+// @Param If-Match header string false "Version ETag the replacement is conditional on"
+// @Success 200 {object} models.EmployeeResponse "Replaced"
+// @Success 201 {object} models.EmployeeResponse "Created"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse "The roles, the password or the manager change and the caller is not signed in"
+// @Failure 403 {object} models.ErrorResponse "The roles change and the caller is not an admin, the password changes and the caller is neither the employee nor an admin, or the manager changes and the caller is neither the current manager nor an admin"
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The manager would create a cycle"
+// @Failure 412 {object} models.ErrorResponse "Precondition Failed"
+// @Failure 422 {object} models.ErrorResponse "The manager does not hold a manager role"
+// @Router /employees/{employeeEmail} [put]
+func (c *EmployeeController) ReplaceEmployeeHandler(ctx *gin.Context) {
+	employeeEmail := ctx.Param("employeeEmail")
+	upsert, err := strconv.ParseBool(ctx.DefaultQuery("upsert", "false"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "upsert must be true or false")
+		return
+	}
+	var body models.NewEmployeeBoundary
+	if err := negotiate.Bind(ctx, &body); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	expectedVersion, ok := parseIfMatch(ctx.GetHeader("If-Match"))
+	if !ok {
+		respondError(ctx, http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch, "If-Match does not match the current version")
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	changed, err := c.Service.RolesChanged(cx, employeeEmail, body.Roles)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	if changed && !middleware.Authorize(ctx, c.Service, middleware.AdminRole) {
+		return
+	}
+	// Only the employee and admins may change the credentials, lest anyone take over the account.
+	passwordChanged, err := c.Service.PasswordChanged(cx, employeeEmail, body.Password)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	if passwordChanged && !changed && !middleware.AuthorizeSelf(ctx, c.Service, employeeEmail, middleware.AdminRole) {
+		return
+	}
+	managerChanged, err := c.Service.ManagerChanged(cx, employeeEmail, body.Manager)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	if managerChanged && !changed && !c.authorizeManagerChange(ctx, cx, employeeEmail) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, createdEmp)
+	replaced, created, err := c.Service.ReplaceEmployee(cx, employeeEmail, body.ToEmployee(), expectedVersion, upsert)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Header("ETag", versionETag(replaced.Version))
+	if created {
+		ctx.Header("Location", "/employees/"+url.PathEscape(replaced.Email))
+		negotiate.Render(ctx, http.StatusCreated, models.NewEmployeeResponse(replaced))
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, models.NewEmployeeResponse(replaced))
 }
 
 // GetEmployeeHandler handles GET /employees/{employeeEmail}?password={password}
 // @Summary Get an employee by email and password
 // @Description Returns employee details if the provided email and password match a record.
 // @Tags employees
-// @Produce json
+// @Produce json,xml
 // @Param employeeEmail path string true "Employee email"
 // @Param password query string true "Employee password"
-// @Success 200 {object} models.EmployeeResponse
+// @Param expand query string false "Related resources to embed in full: manager" Enums(manager)
+// @Param If-None-Match header string false "Entity tag from a previous response"
+// @Success 200 {object} models.EmployeeResponse "Employee; a models.ExpandedEmployee with expand=manager"
+// @Success 304 "Not Modified"
 // @Router /employees/{employeeEmail} [get]
 func (c *EmployeeController) GetEmployeeHandler(ctx *gin.Context) {
 	email := ctx.Param("employeeEmail")
 	password := ctx.Query("password")
 	if email == "" || password == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Missing email or password"})
+		respondError(ctx, http.StatusBadRequest, errors.CodeMissingParameter, "Missing email or password")
+		return
+	}
+	expand, ok := parseExpand(ctx.Query("expand"))
+	if !ok {
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, invalidExpandMessage)
 		return
 	}
 
@@ -79,162 +195,383 @@ func (c *EmployeeController) GetEmployeeHandler(ctx *gin.Context) {
 
 	emp, err := c.Service.GetEmployee(cx, email, password)
 	if err != nil {
-		if httpErr, ok := err.(*errors.HTTPError); ok {
-			ctx.JSON(httpErr.Code, gin.H{"error": httpErr.Msg})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		}
+		handleError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, emp)
+	if expand.Manager {
+		c.respondExpandedWithETag(ctx, cx, emp)
+		return
+	}
+	respondWithETag(ctx, emp)
 }
 
 // ListEmployeesHandler handles GET /employees with filtering and pagination.
 // @Summary List employees with filtering
 // @Description Returns a paginated list of employees. When the "criteria" query parameter is provided,
-// it filters employees by email domain, role, or age. If no employees match the criteria, an empty array is returned.
+// it filters employees by email domain, role, age, address country, address city, department, employment status, tenure, job title, grade, skill, location or cost center. If no employees match the criteria,
+// an empty array is returned. Terminated employees are only listed with criteria=byStatus&value=terminated.
 // Passwords are not exposed.
 // @Tags employees
-// @Produce json
-// @Param criteria query string false "Filter criteria. Allowed values: byEmailDomain,byRole,byAge. If set to 'none' or omitted, all employees are returned" Enums(byEmailDomain,byRole,byAge) default()
+// @Produce json,xml,application/msgpack
+// @Param criteria query string false "Filter criteria. Allowed values: byEmailDomain,byRole,byAge,byCountry,byCity,byDepartment,byStatus,byTenure,byTitle,byGrade,bySkill,byLocation,byCostCenter. If set to 'none' or omitted, all employees are returned" Enums(byEmailDomain,byRole,byAge,byCountry,byCity,byDepartment,byStatus,byTenure,byTitle,byGrade,bySkill,byLocation,byCostCenter) default()
+// @Param value query string false "Argument of the criteria: the email domain, the role, the age in years, the ISO country code, the city, the department name, the status (active, on_leave or terminated), the job title, the grade, the skill name, the location name or the cost center"
+// @Param min query int false "Fewest full years since the hire date, for byTenure"
+// @Param max query int false "Most full years since the hire date, for byTenure"
+// @Param minLevel query int false "Lowest proficiency from 1 to 5, for bySkill" default(1)
 // @Param page query int false "Page number" default(1)
 // @Param size query int false "Page size" default(10)
+// @Param sort query string false "Sort key (email, name or seniority); prefix with - for descending order" default(email)
+// @Param expand query string false "Related resources to embed in full: manager" Enums(manager)
 // @Success 200 {array} models.EmployeeResponse
 // @Failure 400 {object} models.ErrorResponse "Bad Request"
 // @Router /employees [get]
 func (c *EmployeeController) ListEmployeesHandler(ctx *gin.Context) {
-	// Parse pagination parameters.
-	page, err := strconv.Atoi(ctx.Query("page"))
-	if err != nil || page < 1 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page parameter"})
-		return
-	}
-	size, err := strconv.Atoi(ctx.Query("size"))
-	if err != nil || size < 1 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid size parameter"})
+	q, err := bindListQuery(ctx, true)
+	if err != nil {
+		handleError(ctx, err)
 		return
 	}
 	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	criteria := ctx.Query("criteria")
 	var employees []models.Employee
-	switch criteria {
-	case "byEmailDomain":
-		domain := ctx.Query("value")
-		if domain == "" {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Missing domain value"})
-			return
-		}
-		employees, err = c.listEmployeesByEmailDomain(cx, domain, page, size)
-	case "byRole":
-		role := ctx.Query("value")
-		if role == "" {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Missing role value"})
-			return
-		}
-		employees, err = c.listEmployeesByRole(cx, role, page, size)
-	case "byAge":
-		ageStr := ctx.Query("value")
-		age, errConv := strconv.Atoi(ageStr)
-		if errConv != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid age value"})
-			return
-		}
-		employees, err = c.listEmployeesByAge(cx, age, page, size)
+	switch q.Criteria {
+	case criteriaByEmailDomain:
+		employees, err = c.listEmployeesByEmailDomain(cx, q)
+	case criteriaByRole:
+		employees, err = c.listEmployeesByRole(cx, q)
+	case criteriaByAge:
+		employees, err = c.listEmployeesByAge(cx, q)
+	case criteriaByCountry:
+		employees, err = c.Service.GetEmployeesByCountry(cx, q.Value, q.Page, q.Size, q.Sort)
+	case criteriaByCity:
+		employees, err = c.Service.GetEmployeesByCity(cx, q.Value, q.Page, q.Size, q.Sort)
+	case criteriaByDepartment:
+		employees, err = c.Service.GetEmployeesByDepartment(cx, q.Value, q.Page, q.Size, q.Sort)
+	case criteriaByStatus:
+		employees, err = c.Service.GetEmployeesByStatus(cx, q.Value, q.Page, q.Size, q.Sort)
+	case criteriaByTenure:
+		employees, err = c.Service.GetEmployeesByTenure(cx, q.MinTenure, q.MaxTenure, time.Now().UTC(), q.Page, q.Size, q.Sort)
+	case criteriaByTitle:
+		employees, err = c.Service.GetEmployeesByTitle(cx, q.Value, q.Page, q.Size, q.Sort)
+	case criteriaByGrade:
+		employees, err = c.Service.GetEmployeesByGrade(cx, q.Value, q.Page, q.Size, q.Sort)
+	case criteriaBySkill:
+		employees, err = c.Service.GetEmployeesBySkill(cx, q.Value, q.MinLevel, q.Page, q.Size, q.Sort)
+	case criteriaByLocation:
+		employees, err = c.Service.GetEmployeesByLocation(cx, q.Value, q.Page, q.Size, q.Sort)
+	case criteriaByCostCenter:
+		employees, err = c.Service.GetEmployeesByCostCenter(cx, q.Value, q.Page, q.Size, q.Sort)
 	default:
-		employees, err = c.Service.GetAllEmployees(cx, page, size)
+		employees, err = c.Service.GetAllEmployees(cx, q.Page, q.Size, q.Sort)
 	}
 	if err != nil {
 		handleError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, employees)
+	c.renderEmployees(ctx, cx, q.Expand, employees)
 }
 
 // Private helper methods to reuse service logic for filtering.
-func (c *EmployeeController) listEmployeesByEmailDomain(cx context.Context, domain string, page, size int) ([]models.Employee, error) {
-	return c.Service.GetEmployeesByEmailDomain(cx, domain, page, size)
+func (c *EmployeeController) listEmployeesByEmailDomain(cx context.Context, q listQuery) ([]models.Employee, error) {
+	return c.Service.GetEmployeesByEmailDomain(cx, q.Value, q.Page, q.Size, q.Sort)
 }
 
-func (c *EmployeeController) listEmployeesByRole(cx context.Context, role string, page, size int) ([]models.Employee, error) {
-	return c.Service.GetEmployeesByRole(cx, role, page, size)
+func (c *EmployeeController) listEmployeesByRole(cx context.Context, q listQuery) ([]models.Employee, error) {
+	return c.Service.GetEmployeesByRole(cx, q.Value, q.Page, q.Size, q.Sort)
 }
 
-func (c *EmployeeController) listEmployeesByAge(cx context.Context, age int, page, size int) ([]models.Employee, error) {
+func (c *EmployeeController) listEmployeesByAge(cx context.Context, q listQuery) ([]models.Employee, error) {
 	// Use current Unix time for age calculation.
-	return c.Service.GetEmployeesByAge(cx, age, time.Now().Unix(), page, size)
+	return c.Service.GetEmployeesByAge(cx, q.Age, time.Now().Unix(), q.Page, q.Size, q.Sort)
+}
+
+// QueryEmployeesByExampleHandler handles POST /employees/query?page={page}&size={size}
+// @Summary Search employees by example or fetch several by email
+// @Description Accepts a partial employee document and returns the employees matching all of its non-empty fields.
+// Roles must all be present on a matching employee, and so must skills, at their level or above.
+// The password cannot be used as a criterion.
+// A body with an "emails" list instead returns one models.EmployeeLookup per email, in request order,
+// with found=false for unknown emails; pagination does not apply.
+// @Tags employees
+// @Accept json,xml
+// @Produce json,xml,application/msgpack
+// @Param example body models.EmployeeQuery true "Partial employee used as the example, or the emails to fetch"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param sort query string false "Sort key (email, name or seniority); prefix with - for descending order" default(email)
+// @Param expand query string false "Related resources to embed in full: manager" Enums(manager)
+// @Success 200 {array} models.EmployeeResponse
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Router /employees/query [post]
+func (c *EmployeeController) QueryEmployeesByExampleHandler(ctx *gin.Context) {
+	q, err := bindListQuery(ctx, false)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	var query models.EmployeeQuery
+	if err := negotiate.Bind(ctx, &query); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if query.Emails != nil {
+		lookups, err := c.Service.GetEmployeesByEmails(cx, query)
+		if err != nil {
+			handleError(ctx, err)
+			return
+		}
+		negotiate.RenderList(ctx, http.StatusOK, lookups)
+		return
+	}
+
+	employees, err := c.Service.GetEmployeesByExample(cx, query.ToEmployee(), q.Page, q.Size, q.Sort)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	c.renderEmployees(ctx, cx, q.Expand, employees)
 }
 
-// handleError is a helper function to process errors.
+// handleError is a helper function to process errors. Server errors are logged with the request.
 func handleError(ctx *gin.Context, err error) {
 	if httpErr, ok := err.(*errors.HTTPError); ok {
-		ctx.JSON(httpErr.Code, gin.H{"error": httpErr.Msg})
+		if httpErr.Code >= http.StatusInternalServerError {
+			logging.FromContext(ctx.Request.Context()).Error("Request failed", "error", err)
+		}
+		respondError(ctx, httpErr.Code, httpErr.ErrorCode, httpErr.Msg)
 	} else {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		respondInternalError(ctx, err)
+	}
+}
+
+// respondInternalError logs err with the request and answers 500 without detail, as the client can do nothing of it.
+func respondInternalError(ctx *gin.Context, err error) {
+	logging.FromContext(ctx.Request.Context()).Error("Request failed", "error", err)
+	respondError(ctx, http.StatusInternalServerError, errors.CodeInternal, "Internal server error")
+}
+
+// respondBindError reports a request body that could not be decoded: 413 when it exceeded the
+// body size limit, 400 otherwise, naming the unexpected fields when strict decoding rejected it.
+func respondBindError(ctx *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if stderrors.As(err, &tooLarge) {
+		respondError(ctx, http.StatusRequestEntityTooLarge, errors.CodePayloadTooLarge, "Request body is too large")
+		return
 	}
+	var unknown *negotiate.UnknownFieldsError
+	if stderrors.As(err, &unknown) {
+		respondError(ctx, http.StatusBadRequest, errors.CodeUnknownFields, "Unknown fields: "+strings.Join(unknown.Fields, ", "))
+		return
+	}
+	respondError(ctx, http.StatusBadRequest, errors.CodeInvalidPayload, "Invalid request payload")
 }
 
-// DeleteAllEmployeesHandler handles DELETE /employees
+// respondError writes an error response with a human-readable message and a machine-readable code.
+// The message is translated when the client prefers another language.
+func respondError(ctx *gin.Context, status int, code, msg string) {
+	negotiate.RenderError(ctx, status, code, msg)
+}
+
+// DeleteAllEmployeesHandler handles DELETE /employees?hard={hard}
 // @Summary Delete all employees
-// @Description Deletes all employee records from the service.
+// @Description Soft-deletes every employee; each one can be brought back with POST /employees/{employeeEmail}/restore.
+// With hard=true, all employee records are removed for good; only admins may do so.
 // @Tags employees
-// @Produce json
+// @Produce json,xml
+// @Security BasicAuth
+// @Param hard query bool false "Remove the records instead of marking them deleted" default(false)
 // @Success 200 {object} map[string]string "Success message"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse "hard=true and the caller is not signed in"
+// @Failure 403 {object} models.ErrorResponse "hard=true and the caller is not an admin"
 // @Failure 500 {object} models.ErrorResponse
 // @Router /employees [delete]
 func (c *EmployeeController) DeleteAllEmployeesHandler(ctx *gin.Context) {
+	hard, ok := c.parseHardFlag(ctx)
+	if !ok {
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	err := c.Service.DeleteAllEmployees(cx, hard)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "All employees deleted"})
+}
+
+// DeleteEmployeeHandler handles DELETE /employees/{employeeEmail}?hard={hard}&reports={policy}&reassignTo={email}
+// @Summary Delete an employee
+// @Description Soft-deletes the employee: it is hidden from every query until restored with POST /employees/{employeeEmail}/restore.
+// With hard=true, the record is removed for good, including an employee that is already soft-deleted; only admins may
+// do so.
+// An employee with direct reports is only deleted with reports=orphan, which clears their manager, or with
+// reassignTo, which moves them to another manager; otherwise the request is rejected with 409.
+// @Tags employees
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param hard query bool false "Remove the record instead of marking it deleted" default(false)
+// @Param reports query string false "What happens to the direct reports" Enums(block, orphan, reassign) default(block)
+// @Param reassignTo query string false "New manager of the direct reports; implies reports=reassign"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse "hard=true and the caller is not signed in"
+// @Failure 403 {object} models.ErrorResponse "hard=true and the caller is not an admin"
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The employee has direct reports, or the reassignment would create a cycle"
+// @Failure 422 {object} models.ErrorResponse "The new manager of the reports does not hold a manager role"
+// @Router /employees/{employeeEmail} [delete]
+func (c *EmployeeController) DeleteEmployeeHandler(ctx *gin.Context) {
+	employeeEmail := ctx.Param("employeeEmail")
+	hard, ok := c.parseHardFlag(ctx)
+	if !ok {
+		return
+	}
+	reports, ok := parseReportsHandling(ctx)
+	if !ok {
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Service.DeleteEmployee(cx, employeeEmail, hard, reports, time.Now().UTC()); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Employee deleted"})
+}
+
+// RestoreEmployeeHandler handles POST /employees/{employeeEmail}/restore
+// @Summary Restore a deleted employee
+// @Description Brings back a soft-deleted employee with its roles, manager and delegation.
+// @Tags employees
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {object} models.EmployeeResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "Employee is not deleted"
+// @Router /employees/{employeeEmail}/restore [post]
+func (c *EmployeeController) RestoreEmployeeHandler(ctx *gin.Context) {
+	employeeEmail := ctx.Param("employeeEmail")
+
 	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	err := c.Service.DeleteAllEmployees(cx)
+	emp, err := c.Service.RestoreEmployee(cx, employeeEmail, time.Now().UTC())
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		handleError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"message": "All employees deleted"})
+	ctx.Header("ETag", versionETag(emp.Version))
+	negotiate.Render(ctx, http.StatusOK, models.NewEmployeeResponse(emp))
+}
+
+// parseHardFlag reads the hard query parameter of the delete endpoints, answering 400 when it is not a boolean.
+// Records cannot be brought back after a hard delete, so only admins may ask for one; others get 401 or 403.
+func (c *EmployeeController) parseHardFlag(ctx *gin.Context) (bool, bool) {
+	hard, err := strconv.ParseBool(ctx.DefaultQuery("hard", "false"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "hard must be true or false")
+		return false, false
+	}
+	if hard && !middleware.Authorize(ctx, c.Service, middleware.AdminRole) {
+		return false, false
+	}
+	return hard, true
+}
+
+// parseReportsHandling reads the reports and reassignTo query parameters, answering 400 when they are invalid.
+// A reassignTo without reports selects the reassign policy.
+func parseReportsHandling(ctx *gin.Context) (services.ReportsHandling, bool) {
+	reports := services.ReportsHandling{Policy: ctx.Query("reports"), ReassignTo: ctx.Query("reassignTo")}
+	if reports.Policy == "" {
+		reports.Policy = services.ReportsBlock
+		if reports.ReassignTo != "" {
+			reports.Policy = services.ReportsReassign
+		}
+	}
+	switch {
+	case reports.Policy != services.ReportsBlock && reports.Policy != services.ReportsOrphan && reports.Policy != services.ReportsReassign:
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "reports must be block, orphan or reassign")
+	case reports.Policy == services.ReportsReassign && reports.ReassignTo == "":
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "reassignTo is required to reassign the reports")
+	case reports.Policy != services.ReportsReassign && reports.ReassignTo != "":
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "reassignTo can only be combined with reports=reassign")
+	default:
+		return reports, true
+	}
+	return services.ReportsHandling{}, false
 }
 
 // SetManagerHandler handles PUT /employees/{employeeEmail}/manager
 // @Summary Set manager for an employee
 // @Description Associates an employee with a manager using ManagerEmailBoundary JSON.
+// A manager that reports, directly or not, to the employee is rejected with 409 naming the cycle, and the employee
+// itself with 400. Assigning the current manager again changes nothing.
+// Only the current manager of the employee, signed in, and admins may change it.
 // @Tags employees
-// @Accept json
-// @Produce json
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
 // @Param employeeEmail path string true "Employee email"
 // @Param manager body models.ManagerEmailBoundary true "Manager email"
+// @Param If-Match header string false "Version ETag the update is conditional on"
 // @Success 200 {object} map[string]string "Success message"
 // @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The manager would create a cycle"
+// @Failure 412 {object} models.ErrorResponse "Precondition Failed"
+// @Failure 422 {object} models.ErrorResponse "The manager does not hold a manager role"
 // @Router /employees/{employeeEmail}/manager [put]
 func (c *EmployeeController) SetManagerHandler(ctx *gin.Context) {
 	employeeEmail := ctx.Param("employeeEmail")
 	var mb models.ManagerEmailBoundary
-	if err := ctx.ShouldBindJSON(&mb); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+	if err := negotiate.Bind(ctx, &mb); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	expectedVersion, ok := parseIfMatch(ctx.GetHeader("If-Match"))
+	if !ok {
+		respondError(ctx, http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch, "If-Match does not match the current version")
 		return
 	}
 
 	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	if err := c.Service.SetManager(cx, employeeEmail, mb.Email); err != nil {
-		// You may check for "not found" error.
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !c.authorizeManagerChange(ctx, cx, employeeEmail) {
+		return
+	}
+	if err := c.Service.SetManager(cx, employeeEmail, mb.Email, expectedVersion); err != nil {
+		handleError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Manager set successfully"})
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Manager set successfully"})
 }
 
 // GetManagerHandler handles GET /employees/{employeeEmail}/manager
 // @Summary Get manager of an employee
 // @Description Returns the manager details (excluding password) for the specified employee.
 // @Tags employees
-// @Produce json
+// @Produce json,xml
 // @Param employeeEmail path string true "Employee email"
+// @Param If-None-Match header string false "Entity tag from a previous response"
 // @Success 200 {object} models.EmployeeResponse
+// @Success 304 "Not Modified"
 // @Failure 404 {object} models.ErrorResponse "Not Found"
 // @Router /employees/{employeeEmail}/manager [get]
 func (c *EmployeeController) GetManagerHandler(ctx *gin.Context) {
@@ -244,61 +581,69 @@ func (c *EmployeeController) GetManagerHandler(ctx *gin.Context) {
 
 	manager, err := c.Service.GetManager(cx, employeeEmail)
 	if err != nil {
-		if httpErr, ok := err.(*errors.HTTPError); ok {
-			ctx.JSON(httpErr.Code, gin.H{"error": httpErr.Msg})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		}
+		handleError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, manager)
+	respondWithETag(ctx, manager)
 }
 
 // GetSubordinatesHandler handles GET /managers/{managerEmail}/subordinates?page={page}&size={size}
 // @Summary Get subordinates for a manager
 // @Description Returns a paginated list of employees managed by the specified manager.
+// With recursive=true, the indirect reports are returned as well, each with its depth below the manager,
+// ordered by depth and then by the sort key. The recursive list follows the manager's own reporting line
+// and does not include reports handed over through a delegation.
 // @Tags employees
-// @Produce json
+// @Produce json,xml,application/msgpack
 // @Param managerEmail path string true "Manager email"
 // @Param page query int false "Page number" default(1)
 // @Param size query int false "Page size" default(10)
-// @Success 200 {array} models.EmployeeResponse
+// @Param sort query string false "Sort key (email, name or seniority); prefix with - for descending order" default(email)
+// @Param expand query string false "Related resources to embed in full: manager" Enums(manager)
+// @Param recursive query bool false "Include indirect reports, annotated with their depth" default(false)
+// @Param depth query int false "With recursive=true, the deepest level to include; every level when omitted"
+// @Success 200 {array} models.Subordinate "Employees; the depth is only set with recursive=true"
 // @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Failure 404 {object} models.ErrorResponse "With recursive=true, when the manager does not exist"
 // @Router /managers/{managerEmail}/subordinates [get]
 func (c *EmployeeController) GetSubordinatesHandler(ctx *gin.Context) {
 	managerEmail := ctx.Param("employeeEmail")
-	page, err := strconv.Atoi(ctx.Query("page"))
-	if err != nil || page < 1 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page parameter"})
+	q, err := bindListQuery(ctx, false)
+	if err != nil {
+		handleError(ctx, err)
 		return
 	}
-	size, err := strconv.Atoi(ctx.Query("size"))
-	if err != nil || size < 1 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid size parameter"})
+	recursive, err := strconv.ParseBool(ctx.DefaultQuery("recursive", "false"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "recursive must be true or false")
 		return
 	}
 	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	subordinates, err := c.Service.GetSubordinates(cx, managerEmail, page, size)
+	if recursive {
+		c.respondAllSubordinates(ctx, cx, managerEmail, q)
+		return
+	}
+	subordinates, err := c.Service.GetSubordinates(cx, managerEmail, q.Page, q.Size, q.Sort)
 	if err != nil {
-		if httpErr, ok := err.(*errors.HTTPError); ok {
-			ctx.JSON(httpErr.Code, gin.H{"error": httpErr.Msg})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		}
+		handleError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, subordinates)
+	c.renderEmployees(ctx, cx, q.Expand, subordinates)
 }
 
 // RemoveManagerHandler handles DELETE /employees/{employeeEmail}/manager
 // @Summary Remove manager association from an employee
-// @Description Unsets the manager for the specified employee.
+// @Description Unsets the manager for the specified employee. Only the current manager of the employee, signed in,
+// and admins may remove it.
 // @Tags employees
-// @Produce json
+// @Produce json,xml
+// @Security BasicAuth
 // @Param employeeEmail path string true "Employee email"
 // @Success 200 {object} map[string]string "Success message"
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /employees/{employeeEmail}/manager [delete]
 func (c *EmployeeController) RemoveManagerHandler(ctx *gin.Context) {
@@ -306,13 +651,12 @@ func (c *EmployeeController) RemoveManagerHandler(ctx *gin.Context) {
 	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
 	defer cancel()
 
+	if !c.authorizeManagerChange(ctx, cx, employeeEmail) {
+		return
+	}
 	if err := c.Service.RemoveManager(cx, employeeEmail); err != nil {
-		if httpErr, ok := err.(*errors.HTTPError); ok {
-			ctx.JSON(httpErr.Code, gin.H{"error": httpErr.Msg})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		}
+		handleError(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"message": "Manager removed successfully"})
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Manager removed successfully"})
 }
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetDelegationHandler handles PUT /employees/{employeeEmail}/delegation
+// @Summary Delegate manager duties
+// @Description Hands the manager's duties to a delegate between startsAt and endsAt. During that window the
+// delegate also sees the manager's subordinates and approves their requests; afterwards duties revert automatically.
+// Only the manager, signed in, and admins may delegate the duties.
+// @Tags employees
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Manager email"
+// @Param delegation body models.Delegation true "Delegate and window"
+// @Success 200 {object} models.EmployeeResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/delegation [put]
+func (c *EmployeeController) SetDelegationHandler(ctx *gin.Context) {
+	var delegation models.Delegation
+	if err := negotiate.Bind(ctx, &delegation); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	if !middleware.AuthorizeSelf(ctx, c.Service, ctx.Param("employeeEmail"), middleware.AdminRole) {
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	manager, err := c.Service.SetDelegation(cx, ctx.Param("employeeEmail"), delegation, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, models.NewEmployeeResponse(manager))
+}
+
+// GetDelegationHandler handles GET /employees/{employeeEmail}/delegation
+// @Summary Get a manager's delegation
+// @Description Returns the configured delegation, whether it is upcoming, active or expired.
+// @Tags employees
+// @Produce json,xml
+// @Param employeeEmail path string true "Manager email"
+// @Success 200 {object} models.Delegation
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/delegation [get]
+func (c *EmployeeController) GetDelegationHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	delegation, err := c.Service.GetDelegation(cx, ctx.Param("employeeEmail"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, delegation)
+}
+
+// RemoveDelegationHandler handles DELETE /employees/{employeeEmail}/delegation
+// @Summary End a manager's delegation
+// @Description Removes the delegation so duties revert to the manager immediately. Only the manager, signed in, and
+// admins may end it.
+// @Tags employees
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Manager email"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/delegation [delete]
+func (c *EmployeeController) RemoveDelegationHandler(ctx *gin.Context) {
+	if !middleware.AuthorizeSelf(ctx, c.Service, ctx.Param("employeeEmail"), middleware.AdminRole) {
+		return
+	}
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Service.RemoveDelegation(cx, ctx.Param("employeeEmail"), time.Now().UTC()); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Delegation removed"})
+}
+
+// GetApproverHandler handles GET /employees/{employeeEmail}/approver
+// @Summary Get the approver of an employee
+// @Description Returns who approves the employee's requests right now: their manager, or the manager's delegate
+// while a delegation is active.
+// @Tags employees
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {object} models.EmployeeResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/approver [get]
+func (c *EmployeeController) GetApproverHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	approver, err := c.Service.ResolveApprover(cx, ctx.Param("employeeEmail"), time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, models.NewEmployeeResponse(approver))
+}
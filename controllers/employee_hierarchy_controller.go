@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetManagerChainHandler handles GET /employees/{employeeEmail}/managers?depth={depth}
+// @Summary Get the manager chain of an employee
+// @Description Returns the managers above the employee, starting with their direct manager, then that manager's
+// manager and so on up to the top of the organization, in a single request. An employee without a manager has an empty chain.
+// @Tags employees
+// @Produce json,xml,application/msgpack
+// @Param employeeEmail path string true "Employee email"
+// @Param depth query int false "Most managers to return; the whole chain when omitted"
+// @Success 200 {array} models.EmployeeResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/managers [get]
+func (c *EmployeeController) GetManagerChainHandler(ctx *gin.Context) {
+	depth, ok := parseDepth(ctx)
+	if !ok {
+		return
+	}
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	managers, err := c.Service.GetManagerChain(cx, ctx.Param("employeeEmail"), depth)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, models.NewEmployeeResponses(managers))
+}
+
+// respondAllSubordinates writes the direct and indirect reports of a manager for GET /subordinates?recursive=true.
+func (c *EmployeeController) respondAllSubordinates(ctx *gin.Context, cx context.Context, managerEmail string, q listQuery) {
+	if q.Expand.Manager {
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "expand cannot be combined with recursive; the managers are part of the result")
+		return
+	}
+	depth, ok := parseDepth(ctx)
+	if !ok {
+		return
+	}
+	subordinates, err := c.Service.GetAllSubordinates(cx, managerEmail, depth, q.Page, q.Size, q.Sort)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, subordinates)
+}
+
+// parseDepth reads the optional depth query parameter, answering 400 when it is not a positive integer.
+// A missing depth is returned as 0, meaning unlimited.
+func parseDepth(ctx *gin.Context) (int, bool) {
+	value, ok := ctx.GetQuery("depth")
+	if !ok {
+		return 0, true
+	}
+	depth, err := strconv.Atoi(value)
+	if err != nil || depth < 1 {
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "depth must be a positive integer")
+		return 0, false
+	}
+	return depth, true
+}
+
+// CountReportsHandler handles GET /employees/{employeeEmail}/reports/count
+// @Summary Count the reports of a manager
+// @Description Returns how many employees report to the manager directly, and how many report to them directly or
+// through other managers, without fetching the subordinates.
+// @Tags employees
+// @Produce json,xml,application/msgpack
+// @Param employeeEmail path string true "Manager email"
+// @Success 200 {object} models.ReportCounts
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/reports/count [get]
+func (c *EmployeeController) CountReportsHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	counts, err := c.Service.CountReports(cx, ctx.Param("employeeEmail"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, counts)
+}
+
+// ReassignReportsHandler handles POST /employees/manager/reassign
+// @Summary Move all reports of a manager to another manager
+// @Description Moves every direct report of the manager from to the manager to in one transaction, e.g. during a reorg.
+// When to is one of the reports, they stay under from and the other reports move under them.
+// Only the manager from, signed in, and admins may move the reports.
+// @Tags employees
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param reassignment body models.ManagerReassignment true "Current and new manager"
+// @Success 200 {object} map[string]int64 "Number of reassigned employees"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse "The current manager does not exist"
+// @Failure 409 {object} models.ErrorResponse "The reassignment would create a cycle"
+// @Failure 422 {object} models.ErrorResponse "The new manager does not hold a manager role"
+// @Router /employees/manager/reassign [post]
+func (c *EmployeeController) ReassignReportsHandler(ctx *gin.Context) {
+	var reassignment models.ManagerReassignment
+	if err := negotiate.Bind(ctx, &reassignment); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	if !middleware.AuthorizeSelf(ctx, c.Service, reassignment.From, middleware.AdminRole) {
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	moved, err := c.Service.ReassignReports(cx, reassignment)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"reassigned": moved})
+}
+
+// GetReportingLinesHandler handles GET /employees/{employeeEmail}/reporting-lines
+// @Summary List the reporting lines of an employee
+// @Description Returns the primary reporting line, the same as the manager field, followed by the dotted lines.
+// @Tags employees
+// @Produce json,xml,application/msgpack
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {array} models.ReportingLine
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/reporting-lines [get]
+func (c *EmployeeController) GetReportingLinesHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	lines, err := c.Service.GetReportingLines(cx, ctx.Param("employeeEmail"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, lines)
+}
+
+// AddReportingLineHandler handles POST /employees/{employeeEmail}/reporting-lines
+// @Summary Add a reporting line to an employee
+// @Description Adds a dotted line to another manager, or with type primary replaces the manager like PUT /employees/{employeeEmail}/manager.
+// Returns the reporting lines of the employee. Only the current manager of the employee, signed in, and admins may
+// add a line.
+// @Tags employees
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param line body models.ReportingLine true "Manager and type of the line"
+// @Success 200 {array} models.ReportingLine
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The line duplicates the primary line, or would create a cycle"
+// @Failure 422 {object} models.ErrorResponse "The primary manager does not hold a manager role"
+// @Router /employees/{employeeEmail}/reporting-lines [post]
+func (c *EmployeeController) AddReportingLineHandler(ctx *gin.Context) {
+	var line models.ReportingLine
+	if err := negotiate.Bind(ctx, &line); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	c.changeReportingLines(ctx, func(cx context.Context, employeeEmail string) error {
+		return c.Service.AddReportingLine(cx, employeeEmail, line)
+	})
+}
+
+// RemoveReportingLineHandler handles DELETE /employees/{employeeEmail}/reporting-lines/{type}/{managerEmail}
+// @Summary Remove a reporting line from an employee
+// @Description Removes a dotted line, or with type primary the manager of the employee. Returns the remaining reporting lines.
+// Only the current manager of the employee, signed in, and admins may remove a line.
+// @Tags employees
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param type path string true "Type of the line" Enums(primary, dotted)
+// @Param managerEmail path string true "Manager email"
+// @Success 200 {array} models.ReportingLine
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/reporting-lines/{type}/{managerEmail} [delete]
+func (c *EmployeeController) RemoveReportingLineHandler(ctx *gin.Context) {
+	line := models.ReportingLine{Manager: ctx.Param("managerEmail"), Type: ctx.Param("type")}
+	c.changeReportingLines(ctx, func(cx context.Context, employeeEmail string) error {
+		return c.Service.RemoveReportingLine(cx, employeeEmail, line)
+	})
+}
+
+// changeReportingLines applies a change to the reporting lines of the employee in the path and writes the resulting lines.
+func (c *EmployeeController) changeReportingLines(ctx *gin.Context, change func(cx context.Context, employeeEmail string) error) {
+	employeeEmail := ctx.Param("employeeEmail")
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if !c.authorizeManagerChange(ctx, cx, employeeEmail) {
+		return
+	}
+	if err := change(cx, employeeEmail); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	lines, err := c.Service.GetReportingLines(cx, employeeEmail)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, lines)
+}
+
+// authorizeManagerChange lets through the current manager of the employee and admins, and otherwise aborts the request
+// with 401 or 403. The manager approves the leave of the employee and reads their reviews, so no one else may change
+// whom the employee reports to.
+func (c *EmployeeController) authorizeManagerChange(ctx *gin.Context, cx context.Context, employeeEmail string) bool {
+	manager, err := c.Service.ManagerOf(cx, employeeEmail)
+	if err != nil {
+		handleError(ctx, err)
+		return false
+	}
+	if manager == "" {
+		return middleware.Authorize(ctx, c.Service, middleware.AdminRole)
+	}
+	return middleware.AuthorizeSelf(ctx, c.Service, manager, middleware.AdminRole)
+}
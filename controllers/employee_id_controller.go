@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEmployeeByIDHandler handles GET /employees/by-id/{id}
+// @Summary Get an employee by internal ID
+// @Description Returns the employee with the given internal ID. Unlike the email, the ID never changes,
+// so it suits references kept by other systems.
+// @Tags employees
+// @Produce json,xml
+// @Param id path string true "Internal employee ID, a ULID"
+// @Param If-None-Match header string false "Entity tag from a previous response"
+// @Success 200 {object} models.EmployeeResponse
+// @Success 304 "Not Modified"
+// @Failure 404 {object} models.ErrorResponse "Not Found"
+// @Router /employees/by-id/{id} [get]
+func (c *EmployeeController) GetEmployeeByIDHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	emp, err := c.Service.GetEmployeeByID(cx, ctx.Param("id"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	respondWithETag(ctx, emp)
+}
+
+// ChangeEmailHandler handles PUT /employees/{employeeEmail}/email
+// @Summary Change the email address of an employee
+// @Description Moves the employee to a new email address, keeping their internal ID. Reports, dotted lines,
+// delegations, teams, notes, leave, attendance, reviews, compensation, certifications, emergency contacts,
+// documents and photos follow the employee; the audit log and org chart snapshots keep the old address.
+// @Tags employees
+// @Accept json,xml
+// @Produce json,xml
+// @Param employeeEmail path string true "Current employee email"
+// @Param email body models.EmailChange true "New email address"
+// @Param If-Match header string false "Version ETag the change is conditional on"
+// @Success 200 {object} models.EmployeeResponse
+// @Header 200 {string} Location "URL of the employee under the new email"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "Another employee has the new email"
+// @Failure 412 {object} models.ErrorResponse "Precondition Failed"
+// @Router /employees/{employeeEmail}/email [put]
+func (c *EmployeeController) ChangeEmailHandler(ctx *gin.Context) {
+	employeeEmail := ctx.Param("employeeEmail")
+	var body models.EmailChange
+	if err := negotiate.Bind(ctx, &body); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	expectedVersion, ok := parseIfMatch(ctx.GetHeader("If-Match"))
+	if !ok {
+		respondError(ctx, http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch, "If-Match does not match the current version")
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	emp, err := c.Service.ChangeEmail(cx, employeeEmail, body.Email, expectedVersion, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Header("ETag", versionETag(emp.Version))
+	ctx.Header("Location", "/employees/"+url.PathEscape(emp.Email))
+	negotiate.Render(ctx, http.StatusOK, models.NewEmployeeResponse(emp))
+}
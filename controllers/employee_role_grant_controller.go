@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GrantTemporaryRoleHandler handles POST /employees/{employeeEmail}/roles/grants
+// @Summary Grant a role temporarily
+// @Description Grants a role until the given time, after which it is revoked automatically. Granting a role
+// the employee already holds temporarily moves its expiry. Grants and revocations are recorded in the audit log.
+// Only admins may call it.
+// @Tags employees
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param grant body models.RoleGrantRequest true "Role and expiry"
+// @Success 200 {object} models.EmployeeResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/roles/grants [post]
+func (c *EmployeeController) GrantTemporaryRoleHandler(ctx *gin.Context) {
+	var grant models.RoleGrantRequest
+	if err := negotiate.Bind(ctx, &grant); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	emp, err := c.Service.GrantTemporaryRole(cx, ctx.Param("employeeEmail"), grant, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, models.NewEmployeeResponse(emp))
+}
+
+// GetEffectiveRolesHandler handles GET /employees/{employeeEmail}/roles/effective
+// @Summary Get the effective roles of an employee
+// @Description Returns the roles the employee holds followed by the roles they imply through the parents in the
+// roles catalog, and the permissions of all of them.
+// @Tags employees
+// @Produce json,xml,application/msgpack
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {object} models.EffectiveRoles
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/roles/effective [get]
+func (c *EmployeeController) GetEffectiveRolesHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	roles, err := c.Service.GetEffectiveRoles(cx, ctx.Param("employeeEmail"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, roles)
+}
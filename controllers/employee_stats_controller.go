@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWorkforceStatsHandler handles GET /employees/stats
+// @Summary Get workforce statistics
+// @Description Returns the headcount of the employees on the payroll, the number of employees per role, per email
+// domain and per manager, their average age and how many fall in each age range. Terminated and deleted employees
+// are not counted.
+// @Tags employees
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} models.WorkforceStats
+// @Router /employees/stats [get]
+func (c *EmployeeController) GetWorkforceStatsHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	stats, err := c.Service.GetWorkforceStats(cx, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, stats)
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TerminateEmployeeHandler handles POST /employees/{employeeEmail}/terminate
+// @Summary Terminate an employee
+// @Description Moves an active or on-leave employee to the terminated status. Terminated employees stay readable
+// but are left out of GET /employees unless listed with criteria=byStatus.
+// @Tags employees
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {object} models.EmployeeResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The employee is already terminated"
+// @Router /employees/{employeeEmail}/terminate [post]
+func (c *EmployeeController) TerminateEmployeeHandler(ctx *gin.Context) {
+	c.changeStatus(ctx, models.StatusTerminated)
+}
+
+// ReactivateEmployeeHandler handles POST /employees/{employeeEmail}/reactivate
+// @Summary Reactivate an employee
+// @Description Moves an on-leave or terminated employee back to the active status.
+// @Tags employees
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {object} models.EmployeeResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The employee is already active"
+// @Router /employees/{employeeEmail}/reactivate [post]
+func (c *EmployeeController) ReactivateEmployeeHandler(ctx *gin.Context) {
+	c.changeStatus(ctx, models.StatusActive)
+}
+
+// StartLeaveHandler handles POST /employees/{employeeEmail}/leave
+// @Summary Put an employee on leave
+// @Description Moves an active employee to the on_leave status until they are reactivated.
+// @Tags employees
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {object} models.EmployeeResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The employee is not active"
+// @Router /employees/{employeeEmail}/leave [post]
+func (c *EmployeeController) StartLeaveHandler(ctx *gin.Context) {
+	c.changeStatus(ctx, models.StatusOnLeave)
+}
+
+// changeStatus moves the employee in the path to the given status and writes the updated employee.
+func (c *EmployeeController) changeStatus(ctx *gin.Context, status string) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	emp, err := c.Service.ChangeStatus(cx, ctx.Param("employeeEmail"), status, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, models.NewEmployeeResponse(emp))
+}
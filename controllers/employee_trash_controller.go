@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultPurgeAge is how long employees stay in the trash when a purge omits olderThan.
+const defaultPurgeAge = 30 * 24 * time.Hour
+
+// ListTrashHandler handles GET /employees/trash
+// @Summary List deleted employees
+// @Description Returns a paginated list of the soft-deleted employees, with the time each one was deleted.
+// Passwords are not exposed.
+// @Tags employees
+// @Produce json,xml,application/msgpack
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param sort query string false "Sort key (email, name or seniority); prefix with - for descending order" default(email)
+// @Param expand query string false "Related resources to embed in full: manager" Enums(manager)
+// @Success 200 {array} models.EmployeeResponse
+// @Failure 400 {object} models.ErrorResponse "Bad Request"
+// @Router /employees/trash [get]
+func (c *EmployeeController) ListTrashHandler(ctx *gin.Context) {
+	q, err := bindListQuery(ctx, false)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	employees, err := c.Service.GetDeletedEmployees(cx, q.Page, q.Size, q.Sort)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	c.renderEmployees(ctx, cx, q.Expand, employees)
+}
+
+// PurgeTrashHandler handles POST /employees/trash/purge?olderThan={olderThan}
+// @Summary Purge deleted employees
+// @Description Permanently removes the employees that were soft-deleted at least olderThan ago. Only admins may call it.
+// @Tags employees
+// @Produce json,xml
+// @Security BasicAuth
+// @Param olderThan query string false "Minimum time in the trash, in days (30d) or as a duration (12h); 0 purges everything" default(30d)
+// @Success 200 {object} map[string]int64 "Number of purged employees"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Router /employees/trash/purge [post]
+func (c *EmployeeController) PurgeTrashHandler(ctx *gin.Context) {
+	olderThan := defaultPurgeAge
+	if v, ok := ctx.GetQuery("olderThan"); ok {
+		age, err := parseAge(v)
+		if err != nil {
+			respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "olderThan must be a number of days such as 30d or a duration such as 12h")
+			return
+		}
+		olderThan = age
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	purged, err := c.Service.PurgeDeletedEmployees(cx, now.Add(-olderThan), now)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"purged": purged})
+}
+
+// parseAge parses a non-negative age given in days, such as "30d", or as a Go duration, such as "12h".
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, errors.NewHTTPError(http.StatusBadRequest, "invalid age "+s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	age, err := time.ParseDuration(s)
+	if err != nil || age < 0 {
+		return 0, errors.NewHTTPError(http.StatusBadRequest, "invalid age "+s)
+	}
+	return age, nil
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// weakETag computes a weak entity tag from the JSON representation of a resource.
+func weakETag(body interface{}) (string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	sum := sha1.Sum(data)
+	return `W/"` + hex.EncodeToString(sum[:]) + `"`, nil
+}
+
+// versionETag returns the strong entity tag for a stored document version.
+func versionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// employeeETag returns the entity tag of an employee: its version when the document
+// carries one, otherwise a weak tag computed from its representation.
+func employeeETag(emp models.EmployeeResponse) (string, error) {
+	if emp.Version > 0 {
+		return versionETag(emp.Version), nil
+	}
+	return weakETag(emp)
+}
+
+// parseIfMatch extracts the expected document version from an If-Match header.
+// It returns 0 when the header is absent or "*", meaning the update is unconditional,
+// and ok=false when the header cannot match any stored version.
+func parseIfMatch(ifMatch string) (version int64, ok bool) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, true
+	}
+	// If-Match uses strong comparison, so weak tags never match.
+	if strings.HasPrefix(ifMatch, "W/") {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(strings.Trim(ifMatch, `"`), 10, 64)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// etagMatches reports whether the If-None-Match header value matches the given entity tag.
+// Comparison is weak, as required for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// respondWithETag writes an employee with an ETag header, or 304 Not Modified
+// when the request's If-None-Match header already matches it.
+func respondWithETag(ctx *gin.Context, emp models.Employee) {
+	body := models.NewEmployeeResponse(emp)
+	etag, err := employeeETag(body)
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	respondTagged(ctx, etag, body)
+}
+
+// respondTagged writes body with the given ETag header, or 304 Not Modified when If-None-Match matches it.
+func respondTagged(ctx *gin.Context, etag string, body interface{}) {
+	ctx.Header("ETag", etag)
+	if etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, body)
+}
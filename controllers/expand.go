@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// expansion lists the related resources embedded in employee responses through the expand query parameter.
+type expansion struct {
+	Manager bool
+}
+
+const invalidExpandMessage = "expand must be a comma-separated list of: " + models.ExpandManager
+
+// parseExpand parses an expand parameter such as "manager". It returns false for unknown expansions.
+func parseExpand(value string) (expansion, bool) {
+	var e expansion
+	if value == "" {
+		return e, true
+	}
+	for _, name := range strings.Split(value, ",") {
+		switch strings.TrimSpace(name) {
+		case models.ExpandManager:
+			e.Manager = true
+		default:
+			return expansion{}, false
+		}
+	}
+	return e, true
+}
+
+// renderEmployees renders a list of employees, embedding the requested related resources.
+func (c *EmployeeController) renderEmployees(ctx *gin.Context, cx context.Context, expand expansion, employees []models.Employee) {
+	if !expand.Manager {
+		negotiate.RenderList(ctx, http.StatusOK, models.NewEmployeeResponses(employees))
+		return
+	}
+	expanded, err := c.Service.ExpandManagers(cx, employees)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, expanded)
+}
+
+// respondExpandedWithETag writes an employee with its related resources embedded. The entity tag is computed
+// from the whole representation, since it changes with the embedded resources and not only the employee's version.
+func (c *EmployeeController) respondExpandedWithETag(ctx *gin.Context, cx context.Context, emp models.Employee) {
+	expanded, err := c.Service.ExpandManagers(cx, []models.Employee{emp})
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	etag, err := weakETag(expanded[0])
+	if err != nil {
+		respondInternalError(ctx, err)
+		return
+	}
+	respondTagged(ctx, etag, expanded[0])
+}
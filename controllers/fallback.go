@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"net/http"
+
+	"WebMVCEmployees/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteNotFoundHandler answers requests for paths no route matches.
+func RouteNotFoundHandler(ctx *gin.Context) {
+	respondError(ctx, http.StatusNotFound, errors.CodeRouteNotFound, "No route matches "+ctx.Request.URL.Path)
+}
+
+// MethodNotAllowedHandler answers requests whose path exists for other methods only.
+// Gin has already listed those methods in the Allow header.
+func MethodNotAllowedHandler(ctx *gin.Context) {
+	respondError(ctx, http.StatusMethodNotAllowed, errors.CodeMethodNotAllowed,
+		"Method "+ctx.Request.Method+" is not allowed on "+ctx.Request.URL.Path)
+}
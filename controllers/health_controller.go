@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"net/http"
+
+	"WebMVCEmployees/health"
+	"WebMVCEmployees/logging"
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthController handles the liveness and readiness probes.
+type HealthController struct {
+	Checker *health.Checker
+}
+
+// NewHealthController creates a new HealthController.
+func NewHealthController(checker *health.Checker) *HealthController {
+	return &HealthController{Checker: checker}
+}
+
+// LivenessHandler handles GET /healthz
+// @Summary Report that the process is up
+// @Description Answers as long as the process serves requests, whatever the state of its dependencies, so that an
+// orchestrator restarts the instance only when it hangs or crashed.
+// @Tags health
+// @Produce json,xml
+// @Success 200 {object} health.Report
+// @Router /healthz [get]
+func (c *HealthController) LivenessHandler(ctx *gin.Context) {
+	negotiate.Render(ctx, http.StatusOK, health.Report{Status: health.StatusOK})
+}
+
+// ReadinessHandler handles GET /readyz
+// @Summary Report whether the instance is ready to serve
+// @Description Checks the dependencies the instance cannot serve without, each within READY_TIMEOUT: the database
+// answers a ping and, with MongoDB, every migration is applied. An instance failing a check answers 503 so that
+// orchestrators and load balancers stop routing traffic to it until it recovers.
+// @Tags health
+// @Produce json,xml
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /readyz [get]
+func (c *HealthController) ReadinessHandler(ctx *gin.Context) {
+	report := c.Checker.Ready(ctx.Request.Context())
+	if !report.Ready() {
+		logger := logging.FromContext(ctx.Request.Context())
+		for _, check := range report.Checks {
+			if check.Status != health.StatusOK {
+				logger.Warn("Readiness check failed", "check", check.Name, "error", check.Error)
+			}
+		}
+		negotiate.Render(ctx, http.StatusServiceUnavailable, report)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, report)
+}
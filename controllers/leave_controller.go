@@ -0,0 +1,231 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LeaveController handles HTTP requests for time off: leave requests, their approval and leave balances.
+type LeaveController struct {
+	Service *services.LeaveService
+}
+
+// NewLeaveController creates a new LeaveController.
+func NewLeaveController(s *services.LeaveService) *LeaveController {
+	return &LeaveController{
+		Service: s,
+	}
+}
+
+// SubmitLeaveRequestHandler handles POST /employees/{employeeEmail}/leave-requests
+// @Summary Request time off
+// @Description Submits a request for time off by the signed-in employee. The request stays pending until the
+// employee's manager, or the manager's delegate, approves or rejects it; its working days are held against the
+// balance of its year meanwhile.
+// @Tags leave
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param request body models.LeaveRequestBoundary true "Dates and reason"
+// @Success 201 {object} models.LeaveRequest
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse "The caller is not the employee"
+// @Failure 404 {object} models.ErrorResponse "Employee not found or has no manager"
+// @Failure 409 {object} models.ErrorResponse "The dates overlap another request or exceed the balance"
+// @Router /employees/{employeeEmail}/leave-requests [post]
+func (c *LeaveController) SubmitLeaveRequestHandler(ctx *gin.Context) {
+	var req models.LeaveRequestBoundary
+	if err := negotiate.Bind(ctx, &req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	leave, err := c.Service.SubmitLeaveRequest(cx, ctx.Param("employeeEmail"), ctx.GetString(middleware.CallerKey), req, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, leave)
+}
+
+// ListLeaveRequestsHandler handles GET /employees/{employeeEmail}/leave-requests?page={page}&size={size}
+// @Summary List an employee's leave requests
+// @Description Returns a page of the leave requests of the employee, latest start date first.
+// @Tags leave
+// @Produce json,xml,application/msgpack
+// @Param employeeEmail path string true "Employee email"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.LeaveRequest
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/leave-requests [get]
+func (c *LeaveController) ListLeaveRequestsHandler(ctx *gin.Context) {
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	requests, err := c.Service.ListLeaveRequests(cx, ctx.Param("employeeEmail"), page, size)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, requests)
+}
+
+// GetLeaveBalanceHandler handles GET /employees/{employeeEmail}/leave-balances/{year}
+// @Summary Get an employee's leave balance
+// @Description Returns the allowance of the employee for the year with the days used, pending and remaining.
+// @Tags leave
+// @Produce json,xml,application/msgpack
+// @Param employeeEmail path string true "Employee email"
+// @Param year path int true "Calendar year"
+// @Success 200 {object} models.LeaveBalance
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/leave-balances/{year} [get]
+func (c *LeaveController) GetLeaveBalanceHandler(ctx *gin.Context) {
+	year, err := strconv.Atoi(ctx.Param("year"))
+	if err != nil || year < 1 {
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "year must be a calendar year such as 2025")
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	balance, err := c.Service.GetLeaveBalance(cx, ctx.Param("employeeEmail"), year)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, balance)
+}
+
+// GetLeaveRequestHandler handles GET /leave-requests/{requestId}
+// @Summary Get a leave request
+// @Tags leave
+// @Produce json,xml,application/msgpack
+// @Param requestId path string true "Leave request identifier"
+// @Success 200 {object} models.LeaveRequest
+// @Failure 404 {object} models.ErrorResponse
+// @Router /leave-requests/{requestId} [get]
+func (c *LeaveController) GetLeaveRequestHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	leave, err := c.Service.GetLeaveRequest(cx, ctx.Param("requestId"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, leave)
+}
+
+// ApproveLeaveRequestHandler handles POST /leave-requests/{requestId}/approve
+// @Summary Approve a leave request
+// @Description Approves a pending leave request, charging its days to the balance. Only the employee's approver,
+// their manager or the manager's active delegate, may call it.
+// @Tags leave
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param requestId path string true "Leave request identifier"
+// @Param decision body models.LeaveDecisionBoundary false "Optional comment"
+// @Success 200 {object} models.LeaveRequest
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse "The caller is not the approver"
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The request is not pending"
+// @Router /leave-requests/{requestId}/approve [post]
+func (c *LeaveController) ApproveLeaveRequestHandler(ctx *gin.Context) {
+	c.decide(ctx, true)
+}
+
+// RejectLeaveRequestHandler handles POST /leave-requests/{requestId}/reject
+// @Summary Reject a leave request
+// @Description Rejects a pending leave request, releasing its days. Only the employee's approver, their manager or
+// the manager's active delegate, may call it.
+// @Tags leave
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param requestId path string true "Leave request identifier"
+// @Param decision body models.LeaveDecisionBoundary false "Optional comment"
+// @Success 200 {object} models.LeaveRequest
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse "The caller is not the approver"
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The request is not pending"
+// @Router /leave-requests/{requestId}/reject [post]
+func (c *LeaveController) RejectLeaveRequestHandler(ctx *gin.Context) {
+	c.decide(ctx, false)
+}
+
+// decide approves or rejects the leave request on behalf of the caller.
+func (c *LeaveController) decide(ctx *gin.Context, approve bool) {
+	var decision models.LeaveDecisionBoundary
+	if ctx.Request.ContentLength != 0 {
+		if err := negotiate.Bind(ctx, &decision); err != nil {
+			respondBindError(ctx, err)
+			return
+		}
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	leave, err := c.Service.DecideLeaveRequest(cx, ctx.Param("requestId"), ctx.GetString(middleware.CallerKey), approve, decision, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, leave)
+}
+
+// CancelLeaveRequestHandler handles POST /leave-requests/{requestId}/cancel
+// @Summary Cancel a leave request
+// @Description Withdraws a pending leave request of the signed-in employee, releasing its days.
+// @Tags leave
+// @Produce json,xml
+// @Security BasicAuth
+// @Param requestId path string true "Leave request identifier"
+// @Success 200 {object} models.LeaveRequest
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse "The caller is not the employee"
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The request is not pending"
+// @Router /leave-requests/{requestId}/cancel [post]
+func (c *LeaveController) CancelLeaveRequestHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	leave, err := c.Service.CancelLeaveRequest(cx, ctx.Param("requestId"), ctx.GetString(middleware.CallerKey), time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, leave)
+}
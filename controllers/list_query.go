@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Defaults applied when a list request omits the pagination parameters.
+const (
+	defaultPage = 1
+	defaultSize = 10
+)
+
+// Filter criteria accepted by GET /employees.
+const (
+	criteriaByEmailDomain = "byEmailDomain"
+	criteriaByRole        = "byRole"
+	criteriaByAge         = "byAge"
+	criteriaByCountry     = "byCountry"
+	criteriaByCity        = "byCity"
+	criteriaByDepartment  = "byDepartment"
+	criteriaByStatus      = "byStatus"
+	criteriaByTenure      = "byTenure"
+	criteriaByTitle       = "byTitle"
+	criteriaByGrade       = "byGrade"
+	criteriaBySkill       = "bySkill"
+	criteriaByLocation    = "byLocation"
+	criteriaByCostCenter  = "byCostCenter"
+)
+
+// listQuery holds the query parameters shared by the list endpoints.
+type listQuery struct {
+	Page int
+	Size int
+	// Criteria selects the filter of GET /employees; empty or "none" lists every employee.
+	Criteria string
+	// Value is the argument of the criteria.
+	Value string
+	// Age is Value parsed for the byAge criteria.
+	Age int
+	// MinTenure and MaxTenure are the bounds in years of the byTenure criteria; a negative MaxTenure has no bound.
+	MinTenure int
+	MaxTenure int
+	// MinLevel is the lowest proficiency matched by the bySkill criteria.
+	MinLevel int
+	// Sort is a sort key such as "name", or "-name" for descending order.
+	Sort string
+	// Expand lists the related resources to embed in each employee.
+	Expand expansion
+}
+
+// queryProblems collects every invalid query parameter so they are reported in a single response.
+type queryProblems struct {
+	codes    []string
+	messages []string
+}
+
+func (p *queryProblems) add(code, msg string) {
+	p.codes = append(p.codes, code)
+	p.messages = append(p.messages, msg)
+}
+
+// err returns a 400 listing every problem, or nil when there is none. The error code is the one
+// shared by all problems, or INVALID_QUERY when they differ.
+func (p *queryProblems) err() error {
+	if len(p.messages) == 0 {
+		return nil
+	}
+	code := p.codes[0]
+	for _, c := range p.codes[1:] {
+		if c != code {
+			code = errors.CodeInvalidQuery
+			break
+		}
+	}
+	return errors.NewCodedError(http.StatusBadRequest, code, strings.Join(p.messages, "; "))
+}
+
+// bindListQuery reads the pagination, sort and expand parameters, applying the defaults for missing ones.
+// When withCriteria is set, the criteria and value filters of GET /employees are read as well.
+func bindListQuery(ctx *gin.Context, withCriteria bool) (listQuery, error) {
+	q := listQuery{Sort: ctx.Query("sort")}
+	var problems queryProblems
+
+	q.Page, q.Size = readPagination(ctx, &problems)
+	if !services.ValidSort(q.Sort) {
+		problems.add(errors.CodeInvalidSort, "sort must be one of "+strings.Join(services.SortKeys(), ", ")+", optionally prefixed with -")
+	}
+	if expand, ok := parseExpand(ctx.Query("expand")); ok {
+		q.Expand = expand
+	} else {
+		problems.add(errors.CodeInvalidQuery, invalidExpandMessage)
+	}
+
+	if withCriteria {
+		q.Criteria, q.Value = ctx.Query("criteria"), ctx.Query("value")
+		switch q.Criteria {
+		case "", "none":
+		case criteriaByEmailDomain:
+			if q.Value == "" {
+				problems.add(errors.CodeMissingParameter, "Missing domain value")
+			}
+		case criteriaByRole:
+			if q.Value == "" {
+				problems.add(errors.CodeMissingParameter, "Missing role value")
+			}
+		case criteriaByAge:
+			age, err := strconv.Atoi(q.Value)
+			if err != nil {
+				problems.add(errors.CodeInvalidCriteria, "Invalid age value")
+			}
+			q.Age = age
+		case criteriaByCountry:
+			if q.Value == "" {
+				problems.add(errors.CodeMissingParameter, "Missing country value")
+			}
+		case criteriaByCity:
+			if q.Value == "" {
+				problems.add(errors.CodeMissingParameter, "Missing city value")
+			}
+		case criteriaByDepartment:
+			if q.Value == "" {
+				problems.add(errors.CodeMissingParameter, "Missing department value")
+			}
+		case criteriaByStatus:
+			switch q.Value {
+			case "":
+				problems.add(errors.CodeMissingParameter, "Missing status value")
+			case models.StatusActive, models.StatusOnLeave, models.StatusTerminated:
+			default:
+				problems.add(errors.CodeInvalidCriteria, "status must be one of active, on_leave or terminated")
+			}
+		case criteriaByTenure:
+			q.MinTenure, q.MaxTenure = readTenure(ctx, &problems)
+		case criteriaByTitle:
+			if q.Value == "" {
+				problems.add(errors.CodeMissingParameter, "Missing title value")
+			}
+		case criteriaByGrade:
+			if q.Value == "" {
+				problems.add(errors.CodeMissingParameter, "Missing grade value")
+			}
+		case criteriaBySkill:
+			if q.Value == "" {
+				problems.add(errors.CodeMissingParameter, "Missing skill value")
+			}
+			q.MinLevel = readMinLevel(ctx, &problems)
+		case criteriaByLocation:
+			if q.Value == "" {
+				problems.add(errors.CodeMissingParameter, "Missing location value")
+			}
+		case criteriaByCostCenter:
+			if q.Value == "" {
+				problems.add(errors.CodeMissingParameter, "Missing cost center value")
+			}
+		default:
+			problems.add(errors.CodeInvalidCriteria, "criteria must be one of byEmailDomain, byRole, byAge, byCountry, byCity, byDepartment, byStatus, byTenure, byTitle, byGrade, bySkill, byLocation, byCostCenter or none")
+		}
+	}
+	return q, problems.err()
+}
+
+// readMinLevel reads the minLevel parameter of the bySkill criteria; a missing minLevel matches any level.
+func readMinLevel(ctx *gin.Context, problems *queryProblems) int {
+	value, ok := ctx.GetQuery("minLevel")
+	if !ok {
+		return models.MinSkillLevel
+	}
+	level, err := strconv.Atoi(value)
+	if err != nil || level < models.MinSkillLevel || level > models.MaxSkillLevel {
+		problems.add(errors.CodeInvalidCriteria, "minLevel must be a number from 1 to 5")
+		return models.MinSkillLevel
+	}
+	return level
+}
+
+// readTenure reads the min and max parameters of the byTenure criteria, in years. A missing min is 0 and a missing
+// max is returned as -1, meaning no upper bound; at least one of them is required.
+func readTenure(ctx *gin.Context, problems *queryProblems) (minYears, maxYears int) {
+	minValue, hasMin := ctx.GetQuery("min")
+	maxValue, hasMax := ctx.GetQuery("max")
+	if !hasMin && !hasMax {
+		problems.add(errors.CodeMissingParameter, "Missing min or max tenure")
+		return 0, -1
+	}
+	maxYears = -1
+	var err error
+	if hasMin {
+		if minYears, err = strconv.Atoi(minValue); err != nil || minYears < 0 {
+			problems.add(errors.CodeInvalidCriteria, "min must be a non-negative number of years")
+		}
+	}
+	if hasMax {
+		if maxYears, err = strconv.Atoi(maxValue); err != nil || maxYears < 0 {
+			problems.add(errors.CodeInvalidCriteria, "max must be a non-negative number of years")
+		} else if maxYears < minYears {
+			problems.add(errors.CodeInvalidCriteria, "max cannot be less than min")
+		}
+	}
+	return minYears, maxYears
+}
+
+// bindPagination reads only the page and size parameters, for lists that cannot be sorted or expanded.
+func bindPagination(ctx *gin.Context) (page, size int, err error) {
+	var problems queryProblems
+	page, size = readPagination(ctx, &problems)
+	return page, size, problems.err()
+}
+
+// readPagination reads the page and size parameters, applying the defaults for missing ones.
+func readPagination(ctx *gin.Context, problems *queryProblems) (page, size int) {
+	page, size = defaultPage, defaultSize
+	if v, ok := ctx.GetQuery("page"); ok {
+		var err error
+		if page, err = strconv.Atoi(v); err != nil || page < 1 {
+			problems.add(errors.CodeInvalidPagination, "page must be a positive integer")
+		}
+	}
+	if v, ok := ctx.GetQuery("size"); ok {
+		var err error
+		if size, err = strconv.Atoi(v); err != nil || size < 1 {
+			problems.add(errors.CodeInvalidPagination, "size must be a positive integer")
+		}
+	}
+	return page, size
+}
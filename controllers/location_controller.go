@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocationController handles HTTP requests for the offices employees work from.
+type LocationController struct {
+	Service *services.LocationService
+}
+
+// NewLocationController creates a new LocationController.
+func NewLocationController(s *services.LocationService) *LocationController {
+	return &LocationController{
+		Service: s,
+	}
+}
+
+// CreateLocationHandler handles POST /locations
+// @Summary Create a location
+// @Description Creates an office employees can then be assigned to, with its address and IANA time zone.
+// @Tags locations
+// @Accept json,xml
+// @Produce json,xml
+// @Param location body models.Location true "Location"
+// @Success 201 {object} models.Location
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "A location with this name already exists"
+// @Router /locations [post]
+func (c *LocationController) CreateLocationHandler(ctx *gin.Context) {
+	var location models.Location
+	if err := negotiate.Bind(ctx, &location); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	created, err := c.Service.CreateLocation(cx, location)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, created)
+}
+
+// ListLocationsHandler handles GET /locations?page={page}&size={size}
+// @Summary List locations
+// @Description Returns a page of the offices ordered by name.
+// @Tags locations
+// @Produce json,xml,application/msgpack
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.Location
+// @Failure 400 {object} models.ErrorResponse
+// @Router /locations [get]
+func (c *LocationController) ListLocationsHandler(ctx *gin.Context) {
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	locations, err := c.Service.ListLocations(cx, page, size)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, locations)
+}
+
+// GetLocationHandler handles GET /locations/{name}
+// @Summary Get a location
+// @Tags locations
+// @Produce json,xml,application/msgpack
+// @Param name path string true "Location name"
+// @Success 200 {object} models.Location
+// @Failure 404 {object} models.ErrorResponse
+// @Router /locations/{name} [get]
+func (c *LocationController) GetLocationHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	location, err := c.Service.GetLocation(cx, ctx.Param("name"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, location)
+}
+
+// UpdateLocationHandler handles PUT /locations/{name}
+// @Summary Update a location
+// @Description Replaces the address and time zone of the office. The name cannot be changed, since employees reference it.
+// @Tags locations
+// @Accept json,xml
+// @Produce json,xml
+// @Param name path string true "Location name"
+// @Param location body models.Location true "Location"
+// @Success 200 {object} models.Location
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /locations/{name} [put]
+func (c *LocationController) UpdateLocationHandler(ctx *gin.Context) {
+	var location models.Location
+	if err := negotiate.Bind(ctx, &location); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	updated, err := c.Service.UpdateLocation(cx, ctx.Param("name"), location)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, updated)
+}
+
+// DeleteLocationHandler handles DELETE /locations/{name}
+// @Summary Delete a location
+// @Description Removes an office no employee is assigned to.
+// @Tags locations
+// @Produce json,xml
+// @Param name path string true "Location name"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "Employees are still assigned to the location"
+// @Router /locations/{name} [delete]
+func (c *LocationController) DeleteLocationHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Service.DeleteLocation(cx, ctx.Param("name")); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Location deleted"})
+}
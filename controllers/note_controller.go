@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoteController handles HTTP requests for the HR notes attached to employees.
+type NoteController struct {
+	Service *services.NoteService
+}
+
+// NewNoteController creates a new NoteController.
+func NewNoteController(s *services.NoteService) *NoteController {
+	return &NoteController{
+		Service: s,
+	}
+}
+
+// AddNoteHandler handles POST /employees/{employeeEmail}/notes
+// @Summary Add a note to an employee
+// @Description Attaches a free-form HR note, recording the signed-in caller as its author and the time it was
+// written. Only callers holding an HR or admin role may call it.
+// @Tags notes
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param note body models.NoteRequest true "Text"
+// @Success 201 {object} models.Note
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/notes [post]
+func (c *NoteController) AddNoteHandler(ctx *gin.Context) {
+	employeeEmail := ctx.Param("employeeEmail")
+	var req models.NoteRequest
+	if err := negotiate.Bind(ctx, &req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	note, err := c.Service.AddNote(cx, employeeEmail, ctx.GetString(middleware.CallerKey), req, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, note)
+}
+
+// ListNotesHandler handles GET /employees/{employeeEmail}/notes?page={page}&size={size}
+// @Summary List an employee's notes
+// @Description Returns a page of the notes attached to the employee, newest first. Only callers holding an HR or
+// admin role may call it.
+// @Tags notes
+// @Produce json,xml,application/msgpack
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.Note
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/notes [get]
+func (c *NoteController) ListNotesHandler(ctx *gin.Context) {
+	employeeEmail := ctx.Param("employeeEmail")
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	notes, err := c.Service.ListNotes(cx, employeeEmail, page, size)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, notes)
+}
+
+// DeleteNoteHandler handles DELETE /employees/{employeeEmail}/notes/{noteId}
+// @Summary Delete a note
+// @Description Removes one of the notes attached to the employee. Only callers holding an HR or admin role may call it.
+// @Tags notes
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param noteId path string true "Note identifier"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/notes/{noteId} [delete]
+func (c *NoteController) DeleteNoteHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Service.DeleteNote(cx, ctx.Param("employeeEmail"), ctx.Param("noteId")); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Note deleted"})
+}
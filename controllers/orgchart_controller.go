@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrgChartController handles HTTP requests for organization snapshots.
+type OrgChartController struct {
+	Service *services.OrgChartService
+}
+
+// NewOrgChartController creates a new OrgChartController.
+func NewOrgChartController(s *services.OrgChartService) *OrgChartController {
+	return &OrgChartController{
+		Service: s,
+	}
+}
+
+// CreateSnapshotHandler handles POST /orgchart/snapshots
+// @Summary Capture an organization snapshot
+// @Description Stores the current reporting structure (every employee and their manager) so it can be compared later.
+// @Tags orgchart
+// @Accept json,xml
+// @Produce json,xml
+// @Param snapshot body models.OrgSnapshotRequest false "Snapshot label"
+// @Success 201 {object} models.OrgSnapshot
+// @Failure 400 {object} models.ErrorResponse
+// @Router /orgchart/snapshots [post]
+func (c *OrgChartController) CreateSnapshotHandler(ctx *gin.Context) {
+	var req models.OrgSnapshotRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := negotiate.Bind(ctx, &req); err != nil {
+			respondBindError(ctx, err)
+			return
+		}
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	snapshot, err := c.Service.CreateSnapshot(cx, req.Label)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, snapshot)
+}
+
+// GetSnapshotHandler handles GET /orgchart/snapshots/{snapshotId}
+// @Summary Get an organization snapshot
+// @Description Returns a stored snapshot, or the live organization for "current". The response can be edited
+// and uploaded to /orgchart/diff to preview a reorg.
+// @Tags orgchart
+// @Produce json,xml
+// @Param snapshotId path string true "Snapshot identifier or current"
+// @Success 200 {object} models.OrgSnapshot
+// @Failure 404 {object} models.ErrorResponse
+// @Router /orgchart/snapshots/{snapshotId} [get]
+func (c *OrgChartController) GetSnapshotHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	snapshot, err := c.Service.GetSnapshot(cx, ctx.Param("snapshotId"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, snapshot)
+}
+
+// DiffHandler handles POST /orgchart/diff
+// @Summary Compare two organization snapshots
+// @Description Returns the structural changes between two snapshots: added and removed employees, employees
+// moved to another manager, and employees who became managers. The second snapshot is either an identifier
+// ("current" for the live organization) or an uploaded snapshot, e.g. a reorg import to review before applying it.
+// Snapshot files can also be sent as multipart/form-data with a "from" field and a "snapshot" file.
+// @Tags orgchart
+// @Accept json,xml,mpfd
+// @Produce json,xml
+// @Param diff body models.OrgDiffRequest true "Snapshots to compare"
+// @Success 200 {object} models.OrgDiff
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /orgchart/diff [post]
+func (c *OrgChartController) DiffHandler(ctx *gin.Context) {
+	var req models.OrgDiffRequest
+	if ctx.ContentType() == "multipart/form-data" {
+		if err := bindSnapshotFile(ctx, &req); err != nil {
+			var tooLarge *http.MaxBytesError
+			if stderrors.As(err, &tooLarge) {
+				respondBindError(ctx, err)
+				return
+			}
+			respondError(ctx, http.StatusBadRequest, errors.CodeSnapshotInvalid, "Invalid snapshot file")
+			return
+		}
+	} else if err := negotiate.Bind(ctx, &req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	diff, err := c.Service.Diff(cx, req)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, diff)
+}
+
+// bindSnapshotFile reads a diff request sent as a form with a "from" field and a "snapshot" JSON file.
+func bindSnapshotFile(ctx *gin.Context, req *models.OrgDiffRequest) error {
+	req.From = ctx.PostForm("from")
+	header, err := ctx.FormFile("snapshot")
+	if err != nil {
+		return err
+	}
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var snapshot models.OrgSnapshot
+	if err := json.NewDecoder(file).Decode(&snapshot); err != nil {
+		return err
+	}
+	req.Snapshot = &snapshot
+	return nil
+}
+
+// exportContentTypes maps the export formats to the media type of the rendered graph.
+var exportContentTypes = map[string]string{
+	services.ExportFormatDOT:     "text/vnd.graphviz; charset=utf-8",
+	services.ExportFormatMermaid: "text/plain; charset=utf-8",
+}
+
+// ExportHandler handles GET /orgchart/export?format={format}&snapshot={snapshotId}
+// @Summary Export the org chart as a diagram
+// @Description Renders the reporting graph as Graphviz DOT or Mermaid text, with an edge from each manager to each
+// of their reports, for diagrams generated in CI or documentation pipelines.
+// @Tags orgchart
+// @Produce text/vnd.graphviz,plain
+// @Param format query string false "Diagram language" Enums(dot, mermaid) default(dot)
+// @Param snapshot query string false "Snapshot identifier, or current for the live organization" default(current)
+// @Success 200 {string} string "The diagram source"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /orgchart/export [get]
+func (c *OrgChartController) ExportHandler(ctx *gin.Context) {
+	format := ctx.DefaultQuery("format", services.ExportFormatDOT)
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "format must be dot or mermaid")
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	diagram, err := c.Service.Export(cx, ctx.DefaultQuery("snapshot", models.CurrentSnapshotID), format)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	ctx.Data(http.StatusOK, contentType, []byte(diagram))
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PhotoController handles HTTP requests for employee profile photos.
+type PhotoController struct {
+	Service *services.PhotoService
+}
+
+// NewPhotoController creates a new PhotoController.
+func NewPhotoController(s *services.PhotoService) *PhotoController {
+	return &PhotoController{
+		Service: s,
+	}
+}
+
+// SetPhotoHandler handles PUT /employees/{employeeEmail}/photo
+// @Summary Upload an employee's photo
+// @Description Stores the request body as the employee's profile photo, replacing the previous one, and generates
+// a thumbnail of at most 128x128 pixels. JPEG, PNG and GIF images of up to 1 MiB and 4096x4096 pixels are accepted;
+// the type is detected from the content.
+// @Tags employees
+// @Accept image/jpeg,image/png,image/gif
+// @Produce json,xml
+// @Param employeeEmail path string true "Employee email"
+// @Param photo body string true "Image data"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 413 {object} models.ErrorResponse
+// @Failure 415 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/photo [put]
+func (c *PhotoController) SetPhotoHandler(ctx *gin.Context) {
+	employeeEmail := ctx.Param("employeeEmail")
+	// Read one byte past the limit so oversized photos are detected without buffering them whole.
+	data, err := io.ReadAll(io.LimitReader(ctx.Request.Body, services.MaxPhotoBytes+1))
+	if err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Service.SetPhoto(cx, employeeEmail, data); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Photo updated"})
+}
+
+// GetPhotoHandler handles GET /employees/{employeeEmail}/photo?variant={variant}
+// @Summary Download an employee's photo
+// @Description Returns the employee's profile photo, or its thumbnail with variant=thumbnail.
+// @Tags employees
+// @Produce image/jpeg,image/png,image/gif
+// @Param employeeEmail path string true "Employee email"
+// @Param variant query string false "Photo variant" Enums(original,thumbnail) default(original)
+// @Param If-None-Match header string false "Entity tag from a previous response"
+// @Success 200 {file} file "Image data"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/photo [get]
+func (c *PhotoController) GetPhotoHandler(ctx *gin.Context) {
+	employeeEmail := ctx.Param("employeeEmail")
+	variant := ctx.DefaultQuery("variant", models.PhotoOriginal)
+	if variant != models.PhotoOriginal && variant != models.PhotoThumbnail {
+		respondError(ctx, http.StatusBadRequest, errors.CodeInvalidQuery, "variant must be original or thumbnail")
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	photo, err := c.Service.GetPhoto(cx, employeeEmail, variant)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	// Every upload stores a new revision, so its identifier is a strong entity tag.
+	etag := `"` + photo.ID + `"`
+	ctx.Header("ETag", etag)
+	ctx.Header("Last-Modified", photo.UploadedAt.UTC().Format(http.TimeFormat))
+	if etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, photo.ContentType, photo.Data)
+}
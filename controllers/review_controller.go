@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewController handles HTTP requests for the performance reviews of employees.
+type ReviewController struct {
+	Service *services.ReviewService
+}
+
+// NewReviewController creates a new ReviewController.
+func NewReviewController(s *services.ReviewService) *ReviewController {
+	return &ReviewController{
+		Service: s,
+	}
+}
+
+// AddReviewHandler handles POST /employees/{employeeEmail}/reviews
+// @Summary Review an employee
+// @Description Records a performance review of the employee for a period. Only the employee's manager may call it,
+// and an employee has at most one review per period.
+// @Tags reviews
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param review body models.ReviewRequest true "Period, rating and comments"
+// @Success 201 {object} models.Review
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse "The caller is not the employee's manager"
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The employee already has a review for the period"
+// @Router /employees/{employeeEmail}/reviews [post]
+func (c *ReviewController) AddReviewHandler(ctx *gin.Context) {
+	var req models.ReviewRequest
+	if err := negotiate.Bind(ctx, &req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	review, err := c.Service.AddReview(cx, ctx.Param("employeeEmail"), ctx.GetString(middleware.CallerKey), req, time.Now().UTC())
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, review)
+}
+
+// ListReviewsHandler handles GET /employees/{employeeEmail}/reviews?page={page}&size={size}
+// @Summary List an employee's reviews
+// @Description Returns a page of the employee's performance reviews, latest period first. Reviews are visible to
+// the employee, to their manager and to callers holding one of the review reader roles.
+// @Tags reviews
+// @Produce json,xml,application/msgpack
+// @Security BasicAuth
+// @Param employeeEmail path string true "Employee email"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.Review
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse "The caller may not read the reviews"
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/reviews [get]
+func (c *ReviewController) ListReviewsHandler(ctx *gin.Context) {
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	roles := ctx.GetStringSlice(middleware.CallerRolesKey)
+	reviews, err := c.Service.ListReviews(cx, ctx.Param("employeeEmail"), ctx.GetString(middleware.CallerKey), roles, page, size)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, reviews)
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleController handles HTTP requests for the roles catalog.
+type RoleController struct {
+	Service *services.RoleService
+}
+
+// NewRoleController creates a new RoleController.
+func NewRoleController(s *services.RoleService) *RoleController {
+	return &RoleController{
+		Service: s,
+	}
+}
+
+// CreateRoleHandler handles POST /roles
+// @Summary Create a role
+// @Description Adds a role, with the permissions it grants, to the catalog clients offer when assigning roles.
+// A parent role is implied by the new role: employees holding it also hold the parent and its permissions.
+// Only admins may call it.
+// @Tags roles
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param role body models.Role true "Role"
+// @Success 201 {object} models.Role
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "A role with this name already exists, or the role would imply itself"
+// @Router /roles [post]
+func (c *RoleController) CreateRoleHandler(ctx *gin.Context) {
+	var role models.Role
+	if err := negotiate.Bind(ctx, &role); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	created, err := c.Service.CreateRole(cx, role)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, created)
+}
+
+// ListRolesHandler handles GET /roles?page={page}&size={size}
+// @Summary List roles
+// @Description Returns a page of the roles ordered by name, e.g. to fill a role picker.
+// @Tags roles
+// @Produce json,xml,application/msgpack
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.Role
+// @Failure 400 {object} models.ErrorResponse
+// @Router /roles [get]
+func (c *RoleController) ListRolesHandler(ctx *gin.Context) {
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	roles, err := c.Service.ListRoles(cx, page, size)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, roles)
+}
+
+// GetRoleHandler handles GET /roles/{name}
+// @Summary Get a role
+// @Tags roles
+// @Produce json,xml,application/msgpack
+// @Param name path string true "Role name"
+// @Success 200 {object} models.Role
+// @Failure 404 {object} models.ErrorResponse
+// @Router /roles/{name} [get]
+func (c *RoleController) GetRoleHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	role, err := c.Service.GetRole(cx, ctx.Param("name"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, role)
+}
+
+// UpdateRoleHandler handles PUT /roles/{name}
+// @Summary Update a role
+// @Description Replaces the description, parent and permissions of the role. The name cannot be changed, since employees hold it.
+// Only admins may call it.
+// @Tags roles
+// @Accept json,xml
+// @Produce json,xml
+// @Security BasicAuth
+// @Param name path string true "Role name"
+// @Param role body models.Role true "Role"
+// @Success 200 {object} models.Role
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "The role would imply itself"
+// @Router /roles/{name} [put]
+func (c *RoleController) UpdateRoleHandler(ctx *gin.Context) {
+	var role models.Role
+	if err := negotiate.Bind(ctx, &role); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	updated, err := c.Service.UpdateRole(cx, ctx.Param("name"), role)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, updated)
+}
+
+// DeleteRoleHandler handles DELETE /roles/{name}
+// @Summary Delete a role
+// @Description Removes a role from the catalog. Employees holding the role keep it; a role that is the parent of others is kept.
+// Only admins may call it.
+// @Tags roles
+// @Produce json,xml
+// @Security BasicAuth
+// @Param name path string true "Role name"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "Other roles have the role as parent"
+// @Router /roles/{name} [delete]
+func (c *RoleController) DeleteRoleHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Service.DeleteRole(cx, ctx.Param("name")); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Role deleted"})
+}
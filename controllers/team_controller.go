@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TeamController handles HTTP requests for teams and their membership.
+type TeamController struct {
+	Service *services.TeamService
+}
+
+// NewTeamController creates a new TeamController.
+func NewTeamController(s *services.TeamService) *TeamController {
+	return &TeamController{
+		Service: s,
+	}
+}
+
+// CreateTeamHandler handles POST /teams
+// @Summary Create a team
+// @Description Creates a team led by an existing employee, optionally with initial members who must be employees as well.
+// @Tags teams
+// @Accept json,xml
+// @Produce json,xml
+// @Param team body models.TeamRequest true "Name, lead and members"
+// @Success 201 {object} models.Team
+// @Failure 400 {object} models.ErrorResponse
+// @Router /teams [post]
+func (c *TeamController) CreateTeamHandler(ctx *gin.Context) {
+	var req models.TeamRequest
+	if err := negotiate.Bind(ctx, &req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	team, err := c.Service.CreateTeam(cx, req)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, team)
+}
+
+// GetTeamHandler handles GET /teams/{teamId}
+// @Summary Get a team
+// @Tags teams
+// @Produce json,xml,application/msgpack
+// @Param teamId path string true "Team identifier"
+// @Success 200 {object} models.Team
+// @Failure 404 {object} models.ErrorResponse
+// @Router /teams/{teamId} [get]
+func (c *TeamController) GetTeamHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	team, err := c.Service.GetTeam(cx, ctx.Param("teamId"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, team)
+}
+
+// DeleteTeamHandler handles DELETE /teams/{teamId}
+// @Summary Delete a team
+// @Description Removes the team; its lead and members are not affected.
+// @Tags teams
+// @Produce json,xml
+// @Param teamId path string true "Team identifier"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 404 {object} models.ErrorResponse
+// @Router /teams/{teamId} [delete]
+func (c *TeamController) DeleteTeamHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Service.DeleteTeam(cx, ctx.Param("teamId")); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Team deleted"})
+}
+
+// AddMemberHandler handles PUT /teams/{teamId}/members/{employeeEmail}
+// @Summary Add a member to a team
+// @Description Adds an existing employee to the team. Adding a member again has no effect.
+// @Tags teams
+// @Produce json,xml
+// @Param teamId path string true "Team identifier"
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {object} models.Team
+// @Failure 404 {object} models.ErrorResponse "The team or the employee does not exist"
+// @Router /teams/{teamId}/members/{employeeEmail} [put]
+func (c *TeamController) AddMemberHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	team, err := c.Service.AddMember(cx, ctx.Param("teamId"), ctx.Param("employeeEmail"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, team)
+}
+
+// RemoveMemberHandler handles DELETE /teams/{teamId}/members/{employeeEmail}
+// @Summary Remove a member from a team
+// @Tags teams
+// @Produce json,xml
+// @Param teamId path string true "Team identifier"
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {object} models.Team
+// @Failure 404 {object} models.ErrorResponse "The team does not exist or the employee is not a member"
+// @Router /teams/{teamId}/members/{employeeEmail} [delete]
+func (c *TeamController) RemoveMemberHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	team, err := c.Service.RemoveMember(cx, ctx.Param("teamId"), ctx.Param("employeeEmail"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, team)
+}
+
+// ListEmployeeTeamsHandler handles GET /employees/{employeeEmail}/teams
+// @Summary List the teams of an employee
+// @Description Returns the teams the employee leads or is a member of, ordered by name.
+// @Tags teams
+// @Produce json,xml,application/msgpack
+// @Param employeeEmail path string true "Employee email"
+// @Success 200 {array} models.Team
+// @Failure 404 {object} models.ErrorResponse
+// @Router /employees/{employeeEmail}/teams [get]
+func (c *TeamController) ListEmployeeTeamsHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	teams, err := c.Service.GetEmployeeTeams(cx, ctx.Param("employeeEmail"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, teams)
+}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+	"WebMVCEmployees/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TitleController handles HTTP requests for the catalog of job titles employees hold.
+type TitleController struct {
+	Service *services.TitleService
+}
+
+// NewTitleController creates a new TitleController.
+func NewTitleController(s *services.TitleService) *TitleController {
+	return &TitleController{
+		Service: s,
+	}
+}
+
+// CreateTitleHandler handles POST /titles
+// @Summary Create a job title
+// @Description Creates a job title employees can then hold, optionally restricted to a list of grades.
+// @Tags titles
+// @Accept json,xml
+// @Produce json,xml
+// @Param title body models.JobTitle true "Job title"
+// @Success 201 {object} models.JobTitle
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "A title with this name already exists"
+// @Router /titles [post]
+func (c *TitleController) CreateTitleHandler(ctx *gin.Context) {
+	var title models.JobTitle
+	if err := negotiate.Bind(ctx, &title); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	created, err := c.Service.CreateTitle(cx, title)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusCreated, created)
+}
+
+// ListTitlesHandler handles GET /titles?page={page}&size={size}
+// @Summary List job titles
+// @Description Returns a page of the job titles ordered by name.
+// @Tags titles
+// @Produce json,xml,application/msgpack
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Success 200 {array} models.JobTitle
+// @Failure 400 {object} models.ErrorResponse
+// @Router /titles [get]
+func (c *TitleController) ListTitlesHandler(ctx *gin.Context) {
+	page, size, err := bindPagination(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	titles, err := c.Service.ListTitles(cx, page, size)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.RenderList(ctx, http.StatusOK, titles)
+}
+
+// GetTitleHandler handles GET /titles/{name}
+// @Summary Get a job title
+// @Tags titles
+// @Produce json,xml,application/msgpack
+// @Param name path string true "Job title"
+// @Success 200 {object} models.JobTitle
+// @Failure 404 {object} models.ErrorResponse
+// @Router /titles/{name} [get]
+func (c *TitleController) GetTitleHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	title, err := c.Service.GetTitle(cx, ctx.Param("name"))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, title)
+}
+
+// UpdateTitleHandler handles PUT /titles/{name}
+// @Summary Update a job title
+// @Description Replaces the description and grades of the job title. The name cannot be changed, since employees reference it.
+// @Tags titles
+// @Accept json,xml
+// @Produce json,xml
+// @Param name path string true "Job title"
+// @Param title body models.JobTitle true "Job title"
+// @Success 200 {object} models.JobTitle
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /titles/{name} [put]
+func (c *TitleController) UpdateTitleHandler(ctx *gin.Context) {
+	var title models.JobTitle
+	if err := negotiate.Bind(ctx, &title); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	updated, err := c.Service.UpdateTitle(cx, ctx.Param("name"), title)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, updated)
+}
+
+// DeleteTitleHandler handles DELETE /titles/{name}
+// @Summary Delete a job title
+// @Description Removes a job title that no employee holds.
+// @Tags titles
+// @Produce json,xml
+// @Param name path string true "Job title"
+// @Success 200 {object} map[string]string "Success message"
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse "Employees still hold the title"
+// @Router /titles/{name} [delete]
+func (c *TitleController) DeleteTitleHandler(ctx *gin.Context) {
+	cx, cancel := context.WithTimeout(ctx.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Service.DeleteTitle(cx, ctx.Param("name")); err != nil {
+		handleError(ctx, err)
+		return
+	}
+	negotiate.Render(ctx, http.StatusOK, gin.H{"message": "Title deleted"})
+}
@@ -4,4 +4,5 @@
 // @description This is a sample server for managing employees.
 // @host localhost:8080
 // @BasePath /
+// @securityDefinitions.basic BasicAuth
 package docs
\ No newline at end of file
@@ -15,27 +15,28 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/employees": {
+        "/admin/audit": {
             "get": {
-                "description": "Returns a paginated list of employees. When the \"criteria\" query parameter is provided,",
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns recorded changes, newest first, optionally for a single employee.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
                 ],
                 "tags": [
-                    "employees"
+                    "admin"
                 ],
-                "summary": "List employees with filtering",
+                "summary": "List the audit log",
                 "parameters": [
                     {
-                        "enum": [
-                            "byEmailDomain",
-                            "byRole",
-                            "byAge"
-                        ],
                         "type": "string",
-                        "default": "",
-                        "description": "Filter criteria. Allowed values: byEmailDomain,byRole,byAge. If set to 'none' or omitted, all employees are returned",
-                        "name": "criteria",
+                        "description": "Employee email",
+                        "name": "employee",
                         "in": "query"
                     },
                     {
@@ -59,7 +60,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.EmployeeResponse"
+                                "$ref": "#/definitions/models.AuditEntry"
                             }
                         }
                     },
@@ -68,60 +69,60 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "Accepts employee details in JSON, validates and stores the employee.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "employees"
-                ],
-                "summary": "Create a new employee",
-                "parameters": [
-                    {
-                        "description": "Employee details",
-                        "name": "employee",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/models.Employee"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/models.EmployeeResponse"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes all employee records from the service.",
+            }
+        },
+        "/admin/clients": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Lists the TypeScript and Java client bundles produced by ` + "`" + `make clients` + "`" + `.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "tags": [
-                    "employees"
+                    "admin"
                 ],
-                "summary": "Delete all employees",
+                "summary": "List generated API client bundles",
                 "responses": {
                     "200": {
-                        "description": "Success message",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/WebMVCEmployees_clients.Bundle"
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -131,70 +132,133 @@ const docTemplate = `{
                 }
             }
         },
-        "/employees/{employeeEmail}": {
+        "/admin/clients/{language}": {
             "get": {
-                "description": "Returns employee details if the provided email and password match a record.",
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Downloads the zipped client bundle for a language (e.g. typescript, java).",
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
-                    "employees"
+                    "admin"
                 ],
-                "summary": "Get an employee by email and password",
+                "summary": "Download a generated API client bundle",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Employee email",
-                        "name": "employeeEmail",
+                        "description": "Client language",
+                        "name": "language",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Employee password",
-                        "name": "password",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.EmployeeResponse"
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/employees/{employeeEmail}/manager": {
+        "/admin/inflight": {
             "get": {
-                "description": "Returns the manager details (excluding password) for the specified employee.",
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the HTTP requests in progress per route and the MongoDB commands awaiting a reply.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "tags": [
-                    "employees"
+                    "admin"
                 ],
-                "summary": "Get manager of an employee",
-                "parameters": [
+                "summary": "Report work in progress",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WebMVCEmployees_inflight.Report"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/loglevel": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Employee email",
-                        "name": "employeeEmail",
-                        "in": "path",
-                        "required": true
+                        "BasicAuth": []
                     }
                 ],
+                "description": "Reports the lowest level the instance logs: debug, info, warn or error.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Report the log level",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.EmployeeResponse"
+                            "$ref": "#/definitions/models.LogLevel"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -202,43 +266,40 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Associates an employee with a manager using ManagerEmailBoundary JSON.",
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Changes the lowest level the instance logs, such as to debug during an incident, without a restart.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "tags": [
-                    "employees"
+                    "admin"
                 ],
-                "summary": "Set manager for an employee",
+                "summary": "Change the log level",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Employee email",
-                        "name": "employeeEmail",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Manager email",
-                        "name": "manager",
+                        "description": "Log level",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ManagerEmailBoundary"
+                            "$ref": "#/definitions/models.LogLevel"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success message",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.LogLevel"
                         }
                     },
                     "400": {
@@ -247,92 +308,93 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Unsets the manager for the specified employee.",
+            }
+        },
+        "/admin/read-only": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Reports whether the instance rejects mutations, why, and since when.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "tags": [
-                    "employees"
-                ],
-                "summary": "Remove manager association from an employee",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Employee email",
-                        "name": "employeeEmail",
-                        "in": "path",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "Report the read-only mode",
                 "responses": {
                     "200": {
-                        "description": "Success message",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/WebMVCEmployees_readonly.State"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/managers/{managerEmail}/subordinates": {
-            "get": {
-                "description": "Returns a paginated list of employees managed by the specified manager.",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "While the mode is on, the instance serves reads but rejects every mutation with 503 and READ_ONLY_MODE.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "tags": [
-                    "employees"
+                    "admin"
                 ],
-                "summary": "Get subordinates for a manager",
+                "summary": "Switch the read-only mode on or off",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Manager email",
-                        "name": "managerEmail",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "size",
-                        "in": "query"
+                        "description": "Read-only mode",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReadOnlyModeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.EmployeeResponse"
-                            }
+                            "$ref": "#/definitions/readonly.State"
                         }
                     },
                     "400": {
@@ -340,66 +402,7485 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
             }
-        }
-    },
+        },
+        "/admin/slo": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns every route objective with its compliance and error budget burn rates over the 5m and 1h windows.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Report per-route SLO compliance",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/WebMVCEmployees_slo.RouteStatus"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/certifications/expiring": {
+            "get": {
+                "description": "Returns a page of the certifications of all employees that expire within the given time, soonest first.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "certifications"
+                ],
+                "summary": "List expiring certifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "90d",
+                        "description": "How far ahead to look, in days (90d) or as a duration (12h)",
+                        "name": "within",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Certification"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/departments": {
+            "get": {
+                "description": "Returns a page of the departments ordered by name.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "departments"
+                ],
+                "summary": "List departments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Department"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a department employees can then reference by name in their department field.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "departments"
+                ],
+                "summary": "Create a department",
+                "parameters": [
+                    {
+                        "description": "Department",
+                        "name": "department",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Department"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Department"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A department with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/departments/{name}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "departments"
+                ],
+                "summary": "Get a department",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Department"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the description of the department. The name cannot be changed, since employees reference it.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "departments"
+                ],
+                "summary": "Update a department",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Department",
+                        "name": "department",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Department"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Department"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a department that no employee belongs to.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "departments"
+                ],
+                "summary": "Delete a department",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Employees still belong to the department",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{documentId}/download": {
+            "get": {
+                "description": "Returns the content of a document through a signed link from the upload or listing responses.",
+                "produces": [
+                    "application/pdf",
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Download a document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document ID",
+                        "name": "documentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry of the link, in seconds since the Unix epoch",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature of the link",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File data",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "The link is invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees": {
+            "get": {
+                "description": "Returns a paginated list of employees. When the \"criteria\" query parameter is provided,",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "List employees with filtering",
+                "parameters": [
+                    {
+                        "enum": [
+                            "byEmailDomain",
+                            "byRole",
+                            "byAge",
+                            "byCountry",
+                            "byCity",
+                            "byDepartment",
+                            "byStatus",
+                            "byTenure",
+                            "byTitle",
+                            "byGrade",
+                            "bySkill",
+                            "byLocation",
+                            "byCostCenter"
+                        ],
+                        "type": "string",
+                        "default": "",
+                        "description": "Filter criteria. Allowed values: byEmailDomain,byRole,byAge,byCountry,byCity,byDepartment,byStatus,byTenure,byTitle,byGrade,bySkill,byLocation,byCostCenter. If set to 'none' or omitted, all employees are returned",
+                        "name": "criteria",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Argument of the criteria: the email domain, the role, the age in years, the ISO country code, the city, the department name, the status (active, on_leave or terminated), the job title, the grade, the skill name, the location name or the cost center",
+                        "name": "value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Fewest full years since the hire date, for byTenure",
+                        "name": "min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Most full years since the hire date, for byTenure",
+                        "name": "max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Lowest proficiency from 1 to 5, for bySkill",
+                        "name": "minLevel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "email",
+                        "description": "Sort key (email, name or seniority); prefix with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "manager"
+                        ],
+                        "type": "string",
+                        "description": "Related resources to embed in full: manager",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EmployeeResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Accepts employee details in JSON, validates and stores the employee. Only admins may create an employee",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Create a new employee",
+                "parameters": [
+                    {
+                        "description": "Employee details",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NewEmployeeBoundary"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key identifying retries of the same request; the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created, when the server runs with CREATE_RETURNS_CREATED=true",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new employee"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "The employee has roles and the caller is not signed in",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The employee has roles and the caller is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The manager does not hold a manager role",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Soft-deletes every employee; each one can be brought back with POST /employees/{employeeEmail}/restore.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Delete all employees",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Remove the records instead of marking them deleted",
+                        "name": "hard",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "hard=true and the caller is not signed in",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "hard=true and the caller is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Creates every employee in the list and reports the outcome of each item.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Create employees in bulk",
+                "parameters": [
+                    {
+                        "description": "Employees to create",
+                        "name": "employees",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.NewEmployeeBoundary"
+                            }
+                        }
+                    },
+                    {
+                        "enum": [
+                            "continue",
+                            "abort"
+                        ],
+                        "type": "string",
+                        "default": "continue",
+                        "description": "Error handling mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key identifying retries of the same request; the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "All items succeeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Some items failed or were skipped",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "An employee has roles and the caller is not signed in",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "An employee has roles and the caller is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/by-id/{id}": {
+            "get": {
+                "description": "Returns the employee with the given internal ID. Unlike the email, the ID never changes,",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Get an employee by internal ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Internal employee ID, a ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/manager/bulk": {
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Sets the manager of each listed employee and reports the outcome of each item. Only admins may call it.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Assign managers in bulk",
+                "parameters": [
+                    {
+                        "description": "Manager assignments",
+                        "name": "assignments",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ManagerAssignment"
+                            }
+                        }
+                    },
+                    {
+                        "enum": [
+                            "continue",
+                            "abort"
+                        ],
+                        "type": "string",
+                        "default": "continue",
+                        "description": "Error handling mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key identifying retries of the same request; the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "All items succeeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Some items failed or were skipped",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/manager/reassign": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Moves every direct report of the manager from to the manager to in one transaction, e.g. during a reorg.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Move all reports of a manager to another manager",
+                "parameters": [
+                    {
+                        "description": "Current and new manager",
+                        "name": "reassignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ManagerReassignment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of reassigned employees",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "The current manager does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The reassignment would create a cycle",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The new manager does not hold a manager role",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/query": {
+            "post": {
+                "description": "Accepts a partial employee document and returns the employees matching all of its non-empty fields.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Search employees by example or fetch several by email",
+                "parameters": [
+                    {
+                        "description": "Partial employee used as the example, or the emails to fetch",
+                        "name": "example",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeQuery"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "email",
+                        "description": "Sort key (email, name or seniority); prefix with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "manager"
+                        ],
+                        "type": "string",
+                        "description": "Related resources to embed in full: manager",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EmployeeResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/roles/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Adds roles to each listed employee and reports the outcome of each item. Only admins may call it.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Assign roles in bulk",
+                "parameters": [
+                    {
+                        "description": "Role assignments",
+                        "name": "assignments",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RoleAssignment"
+                            }
+                        }
+                    },
+                    {
+                        "enum": [
+                            "continue",
+                            "abort"
+                        ],
+                        "type": "string",
+                        "default": "continue",
+                        "description": "Error handling mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key identifying retries of the same request; the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "All items succeeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Some items failed or were skipped",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/stats": {
+            "get": {
+                "description": "Returns the headcount of the employees on the payroll, the number of employees per role, per email",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Get workforce statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkforceStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/trash": {
+            "get": {
+                "description": "Returns a paginated list of the soft-deleted employees, with the time each one was deleted.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "List deleted employees",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "email",
+                        "description": "Sort key (email, name or seniority); prefix with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "manager"
+                        ],
+                        "type": "string",
+                        "description": "Related resources to embed in full: manager",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EmployeeResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/trash/purge": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Permanently removes the employees that were soft-deleted at least olderThan ago. Only admins may call it.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Purge deleted employees",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "30d",
+                        "description": "Minimum time in the trash, in days (30d) or as a duration (12h); 0 purges everything",
+                        "name": "olderThan",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of purged employees",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}": {
+            "get": {
+                "description": "Returns employee details if the provided email and password match a record.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Get an employee by email and password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Employee password",
+                        "name": "password",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "manager"
+                        ],
+                        "type": "string",
+                        "description": "Related resources to embed in full: manager",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Employee; a models.ExpandedEmployee with expand=manager",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Replaces the stored employee with the body. With upsert=true, an employee that does not exist yet is created,",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Replace an employee, or create it with upsert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Employee details; the email may be omitted",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NewEmployeeBoundary"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Create the employee when it does not exist",
+                        "name": "upsert",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ETag the replacement is conditional on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Replaced",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "The roles, the password or the manager change and the caller is not signed in",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The roles change and the caller is not an admin, the password changes and the caller is neither the employee nor an admin, or the manager changes and the caller is neither the current manager nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The manager would create a cycle",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The manager does not hold a manager role",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Soft-deletes the employee: it is hidden from every query until restored with POST /employees/{employeeEmail}/restore.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Delete an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Remove the record instead of marking it deleted",
+                        "name": "hard",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "block",
+                            "orphan",
+                            "reassign"
+                        ],
+                        "type": "string",
+                        "default": "block",
+                        "description": "What happens to the direct reports",
+                        "name": "reports",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "New manager of the direct reports; implies reports=reassign",
+                        "name": "reassignTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "hard=true and the caller is not signed in",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "hard=true and the caller is not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The employee has direct reports, or the reassignment would create a cycle",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The new manager of the reports does not hold a manager role",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/approver": {
+            "get": {
+                "description": "Returns who approves the employee's requests right now: their manager, or the manager's delegate",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Get the approver of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/attendance": {
+            "get": {
+                "description": "Returns the time the employee worked on each day of the day or week containing date. Records count",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "attendance"
+                ],
+                "summary": "Summarize an employee's attendance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Summary period; weeks start on Monday",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A day of the period, formatted as YYYY-MM-DD; defaults to today",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AttendanceSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/certifications": {
+            "get": {
+                "description": "Returns a page of the certifications of the employee: those that never expire, then the others by expiry day.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "certifications"
+                ],
+                "summary": "List an employee's certifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Certification"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Records a certification held by the employee, with the day it expires unless it never does.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "certifications"
+                ],
+                "summary": "Add a certification to an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name, issuer and expiry",
+                        "name": "certification",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CertificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Certification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/certifications/{certificationId}": {
+            "put": {
+                "description": "Replaces one of the certifications of the employee, typically with the expiry of its renewal.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "certifications"
+                ],
+                "summary": "Replace a certification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Certification identifier",
+                        "name": "certificationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name, issuer and expiry",
+                        "name": "certification",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CertificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Certification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes one of the certifications of the employee.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "certifications"
+                ],
+                "summary": "Delete a certification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Certification identifier",
+                        "name": "certificationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/checkin": {
+            "post": {
+                "description": "Opens an attendance record of the employee at the current time.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "attendance"
+                ],
+                "summary": "Check in",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.AttendanceRecord"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The employee is already checked in",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/checkout": {
+            "post": {
+                "description": "Closes the open attendance record of the employee at the current time.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "attendance"
+                ],
+                "summary": "Check out",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AttendanceRecord"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The employee is not checked in",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/compensation": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the salary in effect today and every salary recorded for the employee. Only callers holding",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "compensation"
+                ],
+                "summary": "Get the salary of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Compensation"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Records the salary of the employee from the effective date, replacing the salary recorded for the",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "compensation"
+                ],
+                "summary": "Set the salary of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Salary, currency and effective date",
+                        "name": "compensation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CompensationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Compensation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/delegation": {
+            "get": {
+                "description": "Returns the configured delegation, whether it is upcoming, active or expired.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Get a manager's delegation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manager email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Delegation"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Hands the manager's duties to a delegate between startsAt and endsAt. During that window the",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Delegate manager duties",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manager email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delegate and window",
+                        "name": "delegation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Delegation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Removes the delegation so duties revert to the manager immediately. Only the manager, signed in, and",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "End a manager's delegation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manager email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/documents": {
+            "get": {
+                "description": "Returns a page of the employee's documents, newest first, each with a fresh signed download link.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "List the documents of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Document"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Stores the request body as a document of the employee, such as a signed contract or a scanned ID.",
+                "consumes": [
+                    "application/pdf",
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "documents"
+                ],
+                "summary": "Upload a document of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File name, without slashes",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "contract",
+                            "id",
+                            "other"
+                        ],
+                        "type": "string",
+                        "default": "other",
+                        "description": "Document category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "description": "File data",
+                        "name": "document",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Document"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/email": {
+            "put": {
+                "description": "Moves the employee to a new email address, keeping their internal ID. Reports, dotted lines,",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Change the email address of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New email address",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EmailChange"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ETag the change is conditional on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the employee under the new email"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another employee has the new email",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/emergency-contacts": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the emergency contacts of the employee, most preferred first. Only the employee and callers",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "emergency-contacts"
+                ],
+                "summary": "Get the emergency contacts of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmergencyContacts"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The caller may not access the contacts",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Replaces the emergency contacts of the employee with up to 5 contacts. Only the employee and callers",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "emergency-contacts"
+                ],
+                "summary": "Replace the emergency contacts of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Emergency contacts",
+                        "name": "contacts",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EmergencyContactsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmergencyContacts"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The caller may not access the contacts",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/leave": {
+            "post": {
+                "description": "Moves an active employee to the on_leave status until they are reactivated.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Put an employee on leave",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The employee is not active",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/leave-balances/{year}": {
+            "get": {
+                "description": "Returns the allowance of the employee for the year with the days used, pending and remaining.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "leave"
+                ],
+                "summary": "Get an employee's leave balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Calendar year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeaveBalance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/leave-requests": {
+            "get": {
+                "description": "Returns a page of the leave requests of the employee, latest start date first.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "leave"
+                ],
+                "summary": "List an employee's leave requests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LeaveRequest"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Submits a request for time off by the signed-in employee. The request stays pending until the",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "leave"
+                ],
+                "summary": "Request time off",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dates and reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LeaveRequestBoundary"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeaveRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The caller is not the employee",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found or has no manager",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The dates overlap another request or exceed the balance",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/manager": {
+            "get": {
+                "description": "Returns the manager details (excluding password) for the specified employee.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Get manager of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Associates an employee with a manager using ManagerEmailBoundary JSON.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Set manager for an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Manager email",
+                        "name": "manager",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ManagerEmailBoundary"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ETag the update is conditional on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The manager would create a cycle",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The manager does not hold a manager role",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Unsets the manager for the specified employee. Only the current manager of the employee, signed in,",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Remove manager association from an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/managers": {
+            "get": {
+                "description": "Returns the managers above the employee, starting with their direct manager, then that manager's",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Get the manager chain of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Most managers to return; the whole chain when omitted",
+                        "name": "depth",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EmployeeResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/notes": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns a page of the notes attached to the employee, newest first. Only callers holding an HR or",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "List an employee's notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Note"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Attaches a free-form HR note, recording the signed-in caller as its author and the time it was",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Add a note to an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Text",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Note"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/notes/{noteId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Removes one of the notes attached to the employee. Only callers holding an HR or admin role may call it.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Delete a note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Note identifier",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/photo": {
+            "get": {
+                "description": "Returns the employee's profile photo, or its thumbnail with variant=thumbnail.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Download an employee's photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "original",
+                            "thumbnail"
+                        ],
+                        "type": "string",
+                        "default": "original",
+                        "description": "Photo variant",
+                        "name": "variant",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity tag from a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image data",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Stores the request body as the employee's profile photo, replacing the previous one, and generates",
+                "consumes": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Upload an employee's photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Image data",
+                        "name": "photo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/reactivate": {
+            "post": {
+                "description": "Moves an on-leave or terminated employee back to the active status.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Reactivate an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The employee is already active",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/reporting-lines": {
+            "get": {
+                "description": "Returns the primary reporting line, the same as the manager field, followed by the dotted lines.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "List the reporting lines of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ReportingLine"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Adds a dotted line to another manager, or with type primary replaces the manager like PUT /employees/{employeeEmail}/manager.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Add a reporting line to an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Manager and type of the line",
+                        "name": "line",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReportingLine"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ReportingLine"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The line duplicates the primary line, or would create a cycle",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The primary manager does not hold a manager role",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/reporting-lines/{type}/{managerEmail}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Removes a dotted line, or with type primary the manager of the employee. Returns the remaining reporting lines.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Remove a reporting line from an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "primary",
+                            "dotted"
+                        ],
+                        "type": "string",
+                        "description": "Type of the line",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Manager email",
+                        "name": "managerEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ReportingLine"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/reports/count": {
+            "get": {
+                "description": "Returns how many employees report to the manager directly, and how many report to them directly or",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Count the reports of a manager",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manager email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReportCounts"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/restore": {
+            "post": {
+                "description": "Brings back a soft-deleted employee with its roles, manager and delegation.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Restore a deleted employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Employee is not deleted",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns a page of the employee's performance reviews, latest period first. Reviews are visible to",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "List an employee's reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Review"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The caller may not read the reviews",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Records a performance review of the employee for a period. Only the employee's manager may call it,",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Review an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Period, rating and comments",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The caller is not the employee's manager",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The employee already has a review for the period",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/roles/effective": {
+            "get": {
+                "description": "Returns the roles the employee holds followed by the roles they imply through the parents in the",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Get the effective roles of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EffectiveRoles"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/roles/grants": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Grants a role until the given time, after which it is revoked automatically. Granting a role",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Grant a role temporarily",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role and expiry",
+                        "name": "grant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RoleGrantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/team/attendance": {
+            "get": {
+                "description": "Returns the attendance summaries of a page of the manager's direct reports, ordered by email.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "attendance"
+                ],
+                "summary": "Summarize a manager's team attendance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manager email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Summary period; weeks start on Monday",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A day of the period, formatted as YYYY-MM-DD; defaults to today",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AttendanceSummary"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/teams": {
+            "get": {
+                "description": "Returns the teams the employee leads or is a member of, ordered by name.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "List the teams of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Team"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeEmail}/terminate": {
+            "post": {
+                "description": "Moves an active or on-leave employee to the terminated status. Terminated employees stay readable",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Terminate an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EmployeeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The employee is already terminated",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Answers as long as the process serves requests, whatever the state of its dependencies, so that an",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Report that the process is up",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WebMVCEmployees_health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/leave-requests/{requestId}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "leave"
+                ],
+                "summary": "Get a leave request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leave request identifier",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeaveRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leave-requests/{requestId}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Approves a pending leave request, charging its days to the balance. Only the employee's approver,",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "leave"
+                ],
+                "summary": "Approve a leave request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leave request identifier",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional comment",
+                        "name": "decision",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeaveDecisionBoundary"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeaveRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The caller is not the approver",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The request is not pending",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leave-requests/{requestId}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Withdraws a pending leave request of the signed-in employee, releasing its days.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "leave"
+                ],
+                "summary": "Cancel a leave request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leave request identifier",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeaveRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The caller is not the employee",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The request is not pending",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leave-requests/{requestId}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Rejects a pending leave request, releasing its days. Only the employee's approver, their manager or",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "leave"
+                ],
+                "summary": "Reject a leave request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leave request identifier",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional comment",
+                        "name": "decision",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeaveDecisionBoundary"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LeaveRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The caller is not the approver",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The request is not pending",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations": {
+            "get": {
+                "description": "Returns a page of the offices ordered by name.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "List locations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Location"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an office employees can then be assigned to, with its address and IANA time zone.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Create a location",
+                "parameters": [
+                    {
+                        "description": "Location",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A location with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{name}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Get a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the address and time zone of the office. The name cannot be changed, since employees reference it.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Update a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes an office no employee is assigned to.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Delete a location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Location name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Employees are still assigned to the location",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/managers/{managerEmail}/subordinates": {
+            "get": {
+                "description": "Returns a paginated list of employees managed by the specified manager.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "employees"
+                ],
+                "summary": "Get subordinates for a manager",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manager email",
+                        "name": "managerEmail",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "email",
+                        "description": "Sort key (email, name or seniority); prefix with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "manager"
+                        ],
+                        "type": "string",
+                        "description": "Related resources to embed in full: manager",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include indirect reports, annotated with their depth",
+                        "name": "recursive",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "With recursive=true, the deepest level to include; every level when omitted",
+                        "name": "depth",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Employees; the depth is only set with recursive=true",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Subordinate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "With recursive=true, when the manager does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgchart/diff": {
+            "post": {
+                "description": "Returns the structural changes between two snapshots: added and removed employees, employees",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "orgchart"
+                ],
+                "summary": "Compare two organization snapshots",
+                "parameters": [
+                    {
+                        "description": "Snapshots to compare",
+                        "name": "diff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrgDiffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrgDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgchart/export": {
+            "get": {
+                "description": "Renders the reporting graph as Graphviz DOT or Mermaid text, with an edge from each manager to each",
+                "produces": [
+                    "text/vnd.graphviz",
+                    "text/plain"
+                ],
+                "tags": [
+                    "orgchart"
+                ],
+                "summary": "Export the org chart as a diagram",
+                "parameters": [
+                    {
+                        "enum": [
+                            "dot",
+                            "mermaid"
+                        ],
+                        "type": "string",
+                        "default": "dot",
+                        "description": "Diagram language",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "current",
+                        "description": "Snapshot identifier, or current for the live organization",
+                        "name": "snapshot",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The diagram source",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgchart/snapshots": {
+            "post": {
+                "description": "Stores the current reporting structure (every employee and their manager) so it can be compared later.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "orgchart"
+                ],
+                "summary": "Capture an organization snapshot",
+                "parameters": [
+                    {
+                        "description": "Snapshot label",
+                        "name": "snapshot",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrgSnapshotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrgSnapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgchart/snapshots/{snapshotId}": {
+            "get": {
+                "description": "Returns a stored snapshot, or the live organization for \"current\". The response can be edited",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "orgchart"
+                ],
+                "summary": "Get an organization snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Snapshot identifier or current",
+                        "name": "snapshotId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrgSnapshot"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks the dependencies the instance cannot serve without, each within READY_TIMEOUT: the database",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Report whether the instance is ready to serve",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/anniversaries": {
+            "get": {
+                "description": "Returns the employees whose hire-date anniversary falls in the month of the current year, with the",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "List work anniversaries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "06",
+                        "description": "Month, from 01 to 12; defaults to the current month",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Anniversary"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/headcount-by-costcenter": {
+            "get": {
+                "description": "Returns how many employees are charged to each cost center, ordered by cost center, for finance.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Count employees per cost center",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CostCenterHeadcount"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "description": "Returns a page of the roles ordered by name, e.g. to fill a role picker.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "List roles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Role"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Adds a role, with the permissions it grants, to the catalog clients offer when assigning roles.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Create a role",
+                "parameters": [
+                    {
+                        "description": "Role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Role"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Role"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A role with this name already exists, or the role would imply itself",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{name}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Get a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Role"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Replaces the description, parent and permissions of the role. The name cannot be changed, since employees hold it.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Update a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Role"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Role"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The role would imply itself",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Removes a role from the catalog. Employees holding the role keep it; a role that is the parent of others is kept.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Delete a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Other roles have the role as parent",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams": {
+            "post": {
+                "description": "Creates a team led by an existing employee, optionally with initial members who must be employees as well.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Create a team",
+                "parameters": [
+                    {
+                        "description": "Name, lead and members",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamId}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Get a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team identifier",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the team; its lead and members are not affected.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Delete a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team identifier",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{teamId}/members/{employeeEmail}": {
+            "put": {
+                "description": "Adds an existing employee to the team. Adding a member again has no effect.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Add a member to a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team identifier",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    },
+                    "404": {
+                        "description": "The team or the employee does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Remove a member from a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team identifier",
+                        "name": "teamId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Employee email",
+                        "name": "employeeEmail",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    },
+                    "404": {
+                        "description": "The team does not exist or the employee is not a member",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/titles": {
+            "get": {
+                "description": "Returns a page of the job titles ordered by name.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "titles"
+                ],
+                "summary": "List job titles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.JobTitle"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a job title employees can then hold, optionally restricted to a list of grades.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "titles"
+                ],
+                "summary": "Create a job title",
+                "parameters": [
+                    {
+                        "description": "Job title",
+                        "name": "title",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.JobTitle"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobTitle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A title with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/titles/{name}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "titles"
+                ],
+                "summary": "Get a job title",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job title",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobTitle"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the description and grades of the job title. The name cannot be changed, since employees reference it.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "titles"
+                ],
+                "summary": "Update a job title",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job title",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Job title",
+                        "name": "title",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.JobTitle"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobTitle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a job title that no employee holds.",
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "titles"
+                ],
+                "summary": "Delete a job title",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job title",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Employees still hold the title",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
     "definitions": {
+        "WebMVCEmployees_clients.Bundle": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "Current is true when the bundle matches the OpenAPI document of the running server.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "language": {
+                    "type": "string",
+                    "example": "typescript"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 48213
+                }
+            }
+        },
+        "WebMVCEmployees_health.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/health.CheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "WebMVCEmployees_inflight.Report": {
+            "type": "object",
+            "properties": {
+                "mongo": {
+                    "description": "Mongo breaks MongoOperations down by command. Idle commands are omitted.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/inflight.CommandInFlight"
+                    }
+                },
+                "mongoOperations": {
+                    "description": "MongoOperations is the total number of MongoDB commands in progress.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "requests": {
+                    "description": "Requests is the total number of HTTP requests in progress.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "routes": {
+                    "description": "Routes breaks Requests down by route. Idle routes are omitted.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/inflight.RouteInFlight"
+                    }
+                }
+            }
+        },
+        "WebMVCEmployees_readonly.State": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Enabled is true while mutations are rejected.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "reason": {
+                    "description": "Reason explains to the clients whose mutations are rejected why, and is empty when none was given.",
+                    "type": "string",
+                    "example": "Migrating the employee records"
+                },
+                "since": {
+                    "description": "Since is when the mode was last switched on or off, and is unset until it is first switched.",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                }
+            }
+        },
+        "WebMVCEmployees_slo.RouteStatus": {
+            "type": "object",
+            "properties": {
+                "objective": {
+                    "$ref": "#/definitions/slo.Objective"
+                },
+                "outOfBudget": {
+                    "description": "OutOfBudget is true when the route burns its error budget faster than allowed over the last hour.",
+                    "type": "boolean"
+                },
+                "windows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/slo.WindowStatus"
+                    }
+                }
+            }
+        },
+        "health.CheckResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error says why the check failed.",
+                    "type": "string",
+                    "example": "context deadline exceeded"
+                },
+                "name": {
+                    "description": "Name names the dependency checked, e.g. mongo.",
+                    "type": "string",
+                    "example": "mongo"
+                },
+                "status": {
+                    "description": "Status is ok or failing.",
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/health.CheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "inflight.CommandInFlight": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "description": "Command is the MongoDB command name, e.g. find or insert.",
+                    "type": "string",
+                    "example": "find"
+                },
+                "operations": {
+                    "description": "Operations is the number of commands awaiting a reply.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "inflight.RouteInFlight": {
+            "type": "object",
+            "properties": {
+                "requests": {
+                    "description": "Requests is the number of requests currently being served.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "route": {
+                    "description": "Route is the HTTP method followed by the route template.",
+                    "type": "string",
+                    "example": "GET /employees"
+                }
+            }
+        },
+        "models.Address": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "description": "City is required when an address is given.",
+                    "type": "string",
+                    "example": "Tel Aviv"
+                },
+                "country": {
+                    "description": "Country is the two-letter ISO 3166-1 country code and is required when an address is given.",
+                    "type": "string",
+                    "example": "IL"
+                },
+                "postalCode": {
+                    "description": "PostalCode is the postal or ZIP code.",
+                    "type": "string",
+                    "example": "6910717"
+                },
+                "street": {
+                    "description": "Street is the street and house number.",
+                    "type": "string",
+                    "example": "Mivtza Kadesh 38"
+                }
+            }
+        },
+        "models.AgeBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count is the number of employees in the range.",
+                    "type": "integer",
+                    "example": 7
+                },
+                "label": {
+                    "description": "Label is the age range, e.g. \"30-39\", \"\u003c20\" or \"60+\".",
+                    "type": "string",
+                    "example": "30-39"
+                }
+            }
+        },
+        "models.Anniversary": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "Date is the day of the anniversary this year, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2025-06-15"
+                },
+                "email": {
+                    "description": "Email is the email of the employee.",
+                    "type": "string",
+                    "example": "jane.doe@s.example.com"
+                },
+                "hireDate": {
+                    "description": "HireDate is the day the employee was hired, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2020-06-15"
+                },
+                "name": {
+                    "description": "Name is the name of the employee.",
+                    "type": "string",
+                    "example": "Jane Doe"
+                },
+                "yearsOfService": {
+                    "description": "YearsOfService is the number of years the employee completes on the anniversary.",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.AttendanceDay": {
+            "type": "object",
+            "properties": {
+                "checkedIn": {
+                    "description": "CheckedIn is set when a record of the day is still open; its time counts up to now.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "date": {
+                    "description": "Date is the day, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2025-03-03"
+                },
+                "minutes": {
+                    "description": "Minutes is the time worked in the records checked in that day.",
+                    "type": "integer",
+                    "example": 480
+                },
+                "records": {
+                    "description": "Records is the number of records checked in that day.",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.AttendanceRecord": {
+            "type": "object",
+            "properties": {
+                "checkIn": {
+                    "description": "CheckIn is when the employee checked in.",
+                    "type": "string"
+                },
+                "checkOut": {
+                    "description": "CheckOut is when the employee checked out; it is missing while the employee is checked in.",
+                    "type": "string"
+                },
+                "employee": {
+                    "description": "Employee is the email of the employee who checked in.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "id": {
+                    "description": "ID identifies the record.",
+                    "type": "string",
+                    "example": "6650c1f2a4d3e2b1c0f9e8d7"
+                }
+            }
+        },
+        "models.AttendanceSummary": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "Days lists every day of the period, in order.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AttendanceDay"
+                    }
+                },
+                "employee": {
+                    "description": "Employee is the email of the employee.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "from": {
+                    "description": "From is the first day of the period, formatted as YYYY-MM-DD; weeks start on Monday.",
+                    "type": "string",
+                    "example": "2025-03-03"
+                },
+                "period": {
+                    "description": "Period is day or week.",
+                    "type": "string",
+                    "example": "week"
+                },
+                "to": {
+                    "description": "To is the last day of the period, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2025-03-09"
+                },
+                "totalMinutes": {
+                    "description": "TotalMinutes is the time worked in the period.",
+                    "type": "integer",
+                    "example": 2400
+                }
+            }
+        },
+        "models.AuditDetails": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "models.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action identifies the kind of change, e.g. role.granted.",
+                    "type": "string",
+                    "example": "role.granted"
+                },
+                "details": {
+                    "description": "Details holds action-specific values such as the role and its expiry.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AuditDetails"
+                        }
+                    ]
+                },
+                "employee": {
+                    "description": "Employee is the email of the employee that was changed.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "time": {
+                    "description": "Time is when the change happened.",
+                    "type": "string"
+                }
+            }
+        },
         "models.Birthdate": {
             "type": "object",
             "properties": {
-                "day": {
-                    "description": "Day represents the two-digit day.",
+                "day": {
+                    "description": "Day represents the two-digit day.",
+                    "type": "string",
+                    "example": "03"
+                },
+                "month": {
+                    "description": "Month represents the two-digit month.",
+                    "type": "string",
+                    "example": "01"
+                },
+                "year": {
+                    "description": "Year represents the four-digit year.",
+                    "type": "string",
+                    "example": "1999"
+                }
+            }
+        },
+        "models.BulkItemResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the machine-readable error code of a failed or skipped item.",
+                    "type": "string",
+                    "example": "EMPLOYEE_NOT_FOUND"
+                },
+                "email": {
+                    "description": "Email identifies the employee the item refers to.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "error": {
+                    "description": "Error describes why the item failed or was skipped.",
+                    "type": "string",
+                    "example": "employee not found"
+                },
+                "index": {
+                    "description": "Index is the position of the item in the request.",
+                    "type": "integer",
+                    "example": 0
+                },
+                "status": {
+                    "description": "Status is the HTTP status the item would have produced on its own.",
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.BulkResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "description": "Failed is the number of items that were rejected.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "description": "Results lists the outcome of every item in request order.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkItemResult"
+                    }
+                },
+                "skipped": {
+                    "description": "Skipped is the number of items not attempted because the request aborted.",
+                    "type": "integer",
+                    "example": 0
+                },
+                "succeeded": {
+                    "description": "Succeeded is the number of items that were applied.",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.Certification": {
+            "type": "object",
+            "properties": {
+                "employee": {
+                    "description": "Employee is the email of the employee holding the certification.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is the day, formatted as YYYY-MM-DD, the certification expires; empty when it never does.",
+                    "type": "string",
+                    "example": "2026-06-30"
+                },
+                "id": {
+                    "description": "ID identifies the certification.",
+                    "type": "string",
+                    "example": "6650c1f2a4d3e2b1c0f9e8d7"
+                },
+                "issuer": {
+                    "description": "Issuer is the organization that granted the certification.",
+                    "type": "string",
+                    "example": "CNCF"
+                },
+                "name": {
+                    "description": "Name is the name of the certification.",
+                    "type": "string",
+                    "example": "Certified Kubernetes Administrator"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is when the certification was last added or replaced.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.CertificationRequest": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "ExpiresAt is the day, formatted as YYYY-MM-DD, the certification expires; empty when it never does.",
+                    "type": "string",
+                    "example": "2026-06-30"
+                },
+                "issuer": {
+                    "description": "Issuer is the organization that granted the certification.",
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "CNCF"
+                },
+                "name": {
+                    "description": "Name is the name of the certification.",
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "Certified Kubernetes Administrator"
+                }
+            }
+        },
+        "models.Compensation": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "Current is the salary in effect today; absent when no salary has taken effect yet.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CompensationRecord"
+                        }
+                    ]
+                },
+                "history": {
+                    "description": "History lists every salary recorded, latest effective date first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CompensationRecord"
+                    }
+                }
+            }
+        },
+        "models.CompensationRecord": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Currency is the ISO 4217 code of the salary currency.",
+                    "type": "string",
+                    "example": "ILS"
+                },
+                "effectiveDate": {
+                    "description": "EffectiveDate is the day, formatted as YYYY-MM-DD, from which the salary applies.",
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "employee": {
+                    "description": "Employee is the email of the employee paid the salary.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "recordedAt": {
+                    "description": "RecordedAt is when the salary was last set.",
+                    "type": "string"
+                },
+                "recordedBy": {
+                    "description": "RecordedBy is the email of the employee who last set the salary.",
+                    "type": "string",
+                    "example": "hr@s.afeka.ac.il"
+                },
+                "salary": {
+                    "description": "Salary is the yearly salary, in Currency.",
+                    "type": "number",
+                    "example": 120000
+                }
+            }
+        },
+        "models.CompensationRequest": {
+            "type": "object",
+            "required": [
+                "effectiveDate"
+            ],
+            "properties": {
+                "currency": {
+                    "description": "Currency is the ISO 4217 code of the salary currency.",
+                    "type": "string",
+                    "example": "ILS"
+                },
+                "effectiveDate": {
+                    "description": "EffectiveDate is the day, formatted as YYYY-MM-DD, from which the salary applies.",
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "salary": {
+                    "description": "Salary is the yearly salary, in Currency.",
+                    "type": "number",
+                    "example": 120000
+                }
+            }
+        },
+        "models.CostCenterHeadcount": {
+            "type": "object",
+            "properties": {
+                "costCenter": {
+                    "description": "CostCenter is the cost center; empty for the employees charged to none.",
+                    "type": "string",
+                    "example": "CC-1200"
+                },
+                "headcount": {
+                    "description": "Headcount is the number of employees charged to the cost center.",
+                    "type": "integer",
+                    "example": 14
+                }
+            }
+        },
+        "models.Delegation": {
+            "type": "object",
+            "properties": {
+                "delegate": {
+                    "description": "Delegate is the email of the employee acting as manager during the window.",
+                    "type": "string",
+                    "example": "deputy@s.example.com"
+                },
+                "endsAt": {
+                    "description": "EndsAt is when the delegation ends and duties revert to the manager.",
+                    "type": "string",
+                    "example": "2025-07-15T00:00:00Z"
+                },
+                "startsAt": {
+                    "description": "StartsAt is when the delegation takes effect.",
+                    "type": "string",
+                    "example": "2025-07-01T00:00:00Z"
+                }
+            }
+        },
+        "models.Department": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description optionally explains what the department does.",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Research and development"
+                },
+                "name": {
+                    "description": "Name identifies the department and cannot be changed once created.",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "R\u0026D"
+                }
+            }
+        },
+        "models.Document": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "Category is contract, id or other.",
+                    "type": "string",
+                    "example": "contract"
+                },
+                "contentType": {
+                    "description": "ContentType is the media type detected from the content.",
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "downloadUrl": {
+                    "description": "DownloadURL is a signed link to the content, usable without credentials until DownloadURLExpiresAt.",
+                    "type": "string",
+                    "example": "/documents/6650c1f2a4d3e2b1c0f9e8d7/download?expires=1735689600\u0026signature=3f1c..."
+                },
+                "downloadUrlExpiresAt": {
+                    "description": "DownloadURLExpiresAt is when DownloadURL stops working.",
+                    "type": "string"
+                },
+                "employee": {
+                    "description": "Employee is the email of the employee the document belongs to.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "id": {
+                    "description": "ID identifies the document.",
+                    "type": "string",
+                    "example": "6650c1f2a4d3e2b1c0f9e8d7"
+                },
+                "name": {
+                    "description": "Name is the file name given on upload.",
+                    "type": "string",
+                    "example": "contract-2025.pdf"
+                },
+                "size": {
+                    "description": "Size is the length of the file in bytes.",
+                    "type": "integer",
+                    "example": 48213
+                },
+                "uploadedAt": {
+                    "description": "UploadedAt is when the document was uploaded.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.EffectiveRoles": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "description": "Permissions lists the permissions of all the roles, without duplicates.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "code:write",
+                        "code:review"
+                    ]
+                },
+                "roles": {
+                    "description": "Roles lists the roles held directly followed by the roles they imply.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Senior Developer",
+                        "Developer"
+                    ]
+                }
+            }
+        },
+        "models.EmailChange": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email is the new email address of the employee.",
+                    "type": "string",
+                    "example": "jane.doe@s.afeka.ac.il"
+                }
+            }
+        },
+        "models.EmergencyContact": {
+            "type": "object",
+            "required": [
+                "phone"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Name is the full name of the contact.",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "John Doe"
+                },
+                "phone": {
+                    "description": "Phone is the phone number of the contact in E.164 format.",
+                    "type": "string",
+                    "example": "+972501234567"
+                },
+                "relationship": {
+                    "description": "Relationship is how the contact relates to the employee.",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Spouse"
+                }
+            }
+        },
+        "models.EmergencyContacts": {
+            "type": "object",
+            "properties": {
+                "contacts": {
+                    "description": "Contacts lists the contacts, most preferred first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EmergencyContact"
+                    }
+                },
+                "employee": {
+                    "description": "Employee is the email of the employee.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is when the contacts were last replaced; absent when none were ever set.",
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "description": "UpdatedBy is the email of the employee who last replaced the contacts.",
+                    "type": "string",
+                    "example": "hr@s.afeka.ac.il"
+                }
+            }
+        },
+        "models.EmergencyContactsRequest": {
+            "type": "object",
+            "properties": {
+                "contacts": {
+                    "description": "Contacts lists up to 5 contacts, most preferred first; an empty list removes them all.",
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "$ref": "#/definitions/models.EmergencyContact"
+                    }
+                }
+            }
+        },
+        "models.EmployeeQuery": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address is the employee's optional postal address.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ]
+                },
+                "birthdate": {
+                    "description": "Birthdate contains the employee's date of birth.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Birthdate"
+                        }
+                    ]
+                },
+                "costCenter": {
+                    "description": "CostCenter is the optional cost center the employee is charged to.",
+                    "type": "string",
+                    "example": "CC-1200"
+                },
+                "department": {
+                    "description": "Department is the optional name of the department the employee belongs to.",
+                    "type": "string",
+                    "example": "R\u0026D"
+                },
+                "email": {
+                    "description": "Email is the unique identifier.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "emails": {
+                    "description": "Emails switches the query to a multi-get of these employees; it cannot be combined with example fields.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "janesmith@s.afeka.ac.il",
+                        "manager@s.example.com"
+                    ]
+                },
+                "grade": {
+                    "description": "Grade is the optional grade of the employee within their title.",
+                    "type": "string",
+                    "example": "L4"
+                },
+                "hireDate": {
+                    "description": "HireDate is the optional date the employee was hired, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2020-03-15"
+                },
+                "locale": {
+                    "description": "Locale is the optional BCP 47 language tag of the language and region the employee prefers.",
+                    "type": "string",
+                    "example": "he-IL"
+                },
+                "location": {
+                    "description": "Location is the optional office the employee works from, one of the locations.",
+                    "type": "string",
+                    "example": "Tel Aviv HQ"
+                },
+                "manager": {
+                    "description": "Manager optionally holds the email of the employee's manager.",
+                    "type": "string",
+                    "example": "manager@s.example.com"
+                },
+                "metadata": {
+                    "description": "Metadata holds organization-specific attributes, such as a badge number or parking spot.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Metadata"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "Name is the full legal name of the employee.",
+                    "type": "string",
+                    "example": "Jane Smith"
+                },
+                "password": {
+                    "description": "Password is the employee's password. It is never returned.",
+                    "type": "string",
+                    "example": "Pa5"
+                },
+                "phone": {
+                    "description": "Phone is an optional phone number in E.164 format.",
+                    "type": "string",
+                    "example": "+972501234567"
+                },
+                "preferredName": {
+                    "description": "PreferredName is the optional name the employee goes by, shown instead of the legal name.",
+                    "type": "string",
+                    "example": "Janie"
+                },
+                "pronouns": {
+                    "description": "Pronouns optionally holds the pronouns of the employee, as they write them.",
+                    "type": "string",
+                    "example": "she/her"
+                },
+                "roles": {
+                    "description": "Roles contains the roles or permissions of the employee.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "DevOps",
+                        "R\u0026D"
+                    ]
+                },
+                "skills": {
+                    "description": "Skills lists the capabilities of the employee, each name at most once.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Skill"
+                    }
+                },
+                "timezone": {
+                    "description": "Timezone is the optional IANA time zone the employee lives in; UTC when omitted.",
+                    "type": "string",
+                    "example": "Asia/Jerusalem"
+                },
+                "title": {
+                    "description": "Title is the optional job title of the employee, one of the titles catalog.",
+                    "type": "string",
+                    "example": "Software Engineer"
+                }
+            }
+        },
+        "models.EmployeeResponse": {
+            "description": "An employee with email, name, birthdate, and roles.",
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address is the employee's optional postal address.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ]
+                },
+                "birthdate": {
+                    "description": "Birthdate contains the employee's date of birth.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Birthdate"
+                        }
+                    ]
+                },
+                "costCenter": {
+                    "description": "CostCenter is the optional cost center the employee is charged to.",
+                    "type": "string",
+                    "example": "CC-1200"
+                },
+                "delegation": {
+                    "description": "Delegation optionally hands this manager's duties to a delegate for a time window.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Delegation"
+                        }
+                    ]
+                },
+                "deletedAt": {
+                    "description": "DeletedAt is set while the employee is soft-deleted; such employees are hidden until restored.",
+                    "type": "string"
+                },
+                "department": {
+                    "description": "Department is the optional name of the department the employee belongs to.",
+                    "type": "string",
+                    "example": "R\u0026D"
+                },
+                "displayName": {
+                    "description": "DisplayName is the name to show: the preferred name when set, the legal name otherwise.",
+                    "type": "string",
+                    "example": "Janie"
+                },
+                "email": {
+                    "description": "Email is the unique identifier.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "grade": {
+                    "description": "Grade is the optional grade of the employee within their title.",
+                    "type": "string",
+                    "example": "L4"
+                },
+                "hireDate": {
+                    "description": "HireDate is the optional date the employee was hired, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2020-03-15"
+                },
+                "id": {
+                    "description": "ID is the immutable internal identifier, which is kept when the email changes.",
+                    "type": "string",
+                    "example": "01J9Z3K7Q8X2M4N6P8R0S2T4V6"
+                },
+                "locale": {
+                    "description": "Locale is the optional BCP 47 language tag of the language and region the employee prefers.",
+                    "type": "string",
+                    "example": "he-IL"
+                },
+                "location": {
+                    "description": "Location is the optional office the employee works from.",
+                    "type": "string",
+                    "example": "Tel Aviv HQ"
+                },
+                "manager": {
+                    "description": "Manager optionally stores the email of the employee's manager.",
+                    "type": "string",
+                    "example": "manager@s.example.com"
+                },
+                "metadata": {
+                    "description": "Metadata holds organization-specific attributes, such as a badge number or parking spot.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Metadata"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "Name is the full legal name of the employee.",
+                    "type": "string",
+                    "example": "Jane Smith"
+                },
+                "phone": {
+                    "description": "Phone is an optional phone number in E.164 format.",
+                    "type": "string",
+                    "example": "+972501234567"
+                },
+                "preferredName": {
+                    "description": "PreferredName is the optional name the employee goes by.",
+                    "type": "string",
+                    "example": "Janie"
+                },
+                "pronouns": {
+                    "description": "Pronouns optionally holds the pronouns of the employee.",
+                    "type": "string",
+                    "example": "she/her"
+                },
+                "reportingLines": {
+                    "description": "ReportingLines lists the primary line, the same as Manager, followed by the dotted lines of the employee.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReportingLine"
+                    }
+                },
+                "roles": {
+                    "description": "Roles contains the roles or permissions of the employee.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "DevOps",
+                        "R\u0026D"
+                    ]
+                },
+                "skills": {
+                    "description": "Skills lists the capabilities of the employee.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Skill"
+                    }
+                },
+                "status": {
+                    "description": "Status is the employment status: active, on_leave or terminated.",
+                    "type": "string",
+                    "example": "active"
+                },
+                "statusHistory": {
+                    "description": "StatusHistory lists the changes of the employment status, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StatusTransition"
+                    }
+                },
+                "temporaryRoles": {
+                    "description": "TemporaryRoles lists the roles that are removed automatically once they expire.\nEach of them is also present in Roles while it is active.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RoleGrant"
+                    }
+                },
+                "timezone": {
+                    "description": "Timezone is the optional IANA time zone the employee lives in.",
+                    "type": "string",
+                    "example": "Asia/Jerusalem"
+                },
+                "title": {
+                    "description": "Title is the optional job title of the employee.",
+                    "type": "string",
+                    "example": "Software Engineer"
+                },
+                "version": {
+                    "description": "Version is incremented on every update and used for optimistic concurrency control.",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is a stable, machine-readable error code clients can branch on.",
+                    "type": "string",
+                    "example": "INVALID_PAYLOAD"
+                },
+                "error": {
+                    "description": "Error is the error message.",
+                    "type": "string",
+                    "example": "Invalid request payload"
+                },
+                "requestId": {
+                    "description": "RequestID identifies the request, also returned in the X-Request-ID header; support finds its log lines by it.",
+                    "type": "string",
+                    "example": "01JA8Z6Q2M4V7K9B3C5D8F1G2H"
+                }
+            }
+        },
+        "models.JobTitle": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description optionally explains the title.",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Designs and builds the product"
+                },
+                "grades": {
+                    "description": "Grades lists the grades holders of the title may have; when empty any grade is accepted.",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "L3",
+                        "L4",
+                        "L5"
+                    ]
+                },
+                "name": {
+                    "description": "Name identifies the title and cannot be changed once created.",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Software Engineer"
+                }
+            }
+        },
+        "models.LeaveBalance": {
+            "type": "object",
+            "properties": {
+                "allowance": {
+                    "description": "Allowance is the number of days off the employee is entitled to in the year.",
+                    "type": "integer",
+                    "example": 20
+                },
+                "employee": {
+                    "description": "Employee is the email of the employee.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "pending": {
+                    "description": "Pending is the number of days of the requests awaiting a decision.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "remaining": {
+                    "description": "Remaining is the number of days that can still be requested.",
+                    "type": "integer",
+                    "example": 13
+                },
+                "used": {
+                    "description": "Used is the number of days of the approved requests.",
+                    "type": "integer",
+                    "example": 5
+                },
+                "year": {
+                    "description": "Year is the calendar year of the balance.",
+                    "type": "integer",
+                    "example": 2025
+                }
+            }
+        },
+        "models.LeaveDecisionBoundary": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "Comment optionally explains the decision to the employee.",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Enjoy!"
+                }
+            }
+        },
+        "models.LeaveRequest": {
+            "type": "object",
+            "properties": {
+                "approver": {
+                    "description": "Approver is the email of the employee who approved or rejected the request, or who was to\napprove it when it was submitted.",
+                    "type": "string",
+                    "example": "manager@s.example.com"
+                },
+                "comment": {
+                    "description": "Comment optionally explains the decision.",
+                    "type": "string",
+                    "example": "Enjoy!"
+                },
+                "createdAt": {
+                    "description": "CreatedAt is when the request was submitted.",
+                    "type": "string"
+                },
+                "days": {
+                    "description": "Days is the number of working days, Monday to Friday, between StartDate and EndDate.",
+                    "type": "integer",
+                    "example": 5
+                },
+                "decidedAt": {
+                    "description": "DecidedAt is when the request left the pending status.",
+                    "type": "string"
+                },
+                "employee": {
+                    "description": "Employee is the email of the employee requesting the time off.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "endDate": {
+                    "description": "EndDate is the last day off, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2025-08-08"
+                },
+                "id": {
+                    "description": "ID identifies the request.",
+                    "type": "string",
+                    "example": "6650c1f2a4d3e2b1c0f9e8d7"
+                },
+                "reason": {
+                    "description": "Reason optionally explains the request to the approver.",
+                    "type": "string",
+                    "example": "Family vacation"
+                },
+                "startDate": {
+                    "description": "StartDate is the first day off, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2025-08-04"
+                },
+                "status": {
+                    "description": "Status is one of pending, approved, rejected or cancelled.",
+                    "type": "string",
+                    "example": "pending"
+                },
+                "year": {
+                    "description": "Year is the year of the balance the request is charged to.",
+                    "type": "integer",
+                    "example": 2025
+                }
+            }
+        },
+        "models.LeaveRequestBoundary": {
+            "type": "object",
+            "required": [
+                "endDate",
+                "startDate"
+            ],
+            "properties": {
+                "endDate": {
+                    "description": "EndDate is the last day off, formatted as YYYY-MM-DD, in the same year as StartDate.",
+                    "type": "string",
+                    "example": "2025-08-08"
+                },
+                "reason": {
+                    "description": "Reason optionally explains the request to the approver.",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Family vacation"
+                },
+                "startDate": {
+                    "description": "StartDate is the first day off, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2025-08-04"
+                }
+            }
+        },
+        "models.Location": {
+            "type": "object",
+            "required": [
+                "timezone"
+            ],
+            "properties": {
+                "address": {
+                    "description": "Address is the postal address of the office.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "Name identifies the location and cannot be changed once created.",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Tel Aviv HQ"
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA time zone of the office.",
+                    "type": "string",
+                    "example": "Asia/Jerusalem"
+                }
+            }
+        },
+        "models.LogLevel": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "description": "Level is debug, info, warn or error.",
+                    "type": "string",
+                    "example": "debug"
+                }
+            }
+        },
+        "models.ManagerAssignment": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email of the employee.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "manager": {
+                    "description": "Email of the manager to assign.",
+                    "type": "string",
+                    "example": "manager@s.example.com"
+                }
+            }
+        },
+        "models.ManagerEmailBoundary": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "The email of the manager.",
+                    "type": "string",
+                    "example": "manager@s.example.com"
+                }
+            }
+        },
+        "models.ManagerReassignment": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
+                    "description": "The email of the current manager.",
+                    "type": "string",
+                    "example": "old.manager@s.example.com"
+                },
+                "to": {
+                    "description": "The email of the new manager.",
+                    "type": "string",
+                    "example": "new.manager@s.example.com"
+                }
+            }
+        },
+        "models.Metadata": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "models.NewEmployeeBoundary": {
+            "description": "The details of a new employee: email, name, password, birthdate, and roles.",
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Address is the employee's optional postal address.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ]
+                },
+                "birthdate": {
+                    "description": "Birthdate contains the employee's date of birth.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Birthdate"
+                        }
+                    ]
+                },
+                "costCenter": {
+                    "description": "CostCenter is the optional cost center the employee is charged to.",
+                    "type": "string",
+                    "example": "CC-1200"
+                },
+                "department": {
+                    "description": "Department is the optional name of the department the employee belongs to.",
+                    "type": "string",
+                    "example": "R\u0026D"
+                },
+                "email": {
+                    "description": "Email is the unique identifier.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "grade": {
+                    "description": "Grade is the optional grade of the employee within their title.",
+                    "type": "string",
+                    "example": "L4"
+                },
+                "hireDate": {
+                    "description": "HireDate is the optional date the employee was hired, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2020-03-15"
+                },
+                "locale": {
+                    "description": "Locale is the optional BCP 47 language tag of the language and region the employee prefers.",
+                    "type": "string",
+                    "example": "he-IL"
+                },
+                "location": {
+                    "description": "Location is the optional office the employee works from, one of the locations.",
+                    "type": "string",
+                    "example": "Tel Aviv HQ"
+                },
+                "manager": {
+                    "description": "Manager optionally holds the email of the employee's manager.",
+                    "type": "string",
+                    "example": "manager@s.example.com"
+                },
+                "metadata": {
+                    "description": "Metadata holds organization-specific attributes, such as a badge number or parking spot.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Metadata"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "Name is the full legal name of the employee.",
+                    "type": "string",
+                    "example": "Jane Smith"
+                },
+                "password": {
+                    "description": "Password is the employee's password. It is never returned.",
+                    "type": "string",
+                    "example": "Pa5"
+                },
+                "phone": {
+                    "description": "Phone is an optional phone number in E.164 format.",
+                    "type": "string",
+                    "example": "+972501234567"
+                },
+                "preferredName": {
+                    "description": "PreferredName is the optional name the employee goes by, shown instead of the legal name.",
+                    "type": "string",
+                    "example": "Janie"
+                },
+                "pronouns": {
+                    "description": "Pronouns optionally holds the pronouns of the employee, as they write them.",
+                    "type": "string",
+                    "example": "she/her"
+                },
+                "roles": {
+                    "description": "Roles contains the roles or permissions of the employee.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "DevOps",
+                        "R\u0026D"
+                    ]
+                },
+                "skills": {
+                    "description": "Skills lists the capabilities of the employee, each name at most once.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Skill"
+                    }
+                },
+                "timezone": {
+                    "description": "Timezone is the optional IANA time zone the employee lives in; UTC when omitted.",
+                    "type": "string",
+                    "example": "Asia/Jerusalem"
+                },
+                "title": {
+                    "description": "Title is the optional job title of the employee, one of the titles catalog.",
+                    "type": "string",
+                    "example": "Software Engineer"
+                }
+            }
+        },
+        "models.Note": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Author identifies who wrote the note.",
+                    "type": "string",
+                    "example": "hr@s.afeka.ac.il"
+                },
+                "createdAt": {
+                    "description": "CreatedAt is when the note was written.",
+                    "type": "string"
+                },
+                "employee": {
+                    "description": "Employee is the email of the employee the note is about.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "id": {
+                    "description": "ID identifies the note.",
+                    "type": "string",
+                    "example": "6650c1f2a4d3e2b1c0f9e8d7"
+                },
+                "text": {
+                    "description": "Text is the content of the note.",
+                    "type": "string",
+                    "example": "Discussed relocation plans."
+                }
+            }
+        },
+        "models.NoteRequest": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "description": "Text is the content of the note.",
+                    "type": "string",
+                    "example": "Discussed relocation plans."
+                }
+            }
+        },
+        "models.OrgDiff": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "Added lists employees present only in the second snapshot.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrgNode"
+                    }
+                },
+                "moved": {
+                    "description": "Moved lists employees present in both snapshots whose manager changed.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrgMove"
+                    }
+                },
+                "newManagers": {
+                    "description": "NewManagers lists employees who have direct reports only in the second snapshot.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "removed": {
+                    "description": "Removed lists employees present only in the first snapshot.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrgNode"
+                    }
+                }
+            }
+        },
+        "models.OrgDiffRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "From is the identifier of the baseline snapshot, or \"current\" for the live organization.",
+                    "type": "string",
+                    "example": "current"
+                },
+                "snapshot": {
+                    "description": "Snapshot is an uploaded snapshot (e.g. a planned reorg) used instead of To.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrgSnapshot"
+                        }
+                    ]
+                },
+                "to": {
+                    "description": "To is the identifier of the snapshot to compare against, or \"current\".",
+                    "type": "string",
+                    "example": "6650c1f2a4d3e2b1c0f9e8d7"
+                }
+            }
+        },
+        "models.OrgMove": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email identifies the employee.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "fromManager": {
+                    "description": "FromManager is the previous manager, absent if the employee had none.",
+                    "type": "string",
+                    "example": "old.manager@s.example.com"
+                },
+                "toManager": {
+                    "description": "ToManager is the new manager, absent if the employee no longer has one.",
+                    "type": "string",
+                    "example": "new.manager@s.example.com"
+                }
+            }
+        },
+        "models.OrgNode": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email identifies the employee.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
+                },
+                "manager": {
+                    "description": "Manager is the email of the employee's manager, if any.",
+                    "type": "string",
+                    "example": "manager@s.example.com"
+                },
+                "name": {
+                    "description": "Name is the full name of the employee.",
+                    "type": "string",
+                    "example": "Jane Smith"
+                }
+            }
+        },
+        "models.OrgSnapshot": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt is when the snapshot was taken.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID identifies a stored snapshot. It is empty for uploaded snapshots.",
+                    "type": "string",
+                    "example": "6650c1f2a4d3e2b1c0f9e8d7"
+                },
+                "label": {
+                    "description": "Label is an optional description, e.g. \"before Q3 reorg\".",
+                    "type": "string",
+                    "example": "before Q3 reorg"
+                },
+                "nodes": {
+                    "description": "Nodes lists every employee with their manager.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrgNode"
+                    }
+                }
+            }
+        },
+        "models.OrgSnapshotRequest": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "description": "Label is an optional description of the snapshot.",
+                    "type": "string",
+                    "example": "before Q3 reorg"
+                }
+            }
+        },
+        "models.ReadOnlyModeRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Enabled switches the mode on when true, and off otherwise.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "reason": {
+                    "description": "Reason explains to the clients whose mutations are rejected why. It is ignored when switching the mode off.",
+                    "type": "string",
+                    "example": "Migrating the employee records"
+                }
+            }
+        },
+        "models.ReportCounts": {
+            "type": "object",
+            "properties": {
+                "direct": {
+                    "description": "Direct is the number of employees reporting to the manager.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "total": {
+                    "description": "Total also counts the reports of the reports, down to the bottom of the organization.",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.ReportingLine": {
+            "type": "object",
+            "required": [
+                "manager"
+            ],
+            "properties": {
+                "manager": {
+                    "description": "Manager is the email of the manager.",
+                    "type": "string",
+                    "example": "manager@s.example.com"
+                },
+                "type": {
+                    "description": "Type is primary for the single line the hierarchy is built from, or dotted for secondary ones.",
+                    "type": "string",
+                    "enum": [
+                        "primary",
+                        "dotted"
+                    ],
+                    "example": "dotted"
+                }
+            }
+        },
+        "models.Review": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "description": "Comments is the written feedback.",
                     "type": "string",
-                    "example": "03"
+                    "example": "Led the migration to the new platform."
                 },
-                "month": {
-                    "description": "Month represents the two-digit month.",
+                "createdAt": {
+                    "description": "CreatedAt is when the review was written.",
+                    "type": "string"
+                },
+                "employee": {
+                    "description": "Employee is the email of the reviewed employee.",
                     "type": "string",
-                    "example": "01"
+                    "example": "janesmith@s.afeka.ac.il"
                 },
-                "year": {
-                    "description": "Year represents the four-digit year.",
+                "id": {
+                    "description": "ID identifies the review.",
                     "type": "string",
-                    "example": "1999"
+                    "example": "6650c1f2a4d3e2b1c0f9e8d7"
+                },
+                "periodEnd": {
+                    "description": "PeriodEnd is the last day of the reviewed period, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2025-06-30"
+                },
+                "periodStart": {
+                    "description": "PeriodStart is the first day of the reviewed period, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "rating": {
+                    "description": "Rating is the overall rating from 1, below expectations, to 5, outstanding.",
+                    "type": "integer",
+                    "example": 4
+                },
+                "reviewer": {
+                    "description": "Reviewer is the email of the manager who wrote the review.",
+                    "type": "string",
+                    "example": "manager@s.example.com"
                 }
             }
         },
-        "models.Employee": {
-            "description": "An employee with email, name, password, birthdate, and roles.",
+        "models.ReviewRequest": {
             "type": "object",
+            "required": [
+                "periodEnd",
+                "periodStart"
+            ],
             "properties": {
-                "birthdate": {
-                    "description": "Birthdate contains the employee's date of birth.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Birthdate"
-                        }
-                    ]
+                "comments": {
+                    "description": "Comments is the written feedback.",
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "Led the migration to the new platform."
                 },
-                "email": {
-                    "description": "Email is the unique identifier.",
+                "periodEnd": {
+                    "description": "PeriodEnd is the last day of the reviewed period, formatted as YYYY-MM-DD.",
                     "type": "string",
-                    "example": "janesmith@s.afeka.ac.il"
+                    "example": "2025-06-30"
                 },
-                "manager": {
-                    "description": "Manager optionally stores the email of the employee's manager.",
+                "periodStart": {
+                    "description": "PeriodStart is the first day of the reviewed period, formatted as YYYY-MM-DD.",
                     "type": "string",
-                    "example": "manager@s.example.com"
+                    "example": "2025-01-01"
+                },
+                "rating": {
+                    "description": "Rating is the overall rating from 1, below expectations, to 5, outstanding.",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 4
+                }
+            }
+        },
+        "models.Role": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Description optionally explains the role.",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Builds and maintains the product"
                 },
                 "name": {
-                    "description": "Name is the full name of the employee.",
+                    "description": "Name identifies the role and cannot be changed once created.",
                     "type": "string",
-                    "example": "Jane Smith"
+                    "maxLength": 64,
+                    "example": "Developer"
                 },
-                "password": {
-                    "description": "Password is the employee's password.",
+                "parent": {
+                    "description": "Parent optionally names a role this role implies: holders of the role also hold the parent,\nits own parent and so on, with all of their permissions.",
                     "type": "string",
-                    "example": "Pa5"
+                    "maxLength": 64,
+                    "example": "Engineer"
+                },
+                "permissions": {
+                    "description": "Permissions lists what holders of the role are allowed to do.",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "code:write",
+                        "deploy:staging"
+                    ]
+                }
+            }
+        },
+        "models.RoleAssignment": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email of the employee receiving the roles.",
+                    "type": "string",
+                    "example": "janesmith@s.afeka.ac.il"
                 },
                 "roles": {
-                    "description": "Roles contains the roles or permissions of the employee.",
+                    "description": "Roles to add to the employee.",
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -411,10 +7892,105 @@ const docTemplate = `{
                 }
             }
         },
-        "models.EmployeeResponse": {
-            "description": "An employee with email, name, birthdate, and roles.",
+        "models.RoleGrant": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "ExpiresAt is when the role is revoked.",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "grantedAt": {
+                    "description": "GrantedAt is when the role was granted or last extended.",
+                    "type": "string",
+                    "example": "2024-12-01T09:30:00Z"
+                },
+                "role": {
+                    "description": "Role is the granted role.",
+                    "type": "string",
+                    "example": "Admin"
+                }
+            }
+        },
+        "models.RoleGrantRequest": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "ExpiresAt is when the role is revoked, in RFC 3339 format.",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "role": {
+                    "description": "Role is the role to grant.",
+                    "type": "string",
+                    "example": "Admin"
+                }
+            }
+        },
+        "models.Skill": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "description": "Level is the proficiency from 1, a beginner, to 5, an expert.",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 4
+                },
+                "name": {
+                    "description": "Name is the name of the skill, such as a language or a tool.",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Go"
+                }
+            }
+        },
+        "models.StatCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count is the number of employees with the value.",
+                    "type": "integer",
+                    "example": 12
+                },
+                "name": {
+                    "description": "Name is the shared value.",
+                    "type": "string",
+                    "example": "Developer"
+                }
+            }
+        },
+        "models.StatusTransition": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "description": "At is when the status changed.",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "from": {
+                    "description": "From is the status before the change.",
+                    "type": "string",
+                    "example": "active"
+                },
+                "to": {
+                    "description": "To is the status after the change.",
+                    "type": "string",
+                    "example": "terminated"
+                }
+            }
+        },
+        "models.Subordinate": {
             "type": "object",
             "properties": {
+                "address": {
+                    "description": "Address is the employee's optional postal address.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ]
+                },
                 "birthdate": {
                     "description": "Birthdate contains the employee's date of birth.",
                     "allOf": [
@@ -423,21 +7999,108 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "costCenter": {
+                    "description": "CostCenter is the optional cost center the employee is charged to.",
+                    "type": "string",
+                    "example": "CC-1200"
+                },
+                "delegation": {
+                    "description": "Delegation optionally hands this manager's duties to a delegate for a time window.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Delegation"
+                        }
+                    ]
+                },
+                "deletedAt": {
+                    "description": "DeletedAt is set while the employee is soft-deleted; such employees are hidden until restored.",
+                    "type": "string"
+                },
+                "department": {
+                    "description": "Department is the optional name of the department the employee belongs to.",
+                    "type": "string",
+                    "example": "R\u0026D"
+                },
+                "depth": {
+                    "description": "Depth is 1 for a direct report, 2 for a report of a direct report, and so on.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "displayName": {
+                    "description": "DisplayName is the name to show: the preferred name when set, the legal name otherwise.",
+                    "type": "string",
+                    "example": "Janie"
+                },
                 "email": {
                     "description": "Email is the unique identifier.",
                     "type": "string",
                     "example": "janesmith@s.afeka.ac.il"
                 },
+                "grade": {
+                    "description": "Grade is the optional grade of the employee within their title.",
+                    "type": "string",
+                    "example": "L4"
+                },
+                "hireDate": {
+                    "description": "HireDate is the optional date the employee was hired, formatted as YYYY-MM-DD.",
+                    "type": "string",
+                    "example": "2020-03-15"
+                },
+                "id": {
+                    "description": "ID is the immutable internal identifier, which is kept when the email changes.",
+                    "type": "string",
+                    "example": "01J9Z3K7Q8X2M4N6P8R0S2T4V6"
+                },
+                "locale": {
+                    "description": "Locale is the optional BCP 47 language tag of the language and region the employee prefers.",
+                    "type": "string",
+                    "example": "he-IL"
+                },
+                "location": {
+                    "description": "Location is the optional office the employee works from.",
+                    "type": "string",
+                    "example": "Tel Aviv HQ"
+                },
                 "manager": {
                     "description": "Manager optionally stores the email of the employee's manager.",
                     "type": "string",
                     "example": "manager@s.example.com"
                 },
+                "metadata": {
+                    "description": "Metadata holds organization-specific attributes, such as a badge number or parking spot.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Metadata"
+                        }
+                    ]
+                },
                 "name": {
-                    "description": "Name is the full name of the employee.",
+                    "description": "Name is the full legal name of the employee.",
                     "type": "string",
                     "example": "Jane Smith"
                 },
+                "phone": {
+                    "description": "Phone is an optional phone number in E.164 format.",
+                    "type": "string",
+                    "example": "+972501234567"
+                },
+                "preferredName": {
+                    "description": "PreferredName is the optional name the employee goes by.",
+                    "type": "string",
+                    "example": "Janie"
+                },
+                "pronouns": {
+                    "description": "Pronouns optionally holds the pronouns of the employee.",
+                    "type": "string",
+                    "example": "she/her"
+                },
+                "reportingLines": {
+                    "description": "ReportingLines lists the primary line, the same as Manager, followed by the dotted lines of the employee.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReportingLine"
+                    }
+                },
                 "roles": {
                     "description": "Roles contains the roles or permissions of the employee.",
                     "type": "array",
@@ -448,29 +8111,281 @@ const docTemplate = `{
                         "DevOps",
                         "R\u0026D"
                     ]
+                },
+                "skills": {
+                    "description": "Skills lists the capabilities of the employee.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Skill"
+                    }
+                },
+                "status": {
+                    "description": "Status is the employment status: active, on_leave or terminated.",
+                    "type": "string",
+                    "example": "active"
+                },
+                "statusHistory": {
+                    "description": "StatusHistory lists the changes of the employment status, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StatusTransition"
+                    }
+                },
+                "temporaryRoles": {
+                    "description": "TemporaryRoles lists the roles that are removed automatically once they expire.\nEach of them is also present in Roles while it is active.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RoleGrant"
+                    }
+                },
+                "timezone": {
+                    "description": "Timezone is the optional IANA time zone the employee lives in.",
+                    "type": "string",
+                    "example": "Asia/Jerusalem"
+                },
+                "title": {
+                    "description": "Title is the optional job title of the employee.",
+                    "type": "string",
+                    "example": "Software Engineer"
+                },
+                "version": {
+                    "description": "Version is incremented on every update and used for optimistic concurrency control.",
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "models.ErrorResponse": {
+        "models.Team": {
             "type": "object",
             "properties": {
-                "error": {
-                    "description": "Error is the error message.",
+                "id": {
+                    "description": "ID identifies the team.",
                     "type": "string",
-                    "example": "Invalid request payload"
+                    "example": "6650c1f2a4d3e2b1c0f9e8d7"
+                },
+                "lead": {
+                    "description": "Lead is the email of the employee leading the team.",
+                    "type": "string",
+                    "example": "lead@s.afeka.ac.il"
+                },
+                "members": {
+                    "description": "Members lists the emails of the team members.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "janesmith@s.afeka.ac.il"
+                    ]
+                },
+                "name": {
+                    "description": "Name is the display name of the team.",
+                    "type": "string",
+                    "example": "Platform"
                 }
             }
         },
-        "models.ManagerEmailBoundary": {
+        "models.TeamRequest": {
             "type": "object",
+            "required": [
+                "lead"
+            ],
             "properties": {
-                "email": {
-                    "description": "The email of the manager.",
+                "lead": {
+                    "description": "Lead is the email of the employee leading the team.",
                     "type": "string",
-                    "example": "manager@s.example.com"
+                    "example": "lead@s.afeka.ac.il"
+                },
+                "members": {
+                    "description": "Members optionally lists the emails of the initial members.",
+                    "type": "array",
+                    "maxItems": 500,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "janesmith@s.afeka.ac.il"
+                    ]
+                },
+                "name": {
+                    "description": "Name is the display name of the team.",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Platform"
+                }
+            }
+        },
+        "models.WorkforceStats": {
+            "type": "object",
+            "properties": {
+                "ageDistribution": {
+                    "description": "AgeDistribution counts the employees per age range, youngest range first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AgeBucket"
+                    }
+                },
+                "averageAge": {
+                    "description": "AverageAge is the mean age of the employees in years, 0 when there are none.",
+                    "type": "number",
+                    "example": 36.4
+                },
+                "byEmailDomain": {
+                    "description": "ByEmailDomain counts the employees per domain of their email address, most common first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StatCount"
+                    }
+                },
+                "byManager": {
+                    "description": "ByManager counts the direct reports of each manager, largest team first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StatCount"
+                    }
+                },
+                "byRole": {
+                    "description": "ByRole counts the employees holding each role, most common first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StatCount"
+                    }
+                },
+                "headcount": {
+                    "description": "Headcount is the number of employees.",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "readonly.State": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Enabled is true while mutations are rejected.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "reason": {
+                    "description": "Reason explains to the clients whose mutations are rejected why, and is empty when none was given.",
+                    "type": "string",
+                    "example": "Migrating the employee records"
+                },
+                "since": {
+                    "description": "Since is when the mode was last switched on or off, and is unset until it is first switched.",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                }
+            }
+        },
+        "slo.Duration": {
+            "type": "integer",
+            "enum": [
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000,
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000,
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000
+            ],
+            "x-enum-varnames": [
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour",
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour",
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour"
+            ]
+        },
+        "slo.Objective": {
+            "type": "object",
+            "properties": {
+                "availabilityTarget": {
+                    "description": "AvailabilityTarget is the fraction of requests that must not fail with a 5xx status, e.g. 0.999.",
+                    "type": "number"
+                },
+                "latencyTarget": {
+                    "description": "LatencyTarget is the fraction of requests that must be faster than LatencyThreshold, e.g. 0.99.",
+                    "type": "number"
+                },
+                "latencyThreshold": {
+                    "description": "LatencyThreshold is the duration under which a request counts as fast.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/slo.Duration"
+                        }
+                    ]
+                },
+                "route": {
+                    "description": "Route is the HTTP method followed by the route template, e.g. \"GET /employees/:employeeEmail\".",
+                    "type": "string"
+                }
+            }
+        },
+        "slo.WindowStatus": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "type": "number"
+                },
+                "availabilityBurnRate": {
+                    "type": "number"
+                },
+                "latencyBurnRate": {
+                    "type": "number"
+                },
+                "latencyCompliance": {
+                    "type": "number"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "window": {
+                    "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BasicAuth": {
+            "type": "basic"
+        }
     }
 }`
 
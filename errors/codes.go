@@ -0,0 +1,152 @@
+package errors
+
+// Machine-readable error codes returned in the "code" field of every error response.
+// Codes are part of the API contract: clients branch on them, so existing codes must never change.
+const (
+	// Generic request errors.
+	CodeInternal          = "INTERNAL_SERVER_ERROR"
+	CodeInvalidPayload    = "INVALID_PAYLOAD"
+	CodePayloadTooLarge   = "PAYLOAD_TOO_LARGE"
+	CodeUnknownFields     = "UNKNOWN_FIELDS"
+	CodeInvalidPagination = "INVALID_PAGINATION"
+	CodeInvalidCriteria   = "INVALID_CRITERIA"
+	CodeMissingParameter  = "MISSING_PARAMETER"
+	CodeInvalidSort       = "INVALID_SORT"
+	CodeInvalidQuery      = "INVALID_QUERY"
+	CodeRejectedByPlugin  = "REJECTED_BY_PLUGIN"
+	CodeReadOnlyReplica   = "READ_ONLY_REPLICA"
+	CodeReadOnlyMode      = "READ_ONLY_MODE"
+	CodeRouteNotFound     = "ROUTE_NOT_FOUND"
+	CodeMethodNotAllowed  = "METHOD_NOT_ALLOWED"
+	CodeUnauthorized      = "UNAUTHORIZED"
+	CodeForbidden         = "FORBIDDEN"
+	CodeTenantRequired    = "TENANT_REQUIRED"
+	CodeInvalidTenant     = "INVALID_TENANT"
+	CodeInvalidLogLevel   = "INVALID_LOG_LEVEL"
+
+	// Employee errors.
+	CodeEmployeeNotFound        = "EMPLOYEE_NOT_FOUND"
+	CodeEmployeeDuplicateEmail  = "EMPLOYEE_DUPLICATE_EMAIL"
+	CodeEmployeeRequiredFields  = "EMPLOYEE_REQUIRED_FIELDS"
+	CodeEmployeeVersionMismatch = "EMPLOYEE_VERSION_MISMATCH"
+	CodeEmailMismatch           = "EMAIL_MISMATCH"
+	CodeEmployeeNotDeleted      = "EMPLOYEE_NOT_DELETED"
+	CodeInvalidEmail            = "INVALID_EMAIL"
+	CodeInvalidBirthdate        = "INVALID_BIRTHDATE"
+	CodeBirthdateInFuture       = "BIRTHDATE_IN_FUTURE"
+	CodeInvalidHireDate         = "INVALID_HIRE_DATE"
+	CodePasswordTooShort        = "PASSWORD_TOO_SHORT"
+	CodePasswordTooWeak         = "PASSWORD_TOO_WEAK"
+	CodePasswordCriterion       = "PASSWORD_SEARCH_NOT_ALLOWED"
+	CodeRolesRequired           = "ROLES_REQUIRED"
+	CodeInvalidMetadata         = "INVALID_METADATA"
+	CodeInvalidPhone            = "INVALID_PHONE"
+	CodeInvalidAddress          = "INVALID_ADDRESS"
+	CodeInvalidSkills           = "INVALID_SKILLS"
+	CodeInvalidTimezone         = "INVALID_TIMEZONE"
+	CodeInvalidLocale           = "INVALID_LOCALE"
+	CodeManagerNotFound         = "MANAGER_NOT_FOUND"
+	CodeManagerNotSet           = "MANAGER_NOT_SET"
+	CodeManagerSelf             = "MANAGER_SELF"
+	CodeManagerCycle            = "MANAGER_CYCLE"
+	CodeManagerRoleMissing      = "MANAGER_ROLE_MISSING"
+	CodeEmployeeHasReports      = "EMPLOYEE_HAS_REPORTS"
+	CodeReportingLineExists     = "REPORTING_LINE_EXISTS"
+	CodeReportingLineNotFound   = "REPORTING_LINE_NOT_FOUND"
+	CodeRoleAlreadyHeld         = "ROLE_ALREADY_HELD"
+	CodeGrantExpiryInPast       = "GRANT_EXPIRY_IN_PAST"
+	CodeDelegateNotFound        = "DELEGATE_NOT_FOUND"
+	CodeDelegateSelf            = "DELEGATE_SELF"
+	CodeInvalidDelegationWindow = "INVALID_DELEGATION_WINDOW"
+	CodeDelegationNotSet        = "DELEGATION_NOT_SET"
+	CodeLookupTooLarge          = "LOOKUP_TOO_LARGE"
+	CodeDepartmentNotFound      = "DEPARTMENT_NOT_FOUND"
+	CodeTitleNotFound           = "TITLE_NOT_FOUND"
+	CodeInvalidGrade            = "INVALID_GRADE"
+	CodeLocationNotFound        = "LOCATION_NOT_FOUND"
+	CodeInvalidCostCenter       = "INVALID_COST_CENTER"
+	CodeInvalidStatusTransition = "INVALID_STATUS_TRANSITION"
+
+	// Bulk operation errors.
+	CodeBulkEmpty       = "BULK_EMPTY"
+	CodeBulkTooLarge    = "BULK_TOO_LARGE"
+	CodeBulkInvalidMode = "BULK_INVALID_MODE"
+	CodeBulkSkipped     = "BULK_SKIPPED"
+
+	// Idempotency errors.
+	CodeIdempotencyKeyTooLong    = "IDEMPOTENCY_KEY_TOO_LONG"
+	CodeIdempotencyKeyReused     = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyKeyInProgress = "IDEMPOTENCY_KEY_IN_PROGRESS"
+
+	// Org chart errors.
+	CodeSnapshotNotFound = "SNAPSHOT_NOT_FOUND"
+	CodeSnapshotInvalid  = "SNAPSHOT_INVALID"
+
+	// Photo errors.
+	CodePhotoNotFound        = "PHOTO_NOT_FOUND"
+	CodePhotoTooLarge        = "PHOTO_TOO_LARGE"
+	CodePhotoUnsupportedType = "PHOTO_UNSUPPORTED_TYPE"
+	CodePhotoInvalid         = "PHOTO_INVALID"
+
+	// Document errors.
+	CodeDocumentNotFound        = "DOCUMENT_NOT_FOUND"
+	CodeDocumentTooLarge        = "DOCUMENT_TOO_LARGE"
+	CodeDocumentUnsupportedType = "DOCUMENT_UNSUPPORTED_TYPE"
+	CodeDocumentLinkInvalid     = "DOCUMENT_LINK_INVALID"
+	CodeDocumentLinkExpired     = "DOCUMENT_LINK_EXPIRED"
+
+	// Note errors.
+	CodeNoteNotFound       = "NOTE_NOT_FOUND"
+	CodeNoteRequiredFields = "NOTE_REQUIRED_FIELDS"
+	CodeNoteTooLong        = "NOTE_TOO_LONG"
+
+	// Certification errors.
+	CodeCertificationNotFound = "CERTIFICATION_NOT_FOUND"
+
+	// Leave errors.
+	CodeLeaveRequestNotFound = "LEAVE_REQUEST_NOT_FOUND"
+	CodeInvalidLeaveDates    = "INVALID_LEAVE_DATES"
+	CodeLeaveOverlap         = "LEAVE_OVERLAP"
+	CodeLeaveBalanceExceeded = "LEAVE_BALANCE_EXCEEDED"
+	CodeLeaveNotPending      = "LEAVE_NOT_PENDING"
+
+	// Attendance errors.
+	CodeAlreadyCheckedIn = "ALREADY_CHECKED_IN"
+	CodeNotCheckedIn     = "NOT_CHECKED_IN"
+
+	// Review errors.
+	CodeInvalidReviewPeriod = "INVALID_REVIEW_PERIOD"
+	CodeReviewExists        = "REVIEW_EXISTS"
+
+	// Department errors.
+	CodeDepartmentExists     = "DEPARTMENT_EXISTS"
+	CodeDepartmentInUse      = "DEPARTMENT_IN_USE"
+	CodeDepartmentNameChange = "DEPARTMENT_NAME_CHANGE"
+
+	// Role catalog errors.
+	CodeRoleNotFound       = "ROLE_NOT_FOUND"
+	CodeRoleExists         = "ROLE_EXISTS"
+	CodeRoleNameChange     = "ROLE_NAME_CHANGE"
+	CodeRoleParentNotFound = "ROLE_PARENT_NOT_FOUND"
+	CodeRoleCycle          = "ROLE_CYCLE"
+	CodeRoleHasChildren    = "ROLE_HAS_CHILDREN"
+
+	// Title catalog errors.
+	CodeTitleExists     = "TITLE_EXISTS"
+	CodeTitleInUse      = "TITLE_IN_USE"
+	CodeTitleNameChange = "TITLE_NAME_CHANGE"
+
+	// Location errors.
+	CodeLocationExists     = "LOCATION_EXISTS"
+	CodeLocationInUse      = "LOCATION_IN_USE"
+	CodeLocationNameChange = "LOCATION_NAME_CHANGE"
+
+	// Team errors.
+	CodeTeamNotFound       = "TEAM_NOT_FOUND"
+	CodeTeamLeadNotFound   = "TEAM_LEAD_NOT_FOUND"
+	CodeTeamMemberNotFound = "TEAM_MEMBER_NOT_FOUND"
+
+	// Client bundle errors.
+	CodeClientNotFound = "CLIENT_BUNDLE_NOT_FOUND"
+	CodeClientStale    = "CLIENT_BUNDLE_STALE"
+)
@@ -1,11 +1,17 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 // HTTPError represents an error with an associated HTTP status code.
 type HTTPError struct {
 	Code int
 	Msg  string
+	// ErrorCode is a stable, machine-readable identifier such as EMPLOYEE_DUPLICATE_EMAIL.
+	ErrorCode string
 }
 
 // Error implements the error interface.
@@ -14,9 +20,37 @@ func (e *HTTPError) Error() string {
 }
 
 // NewHTTPError creates a new HTTPError with the given code and message.
+// Its ErrorCode is the generic code for the status, e.g. NOT_FOUND.
 func NewHTTPError(code int, msg string) error {
 	return &HTTPError{
-		Code: code,
-		Msg:  msg,
+		Code:      code,
+		Msg:       msg,
+		ErrorCode: StatusCode(code),
 	}
-}
\ No newline at end of file
+}
+
+// NewCodedError creates a new HTTPError with the given status, machine-readable error code and message.
+func NewCodedError(code int, errorCode, msg string) error {
+	return &HTTPError{
+		Code:      code,
+		Msg:       msg,
+		ErrorCode: errorCode,
+	}
+}
+
+// StatusCode returns the generic error code for an HTTP status, e.g. BAD_REQUEST for 400.
+func StatusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return CodeInternal
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, text)
+}
@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage is the language of the messages passed to NewHTTPError and NewCodedError.
+const DefaultLanguage = "en"
+
+// Translator looks up the message of an error code in a language.
+type Translator interface {
+	// Translate returns the message for code in lang, or false when it has none.
+	Translate(lang, code string) (string, bool)
+}
+
+// Catalog is a Translator holding messages by language, then by error code.
+type Catalog map[string]map[string]string
+
+// Translate implements Translator.
+func (c Catalog) Translate(lang, code string) (string, bool) {
+	msg, ok := c[lang][code]
+	return msg, ok
+}
+
+// Translations localizes error messages. Replace it to plug in another catalog or translation service;
+// messages it cannot translate are sent in English.
+var Translations Translator = DefaultCatalog
+
+// Localize returns the message for code in the language the client prefers according to its Accept-Language
+// header, along with that language. It falls back to msg, in English, when no preferred language has a translation.
+// Translated messages describe the error code; the English message may carry more detail.
+func Localize(acceptLanguage, code, msg string) (string, string) {
+	for _, lang := range PreferredLanguages(acceptLanguage) {
+		if lang == DefaultLanguage {
+			break
+		}
+		if translated, ok := Translations.Translate(lang, code); ok {
+			return translated, lang
+		}
+	}
+	return msg, DefaultLanguage
+}
+
+// PreferredLanguages lists the primary language subtags of an Accept-Language header by decreasing quality,
+// e.g. "he-IL,en;q=0.8" yields he, en. Languages with quality 0 and the wildcard are left out.
+func PreferredLanguages(acceptLanguage string) []string {
+	type weighted struct {
+		lang    string
+		quality float64
+	}
+	var langs []weighted
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if lang == "" || lang == "*" || seen[lang] {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		seen[lang] = true
+		langs = append(langs, weighted{lang, quality})
+	}
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].quality > langs[j].quality })
+
+	preferred := make([]string, len(langs))
+	for i, l := range langs {
+		preferred[i] = l.lang
+	}
+	return preferred
+}
@@ -0,0 +1,61 @@
+package errors
+
+// DefaultCatalog holds the built-in translations of the error messages. English messages are the ones
+// passed when creating errors, so they are not listed.
+var DefaultCatalog = Catalog{
+	"he": {
+		CodeInternal:          "שגיאה פנימית בשרת",
+		CodeInvalidPayload:    "גוף הבקשה אינו תקין",
+		CodePayloadTooLarge:   "גוף הבקשה גדול מדי",
+		CodeUnknownFields:     "הבקשה מכילה שדות לא מוכרים",
+		CodeInvalidPagination: "פרמטרי העימוד אינם תקינים",
+		CodeInvalidCriteria:   "קריטריון הסינון אינו תקין",
+		CodeMissingParameter:  "חסר פרמטר חובה",
+		CodeInvalidSort:       "מפתח המיון אינו תקין",
+		CodeInvalidQuery:      "פרמטרי השאילתה אינם תקינים",
+		CodeReadOnlyReplica:   "שרת זה מאפשר קריאה בלבד",
+		CodeReadOnlyMode:      "השירות מאפשר כרגע קריאה בלבד",
+		CodeRouteNotFound:     "הנתיב המבוקש לא נמצא",
+		CodeMethodNotAllowed:  "השיטה אינה נתמכת בנתיב זה",
+		CodeUnauthorized:      "יש להזדהות עם כתובת הדוא\"ל והסיסמה של העובד",
+		CodeForbidden:         "אין לך הרשאה לבצע פעולה זו",
+		CodeTenantRequired:    "יש לציין את הארגון של הבקשה",
+		CodeInvalidTenant:     "מזהה הארגון אינו תקין",
+		CodeInvalidLogLevel:   "רמת הרישום אינה תקינה",
+
+		CodeEmployeeNotFound:        "העובד לא נמצא",
+		CodeEmployeeDuplicateEmail:  "קיים כבר עובד עם כתובת דוא\"ל זו",
+		CodeEmployeeRequiredFields:  "דוא\"ל ושם הם שדות חובה",
+		CodeEmployeeVersionMismatch: "העובד שונה על ידי בקשה אחרת",
+		CodeEmailMismatch:           "כתובת הדוא\"ל בגוף הבקשה אינה תואמת לכתובת בנתיב",
+		CodeEmployeeNotDeleted:      "העובד אינו מחוק",
+		CodeInvalidEmail:            "כתובת הדוא\"ל אינה תקינה",
+		CodeInvalidBirthdate:        "תאריך הלידה אינו תקין",
+		CodeBirthdateInFuture:       "תאריך הלידה אינו יכול להיות בעתיד",
+		CodeInvalidHireDate:         "תאריך הקליטה אינו תקין",
+		CodePasswordTooShort:        "הסיסמה קצרה מדי",
+		CodePasswordTooWeak:         "הסיסמה חלשה מדי",
+		CodePasswordCriterion:       "לא ניתן לחפש לפי סיסמה",
+		CodeRolesRequired:           "יש לציין לפחות תפקיד אחד",
+		CodeInvalidMetadata:         "השדות המותאמים אישית אינם תקינים",
+		CodeInvalidPhone:            "מספר הטלפון חייב להיות בתבנית E.164, למשל +972501234567",
+		CodeInvalidAddress:          "הכתובת חייבת לכלול עיר וקוד מדינה בן שתי אותיות",
+		CodeInvalidSkills:           "לכל מיומנות נדרשים שם ייחודי ורמה בין 1 ל-5",
+		CodeInvalidTimezone:         "אזור הזמן חייב להיות אזור זמן של IANA, למשל Asia/Jerusalem",
+		CodeInvalidLocale:           "השפה והאזור חייבים להיות תג BCP 47, למשל he-IL",
+		CodeManagerNotFound:         "המנהל לא נמצא",
+		CodeManagerNotSet:           "לעובד לא הוגדר מנהל",
+		CodeManagerSelf:             "עובד אינו יכול להיות המנהל של עצמו",
+		CodeManagerCycle:            "המנהל יוצר מעגל בשרשרת הניהול",
+		CodeManagerRoleMissing:      "למנהל אין תפקיד המאפשר ניהול עובדים",
+		CodeEmployeeHasReports:      "לעובד יש כפופים; יש להעביר אותם למנהל אחר או לנתק אותם תחילה",
+		CodeDepartmentNotFound:      "המחלקה לא נמצאה",
+		CodeTitleNotFound:           "התואר התפקידי לא נמצא",
+		CodeInvalidGrade:            "הדרגה אינה מתאימה לתואר התפקידי",
+		CodeLocationNotFound:        "המשרד לא נמצא",
+		CodeInvalidCostCenter:       "מרכז העלות אינו מוכר",
+		CodeInvalidStatusTransition: "לא ניתן להעביר את העובד למצב ההעסקה המבוקש",
+		CodeReportingLineExists:     "המנהל כבר מוגדר כמנהל הישיר של העובד",
+		CodeReportingLineNotFound:   "קו הדיווח לא נמצא",
+	},
+}
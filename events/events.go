@@ -0,0 +1,101 @@
+// Package events turns the changes to the employee collection, read from a MongoDB change stream, into events
+// delivered to in-process subscribers, such as webhooks, server-sent event streams and metrics.
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Operations changing employees.
+const (
+	OperationInsert  = "insert"
+	OperationUpdate  = "update"
+	OperationReplace = "replace"
+	OperationDelete  = "delete"
+)
+
+// Change is a change to an employee document. Soft deletes are updates; OperationDelete removes the document.
+type Change struct {
+	// Operation is one of OperationInsert, OperationUpdate, OperationReplace and OperationDelete.
+	Operation string
+	// DocumentID is the _id of the employee document.
+	DocumentID bson.ObjectID
+	// Employee is the employee as it is after the change, or as it is now when it changed again since. It is nil
+	// after a delete, or when the employee has been deleted since.
+	Employee *models.Employee
+	// Time is when the change was committed.
+	Time time.Time
+}
+
+// Hub fans changes out to its subscribers. It is safe for concurrent use.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[chan Change]struct{}
+	closed      bool
+	dropped     atomic.Int64
+}
+
+// NewHub creates a Hub without subscribers.
+func NewHub() *Hub {
+	return &Hub{subscribers: map[chan Change]struct{}{}}
+}
+
+// Subscribe returns a channel receiving the changes published from now on, holding up to buffer of them not yet
+// received. A subscriber falling further behind misses changes rather than hold up the others; see Dropped.
+// The channel is closed by cancel, or when the hub closes.
+func (h *Hub) Subscribe(buffer int) (<-chan Change, func()) {
+	ch := make(chan Change, buffer)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	h.subscribers[ch] = struct{}{}
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[ch]; ok {
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Publish delivers the change to every subscriber with room in its buffer.
+func (h *Hub) Publish(change Change) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- change:
+		default:
+			h.dropped.Add(1)
+		}
+	}
+}
+
+// Dropped returns how many deliveries were missed by subscribers whose buffer was full.
+func (h *Hub) Dropped() int64 {
+	return h.dropped.Load()
+}
+
+// Close closes the channels of every subscriber. Changes published afterwards are discarded.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for ch := range h.subscribers {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,43 @@
+package events
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics is a subscriber counting the employee changes by operation, as employee_changes_total, along with the
+// deliveries subscribers missed, as employee_change_deliveries_dropped_total.
+type Metrics struct {
+	changes *prometheus.CounterVec
+	dropped prometheus.CounterFunc
+}
+
+// NewMetrics subscribes the counters to hub. They stop counting when the hub closes.
+func NewMetrics(hub *Hub) *Metrics {
+	m := &Metrics{
+		changes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "employee_changes_total",
+			Help: "Number of changes to employees read from the MongoDB change stream, by operation.",
+		}, []string{"operation"}),
+		dropped: prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "employee_change_deliveries_dropped_total",
+			Help: "Number of employee changes subscribers missed because they were falling behind.",
+		}, func() float64 { return float64(hub.Dropped()) }),
+	}
+	changes, _ := hub.Subscribe(256)
+	go func() {
+		for change := range changes {
+			m.changes.WithLabelValues(change.Operation).Inc()
+		}
+	}()
+	return m
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.changes.Describe(ch)
+	m.dropped.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.changes.Collect(ch)
+	m.dropped.Collect(ch)
+}
@@ -0,0 +1,185 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Server error codes of change streams.
+const (
+	// changeStreamsUnsupported is returned by standalone servers, which have no change streams.
+	changeStreamsUnsupported = 40573
+	// changeStreamFatalError and changeStreamHistoryLost are returned when the resume token is older than the oplog.
+	changeStreamFatalError  = 280
+	changeStreamHistoryLost = 286
+)
+
+// tokenSaveInterval is how often the resume token is saved while changes flow. Changes read after the last save
+// are delivered again after a crash.
+const tokenSaveInterval = time.Second
+
+// maxBackoff caps the delay between attempts to reopen a failed change stream.
+const maxBackoff = time.Minute
+
+// Watcher reads the changes to the employee collection from a change stream and publishes them to a Hub. The
+// consumer names the position it saves, so each instance resumes where it stopped, delivering every change at
+// least once across restarts, as long as the oplog still holds it.
+type Watcher struct {
+	Employees *mongo.Collection
+	Tokens    *repository.ResumeTokenRepository
+	Consumer  string
+	Hub       *Hub
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// NewWatcher creates a Watcher publishing the changes to employees to hub, saving its position as consumer.
+func NewWatcher(employees *mongo.Collection, tokens *repository.ResumeTokenRepository, consumer string, hub *Hub) *Watcher {
+	return &Watcher{Employees: employees, Tokens: tokens, Consumer: consumer, Hub: hub}
+}
+
+// Start watches the changes in the background. Standalone servers have no change streams; there the watcher logs
+// that events are unavailable and stops.
+func (w *Watcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.wg.Add(1)
+	go w.run(ctx)
+}
+
+// Stop stops watching, saves the position reached and closes the hub.
+func (w *Watcher) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	w.Hub.Close()
+}
+
+// run reopens the change stream whenever it fails, waiting longer after each consecutive failure.
+func (w *Watcher) run(ctx context.Context) {
+	defer w.wg.Done()
+	backoff := time.Second
+	for {
+		err := w.watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		var serverErr mongo.ServerError
+		switch {
+		case err == nil:
+			// The stream was invalidated, e.g. by dropping the collection; the saved position is gone with it.
+			backoff = time.Second
+			if err := w.Tokens.Delete(ctx, w.Consumer); err != nil {
+				slog.Error("Failed to reset the employee change stream position", "error", err)
+			}
+			continue
+		case errors.As(err, &serverErr) && serverErr.HasErrorCode(changeStreamsUnsupported):
+			slog.Warn("MongoDB runs standalone: change streams, and the employee events read from them, are unavailable")
+			return
+		case errors.As(err, &serverErr) && (serverErr.HasErrorCode(changeStreamHistoryLost) || serverErr.HasErrorCode(changeStreamFatalError)):
+			slog.Warn("Employee changes were missed while the change stream was stopped; resuming from now", "error", err)
+			if err := w.Tokens.Delete(ctx, w.Consumer); err != nil {
+				slog.Error("Failed to reset the employee change stream position", "error", err)
+			}
+			continue
+		}
+		slog.Error("Employee change stream failed, reopening", "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+// changeEvent is a change stream event on the employee collection.
+type changeEvent struct {
+	OperationType string         `bson:"operationType"`
+	ClusterTime   bson.Timestamp `bson:"clusterTime"`
+	DocumentKey   struct {
+		ID bson.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument *models.Employee `bson:"fullDocument"`
+}
+
+// watch publishes the changes from the saved position until the stream fails or ctx is done. It returns nil when
+// the stream is invalidated.
+func (w *Watcher) watch(ctx context.Context) error {
+	token, err := w.Tokens.Load(ctx, w.Consumer)
+	if err != nil {
+		return err
+	}
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if token != nil {
+		opts.SetResumeAfter(token)
+	}
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"operationType": bson.M{"$in": bson.A{
+		OperationInsert, OperationUpdate, OperationReplace, OperationDelete,
+	}}}}}}
+	stream, err := w.Employees.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return err
+	}
+
+	// The position is saved at most once per tokenSaveInterval, and once more when the stream ends, even after ctx
+	// is done.
+	saved, savedAt := token, time.Now()
+	save := func(ctx context.Context) {
+		current := stream.ResumeToken()
+		if current == nil || bytes.Equal(current, saved) {
+			return
+		}
+		if err := w.Tokens.Save(ctx, w.Consumer, current); err != nil {
+			slog.Error("Failed to save the employee change stream position", "error", err)
+			return
+		}
+		saved, savedAt = current, time.Now()
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		save(ctx)
+		stream.Close(ctx)
+	}()
+
+	for stream.Next(ctx) {
+		var event changeEvent
+		if err := stream.Decode(&event); err != nil {
+			return err
+		}
+		switch event.OperationType {
+		case OperationInsert, OperationUpdate, OperationReplace:
+		case OperationDelete:
+			event.FullDocument = nil
+		default:
+			// An invalidate event, after which the stream ends.
+			continue
+		}
+		if event.FullDocument != nil {
+			event.FullDocument.Password = ""
+		}
+		w.Hub.Publish(Change{
+			Operation:  event.OperationType,
+			DocumentID: event.DocumentKey.ID,
+			Employee:   event.FullDocument,
+			Time:       time.Unix(int64(event.ClusterTime.T), 0).UTC(),
+		})
+		if time.Since(savedAt) >= tokenSaveInterval {
+			save(ctx)
+		}
+	}
+	return stream.Err()
+}
@@ -0,0 +1,94 @@
+// Package health reports whether an instance is alive and ready to serve, by checking the dependencies it cannot
+// serve without, so that orchestrators stop routing traffic to an instance whose database is unreachable.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Statuses of a Report and of its checks.
+const (
+	StatusOK      = "ok"
+	StatusFailing = "failing"
+)
+
+// CheckResult is the outcome of one check.
+type CheckResult struct {
+	// Name names the dependency checked, e.g. mongo.
+	Name string `json:"name" xml:"name" example:"mongo"`
+	// Status is ok or failing.
+	Status string `json:"status" xml:"status" example:"ok"`
+	// Error says why the check failed.
+	Error string `json:"error,omitempty" xml:"error,omitempty" example:"context deadline exceeded"`
+}
+
+// Report is the state of an instance: ok when every check passed, failing otherwise.
+type Report struct {
+	Status string        `json:"status" xml:"status" example:"ok"`
+	Checks []CheckResult `json:"checks,omitempty" xml:"checks>check,omitempty"`
+}
+
+// Ready reports whether every check passed.
+func (r Report) Ready() bool {
+	return r.Status == StatusOK
+}
+
+// Check checks a dependency, returning why it is unusable.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs the readiness checks of an instance.
+type Checker struct {
+	timeout time.Duration
+	mu      sync.Mutex
+	checks  []namedCheck
+}
+
+// NewChecker creates a Checker failing the checks that take longer than timeout.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add adds a check of the dependency named name.
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Ready runs the checks concurrently and reports their outcome, in the order they were added. An instance without
+// checks is ready.
+func (c *Checker) Ready(ctx context.Context) Report {
+	c.mu.Lock()
+	checks := c.checks
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	report := Report{Status: StatusOK, Checks: make([]CheckResult, len(checks))}
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = CheckResult{Name: check.name, Status: StatusOK}
+			if err := check.check(ctx); err != nil {
+				report.Checks[i].Status = StatusFailing
+				report.Checks[i].Error = err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+	for _, result := range report.Checks {
+		if result.Status != StatusOK {
+			report.Status = StatusFailing
+		}
+	}
+	return report
+}
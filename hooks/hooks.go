@@ -0,0 +1,191 @@
+// Package hooks lets deployments intercept employee operations without forking the service layer.
+//
+// A plugin is a Go package compiled into the binary that registers its hooks from init():
+//
+//	func init() {
+//		hooks.RegisterPlugin(hooks.PluginFunc("badge-numbers", func(r *hooks.Registry) {
+//			r.OnBeforeCreate(func(ctx context.Context, emp *models.Employee) error {
+//				// validate or enrich emp here
+//				return nil
+//			})
+//		}))
+//	}
+//
+// and is enabled by a blank import in cmd/webmvc_employees/plugins.go.
+package hooks
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"WebMVCEmployees/models"
+)
+
+// BeforeCreateHook runs after the built-in validation and before an employee is stored.
+// It may modify the employee; returning an error rejects the creation.
+type BeforeCreateHook func(ctx context.Context, emp *models.Employee) error
+
+// AfterCreateHook runs after an employee has been stored. The password is already removed.
+type AfterCreateHook func(ctx context.Context, emp models.Employee)
+
+// BeforeManagerSetHook runs before a manager is assigned; returning an error rejects the assignment.
+type BeforeManagerSetHook func(ctx context.Context, employeeEmail, managerEmail string) error
+
+// AfterManagerSetHook runs after a manager has been assigned.
+type AfterManagerSetHook func(ctx context.Context, employeeEmail, managerEmail string)
+
+// BeforeManagerRemoveHook runs before a manager is removed; returning an error rejects the removal.
+type BeforeManagerRemoveHook func(ctx context.Context, employeeEmail string) error
+
+// AfterManagerRemoveHook runs after a manager has been removed.
+type AfterManagerRemoveHook func(ctx context.Context, employeeEmail string)
+
+// Registry holds the hooks run by the service layer. It is safe for concurrent use.
+type Registry struct {
+	mu                  sync.RWMutex
+	beforeCreate        []BeforeCreateHook
+	afterCreate         []AfterCreateHook
+	beforeManagerSet    []BeforeManagerSetHook
+	afterManagerSet     []AfterManagerSetHook
+	beforeManagerRemove []BeforeManagerRemoveHook
+	afterManagerRemove  []AfterManagerRemoveHook
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Default is the registry plugins register with and the service uses unless configured otherwise.
+var Default = NewRegistry()
+
+// Plugin is a compiled-in extension that registers hooks.
+type Plugin interface {
+	Name() string
+	Register(r *Registry)
+}
+
+type pluginFunc struct {
+	name     string
+	register func(r *Registry)
+}
+
+func (p pluginFunc) Name() string         { return p.name }
+func (p pluginFunc) Register(r *Registry) { p.register(r) }
+
+// PluginFunc adapts a registration function into a named Plugin.
+func PluginFunc(name string, register func(r *Registry)) Plugin {
+	return pluginFunc{name: name, register: register}
+}
+
+// RegisterPlugin registers the hooks of a plugin with the Default registry.
+func RegisterPlugin(p Plugin) {
+	p.Register(Default)
+	slog.Info("Registered plugin", "plugin", p.Name())
+}
+
+// OnBeforeCreate adds a BeforeCreateHook.
+func (r *Registry) OnBeforeCreate(h BeforeCreateHook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.beforeCreate = append(r.beforeCreate, h)
+}
+
+// OnAfterCreate adds an AfterCreateHook.
+func (r *Registry) OnAfterCreate(h AfterCreateHook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.afterCreate = append(r.afterCreate, h)
+}
+
+// OnBeforeManagerSet adds a BeforeManagerSetHook.
+func (r *Registry) OnBeforeManagerSet(h BeforeManagerSetHook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.beforeManagerSet = append(r.beforeManagerSet, h)
+}
+
+// OnAfterManagerSet adds an AfterManagerSetHook.
+func (r *Registry) OnAfterManagerSet(h AfterManagerSetHook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.afterManagerSet = append(r.afterManagerSet, h)
+}
+
+// OnBeforeManagerRemove adds a BeforeManagerRemoveHook.
+func (r *Registry) OnBeforeManagerRemove(h BeforeManagerRemoveHook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.beforeManagerRemove = append(r.beforeManagerRemove, h)
+}
+
+// OnAfterManagerRemove adds an AfterManagerRemoveHook.
+func (r *Registry) OnAfterManagerRemove(h AfterManagerRemoveHook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.afterManagerRemove = append(r.afterManagerRemove, h)
+}
+
+// BeforeCreate runs the BeforeCreate hooks in registration order, stopping at the first error.
+func (r *Registry) BeforeCreate(ctx context.Context, emp *models.Employee) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, h := range r.beforeCreate {
+		if err := h(ctx, emp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AfterCreate runs the AfterCreate hooks in registration order.
+func (r *Registry) AfterCreate(ctx context.Context, emp models.Employee) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, h := range r.afterCreate {
+		h(ctx, emp)
+	}
+}
+
+// BeforeManagerSet runs the BeforeManagerSet hooks in registration order, stopping at the first error.
+func (r *Registry) BeforeManagerSet(ctx context.Context, employeeEmail, managerEmail string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, h := range r.beforeManagerSet {
+		if err := h(ctx, employeeEmail, managerEmail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AfterManagerSet runs the AfterManagerSet hooks in registration order.
+func (r *Registry) AfterManagerSet(ctx context.Context, employeeEmail, managerEmail string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, h := range r.afterManagerSet {
+		h(ctx, employeeEmail, managerEmail)
+	}
+}
+
+// BeforeManagerRemove runs the BeforeManagerRemove hooks in registration order, stopping at the first error.
+func (r *Registry) BeforeManagerRemove(ctx context.Context, employeeEmail string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, h := range r.beforeManagerRemove {
+		if err := h(ctx, employeeEmail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AfterManagerRemove runs the AfterManagerRemove hooks in registration order.
+func (r *Registry) AfterManagerRemove(ctx context.Context, employeeEmail string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, h := range r.afterManagerRemove {
+		h(ctx, employeeEmail)
+	}
+}
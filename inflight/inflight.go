@@ -0,0 +1,135 @@
+// Package inflight tracks the HTTP requests and MongoDB operations currently in progress, so operators
+// can tell when an instance has drained and is safe to roll.
+package inflight
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/v2/event"
+)
+
+// RouteInFlight is the number of requests in progress on a route.
+type RouteInFlight struct {
+	// Route is the HTTP method followed by the route template.
+	Route string `json:"route" xml:"route" example:"GET /employees"`
+	// Requests is the number of requests currently being served.
+	Requests int64 `json:"requests" xml:"requests" example:"3"`
+}
+
+// CommandInFlight is the number of MongoDB commands of one kind in progress.
+type CommandInFlight struct {
+	// Command is the MongoDB command name, e.g. find or insert.
+	Command string `json:"command" xml:"command" example:"find"`
+	// Operations is the number of commands awaiting a reply.
+	Operations int64 `json:"operations" xml:"operations" example:"1"`
+}
+
+// Report is a point-in-time view of the work in progress.
+type Report struct {
+	// Requests is the total number of HTTP requests in progress.
+	Requests int64 `json:"requests" xml:"requests" example:"3"`
+	// Routes breaks Requests down by route. Idle routes are omitted.
+	Routes []RouteInFlight `json:"routes" xml:"routes>route"`
+	// MongoOperations is the total number of MongoDB commands in progress.
+	MongoOperations int64 `json:"mongoOperations" xml:"mongoOperations" example:"1"`
+	// Mongo breaks MongoOperations down by command. Idle commands are omitted.
+	Mongo []CommandInFlight `json:"mongo" xml:"mongo>command"`
+}
+
+// Tracker counts requests and MongoDB commands in progress.
+type Tracker struct {
+	mu       sync.Mutex
+	routes   map[string]int64
+	commands map[string]int64
+
+	requestsDesc *prometheus.Desc
+	mongoDesc    *prometheus.Desc
+}
+
+// NewTracker creates an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{
+		routes:   make(map[string]int64),
+		commands: make(map[string]int64),
+		requestsDesc: prometheus.NewDesc("http_requests_in_flight",
+			"Number of HTTP requests currently being served.", []string{"route"}, nil),
+		mongoDesc: prometheus.NewDesc("mongo_operations_in_flight",
+			"Number of MongoDB commands awaiting a reply.", []string{"command"}, nil),
+	}
+}
+
+// Begin records the start of a request on a route.
+func (t *Tracker) Begin(route string) {
+	t.mu.Lock()
+	t.routes[route]++
+	t.mu.Unlock()
+}
+
+// End records the end of a request started with Begin.
+func (t *Tracker) End(route string) {
+	t.mu.Lock()
+	t.routes[route]--
+	t.mu.Unlock()
+}
+
+// CommandMonitor returns a MongoDB command monitor that counts commands in progress.
+func (t *Tracker) CommandMonitor() *event.CommandMonitor {
+	finished := func(name string) {
+		t.mu.Lock()
+		t.commands[name]--
+		t.mu.Unlock()
+	}
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			t.mu.Lock()
+			t.commands[e.CommandName]++
+			t.mu.Unlock()
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) { finished(e.CommandName) },
+		Failed:    func(_ context.Context, e *event.CommandFailedEvent) { finished(e.CommandName) },
+	}
+}
+
+// Report returns the work currently in progress, sorted by route and command.
+func (t *Tracker) Report() Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	report := Report{Routes: []RouteInFlight{}, Mongo: []CommandInFlight{}}
+	for route, n := range t.routes {
+		if n > 0 {
+			report.Requests += n
+			report.Routes = append(report.Routes, RouteInFlight{Route: route, Requests: n})
+		}
+	}
+	for command, n := range t.commands {
+		if n > 0 {
+			report.MongoOperations += n
+			report.Mongo = append(report.Mongo, CommandInFlight{Command: command, Operations: n})
+		}
+	}
+	sort.Slice(report.Routes, func(i, j int) bool { return report.Routes[i].Route < report.Routes[j].Route })
+	sort.Slice(report.Mongo, func(i, j int) bool { return report.Mongo[i].Command < report.Mongo[j].Command })
+	return report
+}
+
+// Describe implements prometheus.Collector.
+func (t *Tracker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- t.requestsDesc
+	ch <- t.mongoDesc
+}
+
+// Collect implements prometheus.Collector. Every route and command seen so far is exported,
+// including idle ones, so the gauges drop to zero instead of disappearing.
+func (t *Tracker) Collect(ch chan<- prometheus.Metric) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for route, n := range t.routes {
+		ch <- prometheus.MustNewConstMetric(t.requestsDesc, prometheus.GaugeValue, float64(n), route)
+	}
+	for command, n := range t.commands {
+		ch <- prometheus.MustNewConstMetric(t.mongoDesc, prometheus.GaugeValue, float64(n), command)
+	}
+}
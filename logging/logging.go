@@ -0,0 +1,63 @@
+// Package logging sets up the structured, leveled logs of the server: JSON lines in production, for log collectors,
+// and readable key=value lines in development. Each request carries a logger of its own, tagged with the fields of the
+// request, so that every line logged while serving it can be told apart.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// The formats of the logs.
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// New returns a logger writing the lines of level and above to w in the format, FormatJSON or FormatConsole.
+func New(w io.Writer, format string, level slog.Leveler) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{Level: level}
+	switch format {
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case FormatConsole:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q; use %s or %s", format, FormatJSON, FormatConsole)
+	}
+}
+
+// ParseLevel parses a level, such as debug, info, warn or error.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(s))
+	return level, err
+}
+
+// contextKey marks the context of a request with its logger.
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger ctx carries, or else the default logger.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// ToggleDebug lowers level to debug, or restores it to base when it is debug already, and returns the level set.
+func ToggleDebug(level *slog.LevelVar, base slog.Level) slog.Level {
+	if level.Level() == slog.LevelDebug {
+		level.Set(base)
+	} else {
+		level.Set(slog.LevelDebug)
+	}
+	return level.Level()
+}
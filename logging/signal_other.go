@@ -0,0 +1,8 @@
+//go:build !unix
+
+package logging
+
+import "log/slog"
+
+// ToggleDebugOnSignal does nothing: the platform has no SIGUSR1. The level is changed with PUT /admin/loglevel instead.
+func ToggleDebugOnSignal(level *slog.LevelVar, base slog.Level) {}
@@ -0,0 +1,22 @@
+//go:build unix
+
+package logging
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ToggleDebugOnSignal toggles level between debug and base with ToggleDebug each time the process receives SIGUSR1,
+// as with `kill -USR1 <pid>`, for debugging an incident without a restart.
+func ToggleDebugOnSignal(level *slog.LevelVar, base slog.Level) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+	go func() {
+		for range signals {
+			slog.Warn("Log level changed on SIGUSR1", "level", ToggleDebug(level, base))
+		}
+	}()
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+
+	"WebMVCEmployees/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLog logs every request once served with the logger of the request, set by Logging: the route template it
+// matched, its status, the bytes of the response body sent, how long it took and the IP of the client. Failures with
+// a 5xx status are logged as errors. Requests to the excluded paths, such as /metrics scraped every few seconds, are
+// not logged.
+func AccessLog(exclude ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if slices.Contains(exclude, ctx.Request.URL.Path) {
+			ctx.Next()
+			return
+		}
+		start := time.Now()
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		level := slog.LevelInfo
+		if status >= 500 {
+			level = slog.LevelError
+		}
+		logging.FromContext(ctx.Request.Context()).Log(ctx.Request.Context(), level, "Request served",
+			"route", ctx.FullPath(),
+			"status", status,
+			"bytes", max(ctx.Writer.Size(), 0),
+			"duration", time.Since(start),
+			"client_ip", ctx.ClientIP(),
+		)
+	}
+}
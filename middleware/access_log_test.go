@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// accessLogEngine returns an engine logging its requests as JSON lines to the buffer, leaving out the excluded paths.
+func accessLogEngine(t *testing.T, buf *bytes.Buffer, exclude ...string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	logger, err := logging.New(buf, logging.FormatJSON, slog.LevelDebug)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := gin.New()
+	r.Use(Logging(logger), AccessLog(exclude...))
+	r.GET("/employees/:employeeEmail", func(ctx *gin.Context) {
+		time.Sleep(10 * time.Millisecond)
+		ctx.String(http.StatusOK, "hello")
+	})
+	r.GET("/fail", func(ctx *gin.Context) {
+		ctx.Status(http.StatusServiceUnavailable)
+	})
+	r.GET("/healthz", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+	return r
+}
+
+// accessLogLines decodes the lines logged.
+func accessLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var fields map[string]any
+		if err := json.Unmarshal([]byte(line), &fields); err != nil {
+			t.Fatalf("expected a JSON line, got %q: %v", line, err)
+		}
+		lines = append(lines, fields)
+	}
+	return lines
+}
+
+func TestAccessLog_Fields(t *testing.T) {
+	var buf bytes.Buffer
+	r := accessLogEngine(t, &buf)
+	req := httptest.NewRequest(http.MethodGet, "/employees/jane.doe@example.com", nil)
+	req.RemoteAddr = "203.0.113.7:52000"
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := accessLogLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d: %s", len(lines), buf.String())
+	}
+	line := lines[0]
+	want := map[string]any{
+		"level":     "INFO",
+		"msg":       "Request served",
+		"method":    "GET",
+		"path":      "/employees/jane.doe@example.com",
+		"route":     "/employees/:employeeEmail",
+		"status":    float64(http.StatusOK),
+		"bytes":     float64(len("hello")),
+		"client_ip": "203.0.113.7",
+	}
+	for key, value := range want {
+		if line[key] != value {
+			t.Errorf("expected %s %v, got %v", key, value, line[key])
+		}
+	}
+	// Durations are logged in nanoseconds.
+	if duration, _ := line["duration"].(float64); time.Duration(duration) < 10*time.Millisecond {
+		t.Errorf("expected a duration of 10ms at least, got %v", line["duration"])
+	}
+}
+
+func TestAccessLog_ServerErrors(t *testing.T) {
+	var buf bytes.Buffer
+	r := accessLogEngine(t, &buf)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	lines := accessLogLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d: %s", len(lines), buf.String())
+	}
+	if lines[0]["level"] != "ERROR" || lines[0]["bytes"] != float64(0) {
+		t.Errorf("expected an error with no bytes sent, got level %v and bytes %v", lines[0]["level"], lines[0]["bytes"])
+	}
+}
+
+func TestAccessLog_UnmatchedRoute(t *testing.T) {
+	var buf bytes.Buffer
+	r := accessLogEngine(t, &buf)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	lines := accessLogLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d: %s", len(lines), buf.String())
+	}
+	if lines[0]["route"] != "" || lines[0]["status"] != float64(http.StatusNotFound) {
+		t.Errorf("expected a 404 without a route, got route %v and status %v", lines[0]["route"], lines[0]["status"])
+	}
+}
+
+func TestAccessLog_Exclude(t *testing.T) {
+	var buf bytes.Buffer
+	r := accessLogEngine(t, &buf, "/healthz")
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if buf.Len() != 0 {
+		t.Fatalf("expected the excluded path not to be logged, got %s", buf.String())
+	}
+
+	// Only the exact path is excluded.
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz/details", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/employees/jane.doe@example.com", nil))
+	if lines := accessLogLines(t, &buf); len(lines) != 2 {
+		t.Errorf("expected the other paths to be logged, got %d lines: %s", len(lines), buf.String())
+	}
+}
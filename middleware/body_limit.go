@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"WebMVCEmployees/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultBodyLimit is the largest request body accepted when no limit is configured (1 MiB).
+const DefaultBodyLimit int64 = 1 << 20
+
+// BodyLimit rejects request bodies larger than limit bytes with 413. Bodies announcing their size
+// are rejected before any of it is read; the others are cut off once the limit is exceeded, so an
+// oversized upload is never buffered in full.
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.ContentLength > limit {
+			abortWithError(ctx, http.StatusRequestEntityTooLarge, errors.CodePayloadTooLarge, "Request body is too large")
+			return
+		}
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,13 @@
+package middleware
+
+import (
+	"WebMVCEmployees/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// abortWithError aborts the request with an error response carrying a machine-readable code.
+// The message is translated when the client prefers another language.
+func abortWithError(ctx *gin.Context, status int, code, msg string) {
+	negotiate.AbortWithError(ctx, status, code, msg)
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/logging"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/tenant"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader is the request header carrying the client-chosen idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength bounds the size of client-provided keys.
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyStore persists the first response sent for each idempotency key.
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, key, fingerprint string) (models.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, key string, status int, contentType, location string, body []byte) error
+	Release(ctx context.Context, key string) error
+}
+
+// responseRecorder captures the response body while writing it to the client.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays the stored response when a request is retried with the same Idempotency-Key.
+// Requests without the header are processed normally. Reusing a key with a different body is rejected
+// with 422, and a retry arriving while the original request is still running gets 409.
+// Server errors are not stored, so the request can be retried with the same key.
+func Idempotency(store IdempotencyStore) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			abortWithError(ctx, http.StatusBadRequest, errors.CodeIdempotencyKeyTooLong, "Idempotency-Key is too long")
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if stderrors.As(err, &tooLarge) {
+				abortWithError(ctx, http.StatusRequestEntityTooLarge, errors.CodePayloadTooLarge, "Request body is too large")
+				return
+			}
+			abortWithError(ctx, http.StatusBadRequest, errors.CodeInvalidPayload, "Invalid request payload")
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(body)
+		fingerprint := hex.EncodeToString(sum[:])
+		scopedKey := ctx.Request.Method + " " + ctx.Request.URL.Path + " " + key
+		if id, ok := tenant.FromContext(ctx.Request.Context()); ok {
+			// Tenants choose their keys independently; one must never be served the response stored for another.
+			scopedKey = id + ":" + scopedKey
+		}
+
+		record, created, err := store.Reserve(ctx.Request.Context(), scopedKey, fingerprint)
+		if err != nil {
+			abortWithError(ctx, http.StatusInternalServerError, errors.CodeInternal, "Internal server error")
+			return
+		}
+		if !created {
+			switch {
+			case record.Fingerprint != fingerprint:
+				abortWithError(ctx, http.StatusUnprocessableEntity, errors.CodeIdempotencyKeyReused, "Idempotency-Key was already used with a different payload")
+			case !record.Completed:
+				abortWithError(ctx, http.StatusConflict, errors.CodeIdempotencyKeyInProgress, "A request with this Idempotency-Key is still being processed")
+			default:
+				ctx.Header("Idempotent-Replayed", "true")
+				if record.Location != "" {
+					ctx.Header("Location", record.Location)
+				}
+				ctx.Data(record.Status, record.ContentType, record.Body)
+				ctx.Abort()
+			}
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+
+		// Persist the outcome even if the client has already disconnected.
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx.Request.Context()), 5*time.Second)
+		defer cancel()
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			err = store.Release(storeCtx, scopedKey)
+		} else {
+			err = store.Complete(storeCtx, scopedKey, status, recorder.Header().Get("Content-Type"), recorder.Header().Get("Location"), recorder.body.Bytes())
+		}
+		if err != nil {
+			logging.FromContext(ctx.Request.Context()).Error("Failed to store idempotent response", "key", key, "error", err)
+		}
+	}
+}
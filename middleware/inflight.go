@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"WebMVCEmployees/inflight"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InFlight counts the requests in progress per route. Requests to unmatched paths and to the
+// routes listed in skip (e.g. the endpoints reporting the counts) are not counted.
+func InFlight(tracker *inflight.Tracker, skip ...string) gin.HandlerFunc {
+	skipped := make(map[string]bool, len(skip))
+	for _, route := range skip {
+		skipped[route] = true
+	}
+	return func(ctx *gin.Context) {
+		route := ctx.Request.Method + " " + ctx.FullPath()
+		if ctx.FullPath() == "" || skipped[route] {
+			ctx.Next()
+			return
+		}
+		tracker.Begin(route)
+		defer tracker.End(route)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"log/slog"
+
+	"WebMVCEmployees/logging"
+	"WebMVCEmployees/requestid"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Logging gives each request a logger tagged with its method, path and ID, set by RequestID, and with the IDs of its
+// trace and span when traced, which the handlers read with logging.FromContext.
+func Logging(logger *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestLogger := logger.With("method", ctx.Request.Method, "path", ctx.Request.URL.Path)
+		if id, ok := requestid.FromContext(ctx.Request.Context()); ok {
+			requestLogger = requestLogger.With("request_id", id)
+		}
+		if span := trace.SpanContextFromContext(ctx.Request.Context()); span.IsValid() {
+			requestLogger = requestLogger.With("trace_id", span.TraceID().String(), "span_id", span.SpanID().String())
+		}
+		ctx.Request = ctx.Request.WithContext(logging.NewContext(ctx.Request.Context(), requestLogger))
+		ctx.Next()
+	}
+}
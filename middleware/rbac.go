@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"WebMVCEmployees/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CallerKey is the context key holding the email of the caller authenticated by RequireSignIn or RequireRoles.
+const CallerKey = "caller"
+
+// CallerRolesKey is the context key holding the effective roles of the authenticated caller.
+const CallerRolesKey = "callerRoles"
+
+// AdminRole is the role allowed to set the roles of employees and to administer the server.
+const AdminRole = "Admin"
+
+// Authenticator identifies the callers of protected routes.
+type Authenticator interface {
+	// Authenticate returns the effective roles of the employee with the given email and password,
+	// and false when the credentials match no employee allowed to sign in.
+	Authenticate(ctx context.Context, email, password string) ([]string, bool, error)
+}
+
+// RequireSignIn lets through any caller signing in with HTTP Basic credentials, their employee email and
+// password, and answers missing or wrong credentials with 401.
+func RequireSignIn(auth Authenticator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, ok := signIn(ctx, auth); ok {
+			ctx.Next()
+		}
+	}
+}
+
+// RequireRoles lets through only the callers holding one of the roles. Callers sign in with HTTP Basic
+// credentials, their employee email and password. Missing or wrong credentials are answered with 401,
+// and callers without any of the roles with 403.
+func RequireRoles(auth Authenticator, roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if Authorize(ctx, auth, roles...) {
+			ctx.Next()
+		}
+	}
+}
+
+// Authorize is RequireRoles for handlers that only restrict some requests, such as those setting roles.
+// It reports whether the caller holds one of the roles, and otherwise aborts the request with 401 or 403.
+func Authorize(ctx *gin.Context, auth Authenticator, roles ...string) bool {
+	held, ok := signIn(ctx, auth)
+	if !ok {
+		return false
+	}
+	if !holdsAny(held, roles) {
+		abortWithError(ctx, http.StatusForbidden, errors.CodeForbidden, "Requires one of the roles "+strings.Join(roles, ", "))
+		return false
+	}
+	return true
+}
+
+// AuthorizeSelf is Authorize for handlers letting employees act on their own record, such as changing their
+// password: the caller signed in as the employee with the email is let through as well.
+func AuthorizeSelf(ctx *gin.Context, auth Authenticator, email string, roles ...string) bool {
+	held, ok := signIn(ctx, auth)
+	if !ok {
+		return false
+	}
+	if ctx.GetString(CallerKey) != email && !holdsAny(held, roles) {
+		abortWithError(ctx, http.StatusForbidden, errors.CodeForbidden, "Requires signing in as "+email+" or one of the roles "+strings.Join(roles, ", "))
+		return false
+	}
+	return true
+}
+
+// holdsAny reports whether any of the held roles is one of roles.
+func holdsAny(held, roles []string) bool {
+	return slices.ContainsFunc(held, func(role string) bool { return slices.Contains(roles, role) })
+}
+
+// signIn authenticates the caller from the HTTP Basic credentials and stores their email under CallerKey and
+// their roles under CallerRolesKey.
+// It returns the caller's effective roles, or aborts the request and returns false.
+func signIn(ctx *gin.Context, auth Authenticator) ([]string, bool) {
+	email, password, ok := ctx.Request.BasicAuth()
+	if !ok {
+		ctx.Header("WWW-Authenticate", `Basic realm="employees"`)
+		abortWithError(ctx, http.StatusUnauthorized, errors.CodeUnauthorized, "Sign in with your employee email and password")
+		return nil, false
+	}
+	held, ok, err := auth.Authenticate(ctx.Request.Context(), email, password)
+	if err != nil {
+		abortWithError(ctx, http.StatusInternalServerError, errors.CodeInternal, err.Error())
+		return nil, false
+	}
+	if !ok {
+		ctx.Header("WWW-Authenticate", `Basic realm="employees"`)
+		abortWithError(ctx, http.StatusUnauthorized, errors.CodeUnauthorized, "Invalid email or password")
+		return nil, false
+	}
+	ctx.Set(CallerKey, email)
+	ctx.Set(CallerRolesKey, held)
+	return held, true
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/readonly"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadOnlyReplica rejects every mutating request with 405 so an instance pointed at a read replica
+// can never write. GET, HEAD and OPTIONS requests are served, as are the routes listed in allowed,
+// given as method and route template (e.g. "POST /employees/query") for read operations sent with a body.
+func ReadOnlyReplica(allowed ...string) gin.HandlerFunc {
+	allowedRoutes := make(map[string]bool, len(allowed))
+	for _, route := range allowed {
+		allowedRoutes[route] = true
+	}
+	return func(ctx *gin.Context) {
+		switch ctx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			ctx.Next()
+			return
+		}
+		if allowedRoutes[ctx.Request.Method+" "+ctx.FullPath()] {
+			ctx.Next()
+			return
+		}
+		ctx.Header("Allow", "GET, HEAD, OPTIONS")
+		abortWithError(ctx, http.StatusMethodNotAllowed, errors.CodeReadOnlyReplica, "This instance is a read-only replica")
+	}
+}
+
+// ReadOnlyMode rejects every mutating request with 503 while the switch is on, explaining why with the reason it was
+// switched on for, and serves every request while it is off. GET, HEAD and OPTIONS requests are always served, as are
+// the routes listed in allowed, given as for ReadOnlyReplica, such as the one switching the mode off.
+func ReadOnlyMode(mode *readonly.Switch, allowed ...string) gin.HandlerFunc {
+	allowedRoutes := make(map[string]bool, len(allowed))
+	for _, route := range allowed {
+		allowedRoutes[route] = true
+	}
+	return func(ctx *gin.Context) {
+		state := mode.State()
+		if !state.Enabled {
+			ctx.Next()
+			return
+		}
+		switch ctx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			ctx.Next()
+			return
+		}
+		if allowedRoutes[ctx.Request.Method+" "+ctx.FullPath()] {
+			ctx.Next()
+			return
+		}
+		message := "The service is in read-only mode; try again later"
+		if state.Reason != "" {
+			message = "The service is in read-only mode: " + state.Reason
+		}
+		abortWithError(ctx, http.StatusServiceUnavailable, errors.CodeReadOnlyMode, message)
+	}
+}
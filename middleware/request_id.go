@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"WebMVCEmployees/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader is the header carrying the ID of a request, given by a client or a proxy, and of its response.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDKey is the key of the request ID in the Gin context, read with ctx.GetString.
+const RequestIDKey = "requestId"
+
+// RequestID identifies each request by the ID of its X-Request-ID header, or by a new one when the header is
+// missing or invalid. The ID is set in the Gin context and in the request context, read with requestid.FromContext,
+// which tags the log lines and the error responses of the request, and is returned in the X-Request-ID header.
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(RequestIDHeader)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+		ctx.Set(RequestIDKey, id)
+		ctx.Request = ctx.Request.WithContext(requestid.NewContext(ctx.Request.Context(), id))
+		ctx.Header(RequestIDHeader, id)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"time"
+
+	"WebMVCEmployees/slo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SLO records the latency and status of every request against the route objectives of the tracker.
+// Requests are keyed by method and route template, e.g. "GET /employees/:employeeEmail".
+func SLO(tracker *slo.Tracker) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+		if ctx.FullPath() == "" {
+			return
+		}
+		tracker.Record(ctx.Request.Method+" "+ctx.FullPath(), time.Since(start), ctx.Writer.Status())
+	}
+}
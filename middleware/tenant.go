@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/tenant"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Tenant makes each request for the tenant whose ID the header carries, rejecting with 400 the requests without one
+// or with an invalid one. The handlers reach the employees of that tenant only.
+func Tenant(header string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(header)
+		if id == "" {
+			abortWithError(ctx, http.StatusBadRequest, errors.CodeTenantRequired, header+" header is required")
+			return
+		}
+		if !tenant.Valid(id) {
+			abortWithError(ctx, http.StatusBadRequest, errors.CodeInvalidTenant, "Invalid "+header+" header")
+			return
+		}
+		ctx.Request = ctx.Request.WithContext(tenant.NewContext(ctx.Request.Context(), id))
+		ctx.Next()
+	}
+}
+
+// OperatorTenant makes each request for the tenant with the ID, or for the employees of no tenant when it is empty.
+// It guards the routes administering the whole deployment, so that their callers are authenticated among its operators
+// rather than among the employees of every tenant.
+func OperatorTenant(id string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Request = ctx.Request.WithContext(tenant.NewContext(ctx.Request.Context(), id))
+		ctx.Next()
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+// Anniversary is an employee celebrating a work anniversary.
+// swagger:model Anniversary
+type Anniversary struct {
+	// Email is the email of the employee.
+	Email string `json:"email" xml:"email" bson:"email" example:"jane.doe@s.example.com"`
+	// Name is the name of the employee.
+	Name string `json:"name" xml:"name" bson:"name" example:"Jane Doe"`
+	// HireDate is the day the employee was hired, formatted as YYYY-MM-DD.
+	HireDate string `json:"hireDate" xml:"hireDate" bson:"hireDate" example:"2020-06-15"`
+	// Date is the day of the anniversary this year, formatted as YYYY-MM-DD.
+	Date string `json:"date" xml:"date" bson:"date" example:"2025-06-15"`
+	// YearsOfService is the number of years the employee completes on the anniversary.
+	YearsOfService int `json:"yearsOfService" xml:"yearsOfService" bson:"yearsOfService" example:"5"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// Periods of an attendance summary.
+const (
+	AttendanceDaily  = "day"
+	AttendanceWeekly = "week"
+)
+
+// AttendanceRecord is a stretch of work of an employee, from check-in to check-out.
+// swagger:model AttendanceRecord
+type AttendanceRecord struct {
+	// ID identifies the record.
+	ID string `json:"id" xml:"id" bson:"_id" example:"6650c1f2a4d3e2b1c0f9e8d7"`
+	// Employee is the email of the employee who checked in.
+	Employee string `json:"employee" xml:"employee" bson:"employee" example:"janesmith@s.afeka.ac.il"`
+	// CheckIn is when the employee checked in.
+	CheckIn time.Time `json:"checkIn" xml:"checkIn" bson:"checkIn"`
+	// CheckOut is when the employee checked out; it is missing while the employee is checked in.
+	CheckOut *time.Time `json:"checkOut,omitempty" xml:"checkOut,omitempty" bson:"checkOut,omitempty"`
+	// Open is set while the employee is checked in; a unique index allows one open record per employee.
+	Open bool `json:"-" xml:"-" bson:"open,omitempty"`
+}
+
+// AttendanceDay is the time an employee worked on one day.
+// swagger:model AttendanceDay
+type AttendanceDay struct {
+	// Date is the day, formatted as YYYY-MM-DD.
+	Date string `json:"date" xml:"date" example:"2025-03-03"`
+	// Minutes is the time worked in the records checked in that day.
+	Minutes int `json:"minutes" xml:"minutes" example:"480"`
+	// Records is the number of records checked in that day.
+	Records int `json:"records" xml:"records" example:"2"`
+	// CheckedIn is set when a record of the day is still open; its time counts up to now.
+	CheckedIn bool `json:"checkedIn,omitempty" xml:"checkedIn,omitempty" example:"false"`
+}
+
+// AttendanceSummary is the time an employee worked in a day or a week.
+// swagger:model AttendanceSummary
+type AttendanceSummary struct {
+	// Employee is the email of the employee.
+	Employee string `json:"employee" xml:"employee" example:"janesmith@s.afeka.ac.il"`
+	// Period is day or week.
+	Period string `json:"period" xml:"period" example:"week"`
+	// From is the first day of the period, formatted as YYYY-MM-DD; weeks start on Monday.
+	From string `json:"from" xml:"from" example:"2025-03-03"`
+	// To is the last day of the period, formatted as YYYY-MM-DD.
+	To string `json:"to" xml:"to" example:"2025-03-09"`
+	// TotalMinutes is the time worked in the period.
+	TotalMinutes int `json:"totalMinutes" xml:"totalMinutes" example:"2400"`
+	// Days lists every day of the period, in order.
+	Days []AttendanceDay `json:"days" xml:"days>day"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Audit log actions.
+const (
+	AuditRoleGranted       = "role.granted"
+	AuditRoleRevoked       = "role.revoked"
+	AuditDelegationSet     = "delegation.set"
+	AuditDelegationRemoved = "delegation.removed"
+	AuditEmployeeDeleted   = "employee.deleted"
+	AuditEmployeeRestored  = "employee.restored"
+	AuditEmployeePurged    = "employee.purged"
+	AuditStatusChanged     = "employee.status_changed"
+	AuditEmailChanged      = "employee.email_changed"
+)
+
+// AuditEntry records a change made to an employee.
+// swagger:model AuditEntry
+type AuditEntry struct {
+	// Time is when the change happened.
+	Time time.Time `json:"time" xml:"time" bson:"time"`
+	// Action identifies the kind of change, e.g. role.granted.
+	Action string `json:"action" xml:"action" bson:"action" example:"role.granted"`
+	// Employee is the email of the employee that was changed.
+	Employee string `json:"employee" xml:"employee" bson:"employee" example:"janesmith@s.afeka.ac.il"`
+	// Details holds action-specific values such as the role and its expiry.
+	Details AuditDetails `json:"details,omitempty" xml:"details,omitempty" bson:"details,omitempty"`
+}
+
+// AuditDetails holds the action-specific values of an audit entry.
+type AuditDetails map[string]string
+
+// MarshalXML encodes the details as one element per key, in key order.
+func (d AuditDetails) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeStringMap(e, start, d)
+}
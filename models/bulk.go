@@ -0,0 +1,55 @@
+package models
+
+// Bulk error handling modes selectable per request.
+const (
+	// BulkModeContinue processes every item and reports each outcome.
+	BulkModeContinue = "continue"
+	// BulkModeAbort stops at the first failing item; the remaining items are reported as skipped.
+	BulkModeAbort = "abort"
+)
+
+// RoleAssignment adds roles to a single employee in a bulk role assignment.
+// swagger:model
+type RoleAssignment struct {
+	// Email of the employee receiving the roles.
+	Email string `json:"email" xml:"email" example:"janesmith@s.afeka.ac.il"`
+	// Roles to add to the employee.
+	Roles []string `json:"roles" xml:"roles>role" example:"DevOps,R&D"`
+}
+
+// ManagerAssignment sets the manager of a single employee in a bulk manager assignment.
+// swagger:model
+type ManagerAssignment struct {
+	// Email of the employee.
+	Email string `json:"email" xml:"email" example:"janesmith@s.afeka.ac.il"`
+	// Email of the manager to assign.
+	Manager string `json:"manager" xml:"manager" example:"manager@s.example.com"`
+}
+
+// BulkItemResult is the outcome of a single item of a bulk operation.
+// swagger:model
+type BulkItemResult struct {
+	// Index is the position of the item in the request.
+	Index int `json:"index" xml:"index" example:"0"`
+	// Email identifies the employee the item refers to.
+	Email string `json:"email,omitempty" xml:"email,omitempty" example:"janesmith@s.afeka.ac.il"`
+	// Status is the HTTP status the item would have produced on its own.
+	Status int `json:"status" xml:"status" example:"200"`
+	// Code is the machine-readable error code of a failed or skipped item.
+	Code string `json:"code,omitempty" xml:"code,omitempty" example:"EMPLOYEE_NOT_FOUND"`
+	// Error describes why the item failed or was skipped.
+	Error string `json:"error,omitempty" xml:"error,omitempty" example:"employee not found"`
+}
+
+// BulkResponse is the multi-status body returned by every bulk endpoint.
+// swagger:model
+type BulkResponse struct {
+	// Succeeded is the number of items that were applied.
+	Succeeded int `json:"succeeded" xml:"succeeded" example:"2"`
+	// Failed is the number of items that were rejected.
+	Failed int `json:"failed" xml:"failed" example:"1"`
+	// Skipped is the number of items not attempted because the request aborted.
+	Skipped int `json:"skipped" xml:"skipped" example:"0"`
+	// Results lists the outcome of every item in request order.
+	Results []BulkItemResult `json:"results" xml:"results>result"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// CertificationRequest is the payload adding or replacing a certification of an employee.
+// swagger:model CertificationRequest
+type CertificationRequest struct {
+	// Name is the name of the certification.
+	Name string `json:"name" xml:"name" validate:"notblank,max=128" example:"Certified Kubernetes Administrator"`
+	// Issuer is the organization that granted the certification.
+	Issuer string `json:"issuer" xml:"issuer" validate:"notblank,max=128" example:"CNCF"`
+	// ExpiresAt is the day, formatted as YYYY-MM-DD, the certification expires; empty when it never does.
+	ExpiresAt string `json:"expiresAt,omitempty" xml:"expiresAt,omitempty" validate:"omitempty,datetime=2006-01-02" example:"2026-06-30"`
+}
+
+// Certification is a certification held by an employee.
+// swagger:model Certification
+type Certification struct {
+	// ID identifies the certification.
+	ID string `json:"id" xml:"id" bson:"_id" example:"6650c1f2a4d3e2b1c0f9e8d7"`
+	// Employee is the email of the employee holding the certification.
+	Employee string `json:"employee" xml:"employee" bson:"employee" example:"janesmith@s.afeka.ac.il"`
+	// Name is the name of the certification.
+	Name string `json:"name" xml:"name" bson:"name" example:"Certified Kubernetes Administrator"`
+	// Issuer is the organization that granted the certification.
+	Issuer string `json:"issuer" xml:"issuer" bson:"issuer" example:"CNCF"`
+	// ExpiresAt is the day, formatted as YYYY-MM-DD, the certification expires; empty when it never does.
+	ExpiresAt string `json:"expiresAt,omitempty" xml:"expiresAt,omitempty" bson:"expiresAt,omitempty" example:"2026-06-30"`
+	// UpdatedAt is when the certification was last added or replaced.
+	UpdatedAt time.Time `json:"updatedAt" xml:"updatedAt" bson:"updatedAt"`
+}
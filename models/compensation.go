@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// CompensationRequest is the payload setting the compensation of an employee from a given date.
+// swagger:model CompensationRequest
+type CompensationRequest struct {
+	// Salary is the yearly salary, in Currency.
+	Salary float64 `json:"salary" xml:"salary" validate:"gt=0" example:"120000"`
+	// Currency is the ISO 4217 code of the salary currency.
+	Currency string `json:"currency" xml:"currency" validate:"iso4217" example:"ILS"`
+	// EffectiveDate is the day, formatted as YYYY-MM-DD, from which the salary applies.
+	EffectiveDate string `json:"effectiveDate" xml:"effectiveDate" validate:"required,datetime=2006-01-02" example:"2025-01-01"`
+}
+
+// CompensationRecord is a salary of an employee, effective from a given date.
+// swagger:model CompensationRecord
+type CompensationRecord struct {
+	// Employee is the email of the employee paid the salary.
+	Employee string `json:"employee" xml:"employee" bson:"employee" example:"janesmith@s.afeka.ac.il"`
+	// Salary is the yearly salary, in Currency.
+	Salary float64 `json:"salary" xml:"salary" bson:"salary" example:"120000"`
+	// Currency is the ISO 4217 code of the salary currency.
+	Currency string `json:"currency" xml:"currency" bson:"currency" example:"ILS"`
+	// EffectiveDate is the day, formatted as YYYY-MM-DD, from which the salary applies.
+	EffectiveDate string `json:"effectiveDate" xml:"effectiveDate" bson:"effectiveDate" example:"2025-01-01"`
+	// RecordedAt is when the salary was last set.
+	RecordedAt time.Time `json:"recordedAt" xml:"recordedAt" bson:"recordedAt"`
+	// RecordedBy is the email of the employee who last set the salary.
+	RecordedBy string `json:"recordedBy,omitempty" xml:"recordedBy,omitempty" bson:"recordedBy,omitempty" example:"hr@s.afeka.ac.il"`
+}
+
+// Compensation is the current salary of an employee together with every salary recorded for them.
+// swagger:model Compensation
+type Compensation struct {
+	// Current is the salary in effect today; absent when no salary has taken effect yet.
+	Current *CompensationRecord `json:"current,omitempty" xml:"current,omitempty"`
+	// History lists every salary recorded, latest effective date first.
+	History []CompensationRecord `json:"history" xml:"history>record"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Delegation hands a manager's duties to another employee for a time window.
+// swagger:model Delegation
+type Delegation struct {
+	// Delegate is the email of the employee acting as manager during the window.
+	Delegate string `json:"delegate" xml:"delegate" bson:"delegate" example:"deputy@s.example.com"`
+	// StartsAt is when the delegation takes effect.
+	StartsAt time.Time `json:"startsAt" xml:"startsAt" bson:"startsAt" example:"2025-07-01T00:00:00Z"`
+	// EndsAt is when the delegation ends and duties revert to the manager.
+	EndsAt time.Time `json:"endsAt" xml:"endsAt" bson:"endsAt" example:"2025-07-15T00:00:00Z"`
+}
+
+// ActiveAt reports whether the delegation is in effect at t.
+func (d *Delegation) ActiveAt(t time.Time) bool {
+	return d != nil && !t.Before(d.StartsAt) && t.Before(d.EndsAt)
+}
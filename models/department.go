@@ -0,0 +1,11 @@
+package models
+
+// Department is an organizational unit employees belong to. It is identified by its name,
+// which employees reference in their department field.
+// swagger:model Department
+type Department struct {
+	// Name identifies the department and cannot be changed once created.
+	Name string `json:"name" xml:"name" bson:"_id" validate:"notblank,max=64" example:"R&D"`
+	// Description optionally explains what the department does.
+	Description string `json:"description,omitempty" xml:"description,omitempty" bson:"description,omitempty" validate:"max=500" example:"Research and development"`
+}
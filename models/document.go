@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Document categories.
+const (
+	DocumentContract = "contract"
+	DocumentID       = "id"
+	DocumentOther    = "other"
+)
+
+// Document describes a file attached to an employee, such as a signed contract or a scanned ID.
+// swagger:model Document
+type Document struct {
+	// ID identifies the document.
+	ID string `json:"id" xml:"id" example:"6650c1f2a4d3e2b1c0f9e8d7"`
+	// Employee is the email of the employee the document belongs to.
+	Employee string `json:"employee" xml:"employee" example:"janesmith@s.afeka.ac.il"`
+	// Name is the file name given on upload.
+	Name string `json:"name" xml:"name" example:"contract-2025.pdf"`
+	// Category is contract, id or other.
+	Category string `json:"category" xml:"category" example:"contract"`
+	// ContentType is the media type detected from the content.
+	ContentType string `json:"contentType" xml:"contentType" example:"application/pdf"`
+	// Size is the length of the file in bytes.
+	Size int64 `json:"size" xml:"size" example:"48213"`
+	// UploadedAt is when the document was uploaded.
+	UploadedAt time.Time `json:"uploadedAt" xml:"uploadedAt"`
+	// DownloadURL is a signed link to the content, usable without credentials until DownloadURLExpiresAt.
+	DownloadURL string `json:"downloadUrl,omitempty" xml:"downloadUrl,omitempty" example:"/documents/6650c1f2a4d3e2b1c0f9e8d7/download?expires=1735689600&signature=3f1c..."`
+	// DownloadURLExpiresAt is when DownloadURL stops working.
+	DownloadURLExpiresAt *time.Time `json:"downloadUrlExpiresAt,omitempty" xml:"downloadUrlExpiresAt,omitempty"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// EmailChange is the body of the request changing the email address of an employee.
+// swagger:model EmailChange
+type EmailChange struct {
+	// Email is the new email address of the employee.
+	Email string `json:"email" xml:"email" validate:"required,email" example:"jane.doe@s.afeka.ac.il"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// EmergencyContact is a person to call when something happens to an employee.
+// swagger:model EmergencyContact
+type EmergencyContact struct {
+	// Name is the full name of the contact.
+	Name string `json:"name" xml:"name" bson:"name" validate:"notblank,max=100" example:"John Doe"`
+	// Relationship is how the contact relates to the employee.
+	Relationship string `json:"relationship" xml:"relationship" bson:"relationship" validate:"notblank,max=50" example:"Spouse"`
+	// Phone is the phone number of the contact in E.164 format.
+	Phone string `json:"phone" xml:"phone" bson:"phone" validate:"required,phone" example:"+972501234567"`
+}
+
+// EmergencyContactsRequest is the payload replacing the emergency contacts of an employee.
+// swagger:model EmergencyContactsRequest
+type EmergencyContactsRequest struct {
+	// Contacts lists up to 5 contacts, most preferred first; an empty list removes them all.
+	Contacts []EmergencyContact `json:"contacts" xml:"contacts>contact" validate:"max=5,dive"`
+}
+
+// EmergencyContacts are the emergency contacts of an employee.
+// swagger:model EmergencyContacts
+type EmergencyContacts struct {
+	// Employee is the email of the employee.
+	Employee string `json:"employee" xml:"employee" bson:"_id" example:"janesmith@s.afeka.ac.il"`
+	// Contacts lists the contacts, most preferred first.
+	Contacts []EmergencyContact `json:"contacts" xml:"contacts>contact" bson:"contacts"`
+	// UpdatedAt is when the contacts were last replaced; absent when none were ever set.
+	UpdatedAt *time.Time `json:"updatedAt,omitempty" xml:"updatedAt,omitempty" bson:"updatedAt"`
+	// UpdatedBy is the email of the employee who last replaced the contacts.
+	UpdatedBy string `json:"updatedBy,omitempty" xml:"updatedBy,omitempty" bson:"updatedBy,omitempty" example:"hr@s.afeka.ac.il"`
+}
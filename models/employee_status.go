@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Employment statuses. Employees stored without a status are active.
+const (
+	StatusActive     = "active"
+	StatusOnLeave    = "on_leave"
+	StatusTerminated = "terminated"
+)
+
+// StatusTransition records a change of an employee's employment status.
+// swagger:model StatusTransition
+type StatusTransition struct {
+	// From is the status before the change.
+	From string `json:"from" xml:"from" bson:"from" example:"active"`
+	// To is the status after the change.
+	To string `json:"to" xml:"to" bson:"to" example:"terminated"`
+	// At is when the status changed.
+	At time.Time `json:"at" xml:"at" bson:"at" example:"2025-01-01T00:00:00Z"`
+}
+
+// EmploymentStatus returns the status of the employee, StatusActive when none was recorded.
+func (e Employee) EmploymentStatus() string {
+	if e.Status == "" {
+		return StatusActive
+	}
+	return e.Status
+}
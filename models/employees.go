@@ -1,68 +1,426 @@
 package models
 
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // FieldNames groups together the field names for an Employee.
 type FieldNames struct {
-	Email     string
-	Name      string
-	Password  string
-	Birthdate string
-	Roles     string
-	Manager   string
+	ID             string
+	TenantID       string
+	Email          string
+	Name           string
+	PreferredName  string
+	Pronouns       string
+	DisplayName    string
+	Password       string
+	Birthdate      string
+	BirthDate      string
+	Roles          string
+	Manager        string
+	Version        string
+	TemporaryRoles string
+	Delegation     string
+	DeletedAt      string
+	Metadata       string
+	Phone          string
+	Address        string
+	Department     string
+	DottedLines    string
+	Status         string
+	StatusHistory  string
+	HireDate       string
+	Title          string
+	Grade          string
+	Location       string
+	CostCenter     string
+	Skills         string
+	Timezone       string
+	Locale         string
 }
 
 // EmployeeFields is an instance containing the field names.
 var EmployeeRef = FieldNames{
-	Email:     "email",
-	Name:      "name",
-	Password:  "password",
-	Birthdate: "birthdate",
-	Roles:     "roles",
-	Manager:   "manager",
+	ID:             "id",
+	TenantID:       "tenantId",
+	Email:          "email",
+	Name:           "name",
+	PreferredName:  "preferredName",
+	Pronouns:       "pronouns",
+	DisplayName:    "displayName",
+	Password:       "password",
+	Birthdate:      "birthdate",
+	BirthDate:      "birthDate",
+	Roles:          "roles",
+	Manager:        "manager",
+	Version:        "version",
+	TemporaryRoles: "temporaryRoles",
+	Delegation:     "delegation",
+	DeletedAt:      "deletedAt",
+	Metadata:       "metadata",
+	Phone:          "phone",
+	Address:        "address",
+	Department:     "department",
+	DottedLines:    "dottedLineManagers",
+	Status:         "status",
+	StatusHistory:  "statusHistory",
+	HireDate:       "hireDate",
+	Title:          "title",
+	Grade:          "grade",
+	Location:       "location",
+	CostCenter:     "costCenter",
+	Skills:         "skills",
+	Timezone:       "timezone",
+	Locale:         "locale",
 }
 
 // Birthdate represents an employee's date of birth.
 // swagger:model Birthdate
 type Birthdate struct {
 	// Day represents the two-digit day.
-	Day string `json:"day" example:"03"`
+	Day string `json:"day" xml:"day" validate:"len=2,number" example:"03"`
 	// Month represents the two-digit month.
-	Month string `json:"month" example:"01"`
+	Month string `json:"month" xml:"month" validate:"len=2,number" example:"01"`
 	// Year represents the four-digit year.
-	Year string `json:"year" example:"1999"`
+	Year string `json:"year" xml:"year" validate:"len=4,number" example:"1999"`
+}
+
+// Date returns the birthdate as a date at midnight UTC, built by time.Date from its parts, and false when a part is
+// not a number or the parts do not name a day of the calendar, such as 30/02, which time.Date would move to March.
+func (b Birthdate) Date() (time.Time, bool) {
+	day, dayErr := strconv.Atoi(b.Day)
+	month, monthErr := strconv.Atoi(b.Month)
+	year, yearErr := strconv.Atoi(b.Year)
+	if dayErr != nil || monthErr != nil || yearErr != nil || year < 0 {
+		return time.Time{}, false
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Year() != year || date.Month() != time.Month(month) || date.Day() != day {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// Numeric reports whether every part of the birthdate is a number, even if together they name no day.
+func (b Birthdate) Numeric() bool {
+	for _, part := range []string{b.Day, b.Month, b.Year} {
+		if _, err := strconv.Atoi(part); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Address is a structured postal address.
+// swagger:model Address
+type Address struct {
+	// Street is the street and house number.
+	Street string `json:"street,omitempty" xml:"street,omitempty" bson:"street,omitempty" example:"Mivtza Kadesh 38"`
+	// City is required when an address is given.
+	City string `json:"city" xml:"city" bson:"city" validate:"notblank" example:"Tel Aviv"`
+	// PostalCode is the postal or ZIP code.
+	PostalCode string `json:"postalCode,omitempty" xml:"postalCode,omitempty" bson:"postalCode,omitempty" example:"6910717"`
+	// Country is the two-letter ISO 3166-1 country code and is required when an address is given.
+	Country string `json:"country" xml:"country" bson:"country" validate:"iso3166_1_alpha2" example:"IL"`
 }
 
-// Employee represents an employee record.
-// swagger:model Employee
-// @Description An employee with email, name, password, birthdate, and roles.
+// Skill levels range from MinSkillLevel, a beginner, to MaxSkillLevel, an expert.
+const (
+	MinSkillLevel = 1
+	MaxSkillLevel = 5
+)
+
+// Skill is a capability of an employee with their proficiency in it.
+// swagger:model Skill
+type Skill struct {
+	// Name is the name of the skill, such as a language or a tool.
+	Name string `json:"name" xml:"name" bson:"name" validate:"notblank,max=64" example:"Go"`
+	// Level is the proficiency from 1, a beginner, to 5, an expert.
+	Level int `json:"level" xml:"level" bson:"level" validate:"min=1,max=5" example:"4"`
+}
+
+// Employee is the stored employee record. It is never bound from or rendered to a request:
+// NewEmployeeBoundary is the input and EmployeeResponse the output.
+// The validate tags are checked before every write; see services/validation.go for the custom rules.
 type Employee struct {
-	// Email is the unique identifier.
-	Email string `json:"email" example:"janesmith@s.afeka.ac.il"`
-	// Name is the full name of the employee.
-	Name string `json:"name" example:"Jane Smith"`
-	// Password is the employee's password. It is omitted in responses.
-	Password string `json:"password,omitempty" example:"Pa5"`
+	// ID is the immutable internal identifier, a ULID assigned on creation. Unlike the email it never changes.
+	ID string `json:"id,omitempty" xml:"id,omitempty" bson:"id,omitempty" example:"01J9Z3K7Q8X2M4N6P8R0S2T4V6"`
+	// TenantID is the organization the employee belongs to when the deployment serves several; it is set from the
+	// request, never from its body. Employees of single-organization deployments have none.
+	TenantID string `json:"-" xml:"-" bson:"tenantId,omitempty"`
+	// Email is the unique identifier, within the tenant.
+	Email string `json:"email" xml:"email" validate:"required,email" example:"janesmith@s.afeka.ac.il"`
+	// Name is the full legal name of the employee.
+	Name string `json:"name" xml:"name" validate:"required" example:"Jane Smith"`
+	// PreferredName is the optional name the employee goes by, shown instead of the legal name.
+	PreferredName string `json:"preferredName,omitempty" xml:"preferredName,omitempty" bson:"preferredName,omitempty" validate:"max=100" example:"Janie"`
+	// Pronouns optionally holds the pronouns of the employee, as they write them.
+	Pronouns string `json:"pronouns,omitempty" xml:"pronouns,omitempty" bson:"pronouns,omitempty" validate:"max=32" example:"she/her"`
+	// DisplayName is derived from PreferredName and Name on every write, so employees can be searched and sorted
+	// by it; see ResolveDisplayName.
+	DisplayName string `json:"-" xml:"-" bson:"displayName,omitempty"`
+	// Password is the employee's password. It is stored but never serialized.
+	Password string `json:"-" xml:"-" validate:"min=3,password"`
 	// Birthdate contains the employee's date of birth.
-	Birthdate Birthdate `json:"birthdate"`
+	Birthdate Birthdate `json:"birthdate" xml:"birthdate" validate:"calendardate,birthdate"`
+	// BirthDate is derived from Birthdate on every write, as a date at midnight UTC, so ages can be computed and
+	// employees found by birth date without parsing the parts; see Birthdate.Date.
+	BirthDate time.Time `json:"-" xml:"-" bson:"birthDate,omitempty"`
 	// Roles contains the roles or permissions of the employee.
-	Roles []string `json:"roles" example:"DevOps,R&D"`
+	Roles []string `json:"roles" xml:"roles>role" example:"DevOps,R&D"`
 	// Manager optionally stores the email of the employee's manager.
-	Manager *string `json:"manager,omitempty" example:"manager@s.example.com"`
+	Manager *string `json:"manager,omitempty" xml:"manager,omitempty" example:"manager@s.example.com"`
+	// Version is incremented on every update and used for optimistic concurrency control.
+	Version int64 `json:"version" xml:"version" example:"1"`
+	// TemporaryRoles lists the roles that are removed automatically once they expire.
+	// Each of them is also present in Roles while it is active.
+	TemporaryRoles []RoleGrant `json:"temporaryRoles,omitempty" xml:"temporaryRoles>grant,omitempty" bson:"temporaryRoles,omitempty"`
+	// Delegation optionally hands this manager's duties to a delegate for a time window.
+	Delegation *Delegation `json:"delegation,omitempty" xml:"delegation,omitempty" bson:"delegation,omitempty"`
+	// DeletedAt is set while the employee is soft-deleted; such employees are hidden until restored.
+	DeletedAt *time.Time `json:"deletedAt,omitempty" xml:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	// Metadata holds organization-specific attributes, such as a badge number or parking spot.
+	Metadata Metadata `json:"metadata,omitempty" xml:"metadata,omitempty" bson:"metadata,omitempty" validate:"max=32,dive,keys,metadatakey,endkeys,max=256"`
+	// Phone is an optional phone number in E.164 format.
+	Phone string `json:"phone,omitempty" xml:"phone,omitempty" bson:"phone,omitempty" validate:"omitempty,phone" example:"+972501234567"`
+	// Address is the employee's optional postal address.
+	Address *Address `json:"address,omitempty" xml:"address,omitempty" bson:"address,omitempty" validate:"omitempty"`
+	// Department is the optional name of the department the employee belongs to.
+	Department string `json:"department,omitempty" xml:"department,omitempty" bson:"department,omitempty" example:"R&D"`
+	// HireDate is the optional date the employee was hired, formatted as YYYY-MM-DD so it sorts chronologically.
+	HireDate string `json:"hireDate,omitempty" xml:"hireDate,omitempty" bson:"hireDate,omitempty" validate:"omitempty,datetime=2006-01-02,pastdate,afterbirthdate" example:"2020-03-15"`
+	// Title is the optional job title of the employee, one of the titles catalog.
+	Title string `json:"title,omitempty" xml:"title,omitempty" bson:"title,omitempty" validate:"max=64" example:"Software Engineer"`
+	// Grade is the optional grade of the employee within their title.
+	Grade string `json:"grade,omitempty" xml:"grade,omitempty" bson:"grade,omitempty" validate:"max=16" example:"L4"`
+	// Location is the optional office the employee works from, one of the locations.
+	Location string `json:"location,omitempty" xml:"location,omitempty" bson:"location,omitempty" validate:"max=64" example:"Tel Aviv HQ"`
+	// CostCenter is the optional cost center the employee is charged to.
+	CostCenter string `json:"costCenter,omitempty" xml:"costCenter,omitempty" bson:"costCenter,omitempty" validate:"max=32" example:"CC-1200"`
+	// Skills lists the capabilities of the employee, each name at most once.
+	Skills []Skill `json:"skills,omitempty" xml:"skills>skill,omitempty" bson:"skills,omitempty" validate:"max=50,unique=Name,dive"`
+	// Timezone is the optional IANA time zone the employee lives in; their age is computed in it.
+	Timezone string `json:"timezone,omitempty" xml:"timezone,omitempty" bson:"timezone,omitempty" validate:"omitempty,timezone" example:"Asia/Jerusalem"`
+	// Locale is the optional BCP 47 language tag of the language and region the employee prefers.
+	Locale string `json:"locale,omitempty" xml:"locale,omitempty" bson:"locale,omitempty" validate:"omitempty,bcp47_language_tag" example:"he-IL"`
+	// DottedLineManagers lists the emails of the employee's dotted-line managers, besides the primary Manager.
+	DottedLineManagers []string `json:"-" xml:"-" bson:"dottedLineManagers,omitempty"`
+	// Status is the employment status, one of active, on_leave or terminated; empty means active.
+	Status string `json:"status,omitempty" xml:"status,omitempty" bson:"status,omitempty"`
+	// StatusHistory lists the changes of the employment status, oldest first.
+	StatusHistory []StatusTransition `json:"statusHistory,omitempty" xml:"statusHistory>transition,omitempty" bson:"statusHistory,omitempty"`
 }
 
-// Employee represents an employee record.
+// NewEmployeeBoundary is the body of the requests creating or replacing an employee.
+// swagger:model NewEmployeeBoundary
+// @Description The details of a new employee: email, name, password, birthdate, and roles.
+type NewEmployeeBoundary struct {
+	// Email is the unique identifier.
+	Email string `json:"email" xml:"email" example:"janesmith@s.afeka.ac.il"`
+	// Name is the full legal name of the employee.
+	Name string `json:"name" xml:"name" example:"Jane Smith"`
+	// PreferredName is the optional name the employee goes by, shown instead of the legal name.
+	PreferredName string `json:"preferredName,omitempty" xml:"preferredName,omitempty" example:"Janie"`
+	// Pronouns optionally holds the pronouns of the employee, as they write them.
+	Pronouns string `json:"pronouns,omitempty" xml:"pronouns,omitempty" example:"she/her"`
+	// Password is the employee's password. It is never returned.
+	Password string `json:"password" xml:"password" example:"Pa5"`
+	// Birthdate contains the employee's date of birth.
+	Birthdate Birthdate `json:"birthdate" xml:"birthdate"`
+	// Roles contains the roles or permissions of the employee.
+	Roles []string `json:"roles" xml:"roles>role" example:"DevOps,R&D"`
+	// Manager optionally holds the email of the employee's manager.
+	Manager *string `json:"manager,omitempty" xml:"manager,omitempty" example:"manager@s.example.com"`
+	// Metadata holds organization-specific attributes, such as a badge number or parking spot.
+	Metadata Metadata `json:"metadata,omitempty" xml:"metadata,omitempty"`
+	// Phone is an optional phone number in E.164 format.
+	Phone string `json:"phone,omitempty" xml:"phone,omitempty" example:"+972501234567"`
+	// Address is the employee's optional postal address.
+	Address *Address `json:"address,omitempty" xml:"address,omitempty"`
+	// Department is the optional name of the department the employee belongs to.
+	Department string `json:"department,omitempty" xml:"department,omitempty" example:"R&D"`
+	// HireDate is the optional date the employee was hired, formatted as YYYY-MM-DD.
+	HireDate string `json:"hireDate,omitempty" xml:"hireDate,omitempty" example:"2020-03-15"`
+	// Title is the optional job title of the employee, one of the titles catalog.
+	Title string `json:"title,omitempty" xml:"title,omitempty" example:"Software Engineer"`
+	// Grade is the optional grade of the employee within their title.
+	Grade string `json:"grade,omitempty" xml:"grade,omitempty" example:"L4"`
+	// Location is the optional office the employee works from, one of the locations.
+	Location string `json:"location,omitempty" xml:"location,omitempty" example:"Tel Aviv HQ"`
+	// CostCenter is the optional cost center the employee is charged to.
+	CostCenter string `json:"costCenter,omitempty" xml:"costCenter,omitempty" example:"CC-1200"`
+	// Skills lists the capabilities of the employee, each name at most once.
+	Skills []Skill `json:"skills,omitempty" xml:"skills>skill,omitempty"`
+	// Timezone is the optional IANA time zone the employee lives in; UTC when omitted.
+	Timezone string `json:"timezone,omitempty" xml:"timezone,omitempty" example:"Asia/Jerusalem"`
+	// Locale is the optional BCP 47 language tag of the language and region the employee prefers.
+	Locale string `json:"locale,omitempty" xml:"locale,omitempty" example:"he-IL"`
+}
+
+// ToEmployee maps the request body to the employee record it describes.
+func (b NewEmployeeBoundary) ToEmployee() Employee {
+	return Employee{
+		Email:         b.Email,
+		Name:          b.Name,
+		PreferredName: b.PreferredName,
+		Pronouns:      b.Pronouns,
+		Password:      b.Password,
+		Birthdate:     b.Birthdate,
+		Roles:         b.Roles,
+		Manager:       b.Manager,
+		Metadata:      b.Metadata,
+		Phone:         b.Phone,
+		Address:       b.Address,
+		Department:    b.Department,
+		HireDate:      b.HireDate,
+		Title:         b.Title,
+		Grade:         b.Grade,
+		Location:      b.Location,
+		CostCenter:    b.CostCenter,
+		Skills:        b.Skills,
+		Timezone:      b.Timezone,
+		Locale:        b.Locale,
+	}
+}
+
+// ResolveDisplayName returns the preferred name of the employee when one is set, and the legal name otherwise.
+func (e Employee) ResolveDisplayName() string {
+	if strings.TrimSpace(e.PreferredName) != "" {
+		return e.PreferredName
+	}
+	return e.Name
+}
+
+// TimeLocation returns the time zone of the employee, UTC when none is set or it cannot be loaded.
+func (e Employee) TimeLocation() *time.Location {
+	if e.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// EmployeeResponse is the representation of an employee returned by the API.
+// It has no password field, so a password cannot be rendered by mistake.
 // swagger:model EmployeeResponse
-// @Description An employee with email, name, password, birthdate, and roles.
+// @Description An employee with email, name, birthdate, and roles.
 type EmployeeResponse struct {
+	// ID is the immutable internal identifier, which is kept when the email changes.
+	ID string `json:"id,omitempty" xml:"id,omitempty" example:"01J9Z3K7Q8X2M4N6P8R0S2T4V6"`
 	// Email is the unique identifier.
-	Email string `json:"email" example:"janesmith@s.afeka.ac.il"`
-	// Name is the full name of the employee.
-	Name string `json:"name" example:"Jane Smith"`
-	// Password is the employee's password. It is omitted in responses.
-	Password string `json:"-"`
+	Email string `json:"email" xml:"email" example:"janesmith@s.afeka.ac.il"`
+	// Name is the full legal name of the employee.
+	Name string `json:"name" xml:"name" example:"Jane Smith"`
+	// PreferredName is the optional name the employee goes by.
+	PreferredName string `json:"preferredName,omitempty" xml:"preferredName,omitempty" example:"Janie"`
+	// Pronouns optionally holds the pronouns of the employee.
+	Pronouns string `json:"pronouns,omitempty" xml:"pronouns,omitempty" example:"she/her"`
+	// DisplayName is the name to show: the preferred name when set, the legal name otherwise.
+	DisplayName string `json:"displayName" xml:"displayName" example:"Janie"`
 	// Birthdate contains the employee's date of birth.
-	Birthdate Birthdate `json:"birthdate"`
+	Birthdate Birthdate `json:"birthdate" xml:"birthdate"`
 	// Roles contains the roles or permissions of the employee.
-	Roles []string `json:"roles" example:"DevOps,R&D"`
+	Roles []string `json:"roles" xml:"roles>role" example:"DevOps,R&D"`
 	// Manager optionally stores the email of the employee's manager.
-	Manager *string `json:"manager,omitempty" example:"manager@s.example.com"`
+	Manager *string `json:"manager,omitempty" xml:"manager,omitempty" example:"manager@s.example.com"`
+	// Version is incremented on every update and used for optimistic concurrency control.
+	Version int64 `json:"version" xml:"version" example:"1"`
+	// TemporaryRoles lists the roles that are removed automatically once they expire.
+	// Each of them is also present in Roles while it is active.
+	TemporaryRoles []RoleGrant `json:"temporaryRoles,omitempty" xml:"temporaryRoles>grant,omitempty" bson:"temporaryRoles,omitempty"`
+	// Delegation optionally hands this manager's duties to a delegate for a time window.
+	Delegation *Delegation `json:"delegation,omitempty" xml:"delegation,omitempty" bson:"delegation,omitempty"`
+	// DeletedAt is set while the employee is soft-deleted; such employees are hidden until restored.
+	DeletedAt *time.Time `json:"deletedAt,omitempty" xml:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	// Metadata holds organization-specific attributes, such as a badge number or parking spot.
+	Metadata Metadata `json:"metadata,omitempty" xml:"metadata,omitempty" bson:"metadata,omitempty"`
+	// Phone is an optional phone number in E.164 format.
+	Phone string `json:"phone,omitempty" xml:"phone,omitempty" bson:"phone,omitempty" example:"+972501234567"`
+	// Address is the employee's optional postal address.
+	Address *Address `json:"address,omitempty" xml:"address,omitempty" bson:"address,omitempty"`
+	// Department is the optional name of the department the employee belongs to.
+	Department string `json:"department,omitempty" xml:"department,omitempty" bson:"department,omitempty" example:"R&D"`
+	// HireDate is the optional date the employee was hired, formatted as YYYY-MM-DD.
+	HireDate string `json:"hireDate,omitempty" xml:"hireDate,omitempty" bson:"hireDate,omitempty" example:"2020-03-15"`
+	// Title is the optional job title of the employee.
+	Title string `json:"title,omitempty" xml:"title,omitempty" bson:"title,omitempty" example:"Software Engineer"`
+	// Grade is the optional grade of the employee within their title.
+	Grade string `json:"grade,omitempty" xml:"grade,omitempty" bson:"grade,omitempty" example:"L4"`
+	// Location is the optional office the employee works from.
+	Location string `json:"location,omitempty" xml:"location,omitempty" bson:"location,omitempty" example:"Tel Aviv HQ"`
+	// CostCenter is the optional cost center the employee is charged to.
+	CostCenter string `json:"costCenter,omitempty" xml:"costCenter,omitempty" bson:"costCenter,omitempty" example:"CC-1200"`
+	// Skills lists the capabilities of the employee.
+	Skills []Skill `json:"skills,omitempty" xml:"skills>skill,omitempty" bson:"skills,omitempty"`
+	// Timezone is the optional IANA time zone the employee lives in.
+	Timezone string `json:"timezone,omitempty" xml:"timezone,omitempty" bson:"timezone,omitempty" example:"Asia/Jerusalem"`
+	// Locale is the optional BCP 47 language tag of the language and region the employee prefers.
+	Locale string `json:"locale,omitempty" xml:"locale,omitempty" bson:"locale,omitempty" example:"he-IL"`
+	// ReportingLines lists the primary line, the same as Manager, followed by the dotted lines of the employee.
+	ReportingLines []ReportingLine `json:"reportingLines,omitempty" xml:"reportingLines>line,omitempty" bson:"-"`
+	// Status is the employment status: active, on_leave or terminated.
+	Status string `json:"status" xml:"status" bson:"status" example:"active"`
+	// StatusHistory lists the changes of the employment status, oldest first.
+	StatusHistory []StatusTransition `json:"statusHistory,omitempty" xml:"statusHistory>transition,omitempty" bson:"statusHistory,omitempty"`
+}
+
+// employeeXML has the fields of EmployeeResponse without its methods, to encode them as is.
+type employeeXML EmployeeResponse
+
+// MarshalXML implements xml.Marshaler. A top-level response keeps the <Employee> element name of the
+// stored record, while nested ones take the name of their field.
+func (r EmployeeResponse) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if start.Name.Local == "EmployeeResponse" {
+		start.Name.Local = "Employee"
+	}
+	return e.EncodeElement(employeeXML(r), start)
+}
+
+// NewEmployeeResponse maps a stored employee to its response, leaving the password out.
+func NewEmployeeResponse(emp Employee) EmployeeResponse {
+	return EmployeeResponse{
+		ID:             emp.ID,
+		Email:          emp.Email,
+		Name:           emp.Name,
+		PreferredName:  emp.PreferredName,
+		Pronouns:       emp.Pronouns,
+		DisplayName:    emp.ResolveDisplayName(),
+		Birthdate:      emp.Birthdate,
+		Roles:          emp.Roles,
+		Manager:        emp.Manager,
+		Version:        emp.Version,
+		TemporaryRoles: emp.TemporaryRoles,
+		Delegation:     emp.Delegation,
+		DeletedAt:      emp.DeletedAt,
+		Metadata:       emp.Metadata,
+		Phone:          emp.Phone,
+		Address:        emp.Address,
+		Department:     emp.Department,
+		HireDate:       emp.HireDate,
+		Title:          emp.Title,
+		Grade:          emp.Grade,
+		Location:       emp.Location,
+		CostCenter:     emp.CostCenter,
+		Skills:         emp.Skills,
+		Timezone:       emp.Timezone,
+		Locale:         emp.Locale,
+		ReportingLines: reportingLines(emp.Manager, emp.DottedLineManagers),
+		Status:         emp.EmploymentStatus(),
+		StatusHistory:  emp.StatusHistory,
+	}
+}
+
+// NewEmployeeResponses maps a list of stored employees to their responses.
+func NewEmployeeResponses(employees []Employee) []EmployeeResponse {
+	responses := make([]EmployeeResponse, len(employees))
+	for i, emp := range employees {
+		responses[i] = NewEmployeeResponse(emp)
+	}
+	return responses
 }
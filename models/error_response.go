@@ -4,5 +4,9 @@ package models
 // swagger:model
 type ErrorResponse struct {
     // Error is the error message.
-    Error string `json:"error" example:"Invalid request payload"`
+    Error string `json:"error" xml:"error" example:"Invalid request payload"`
+    // Code is a stable, machine-readable error code clients can branch on.
+    Code string `json:"code" xml:"code" example:"INVALID_PAYLOAD"`
+    // RequestID identifies the request, also returned in the X-Request-ID header; support finds its log lines by it.
+    RequestID string `json:"requestId,omitempty" xml:"requestId,omitempty" example:"01JA8Z6Q2M4V7K9B3C5D8F1G2H"`
 }
\ No newline at end of file
@@ -0,0 +1,27 @@
+package models
+
+import "encoding/xml"
+
+// Expansions that can be requested with the expand query parameter.
+const (
+	ExpandManager = "manager"
+)
+
+// ExpandedEmployee is an employee whose related resources are embedded in full instead of referenced by email.
+// swagger:model ExpandedEmployee
+type ExpandedEmployee struct {
+	EmployeeResponse
+	// Manager is the employee's manager, embedded when expand=manager was requested.
+	// It is omitted when the employee has no manager or the manager no longer exists.
+	Manager *EmployeeResponse `json:"manager,omitempty" xml:"manager,omitempty"`
+}
+
+// MarshalXML implements xml.Marshaler, encoding the employee as an <Employee> element.
+// It is needed since the method of the embedded EmployeeResponse would otherwise drop the expansions.
+func (x ExpandedEmployee) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "Employee"
+	return e.EncodeElement(struct {
+		employeeXML
+		Manager *EmployeeResponse `xml:"manager,omitempty"`
+	}{employeeXML(x.EmployeeResponse), x.Manager}, start)
+}
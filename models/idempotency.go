@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// IdempotencyRecord stores the first response sent for an Idempotency-Key so retries can be replayed.
+type IdempotencyRecord struct {
+	// Key is the idempotency key scoped by method and path.
+	Key string `bson:"_id"`
+	// Fingerprint is a hash of the request body the key was first used with.
+	Fingerprint string `bson:"fingerprint"`
+	// Completed is false while the original request is still being processed.
+	Completed   bool      `bson:"completed"`
+	Status      int       `bson:"status,omitempty"`
+	ContentType string    `bson:"contentType,omitempty"`
+	Location    string    `bson:"location,omitempty"`
+	Body        []byte    `bson:"body,omitempty"`
+	CreatedAt   time.Time `bson:"createdAt"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// Statuses of a leave request. A request starts pending until the approver approves or rejects it,
+// or the employee cancels it.
+const (
+	LeavePending   = "pending"
+	LeaveApproved  = "approved"
+	LeaveRejected  = "rejected"
+	LeaveCancelled = "cancelled"
+)
+
+// LeaveRequestBoundary is the payload an employee submits to request time off.
+// swagger:model LeaveRequestBoundary
+type LeaveRequestBoundary struct {
+	// StartDate is the first day off, formatted as YYYY-MM-DD.
+	StartDate string `json:"startDate" xml:"startDate" validate:"required,datetime=2006-01-02" example:"2025-08-04"`
+	// EndDate is the last day off, formatted as YYYY-MM-DD, in the same year as StartDate.
+	EndDate string `json:"endDate" xml:"endDate" validate:"required,datetime=2006-01-02" example:"2025-08-08"`
+	// Reason optionally explains the request to the approver.
+	Reason string `json:"reason,omitempty" xml:"reason,omitempty" validate:"max=500" example:"Family vacation"`
+}
+
+// LeaveDecisionBoundary is the optional payload of approving or rejecting a leave request.
+// swagger:model LeaveDecisionBoundary
+type LeaveDecisionBoundary struct {
+	// Comment optionally explains the decision to the employee.
+	Comment string `json:"comment,omitempty" xml:"comment,omitempty" validate:"max=500" example:"Enjoy!"`
+}
+
+// LeaveRequest is a request for time off and its outcome.
+// swagger:model LeaveRequest
+type LeaveRequest struct {
+	// ID identifies the request.
+	ID string `json:"id" xml:"id" bson:"_id" example:"6650c1f2a4d3e2b1c0f9e8d7"`
+	// Employee is the email of the employee requesting the time off.
+	Employee string `json:"employee" xml:"employee" bson:"employee" example:"janesmith@s.afeka.ac.il"`
+	// StartDate is the first day off, formatted as YYYY-MM-DD.
+	StartDate string `json:"startDate" xml:"startDate" bson:"startDate" example:"2025-08-04"`
+	// EndDate is the last day off, formatted as YYYY-MM-DD.
+	EndDate string `json:"endDate" xml:"endDate" bson:"endDate" example:"2025-08-08"`
+	// Year is the year of the balance the request is charged to.
+	Year int `json:"year" xml:"year" bson:"year" example:"2025"`
+	// Days is the number of working days, Monday to Friday, between StartDate and EndDate.
+	Days int `json:"days" xml:"days" bson:"days" example:"5"`
+	// Reason optionally explains the request to the approver.
+	Reason string `json:"reason,omitempty" xml:"reason,omitempty" bson:"reason,omitempty" example:"Family vacation"`
+	// Status is one of pending, approved, rejected or cancelled.
+	Status string `json:"status" xml:"status" bson:"status" example:"pending"`
+	// Approver is the email of the employee who approved or rejected the request, or who was to
+	// approve it when it was submitted.
+	Approver string `json:"approver" xml:"approver" bson:"approver" example:"manager@s.example.com"`
+	// Comment optionally explains the decision.
+	Comment string `json:"comment,omitempty" xml:"comment,omitempty" bson:"comment,omitempty" example:"Enjoy!"`
+	// CreatedAt is when the request was submitted.
+	CreatedAt time.Time `json:"createdAt" xml:"createdAt" bson:"createdAt"`
+	// DecidedAt is when the request left the pending status.
+	DecidedAt *time.Time `json:"decidedAt,omitempty" xml:"decidedAt,omitempty" bson:"decidedAt,omitempty"`
+}
+
+// LeaveBalance is the time off of an employee in a year, in working days.
+// swagger:model LeaveBalance
+type LeaveBalance struct {
+	// Employee is the email of the employee.
+	Employee string `json:"employee" xml:"employee" bson:"employee" example:"janesmith@s.afeka.ac.il"`
+	// Year is the calendar year of the balance.
+	Year int `json:"year" xml:"year" bson:"year" example:"2025"`
+	// Allowance is the number of days off the employee is entitled to in the year.
+	Allowance int `json:"allowance" xml:"allowance" bson:"allowance" example:"20"`
+	// Used is the number of days of the approved requests.
+	Used int `json:"used" xml:"used" bson:"used" example:"5"`
+	// Pending is the number of days of the requests awaiting a decision.
+	Pending int `json:"pending" xml:"pending" bson:"pending" example:"2"`
+	// Remaining is the number of days that can still be requested.
+	Remaining int `json:"remaining" xml:"remaining" bson:"-" example:"13"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// Location is an office employees work from.
+// swagger:model Location
+type Location struct {
+	// Name identifies the location and cannot be changed once created.
+	Name string `json:"name" xml:"name" bson:"_id" validate:"notblank,max=64" example:"Tel Aviv HQ"`
+	// Address is the postal address of the office.
+	Address Address `json:"address" xml:"address" bson:"address"`
+	// Timezone is the IANA time zone of the office.
+	Timezone string `json:"timezone" xml:"timezone" bson:"timezone" validate:"required,timezone" example:"Asia/Jerusalem"`
+}
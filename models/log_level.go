@@ -0,0 +1,8 @@
+package models
+
+// LogLevel is the lowest level an instance logs, read and set through /admin/loglevel.
+// swagger:model LogLevel
+type LogLevel struct {
+	// Level is debug, info, warn or error.
+	Level string `json:"level" xml:"level" example:"debug"`
+}
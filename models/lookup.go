@@ -0,0 +1,21 @@
+package models
+
+// EmployeeQuery is the body of POST /employees/query. It is either a partial employee used as an
+// example, or a list of emails to fetch in one round trip.
+// swagger:model
+type EmployeeQuery struct {
+	NewEmployeeBoundary
+	// Emails switches the query to a multi-get of these employees; it cannot be combined with example fields.
+	Emails []string `json:"emails,omitempty" xml:"emails>email,omitempty" example:"janesmith@s.afeka.ac.il,manager@s.example.com"`
+}
+
+// EmployeeLookup is the result of a multi-get for one requested email, in request order.
+// swagger:model
+type EmployeeLookup struct {
+	// Email is the requested email.
+	Email string `json:"email" xml:"email" example:"janesmith@s.afeka.ac.il"`
+	// Found is false when no employee has this email.
+	Found bool `json:"found" xml:"found" example:"true"`
+	// Employee is the matching employee.
+	Employee *EmployeeResponse `json:"employee,omitempty" xml:"employee,omitempty"`
+}
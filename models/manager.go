@@ -4,5 +4,14 @@ package models
 // swagger:model
 type ManagerEmailBoundary struct {
 	// The email of the manager.
-	Email string `json:"email" example:"manager@s.example.com"`
-}
\ No newline at end of file
+	Email string `json:"email" xml:"email" example:"manager@s.example.com"`
+}
+
+// ManagerReassignment moves every direct report of one manager to another.
+// swagger:model
+type ManagerReassignment struct {
+	// The email of the current manager.
+	From string `json:"from" xml:"from" validate:"required" example:"old.manager@s.example.com"`
+	// The email of the new manager.
+	To string `json:"to" xml:"to" validate:"required" example:"new.manager@s.example.com"`
+}
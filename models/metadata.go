@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/xml"
+	"sort"
+)
+
+// Metadata holds organization-specific employee attributes, such as a badge number or parking spot,
+// keyed by attribute name.
+type Metadata map[string]string
+
+// MarshalXML encodes the metadata as one element per key, in key order.
+func (m Metadata) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeStringMap(e, start, m)
+}
+
+// UnmarshalXML decodes one entry per child element, named after the key.
+func (m *Metadata) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	entries := Metadata{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			entries[t.Name.Local] = value
+		case xml.EndElement:
+			*m = entries
+			return nil
+		}
+	}
+}
+
+// encodeStringMap encodes a map as one element per key, in key order, so the output is stable.
+func encodeStringMap(e *xml.Encoder, start xml.StartElement, m map[string]string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := e.EncodeElement(m[key], xml.StartElement{Name: xml.Name{Local: key}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
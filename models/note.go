@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Note is a free-form HR note attached to an employee.
+// swagger:model Note
+type Note struct {
+	// ID identifies the note.
+	ID string `json:"id" xml:"id" bson:"_id" example:"6650c1f2a4d3e2b1c0f9e8d7"`
+	// Employee is the email of the employee the note is about.
+	Employee string `json:"employee" xml:"employee" bson:"employee" example:"janesmith@s.afeka.ac.il"`
+	// Author identifies who wrote the note.
+	Author string `json:"author" xml:"author" bson:"author" example:"hr@s.afeka.ac.il"`
+	// Text is the content of the note.
+	Text string `json:"text" xml:"text" bson:"text" example:"Discussed relocation plans."`
+	// CreatedAt is when the note was written.
+	CreatedAt time.Time `json:"createdAt" xml:"createdAt" bson:"createdAt"`
+}
+
+// NoteRequest is the payload for adding a note to an employee.
+// swagger:model NoteRequest
+type NoteRequest struct {
+	// Text is the content of the note.
+	Text string `json:"text" xml:"text" example:"Discussed relocation plans."`
+}
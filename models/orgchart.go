@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// CurrentSnapshotID refers to the live organization instead of a stored snapshot.
+const CurrentSnapshotID = "current"
+
+// OrgNode is an employee's position in an organization snapshot.
+// swagger:model OrgNode
+type OrgNode struct {
+	// Email identifies the employee.
+	Email string `json:"email" xml:"email" bson:"email" example:"janesmith@s.afeka.ac.il"`
+	// Name is the full name of the employee.
+	Name string `json:"name" xml:"name" bson:"name" example:"Jane Smith"`
+	// Manager is the email of the employee's manager, if any.
+	Manager *string `json:"manager,omitempty" xml:"manager,omitempty" bson:"manager,omitempty" example:"manager@s.example.com"`
+}
+
+// OrgSnapshot is the reporting structure of the organization at a point in time.
+// swagger:model OrgSnapshot
+type OrgSnapshot struct {
+	// ID identifies a stored snapshot. It is empty for uploaded snapshots.
+	ID string `json:"id,omitempty" xml:"id,omitempty" bson:"_id" example:"6650c1f2a4d3e2b1c0f9e8d7"`
+	// Label is an optional description, e.g. "before Q3 reorg".
+	Label string `json:"label,omitempty" xml:"label,omitempty" bson:"label,omitempty" example:"before Q3 reorg"`
+	// CreatedAt is when the snapshot was taken.
+	CreatedAt time.Time `json:"createdAt" xml:"createdAt" bson:"createdAt"`
+	// Nodes lists every employee with their manager.
+	Nodes []OrgNode `json:"nodes" xml:"nodes>node" bson:"nodes"`
+}
+
+// OrgSnapshotRequest is the payload for capturing a snapshot of the current organization.
+// swagger:model OrgSnapshotRequest
+type OrgSnapshotRequest struct {
+	// Label is an optional description of the snapshot.
+	Label string `json:"label" xml:"label" example:"before Q3 reorg"`
+}
+
+// OrgDiffRequest selects the two organization snapshots to compare.
+// swagger:model OrgDiffRequest
+type OrgDiffRequest struct {
+	// From is the identifier of the baseline snapshot, or "current" for the live organization.
+	From string `json:"from" xml:"from" example:"current"`
+	// To is the identifier of the snapshot to compare against, or "current".
+	To string `json:"to,omitempty" xml:"to,omitempty" example:"6650c1f2a4d3e2b1c0f9e8d7"`
+	// Snapshot is an uploaded snapshot (e.g. a planned reorg) used instead of To.
+	Snapshot *OrgSnapshot `json:"snapshot,omitempty" xml:"snapshot,omitempty"`
+}
+
+// OrgMove describes an employee whose manager changed.
+// swagger:model OrgMove
+type OrgMove struct {
+	// Email identifies the employee.
+	Email string `json:"email" xml:"email" example:"janesmith@s.afeka.ac.il"`
+	// FromManager is the previous manager, absent if the employee had none.
+	FromManager *string `json:"fromManager,omitempty" xml:"fromManager,omitempty" example:"old.manager@s.example.com"`
+	// ToManager is the new manager, absent if the employee no longer has one.
+	ToManager *string `json:"toManager,omitempty" xml:"toManager,omitempty" example:"new.manager@s.example.com"`
+}
+
+// OrgDiff lists the structural changes between two organization snapshots.
+// swagger:model OrgDiff
+type OrgDiff struct {
+	// Added lists employees present only in the second snapshot.
+	Added []OrgNode `json:"added" xml:"added>node"`
+	// Removed lists employees present only in the first snapshot.
+	Removed []OrgNode `json:"removed" xml:"removed>node"`
+	// Moved lists employees present in both snapshots whose manager changed.
+	Moved []OrgMove `json:"moved" xml:"moved>move"`
+	// NewManagers lists employees who have direct reports only in the second snapshot.
+	NewManagers []string `json:"newManagers" xml:"newManagers>email"`
+}
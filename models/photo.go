@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Photo variants served by GET /employees/{email}/photo.
+const (
+	PhotoOriginal  = "original"
+	PhotoThumbnail = "thumbnail"
+)
+
+// Photo is an employee's profile photo or its thumbnail.
+type Photo struct {
+	// ID identifies the stored revision; it changes with every upload.
+	ID          string
+	ContentType string
+	Data        []byte
+	UploadedAt  time.Time
+}
@@ -0,0 +1,10 @@
+package models
+
+// ReadOnlyModeRequest is the payload for switching the read-only mode of an instance on or off.
+// swagger:model ReadOnlyModeRequest
+type ReadOnlyModeRequest struct {
+	// Enabled switches the mode on when true, and off otherwise.
+	Enabled bool `json:"enabled" xml:"enabled" example:"true"`
+	// Reason explains to the clients whose mutations are rejected why. It is ignored when switching the mode off.
+	Reason string `json:"reason" xml:"reason" example:"Migrating the employee records"`
+}
@@ -0,0 +1,39 @@
+package models
+
+// Types of reporting lines.
+const (
+	// ReportingPrimary is the line to the manager in the `manager` field, the one the hierarchy is built from.
+	ReportingPrimary = "primary"
+	// ReportingDotted is a secondary line, e.g. to a project lead in a matrix organization.
+	ReportingDotted = "dotted"
+)
+
+// ReportingLine is a typed relationship between an employee and one of their managers.
+// swagger:model ReportingLine
+type ReportingLine struct {
+	// Manager is the email of the manager.
+	Manager string `json:"manager" xml:"manager" validate:"required" example:"manager@s.example.com"`
+	// Type is primary for the single line the hierarchy is built from, or dotted for secondary ones.
+	Type string `json:"type" xml:"type" validate:"oneof=primary dotted" example:"dotted"`
+}
+
+// reportingLines lists the primary line of an employee, if any, followed by the dotted ones.
+func reportingLines(manager *string, dotted []string) []ReportingLine {
+	var lines []ReportingLine
+	if manager != nil {
+		lines = append(lines, ReportingLine{Manager: *manager, Type: ReportingPrimary})
+	}
+	for _, email := range dotted {
+		lines = append(lines, ReportingLine{Manager: email, Type: ReportingDotted})
+	}
+	return lines
+}
+
+// ReportingLines returns the primary line of the employee, if any, followed by the dotted ones.
+func (e Employee) ReportingLines() []ReportingLine {
+	lines := reportingLines(e.Manager, e.DottedLineManagers)
+	if lines == nil {
+		return []ReportingLine{}
+	}
+	return lines
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// ReviewRequest is the payload a manager submits to review an employee.
+// swagger:model ReviewRequest
+type ReviewRequest struct {
+	// PeriodStart is the first day of the reviewed period, formatted as YYYY-MM-DD.
+	PeriodStart string `json:"periodStart" xml:"periodStart" validate:"required,datetime=2006-01-02" example:"2025-01-01"`
+	// PeriodEnd is the last day of the reviewed period, formatted as YYYY-MM-DD.
+	PeriodEnd string `json:"periodEnd" xml:"periodEnd" validate:"required,datetime=2006-01-02" example:"2025-06-30"`
+	// Rating is the overall rating from 1, below expectations, to 5, outstanding.
+	Rating int `json:"rating" xml:"rating" validate:"min=1,max=5" example:"4"`
+	// Comments is the written feedback.
+	Comments string `json:"comments,omitempty" xml:"comments,omitempty" validate:"max=5000" example:"Led the migration to the new platform."`
+}
+
+// Review is a performance review of an employee for a period.
+// swagger:model Review
+type Review struct {
+	// ID identifies the review.
+	ID string `json:"id" xml:"id" bson:"_id" example:"6650c1f2a4d3e2b1c0f9e8d7"`
+	// Employee is the email of the reviewed employee.
+	Employee string `json:"employee" xml:"employee" bson:"employee" example:"janesmith@s.afeka.ac.il"`
+	// Reviewer is the email of the manager who wrote the review.
+	Reviewer string `json:"reviewer" xml:"reviewer" bson:"reviewer" example:"manager@s.example.com"`
+	// PeriodStart is the first day of the reviewed period, formatted as YYYY-MM-DD.
+	PeriodStart string `json:"periodStart" xml:"periodStart" bson:"periodStart" example:"2025-01-01"`
+	// PeriodEnd is the last day of the reviewed period, formatted as YYYY-MM-DD.
+	PeriodEnd string `json:"periodEnd" xml:"periodEnd" bson:"periodEnd" example:"2025-06-30"`
+	// Rating is the overall rating from 1, below expectations, to 5, outstanding.
+	Rating int `json:"rating" xml:"rating" bson:"rating" example:"4"`
+	// Comments is the written feedback.
+	Comments string `json:"comments,omitempty" xml:"comments,omitempty" bson:"comments,omitempty" example:"Led the migration to the new platform."`
+	// CreatedAt is when the review was written.
+	CreatedAt time.Time `json:"createdAt" xml:"createdAt" bson:"createdAt"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// Role is an entry of the roles catalog: a role employees may hold, with the permissions it grants.
+// Clients list the catalog to offer the known roles instead of free-typed strings.
+// swagger:model Role
+type Role struct {
+	// Name identifies the role and cannot be changed once created.
+	Name string `json:"name" xml:"name" bson:"_id" validate:"notblank,max=64" example:"Developer"`
+	// Description optionally explains the role.
+	Description string `json:"description,omitempty" xml:"description,omitempty" bson:"description,omitempty" validate:"max=500" example:"Builds and maintains the product"`
+	// Parent optionally names a role this role implies: holders of the role also hold the parent,
+	// its own parent and so on, with all of their permissions.
+	Parent string `json:"parent,omitempty" xml:"parent,omitempty" bson:"parent,omitempty" validate:"max=64" example:"Engineer"`
+	// Permissions lists what holders of the role are allowed to do.
+	Permissions []string `json:"permissions" xml:"permissions>permission" bson:"permissions" validate:"max=50,dive,notblank,max=64" example:"code:write,deploy:staging"`
+}
+
+// EffectiveRoles lists the roles an employee holds, directly or implied by the parents of their roles,
+// and the permissions those roles grant.
+// swagger:model EffectiveRoles
+type EffectiveRoles struct {
+	// Roles lists the roles held directly followed by the roles they imply.
+	Roles []string `json:"roles" xml:"roles>role" example:"Senior Developer,Developer"`
+	// Permissions lists the permissions of all the roles, without duplicates.
+	Permissions []string `json:"permissions" xml:"permissions>permission" example:"code:write,code:review"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// RoleGrant is a role held until ExpiresAt, after which the scheduler revokes it.
+// swagger:model RoleGrant
+type RoleGrant struct {
+	// Role is the granted role.
+	Role string `json:"role" xml:"role" bson:"role" example:"Admin"`
+	// ExpiresAt is when the role is revoked.
+	ExpiresAt time.Time `json:"expiresAt" xml:"expiresAt" bson:"expiresAt" example:"2025-01-01T00:00:00Z"`
+	// GrantedAt is when the role was granted or last extended.
+	GrantedAt time.Time `json:"grantedAt" xml:"grantedAt" bson:"grantedAt" example:"2024-12-01T09:30:00Z"`
+}
+
+// RoleGrantRequest is the payload for granting a role temporarily.
+// swagger:model RoleGrantRequest
+type RoleGrantRequest struct {
+	// Role is the role to grant.
+	Role string `json:"role" xml:"role" example:"Admin"`
+	// ExpiresAt is when the role is revoked, in RFC 3339 format.
+	ExpiresAt time.Time `json:"expiresAt" xml:"expiresAt" example:"2025-01-01T00:00:00Z"`
+}
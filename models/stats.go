@@ -0,0 +1,45 @@
+package models
+
+// StatCount is the number of employees sharing a value, such as a role or an email domain.
+// swagger:model StatCount
+type StatCount struct {
+	// Name is the shared value.
+	Name string `json:"name" xml:"name" bson:"_id" example:"Developer"`
+	// Count is the number of employees with the value.
+	Count int64 `json:"count" xml:"count" bson:"count" example:"12"`
+}
+
+// AgeBucket is the number of employees whose age falls in a range.
+// swagger:model AgeBucket
+type AgeBucket struct {
+	// Label is the age range, e.g. "30-39", "<20" or "60+".
+	Label string `json:"label" xml:"label" example:"30-39"`
+	// Count is the number of employees in the range.
+	Count int64 `json:"count" xml:"count" example:"7"`
+}
+
+// WorkforceStats are aggregate numbers over the employees currently on the payroll.
+// swagger:model WorkforceStats
+type WorkforceStats struct {
+	// Headcount is the number of employees.
+	Headcount int64 `json:"headcount" xml:"headcount" example:"42"`
+	// ByRole counts the employees holding each role, most common first.
+	ByRole []StatCount `json:"byRole" xml:"byRole>role"`
+	// ByEmailDomain counts the employees per domain of their email address, most common first.
+	ByEmailDomain []StatCount `json:"byEmailDomain" xml:"byEmailDomain>domain"`
+	// ByManager counts the direct reports of each manager, largest team first.
+	ByManager []StatCount `json:"byManager" xml:"byManager>manager"`
+	// AverageAge is the mean age of the employees in years, 0 when there are none.
+	AverageAge float64 `json:"averageAge" xml:"averageAge" example:"36.4"`
+	// AgeDistribution counts the employees per age range, youngest range first.
+	AgeDistribution []AgeBucket `json:"ageDistribution" xml:"ageDistribution>bucket"`
+}
+
+// CostCenterHeadcount is the number of employees charged to a cost center.
+// swagger:model CostCenterHeadcount
+type CostCenterHeadcount struct {
+	// CostCenter is the cost center; empty for the employees charged to none.
+	CostCenter string `json:"costCenter" xml:"costCenter" bson:"_id" example:"CC-1200"`
+	// Headcount is the number of employees charged to the cost center.
+	Headcount int64 `json:"headcount" xml:"headcount" bson:"headcount" example:"14"`
+}
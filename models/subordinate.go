@@ -0,0 +1,30 @@
+package models
+
+import "encoding/xml"
+
+// Subordinate is an employee in the reporting line of a manager, annotated with how far below the manager it is.
+// swagger:model Subordinate
+type Subordinate struct {
+	EmployeeResponse
+	// Depth is 1 for a direct report, 2 for a report of a direct report, and so on.
+	Depth int `json:"depth" xml:"depth" example:"1"`
+}
+
+// MarshalXML implements xml.Marshaler, encoding the subordinate as an <Employee> element.
+// It is needed since the method of the embedded EmployeeResponse would otherwise drop the depth.
+func (s Subordinate) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "Employee"
+	return e.EncodeElement(struct {
+		employeeXML
+		Depth int `xml:"depth"`
+	}{employeeXML(s.EmployeeResponse), s.Depth}, start)
+}
+
+// ReportCounts is the span of control of a manager.
+// swagger:model ReportCounts
+type ReportCounts struct {
+	// Direct is the number of employees reporting to the manager.
+	Direct int64 `json:"direct" xml:"direct" bson:"direct" example:"3"`
+	// Total also counts the reports of the reports, down to the bottom of the organization.
+	Total int64 `json:"total" xml:"total" bson:"total" example:"12"`
+}
@@ -0,0 +1,25 @@
+package models
+
+// Team is a group of employees working together under a lead.
+// swagger:model Team
+type Team struct {
+	// ID identifies the team.
+	ID string `json:"id" xml:"id" bson:"_id" example:"6650c1f2a4d3e2b1c0f9e8d7"`
+	// Name is the display name of the team.
+	Name string `json:"name" xml:"name" bson:"name" example:"Platform"`
+	// Lead is the email of the employee leading the team.
+	Lead string `json:"lead" xml:"lead" bson:"lead" example:"lead@s.afeka.ac.il"`
+	// Members lists the emails of the team members.
+	Members []string `json:"members" xml:"members>member" bson:"members" example:"janesmith@s.afeka.ac.il"`
+}
+
+// TeamRequest is the payload for creating a team.
+// swagger:model TeamRequest
+type TeamRequest struct {
+	// Name is the display name of the team.
+	Name string `json:"name" xml:"name" validate:"notblank,max=100" example:"Platform"`
+	// Lead is the email of the employee leading the team.
+	Lead string `json:"lead" xml:"lead" validate:"required" example:"lead@s.afeka.ac.il"`
+	// Members optionally lists the emails of the initial members.
+	Members []string `json:"members,omitempty" xml:"members>member,omitempty" validate:"max=500" example:"janesmith@s.afeka.ac.il"`
+}
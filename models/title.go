@@ -0,0 +1,12 @@
+package models
+
+// JobTitle is an entry of the titles catalog: a job title employees may hold, with the grades it is paid at.
+// swagger:model JobTitle
+type JobTitle struct {
+	// Name identifies the title and cannot be changed once created.
+	Name string `json:"name" xml:"name" bson:"_id" validate:"notblank,max=64" example:"Software Engineer"`
+	// Description optionally explains the title.
+	Description string `json:"description,omitempty" xml:"description,omitempty" bson:"description,omitempty" validate:"max=500" example:"Designs and builds the product"`
+	// Grades lists the grades holders of the title may have; when empty any grade is accepted.
+	Grades []string `json:"grades" xml:"grades>grade" bson:"grades" validate:"max=50,dive,notblank,max=16" example:"L3,L4,L5"`
+}
@@ -0,0 +1,176 @@
+// Package negotiate renders responses and binds request bodies in the format the client asked for.
+//
+// JSON is the default. Clients sending "Accept: application/xml" receive XML encoded from the xml struct
+// tags on the models; lists are wrapped in a <list> element. Request bodies sent with an XML Content-Type
+// are decoded the same way. List endpoints additionally offer MessagePack ("Accept: application/msgpack"),
+// encoded with the json field names.
+package negotiate
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"reflect"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/requestid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/ugorji/go/codec"
+)
+
+// offered lists the response formats in order of preference.
+var offered = []string{binding.MIMEJSON, binding.MIMEXML, binding.MIMEXML2}
+
+// offeredForLists adds MessagePack to the formats offered by list endpoints.
+var offeredForLists = append(append([]string{}, offered...), binding.MIMEMSGPACK2, binding.MIMEMSGPACK)
+
+// msgpackHandle writes the current MessagePack spec (str8, bin and timestamp types) so any decoder can read it.
+var msgpackHandle = &codec.MsgpackHandle{WriteExt: true}
+
+// Render writes obj with the given status in the format negotiated from the Accept header.
+// Requests that accept none of the offered formats receive JSON.
+func Render(ctx *gin.Context, status int, obj interface{}) {
+	render(ctx, status, obj, ctx.NegotiateFormat(offered...))
+}
+
+// RenderList is Render for list endpoints, which also offer MessagePack for high-volume consumers.
+func RenderList(ctx *gin.Context, status int, list interface{}) {
+	render(ctx, status, list, ctx.NegotiateFormat(offeredForLists...))
+}
+
+func render(ctx *gin.Context, status int, obj interface{}, format string) {
+	ctx.Header("Vary", "Accept")
+	switch format {
+	case binding.MIMEXML, binding.MIMEXML2:
+		ctx.XML(status, xmlValue(obj))
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		var buf bytes.Buffer
+		if err := codec.NewEncoder(&buf, msgpackHandle).Encode(obj); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, "Internal server error", errors.CodeInternal))
+			return
+		}
+		ctx.Data(status, binding.MIMEMSGPACK2, buf.Bytes())
+	default:
+		ctx.JSON(status, obj)
+	}
+}
+
+// Abort renders obj like Render and stops the remaining handlers.
+func Abort(ctx *gin.Context, status int, obj interface{}) {
+	Render(ctx, status, obj)
+	ctx.Abort()
+}
+
+// RenderError writes an error response with a machine-readable code and a message in the language
+// negotiated from the Accept-Language header.
+func RenderError(ctx *gin.Context, status int, code, msg string) {
+	msg, lang := errors.Localize(ctx.GetHeader("Accept-Language"), code, msg)
+	ctx.Header("Content-Language", lang)
+	ctx.Writer.Header().Add("Vary", "Accept-Language")
+	Render(ctx, status, errorResponse(ctx, msg, code))
+}
+
+// errorResponse returns the error response with the message and code, tagged with the ID of the request.
+func errorResponse(ctx *gin.Context, msg, code string) models.ErrorResponse {
+	id, _ := requestid.FromContext(ctx.Request.Context())
+	return models.ErrorResponse{Error: msg, Code: code, RequestID: id}
+}
+
+// AbortWithError renders an error response like RenderError and stops the remaining handlers.
+func AbortWithError(ctx *gin.Context, status int, code, msg string) {
+	RenderError(ctx, status, code, msg)
+	ctx.Abort()
+}
+
+// Bind decodes the request body into obj according to its Content-Type. Bodies without an XML
+// Content-Type are decoded as JSON. With StrictDecoding, a JSON body carrying fields obj does not
+// declare is rejected with an *UnknownFieldsError.
+func Bind(ctx *gin.Context, obj interface{}) error {
+	switch ctx.ContentType() {
+	case binding.MIMEXML, binding.MIMEXML2:
+		if err := decodeXML(ctx.Request.Body, obj); err != nil {
+			return err
+		}
+		if binding.Validator == nil {
+			return nil
+		}
+		return binding.Validator.ValidateStruct(obj)
+	default:
+		if StrictDecoding && ctx.Request.Body != nil {
+			body, err := io.ReadAll(ctx.Request.Body)
+			if err != nil {
+				return err
+			}
+			ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+			if fields := unknownFields(body, obj); len(fields) > 0 {
+				return &UnknownFieldsError{Fields: fields}
+			}
+		}
+		return ctx.ShouldBindJSON(obj)
+	}
+}
+
+// list wraps a slice so it encodes as a single <list> root element with one child per item.
+type list struct {
+	items reflect.Value
+}
+
+// MarshalXML implements xml.Marshaler.
+func (l list) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "list"}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for i := 0; i < l.items.Len(); i++ {
+		if err := e.Encode(l.items.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// xmlValue returns the value to encode as XML, wrapping slices in a <list> element.
+func xmlValue(obj interface{}) interface{} {
+	if v := reflect.ValueOf(obj); v.Kind() == reflect.Slice {
+		return list{items: v}
+	}
+	return obj
+}
+
+// decodeXML decodes an XML document into obj. Slices are read from a root element whose children are the items.
+func decodeXML(r io.Reader, obj interface{}) error {
+	target := reflect.ValueOf(obj)
+	if target.Kind() != reflect.Pointer || target.Elem().Kind() != reflect.Slice {
+		return xml.NewDecoder(r).Decode(obj)
+	}
+	slice := target.Elem()
+	decoder := xml.NewDecoder(r)
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				depth++
+				continue
+			}
+			item := reflect.New(slice.Type().Elem())
+			if err := decoder.DecodeElement(item.Interface(), &t); err != nil {
+				return err
+			}
+			slice.Set(reflect.Append(slice, item.Elem()))
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
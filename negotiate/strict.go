@@ -0,0 +1,121 @@
+package negotiate
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StrictDecoding rejects JSON request bodies carrying fields the target model does not declare,
+// so misspelled fields are reported instead of silently dropped. Disable it for lenient clients.
+var StrictDecoding = true
+
+// UnknownFieldsError lists the fields of a request body that the target model does not declare.
+type UnknownFieldsError struct {
+	// Fields holds the path of each unexpected field, e.g. "birthdate.dya" or "[2].nmae".
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return "unknown fields: " + strings.Join(e.Fields, ", ")
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// unknownFields returns the sorted paths of the fields in data that would be ignored when decoding into obj.
+// Data that is not valid JSON yields none; the decoder reports it.
+func unknownFields(data []byte, obj interface{}) []string {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+	var fields []string
+	collectUnknownFields(doc, reflect.TypeOf(obj), "", &fields)
+	sort.Strings(fields)
+	return fields
+}
+
+func collectUnknownFields(value interface{}, t reflect.Type, path string, fields *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// Types decoding themselves accept whatever they understand.
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			known := jsonFields(t)
+			for key, child := range v {
+				field, ok := known[strings.ToLower(key)]
+				if !ok {
+					*fields = append(*fields, joinPath(path, key))
+					continue
+				}
+				collectUnknownFields(child, field.Type, joinPath(path, key), fields)
+			}
+		case reflect.Map:
+			for key, child := range v {
+				collectUnknownFields(child, t.Elem(), joinPath(path, key), fields)
+			}
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for i, child := range v {
+			collectUnknownFields(child, t.Elem(), path+"["+strconv.Itoa(i)+"]", fields)
+		}
+	}
+}
+
+// jsonFields maps the lower-cased JSON names of a struct's fields, including promoted ones, to the fields.
+// Names are lower-cased because encoding/json matches them case-insensitively.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, promoted := range jsonFields(embedded) {
+					if _, ok := fields[key]; !ok {
+						fields[key] = promoted
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,60 @@
+// Package readonly holds the read-only mode of an instance, switched on and off at runtime, during which the
+// instance keeps serving reads but rejects every mutation, such as while data is migrated or an incident is handled.
+package readonly
+
+import (
+	"sync"
+	"time"
+)
+
+// State is the read-only mode of an instance.
+type State struct {
+	// Enabled is true while mutations are rejected.
+	Enabled bool `json:"enabled" xml:"enabled" example:"true"`
+	// Reason explains to the clients whose mutations are rejected why, and is empty when none was given.
+	Reason string `json:"reason,omitempty" xml:"reason,omitempty" example:"Migrating the employee records"`
+	// Since is when the mode was last switched on or off, and is unset until it is first switched.
+	Since *time.Time `json:"since,omitempty" xml:"since,omitempty" example:"2025-01-01T00:00:00Z"`
+}
+
+// Switch turns the read-only mode of an instance on and off. It is safe for concurrent use.
+type Switch struct {
+	mu    sync.RWMutex
+	state State
+}
+
+// NewSwitch creates a Switch with the read-only mode off.
+func NewSwitch() *Switch {
+	return &Switch{}
+}
+
+// Enable switches the read-only mode on, explaining why with reason. Enabling it again updates the reason only.
+func (s *Switch) Enable(reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.state.Enabled {
+		now := time.Now().UTC()
+		s.state.Since = &now
+	}
+	s.state.Enabled = true
+	s.state.Reason = reason
+}
+
+// Disable switches the read-only mode off.
+func (s *Switch) Disable() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state.Enabled {
+		now := time.Now().UTC()
+		s.state.Since = &now
+	}
+	s.state.Enabled = false
+	s.state.Reason = ""
+}
+
+// State returns the read-only mode as it is now.
+func (s *Switch) State() State {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.state
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// AttendanceCollection is the name of the collection holding attendance records.
+const AttendanceCollection = "attendance"
+
+// AttendanceRepository encapsulates operations on the attendance collection.
+type AttendanceRepository struct {
+	Collection *TenantCollection
+}
+
+// NewAttendanceRepository creates a new AttendanceRepository and ensures an index for summarizing an employee's
+// records by check-in time, and a unique index allowing a single open record per employee.
+func NewAttendanceRepository(client *mongo.Client, dbName string) (*AttendanceRepository, error) {
+	indexModels := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "employee", Value: 1}, {Key: "checkIn", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "employee", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"open": true}),
+		},
+	}
+	coll := NewTenantCollection(client.Database(dbName), AttendanceCollection, indexModels...)
+	if err := coll.CreateIndexes(); err != nil {
+		slog.Warn("Failed to create indexes on attendance", "error", err)
+		return nil, err
+	}
+
+	return &AttendanceRepository{
+		Collection: coll,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"WebMVCEmployees/models"
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// AuditCollection is the name of the collection holding the audit log.
+const AuditCollection = "audit_log"
+
+// AuditRepository stores the audit log, each tenant's in the database of the tenant.
+type AuditRepository struct {
+	Collection *TenantCollection
+}
+
+// NewAuditRepository creates a new AuditRepository.
+func NewAuditRepository(client *mongo.Client, dbName string) *AuditRepository {
+	return &AuditRepository{
+		Collection: NewTenantCollection(client.Database(dbName), AuditCollection),
+	}
+}
+
+// Record appends an entry to the audit log.
+func (r *AuditRepository) Record(ctx context.Context, entry models.AuditEntry) error {
+	coll, err := r.Collection.In(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = coll.InsertOne(ctx, entry)
+	return err
+}
+
+// List returns a page of audit entries, newest first. An empty employee returns entries for every employee.
+func (r *AuditRepository) List(ctx context.Context, employee string, page, size int) ([]models.AuditEntry, error) {
+	filter := bson.M{}
+	if employee != "" {
+		filter["employee"] = employee
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "time", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((page - 1) * size)).
+		SetLimit(int64(size))
+	coll, err := r.Collection.In(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := coll.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	entries := []models.AuditEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// DefaultBatchSize is how many writes BulkWrite sends per round trip unless told otherwise.
+const DefaultBatchSize = 1000
+
+// BulkWrite applies the writes to the collection, sending batchSize of them per round trip, or DefaultBatchSize when
+// batchSize is not positive. Unordered, every write that can apply does; ordered, the writes stop at the first one
+// failing. The failures of every batch are reported in a single mongo.BulkWriteException whose write errors are
+// indexed by position in writes, and the result counts the writes of every batch. Any other error stops the writes
+// and is returned along with the result so far.
+// The batches are not retried by a RetryPolicy: a batch failing after part of it applied would then report the
+// inserts it made as duplicates.
+func BulkWrite(ctx context.Context, coll *mongo.Collection, writes []mongo.WriteModel, batchSize int, ordered bool) (*mongo.BulkWriteResult, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	total := &mongo.BulkWriteResult{Acknowledged: true}
+	var failures mongo.BulkWriteException
+	for start := 0; start < len(writes); start += batchSize {
+		end := min(start+batchSize, len(writes))
+		res, err := coll.BulkWrite(ctx, writes[start:end], options.BulkWrite().SetOrdered(ordered))
+		if res != nil {
+			addBulkWriteResult(total, res, start)
+		}
+		var batchErr mongo.BulkWriteException
+		if err != nil && !errors.As(err, &batchErr) {
+			return total, err
+		}
+		for _, writeErr := range batchErr.WriteErrors {
+			writeErr.Index += start
+			failures.WriteErrors = append(failures.WriteErrors, writeErr)
+		}
+		if batchErr.WriteConcernError != nil {
+			failures.WriteConcernError = batchErr.WriteConcernError
+		}
+		failures.Labels = append(failures.Labels, batchErr.Labels...)
+		if ordered && len(batchErr.WriteErrors) > 0 {
+			break
+		}
+	}
+	if len(failures.WriteErrors) > 0 || failures.WriteConcernError != nil {
+		return total, failures
+	}
+	return total, nil
+}
+
+// addBulkWriteResult adds the counts of the result of the batch starting at offset to total.
+func addBulkWriteResult(total, res *mongo.BulkWriteResult, offset int) {
+	total.InsertedCount += res.InsertedCount
+	total.MatchedCount += res.MatchedCount
+	total.ModifiedCount += res.ModifiedCount
+	total.DeletedCount += res.DeletedCount
+	total.UpsertedCount += res.UpsertedCount
+	for index, id := range res.UpsertedIDs {
+		if total.UpsertedIDs == nil {
+			total.UpsertedIDs = make(map[int64]any)
+		}
+		total.UpsertedIDs[index+int64(offset)] = id
+	}
+	total.Acknowledged = total.Acknowledged && res.Acknowledged
+}
+
+// bulkWriteFailure records err, the duplicate key error of a store outside MongoDB, as the failure of the write at
+// index, as the server reports the failures of a bulk write. It reports false for any other error.
+func bulkWriteFailure(failures *mongo.BulkWriteException, index int, err error) bool {
+	var writeErr mongo.WriteException
+	if !errors.As(err, &writeErr) || len(writeErr.WriteErrors) != 1 {
+		return false
+	}
+	failure := writeErr.WriteErrors[0]
+	failure.Index = index
+	failures.WriteErrors = append(failures.WriteErrors, mongo.BulkWriteError{WriteError: failure})
+	return true
+}
+
+// bulkWriteError returns the failures recorded, or nil when there are none.
+func bulkWriteError(failures mongo.BulkWriteException) error {
+	if len(failures.WriteErrors) == 0 {
+		return nil
+	}
+	return failures
+}
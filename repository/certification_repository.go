@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// CertificationCollection is the name of the collection holding employee certifications.
+const CertificationCollection = "certifications"
+
+// CertificationRepository encapsulates operations on the certifications collection.
+type CertificationRepository struct {
+	Collection *TenantCollection
+}
+
+// NewCertificationRepository creates a new CertificationRepository and ensures the indexes for listing an employee's
+// certifications and the certifications expiring across all employees.
+func NewCertificationRepository(client *mongo.Client, dbName string) (*CertificationRepository, error) {
+	indexModels := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "employee", Value: 1}, {Key: "expiresAt", Value: 1}}},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}},
+	}
+	coll := NewTenantCollection(client.Database(dbName), CertificationCollection, indexModels...)
+	if err := coll.CreateIndexes(); err != nil {
+		slog.Warn("Failed to create indexes on certifications", "error", err)
+		return nil, err
+	}
+
+	return &CertificationRepository{
+		Collection: coll,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CompensationCollection is the name of the collection holding salaries. They are kept apart from the employees
+// so that reading an employee can never expose them.
+const CompensationCollection = "compensation"
+
+// CompensationRepository encapsulates operations on the compensation collection.
+type CompensationRepository struct {
+	Collection *TenantCollection
+}
+
+// NewCompensationRepository creates a new CompensationRepository and ensures a unique index on the employee
+// and effective date, so an employee has a single salary per day.
+func NewCompensationRepository(client *mongo.Client, dbName string) (*CompensationRepository, error) {
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "employee", Value: 1}, {Key: "effectiveDate", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	}
+	coll := NewTenantCollection(client.Database(dbName), CompensationCollection, indexModel)
+	if err := coll.CreateIndexes(); err != nil {
+		slog.Warn("Failed to create index on compensation", "error", err)
+		return nil, err
+	}
+
+	return &CompensationRepository{
+		Collection: coll,
+	}, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import "go.mongodb.org/mongo-driver/v2/mongo"
+
+// DepartmentCollection is the name of the collection holding departments.
+const DepartmentCollection = "departments"
+
+// DepartmentRepository encapsulates operations on the departments collection.
+// Departments are keyed by name, so the _id index keeps names unique.
+type DepartmentRepository struct {
+	Collection *TenantCollection
+}
+
+// NewDepartmentRepository creates a new DepartmentRepository.
+func NewDepartmentRepository(client *mongo.Client, dbName string) *DepartmentRepository {
+	return &DepartmentRepository{
+		Collection: NewTenantCollection(client.Database(dbName), DepartmentCollection),
+	}
+}
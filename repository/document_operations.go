@@ -0,0 +1,527 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// documentFilter checks stored employees against criteria: the MongoDB filter of the criteria, but for the age, which
+// is computed by ageOf rather than by an aggregation expression.
+type documentFilter struct {
+	filter bson.M
+	age    *AgeCriterion
+}
+
+// documentFilterOf returns the filter checking employees against the criteria.
+func documentFilterOf(criteria EmployeeCriteria) (documentFilter, error) {
+	filter, err := filterOf(criteria.Filter())
+	if err != nil {
+		return documentFilter{}, err
+	}
+	delete(filter, "$expr")
+	return documentFilter{filter: filter, age: criteria.Age}, nil
+}
+
+// matches reports whether the stored employee satisfies the criteria.
+func (f documentFilter) matches(doc bson.M) (bool, error) {
+	ok, err := matches(doc, f.filter)
+	if err != nil || !ok || f.age == nil {
+		return ok, err
+	}
+	var emp models.Employee
+	if err := decodeDocument(doc, &emp); err != nil {
+		return false, err
+	}
+	age, ok := ageOf(emp, f.age.At)
+	return ok && age == f.age.Years, nil
+}
+
+// reached is an employee found following the reporting lines, depth levels away from where the walk started.
+type reached struct {
+	doc   bson.M
+	depth int
+}
+
+// documentStore is the storage of the stores keeping employees as documents outside MongoDB; documentOperations
+// implements the operations of the store over it. The documents are in canonical form, password included.
+type documentStore interface {
+	// load returns the employees matching the criteria, in insertion order, at most limit of them when limit > 0.
+	load(ctx context.Context, criteria EmployeeCriteria, limit int) ([]bson.M, error)
+	// change calls change on the employees matching the criteria, in insertion order, at most limit of them when
+	// limit > 0, and stores those it reports changed, in a single write. It returns the documents stored.
+	change(ctx context.Context, criteria EmployeeCriteria, limit int, change func(emp *models.Employee) bool) ([]bson.M, error)
+	// remove removes the employees matching the criteria and returns how many it removed.
+	remove(ctx context.Context, criteria EmployeeCriteria) (int64, error)
+	// walk follows the reporting lines from start, up to its managers or down to its reports, through the
+	// employees matching through, as the $graphLookup of EmployeeRepository does: each employee is reached once,
+	// at the least depth it is found at. With maxDepth > 0, at most maxDepth levels are followed.
+	walk(ctx context.Context, start bson.M, through EmployeeCriteria, up bool, maxDepth int) ([]reached, error)
+}
+
+// changeDocument calls change on the employee stored as doc, and returns the document storing the changed employee,
+// or nil when change reports no change.
+func changeDocument(doc bson.M, change func(emp *models.Employee) bool) (bson.M, error) {
+	var emp models.Employee
+	if err := decodeDocument(doc, &emp); err != nil {
+		return nil, err
+	}
+	if !change(&emp) {
+		return nil, nil
+	}
+	changed, err := canonicalDocument(emp)
+	if err != nil {
+		return nil, err
+	}
+	changed["_id"] = doc["_id"]
+	return changed, nil
+}
+
+// walkDocuments follows the reporting lines from start through docs, as documentStore.walk does.
+func walkDocuments(start bson.M, docs []bson.M, up bool, maxDepth int) []reached {
+	from, to := models.EmployeeRef.Email, models.EmployeeRef.Manager
+	if up {
+		from, to = to, from
+	}
+	var walked []reached
+	visited := make([]bool, len(docs))
+	frontier := []bson.M{start}
+	for depth := 1; len(frontier) > 0 && (maxDepth <= 0 || depth <= maxDepth); depth++ {
+		// Values other than strings, such as a missing manager, connect nothing.
+		searched := map[string]bool{}
+		for _, doc := range frontier {
+			if value, ok := doc[from].(string); ok {
+				searched[value] = true
+			}
+		}
+		frontier = nil
+		for i, doc := range docs {
+			if value, ok := doc[to].(string); ok && !visited[i] && searched[value] {
+				visited[i] = true
+				walked = append(walked, reached{doc: doc, depth: depth})
+				frontier = append(frontier, doc)
+			}
+		}
+	}
+	return walked
+}
+
+// documentOperations implements the operations of the store that MemoryEmployeeRepository,
+// SQLiteEmployeeRepository and PostgresEmployeeRepository share, over the documents of the store. The other
+// subsystems are not available with these stores, so only the employees hold references to other employees.
+type documentOperations struct {
+	store documentStore
+}
+
+// FindByEmail returns the employee with the email unless it is soft-deleted.
+func (o documentOperations) FindByEmail(ctx context.Context, email string) (models.Employee, error) {
+	return o.FindOne(ctx, EmployeeCriteria{State: Active, Emails: []string{email}})
+}
+
+// FindWithCredentials returns the first employee matching the criteria, in insertion order, password included.
+func (o documentOperations) FindWithCredentials(ctx context.Context, criteria EmployeeCriteria) (models.Employee, error) {
+	return o.findOne(ctx, criteria, true)
+}
+
+// FindOne returns the first employee matching the criteria, in insertion order, without the password.
+func (o documentOperations) FindOne(ctx context.Context, criteria EmployeeCriteria) (models.Employee, error) {
+	return o.findOne(ctx, criteria, false)
+}
+
+// findOne returns the first employee matching the criteria, with the password when credentials is set.
+func (o documentOperations) findOne(ctx context.Context, criteria EmployeeCriteria, credentials bool) (models.Employee, error) {
+	found, err := o.store.load(ctx, criteria, 1)
+	if err != nil {
+		return models.Employee{}, err
+	}
+	if len(found) == 0 {
+		return models.Employee{}, mongo.ErrNoDocuments
+	}
+	return decodeStored(found[0], credentials)
+}
+
+// employees returns the employees matching the criteria, in insertion order, without their password.
+func (o documentOperations) employees(ctx context.Context, criteria EmployeeCriteria) ([]models.Employee, error) {
+	found, err := o.store.load(ctx, criteria, 0)
+	if err != nil {
+		return nil, err
+	}
+	emps := make([]models.Employee, len(found))
+	for i, doc := range found {
+		if emps[i], err = decodeStored(doc, false); err != nil {
+			return nil, err
+		}
+	}
+	return emps, nil
+}
+
+// changeAll calls change on every employee matching the criteria and returns how many it changed.
+func (o documentOperations) changeAll(ctx context.Context, criteria EmployeeCriteria, change func(emp *models.Employee) bool) (int64, error) {
+	changed, err := o.store.change(ctx, criteria, 0, change)
+	return int64(len(changed)), err
+}
+
+// changeFirst calls change on the first employee matching the criteria and returns the changed employee, without
+// the password, or mongo.ErrNoDocuments when none matches.
+func (o documentOperations) changeFirst(ctx context.Context, criteria EmployeeCriteria, change func(emp *models.Employee) bool) (models.Employee, error) {
+	changed, err := o.store.change(ctx, criteria, 1, change)
+	if err != nil {
+		return models.Employee{}, err
+	}
+	if len(changed) == 0 {
+		return models.Employee{}, mongo.ErrNoDocuments
+	}
+	return decodeStored(changed[0], false)
+}
+
+// SetManager makes manager the manager of every employee matching the criteria, dropping it from their dotted lines,
+// or removes their manager when it is empty. It returns how many employees it updated.
+func (o documentOperations) SetManager(ctx context.Context, criteria EmployeeCriteria, manager string) (int64, error) {
+	return o.changeAll(ctx, criteria, func(emp *models.Employee) bool {
+		if manager == "" {
+			emp.Manager = nil
+		} else {
+			emp.Manager = &manager
+			emp.DottedLineManagers = slices.DeleteFunc(emp.DottedLineManagers, func(m string) bool { return m == manager })
+		}
+		emp.Version++
+		return true
+	})
+}
+
+// AddRoles adds the roles every employee matching the criteria does not hold yet, and returns how many employees it
+// updated.
+func (o documentOperations) AddRoles(ctx context.Context, criteria EmployeeCriteria, roles []string) (int64, error) {
+	return o.changeAll(ctx, criteria, func(emp *models.Employee) bool {
+		for _, role := range roles {
+			if !slices.Contains(emp.Roles, role) {
+				emp.Roles = append(emp.Roles, role)
+			}
+		}
+		emp.Version++
+		return true
+	})
+}
+
+// GrantTemporaryRole grants the role of the grant to the first employee matching the criteria until it expires, and
+// returns the updated employee. A temporary grant of the role the employee holds already is extended; otherwise the
+// role is added to their roles along with the grant.
+func (o documentOperations) GrantTemporaryRole(ctx context.Context, criteria EmployeeCriteria, grant models.RoleGrant) (models.Employee, error) {
+	return o.changeFirst(ctx, criteria, func(emp *models.Employee) bool {
+		if i := slices.IndexFunc(emp.TemporaryRoles, func(g models.RoleGrant) bool { return g.Role == grant.Role }); i >= 0 {
+			emp.TemporaryRoles[i].ExpiresAt, emp.TemporaryRoles[i].GrantedAt = grant.ExpiresAt, grant.GrantedAt
+		} else {
+			if !slices.Contains(emp.Roles, grant.Role) {
+				emp.Roles = append(emp.Roles, grant.Role)
+			}
+			emp.TemporaryRoles = append(emp.TemporaryRoles, grant)
+		}
+		emp.Version++
+		return true
+	})
+}
+
+// RevokeExpiredRole removes the role, and its temporary grant, from every employee matching the criteria whose grant
+// of the role expired at or before now. It returns how many employees it updated.
+func (o documentOperations) RevokeExpiredRole(ctx context.Context, criteria EmployeeCriteria, role string, now time.Time) (int64, error) {
+	return o.changeAll(ctx, criteria, func(emp *models.Employee) bool {
+		expired := slices.ContainsFunc(emp.TemporaryRoles, func(g models.RoleGrant) bool {
+			return g.Role == role && !g.ExpiresAt.After(now)
+		})
+		if !expired {
+			return false
+		}
+		emp.Roles = slices.DeleteFunc(emp.Roles, func(r string) bool { return r == role })
+		emp.TemporaryRoles = slices.DeleteFunc(emp.TemporaryRoles, func(g models.RoleGrant) bool { return g.Role == role })
+		emp.Version++
+		return true
+	})
+}
+
+// SetDelegation sets the delegation of the first employee matching the criteria, replacing any other, and returns the
+// updated employee.
+func (o documentOperations) SetDelegation(ctx context.Context, criteria EmployeeCriteria, delegation models.Delegation) (models.Employee, error) {
+	return o.changeFirst(ctx, criteria, func(emp *models.Employee) bool {
+		emp.Delegation = &delegation
+		emp.Version++
+		return true
+	})
+}
+
+// RemoveDelegation removes the delegation of every employee matching the criteria, and returns how many had one.
+func (o documentOperations) RemoveDelegation(ctx context.Context, criteria EmployeeCriteria) (int64, error) {
+	return o.changeAll(ctx, criteria, func(emp *models.Employee) bool {
+		if emp.Delegation == nil {
+			return false
+		}
+		emp.Delegation = nil
+		emp.Version++
+		return true
+	})
+}
+
+// SetStatus moves the first employee matching the criteria to the status the transition leads to, recording it in
+// their status history, and returns the updated employee.
+func (o documentOperations) SetStatus(ctx context.Context, criteria EmployeeCriteria, transition models.StatusTransition) (models.Employee, error) {
+	return o.changeFirst(ctx, criteria, func(emp *models.Employee) bool {
+		emp.Status = transition.To
+		emp.StatusHistory = append(emp.StatusHistory, transition)
+		emp.Version++
+		return true
+	})
+}
+
+// AddDottedLine adds the manager to the dotted lines of every employee matching the criteria, unless it is there
+// already, and returns how many employees it updated.
+func (o documentOperations) AddDottedLine(ctx context.Context, criteria EmployeeCriteria, manager string) (int64, error) {
+	return o.changeAll(ctx, criteria, func(emp *models.Employee) bool {
+		if !slices.Contains(emp.DottedLineManagers, manager) {
+			emp.DottedLineManagers = append(emp.DottedLineManagers, manager)
+		}
+		emp.Version++
+		return true
+	})
+}
+
+// RemoveDottedLine removes the manager from the dotted lines of every employee matching the criteria, and returns
+// how many had it.
+func (o documentOperations) RemoveDottedLine(ctx context.Context, criteria EmployeeCriteria, manager string) (int64, error) {
+	return o.changeAll(ctx, criteria, func(emp *models.Employee) bool {
+		if !slices.Contains(emp.DottedLineManagers, manager) {
+			return false
+		}
+		emp.DottedLineManagers = slices.DeleteFunc(emp.DottedLineManagers, func(m string) bool { return m == manager })
+		emp.Version++
+		return true
+	})
+}
+
+// ChangeEmail changes the email of the first employee matching the criteria, and reports whether one matched. An
+// email taken in the tenant is reported as a duplicate key error.
+func (o documentOperations) ChangeEmail(ctx context.Context, criteria EmployeeCriteria, email string) (bool, error) {
+	changed, err := o.store.change(ctx, criteria, 1, func(emp *models.Employee) bool {
+		emp.Email = email
+		emp.Version++
+		return true
+	})
+	return len(changed) > 0, err
+}
+
+// AssignID sets the internal ID of the first employee matching the criteria, and reports whether one matched. An ID
+// taken is reported as a duplicate key error.
+func (o documentOperations) AssignID(ctx context.Context, criteria EmployeeCriteria, id string) (bool, error) {
+	changed, err := o.store.change(ctx, criteria, 1, func(emp *models.Employee) bool {
+		emp.ID = id
+		return true
+	})
+	return len(changed) > 0, err
+}
+
+// DeriveDisplayNames sets the display name of the employees matching the criteria stored without one, from their
+// preferred name or else their legal name, and returns how many it set.
+func (o documentOperations) DeriveDisplayNames(ctx context.Context, criteria EmployeeCriteria) (int64, error) {
+	return o.changeAll(ctx, criteria, func(emp *models.Employee) bool {
+		if emp.DisplayName != "" {
+			return false
+		}
+		emp.DisplayName = emp.PreferredName
+		if emp.DisplayName == "" {
+			emp.DisplayName = emp.Name
+		}
+		return true
+	})
+}
+
+// SoftDelete marks every employee matching the criteria as deleted at the time, and returns how many it marked.
+func (o documentOperations) SoftDelete(ctx context.Context, criteria EmployeeCriteria, at time.Time) (int64, error) {
+	return o.changeAll(ctx, criteria, func(emp *models.Employee) bool {
+		emp.DeletedAt = &at
+		emp.Version++
+		return true
+	})
+}
+
+// Restore clears the deletion mark of the first employee matching the criteria, and returns the restored employee.
+func (o documentOperations) Restore(ctx context.Context, criteria EmployeeCriteria) (models.Employee, error) {
+	return o.changeFirst(ctx, criteria, func(emp *models.Employee) bool {
+		emp.DeletedAt = nil
+		emp.Version++
+		return true
+	})
+}
+
+// Delete removes every employee matching the criteria for good, and returns how many it removed.
+func (o documentOperations) Delete(ctx context.Context, criteria EmployeeCriteria) (int64, error) {
+	return o.store.remove(ctx, criteria)
+}
+
+// MoveEmailReferences points the managers, dotted lines and delegations of the employees matching the criteria
+// referring to the employee with the old email at the new email, as EmployeeRepository.MoveEmailReferences does.
+func (o documentOperations) MoveEmailReferences(ctx context.Context, criteria EmployeeCriteria, from, to string) error {
+	criteria.References = from
+	_, err := o.store.change(ctx, criteria, 0, func(emp *models.Employee) bool {
+		if emp.Manager != nil && *emp.Manager == from {
+			manager := to
+			emp.Manager = &manager
+			emp.Version++
+		}
+		// Only the first occurrence is moved, as the positional update of MongoDB does.
+		if i := slices.Index(emp.DottedLineManagers, from); i >= 0 {
+			emp.DottedLineManagers[i] = to
+			emp.Version++
+		}
+		if emp.Delegation != nil && emp.Delegation.Delegate == from {
+			emp.Delegation.Delegate = to
+			emp.Version++
+		}
+		return true
+	})
+	return err
+}
+
+// ManagerChain returns the first employee matching the criteria followed by their manager, the manager's manager and
+// so on, as EmployeeRepository.ManagerChain does.
+func (o documentOperations) ManagerChain(ctx context.Context, criteria EmployeeCriteria, maxDepth int) ([]models.Employee, error) {
+	found, err := o.store.load(ctx, criteria, 1)
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	walked, err := o.store.walk(ctx, found[0], walkedThrough(criteria), true, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	start, err := decodeStored(found[0], false)
+	if err != nil {
+		return nil, err
+	}
+	managers := make([]Report, len(walked))
+	for i, manager := range walked {
+		if managers[i].Employee, err = decodeStored(manager.doc, false); err != nil {
+			return nil, err
+		}
+		managers[i].Depth = manager.depth
+	}
+	return chainOf(start, managers), nil
+}
+
+// reportsOf returns the reports of the first employee matching the criteria, as documents with their depth, leaving
+// out the employee if a stored cycle leads back to them. It reports false when no employee matches.
+func (o documentOperations) reportsOf(ctx context.Context, criteria EmployeeCriteria, maxDepth int) ([]bson.M, bool, error) {
+	found, err := o.store.load(ctx, criteria, 1)
+	if err != nil || len(found) == 0 {
+		return nil, false, err
+	}
+	walked, err := o.store.walk(ctx, found[0], walkedThrough(criteria), false, maxDepth)
+	if err != nil {
+		return nil, false, err
+	}
+	var reports []bson.M
+	for _, report := range walked {
+		if equal(report.doc[models.EmployeeRef.Email], found[0][models.EmployeeRef.Email]) {
+			continue
+		}
+		doc := maps.Clone(report.doc)
+		doc["depth"] = report.depth
+		reports = append(reports, doc)
+	}
+	return reports, true, nil
+}
+
+// Reports returns the direct and indirect reports of the first employee matching the criteria, as
+// EmployeeRepository.Reports does.
+func (o documentOperations) Reports(ctx context.Context, criteria EmployeeCriteria, maxDepth int, page Page) ([]Report, error) {
+	docs, _, err := o.reportsOf(ctx, criteria, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	find, err := resolveFindOptions([]options.Lister[options.FindOptions]{
+		page.findOptions().SetSort(append(bson.D{{Key: "depth", Value: 1}}, page.order()...)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if docs, err = applyFindOptions(docs, find); err != nil {
+		return nil, err
+	}
+	var reports []Report
+	err = decodeAll(docs, &reports)
+	return reports, err
+}
+
+// CountReports counts the direct reports of the first employee matching the criteria and all their reports, direct
+// and indirect. It returns mongo.ErrNoDocuments when no employee matches.
+func (o documentOperations) CountReports(ctx context.Context, criteria EmployeeCriteria) (models.ReportCounts, error) {
+	docs, found, err := o.reportsOf(ctx, criteria, 0)
+	if err != nil {
+		return models.ReportCounts{}, err
+	}
+	if !found {
+		return models.ReportCounts{}, mongo.ErrNoDocuments
+	}
+	counts := models.ReportCounts{Total: int64(len(docs))}
+	for _, doc := range docs {
+		if doc["depth"] == 1 {
+			counts.Direct++
+		}
+	}
+	return counts, nil
+}
+
+// Anniversaries returns the work anniversaries falling in the month of the year of the employees matching the
+// criteria, as EmployeeRepository.Anniversaries does.
+func (o documentOperations) Anniversaries(ctx context.Context, criteria EmployeeCriteria, month time.Month, year int, page Page) ([]models.Anniversary, error) {
+	emps, err := o.employees(ctx, anniversaryCriteria(criteria, month, year))
+	if err != nil {
+		return nil, err
+	}
+	anniversaries := make([]models.Anniversary, len(emps))
+	for i, emp := range emps {
+		anniversaries[i] = anniversaryOf(emp, year)
+	}
+	sort.Slice(anniversaries, func(i, j int) bool {
+		if anniversaries[i].Date != anniversaries[j].Date {
+			return anniversaries[i].Date < anniversaries[j].Date
+		}
+		return anniversaries[i].Email < anniversaries[j].Email
+	})
+	anniversaries = anniversaries[min(int(page.Skip), len(anniversaries)):]
+	if page.Limit > 0 {
+		anniversaries = anniversaries[:min(int(page.Limit), len(anniversaries))]
+	}
+	return anniversaries, nil
+}
+
+// HeadcountByCostCenter counts the employees matching the criteria charged to each cost center, as
+// EmployeeRepository.HeadcountByCostCenter does.
+func (o documentOperations) HeadcountByCostCenter(ctx context.Context, criteria EmployeeCriteria) ([]models.CostCenterHeadcount, error) {
+	emps, err := o.employees(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	byCostCenter := map[string]int64{}
+	for _, emp := range emps {
+		byCostCenter[emp.CostCenter]++
+	}
+	headcounts := []models.CostCenterHeadcount{}
+	for costCenter, headcount := range byCostCenter {
+		headcounts = append(headcounts, models.CostCenterHeadcount{CostCenter: costCenter, Headcount: headcount})
+	}
+	return headcounts, nil
+}
+
+// Workforce breaks the employees matching the criteria down by role, email domain, manager and age as of now.
+func (o documentOperations) Workforce(ctx context.Context, criteria EmployeeCriteria, now time.Time) (Workforce, error) {
+	emps, err := o.employees(ctx, criteria)
+	if err != nil {
+		return Workforce{}, err
+	}
+	return workforceOf(emps, now), nil
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// DocumentBucket is the name of the GridFS bucket holding employee documents.
+const DocumentBucket = "employee_documents"
+
+// DocumentRepository stores employee documents in GridFS. Each document is a file named after the upload, with
+// the employee, category and content type kept in its metadata. Each tenant keeps its documents in a bucket of the
+// tenant's database.
+type DocumentRepository struct {
+	// Files is the files collection of the bucket.
+	Files *TenantCollection
+}
+
+// documentMetadata is stored with each document file.
+type documentMetadata struct {
+	Employee    string `bson:"employee"`
+	Category    string `bson:"category"`
+	ContentType string `bson:"contentType"`
+}
+
+// documentFile is a document as stored in the files collection of the bucket.
+type documentFile struct {
+	ID         bson.ObjectID    `bson:"_id"`
+	Filename   string           `bson:"filename"`
+	Length     int64            `bson:"length"`
+	UploadDate time.Time        `bson:"uploadDate"`
+	Metadata   documentMetadata `bson:"metadata"`
+}
+
+func (f documentFile) document() models.Document {
+	return models.Document{
+		ID:          f.ID.Hex(),
+		Employee:    f.Metadata.Employee,
+		Name:        f.Filename,
+		Category:    f.Metadata.Category,
+		ContentType: f.Metadata.ContentType,
+		Size:        f.Length,
+		UploadedAt:  f.UploadDate,
+	}
+}
+
+// NewDocumentRepository creates a new DocumentRepository and ensures an index listing an employee's documents by
+// upload date.
+func NewDocumentRepository(client *mongo.Client, dbName string) (*DocumentRepository, error) {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "metadata.employee", Value: 1}, {Key: "uploadDate", Value: -1}},
+	}
+	files := NewTenantCollection(client.Database(dbName), DocumentBucket+".files", indexModel)
+	if err := files.CreateIndexes(); err != nil {
+		slog.Warn("Failed to create index on documents", "error", err)
+		return nil, err
+	}
+
+	return &DocumentRepository{
+		Files: files,
+	}, nil
+}
+
+// bucket returns the bucket of the tenant ctx is made for.
+func (r *DocumentRepository) bucket(ctx context.Context) (*mongo.GridFSBucket, error) {
+	files, err := r.Files.In(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return files.Database().GridFSBucket(options.GridFSBucket().SetName(DocumentBucket)), nil
+}
+
+// Save stores the content of a document and returns the document with its identifier and upload date.
+func (r *DocumentRepository) Save(ctx context.Context, doc models.Document, data []byte) (models.Document, error) {
+	metadata := documentMetadata{Employee: doc.Employee, Category: doc.Category, ContentType: doc.ContentType}
+	bucket, err := r.bucket(ctx)
+	if err != nil {
+		return models.Document{}, err
+	}
+	id, err := bucket.UploadFromStream(ctx, doc.Name, bytes.NewReader(data), options.GridFSUpload().SetMetadata(metadata))
+	if err != nil {
+		return models.Document{}, err
+	}
+	return r.Find(ctx, id.Hex())
+}
+
+// List returns a page of the employee's documents, newest first.
+func (r *DocumentRepository) List(ctx context.Context, email string, skip, limit int) ([]models.Document, error) {
+	findOptions := options.GridFSFind().
+		SetSort(bson.D{{Key: "uploadDate", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int32(skip)).
+		SetLimit(int32(limit))
+	bucket, err := r.bucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := bucket.Find(ctx, bson.M{"metadata.employee": email}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	var files []documentFile
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	docs := make([]models.Document, len(files))
+	for i, file := range files {
+		docs[i] = file.document()
+	}
+	return docs, nil
+}
+
+// Find returns the document with the given identifier, or mongo.ErrFileNotFound.
+func (r *DocumentRepository) Find(ctx context.Context, id string) (models.Document, error) {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return models.Document{}, mongo.ErrFileNotFound
+	}
+	files, err := r.Files.In(ctx)
+	if err != nil {
+		return models.Document{}, err
+	}
+	var file documentFile
+	err = files.FindOne(ctx, bson.M{"_id": objectID}).Decode(&file)
+	if err == mongo.ErrNoDocuments {
+		return models.Document{}, mongo.ErrFileNotFound
+	}
+	if err != nil {
+		return models.Document{}, err
+	}
+	return file.document(), nil
+}
+
+// Load returns the document with the given identifier and its content, or mongo.ErrFileNotFound.
+func (r *DocumentRepository) Load(ctx context.Context, id string) (models.Document, []byte, error) {
+	doc, err := r.Find(ctx, id)
+	if err != nil {
+		return models.Document{}, nil, err
+	}
+	objectID, _ := bson.ObjectIDFromHex(id)
+	bucket, err := r.bucket(ctx)
+	if err != nil {
+		return models.Document{}, nil, err
+	}
+	stream, err := bucket.OpenDownloadStream(ctx, objectID)
+	if err != nil {
+		return models.Document{}, nil, err
+	}
+	defer stream.Close()
+
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		return models.Document{}, nil, err
+	}
+	return doc, data, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"WebMVCEmployees/models"
+	"context"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// emailReference is a field holding the email of an employee.
+type emailReference struct {
+	collection string
+	field      string
+	// list is set when the field holds an array of emails rather than a single one.
+	list bool
+}
+
+// target is the path updated to replace the email: the field itself, or the matched element of a list.
+func (r emailReference) target() string {
+	if r.list {
+		return r.field + ".$"
+	}
+	return r.field
+}
+
+// filter matches the records referring to the email, among those matching scope.
+func (r emailReference) filter(email string, scope bson.M) bson.M {
+	scope[r.field] = email
+	return scope
+}
+
+// employeeEmailReferences lists the fields of the employee records that refer to other employees by email.
+var employeeEmailReferences = []emailReference{
+	{field: models.EmployeeRef.Manager},
+	{field: models.EmployeeRef.DottedLines, list: true},
+	{field: models.EmployeeRef.Delegation + ".delegate"},
+}
+
+// emailReferences lists the fields outside the employee records that refer to employees by email; they follow the
+// employee when the email changes. The audit log and the org chart snapshots are history and keep the old email.
+var emailReferences = []emailReference{
+	{collection: NoteCollection, field: "employee"},
+	{collection: NoteCollection, field: "author"},
+	{collection: CertificationCollection, field: "employee"},
+	{collection: LeaveRequestCollection, field: "employee"},
+	{collection: LeaveRequestCollection, field: "approver"},
+	{collection: LeaveBalanceCollection, field: "employee"},
+	{collection: AttendanceCollection, field: "employee"},
+	{collection: ReviewCollection, field: "employee"},
+	{collection: ReviewCollection, field: "reviewer"},
+	{collection: CompensationCollection, field: "employee"},
+	{collection: CompensationCollection, field: "recordedBy"},
+	{collection: EmergencyContactCollection, field: "updatedBy"},
+	{collection: TeamCollection, field: "lead"},
+	{collection: TeamCollection, field: "members", list: true},
+	{collection: DocumentBucket + ".files", field: "metadata.employee"},
+}
+
+// emailKeyedCollections lists the collections whose documents are keyed by the email of an employee, alone or
+// followed by a slash and a qualifier such as the year of a leave balance.
+var emailKeyedCollections = []string{EmergencyContactCollection, LeaveBalanceCollection}
+
+// MoveEmailReferences points every reference to the employee with the old email at the new email: the managers,
+// dotted lines and delegations of the employees matching the criteria, and the records of the other subsystems. It
+// does not change the email of the employee itself.
+func (r *EmployeeRepository) MoveEmailReferences(ctx context.Context, criteria EmployeeCriteria, from, to string) error {
+	for _, ref := range employeeEmailReferences {
+		if _, err := r.Collection.UpdateMany(ctx, ref.filter(from, criteria.Filter()), bson.M{
+			"$set": bson.M{ref.target(): to},
+			"$inc": bson.M{models.EmployeeRef.Version: 1},
+		}); err != nil {
+			return err
+		}
+	}
+
+	// The records of the other subsystems are kept in the database of the tenant ctx is made for.
+	db := tenantDatabase(ctx, r.Collection.Database())
+	for _, ref := range emailReferences {
+		if _, err := db.Collection(ref.collection).UpdateMany(ctx, bson.M{ref.field: from}, bson.M{"$set": bson.M{ref.target(): to}}); err != nil {
+			return err
+		}
+	}
+
+	// Documents keyed by the email are copied under the new key, since an _id cannot be changed.
+	keyed := bson.M{"$regex": "^" + regexp.QuoteMeta(from) + "(/|$)"}
+	for _, name := range emailKeyedCollections {
+		coll := db.Collection(name)
+		cursor, err := coll.Find(ctx, bson.M{"_id": keyed})
+		if err != nil {
+			return err
+		}
+		var docs []bson.M
+		if err := cursor.All(ctx, &docs); err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			key, _ := doc["_id"].(string)
+			doc["_id"] = to + strings.TrimPrefix(key, from)
+			if _, err := coll.InsertOne(ctx, doc); err != nil {
+				return err
+			}
+			if _, err := coll.DeleteOne(ctx, bson.M{"_id": key}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Photo files are named after the email of the employee, followed by the variant.
+	photos := db.Collection(PhotoBucket + ".files")
+	cursor, err := photos.Find(ctx, bson.M{"filename": bson.M{"$regex": "^" + regexp.QuoteMeta(from) + "/"}})
+	if err != nil {
+		return err
+	}
+	var files []struct {
+		ID       bson.ObjectID `bson:"_id"`
+		Filename string        `bson:"filename"`
+	}
+	if err := cursor.All(ctx, &files); err != nil {
+		return err
+	}
+	for _, file := range files {
+		filename := to + strings.TrimPrefix(file.Filename, from)
+		if _, err := photos.UpdateOne(ctx, bson.M{"_id": file.ID}, bson.M{"$set": bson.M{"filename": filename}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package repository
+
+import "go.mongodb.org/mongo-driver/v2/mongo"
+
+// EmergencyContactCollection is the name of the collection holding emergency contacts. They are kept apart from the
+// employees so that reading an employee can never expose them.
+const EmergencyContactCollection = "emergency_contacts"
+
+// EmergencyContactRepository encapsulates operations on the emergency contacts collection.
+// Each document holds the contacts of one employee and is keyed by the employee's email.
+type EmergencyContactRepository struct {
+	Collection *TenantCollection
+}
+
+// NewEmergencyContactRepository creates a new EmergencyContactRepository.
+func NewEmergencyContactRepository(client *mongo.Client, dbName string) *EmergencyContactRepository {
+	return &EmergencyContactRepository{
+		Collection: NewTenantCollection(client.Database(dbName), EmergencyContactCollection),
+	}
+}
@@ -0,0 +1,422 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// EmployeeState selects employees by soft deletion and termination.
+type EmployeeState int
+
+const (
+	// Listed employees are neither soft-deleted nor terminated; they are those the list endpoints show.
+	Listed EmployeeState = iota
+	// Active employees are not soft-deleted, whatever their status.
+	Active
+	// Deleted employees are soft-deleted.
+	Deleted
+	// AnyState matches employees whether soft-deleted or not.
+	AnyState
+)
+
+// EmployeeCriteria selects the employees a read or a write of the store applies to. Every criterion set must hold;
+// the zero value matches the listed employees.
+type EmployeeCriteria struct {
+	// State restricts the employees by soft deletion and termination.
+	State EmployeeState
+	// Tenant restricts the employees to a tenant, when set.
+	Tenant string
+	// NoTenant restricts the employees to those belonging to no tenant.
+	NoTenant bool
+	// Emails restricts the employees to those with one of the emails, when not nil.
+	Emails []string
+	// ExceptEmail leaves out the employee with the email.
+	ExceptEmail string
+	// ID restricts the employees to the one with the internal ID, when set.
+	ID string
+	// MissingID restricts the employees to those stored without an ID.
+	MissingID bool
+	// Version restricts the employees to those at the version, when non-zero.
+	Version int64
+	// Managers restricts the employees to those managed by one of the emails, when not nil.
+	Managers []string
+	// EmailDomain restricts the employees to those whose email is at the domain, in any case.
+	EmailDomain string
+	// Roles lists sets of roles; an employee must hold one role of each set.
+	Roles [][]string
+	// Status restricts the employees to those with the status; employees without one are active.
+	Status string
+	// Department, Title, Grade, Location and CostCenter restrict the employees to those with the value given.
+	Department string
+	Title      string
+	Grade      string
+	Location   string
+	CostCenter string
+	// Country restricts the employees to those whose address is in the country, an ISO 3166-1 alpha-2 code.
+	Country string
+	// City restricts the employees to those whose address is in the city, in any case.
+	City string
+	// HiredAfter and HiredBy bound the hire dates, formatted as YYYY-MM-DD: hired after the first day and at or
+	// before the second. Employees without a hire date never match.
+	HiredAfter string
+	HiredBy    string
+	// HireMonth restricts the employees to those hired in the month of any year, when set.
+	HireMonth time.Month
+	// Skill restricts the employees to those having the named skill at MinSkillLevel or above.
+	Skill         string
+	MinSkillLevel int
+	// Age restricts the employees to those of the age, leaving out those without a birth date.
+	Age *AgeCriterion
+	// DelegatedTo restricts the employees to managers delegating their duties to the email at DelegatedAt.
+	DelegatedTo string
+	DelegatedAt time.Time
+	// RolesExpiredBy restricts the employees to those holding a temporary role expired at or before the time.
+	RolesExpiredBy time.Time
+	// DeletedBy restricts the soft-deleted employees to those deleted at or before the time.
+	DeletedBy time.Time
+	// References restricts the employees to those referring to the email as their manager, as one of their
+	// dotted-line managers or as their delegate.
+	References string
+	// Example restricts the employees to those matching every non-empty field of the example employee; see
+	// HasExampleFields.
+	Example *models.Employee
+}
+
+// AgeCriterion matches the employees Years old at At, in the time zone of each employee.
+type AgeCriterion struct {
+	Years int
+	At    time.Time
+}
+
+// SortKey orders a list by an employee field.
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// Page selects and orders the employees of a list.
+type Page struct {
+	// Sort orders the employees by each key in turn.
+	Sort []SortKey
+	// Collation compares the strings sorted, unless nil.
+	Collation *options.Collation
+	// Skip leaves out the first employees; Limit caps the number of employees returned, unless zero.
+	Skip  int64
+	Limit int64
+}
+
+// Filter returns the MongoDB filter matching the criteria.
+func (c EmployeeCriteria) Filter() bson.M {
+	filter := bson.M{}
+	if c.Example != nil {
+		filter = exampleFilter(*c.Example)
+	}
+	var and bson.A
+	switch c.State {
+	case Listed:
+		filter[models.EmployeeRef.DeletedAt] = nil
+		filter[models.EmployeeRef.Status] = bson.M{"$ne": models.StatusTerminated}
+	case Active:
+		filter[models.EmployeeRef.DeletedAt] = nil
+	case Deleted:
+		deletedAt := bson.M{"$ne": nil}
+		if !c.DeletedBy.IsZero() {
+			deletedAt["$lte"] = c.DeletedBy
+		}
+		filter[models.EmployeeRef.DeletedAt] = deletedAt
+	}
+	if c.Tenant != "" {
+		filter[models.EmployeeRef.TenantID] = c.Tenant
+	} else if c.NoTenant {
+		filter[models.EmployeeRef.TenantID] = nil
+	}
+
+	var email bson.M
+	setEmail := func(operator string, value any) {
+		if email == nil {
+			email = bson.M{}
+		}
+		email[operator] = value
+	}
+	if c.Emails != nil {
+		setEmail("$in", c.Emails)
+	}
+	if c.ExceptEmail != "" {
+		setEmail("$ne", c.ExceptEmail)
+	}
+	if c.EmailDomain != "" {
+		setEmail("$regex", "@"+regexp.QuoteMeta(c.EmailDomain)+"$")
+		email["$options"] = "i"
+	}
+	if email != nil {
+		if example, ok := filter[models.EmployeeRef.Email]; ok {
+			and = append(and, bson.M{models.EmployeeRef.Email: example})
+		}
+		filter[models.EmployeeRef.Email] = email
+	}
+
+	if c.ID != "" {
+		filter[models.EmployeeRef.ID] = c.ID
+	}
+	if c.MissingID {
+		filter[models.EmployeeRef.ID] = bson.M{"$exists": false}
+	}
+	if c.Version != 0 {
+		filter[models.EmployeeRef.Version] = c.Version
+	}
+	if c.Managers != nil {
+		filter[models.EmployeeRef.Manager] = bson.M{"$in": c.Managers}
+	}
+	for _, roles := range c.Roles {
+		and = append(and, bson.M{models.EmployeeRef.Roles: bson.M{"$in": roles}})
+	}
+	switch c.Status {
+	case "":
+	case models.StatusActive:
+		filter[models.EmployeeRef.Status] = bson.M{"$in": bson.A{nil, models.StatusActive}}
+	default:
+		filter[models.EmployeeRef.Status] = c.Status
+	}
+	for field, value := range map[string]string{
+		models.EmployeeRef.Department: c.Department,
+		models.EmployeeRef.Title:      c.Title,
+		models.EmployeeRef.Grade:      c.Grade,
+		models.EmployeeRef.Location:   c.Location,
+		models.EmployeeRef.CostCenter: c.CostCenter,
+	} {
+		if value != "" {
+			filter[field] = value
+		}
+	}
+	if c.Country != "" {
+		filter[models.EmployeeRef.Address+".country"] = c.Country
+	}
+	if c.City != "" {
+		filter[models.EmployeeRef.Address+".city"] = bson.M{"$regex": "^" + regexp.QuoteMeta(c.City) + "$", "$options": "i"}
+	}
+	if c.HiredAfter != "" || c.HiredBy != "" || c.HireMonth != 0 {
+		hireDate := bson.M{}
+		if c.HiredAfter != "" {
+			hireDate["$gt"] = c.HiredAfter
+		}
+		if c.HiredBy != "" {
+			hireDate["$lte"] = c.HiredBy
+		}
+		// Hire dates are formatted as YYYY-MM-DD, so the month is matched on the string.
+		if c.HireMonth != 0 {
+			hireDate["$regex"] = fmt.Sprintf(`^\d{4}-%02d-`, c.HireMonth)
+		}
+		filter[models.EmployeeRef.HireDate] = hireDate
+	}
+	if c.Skill != "" {
+		filter[models.EmployeeRef.Skills] = bson.M{"$elemMatch": bson.M{"name": c.Skill, "level": bson.M{"$gte": c.MinSkillLevel}}}
+	}
+	if c.Age != nil {
+		filter[models.EmployeeRef.BirthDate] = BornAround(c.Age.Years, c.Age.At)
+		filter["$expr"] = bson.M{"$eq": bson.A{ageExpression("$"+models.EmployeeRef.BirthDate, c.Age.At), c.Age.Years}}
+	}
+	if c.DelegatedTo != "" {
+		filter[models.EmployeeRef.Delegation+".delegate"] = c.DelegatedTo
+		filter[models.EmployeeRef.Delegation+".startsAt"] = bson.M{"$lte": c.DelegatedAt}
+		filter[models.EmployeeRef.Delegation+".endsAt"] = bson.M{"$gt": c.DelegatedAt}
+	}
+	if !c.RolesExpiredBy.IsZero() {
+		filter[models.EmployeeRef.TemporaryRoles+".expiresAt"] = bson.M{"$lte": c.RolesExpiredBy}
+	}
+	if c.References != "" {
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{models.EmployeeRef.Manager: c.References},
+			bson.M{models.EmployeeRef.DottedLines: c.References},
+			bson.M{models.EmployeeRef.Delegation + ".delegate": c.References},
+		}})
+	}
+	if len(and) > 0 {
+		filter["$and"] = and
+	}
+	return filter
+}
+
+// order returns the MongoDB sort of the keys of p.
+func (p Page) order() bson.D {
+	order := make(bson.D, len(p.Sort))
+	for i, key := range p.Sort {
+		order[i] = bson.E{Key: key.Field, Value: 1}
+		if key.Descending {
+			order[i].Value = -1
+		}
+	}
+	return order
+}
+
+// findOptions returns the find options sorting and paging a list as p does, leaving the password out.
+func (p Page) findOptions() *options.FindOptionsBuilder {
+	findOptions := options.Find().SetProjection(withoutCredentials)
+	if len(p.Sort) > 0 {
+		findOptions.SetSort(p.order())
+	}
+	if p.Collation != nil {
+		findOptions.SetCollation(p.Collation)
+	}
+	if p.Skip > 0 {
+		findOptions.SetSkip(p.Skip)
+	}
+	if p.Limit > 0 {
+		findOptions.SetLimit(p.Limit)
+	}
+	return findOptions
+}
+
+// HasExampleFields reports whether the example employee sets a field EmployeeCriteria.Example would match on.
+func HasExampleFields(example models.Employee) bool {
+	return len(exampleFilter(example)) > 0
+}
+
+// exampleFilter converts the non-empty fields of an example employee into a Mongo filter.
+func exampleFilter(example models.Employee) bson.M {
+	filter := bson.M{}
+	if example.Email != "" {
+		filter[models.EmployeeRef.Email] = example.Email
+	}
+	// A name matches the display name, or the legal name of an employee going by a preferred name.
+	if example.Name != "" {
+		filter["$or"] = bson.A{
+			bson.M{models.EmployeeRef.DisplayName: example.Name},
+			bson.M{models.EmployeeRef.Name: example.Name},
+		}
+	}
+	if example.PreferredName != "" {
+		filter[models.EmployeeRef.PreferredName] = example.PreferredName
+	}
+	if example.Pronouns != "" {
+		filter[models.EmployeeRef.Pronouns] = example.Pronouns
+	}
+	if example.Birthdate.Day != "" {
+		filter[models.EmployeeRef.Birthdate+".day"] = example.Birthdate.Day
+	}
+	if example.Birthdate.Month != "" {
+		filter[models.EmployeeRef.Birthdate+".month"] = example.Birthdate.Month
+	}
+	if example.Birthdate.Year != "" {
+		filter[models.EmployeeRef.Birthdate+".year"] = example.Birthdate.Year
+	}
+	if len(example.Roles) > 0 {
+		filter[models.EmployeeRef.Roles] = bson.M{"$all": example.Roles}
+	}
+	if example.Manager != nil {
+		filter[models.EmployeeRef.Manager] = *example.Manager
+	}
+	for key, value := range example.Metadata {
+		filter[models.EmployeeRef.Metadata+"."+key] = value
+	}
+	if example.Phone != "" {
+		filter[models.EmployeeRef.Phone] = example.Phone
+	}
+	if example.Department != "" {
+		filter[models.EmployeeRef.Department] = example.Department
+	}
+	if example.HireDate != "" {
+		filter[models.EmployeeRef.HireDate] = example.HireDate
+	}
+	if example.Title != "" {
+		filter[models.EmployeeRef.Title] = example.Title
+	}
+	if example.Grade != "" {
+		filter[models.EmployeeRef.Grade] = example.Grade
+	}
+	if example.Location != "" {
+		filter[models.EmployeeRef.Location] = example.Location
+	}
+	if example.CostCenter != "" {
+		filter[models.EmployeeRef.CostCenter] = example.CostCenter
+	}
+	if len(example.Skills) > 0 {
+		held := make([]bson.M, len(example.Skills))
+		for i, skill := range example.Skills {
+			held[i] = bson.M{"$elemMatch": bson.M{"name": skill.Name, "level": bson.M{"$gte": skill.Level}}}
+		}
+		filter[models.EmployeeRef.Skills] = bson.M{"$all": held}
+	}
+	if example.Address != nil {
+		for field, value := range map[string]string{
+			"street":     example.Address.Street,
+			"city":       example.Address.City,
+			"postalCode": example.Address.PostalCode,
+			"country":    example.Address.Country,
+		} {
+			if value != "" {
+				filter[models.EmployeeRef.Address+"."+field] = value
+			}
+		}
+	}
+	return filter
+}
+
+// BornAround filters the employees who may be ageInYears old as of now, by a range of birth dates the indexed
+// birthDate field is matched against. The range is two days wider at each end than the ages in UTC, for the time
+// zones of the employees and for birthdays on February 29. Employees without a birth date, whose birthdate is not a
+// day of the calendar, have no age and are left out.
+func BornAround(ageInYears int, now time.Time) bson.M {
+	after, by := bornBetween(ageInYears, now)
+	return bson.M{"$gt": after, "$lte": by}
+}
+
+// bornBetween returns the range of birth dates of BornAround: after the first and at or before the second.
+func bornBetween(ageInYears int, now time.Time) (after, by time.Time) {
+	today := now.UTC().Truncate(24 * time.Hour)
+	return today.AddDate(-ageInYears-1, 0, -2), today.AddDate(-ageInYears, 0, 2)
+}
+
+// localDatePart extracts a part of the date now, such as its year, in the time zone of the employee, or in UTC
+// when they have none.
+func localDatePart(operator string, now time.Time) bson.M {
+	return bson.M{operator: bson.M{
+		"date":     now,
+		"timezone": bson.M{"$ifNull": bson.A{"$" + models.EmployeeRef.Timezone, "UTC"}},
+	}}
+}
+
+// ageExpression computes the age in full years, as of now where the employee lives, of someone born on birthDate,
+// a date at midnight UTC: the years between the birth date and today, less one when the birthday has not yet come
+// this year.
+func ageExpression(birthDate any, now time.Time) bson.M {
+	today := bson.M{"$dateFromParts": bson.M{
+		"year":  localDatePart("$year", now),
+		"month": localDatePart("$month", now),
+		"day":   localDatePart("$dayOfMonth", now),
+	}}
+	monthDay := func(date any) bson.M {
+		return bson.M{"$add": bson.A{bson.M{"$multiply": bson.A{bson.M{"$month": date}, 100}}, bson.M{"$dayOfMonth": date}}}
+	}
+	return bson.M{"$subtract": bson.A{
+		bson.M{"$dateDiff": bson.M{"startDate": birthDate, "endDate": today, "unit": "year"}},
+		bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{monthDay(birthDate), monthDay(today)}}, 1, 0}},
+	}}
+}
+
+// ageOf computes the age of the employee as ageExpression does, in full years as of now where the employee lives, and
+// reports false for employees without a birth date.
+func ageOf(emp models.Employee, now time.Time) (int, bool) {
+	if emp.BirthDate.IsZero() {
+		return 0, false
+	}
+	location := time.UTC
+	if emp.Timezone != "" {
+		// Time zones are validated when written; an unknown one is taken as UTC.
+		if loaded, err := time.LoadLocation(emp.Timezone); err == nil {
+			location = loaded
+		}
+	}
+	year, month, day := now.In(location).Date()
+	born := emp.BirthDate.UTC()
+	age := year - born.Year()
+	if int(born.Month())*100+born.Day() > int(month)*100+day {
+		age--
+	}
+	return age, true
+}
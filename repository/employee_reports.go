@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Report is an employee found below a manager, with the number of levels between them.
+type Report struct {
+	models.Employee `bson:",inline"`
+	// Depth is 1 for a direct report, 2 for a report of a direct report, and so on.
+	Depth int `bson:"depth"`
+}
+
+// walkedThrough selects the employees the reporting lines are followed through from an employee matching the
+// criteria: the active ones, of the tenant the criteria restrict the employees to.
+func walkedThrough(criteria EmployeeCriteria) EmployeeCriteria {
+	return EmployeeCriteria{State: Active, Tenant: criteria.Tenant, NoTenant: criteria.NoTenant}
+}
+
+// chainOf returns start followed by the managers above them, ordered by depth. Every employee has a single manager,
+// so the managers form a path with one employee per depth; it ends when it comes back to start.
+func chainOf(start models.Employee, managers []Report) []models.Employee {
+	sort.SliceStable(managers, func(i, j int) bool { return managers[i].Depth < managers[j].Depth })
+	chain := []models.Employee{start}
+	for _, manager := range managers {
+		if manager.Email == start.Email {
+			break
+		}
+		chain = append(chain, manager.Employee)
+	}
+	return chain
+}
+
+// graphLookup returns the $graphLookup stage following the reporting lines from the employees of the pipeline, up
+// to their managers or down to their reports, through the employees matching through. The employees found are put
+// in the field as, with their depth from 0; with maxDepth > 0, at most maxDepth levels are followed.
+func (r *EmployeeRepository) graphLookup(as string, through EmployeeCriteria, up bool, maxDepth int) bson.D {
+	from, to := models.EmployeeRef.Email, models.EmployeeRef.Manager
+	if up {
+		from, to = to, from
+	}
+	lookup := bson.M{
+		"from":                    r.Collection.Name(),
+		"startWith":               "$" + from,
+		"connectFromField":        from,
+		"connectToField":          to,
+		"as":                      as,
+		"depthField":              "depth",
+		"restrictSearchWithMatch": through.Filter(),
+	}
+	if maxDepth > 0 {
+		lookup["maxDepth"] = maxDepth - 1
+	}
+	return bson.D{{Key: "$graphLookup", Value: lookup}}
+}
+
+// aggregate runs the pipeline over the employee collection and decodes its output into results.
+func (r *EmployeeRepository) aggregate(ctx context.Context, pipeline mongo.Pipeline, results any, opts ...options.Lister[options.AggregateOptions]) error {
+	return r.Retry.do(ctx, false, func() error {
+		cursor, err := r.Collection.Aggregate(ctx, pipeline, opts...)
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(ctx)
+		return cursor.All(ctx, results)
+	})
+}
+
+// ManagerChain returns the first employee matching the criteria followed by their manager, the manager's manager and
+// so on, resolved with a single $graphLookup through the active employees of the tenant of the criteria. With
+// maxDepth > 0, at most maxDepth managers are returned. The chain stops at the first employee without an active
+// manager, or when it comes back to the employee. It is empty when no employee matches.
+func (r *EmployeeRepository) ManagerChain(ctx context.Context, criteria EmployeeCriteria, maxDepth int) ([]models.Employee, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: criteria.Filter()}},
+		{{Key: "$limit", Value: 1}},
+		r.graphLookup("chain", walkedThrough(criteria), true, maxDepth),
+		{{Key: "$unset", Value: bson.A{models.EmployeeRef.Password, "chain." + models.EmployeeRef.Password}}},
+	}
+	var results []struct {
+		models.Employee `bson:",inline"`
+		Chain           []Report `bson:"chain"`
+	}
+	if err := r.aggregate(ctx, pipeline, &results); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+	return chainOf(results[0].Employee, results[0].Chain), nil
+}
+
+// Reports returns the direct and indirect reports of the first employee matching the criteria, resolved with a
+// single $graphLookup through the active employees of the tenant of the criteria. With maxDepth > 0, only the reports
+// at most maxDepth levels below are returned. They are ordered by depth, then as the page orders them, and paged.
+// There are none when no employee matches.
+func (r *EmployeeRepository) Reports(ctx context.Context, criteria EmployeeCriteria, maxDepth int, page Page) ([]Report, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: criteria.Filter()}},
+		{{Key: "$limit", Value: 1}},
+		r.graphLookup("reports", walkedThrough(criteria), false, maxDepth),
+		{{Key: "$project", Value: bson.M{"manager": "$" + models.EmployeeRef.Email, "reports": 1}}},
+		{{Key: "$unwind", Value: "$reports"}},
+		// The manager can only be found below themselves through a cycle stored before cycles were rejected.
+		{{Key: "$match", Value: bson.M{"$expr": bson.M{"$ne": bson.A{"$reports." + models.EmployeeRef.Email, "$manager"}}}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$reports"}}},
+		{{Key: "$sort", Value: append(bson.D{{Key: "depth", Value: 1}}, page.order()...)}},
+	}
+	if page.Skip > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: page.Skip}})
+	}
+	if page.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: page.Limit}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$unset", Value: models.EmployeeRef.Password}})
+	opts := options.Aggregate()
+	if page.Collation != nil {
+		opts.SetCollation(page.Collation)
+	}
+	var reports []Report
+	if err := r.aggregate(ctx, pipeline, &reports, opts); err != nil {
+		return nil, err
+	}
+	// The depth of $graphLookup counts from 0 for the direct reports.
+	for i := range reports {
+		reports[i].Depth++
+	}
+	return reports, nil
+}
+
+// CountReports counts the direct reports of the first employee matching the criteria and all their reports, direct
+// and indirect, in a single aggregation through the active employees of the tenant of the criteria. It returns
+// mongo.ErrNoDocuments when no employee matches.
+func (r *EmployeeRepository) CountReports(ctx context.Context, criteria EmployeeCriteria) (models.ReportCounts, error) {
+	// countReports counts the reports matching cond, leaving out the manager if a stored cycle leads back to them.
+	countReports := func(cond any) bson.M {
+		return bson.M{"$size": bson.M{"$filter": bson.M{
+			"input": "$reports",
+			"cond": bson.M{"$and": bson.A{
+				bson.M{"$ne": bson.A{"$$this." + models.EmployeeRef.Email, "$" + models.EmployeeRef.Email}},
+				cond,
+			}},
+		}}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: criteria.Filter()}},
+		{{Key: "$limit", Value: 1}},
+		r.graphLookup("reports", walkedThrough(criteria), false, 0),
+		{{Key: "$project", Value: bson.M{
+			"direct": countReports(bson.M{"$eq": bson.A{"$$this.depth", 0}}),
+			"total":  countReports(true),
+		}}},
+	}
+	var counts []models.ReportCounts
+	if err := r.aggregate(ctx, pipeline, &counts); err != nil {
+		return models.ReportCounts{}, err
+	}
+	if len(counts) == 0 {
+		return models.ReportCounts{}, mongo.ErrNoDocuments
+	}
+	return counts[0], nil
+}
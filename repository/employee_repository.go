@@ -3,38 +3,257 @@ package repository
 import (
 	"WebMVCEmployees/models"
 	"context"
-	"log"
+	"errors"
+	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
 )
 
 // EmployeeRepository encapsulates operations on the employee collection.
 type EmployeeRepository struct {
 	Collection *mongo.Collection
+	// Retry runs the operations failing on transient errors again.
+	Retry RetryPolicy
+	// BatchSize is how many employees InsertMany sends per round trip, DefaultBatchSize when zero.
+	BatchSize int
+	// standalone is set once the server is known to run standalone, without transactions, so that InTransaction
+	// stops starting them.
+	standalone atomic.Bool
 }
 
-// NewEmployeeRepository creates a new EmployeeRepository and ensures that a unique index is set on the email field.
+// NewEmployeeRepository creates a new EmployeeRepository and ensures that unique indexes are set on the email, within
+// the tenant, and internal ID fields; the latter is sparse, since employees created before IDs were introduced have
+// none.
+// The roles, title, grade, location, cost center and skills fields are indexed as well, since employees are listed by
+// them, and so are the manager, which subordinates are found by, the display name, which employees are sorted by, and
+// the birth date, which narrows down the employees of an age.
+// The collection validates the employees written to it against employeeSchema.
 func NewEmployeeRepository(client *mongo.Client, dbName, collName string) (*EmployeeRepository, error) {
 	coll := client.Database(dbName).Collection(collName)
 
-	// Create a unique index on the email field.
-	indexModel := mongo.IndexModel{
-		Keys:    bson.D{{Key: models.EmployeeRef.Email, Value: 1}},
-		Options: options.Index().SetUnique(true),
+	// Create a unique index on the email field within each tenant. Employees without a tenant are indexed under a
+	// null tenant, so their emails are unique among them; the email leads, so employees are found by it alone.
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: models.EmployeeRef.Email, Value: 1}, {Key: models.EmployeeRef.TenantID, Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: models.EmployeeRef.ID, Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+		{Keys: bson.D{{Key: models.EmployeeRef.DisplayName, Value: 1}}},
+		{Keys: bson.D{{Key: models.EmployeeRef.BirthDate, Value: 1}}},
+		// A multikey index over the entries of the roles array.
+		{Keys: bson.D{{Key: models.EmployeeRef.Roles, Value: 1}}},
+		{Keys: bson.D{{Key: models.EmployeeRef.Manager, Value: 1}}},
+		{Keys: bson.D{{Key: models.EmployeeRef.Title, Value: 1}}},
+		{Keys: bson.D{{Key: models.EmployeeRef.Grade, Value: 1}}},
+		{Keys: bson.D{{Key: models.EmployeeRef.Location, Value: 1}}},
+		{Keys: bson.D{{Key: models.EmployeeRef.CostCenter, Value: 1}}},
+		// A multikey index over the entries of the skills array.
+		{Keys: bson.D{{Key: models.EmployeeRef.Skills + ".name", Value: 1}, {Key: models.EmployeeRef.Skills + ".level", Value: 1}}},
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	_, err := coll.Indexes().CreateOne(ctx, indexModel)
+	if err := installValidator(ctx, coll.Database(), collName); err != nil {
+		slog.Warn("Failed to install the employee schema validator", "error", err)
+		return nil, err
+	}
+	_, err := coll.Indexes().CreateMany(ctx, indexModels)
 	if err != nil {
-		log.Printf("Failed to create unique index on email: %v", err)
+		slog.Warn("Failed to create employee indexes", "error", err)
 		return nil, err
 	}
 
-	return &EmployeeRepository{
+	repo := &EmployeeRepository{
 		Collection: coll,
-	}, nil
+		Retry:      DefaultRetryPolicy,
+	}
+	transactions, err := supportsTransactions(ctx, coll.Database())
+	if err != nil {
+		return nil, err
+	}
+	if !transactions {
+		slog.Warn("MongoDB runs standalone: writes spanning several employees are applied one after the other, without a transaction")
+		repo.standalone.Store(true)
+	}
+	return repo, nil
+}
+
+// supportsTransactions reports whether the deployment of db runs multi-document transactions: replica sets and
+// sharded clusters do, standalone servers do not.
+func supportsTransactions(ctx context.Context, db *mongo.Database) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := db.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
+
+// NewReadOnlyEmployeeRepository creates an EmployeeRepository for a read replica.
+// It does not create indexes, since those are writes that must happen on the primary.
+func NewReadOnlyEmployeeRepository(client *mongo.Client, dbName, collName string) *EmployeeRepository {
+	return &EmployeeRepository{
+		Collection: client.Database(dbName).Collection(collName),
+		Retry:      DefaultRetryPolicy,
+	}
+}
+
+// ReplaceEmployee replaces the stored employee having emp's email, in emp's tenant, with emp. When version is non-zero,
+// the replacement only applies while the stored employee is at that version. With upsert, emp is
+// inserted when no employee matches. It reports whether emp was inserted, and returns
+// mongo.ErrNoDocuments when nothing was replaced or inserted.
+func (r *EmployeeRepository) ReplaceEmployee(ctx context.Context, emp models.Employee, version int64, upsert bool) (bool, error) {
+	filter := replacedBy(emp, version).Filter()
+	var res *mongo.UpdateResult
+	err := r.Retry.do(ctx, true, func() (err error) {
+		res, err = r.Collection.ReplaceOne(ctx, filter, emp, options.Replace().SetUpsert(upsert))
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	if res.MatchedCount == 0 && res.UpsertedCount == 0 {
+		return false, mongo.ErrNoDocuments
+	}
+	return res.UpsertedCount > 0, nil
+}
+
+// replacedBy selects the employee emp replaces: the one with its email, soft-deleted or not, in its tenant when it has
+// one, at the version unless it is zero.
+func replacedBy(emp models.Employee, version int64) EmployeeCriteria {
+	return EmployeeCriteria{State: AnyState, Emails: []string{emp.Email}, Tenant: emp.TenantID, Version: version}
+}
+
+// illegalOperation is the server error code of a transaction started on a standalone server.
+const illegalOperation = 20
+
+// Create inserts a new employee.
+func (r *EmployeeRepository) Create(ctx context.Context, emp models.Employee) error {
+	return r.Retry.do(ctx, true, func() error {
+		_, err := r.Collection.InsertOne(ctx, emp)
+		return err
+	})
+}
+
+// InsertMany inserts new employees, BatchSize of them per round trip. Unordered, every employee that can be inserted
+// is; ordered, the inserts stop at the first one failing. The failed inserts are reported in a
+// mongo.BulkWriteException indexed by position in emps.
+func (r *EmployeeRepository) InsertMany(ctx context.Context, emps []models.Employee, ordered bool) error {
+	writes := make([]mongo.WriteModel, len(emps))
+	for i, emp := range emps {
+		writes[i] = mongo.NewInsertOneModel().SetDocument(emp)
+	}
+	_, err := BulkWrite(ctx, r.Collection, writes, r.BatchSize, ordered)
+	return err
+}
+
+// withoutCredentials is the projection employees are read with, leaving their password out. Only authentication
+// reads it, through FindWithCredentials.
+var withoutCredentials = bson.M{models.EmployeeRef.Password: 0}
+
+// FindByEmail returns the employee with the email unless it is soft-deleted.
+func (r *EmployeeRepository) FindByEmail(ctx context.Context, email string) (models.Employee, error) {
+	return r.FindOne(ctx, EmployeeCriteria{State: Active, Emails: []string{email}})
+}
+
+// FindWithCredentials returns the first employee matching the criteria, password included.
+func (r *EmployeeRepository) FindWithCredentials(ctx context.Context, criteria EmployeeCriteria) (models.Employee, error) {
+	return r.findOne(ctx, criteria.Filter())
+}
+
+// FindOne returns the first employee matching the criteria, without the password.
+func (r *EmployeeRepository) FindOne(ctx context.Context, criteria EmployeeCriteria) (models.Employee, error) {
+	return r.findOne(ctx, criteria.Filter(), options.FindOne().SetProjection(withoutCredentials))
+}
+
+// findOne returns the first employee matching the filter, read with opts.
+func (r *EmployeeRepository) findOne(ctx context.Context, filter bson.M, opts ...options.Lister[options.FindOneOptions]) (models.Employee, error) {
+	var emp models.Employee
+	err := r.Retry.do(ctx, false, func() error {
+		return r.Collection.FindOne(ctx, filter, opts...).Decode(&emp)
+	})
+	return emp, err
+}
+
+// list returns the employees matching the filter. Unless opts project the employees otherwise, the password is
+// left out.
+func (r *EmployeeRepository) list(ctx context.Context, filter bson.M, opts ...options.Lister[options.FindOptions]) ([]models.Employee, error) {
+	find, err := resolveFindOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if find.Projection == nil {
+		opts = append(opts, options.Find().SetProjection(withoutCredentials))
+	}
+	var employees []models.Employee
+	err = r.Retry.do(ctx, false, func() error {
+		cursor, err := r.Collection.Find(ctx, filter, opts...)
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(ctx)
+		return cursor.All(ctx, &employees)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return employees, nil
+}
+
+// List returns a page of the employees matching the criteria, without their password.
+func (r *EmployeeRepository) List(ctx context.Context, criteria EmployeeCriteria, page Page) ([]models.Employee, error) {
+	return r.list(ctx, criteria.Filter(), page.findOptions())
+}
+
+// Count returns the number of employees matching the criteria.
+func (r *EmployeeRepository) Count(ctx context.Context, criteria EmployeeCriteria) (int64, error) {
+	return r.count(ctx, criteria.Filter())
+}
+
+// count returns the number of employees matching the filter.
+func (r *EmployeeRepository) count(ctx context.Context, filter bson.M) (int64, error) {
+	var count int64
+	err := r.Retry.do(ctx, false, func() (err error) {
+		count, err = r.Collection.CountDocuments(ctx, filter)
+		return err
+	})
+	return count, err
+}
+
+// InTransaction runs fn in a transaction, so that its writes apply together or not at all. Standalone servers, such
+// as the one of docker-compose, do not support transactions; there fn runs without one and its writes are applied
+// one after the other, so a failure midway leaves the earlier ones applied.
+// A server found standalone when the repository was created, or when a transaction was refused, is not asked again.
+// fn returns the driver errors as they are, so a standalone server can be told apart from a failed write.
+func (r *EmployeeRepository) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.standalone.Load() {
+		return fn(ctx)
+	}
+	session, err := r.Collection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// Transactions read from the primary whatever the read preference of the client.
+	_, err = session.WithTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		return nil, fn(ctx)
+	}, options.Transaction().SetReadPreference(readpref.Primary()))
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(illegalOperation) {
+		r.standalone.Store(true)
+		err = fn(ctx)
+	}
+	return err
 }
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Server error codes of installing the validator.
+const (
+	unauthorized      = 13
+	namespaceNotFound = 26
+	namespaceExists   = 48
+)
+
+// employeeSchema is the $jsonSchema validator of the employee collection. It checks what the service relies on when
+// reading employees back, the identity, password and birthdate of each, leaving the optional fields to the service,
+// so that documents written out of band, e.g. from the shell, cannot break the reads.
+var employeeSchema = bson.M{"$jsonSchema": bson.M{
+	"bsonType": "object",
+	"required": bson.A{models.EmployeeRef.Email, models.EmployeeRef.Name, models.EmployeeRef.Password, models.EmployeeRef.Birthdate},
+	"properties": bson.M{
+		models.EmployeeRef.Email:    bson.M{"bsonType": "string", "pattern": `^[^@\s]+@[^@\s]+$`},
+		models.EmployeeRef.Name:     bson.M{"bsonType": "string", "minLength": 1},
+		models.EmployeeRef.Password: bson.M{"bsonType": "string"},
+		models.EmployeeRef.Birthdate: bson.M{
+			"bsonType": "object",
+			"required": bson.A{"day", "month", "year"},
+			"properties": bson.M{
+				"day":   bson.M{"bsonType": "string", "pattern": `^[0-9]{2}$`},
+				"month": bson.M{"bsonType": "string", "pattern": `^[0-9]{2}$`},
+				"year":  bson.M{"bsonType": "string", "pattern": `^[0-9]{4}$`},
+			},
+		},
+		models.EmployeeRef.BirthDate: bson.M{"bsonType": "date"},
+		models.EmployeeRef.Roles:     bson.M{"bsonType": bson.A{"array", "null"}, "items": bson.M{"bsonType": "string"}},
+		models.EmployeeRef.Manager:   bson.M{"bsonType": bson.A{"string", "null"}},
+		models.EmployeeRef.Version:   bson.M{"bsonType": bson.A{"int", "long"}},
+		models.EmployeeRef.DeletedAt: bson.M{"bsonType": bson.A{"date", "null"}},
+	},
+}}
+
+// installValidator makes the employee collection reject inserts and updates leaving an employee that does not match
+// employeeSchema, creating the collection when it does not exist yet. The validation level is moderate, so employees
+// stored invalid before keep being updatable. A user without the right to change the collection options only gets a
+// warning, since the service validates its own writes anyway.
+func installValidator(ctx context.Context, db *mongo.Database, collName string) error {
+	for {
+		err := db.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: collName},
+			{Key: "validator", Value: employeeSchema},
+			{Key: "validationLevel", Value: "moderate"},
+			{Key: "validationAction", Value: "error"},
+		}).Err()
+		var serverErr mongo.ServerError
+		if !errors.As(err, &serverErr) {
+			return err
+		}
+		switch {
+		case serverErr.HasErrorCode(unauthorized):
+			slog.Warn("Not allowed to install the employee schema validator", "error", err)
+			return nil
+		case !serverErr.HasErrorCode(namespaceNotFound):
+			return err
+		}
+
+		err = db.CreateCollection(ctx, collName, options.CreateCollection().
+			SetValidator(employeeSchema).
+			SetValidationLevel("moderate").
+			SetValidationAction("error"))
+		// Another instance may have created the collection meanwhile; it is then modified as it is.
+		if !errors.As(err, &serverErr) || !serverErr.HasErrorCode(namespaceExists) {
+			return err
+		}
+	}
+}
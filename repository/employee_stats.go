@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Workforce breaks employees down for the workforce statistics.
+type Workforce struct {
+	Headcount int64
+	// ByRole, ByDomain and ByManager count the employees by role, by the domain of their email and by manager, the
+	// largest groups first and then by name. Employees without a manager are not counted by manager.
+	ByRole    []models.StatCount
+	ByDomain  []models.StatCount
+	ByManager []models.StatCount
+	// Ages counts the employees by age in full years; those without a birth date are left out, and a birth date in
+	// the future gives a negative age.
+	Ages map[int]int64
+}
+
+// anniversaryCriteria narrows the criteria down to the employees whose work anniversary falls in the month of the
+// year: those hired in the month of an earlier year.
+func anniversaryCriteria(criteria EmployeeCriteria, month time.Month, year int) EmployeeCriteria {
+	criteria.HireMonth = month
+	criteria.HiredBy = fmt.Sprintf("%04d-12-31", year-1)
+	return criteria
+}
+
+// anniversaryOf returns the work anniversary of the employee in the year. Anniversaries of hires on February 29 fall
+// on that day even in common years.
+func anniversaryOf(emp models.Employee, year int) models.Anniversary {
+	hired, _ := strconv.Atoi(emp.HireDate[:4])
+	return models.Anniversary{
+		Email:          emp.Email,
+		Name:           emp.Name,
+		HireDate:       emp.HireDate,
+		Date:           fmt.Sprintf("%04d", year) + emp.HireDate[4:],
+		YearsOfService: year - hired,
+	}
+}
+
+// Anniversaries returns the work anniversaries falling in the month of the year of the employees matching the
+// criteria, with the years of service they complete, ordered by date and then by email, and paged. Employees hired
+// in the year or later, or without a hire date, have none.
+func (r *EmployeeRepository) Anniversaries(ctx context.Context, criteria EmployeeCriteria, month time.Month, year int, page Page) ([]models.Anniversary, error) {
+	hireDate := "$" + models.EmployeeRef.HireDate
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: anniversaryCriteria(criteria, month, year).Filter()}},
+		{{Key: "$project", Value: bson.M{
+			"_id":                       0,
+			models.EmployeeRef.Email:    1,
+			models.EmployeeRef.Name:     1,
+			models.EmployeeRef.HireDate: 1,
+			"date": bson.M{"$concat": bson.A{
+				fmt.Sprintf("%04d", year),
+				bson.M{"$substrBytes": bson.A{hireDate, 4, 6}},
+			}},
+			"yearsOfService": bson.M{"$subtract": bson.A{
+				year,
+				bson.M{"$toInt": bson.M{"$substrBytes": bson.A{hireDate, 0, 4}}},
+			}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "date", Value: 1}, {Key: models.EmployeeRef.Email, Value: 1}}}},
+	}
+	if page.Skip > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: page.Skip}})
+	}
+	if page.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: page.Limit}})
+	}
+	anniversaries := []models.Anniversary{}
+	if err := r.aggregate(ctx, pipeline, &anniversaries); err != nil {
+		return nil, err
+	}
+	return anniversaries, nil
+}
+
+// HeadcountByCostCenter counts the employees matching the criteria charged to each cost center, in no particular
+// order. Employees charged to none are counted under an empty cost center.
+func (r *EmployeeRepository) HeadcountByCostCenter(ctx context.Context, criteria EmployeeCriteria) ([]models.CostCenterHeadcount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: criteria.Filter()}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       bson.M{"$ifNull": bson.A{"$" + models.EmployeeRef.CostCenter, ""}},
+			"headcount": bson.M{"$sum": 1},
+		}}},
+	}
+	headcounts := []models.CostCenterHeadcount{}
+	if err := r.aggregate(ctx, pipeline, &headcounts); err != nil {
+		return nil, err
+	}
+	return headcounts, nil
+}
+
+// countBy groups the documents on the expression and sorts the groups by size, then by name.
+func countBy(expression any) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": expression, "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+}
+
+// Workforce breaks the employees matching the criteria down by role, email domain, manager and age as of now, in a
+// single aggregation.
+func (r *EmployeeRepository) Workforce(ctx context.Context, criteria EmployeeCriteria, now time.Time) (Workforce, error) {
+	byRole := append(mongo.Pipeline{{{Key: "$unwind", Value: "$" + models.EmployeeRef.Roles}}},
+		countBy("$"+models.EmployeeRef.Roles)...)
+	byDomain := countBy(bson.M{"$arrayElemAt": bson.A{bson.M{"$split": bson.A{"$" + models.EmployeeRef.Email, "@"}}, 1}})
+	byManager := append(mongo.Pipeline{{{Key: "$match", Value: bson.M{models.EmployeeRef.Manager: bson.M{"$type": "string"}}}}},
+		countBy("$"+models.EmployeeRef.Manager)...)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: criteria.Filter()}},
+		{{Key: "$addFields", Value: bson.M{"age": ageExpression("$"+models.EmployeeRef.BirthDate, now)}}},
+		{{Key: "$facet", Value: bson.M{
+			"headcount": mongo.Pipeline{{{Key: "$count", Value: "count"}}},
+			"byRole":    byRole,
+			"byDomain":  byDomain,
+			"byManager": byManager,
+			"ages": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"age": bson.M{"$type": "number"}}}},
+				{{Key: "$group", Value: bson.M{"_id": "$age", "count": bson.M{"$sum": 1}}}},
+			},
+		}}},
+	}
+	var facets []struct {
+		Headcount []struct {
+			Count int64 `bson:"count"`
+		} `bson:"headcount"`
+		ByRole    []models.StatCount `bson:"byRole"`
+		ByDomain  []models.StatCount `bson:"byDomain"`
+		ByManager []models.StatCount `bson:"byManager"`
+		Ages      []struct {
+			Age   int   `bson:"_id"`
+			Count int64 `bson:"count"`
+		} `bson:"ages"`
+	}
+	if err := r.aggregate(ctx, pipeline, &facets); err != nil {
+		return Workforce{}, err
+	}
+	workforce := Workforce{Ages: map[int]int64{}}
+	if len(facets) == 0 {
+		return workforce, nil
+	}
+	result := facets[0]
+	if len(result.Headcount) > 0 {
+		workforce.Headcount = result.Headcount[0].Count
+	}
+	workforce.ByRole, workforce.ByDomain, workforce.ByManager = result.ByRole, result.ByDomain, result.ByManager
+	for _, age := range result.Ages {
+		workforce.Ages[age.Age] = age.Count
+	}
+	return workforce, nil
+}
+
+// workforceOf breaks the employees down as Workforce does.
+func workforceOf(emps []models.Employee, now time.Time) Workforce {
+	byRole, byDomain, byManager := map[string]int64{}, map[string]int64{}, map[string]int64{}
+	workforce := Workforce{Headcount: int64(len(emps)), Ages: map[int]int64{}}
+	for _, emp := range emps {
+		for _, role := range emp.Roles {
+			byRole[role]++
+		}
+		// The domain is what follows the first @ up to the next one, as $split has it.
+		if parts := strings.Split(emp.Email, "@"); len(parts) > 1 {
+			byDomain[parts[1]]++
+		} else {
+			byDomain[""]++
+		}
+		if emp.Manager != nil {
+			byManager[*emp.Manager]++
+		}
+		if age, ok := ageOf(emp, now); ok {
+			workforce.Ages[age]++
+		}
+	}
+	workforce.ByRole, workforce.ByDomain, workforce.ByManager = statCounts(byRole), statCounts(byDomain), statCounts(byManager)
+	return workforce
+}
+
+// statCounts lists the counts by name, the largest first and then by name.
+func statCounts(counts map[string]int64) []models.StatCount {
+	stats := make([]models.StatCount, 0, len(counts))
+	for name, count := range counts {
+		stats = append(stats, models.StatCount{Name: name, Count: count})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Every update below moves the employees it changes to their next version, except those filling in fields derived
+// from others, such as the internal ID and the display name.
+
+// nextVersion is the update moving an employee to their next version.
+var nextVersion = bson.M{models.EmployeeRef.Version: 1}
+
+// updateAll applies the update to every employee matching the filter and returns how many matched.
+func (r *EmployeeRepository) updateAll(ctx context.Context, filter bson.M, update any) (int64, error) {
+	var res *mongo.UpdateResult
+	err := r.Retry.do(ctx, true, func() (err error) {
+		res, err = r.Collection.UpdateMany(ctx, filter, update)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.MatchedCount, nil
+}
+
+// updateOne applies the update to the first employee matching the filter and reports whether one matched.
+func (r *EmployeeRepository) updateOne(ctx context.Context, filter bson.M, update bson.M) (bool, error) {
+	var res *mongo.UpdateResult
+	err := r.Retry.do(ctx, true, func() (err error) {
+		res, err = r.Collection.UpdateOne(ctx, filter, update)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// findAndUpdate applies the update to the first employee matching the filter and returns the updated employee,
+// without the password, or mongo.ErrNoDocuments when none matches.
+func (r *EmployeeRepository) findAndUpdate(ctx context.Context, filter bson.M, update bson.M) (models.Employee, error) {
+	var emp models.Employee
+	err := r.Retry.do(ctx, true, func() error {
+		return r.Collection.FindOneAndUpdate(ctx, filter, update,
+			options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(withoutCredentials)).Decode(&emp)
+	})
+	return emp, err
+}
+
+// SetManager makes manager the manager of every employee matching the criteria, dropping it from their dotted lines,
+// or removes their manager when it is empty. It returns how many employees it updated.
+func (r *EmployeeRepository) SetManager(ctx context.Context, criteria EmployeeCriteria, manager string) (int64, error) {
+	update := bson.M{"$inc": nextVersion}
+	if manager == "" {
+		update["$unset"] = bson.M{models.EmployeeRef.Manager: ""}
+	} else {
+		update["$set"] = bson.M{models.EmployeeRef.Manager: manager}
+		update["$pull"] = bson.M{models.EmployeeRef.DottedLines: manager}
+	}
+	return r.updateAll(ctx, criteria.Filter(), update)
+}
+
+// AddRoles adds the roles every employee matching the criteria does not hold yet, and returns how many employees it
+// updated.
+func (r *EmployeeRepository) AddRoles(ctx context.Context, criteria EmployeeCriteria, roles []string) (int64, error) {
+	return r.updateAll(ctx, criteria.Filter(), bson.M{
+		"$addToSet": bson.M{models.EmployeeRef.Roles: bson.M{"$each": roles}},
+		"$inc":      nextVersion,
+	})
+}
+
+// GrantTemporaryRole grants the role of the grant to the first employee matching the criteria until it expires, and
+// returns the updated employee. A temporary grant of the role the employee holds already is extended; otherwise the
+// role is added to their roles along with the grant.
+func (r *EmployeeRepository) GrantTemporaryRole(ctx context.Context, criteria EmployeeCriteria, grant models.RoleGrant) (models.Employee, error) {
+	granted := models.EmployeeRef.TemporaryRoles + ".role"
+	extended := criteria.Filter()
+	extended[granted] = grant.Role
+	emp, err := r.findAndUpdate(ctx, extended, bson.M{
+		"$set": bson.M{
+			models.EmployeeRef.TemporaryRoles + ".$.expiresAt": grant.ExpiresAt,
+			models.EmployeeRef.TemporaryRoles + ".$.grantedAt": grant.GrantedAt,
+		},
+		"$inc": nextVersion,
+	})
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return emp, err
+	}
+	added := criteria.Filter()
+	added[granted] = bson.M{"$ne": grant.Role}
+	return r.findAndUpdate(ctx, added, bson.M{
+		"$addToSet": bson.M{models.EmployeeRef.Roles: grant.Role},
+		"$push":     bson.M{models.EmployeeRef.TemporaryRoles: grant},
+		"$inc":      nextVersion,
+	})
+}
+
+// RevokeExpiredRole removes the role, and its temporary grant, from every employee matching the criteria whose grant
+// of the role expired at or before now. It returns how many employees it updated.
+func (r *EmployeeRepository) RevokeExpiredRole(ctx context.Context, criteria EmployeeCriteria, role string, now time.Time) (int64, error) {
+	filter := criteria.Filter()
+	filter[models.EmployeeRef.TemporaryRoles] = bson.M{"$elemMatch": bson.M{"role": role, "expiresAt": bson.M{"$lte": now}}}
+	return r.updateAll(ctx, filter, bson.M{
+		"$pull": bson.M{
+			models.EmployeeRef.Roles:          role,
+			models.EmployeeRef.TemporaryRoles: bson.M{"role": role},
+		},
+		"$inc": nextVersion,
+	})
+}
+
+// SetDelegation sets the delegation of the first employee matching the criteria, replacing any other, and returns the
+// updated employee.
+func (r *EmployeeRepository) SetDelegation(ctx context.Context, criteria EmployeeCriteria, delegation models.Delegation) (models.Employee, error) {
+	return r.findAndUpdate(ctx, criteria.Filter(), bson.M{
+		"$set": bson.M{models.EmployeeRef.Delegation: delegation},
+		"$inc": nextVersion,
+	})
+}
+
+// RemoveDelegation removes the delegation of every employee matching the criteria, and returns how many had one.
+func (r *EmployeeRepository) RemoveDelegation(ctx context.Context, criteria EmployeeCriteria) (int64, error) {
+	filter := criteria.Filter()
+	filter[models.EmployeeRef.Delegation] = bson.M{"$exists": true}
+	return r.updateAll(ctx, filter, bson.M{
+		"$unset": bson.M{models.EmployeeRef.Delegation: ""},
+		"$inc":   nextVersion,
+	})
+}
+
+// SetStatus moves the first employee matching the criteria to the status the transition leads to, recording it in
+// their status history, and returns the updated employee.
+func (r *EmployeeRepository) SetStatus(ctx context.Context, criteria EmployeeCriteria, transition models.StatusTransition) (models.Employee, error) {
+	return r.findAndUpdate(ctx, criteria.Filter(), bson.M{
+		"$set":  bson.M{models.EmployeeRef.Status: transition.To},
+		"$push": bson.M{models.EmployeeRef.StatusHistory: transition},
+		"$inc":  nextVersion,
+	})
+}
+
+// AddDottedLine adds the manager to the dotted lines of every employee matching the criteria, unless it is there
+// already, and returns how many employees it updated.
+func (r *EmployeeRepository) AddDottedLine(ctx context.Context, criteria EmployeeCriteria, manager string) (int64, error) {
+	return r.updateAll(ctx, criteria.Filter(), bson.M{
+		"$addToSet": bson.M{models.EmployeeRef.DottedLines: manager},
+		"$inc":      nextVersion,
+	})
+}
+
+// RemoveDottedLine removes the manager from the dotted lines of every employee matching the criteria, and returns
+// how many had it.
+func (r *EmployeeRepository) RemoveDottedLine(ctx context.Context, criteria EmployeeCriteria, manager string) (int64, error) {
+	filter := criteria.Filter()
+	filter[models.EmployeeRef.DottedLines] = manager
+	return r.updateAll(ctx, filter, bson.M{
+		"$pull": bson.M{models.EmployeeRef.DottedLines: manager},
+		"$inc":  nextVersion,
+	})
+}
+
+// ChangeEmail changes the email of the first employee matching the criteria, and reports whether one matched. An
+// email taken in the tenant is reported as a duplicate key error.
+func (r *EmployeeRepository) ChangeEmail(ctx context.Context, criteria EmployeeCriteria, email string) (bool, error) {
+	return r.updateOne(ctx, criteria.Filter(), bson.M{
+		"$set": bson.M{models.EmployeeRef.Email: email},
+		"$inc": nextVersion,
+	})
+}
+
+// AssignID sets the internal ID of the first employee matching the criteria, and reports whether one matched. An ID
+// taken is reported as a duplicate key error.
+func (r *EmployeeRepository) AssignID(ctx context.Context, criteria EmployeeCriteria, id string) (bool, error) {
+	return r.updateOne(ctx, criteria.Filter(), bson.M{"$set": bson.M{models.EmployeeRef.ID: id}})
+}
+
+// DeriveDisplayNames sets the display name of the employees matching the criteria stored without one, from their
+// preferred name or else their legal name, and returns how many it set.
+func (r *EmployeeRepository) DeriveDisplayNames(ctx context.Context, criteria EmployeeCriteria) (int64, error) {
+	filter := criteria.Filter()
+	filter[models.EmployeeRef.DisplayName] = bson.M{"$exists": false}
+	return r.updateAll(ctx, filter, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{models.EmployeeRef.DisplayName: bson.M{"$ifNull": bson.A{
+			"$" + models.EmployeeRef.PreferredName,
+			"$" + models.EmployeeRef.Name,
+		}}}}},
+	})
+}
+
+// SoftDelete marks every employee matching the criteria as deleted at the time, and returns how many it marked.
+func (r *EmployeeRepository) SoftDelete(ctx context.Context, criteria EmployeeCriteria, at time.Time) (int64, error) {
+	return r.updateAll(ctx, criteria.Filter(), bson.M{
+		"$set": bson.M{models.EmployeeRef.DeletedAt: at},
+		"$inc": nextVersion,
+	})
+}
+
+// Restore clears the deletion mark of the first employee matching the criteria, and returns the restored employee.
+func (r *EmployeeRepository) Restore(ctx context.Context, criteria EmployeeCriteria) (models.Employee, error) {
+	return r.findAndUpdate(ctx, criteria.Filter(), bson.M{
+		"$unset": bson.M{models.EmployeeRef.DeletedAt: ""},
+		"$inc":   nextVersion,
+	})
+}
+
+// Delete removes every employee matching the criteria for good, and returns how many it removed.
+func (r *EmployeeRepository) Delete(ctx context.Context, criteria EmployeeCriteria) (int64, error) {
+	var res *mongo.DeleteResult
+	err := r.Retry.do(ctx, true, func() (err error) {
+		res, err = r.Collection.DeleteMany(ctx, criteria.Filter())
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// explainableCommands are the commands whose query plans are captured.
+var explainableCommands = map[string]bool{
+	"find":          true,
+	"aggregate":     true,
+	"count":         true,
+	"distinct":      true,
+	"update":        true,
+	"delete":        true,
+	"findAndModify": true,
+}
+
+// sessionFields are command fields that are not allowed inside an explain command.
+var sessionFields = map[string]bool{
+	"lsid":             true,
+	"txnNumber":        true,
+	"autocommit":       true,
+	"startTransaction": true,
+	"readConcern":      true,
+	"writeConcern":     true,
+}
+
+// QueryExplainer logs the query plans of a sample of the repository queries and flags collection scans,
+// so filters that stop using an index are visible without a profiling session. It is a debugging aid:
+// every sampled query is run a second time as an explain command.
+type QueryExplainer struct {
+	sampleRate float64
+	client     atomic.Pointer[mongo.Client]
+}
+
+// NewQueryExplainer creates a QueryExplainer that explains the given fraction (0 to 1) of queries.
+func NewQueryExplainer(sampleRate float64) *QueryExplainer {
+	return &QueryExplainer{sampleRate: sampleRate}
+}
+
+// Attach sets the client used to run explain commands. Queries started before Attach are not explained.
+func (q *QueryExplainer) Attach(client *mongo.Client) {
+	q.client.Store(client)
+}
+
+// CommandMonitor returns a MongoDB command monitor that explains sampled queries in the background.
+func (q *QueryExplainer) CommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			if !explainableCommands[e.CommandName] || rand.Float64() >= q.sampleRate {
+				return
+			}
+			client := q.client.Load()
+			if client == nil {
+				return
+			}
+			// The command is only valid during the callback, so copy it before explaining asynchronously.
+			command := append(bson.Raw(nil), e.Command...)
+			go q.explain(client, e.DatabaseName, e.CommandName, command)
+		},
+	}
+}
+
+// explain runs the explain command for a captured query and logs its plan.
+func (q *QueryExplainer) explain(client *mongo.Client, dbName, commandName string, command bson.Raw) {
+	elements, err := command.Elements()
+	if err != nil {
+		return
+	}
+	var explained bson.D
+	var collection string
+	for _, element := range elements {
+		key := element.Key()
+		if strings.HasPrefix(key, "$") || sessionFields[key] {
+			continue
+		}
+		if key == commandName {
+			collection, _ = element.Value().StringValueOK()
+		}
+		explained = append(explained, bson.E{Key: key, Value: element.Value()})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := client.Database(dbName).RunCommand(ctx, bson.D{
+		{Key: "explain", Value: explained},
+		{Key: "verbosity", Value: "queryPlanner"},
+	}).Raw()
+	if err != nil {
+		slog.Warn("Failed to explain a query", "command", commandName, "collection", dbName+"."+collection, "error", err)
+		return
+	}
+
+	plan, err := result.LookupErr("queryPlanner", "winningPlan")
+	if err != nil {
+		return
+	}
+	var stages []string
+	planStages(plan, &stages)
+	// Collection scans are flagged with a warning: they are what an index is missing for.
+	collectionScan := slices.Contains(stages, "COLLSCAN")
+	level := slog.LevelInfo
+	if collectionScan {
+		level = slog.LevelWarn
+	}
+	slog.Log(context.Background(), level, "Query plan", "command", commandName, "collection", dbName+"."+collection,
+		"plan", strings.Join(stages, " <- "), "collection_scan", collectionScan, "filter", queryFilter(command))
+}
+
+// planStages collects the stage names of a plan, outermost first.
+func planStages(value bson.RawValue, stages *[]string) {
+	doc, ok := value.DocumentOK()
+	if !ok {
+		if arr, ok := value.ArrayOK(); ok {
+			values, _ := arr.Values()
+			for _, v := range values {
+				planStages(v, stages)
+			}
+		}
+		return
+	}
+	if stage, ok := doc.Lookup("stage").StringValueOK(); ok {
+		*stages = append(*stages, stage)
+	}
+	elements, _ := doc.Elements()
+	for _, element := range elements {
+		switch element.Key() {
+		case "inputStage", "inputStages", "queryPlan", "shards":
+			planStages(element.Value(), stages)
+		}
+	}
+}
+
+// queryFilter returns the filter of a captured query for the log line.
+func queryFilter(command bson.Raw) string {
+	for _, key := range []string{"filter", "query", "pipeline"} {
+		if value, err := command.LookupErr(key); err == nil {
+			return value.String()
+		}
+	}
+	if updates, err := command.LookupErr("updates", "0", "q"); err == nil {
+		return updates.String()
+	}
+	if deletes, err := command.LookupErr("deletes", "0", "q"); err == nil {
+		return deletes.String()
+	}
+	return "{}"
+}
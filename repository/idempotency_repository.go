@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"WebMVCEmployees/models"
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// IdempotencyCollection is the name of the collection holding idempotency records.
+const IdempotencyCollection = "idempotency_keys"
+
+// IdempotencyRepository stores responses keyed by Idempotency-Key.
+type IdempotencyRepository struct {
+	Collection *mongo.Collection
+}
+
+// NewIdempotencyRepository creates a new IdempotencyRepository and ensures that records expire after ttl.
+func NewIdempotencyRepository(client *mongo.Client, dbName string, ttl time.Duration) (*IdempotencyRepository, error) {
+	coll := client.Database(dbName).Collection(IdempotencyCollection)
+
+	// Create a TTL index so Mongo removes records once they expire.
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "createdAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(ttl.Seconds())),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := coll.Indexes().CreateOne(ctx, indexModel)
+	if err != nil {
+		slog.Warn("Failed to create TTL index on idempotency keys", "error", err)
+		return nil, err
+	}
+
+	return &IdempotencyRepository{
+		Collection: coll,
+	}, nil
+}
+
+// Reserve claims the key for a new request. If the key was already used, the existing record
+// is returned and created is false.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, key, fingerprint string) (models.IdempotencyRecord, bool, error) {
+	record := models.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   time.Now().UTC(),
+	}
+	_, err := r.Collection.InsertOne(ctx, record)
+	if err == nil {
+		return record, true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return models.IdempotencyRecord{}, false, err
+	}
+	var existing models.IdempotencyRecord
+	if err := r.Collection.FindOne(ctx, bson.M{"_id": key}).Decode(&existing); err != nil {
+		return models.IdempotencyRecord{}, false, err
+	}
+	return existing, false, nil
+}
+
+// Complete stores the response sent for a reserved key.
+func (r *IdempotencyRepository) Complete(ctx context.Context, key string, status int, contentType, location string, body []byte) error {
+	_, err := r.Collection.UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$set": bson.M{
+		"completed":   true,
+		"status":      status,
+		"contentType": contentType,
+		"location":    location,
+		"body":        body,
+	}})
+	return err
+}
+
+// Release removes a reserved key so the request can be retried.
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	_, err := r.Collection.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
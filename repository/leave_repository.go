@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Names of the collections holding leave requests and the yearly leave balances of employees.
+const (
+	LeaveRequestCollection = "leave_requests"
+	LeaveBalanceCollection = "leave_balances"
+)
+
+// LeaveRepository encapsulates operations on the leave requests and leave balances collections.
+// Balances are keyed by employee and year, so the _id index keeps one balance per employee per year.
+type LeaveRepository struct {
+	Requests *TenantCollection
+	Balances *TenantCollection
+}
+
+// NewLeaveRepository creates a new LeaveRepository and ensures an index for listing an employee's requests by date.
+func NewLeaveRepository(client *mongo.Client, dbName string) (*LeaveRepository, error) {
+	db := client.Database(dbName)
+
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "employee", Value: 1}, {Key: "startDate", Value: 1}},
+	}
+	requests := NewTenantCollection(db, LeaveRequestCollection, indexModel)
+	if err := requests.CreateIndexes(); err != nil {
+		slog.Warn("Failed to create index on leave requests", "error", err)
+		return nil, err
+	}
+
+	return &LeaveRepository{
+		Requests: requests,
+		Balances: NewTenantCollection(db, LeaveBalanceCollection),
+	}, nil
+}
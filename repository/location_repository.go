@@ -0,0 +1,19 @@
+package repository
+
+import "go.mongodb.org/mongo-driver/v2/mongo"
+
+// LocationCollection is the name of the collection holding office locations.
+const LocationCollection = "locations"
+
+// LocationRepository encapsulates operations on the locations collection.
+// Locations are keyed by name, so the _id index keeps names unique.
+type LocationRepository struct {
+	Collection *TenantCollection
+}
+
+// NewLocationRepository creates a new LocationRepository.
+func NewLocationRepository(client *mongo.Client, dbName string) *LocationRepository {
+	return &LocationRepository{
+		Collection: NewTenantCollection(client.Database(dbName), LocationCollection),
+	}
+}
@@ -0,0 +1,338 @@
+package repository
+
+import (
+	"WebMVCEmployees/models"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// employeeCollection is the collection name the stores outside MongoDB report duplicate key errors in.
+const employeeCollection = "employees"
+
+// duplicateKey is the server error code of a write violating a unique index.
+const duplicateKey = 11000
+
+// MemoryEmployeeRepository keeps employees in memory, for demos and for tests that run without MongoDB. It stores
+// them as BSON documents and checks them against the filters of the criteria itself, so it behaves like
+// EmployeeRepository: emails are unique, internal IDs are unique when set, and lists are sorted and paged alike.
+//
+// It is safe for concurrent use. Writes are serialized, and a transaction holds off the writes of others until it
+// ends; reads do not wait for transactions, so they may see writes that are later rolled back.
+type MemoryEmployeeRepository struct {
+	documentOperations
+	// txMu is held by each write, and by transactions from start to end.
+	txMu sync.Mutex
+	mu   sync.RWMutex
+	// docs holds the employees in insertion order. The documents are never modified once stored, only replaced,
+	// so a copy of the slice is a snapshot.
+	docs []bson.M
+}
+
+// NewMemoryEmployeeRepository creates an empty MemoryEmployeeRepository.
+func NewMemoryEmployeeRepository() *MemoryEmployeeRepository {
+	r := &MemoryEmployeeRepository{}
+	r.documentOperations = documentOperations{r}
+	return r
+}
+
+// memoryTransaction marks the context of a transaction with the repository running it.
+type memoryTransaction struct{}
+
+// write runs fn holding the write lock, waiting for the transaction in progress unless ctx belongs to it.
+func (r *MemoryEmployeeRepository) write(ctx context.Context, fn func() error) error {
+	if ctx.Value(memoryTransaction{}) != r {
+		r.txMu.Lock()
+		defer r.txMu.Unlock()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return fn()
+}
+
+// duplicateKeyError reports a write violating the unique index on field as the MongoDB server does.
+func duplicateKeyError(field string, value any) error {
+	return mongo.WriteException{WriteErrors: []mongo.WriteError{{
+		Code:    duplicateKey,
+		Message: fmt.Sprintf("E11000 duplicate key error collection: %s index: %s_1 dup key: { %s: %q }", employeeCollection, field, field, value),
+	}}}
+}
+
+// checkUnique returns a duplicate key error when doc has the email, in the same tenant, or the internal ID of a
+// stored employee other than the one at index skip.
+func (r *MemoryEmployeeRepository) checkUnique(doc bson.M, skip int) error {
+	for i, other := range r.docs {
+		if i == skip {
+			continue
+		}
+		for _, field := range []string{models.EmployeeRef.Email, models.EmployeeRef.ID} {
+			value, ok := doc[field]
+			if !ok {
+				continue
+			}
+			if field == models.EmployeeRef.Email && !equal(doc[models.EmployeeRef.TenantID], other[models.EmployeeRef.TenantID]) {
+				continue
+			}
+			if otherValue, ok := other[field]; ok && equal(value, otherValue) {
+				return duplicateKeyError(field, value)
+			}
+		}
+	}
+	return nil
+}
+
+// filterOf converts a filter to canonical form.
+func filterOf(filter bson.M) (bson.M, error) {
+	if filter == nil {
+		return bson.M{}, nil
+	}
+	return canonicalDocument(filter)
+}
+
+// find returns the indexes of the stored employees matching the filter, at most limit of them when limit > 0.
+func (r *MemoryEmployeeRepository) find(filter documentFilter, limit int) ([]int, error) {
+	var found []int
+	for i, doc := range r.docs {
+		ok, err := filter.matches(doc)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			found = append(found, i)
+			if len(found) == limit {
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+// Create inserts a new employee.
+func (r *MemoryEmployeeRepository) Create(ctx context.Context, emp models.Employee) error {
+	doc, err := canonicalDocument(emp)
+	if err != nil {
+		return err
+	}
+	doc["_id"] = bson.NewObjectID()
+	return r.write(ctx, func() error {
+		if err := r.checkUnique(doc, -1); err != nil {
+			return err
+		}
+		r.docs = append(r.docs, doc)
+		return nil
+	})
+}
+
+// InsertMany inserts new employees, as EmployeeRepository.InsertMany does.
+func (r *MemoryEmployeeRepository) InsertMany(ctx context.Context, emps []models.Employee, ordered bool) error {
+	docs := make([]bson.M, len(emps))
+	for i, emp := range emps {
+		doc, err := canonicalDocument(emp)
+		if err != nil {
+			return err
+		}
+		doc["_id"] = bson.NewObjectID()
+		docs[i] = doc
+	}
+	var failures mongo.BulkWriteException
+	err := r.write(ctx, func() error {
+		for i, doc := range docs {
+			if err := r.checkUnique(doc, -1); err != nil {
+				bulkWriteFailure(&failures, i, err)
+				if ordered {
+					break
+				}
+				continue
+			}
+			r.docs = append(r.docs, doc)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return bulkWriteError(failures)
+}
+
+// decodeStored decodes a stored employee, leaving the password out unless credentials is set, as
+// EmployeeRepository reads employees.
+func decodeStored(doc bson.M, credentials bool) (emp models.Employee, err error) {
+	if !credentials {
+		if doc, err = project(doc, withoutCredentials); err != nil {
+			return emp, err
+		}
+	}
+	err = decodeDocument(doc, &emp)
+	return emp, err
+}
+
+// load returns the employees matching the criteria, in insertion order, at most limit of them when limit > 0.
+func (r *MemoryEmployeeRepository) load(ctx context.Context, criteria EmployeeCriteria, limit int) ([]bson.M, error) {
+	filter, err := documentFilterOf(criteria)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	found, err := r.find(filter, limit)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]bson.M, len(found))
+	for i, j := range found {
+		docs[i] = r.docs[j]
+	}
+	return docs, nil
+}
+
+// List returns a page of the employees matching the criteria, without their password.
+func (r *MemoryEmployeeRepository) List(ctx context.Context, criteria EmployeeCriteria, page Page) (employees []models.Employee, err error) {
+	find, err := resolveFindOptions([]options.Lister[options.FindOptions]{page.findOptions()})
+	if err != nil {
+		return nil, err
+	}
+	docs, err := r.load(ctx, criteria, 0)
+	if err != nil {
+		return nil, err
+	}
+	if docs, err = applyFindOptions(docs, find); err != nil {
+		return nil, err
+	}
+	err = decodeAll(docs, &employees)
+	return employees, err
+}
+
+// Count returns the number of employees matching the criteria.
+func (r *MemoryEmployeeRepository) Count(ctx context.Context, criteria EmployeeCriteria) (int64, error) {
+	docs, err := r.load(ctx, criteria, 0)
+	return int64(len(docs)), err
+}
+
+// change calls change on the employees matching the criteria and stores those it changed. Like MongoDB, it stops at
+// the first write violating a unique index, keeping the writes before it.
+func (r *MemoryEmployeeRepository) change(ctx context.Context, criteria EmployeeCriteria, limit int, change func(emp *models.Employee) bool) (changed []bson.M, err error) {
+	filter, err := documentFilterOf(criteria)
+	if err != nil {
+		return nil, err
+	}
+	err = r.write(ctx, func() error {
+		found, err := r.find(filter, limit)
+		if err != nil {
+			return err
+		}
+		for _, i := range found {
+			doc, err := changeDocument(r.docs[i], change)
+			if err != nil {
+				return err
+			}
+			if doc == nil {
+				continue
+			}
+			if err := r.checkUnique(doc, i); err != nil {
+				return err
+			}
+			r.docs[i] = doc
+			changed = append(changed, doc)
+		}
+		return nil
+	})
+	return changed, err
+}
+
+// ReplaceEmployee replaces the stored employee having emp's email with emp, as EmployeeRepository.ReplaceEmployee
+// does.
+func (r *MemoryEmployeeRepository) ReplaceEmployee(ctx context.Context, emp models.Employee, version int64, upsert bool) (inserted bool, err error) {
+	doc, err := canonicalDocument(emp)
+	if err != nil {
+		return false, err
+	}
+	filter, err := documentFilterOf(replacedBy(emp, version))
+	if err != nil {
+		return false, err
+	}
+	err = r.write(ctx, func() error {
+		found, err := r.find(filter, 1)
+		if err != nil {
+			return err
+		}
+		if len(found) > 0 {
+			doc["_id"] = r.docs[found[0]]["_id"]
+			if err := r.checkUnique(doc, found[0]); err != nil {
+				return err
+			}
+			r.docs[found[0]] = doc
+			return nil
+		}
+		if !upsert {
+			return mongo.ErrNoDocuments
+		}
+		doc["_id"] = bson.NewObjectID()
+		if err := r.checkUnique(doc, -1); err != nil {
+			return err
+		}
+		r.docs = append(r.docs, doc)
+		inserted = true
+		return nil
+	})
+	return inserted, err
+}
+
+// remove removes the employees matching the criteria.
+func (r *MemoryEmployeeRepository) remove(ctx context.Context, criteria EmployeeCriteria) (deleted int64, err error) {
+	filter, err := documentFilterOf(criteria)
+	if err != nil {
+		return 0, err
+	}
+	err = r.write(ctx, func() error {
+		found, err := r.find(filter, 0)
+		if err != nil {
+			return err
+		}
+		kept := make([]bson.M, 0, len(r.docs)-len(found))
+		for i, doc := range r.docs {
+			if !slices.Contains(found, i) {
+				kept = append(kept, doc)
+			}
+		}
+		r.docs = kept
+		deleted = int64(len(found))
+		return nil
+	})
+	return deleted, err
+}
+
+// walk follows the reporting lines from start through the employees matching through.
+func (r *MemoryEmployeeRepository) walk(ctx context.Context, start bson.M, through EmployeeCriteria, up bool, maxDepth int) ([]reached, error) {
+	docs, err := r.load(ctx, through, 0)
+	if err != nil {
+		return nil, err
+	}
+	return walkDocuments(start, docs, up, maxDepth), nil
+}
+
+// InTransaction runs fn in a transaction: the writes of others wait until it ends, and its own writes are rolled back
+// when fn fails. A transaction started within fn joins the one in progress.
+func (r *MemoryEmployeeRepository) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(memoryTransaction{}) == r {
+		return fn(ctx)
+	}
+	r.txMu.Lock()
+	defer r.txMu.Unlock()
+
+	r.mu.RLock()
+	snapshot := slices.Clone(r.docs)
+	r.mu.RUnlock()
+
+	err := fn(context.WithValue(ctx, memoryTransaction{}, r))
+	if err != nil {
+		r.mu.Lock()
+		r.docs = snapshot
+		r.mu.Unlock()
+	}
+	return err
+}
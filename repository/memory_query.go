@@ -0,0 +1,751 @@
+package repository
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// This file interprets the subset of the MongoDB query language that the filters of EmployeeCriteria use, over
+// documents held in memory.
+// Documents are kept in canonical form: documents are bson.M, arrays bson.A and scalars their BSON types, such as
+// bson.DateTime for time.Time and int32 or int64 for integers.
+
+// unsupported reports a filter or a projection using an operator or a form the interpreter does not know.
+func unsupported(what string) error {
+	return errors.New("memory store: unsupported " + what)
+}
+
+// canonical converts a Go value to canonical form by encoding it to BSON and decoding it back.
+func canonical(v any) (any, error) {
+	data, err := bson.Marshal(bson.M{"v": v})
+	if err != nil {
+		return nil, err
+	}
+	dec := bson.NewDecoder(bson.NewDocumentReader(bytes.NewReader(data)))
+	dec.DefaultDocumentM()
+	var doc bson.M
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc["v"], nil
+}
+
+// canonicalDocument converts a Go value encoding to a BSON document, such as a struct or a filter, to a bson.M.
+func canonicalDocument(v any) (bson.M, error) {
+	c, err := canonical(v)
+	if err != nil {
+		return nil, err
+	}
+	doc, ok := c.(bson.M)
+	if !ok {
+		return nil, fmt.Errorf("memory store: %T is not a document", v)
+	}
+	return doc, nil
+}
+
+// cloneDocument returns a deep copy of a canonical document.
+func cloneDocument(doc bson.M) bson.M {
+	out := make(bson.M, len(doc))
+	for key, value := range doc {
+		out[key] = cloneValue(value)
+	}
+	return out
+}
+
+func cloneValue(v any) any {
+	switch t := v.(type) {
+	case bson.M:
+		return cloneDocument(t)
+	case bson.A:
+		out := make(bson.A, len(t))
+		for i, elem := range t {
+			out[i] = cloneValue(elem)
+		}
+		return out
+	}
+	return v
+}
+
+// decodeDocument decodes a canonical document into out, a pointer.
+func decodeDocument(doc bson.M, out any) error {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, out)
+}
+
+// decodeAll decodes canonical documents into results, a pointer to a slice, like mongo.Cursor.All.
+func decodeAll(docs []bson.M, results any) error {
+	slice := reflect.ValueOf(results)
+	if slice.Kind() != reflect.Pointer || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("memory store: results must be a pointer to a slice, got %T", results)
+	}
+	slice = slice.Elem()
+	out := slice.Slice(0, 0)
+	for _, doc := range docs {
+		elem := reflect.New(slice.Type().Elem())
+		if err := decodeDocument(doc, elem.Interface()); err != nil {
+			return err
+		}
+		out = reflect.Append(out, elem.Elem())
+	}
+	slice.Set(out)
+	return nil
+}
+
+// lookup returns the values at a dotted path of a document. Arrays of documents along the path are traversed, as
+// in MongoDB queries, so a path may yield several values; a missing field yields none.
+func lookup(v any, path string) []any {
+	return lookupParts(v, strings.Split(path, "."))
+}
+
+func lookupParts(v any, parts []string) []any {
+	if len(parts) == 0 {
+		return []any{v}
+	}
+	switch t := v.(type) {
+	case bson.M:
+		child, ok := t[parts[0]]
+		if !ok {
+			return nil
+		}
+		return lookupParts(child, parts[1:])
+	case bson.A:
+		if i, err := strconv.Atoi(parts[0]); err == nil {
+			if i < len(t) {
+				return lookupParts(t[i], parts[1:])
+			}
+			return nil
+		}
+		var values []any
+		for _, elem := range t {
+			if _, ok := elem.(bson.M); ok {
+				values = append(values, lookupParts(elem, parts)...)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// expand adds the elements of the array values to the values, since a query condition on an array field holds when
+// it holds for the array or for any of its elements.
+func expand(values []any) []any {
+	out := values
+	for _, v := range values {
+		if array, ok := v.(bson.A); ok {
+			out = append(out[:len(out):len(out)], array...)
+		}
+	}
+	return out
+}
+
+// toNumber returns the value of a BSON number.
+func toNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// compare orders two values of the same kind, reporting false when they cannot be compared.
+func compare(a, b any) (int, bool) {
+	if x, ok := toNumber(a); ok {
+		y, ok := toNumber(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+	switch x := a.(type) {
+	case nil:
+		return 0, b == nil
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	case bson.DateTime:
+		if y, ok := b.(bson.DateTime); ok {
+			return compareInts(int64(x), int64(y)), true
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0, true
+			case y:
+				return -1, true
+			}
+			return 1, true
+		}
+	case bson.ObjectID:
+		if y, ok := b.(bson.ObjectID); ok {
+			return bytes.Compare(x[:], y[:]), true
+		}
+	}
+	return 0, false
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// equal reports whether two canonical values are equal, comparing numbers by value.
+func equal(a, b any) bool {
+	if c, ok := compare(a, b); ok {
+		return c == 0
+	}
+	switch x := a.(type) {
+	case bson.M:
+		y, ok := b.(bson.M)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case bson.A:
+		y, ok := b.(bson.A)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// typeRank orders the BSON types as MongoDB sorts them.
+func typeRank(v any) int {
+	if _, ok := toNumber(v); ok {
+		return 2
+	}
+	switch v.(type) {
+	case nil:
+		return 1
+	case string:
+		return 3
+	case bson.M:
+		return 4
+	case bson.A:
+		return 5
+	case bson.ObjectID:
+		return 7
+	case bool:
+		return 8
+	case bson.DateTime:
+		return 9
+	}
+	return 10
+}
+
+// compareSorted orders any two values as MongoDB sorts them, types first.
+func compareSorted(a, b any) int {
+	if ra, rb := typeRank(a), typeRank(b); ra != rb {
+		return compareInts(int64(ra), int64(rb))
+	}
+	c, _ := compare(a, b)
+	return c
+}
+
+// truthy reports whether an expression result counts as true: anything but null, false and zero.
+func truthy(v any) bool {
+	if n, ok := toNumber(v); ok {
+		return n != 0
+	}
+	switch t := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return t
+	}
+	return true
+}
+
+// isOperatorDocument reports whether a condition is a document of query operators, such as {$gt: 1}, rather than
+// a document to compare with.
+func isOperatorDocument(cond any) (bson.M, bool) {
+	doc, ok := cond.(bson.M)
+	if !ok || len(doc) == 0 {
+		return nil, false
+	}
+	for key := range doc {
+		if !strings.HasPrefix(key, "$") {
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+// matches reports whether a document satisfies a filter.
+func matches(doc bson.M, filter bson.M) (bool, error) {
+	for key, cond := range filter {
+		switch key {
+		case "$and", "$or", "$nor":
+			clauses, _ := cond.(bson.A)
+			matched := 0
+			for _, clause := range clauses {
+				sub, ok := clause.(bson.M)
+				if !ok {
+					continue
+				}
+				ok, err := matches(doc, sub)
+				if err != nil {
+					return false, err
+				}
+				if ok {
+					matched++
+				}
+			}
+			if key == "$and" && matched != len(clauses) || key == "$or" && matched == 0 || key == "$nor" && matched > 0 {
+				return false, nil
+			}
+		default:
+			if strings.HasPrefix(key, "$") {
+				return false, unsupported("query operator " + key)
+			}
+			ok, err := matchCondition(lookup(doc, key), cond)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// matchCondition reports whether the values found at a field path satisfy a condition: a value to compare with, or a
+// document of query operators.
+func matchCondition(values []any, cond any) (bool, error) {
+	ops, ok := isOperatorDocument(cond)
+	if !ok {
+		return matchEqual(values, cond), nil
+	}
+	for op, arg := range ops {
+		if op == "$options" {
+			continue
+		}
+		ok, err := matchOperator(values, op, arg, ops)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchEqual reports whether a value, or an element of an array value, equals want. Null matches a missing field.
+func matchEqual(values []any, want any) bool {
+	if want == nil && len(values) == 0 {
+		return true
+	}
+	for _, v := range expand(values) {
+		if equal(v, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCompare reports whether a value, or an element of an array value, compares with arg as accepted by ok.
+func matchCompare(values []any, arg any, ok func(int) bool) bool {
+	for _, v := range expand(values) {
+		if c, comparable := compare(v, arg); comparable && ok(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchOperator(values []any, op string, arg any, ops bson.M) (bool, error) {
+	switch op {
+	case "$eq":
+		return matchEqual(values, arg), nil
+	case "$ne":
+		return !matchEqual(values, arg), nil
+	case "$in", "$nin":
+		list, _ := arg.(bson.A)
+		found := false
+		for _, want := range list {
+			if matchEqual(values, want) {
+				found = true
+				break
+			}
+		}
+		return found == (op == "$in"), nil
+	case "$gt":
+		return matchCompare(values, arg, func(c int) bool { return c > 0 }), nil
+	case "$gte":
+		return matchCompare(values, arg, func(c int) bool { return c >= 0 }), nil
+	case "$lt":
+		return matchCompare(values, arg, func(c int) bool { return c < 0 }), nil
+	case "$lte":
+		return matchCompare(values, arg, func(c int) bool { return c <= 0 }), nil
+	case "$exists":
+		return (len(values) > 0) == truthy(arg), nil
+	case "$regex":
+		pattern, _ := arg.(string)
+		if options, _ := ops["$options"].(string); options != "" {
+			pattern = "(?" + options + ")" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, unsupported("regular expression " + pattern)
+		}
+		for _, v := range expand(values) {
+			if s, ok := v.(string); ok && re.MatchString(s) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "$elemMatch":
+		for _, v := range values {
+			array, ok := v.(bson.A)
+			if !ok {
+				continue
+			}
+			for _, elem := range array {
+				var matched bool
+				var err error
+				if _, isOps := isOperatorDocument(arg); isOps {
+					matched, err = matchCondition([]any{elem}, arg)
+				} else if doc, ok := elem.(bson.M); ok {
+					if sub, ok := arg.(bson.M); ok {
+						matched, err = matches(doc, sub)
+					}
+				}
+				if err != nil || matched {
+					return matched, err
+				}
+			}
+		}
+		return false, nil
+	case "$all":
+		list, _ := arg.(bson.A)
+		if len(list) == 0 {
+			return false, nil
+		}
+		for _, want := range list {
+			if sub, ok := want.(bson.M); ok && len(sub) == 1 && sub["$elemMatch"] != nil {
+				ok, err := matchOperator(values, "$elemMatch", sub["$elemMatch"], sub)
+				if err != nil || !ok {
+					return false, err
+				}
+			} else if !matchEqual(values, want) {
+				return false, nil
+			}
+		}
+		return true, nil
+	case "$size":
+		n, _ := toNumber(arg)
+		for _, v := range values {
+			if array, ok := v.(bson.A); ok && float64(len(array)) == n {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "$type":
+		for _, v := range expand(values) {
+			ok, err := hasType(v, arg)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case "$not":
+		ok, err := matchCondition(values, arg)
+		return !ok && err == nil, err
+	}
+	return false, unsupported("query operator " + op)
+}
+
+// hasType reports whether a value has the BSON type named by alias, such as "string" or "number".
+func hasType(v any, alias any) (bool, error) {
+	var ok bool
+	switch alias {
+	case "number":
+		_, ok = toNumber(v)
+	case "string":
+		_, ok = v.(string)
+	case "null":
+		ok = v == nil
+	case "bool":
+		_, ok = v.(bool)
+	case "date":
+		_, ok = v.(bson.DateTime)
+	case "object":
+		_, ok = v.(bson.M)
+	case "array":
+		_, ok = v.(bson.A)
+	default:
+		return false, unsupported(fmt.Sprintf("type alias %v", alias))
+	}
+	return ok, nil
+}
+
+// setPath sets the field at a dotted path of a document, creating the documents on the way.
+func setPath(doc bson.M, path string, value any) error {
+	parts := strings.Split(path, ".")
+	var current any = doc
+	for i, part := range parts {
+		last := i == len(parts)-1
+		switch t := current.(type) {
+		case bson.M:
+			if last {
+				t[part] = value
+				return nil
+			}
+			next, ok := t[part]
+			if !ok || next == nil {
+				next = bson.M{}
+				t[part] = next
+			}
+			current = next
+		case bson.A:
+			index, err := strconv.Atoi(part)
+			if err != nil || index >= len(t) {
+				return unsupported("update of array path " + path)
+			}
+			if last {
+				t[index] = value
+				return nil
+			}
+			current = t[index]
+		default:
+			return unsupported("update of path " + path + " through a scalar")
+		}
+	}
+	return nil
+}
+
+// unsetPath removes the field at a dotted path of a document, if any.
+func unsetPath(doc bson.M, path string) {
+	parts := strings.Split(path, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := doc[part].(bson.M)
+		if !ok {
+			return
+		}
+		doc = next
+	}
+	delete(doc, parts[len(parts)-1])
+}
+
+// excludePath removes the field at a path, split into parts, as a projection excludes it: from the documents of the
+// arrays along the path as well, unlike unsetPath.
+func excludePath(v any, parts []string) {
+	switch v := v.(type) {
+	case bson.M:
+		if len(parts) == 1 {
+			delete(v, parts[0])
+			return
+		}
+		excludePath(v[parts[0]], parts[1:])
+	case bson.A:
+		for _, elem := range v {
+			excludePath(elem, parts)
+		}
+	}
+}
+
+// firstValue returns the value at a path of a document, or nil when the field is missing.
+func firstValue(doc bson.M, path string) any {
+	values := lookup(doc, path)
+	if len(values) == 0 {
+		return nil
+	}
+	return values[0]
+}
+
+// collatorOf returns the collator comparing strings as the collation does, or nil for the simple collation, which
+// compares them byte by byte. Only the locale, the strength and numeric ordering are honored.
+func collatorOf(collation *options.Collation) (*collate.Collator, error) {
+	if collation == nil || collation.Locale == "" || collation.Locale == "simple" {
+		return nil, nil
+	}
+	tag, err := language.Parse(collation.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("collation locale %q: %w", collation.Locale, err)
+	}
+	var opts []collate.Option
+	switch collation.Strength {
+	case 1:
+		opts = append(opts, collate.IgnoreCase, collate.IgnoreDiacritics)
+	case 2:
+		opts = append(opts, collate.IgnoreCase)
+	}
+	if collation.NumericOrdering {
+		opts = append(opts, collate.Numeric)
+	}
+	return collate.New(tag, opts...), nil
+}
+
+// compareCollated orders any two values as compareSorted does, comparing strings with collator when there is one.
+func compareCollated(a, b any, collator *collate.Collator) int {
+	if x, ok := a.(string); ok && collator != nil {
+		if y, ok := b.(string); ok {
+			return collator.CompareString(x, y)
+		}
+	}
+	return compareSorted(a, b)
+}
+
+// sortDocuments orders documents by a sort specification such as bson.D{{Key: "email", Value: 1}}, keeping the
+// current order for ties. Strings compare with collator, or byte by byte when it is nil.
+func sortDocuments(docs []bson.M, spec any, collator *collate.Collator) error {
+	var keys bson.D
+	switch t := spec.(type) {
+	case nil:
+		return nil
+	case bson.D:
+		keys = t
+	case bson.M:
+		if len(t) > 1 {
+			return unsupported("sort on several keys of an unordered document")
+		}
+		for key, direction := range t {
+			keys = append(keys, bson.E{Key: key, Value: direction})
+		}
+	default:
+		return unsupported(fmt.Sprintf("sort specification %T", spec))
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		for _, key := range keys {
+			c := compareCollated(firstValue(docs[i], key.Key), firstValue(docs[j], key.Key), collator)
+			if direction, _ := toNumber(key.Value); direction < 0 {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+// project applies a projection to a document: fields set to 1 are kept and fields set to 0 removed. The _id is kept
+// unless excluded.
+func project(doc bson.M, spec bson.M) (bson.M, error) {
+	inclusion := false
+	for key, value := range spec {
+		if key == "_id" {
+			continue
+		}
+		if n, ok := toNumber(value); !ok || n != 0 {
+			if b, isBool := value.(bool); !isBool || b {
+				inclusion = true
+			}
+		}
+	}
+
+	if !inclusion {
+		out := cloneDocument(doc)
+		for path := range spec {
+			excludePath(out, strings.Split(path, "."))
+		}
+		return out, nil
+	}
+	out := bson.M{}
+	if id, ok := doc["_id"]; ok {
+		out["_id"] = id
+	}
+	for path, value := range spec {
+		n, isNumber := toNumber(value)
+		b, isBool := value.(bool)
+		var err error
+		switch {
+		case isNumber && n == 0, isBool && !b:
+			unsetPath(out, path)
+		case isNumber, isBool:
+			if values := lookup(doc, path); len(values) > 0 {
+				err = setPath(out, path, cloneValue(values[0]))
+			}
+		default:
+			err = unsupported("computed field " + path)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// resolveFindOptions applies the find option builders to a single FindOptions.
+func resolveFindOptions(opts []options.Lister[options.FindOptions]) (options.FindOptions, error) {
+	var find options.FindOptions
+	for _, opt := range opts {
+		for _, set := range opt.List() {
+			if err := set(&find); err != nil {
+				return find, err
+			}
+		}
+	}
+	return find, nil
+}
+
+// applyFindOptions sorts, pages and projects documents as the find options ask.
+func applyFindOptions(docs []bson.M, find options.FindOptions) ([]bson.M, error) {
+	collator, err := collatorOf(find.Collation)
+	if err != nil {
+		return nil, err
+	}
+	if err := sortDocuments(docs, find.Sort, collator); err != nil {
+		return nil, err
+	}
+	if find.Skip != nil {
+		docs = docs[min(int(*find.Skip), len(docs)):]
+	}
+	if find.Limit != nil && *find.Limit > 0 {
+		docs = docs[:min(int(*find.Limit), len(docs))]
+	}
+	if find.Projection != nil {
+		projection, err := canonicalDocument(find.Projection)
+		if err != nil {
+			return nil, err
+		}
+		for i, doc := range docs {
+			if docs[i], err = project(doc, projection); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return docs, nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// canonicalFor returns v in canonical form, failing the test when it cannot be encoded.
+func canonicalFor(t *testing.T, v any) bson.M {
+	t.Helper()
+	doc, err := canonicalDocument(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// queryDocument is the document the query tests match.
+func queryDocument(t *testing.T) bson.M {
+	return canonicalFor(t, bson.M{
+		"email":     "alice@example.com",
+		"name":      "Alice",
+		"roles":     bson.A{"admin", "user"},
+		"grade":     int32(7),
+		"salary":    4200.5,
+		"manager":   nil,
+		"birthDate": time.Date(1990, time.March, 15, 0, 0, 0, 0, time.UTC),
+		"address":   bson.M{"city": "Haifa", "country": "Israel"},
+		"skills": bson.A{
+			bson.M{"name": "go", "level": int32(4)},
+			bson.M{"name": "sql", "level": int32(2)},
+		},
+	})
+}
+
+func TestMatches_Operators(t *testing.T) {
+	doc := queryDocument(t)
+	tests := []struct {
+		name   string
+		filter bson.M
+		want   bool
+	}{
+		{"equal", bson.M{"email": "alice@example.com"}, true},
+		{"not equal", bson.M{"email": "bob@example.com"}, false},
+		{"array element", bson.M{"roles": "admin"}, true},
+		{"dotted path", bson.M{"address.city": "Haifa"}, true},
+		{"path through an array", bson.M{"skills.name": "sql"}, true},
+		{"null matches null", bson.M{"manager": nil}, true},
+		{"null matches missing", bson.M{"deletedAt": nil}, true},
+		{"$ne", bson.M{"name": bson.M{"$ne": "Bob"}}, true},
+		{"$in", bson.M{"roles": bson.M{"$in": bson.A{"guest", "user"}}}, true},
+		{"$nin", bson.M{"roles": bson.M{"$nin": bson.A{"admin"}}}, false},
+		{"$gt int with double", bson.M{"grade": bson.M{"$gt": 6.5}}, true},
+		{"$gte", bson.M{"grade": bson.M{"$gte": 7}}, true},
+		{"$lt", bson.M{"salary": bson.M{"$lt": 4200}}, false},
+		{"$lte dates", bson.M{"birthDate": bson.M{"$lte": time.Date(1990, time.March, 15, 0, 0, 0, 0, time.UTC)}}, true},
+		{"range", bson.M{"grade": bson.M{"$gt": 5, "$lt": 7}}, false},
+		{"$gt across types", bson.M{"name": bson.M{"$gt": 1}}, false},
+		{"$exists", bson.M{"address": bson.M{"$exists": true}}, true},
+		{"$exists false", bson.M{"deletedAt": bson.M{"$exists": false}}, true},
+		{"$regex", bson.M{"email": bson.M{"$regex": "@example\\.com$"}}, true},
+		{"$regex options", bson.M{"name": bson.M{"$regex": "^ALI", "$options": "i"}}, true},
+		{"$elemMatch document", bson.M{"skills": bson.M{"$elemMatch": bson.M{"name": "go", "level": bson.M{"$gte": 3}}}}, true},
+		{"$elemMatch on other elements", bson.M{"skills": bson.M{"$elemMatch": bson.M{"name": "sql", "level": bson.M{"$gte": 3}}}}, false},
+		{"$elemMatch operators", bson.M{"roles": bson.M{"$elemMatch": bson.M{"$eq": "user"}}}, true},
+		{"$all", bson.M{"roles": bson.M{"$all": bson.A{"user", "admin"}}}, true},
+		{"$all missing one", bson.M{"roles": bson.M{"$all": bson.A{"user", "guest"}}}, false},
+		{"$all empty", bson.M{"roles": bson.M{"$all": bson.A{}}}, false},
+		{"$size", bson.M{"roles": bson.M{"$size": 2}}, true},
+		{"$type", bson.M{"grade": bson.M{"$type": "number"}}, true},
+		{"$type date", bson.M{"birthDate": bson.M{"$type": "date"}}, true},
+		{"$not", bson.M{"grade": bson.M{"$not": bson.M{"$gt": 5}}}, false},
+		{"$and", bson.M{"$and": bson.A{bson.M{"name": "Alice"}, bson.M{"grade": 7}}}, true},
+		{"$or", bson.M{"$or": bson.A{bson.M{"name": "Bob"}, bson.M{"grade": 7}}}, true},
+		{"$nor", bson.M{"$nor": bson.A{bson.M{"name": "Bob"}, bson.M{"grade": 7}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matches(doc, canonicalFor(t, tt.filter))
+			if err != nil {
+				t.Fatalf("expected the filter to be supported, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected a match: %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMatches_UnsupportedReturnsError(t *testing.T) {
+	doc := queryDocument(t)
+	tests := []struct {
+		name   string
+		filter bson.M
+	}{
+		{"top-level operator", bson.M{"$where": "true"}},
+		{"field operator", bson.M{"name": bson.M{"$near": bson.A{0, 0}}}},
+		{"type alias", bson.M{"name": bson.M{"$type": "decimal"}}},
+		{"invalid regex", bson.M{"name": bson.M{"$regex": "("}}},
+		{"within $or", bson.M{"$or": bson.A{bson.M{"name": bson.M{"$text": "x"}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := matches(doc, canonicalFor(t, tt.filter))
+			if err == nil || !strings.HasPrefix(err.Error(), "memory store: unsupported") {
+				t.Errorf("expected an unsupported error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSortDocuments(t *testing.T) {
+	docs := []bson.M{
+		{"name": "bob", "grade": int32(2)},
+		{"name": "Alice", "grade": int32(2)},
+		{"name": "carol", "grade": int32(1)},
+	}
+	if err := sortDocuments(docs, bson.D{{Key: "grade", Value: -1}, {Key: "name", Value: 1}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := []any{docs[0]["name"], docs[1]["name"], docs[2]["name"]}; got[0] != "Alice" || got[1] != "bob" || got[2] != "carol" {
+		t.Errorf("expected Alice, bob, carol, got %v", got)
+	}
+
+	collator, err := collatorOf(&options.Collation{Locale: "en", Strength: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sortDocuments(docs, bson.D{{Key: "name", Value: -1}}, collator); err != nil {
+		t.Fatal(err)
+	}
+	if docs[0]["name"] != "carol" || docs[2]["name"] != "Alice" {
+		t.Errorf("expected the collated order carol, bob, Alice, got %v, %v, %v", docs[0]["name"], docs[1]["name"], docs[2]["name"])
+	}
+
+	if err := sortDocuments(docs, bson.M{"name": 1, "grade": 1}, nil); err == nil {
+		t.Error("expected a sort on several keys of a bson.M to fail, got no error")
+	}
+}
+
+func TestProject(t *testing.T) {
+	doc := queryDocument(t)
+	doc["_id"] = "id"
+
+	included, err := project(doc, bson.M{"name": 1, "address.city": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := canonicalFor(t, bson.M{"_id": "id", "name": "Alice", "address": bson.M{"city": "Haifa"}})
+	if !equal(included, want) {
+		t.Errorf("expected %v, got %v", want, included)
+	}
+
+	excluded, err := project(doc, bson.M{"skills": 0, "address.country": 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := excluded["skills"]; ok {
+		t.Error("expected skills to be excluded")
+	}
+	if got := firstValue(excluded, "address.city"); got != "Haifa" {
+		t.Errorf("expected address.city to be kept, got %v", got)
+	}
+	if _, ok := doc["skills"]; !ok {
+		t.Error("expected the projected document to be left unchanged")
+	}
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sqlMigrations holds the schemas of the SQL stores, a directory per database with one file per version, applied in
+// name order.
+//
+//go:embed migrations/*/*.sql
+var sqlMigrations embed.FS
+
+// migration is a version of a schema.
+type migration struct {
+	version string
+	script  string
+}
+
+// migrationsOf returns the migrations of the database, such as postgres or sqlite, in the order they apply.
+func migrationsOf(database string) ([]migration, error) {
+	names, err := fs.Glob(sqlMigrations, "migrations/"+database+"/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	migrations := make([]migration, len(names))
+	for i, name := range names {
+		script, err := sqlMigrations.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		migrations[i] = migration{version: strings.TrimSuffix(path.Base(name), ".sql"), script: string(script)}
+	}
+	return migrations, nil
+}
+
+// migratePostgres applies the migrations the database has not seen yet, recording each in schema_migrations. An
+// advisory lock keeps instances starting together from applying the same migration twice.
+func migratePostgres(ctx context.Context, pool *pgxpool.Pool) error {
+	migrations, err := migrationsOf("postgres")
+	if err != nil {
+		return err
+	}
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('schema_migrations'))`); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    text PRIMARY KEY,
+			applied_at timestamptz NOT NULL DEFAULT now()
+		)`); err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			var applied bool
+			if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, m.version).Scan(&applied); err != nil {
+				return err
+			}
+			if applied {
+				continue
+			}
+			if _, err := tx.Exec(ctx, m.script); err != nil {
+				return fmt.Errorf("migration %s: %w", m.version, err)
+			}
+			if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, m.version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// migrateSQLite applies the migrations the database has not seen yet, recording each in schema_migrations. The
+// database is opened by config.ConnectSQLite, whose transactions take the write lock from their start, so processes
+// opening it together wait for each other.
+func migrateSQLite(ctx context.Context, db *sql.DB) error {
+	migrations, err := migrationsOf("sqlite")
+	if err != nil {
+		return err
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		var applied bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = ?)`, m.version).Scan(&applied); err != nil {
+			return err
+		}
+		if applied {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, m.script); err != nil {
+			return fmt.Errorf("migration %s: %w", m.version, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES (?)`, m.version); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
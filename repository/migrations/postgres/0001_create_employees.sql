@@ -0,0 +1,17 @@
+-- Employees are stored whole as JSON documents, in the relaxed extended JSON of MongoDB, so that the services
+-- address them with the same field names whatever the storage. The email and the internal ID are copied to
+-- columns to be kept unique; the ID is nullable, since employees created before IDs were introduced have none.
+CREATE TABLE employees (
+    seq   bigserial PRIMARY KEY,
+    email text NOT NULL CONSTRAINT employees_email_unique UNIQUE,
+    id    text CONSTRAINT employees_id_unique UNIQUE,
+    doc   jsonb NOT NULL
+);
+
+-- Filters on fields of the document, such as the roles, title or status, use containment.
+CREATE INDEX employees_doc ON employees USING gin (doc jsonb_path_ops);
+
+-- Employees are listed by email, display name or hire date, compared byte by byte as MongoDB does.
+CREATE INDEX employees_email_order ON employees ((doc ->> 'email') COLLATE "C");
+CREATE INDEX employees_display_name_order ON employees ((doc ->> 'displayName') COLLATE "C");
+CREATE INDEX employees_hire_date_order ON employees ((doc ->> 'hireDate') COLLATE "C");
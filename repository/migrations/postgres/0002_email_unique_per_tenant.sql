@@ -0,0 +1,5 @@
+-- Employees belong to a tenant when the deployment serves several organizations, and their emails are only unique
+-- within it. The tenant is copied from the document to a column, empty for the employees without one.
+ALTER TABLE employees ADD COLUMN tenant text NOT NULL DEFAULT '';
+ALTER TABLE employees DROP CONSTRAINT employees_email_unique;
+ALTER TABLE employees ADD CONSTRAINT employees_email_unique UNIQUE (email, tenant);
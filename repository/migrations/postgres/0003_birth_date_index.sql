@@ -0,0 +1,20 @@
+-- Dates are stored in relaxed extended JSON: {"$date": "<ISO 8601 in UTC>"} from 1970 to 9999, and
+-- {"$date": {"$numberLong": "<milliseconds since 1970>"}} otherwise, as for most birth dates before 1970.
+-- employee_date reads either form as a timestamp in UTC, and any other value as NULL. It only uses immutable
+-- functions, parsing the ISO form by its fixed layout rather than casting it, so that it can be indexed.
+CREATE FUNCTION employee_date(value jsonb) RETURNS timestamp
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT CASE jsonb_typeof(value -> '$date')
+        WHEN 'string' THEN make_timestamp(
+            substr(value ->> '$date', 1, 4)::int,
+            substr(value ->> '$date', 6, 2)::int,
+            substr(value ->> '$date', 9, 2)::int,
+            substr(value ->> '$date', 12, 2)::int,
+            substr(value ->> '$date', 15, 2)::int,
+            rtrim(substr(value ->> '$date', 18), 'Z')::float8)
+        WHEN 'object' THEN timestamp '1970-01-01' + (value -> '$date' ->> '$numberLong')::bigint * interval '1 millisecond'
+    END
+$$;
+
+-- Employees of an age are found by the range of their birth dates.
+CREATE INDEX employees_birth_date ON employees (employee_date(doc -> 'birthDate'));
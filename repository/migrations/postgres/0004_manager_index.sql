@@ -0,0 +1,2 @@
+-- Reports are found by the email of their manager when the reporting lines are followed.
+CREATE INDEX employees_manager ON employees ((doc ->> 'manager'));
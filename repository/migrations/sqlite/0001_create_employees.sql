@@ -0,0 +1,9 @@
+-- Employees are stored whole as JSON documents, in the relaxed extended JSON of MongoDB, so that the services
+-- address them with the same field names whatever the storage. The email and the internal ID are copied to
+-- columns to be kept unique; the ID is nullable, since employees created before IDs were introduced have none.
+CREATE TABLE employees (
+    seq   INTEGER PRIMARY KEY AUTOINCREMENT,
+    email TEXT NOT NULL UNIQUE,
+    id    TEXT UNIQUE,
+    doc   TEXT NOT NULL
+);
@@ -0,0 +1,14 @@
+-- Employees belong to a tenant when the deployment serves several organizations, and their emails are only unique
+-- within it. The tenant is copied from the document to a column, empty for the employees without one. SQLite cannot
+-- drop the constraint on the email, so the table is copied.
+CREATE TABLE employees_by_tenant (
+    seq    INTEGER PRIMARY KEY AUTOINCREMENT,
+    email  TEXT NOT NULL,
+    tenant TEXT NOT NULL DEFAULT '',
+    id     TEXT UNIQUE,
+    doc    TEXT NOT NULL,
+    UNIQUE (email, tenant)
+);
+INSERT INTO employees_by_tenant (seq, email, id, doc) SELECT seq, email, id, doc FROM employees;
+DROP TABLE employees;
+ALTER TABLE employees_by_tenant RENAME TO employees;
@@ -0,0 +1,7 @@
+-- Employees are listed by age from a range of birth dates, found by this index. Dates are stored in relaxed
+-- extended JSON: {"$date": "<ISO 8601 in UTC>"} from 1970 to 9999, and {"$date": {"$numberLong": "<milliseconds
+-- since 1970>"}} otherwise, as for most birth dates before 1970; the expression reads either form as milliseconds
+-- since 1970, and must stay as the store writes it in its queries for the index to apply.
+CREATE INDEX employees_birth_date ON employees (
+    CASE json_type(doc -> '$."birthDate"', '$."$date"') WHEN 'text' THEN CAST(round(unixepoch(doc -> '$."birthDate"' ->> '$."$date"', 'subsec') * 1000) AS INTEGER) WHEN 'object' THEN CAST(doc -> '$."birthDate"' ->> '$."$date"."$numberLong"' AS INTEGER) END
+);
@@ -0,0 +1,3 @@
+-- Reports are found by the email of their manager when the reporting lines are followed. The expression must stay as
+-- the store writes it in its queries for the index to apply.
+CREATE INDEX employees_manager ON employees ((doc ->> '$."manager"'));
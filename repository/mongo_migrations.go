@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// MigrationCollection is the name of the collection recording the migrations applied to MongoDB, a document per
+// version, next to the lock held while they apply.
+const MigrationCollection = "migrations"
+
+// migrationLockID is the _id of the lock document in the migrations collection.
+const migrationLockID = "lock"
+
+// migrationLockLease is how long the lock is held without renewal. It is renewed before each migration, so a
+// single migration running longer may start alongside one of another instance; an instance that crashed holding
+// the lock blocks the others no longer than that.
+const migrationLockLease = 10 * time.Minute
+
+// MongoMigration is a change to the data stored in MongoDB, such as a new index, a renamed field or a field
+// backfilled in a new format. It is applied once per database, after the migrations before it.
+type MongoMigration struct {
+	// Version identifies the migration, e.g. 0002_rename_phone; migrations apply in the order of mongoMigrations.
+	Version string
+	// Description says what the migration changes, for the migrate status command.
+	Description string
+	// Up applies the migration to the employee collection, and to the other collections of its database. A failed
+	// Up runs again on the next attempt, over whatever it changed before failing, so it must be idempotent.
+	Up func(ctx context.Context, employees *mongo.Collection) error
+}
+
+// mongoMigrations lists the migrations in the order they apply. A released migration is never edited, reordered or
+// removed: a change to it goes in a new migration at the end.
+var mongoMigrations = []MongoMigration{
+	{Version: "0001_birth_date", Description: "store the birthdate of each employee as a date", Up: backfillBirthDates},
+	{Version: "0002_email_unique_per_tenant", Description: "keep emails unique within each tenant only", Up: dropGlobalEmailIndex},
+}
+
+// migrationBatchSize is how many documents a migration writes at once.
+const migrationBatchSize = 1000
+
+// backfillBirthDates stores the birth date of the employees written before birth dates were. Employees whose
+// birthdate is not a day of the calendar, such as 31/02, are left without one.
+func backfillBirthDates(ctx context.Context, employees *mongo.Collection) error {
+	cursor, err := employees.Find(ctx, bson.M{models.EmployeeRef.BirthDate: bson.M{"$exists": false}},
+		options.Find().SetProjection(bson.M{models.EmployeeRef.Birthdate: 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var writes []mongo.WriteModel
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		_, err := BulkWrite(ctx, employees, writes, migrationBatchSize, false)
+		writes = writes[:0]
+		return err
+	}
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID        any           `bson:"_id"`
+			Birthdate bson.RawValue `bson:"birthdate"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		// Birthdates written out of band may not even hold strings.
+		var birthdate models.Birthdate
+		if err := doc.Birthdate.Unmarshal(&birthdate); err != nil {
+			continue
+		}
+		date, ok := birthdate.Date()
+		if !ok {
+			continue
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": doc.ID}).
+			SetUpdate(bson.M{"$set": bson.M{models.EmployeeRef.BirthDate: date}}))
+		if len(writes) == migrationBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// globalEmailIndex is the name of the index that kept emails unique across every tenant, before they were unique
+// within each tenant only.
+const globalEmailIndex = "email_1"
+
+// indexNotFound is the server error code of dropping an index that does not exist.
+const indexNotFound = 27
+
+// dropGlobalEmailIndex drops the index keeping emails unique across tenants. The index keeping them unique within
+// each tenant is created along with the repository, so emails stay unique among the employees without a tenant.
+func dropGlobalEmailIndex(ctx context.Context, employees *mongo.Collection) error {
+	err := employees.Indexes().DropOne(ctx, globalEmailIndex)
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && (serverErr.HasErrorCode(indexNotFound) || serverErr.HasErrorCode(namespaceNotFound)) {
+		return nil
+	}
+	return err
+}
+
+// MigrationStatus is a migration along with when it was applied, zero when it is pending.
+type MigrationStatus struct {
+	Version     string
+	Description string
+	AppliedAt   time.Time
+}
+
+// MongoMigrator applies the migrations to a database, recording each in the migrations collection. Instances
+// migrating together take turns through a lock, so each migration is applied by one of them.
+type MongoMigrator struct {
+	Employees  *mongo.Collection
+	Collection *mongo.Collection
+	Migrations []MongoMigration
+}
+
+// NewMongoMigrator creates a MongoMigrator applying the migrations of this version to the employee collection and
+// its database.
+func NewMongoMigrator(employees *mongo.Collection) *MongoMigrator {
+	return &MongoMigrator{
+		Employees:  employees,
+		Collection: employees.Database().Collection(MigrationCollection),
+		Migrations: mongoMigrations,
+	}
+}
+
+// applied returns when each recorded migration was applied, by version.
+func (m *MongoMigrator) applied(ctx context.Context) (map[string]time.Time, error) {
+	cursor, err := m.Collection.Find(ctx, bson.M{"_id": bson.M{"$ne": migrationLockID}})
+	if err != nil {
+		return nil, err
+	}
+	var records []struct {
+		Version   string    `bson:"_id"`
+		AppliedAt time.Time `bson:"appliedAt"`
+	}
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	applied := make(map[string]time.Time, len(records))
+	for _, record := range records {
+		applied[record.Version] = record.AppliedAt
+	}
+	return applied, nil
+}
+
+// Status returns every migration in order, with when it was applied.
+func (m *MongoMigrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, len(m.Migrations))
+	for i, migration := range m.Migrations {
+		statuses[i] = MigrationStatus{Version: migration.Version, Description: migration.Description, AppliedAt: applied[migration.Version]}
+	}
+	return statuses, nil
+}
+
+// Pending returns the versions of the migrations not applied yet, in order.
+func (m *MongoMigrator) Pending(ctx context.Context) ([]string, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []string
+	for _, migration := range m.Migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration.Version)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies the pending migrations in order and returns the versions it applied. It stops at the first failing
+// migration, which is left pending along with those after it.
+func (m *MongoMigrator) Up(ctx context.Context) ([]string, error) {
+	owner := bson.NewObjectID().Hex()
+	if err := m.lock(ctx, owner); err != nil {
+		return nil, err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		m.Collection.DeleteOne(ctx, bson.M{"_id": migrationLockID, "owner": owner})
+	}()
+
+	// Migrations applied by another instance while this one waited for the lock are skipped.
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, migration := range m.Migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.lock(ctx, owner); err != nil {
+			return versions, err
+		}
+		if err := migration.Up(ctx, m.Employees); err != nil {
+			return versions, fmt.Errorf("migration %s: %w", migration.Version, err)
+		}
+		if _, err := m.Collection.InsertOne(ctx, bson.M{
+			"_id":         migration.Version,
+			"description": migration.Description,
+			"appliedAt":   time.Now().UTC(),
+		}); err != nil {
+			return versions, err
+		}
+		versions = append(versions, migration.Version)
+	}
+	return versions, nil
+}
+
+// lock takes the lock for owner, or renews it when owner holds it already, waiting while another owner holds it.
+func (m *MongoMigrator) lock(ctx context.Context, owner string) error {
+	for {
+		now := time.Now().UTC()
+		// Once another owner holds an unexpired lock, the filter matches nothing and the upsert collides with the
+		// lock document.
+		_, err := m.Collection.UpdateOne(ctx,
+			bson.M{"_id": migrationLockID, "$or": bson.A{bson.M{"owner": owner}, bson.M{"expiresAt": bson.M{"$lte": now}}}},
+			bson.M{"$set": bson.M{"owner": owner, "expiresAt": now.Add(migrationLockLease)}},
+			options.UpdateOne().SetUpsert(true))
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the migration lock: %w", ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
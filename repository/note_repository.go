@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// NoteCollection is the name of the collection holding employee notes.
+const NoteCollection = "employee_notes"
+
+// NoteRepository encapsulates operations on the employee notes collection.
+type NoteRepository struct {
+	Collection *TenantCollection
+}
+
+// NewNoteRepository creates a new NoteRepository and ensures an index for listing an employee's notes, newest first.
+func NewNoteRepository(client *mongo.Client, dbName string) (*NoteRepository, error) {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "employee", Value: 1}, {Key: "createdAt", Value: -1}},
+	}
+	coll := NewTenantCollection(client.Database(dbName), NoteCollection, indexModel)
+	if err := coll.CreateIndexes(); err != nil {
+		slog.Warn("Failed to create index on employee notes", "error", err)
+		return nil, err
+	}
+
+	return &NoteRepository{
+		Collection: coll,
+	}, nil
+}
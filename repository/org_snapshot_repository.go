@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// OrgSnapshotCollection is the name of the collection holding organization snapshots.
+const OrgSnapshotCollection = "org_snapshots"
+
+// OrgSnapshotRepository encapsulates operations on the organization snapshot collection.
+type OrgSnapshotRepository struct {
+	Collection *TenantCollection
+}
+
+// NewOrgSnapshotRepository creates a new OrgSnapshotRepository. Snapshots are looked up by _id only,
+// so no additional indexes are needed.
+func NewOrgSnapshotRepository(client *mongo.Client, dbName string) *OrgSnapshotRepository {
+	return &OrgSnapshotRepository{
+		Collection: NewTenantCollection(client.Database(dbName), OrgSnapshotCollection),
+	}
+}
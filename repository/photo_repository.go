@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// PhotoBucket is the name of the GridFS bucket holding employee photos.
+const PhotoBucket = "employee_photos"
+
+// PhotoRepository stores employee photos in GridFS. Each variant of an employee's photo is a file
+// named "<email>/<variant>"; uploading a new photo adds a revision and removes the older ones. Each tenant keeps its
+// photos in a bucket of the tenant's database.
+type PhotoRepository struct {
+	// DB holds the bucket of the deployment, used for no tenant.
+	DB *mongo.Database
+}
+
+// photoMetadata is stored with each photo file.
+type photoMetadata struct {
+	ContentType string `bson:"contentType"`
+}
+
+// NewPhotoRepository creates a new PhotoRepository.
+func NewPhotoRepository(client *mongo.Client, dbName string) *PhotoRepository {
+	return &PhotoRepository{
+		DB: client.Database(dbName),
+	}
+}
+
+// bucket returns the bucket of the tenant ctx is made for.
+func (r *PhotoRepository) bucket(ctx context.Context) *mongo.GridFSBucket {
+	return tenantDatabase(ctx, r.DB).GridFSBucket(options.GridFSBucket().SetName(PhotoBucket))
+}
+
+func photoFilename(email, variant string) string {
+	return email + "/" + variant
+}
+
+// Save stores a variant of an employee's photo, replacing the previous one.
+func (r *PhotoRepository) Save(ctx context.Context, email, variant string, photo models.Photo) error {
+	filename := photoFilename(email, variant)
+	bucket := r.bucket(ctx)
+	id, err := bucket.UploadFromStream(ctx, filename, bytes.NewReader(photo.Data),
+		options.GridFSUpload().SetMetadata(photoMetadata{ContentType: photo.ContentType}))
+	if err != nil {
+		return err
+	}
+
+	cursor, err := bucket.Find(ctx, bson.M{"filename": filename, "_id": bson.M{"$ne": id}})
+	if err != nil {
+		return err
+	}
+	var previous []struct {
+		ID bson.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &previous); err != nil {
+		return err
+	}
+	for _, file := range previous {
+		if err := bucket.Delete(ctx, file.ID); err != nil && err != mongo.ErrFileNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load returns the latest revision of a variant of an employee's photo, or mongo.ErrFileNotFound.
+func (r *PhotoRepository) Load(ctx context.Context, email, variant string) (models.Photo, error) {
+	stream, err := r.bucket(ctx).OpenDownloadStreamByName(ctx, photoFilename(email, variant))
+	if err != nil {
+		return models.Photo{}, err
+	}
+	defer stream.Close()
+
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		return models.Photo{}, err
+	}
+	file := stream.GetFile()
+	var metadata photoMetadata
+	if err := bson.Unmarshal(file.Metadata, &metadata); err != nil {
+		return models.Photo{}, err
+	}
+	photo := models.Photo{ContentType: metadata.ContentType, Data: data, UploadedAt: file.UploadDate}
+	if id, ok := file.ID.(bson.ObjectID); ok {
+		photo.ID = id.Hex()
+	}
+	return photo, nil
+}
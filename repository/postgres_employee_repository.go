@@ -0,0 +1,463 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"WebMVCEmployees/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// uniqueViolation is the SQLSTATE of a write violating a unique constraint.
+const uniqueViolation = "23505"
+
+// PostgresEmployeeRepository stores employees in PostgreSQL, for deployments without MongoDB. Each employee is a row
+// holding its document as JSON. The criteria, such as the roles, email domain, skills or age, are translated to SQL,
+// and the employees found are checked against the whole criteria before use. The reporting lines are followed by a
+// recursive query.
+type PostgresEmployeeRepository struct {
+	documentOperations
+	Pool *pgxpool.Pool
+}
+
+// NewPostgresEmployeeRepository creates a PostgresEmployeeRepository and migrates the database to the current schema.
+func NewPostgresEmployeeRepository(pool *pgxpool.Pool) (*PostgresEmployeeRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := migratePostgres(ctx, pool); err != nil {
+		return nil, err
+	}
+	r := &PostgresEmployeeRepository{Pool: pool}
+	r.documentOperations = documentOperations{r}
+	return r, nil
+}
+
+// postgresTransaction marks the context of a transaction with the transaction.
+type postgresTransaction struct{}
+
+// postgresConn is a connection pool or a transaction.
+type postgresConn interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// conn returns the transaction ctx belongs to, or the pool.
+func (r *PostgresEmployeeRepository) conn(ctx context.Context) postgresConn {
+	if tx, ok := ctx.Value(postgresTransaction{}).(pgx.Tx); ok {
+		return tx
+	}
+	return r.Pool
+}
+
+// write runs fn in a transaction, nested in the one ctx belongs to, so that a failed write leaves that one usable.
+func (r *PostgresEmployeeRepository) write(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return pgx.BeginFunc(ctx, r.conn(ctx), fn)
+}
+
+// storedEmployee is a row of the employees table.
+type storedEmployee struct {
+	seq int64
+	doc bson.M
+}
+
+// encodeEmployee returns the columns of the row storing doc.
+func encodeEmployee(doc bson.M) (email, tenant string, id *string, data []byte, err error) {
+	email, _ = doc[models.EmployeeRef.Email].(string)
+	tenant, _ = doc[models.EmployeeRef.TenantID].(string)
+	if value, ok := doc[models.EmployeeRef.ID].(string); ok {
+		id = &value
+	}
+	data, err = bson.MarshalExtJSON(doc, false, false)
+	return email, tenant, id, data, err
+}
+
+// decodeEmployee decodes the document of a row to canonical form.
+func decodeEmployee(data []byte) (bson.M, error) {
+	vr, err := bson.NewExtJSONValueReader(bytes.NewReader(data), false)
+	if err != nil {
+		return nil, err
+	}
+	dec := bson.NewDecoder(vr)
+	dec.DefaultDocumentM()
+	var doc bson.M
+	err = dec.Decode(&doc)
+	return doc, err
+}
+
+// postgresWriteError reports a write violating a unique constraint as a duplicate key error.
+func postgresWriteError(err error, doc bson.M) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolation {
+		return err
+	}
+	field := models.EmployeeRef.Email
+	if pgErr.ConstraintName == "employees_id_unique" {
+		field = models.EmployeeRef.ID
+	}
+	return duplicateKeyError(field, doc[field])
+}
+
+// loadPostgres returns the employees matching the criteria in insertion order, at most limit of them when
+// limit > 0. With forUpdate, their rows stay locked until the transaction of db ends.
+func loadPostgres(ctx context.Context, db postgresConn, criteria EmployeeCriteria, limit int, forUpdate bool) ([]storedEmployee, error) {
+	filter, err := documentFilterOf(criteria)
+	if err != nil {
+		return nil, err
+	}
+	var q sqlQuery
+	cond, exact, err := q.criteria(criteria)
+	if err != nil {
+		return nil, err
+	}
+	sql := "SELECT seq, doc FROM employees WHERE " + cond + " ORDER BY seq"
+	if exact && limit > 0 {
+		sql += " LIMIT " + q.arg(limit)
+	}
+	if forUpdate {
+		sql += " FOR UPDATE"
+	}
+	return queryPostgres(ctx, db, sql, q.args, filter, limit)
+}
+
+// queryPostgres runs a query returning seq and doc columns and keeps the rows matching the filter, at most limit of
+// them when limit > 0.
+func queryPostgres(ctx context.Context, db postgresConn, sql string, args []any, filter documentFilter, limit int) ([]storedEmployee, error) {
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found []storedEmployee
+	for rows.Next() {
+		var row storedEmployee
+		var data []byte
+		if err := rows.Scan(&row.seq, &data); err != nil {
+			return nil, err
+		}
+		if row.doc, err = decodeEmployee(data); err != nil {
+			return nil, err
+		}
+		ok, err := filter.matches(row.doc)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		found = append(found, row)
+		if len(found) == limit {
+			break
+		}
+	}
+	return found, rows.Err()
+}
+
+// docsOf returns the documents of the rows.
+func docsOf(rows []storedEmployee) []bson.M {
+	docs := make([]bson.M, len(rows))
+	for i, row := range rows {
+		docs[i] = row.doc
+	}
+	return docs
+}
+
+// storePostgres writes doc to the row with the sequence number.
+func storePostgres(ctx context.Context, tx pgx.Tx, seq int64, doc bson.M) error {
+	email, tenant, id, data, err := encodeEmployee(doc)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `UPDATE employees SET email = $1, tenant = $2, id = $3, doc = $4 WHERE seq = $5`, email, tenant, id, data, seq)
+	return postgresWriteError(err, doc)
+}
+
+// insertPostgres adds doc as a new row.
+func insertPostgres(ctx context.Context, tx pgx.Tx, doc bson.M) error {
+	email, tenant, id, data, err := encodeEmployee(doc)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO employees (email, tenant, id, doc) VALUES ($1, $2, $3, $4)`, email, tenant, id, data)
+	return postgresWriteError(err, doc)
+}
+
+// Create inserts a new employee.
+func (r *PostgresEmployeeRepository) Create(ctx context.Context, emp models.Employee) error {
+	doc, err := canonicalDocument(emp)
+	if err != nil {
+		return err
+	}
+	doc["_id"] = bson.NewObjectID()
+	return r.write(ctx, func(tx pgx.Tx) error {
+		return insertPostgres(ctx, tx, doc)
+	})
+}
+
+// InsertMany inserts new employees, as EmployeeRepository.InsertMany does, in a single transaction.
+func (r *PostgresEmployeeRepository) InsertMany(ctx context.Context, emps []models.Employee, ordered bool) error {
+	docs := make([]bson.M, len(emps))
+	for i, emp := range emps {
+		doc, err := canonicalDocument(emp)
+		if err != nil {
+			return err
+		}
+		doc["_id"] = bson.NewObjectID()
+		docs[i] = doc
+	}
+	var failures mongo.BulkWriteException
+	err := r.write(ctx, func(tx pgx.Tx) error {
+		for i, doc := range docs {
+			// A violation would abort the whole transaction, so each insert runs in a savepoint of its own.
+			err := pgx.BeginFunc(ctx, tx, func(tx pgx.Tx) error {
+				return insertPostgres(ctx, tx, doc)
+			})
+			if err != nil {
+				if !bulkWriteFailure(&failures, i, err) {
+					return err
+				}
+				if ordered {
+					break
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return bulkWriteError(failures)
+}
+
+// load returns the employees matching the criteria, in insertion order, at most limit of them when limit > 0.
+func (r *PostgresEmployeeRepository) load(ctx context.Context, criteria EmployeeCriteria, limit int) ([]bson.M, error) {
+	found, err := loadPostgres(ctx, r.conn(ctx), criteria, limit, false)
+	return docsOf(found), err
+}
+
+// List returns a page of the employees matching the criteria, without their password. When the criteria and the
+// sort translate to SQL exactly, the employees are sorted and paged by the database; otherwise the employees the SQL
+// condition finds are checked against the criteria, and sorted and paged here.
+func (r *PostgresEmployeeRepository) List(ctx context.Context, criteria EmployeeCriteria, page Page) (employees []models.Employee, err error) {
+	find, err := resolveFindOptions([]options.Lister[options.FindOptions]{page.findOptions()})
+	if err != nil {
+		return nil, err
+	}
+	filter, err := documentFilterOf(criteria)
+	if err != nil {
+		return nil, err
+	}
+	var q sqlQuery
+	cond, exact, err := q.criteria(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := "SELECT seq, doc FROM employees WHERE " + cond
+	// PostgreSQL collations differ from MongoDB's, so lists in a collation are sorted here.
+	if order, ok := orderBy(find.Sort, stringField); exact && ok && find.Collation == nil {
+		sql += " ORDER BY " + order
+		if find.Skip != nil {
+			sql += " OFFSET " + q.arg(*find.Skip)
+		}
+		if find.Limit != nil && *find.Limit > 0 {
+			sql += " LIMIT " + q.arg(*find.Limit)
+		}
+		find.Sort, find.Skip, find.Limit = nil, nil, nil
+	} else {
+		sql += " ORDER BY seq"
+	}
+	found, err := queryPostgres(ctx, r.conn(ctx), sql, q.args, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := applyFindOptions(docsOf(found), find)
+	if err != nil {
+		return nil, err
+	}
+	err = decodeAll(docs, &employees)
+	return employees, err
+}
+
+// Count returns the number of employees matching the criteria, counted by the database when they translate to SQL
+// exactly.
+func (r *PostgresEmployeeRepository) Count(ctx context.Context, criteria EmployeeCriteria) (int64, error) {
+	var q sqlQuery
+	cond, exact, err := q.criteria(criteria)
+	if err != nil {
+		return 0, err
+	}
+	if exact {
+		rows, err := r.conn(ctx).Query(ctx, "SELECT count(*) FROM employees WHERE "+cond, q.args...)
+		if err != nil {
+			return 0, err
+		}
+		return pgx.CollectExactlyOneRow(rows, pgx.RowTo[int64])
+	}
+	filter, err := documentFilterOf(criteria)
+	if err != nil {
+		return 0, err
+	}
+	found, err := queryPostgres(ctx, r.conn(ctx), "SELECT seq, doc FROM employees WHERE "+cond+" ORDER BY seq", q.args, filter, 0)
+	return int64(len(found)), err
+}
+
+// change calls change on the employees matching the criteria and stores those it changed, their rows locked until
+// then. Unlike MongoDB, a write violating a unique index rolls back the writes before it.
+func (r *PostgresEmployeeRepository) change(ctx context.Context, criteria EmployeeCriteria, limit int, change func(emp *models.Employee) bool) (changed []bson.M, err error) {
+	err = r.write(ctx, func(tx pgx.Tx) error {
+		found, err := loadPostgres(ctx, tx, criteria, limit, true)
+		if err != nil {
+			return err
+		}
+		for _, row := range found {
+			doc, err := changeDocument(row.doc, change)
+			if err != nil {
+				return err
+			}
+			if doc == nil {
+				continue
+			}
+			if err := storePostgres(ctx, tx, row.seq, doc); err != nil {
+				return err
+			}
+			changed = append(changed, doc)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// ReplaceEmployee replaces the stored employee having emp's email with emp, as EmployeeRepository.ReplaceEmployee
+// does.
+func (r *PostgresEmployeeRepository) ReplaceEmployee(ctx context.Context, emp models.Employee, version int64, upsert bool) (inserted bool, err error) {
+	doc, err := canonicalDocument(emp)
+	if err != nil {
+		return false, err
+	}
+	err = r.write(ctx, func(tx pgx.Tx) error {
+		found, err := loadPostgres(ctx, tx, replacedBy(emp, version), 1, true)
+		if err != nil {
+			return err
+		}
+		if len(found) > 0 {
+			doc["_id"] = found[0].doc["_id"]
+			return storePostgres(ctx, tx, found[0].seq, doc)
+		}
+		if !upsert {
+			return mongo.ErrNoDocuments
+		}
+		doc["_id"] = bson.NewObjectID()
+		inserted = true
+		return insertPostgres(ctx, tx, doc)
+	})
+	return inserted && err == nil, err
+}
+
+// remove removes the employees matching the criteria.
+func (r *PostgresEmployeeRepository) remove(ctx context.Context, criteria EmployeeCriteria) (deleted int64, err error) {
+	err = r.write(ctx, func(tx pgx.Tx) error {
+		found, err := loadPostgres(ctx, tx, criteria, 0, true)
+		if err != nil || len(found) == 0 {
+			return err
+		}
+		seqs := make([]int64, len(found))
+		for i, row := range found {
+			seqs[i] = row.seq
+		}
+		tag, err := tx.Exec(ctx, `DELETE FROM employees WHERE seq = ANY($1)`, seqs)
+		deleted = tag.RowsAffected()
+		return err
+	})
+	return deleted, err
+}
+
+// walk follows the reporting lines from start through the employees matching through, in a recursive query served
+// by the indexes on the email and the manager. Where through has no exact SQL equivalent, the employees matching it
+// are loaded and walked here.
+func (r *PostgresEmployeeRepository) walk(ctx context.Context, start bson.M, through EmployeeCriteria, up bool, maxDepth int) ([]reached, error) {
+	var q sqlQuery
+	cond, exact, err := q.criteria(through)
+	if err != nil {
+		return nil, err
+	}
+	if !exact {
+		found, err := loadPostgres(ctx, r.conn(ctx), through, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		return walkDocuments(start, docsOf(found), up, maxDepth), nil
+	}
+	// Each step connects the value of from, in the employees reached, to the value of to in the next ones.
+	startField, from, to := models.EmployeeRef.Email, "email", postgresManager
+	if up {
+		startField, from, to = models.EmployeeRef.Manager, postgresManager, "email"
+	}
+	startAt, ok := start[startField].(string)
+	if !ok {
+		return nil, nil
+	}
+	// The path lists the employees on the way, so that a stored cycle ends the walk.
+	limit := ""
+	if maxDepth > 0 {
+		limit = " AND walk.depth < " + q.arg(maxDepth) + "::int"
+	}
+	sql := fmt.Sprintf(`WITH RECURSIVE walk(walked, connect, depth, path) AS (
+	SELECT seq, %[1]s, 1, ARRAY[seq] FROM employees WHERE %[2]s = %[3]s::text AND %[4]s
+	UNION ALL
+	SELECT employees.seq, %[1]s, walk.depth + 1, walk.path || employees.seq
+	FROM walk JOIN employees ON %[2]s = walk.connect
+	WHERE %[4]s AND employees.seq <> ALL(walk.path)%[5]s
+)
+SELECT employees.doc, reached.depth FROM (SELECT walked, min(depth) AS depth FROM walk GROUP BY walked) AS reached
+JOIN employees ON employees.seq = reached.walked ORDER BY employees.seq`, from, to, q.arg(startAt), cond, limit)
+	rows, err := r.conn(ctx).Query(ctx, sql, q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var walked []reached
+	for rows.Next() {
+		var data []byte
+		var depth int
+		if err := rows.Scan(&data, &depth); err != nil {
+			return nil, err
+		}
+		doc, err := decodeEmployee(data)
+		if err != nil {
+			return nil, err
+		}
+		walked = append(walked, reached{doc: doc, depth: depth})
+	}
+	return walked, rows.Err()
+}
+
+// postgresManager is the SQL of the manager of an employee, as the index on it is defined.
+const postgresManager = `(doc ->> 'manager')`
+
+// InTransaction runs fn in a transaction, rolled back when fn fails. A transaction started within fn joins the one in
+// progress.
+func (r *PostgresEmployeeRepository) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(postgresTransaction{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+	return pgx.BeginFunc(ctx, r.Pool, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, postgresTransaction{}, tx))
+	})
+}
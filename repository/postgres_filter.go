@@ -0,0 +1,400 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// stringFields lists the top-level employee fields that hold a string whenever they are set, so that regular
+// expressions and orderings on them translate to SQL exactly.
+var stringFields = map[string]bool{
+	models.EmployeeRef.ID:            true,
+	models.EmployeeRef.TenantID:      true,
+	models.EmployeeRef.Email:         true,
+	models.EmployeeRef.Name:          true,
+	models.EmployeeRef.PreferredName: true,
+	models.EmployeeRef.DisplayName:   true,
+	models.EmployeeRef.Manager:       true,
+	models.EmployeeRef.Department:    true,
+	models.EmployeeRef.HireDate:      true,
+	models.EmployeeRef.Title:         true,
+	models.EmployeeRef.Grade:         true,
+	models.EmployeeRef.Location:      true,
+	models.EmployeeRef.CostCenter:    true,
+	models.EmployeeRef.Timezone:      true,
+	models.EmployeeRef.Locale:        true,
+	models.EmployeeRef.Status:        true,
+}
+
+// sqlQuery accumulates the arguments of a SQL statement.
+type sqlQuery struct {
+	args []any
+}
+
+// arg adds an argument and returns its placeholder.
+func (q *sqlQuery) arg(v any) string {
+	q.args = append(q.args, v)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+// field adds a field name as a text argument, since jsonb -> also takes array indexes.
+func (q *sqlQuery) field(key string) string {
+	return q.arg(key) + "::text"
+}
+
+// stringField returns the text of one of the stringFields, compared byte by byte as MongoDB does. The name
+// is spelled out rather than passed as an argument, so that the indexes on the field apply.
+func stringField(key string) string {
+	return `(doc ->> '` + key + `') COLLATE "C"`
+}
+
+// jsonField returns the SQL of a top-level field of the doc column, its name spelled out so that the indexes on the
+// field, such as the one on the birth date, apply.
+func jsonField(key string) string {
+	return `doc -> '` + strings.ReplaceAll(key, "'", "''") + `'`
+}
+
+// postgresDate returns the SQL of the date held by a JSON value, as a timestamp in UTC, or NULL when it holds none.
+// employee_date is created by the migrations, since dates are stored in either of two forms.
+func postgresDate(value string) string {
+	return "employee_date(" + value + ")"
+}
+
+// timestamp adds a time as a timestamp argument, in UTC as postgresDate returns dates.
+func (q *sqlQuery) timestamp(t time.Time) string {
+	return q.arg(t.UTC()) + "::timestamp"
+}
+
+// contains adds a JSON value as an argument and returns the condition that the JSON value at expr contains it. On
+// the doc column, the GIN index on the column serves it.
+func (q *sqlQuery) contains(expr string, value map[string]any) string {
+	data, _ := json.Marshal(value) // Documents of strings always encode.
+	return expr + " @> " + q.arg(string(data)) + "::jsonb"
+}
+
+// elements returns the SQL of the elements of a JSON array, none when the value is not an array.
+func elements(value string) string {
+	return "jsonb_array_elements(CASE jsonb_typeof(" + value + ") WHEN 'array' THEN " + value + " ELSE '[]' END)"
+}
+
+// criteria translates employee criteria to a SQL condition on the doc column. The criteria on nested fields and the
+// age are spelled out here; the others are translated from their filter by where, and are exact unless an example
+// employee sets fields without a SQL equivalent.
+func (q *sqlQuery) criteria(c EmployeeCriteria) (cond string, exact bool, err error) {
+	rest := c
+	rest.City = ""
+	rest.Skill, rest.MinSkillLevel = "", 0
+	rest.Age = nil
+	rest.DelegatedTo, rest.DelegatedAt = "", time.Time{}
+	rest.RolesExpiredBy, rest.DeletedBy = time.Time{}, time.Time{}
+	filter, err := filterOf(rest.Filter())
+	if err != nil {
+		return "", false, err
+	}
+	cond, exact = q.where(filter)
+	conds := []string{cond}
+
+	if c.City != "" {
+		address := jsonField(models.EmployeeRef.Address)
+		conds = append(conds, fmt.Sprintf("jsonb_typeof(%s -> 'city') = 'string' AND (%s ->> 'city') ~* %s::text",
+			address, address, q.arg("^"+regexp.QuoteMeta(c.City)+"$")))
+	}
+	if c.Skill != "" {
+		skills := jsonField(models.EmployeeRef.Skills)
+		conds = append(conds,
+			q.contains("doc", map[string]any{models.EmployeeRef.Skills: []any{map[string]any{"name": c.Skill}}}),
+			fmt.Sprintf("EXISTS (SELECT FROM %s AS skill WHERE %s AND CASE jsonb_typeof(skill -> 'level') WHEN 'number' THEN (skill ->> 'level')::numeric >= %s::int END)",
+				elements(skills), q.contains("skill", map[string]any{"name": c.Skill}), q.arg(c.MinSkillLevel)))
+	}
+	if c.Age != nil {
+		// The range of birth dates is served by the index on them; the age itself is computed as ageOf does,
+		// from the birth date in UTC and today where the employee lives.
+		born := postgresDate(jsonField(models.EmployeeRef.BirthDate))
+		after, by := bornBetween(c.Age.Years, c.Age.At)
+		today := fmt.Sprintf("(%s::timestamptz AT TIME ZONE coalesce(doc ->> '%s', 'UTC'))", q.arg(c.Age.At.UTC()), models.EmployeeRef.Timezone)
+		conds = append(conds,
+			fmt.Sprintf("%s > %s AND %s <= %s", born, q.timestamp(after), born, q.timestamp(by)),
+			fmt.Sprintf("date_part('year', %[2]s) - date_part('year', %[1]s) - CASE WHEN date_part('month', %[1]s) * 100 + date_part('day', %[1]s) > date_part('month', %[2]s) * 100 + date_part('day', %[2]s) THEN 1 ELSE 0 END = %[3]s::int",
+				born, today, q.arg(c.Age.Years)))
+	}
+	if c.DelegatedTo != "" {
+		delegation := jsonField(models.EmployeeRef.Delegation)
+		conds = append(conds, fmt.Sprintf("%s AND %s <= %s AND %s > %s",
+			q.contains("doc", map[string]any{models.EmployeeRef.Delegation: map[string]any{"delegate": c.DelegatedTo}}),
+			postgresDate(delegation+" -> 'startsAt'"), q.timestamp(c.DelegatedAt), postgresDate(delegation+" -> 'endsAt'"), q.timestamp(c.DelegatedAt)))
+	}
+	if !c.RolesExpiredBy.IsZero() {
+		conds = append(conds, fmt.Sprintf("EXISTS (SELECT FROM %s AS role WHERE %s <= %s)",
+			elements(jsonField(models.EmployeeRef.TemporaryRoles)), postgresDate("role -> 'expiresAt'"), q.timestamp(c.RolesExpiredBy)))
+	}
+	if !c.DeletedBy.IsZero() {
+		conds = append(conds, fmt.Sprintf("%s <= %s", postgresDate(jsonField(models.EmployeeRef.DeletedAt)), q.timestamp(c.DeletedBy)))
+	}
+	return strings.Join(conds, " AND "), exact, nil
+}
+
+// kept returns a condition, dropping the arguments added since mark when the condition is empty, left out of the
+// statement, since PostgreSQL cannot tell the types of arguments left unused.
+func (q *sqlQuery) kept(mark int, c string) string {
+	if c == "" {
+		q.args = q.args[:mark]
+	}
+	return c
+}
+
+// where translates a filter to a SQL condition on the doc column. The condition holds for every employee matching
+// the filter, but may hold for others too where an operator has no SQL equivalent; exact reports whether it holds
+// for those employees only. Employees found with an inexact condition are filtered again by matches.
+func (q *sqlQuery) where(filter bson.M) (cond string, exact bool) {
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	exact = true
+	var conds []string
+	for _, key := range keys {
+		c, ok := q.clause(key, filter[key])
+		exact = exact && ok
+		if c != "" {
+			conds = append(conds, c)
+		}
+	}
+	if len(conds) == 0 {
+		return "TRUE", exact
+	}
+	return strings.Join(conds, " AND "), exact
+}
+
+// clause translates a single key of a filter, returning an empty condition when it has no SQL equivalent.
+func (q *sqlQuery) clause(key string, cond any) (string, bool) {
+	switch key {
+	case "$and", "$or":
+		clauses, _ := cond.(bson.A)
+		exact := true
+		var conds []string
+		for _, clause := range clauses {
+			sub, _ := clause.(bson.M)
+			c, ok := q.where(sub)
+			exact = exact && ok
+			conds = append(conds, "("+c+")")
+		}
+		if len(conds) == 0 {
+			return "", false
+		}
+		if key == "$and" {
+			return strings.Join(conds, " AND "), exact
+		}
+		return "(" + strings.Join(conds, " OR ") + ")", exact
+	}
+	if strings.HasPrefix(key, "$") {
+		return "", false
+	}
+
+	ops, isOps := isOperatorDocument(cond)
+	if !isOps {
+		mark := len(q.args)
+		c, ok := q.equals(key, cond)
+		return q.kept(mark, c), ok
+	}
+	opNames := make([]string, 0, len(ops))
+	for op := range ops {
+		opNames = append(opNames, op)
+	}
+	sort.Strings(opNames)
+
+	exact := true
+	var conds []string
+	for _, op := range opNames {
+		if op == "$options" {
+			continue
+		}
+		mark := len(q.args)
+		c, ok := q.operator(key, op, ops[op], ops)
+		exact = exact && ok
+		if c = q.kept(mark, c); c != "" {
+			conds = append(conds, c)
+		}
+	}
+	return strings.Join(conds, " AND "), exact
+}
+
+// scalarJSON encodes a string, number or boolean as JSON, reporting false for other values.
+func scalarJSON(v any) (string, bool) {
+	switch v.(type) {
+	case string, bool, int32, int64, float64:
+		data, err := json.Marshal(v)
+		return string(data), err == nil
+	}
+	return "", false
+}
+
+// equals translates the equality of a field with a value: the field holds the value, or is an array holding it.
+// Null also matches a missing top-level field.
+func (q *sqlQuery) equals(key string, value any) (string, bool) {
+	parts := strings.Split(key, ".")
+	if value == nil {
+		if len(parts) > 1 {
+			return "", false
+		}
+		field := q.field(key)
+		return fmt.Sprintf("((doc -> %s) IS NULL OR (doc -> %s) = 'null' OR (doc -> %s) @> '[null]')", field, field, field), true
+	}
+	if _, ok := scalarJSON(value); !ok {
+		return "", false
+	}
+	var conds []string
+	for _, doc := range containing(parts, value) {
+		conds = append(conds, q.contains("doc", doc))
+	}
+	return "(" + strings.Join(conds, " OR ") + ")", true
+}
+
+// containing returns the documents, one of which an employee contains when the field at the path equals the value.
+// Each field on the path may hold the next document or an array holding it, and the last one the value or an array
+// holding it, since MongoDB searches arrays element by element.
+func containing(parts []string, value any) []map[string]any {
+	values := []any{value, []any{value}}
+	if len(parts) > 1 {
+		values = nil
+		for _, doc := range containing(parts[1:], value) {
+			values = append(values, doc, []any{doc})
+		}
+	}
+	docs := make([]map[string]any, len(values))
+	for i, v := range values {
+		docs[i] = map[string]any{parts[0]: v}
+	}
+	return docs
+}
+
+// notEquals negates equals, where a NULL result, as for a missing field, counts as false.
+func (q *sqlQuery) notEquals(key string, value any) (string, bool) {
+	c, ok := q.equals(key, value)
+	if !ok {
+		return "", false
+	}
+	return "NOT coalesce(" + c + ", false)", true
+}
+
+func (q *sqlQuery) operator(key, op string, arg any, ops bson.M) (string, bool) {
+	// Only equalities translate on dotted paths, which may run through arrays.
+	if strings.Contains(key, ".") && op != "$eq" && op != "$ne" && op != "$in" && op != "$nin" && op != "$all" {
+		return "", false
+	}
+	switch op {
+	case "$eq":
+		return q.equals(key, arg)
+	case "$ne":
+		return q.notEquals(key, arg)
+	case "$in", "$nin":
+		list, _ := arg.(bson.A)
+		var conds []string
+		for _, value := range list {
+			c, ok := q.equals(key, value)
+			if !ok {
+				return "", false
+			}
+			conds = append(conds, c)
+		}
+		c := "FALSE"
+		if len(conds) > 0 {
+			c = "(" + strings.Join(conds, " OR ") + ")"
+		}
+		if op == "$nin" {
+			return "NOT coalesce(" + c + ", false)", true
+		}
+		return c, true
+	case "$all":
+		list, _ := arg.(bson.A)
+		if len(list) == 0 {
+			return "FALSE", true
+		}
+		var conds []string
+		for _, value := range list {
+			c, ok := q.equals(key, value)
+			if !ok || value == nil {
+				return "", false
+			}
+			conds = append(conds, c)
+		}
+		return strings.Join(conds, " AND "), true
+	case "$exists":
+		if truthy(arg) {
+			return "doc ? " + q.field(key), true
+		}
+		return "NOT doc ? " + q.field(key), true
+	case "$regex":
+		pattern, ok := arg.(string)
+		if !ok || !stringFields[key] {
+			return "", false
+		}
+		match := "~"
+		switch ops["$options"] {
+		case nil, "":
+		case "i":
+			match = "~*"
+		default:
+			return "", false
+		}
+		return fmt.Sprintf("%s %s %s::text", stringField(key), match, q.arg(pattern)), true
+	case "$gt", "$gte", "$lt", "$lte":
+		comparison := map[string]string{"$gt": ">", "$gte": ">=", "$lt": "<", "$lte": "<="}[op]
+		// Dates only compare with dates, as in MongoDB, since postgresDate is NULL for other values.
+		if date, ok := arg.(bson.DateTime); ok {
+			return fmt.Sprintf("%s %s %s", postgresDate(jsonField(key)), comparison, q.timestamp(date.Time())), true
+		}
+		value, ok := arg.(string)
+		if !ok || !stringFields[key] {
+			return "", false
+		}
+		return fmt.Sprintf("%s %s %s::text", stringField(key), comparison, q.arg(value)), true
+	}
+	return "", false
+}
+
+// orderBy translates a sort specification to an ORDER BY clause, with insertion order breaking ties, where field
+// returns the SQL of the text of a field. It reports false when the specification sorts on fields that are not known
+// to hold strings, which are sorted by sortDocuments.
+func orderBy(spec any, field func(key string) string) (string, bool) {
+	var keys bson.D
+	switch t := spec.(type) {
+	case nil:
+	case bson.D:
+		keys = t
+	case bson.M:
+		if len(t) > 1 {
+			return "", false
+		}
+		for key, direction := range t {
+			keys = append(keys, bson.E{Key: key, Value: direction})
+		}
+	default:
+		return "", false
+	}
+
+	var terms []string
+	for _, key := range keys {
+		direction, ok := toNumber(key.Value)
+		if !ok || !stringFields[key.Key] {
+			return "", false
+		}
+		// MongoDB orders missing fields before strings, and compares strings byte by byte.
+		if direction < 0 {
+			terms = append(terms, field(key.Key)+" DESC NULLS LAST")
+		} else {
+			terms = append(terms, field(key.Key)+" ASC NULLS FIRST")
+		}
+	}
+	return strings.Join(append(terms, "seq"), ", "), true
+}
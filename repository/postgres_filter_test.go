@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/models"
+)
+
+// These tests check the SQL the PostgreSQL store builds without a database; the store itself runs the conformance
+// tests in tests/ when POSTGRES_URL is set.
+
+func TestSQLQueryCriteria_TranslatesExactly(t *testing.T) {
+	at := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.FixedZone("IDT", 3*60*60))
+	tests := []struct {
+		name     string
+		criteria EmployeeCriteria
+		want     []string
+	}{
+		{"listed", EmployeeCriteria{}, []string{"doc -> $1::text", "NOT coalesce("}},
+		{"roles", EmployeeCriteria{Roles: [][]string{{"admin", "owner"}, {"user"}}}, []string{"doc @> $"}},
+		{"email domain", EmployeeCriteria{EmailDomain: "example.com"}, []string{`(doc ->> 'email') COLLATE "C" ~* $`}},
+		{"emails", EmployeeCriteria{State: AnyState, Emails: []string{"a@x.com"}, ExceptEmail: "b@x.com"}, []string{"doc @> $"}},
+		{"managers and status", EmployeeCriteria{State: Active, Managers: []string{"m@x.com"}, Status: models.StatusActive}, []string{"doc @> $"}},
+		{"missing ID", EmployeeCriteria{State: AnyState, MissingID: true}, []string{"NOT doc ? $"}},
+		{"catalog fields", EmployeeCriteria{Department: "R&D", Title: "Engineer", Grade: "L4", Location: "HQ", CostCenter: "CC1"}, []string{"doc @> $"}},
+		{"tenure", EmployeeCriteria{HiredAfter: "2020-01-01", HiredBy: "2024-01-01"}, []string{`(doc ->> 'hireDate') COLLATE "C" >`}},
+		{"country", EmployeeCriteria{Country: "IL"}, []string{"doc @> $"}},
+		{"city", EmployeeCriteria{City: "Tel Aviv"}, []string{"(doc -> 'address' ->> 'city') ~* $"}},
+		{"skill", EmployeeCriteria{Skill: "go", MinSkillLevel: 3}, []string{"jsonb_array_elements(CASE jsonb_typeof(doc -> 'skills')", "(skill ->> 'level')::numeric >= $"}},
+		{"age", EmployeeCriteria{Age: &AgeCriterion{Years: 36, At: at}}, []string{"employee_date(doc -> 'birthDate') > $", "coalesce(doc ->> 'timezone', 'UTC')"}},
+		{"delegation", EmployeeCriteria{State: Active, DelegatedTo: "d@x.com", DelegatedAt: at}, []string{"employee_date(doc -> 'delegation' -> 'startsAt') <= $"}},
+		{"expired roles", EmployeeCriteria{State: AnyState, RolesExpiredBy: at}, []string{"employee_date(role -> 'expiresAt') <= $"}},
+		{"deleted by", EmployeeCriteria{State: Deleted, DeletedBy: at}, []string{"employee_date(doc -> 'deletedAt') <= $"}},
+		{"example", EmployeeCriteria{State: Active, Example: &models.Employee{Name: "Ann", Address: &models.Address{City: "Haifa"}, Metadata: map[string]string{"team": "core"}}}, []string{"doc @> $"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q sqlQuery
+			cond, exact, err := q.criteria(tt.criteria)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !exact {
+				t.Errorf("expected the criteria to translate exactly, got %s", cond)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(cond, want) {
+					t.Errorf("expected the condition to contain %q, got %s", want, cond)
+				}
+			}
+			if placeholders := strings.Count(cond, "$"); placeholders < len(q.args) {
+				t.Errorf("expected every one of the %d arguments to be used, got %s", len(q.args), cond)
+			}
+			for _, arg := range q.args {
+				if ts, ok := arg.(time.Time); ok && ts.Location() != time.UTC {
+					t.Errorf("expected the times to be passed in UTC, got %v", ts)
+				}
+			}
+		})
+	}
+}
+
+func TestSQLQueryCriteria_InexactExample(t *testing.T) {
+	var q sqlQuery
+	example := models.Employee{Skills: []models.Skill{{Name: "go", Level: 3}}}
+	if _, exact, err := q.criteria(EmployeeCriteria{Example: &example}); err != nil || exact {
+		t.Errorf("expected the skills of an example to be checked after the query, got exact %v and error %v", exact, err)
+	}
+}
+
+func TestSQLQueryCriteria_AgeUsesTheBirthDateIndex(t *testing.T) {
+	migrations, err := migrationsOf("postgres")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var script string
+	for _, m := range migrations {
+		script += m.script
+	}
+	indexed := postgresDate(jsonField(models.EmployeeRef.BirthDate))
+	if !strings.Contains(script, "ON employees ("+indexed+")") {
+		t.Fatalf("expected a migration to index %s", indexed)
+	}
+
+	var q sqlQuery
+	cond, _, err := q.criteria(EmployeeCriteria{Age: &AgeCriterion{Years: 30, At: time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(cond, indexed+" > ") || !strings.Contains(cond, indexed+" <= ") {
+		t.Errorf("expected the birth dates to be bounded on %s, got %s", indexed, cond)
+	}
+	after, by := bornBetween(30, time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC))
+	if !containsArg(q.args, after) || !containsArg(q.args, by) {
+		t.Errorf("expected the bounds %v and %v among the arguments, got %v", after, by, q.args)
+	}
+}
+
+// containsArg reports whether a time is among the arguments of a query.
+func containsArg(args []any, t time.Time) bool {
+	for _, arg := range args {
+		if ts, ok := arg.(time.Time); ok && ts.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestContaining(t *testing.T) {
+	if got := containing([]string{"roles"}, "admin"); len(got) != 2 {
+		t.Errorf("expected a top-level field to hold the value or an array of it, got %v", got)
+	}
+	// address.city: address holds a document or an array of them, and city the value or an array of it.
+	got := containing([]string{"address", "city"}, "Haifa")
+	if len(got) != 4 {
+		t.Fatalf("expected 4 documents, got %v", got)
+	}
+	arrays := 0
+	for _, doc := range got {
+		if _, ok := doc["address"].([]any); ok {
+			arrays++
+		}
+	}
+	if arrays != 2 {
+		t.Errorf("expected 2 of the documents to hold address as an array, got %v", got)
+	}
+}
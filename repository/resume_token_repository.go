@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ResumeTokenCollection is the name of the collection holding the positions of the change stream consumers.
+const ResumeTokenCollection = "change_stream_tokens"
+
+// ResumeTokenRepository stores how far each consumer has read a change stream, so that it resumes from there after
+// a restart.
+type ResumeTokenRepository struct {
+	Collection *mongo.Collection
+}
+
+// NewResumeTokenRepository creates a new ResumeTokenRepository.
+func NewResumeTokenRepository(client *mongo.Client, dbName string) *ResumeTokenRepository {
+	return &ResumeTokenRepository{
+		Collection: client.Database(dbName).Collection(ResumeTokenCollection),
+	}
+}
+
+// Load returns the resume token saved by the consumer, or nil when it has none.
+func (r *ResumeTokenRepository) Load(ctx context.Context, consumer string) (bson.Raw, error) {
+	var saved struct {
+		Token bson.Raw `bson:"token"`
+	}
+	err := r.Collection.FindOne(ctx, bson.M{"_id": consumer}).Decode(&saved)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return saved.Token, nil
+}
+
+// Save records the resume token of the consumer.
+func (r *ResumeTokenRepository) Save(ctx context.Context, consumer string, token bson.Raw) error {
+	_, err := r.Collection.UpdateOne(ctx, bson.M{"_id": consumer},
+		bson.M{"$set": bson.M{"token": token, "savedAt": time.Now().UTC()}},
+		options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+// Delete forgets the resume token of the consumer, which then starts over from the changes to come.
+func (r *ResumeTokenRepository) Delete(ctx context.Context, consumer string) error {
+	_, err := r.Collection.DeleteOne(ctx, bson.M{"_id": consumer})
+	return err
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// RetryPolicy runs operations failing on a transient error, such as a dropped connection or a primary election,
+// again after a delay growing exponentially, with jitter, so that brief outages are not reported to clients.
+type RetryPolicy struct {
+	// Attempts is the number of times an operation runs at most; one or less disables retries.
+	Attempts int
+	// BaseDelay is the delay before the second attempt, on average; each later attempt waits twice as long.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy runs operations up to three times, waiting 100ms and then 200ms on average in between.
+var DefaultRetryPolicy = RetryPolicy{Attempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+// Server error codes of operations refused by a server that is not, or no longer, the primary. The operation did
+// not run, so even a write can run again.
+var refusedCodes = []int{
+	10107, // NotWritablePrimary
+	13435, // NotPrimaryNoSecondaryOk
+	13436, // NotPrimaryOrSecondary
+}
+
+// Server error codes of operations interrupted by an election or a shutdown. A write may have been partly applied.
+var interruptedCodes = []int{
+	6,     // HostUnreachable
+	7,     // HostNotFound
+	89,    // NetworkTimeout
+	91,    // ShutdownInProgress
+	189,   // PrimarySteppedDown
+	9001,  // SocketException
+	11600, // InterruptedAtShutdown
+	11602, // InterruptedDueToReplStateChange
+}
+
+// transient reports whether an operation failing with err may succeed if run again. Writes, which need not be
+// idempotent, only run again when the server refused them.
+func transient(err error, write bool) bool {
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		for _, code := range refusedCodes {
+			if serverErr.HasErrorCode(code) {
+				return true
+			}
+		}
+		if !write {
+			for _, code := range interruptedCodes {
+				if serverErr.HasErrorCode(code) {
+					return true
+				}
+			}
+		}
+	}
+	return !write && mongo.IsNetworkError(err)
+}
+
+// do runs op, and runs it again while it fails on a transient error, attempts remain and the deadline of ctx leaves
+// time for the delay. Operations in a transaction are not run again on their own: the transaction is retried whole.
+func (p RetryPolicy) do(ctx context.Context, write bool, op func() error) error {
+	err := op()
+	if mongo.SessionFromContext(ctx) != nil {
+		return err
+	}
+	delay := p.BaseDelay
+	for attempt := 1; attempt < p.Attempts && err != nil && ctx.Err() == nil && transient(err, write); attempt++ {
+		wait := delay/2 + rand.N(delay/2+1)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		delay = min(2*delay, p.MaxDelay)
+		err = op()
+	}
+	return err
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// fastRetries keeps the delays of the tests short.
+var fastRetries = RetryPolicy{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: 4 * time.Millisecond}
+
+func TestTransient(t *testing.T) {
+	notPrimary := mongo.CommandError{Code: 10107, Name: "NotWritablePrimary"}
+	steppedDown := mongo.CommandError{Code: 189, Name: "PrimarySteppedDown"}
+	network := mongo.CommandError{Message: "connection reset", Labels: []string{"NetworkError"}}
+	duplicate := mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000, Message: "duplicate key"}}}
+
+	tests := []struct {
+		name  string
+		err   error
+		read  bool
+		write bool
+	}{
+		{"refused by a secondary", notPrimary, true, true},
+		{"refused, wrapped", fmt.Errorf("insert: %w", notPrimary), true, true},
+		{"interrupted by an election", steppedDown, true, false},
+		{"network error", network, true, false},
+		{"duplicate key", duplicate, false, false},
+		{"no document", mongo.ErrNoDocuments, false, false},
+		{"deadline exceeded", context.DeadlineExceeded, false, false},
+		{"other error", errors.New("invalid filter"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transient(tt.err, false); got != tt.read {
+				t.Errorf("expected a read failing on it to be retried: %v, got %v", tt.read, got)
+			}
+			if got := transient(tt.err, true); got != tt.write {
+				t.Errorf("expected a write failing on it to be retried: %v, got %v", tt.write, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_RetriesTransientErrors(t *testing.T) {
+	calls := 0
+	err := fastRetries.do(context.Background(), false, func() error {
+		calls++
+		if calls < 3 {
+			return mongo.CommandError{Code: 189, Name: "PrimarySteppedDown"}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryPolicy_StopsOnOtherErrors(t *testing.T) {
+	duplicate := mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000, Message: "duplicate key"}}}
+	calls := 0
+	err := fastRetries.do(context.Background(), true, func() error {
+		calls++
+		return duplicate
+	})
+	if !mongo.IsDuplicateKeyError(err) {
+		t.Fatalf("expected the duplicate key error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single attempt, got %d", calls)
+	}
+}
+
+func TestRetryPolicy_StopsAtTheAttemptLimit(t *testing.T) {
+	for _, attempts := range []int{0, 1, 2, 5} {
+		t.Run(fmt.Sprint(attempts, " attempts"), func(t *testing.T) {
+			policy := fastRetries
+			policy.Attempts = attempts
+			calls := 0
+			err := policy.do(context.Background(), true, func() error {
+				calls++
+				return mongo.CommandError{Code: 10107, Name: "NotWritablePrimary", Message: fmt.Sprint("attempt ", calls)}
+			})
+			var last mongo.CommandError
+			if !errors.As(err, &last) || last.Message != fmt.Sprint("attempt ", calls) {
+				t.Fatalf("expected the error of the last attempt, got %v", err)
+			}
+			if want := max(attempts, 1); calls != want {
+				t.Errorf("expected %d attempts, got %d", want, calls)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_StopsBeforeTheDeadline(t *testing.T) {
+	// The delay before the second attempt, 50ms at least, would outlast the deadline.
+	policy := RetryPolicy{Attempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	calls := 0
+	start := time.Now()
+	policy.do(ctx, false, func() error {
+		calls++
+		return mongo.CommandError{Code: 189, Name: "PrimarySteppedDown"}
+	})
+	if calls != 1 {
+		t.Errorf("expected a single attempt, got %d", calls)
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("expected to give up at once, took %s", elapsed)
+	}
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ReviewCollection is the name of the collection holding performance reviews.
+const ReviewCollection = "reviews"
+
+// ReviewRepository encapsulates operations on the reviews collection.
+type ReviewRepository struct {
+	Collection *TenantCollection
+}
+
+// NewReviewRepository creates a new ReviewRepository and ensures a unique index allowing one review per employee
+// per period, which also serves listing an employee's reviews by period.
+func NewReviewRepository(client *mongo.Client, dbName string) (*ReviewRepository, error) {
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "employee", Value: 1}, {Key: "periodEnd", Value: -1}, {Key: "periodStart", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	}
+	coll := NewTenantCollection(client.Database(dbName), ReviewCollection, indexModel)
+	if err := coll.CreateIndexes(); err != nil {
+		slog.Warn("Failed to create index on reviews", "error", err)
+		return nil, err
+	}
+
+	return &ReviewRepository{
+		Collection: coll,
+	}, nil
+}
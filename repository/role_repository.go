@@ -0,0 +1,19 @@
+package repository
+
+import "go.mongodb.org/mongo-driver/v2/mongo"
+
+// RoleCollection is the name of the collection holding the roles catalog.
+const RoleCollection = "roles"
+
+// RoleRepository encapsulates operations on the roles collection.
+// Roles are keyed by name, so the _id index keeps names unique.
+type RoleRepository struct {
+	Collection *TenantCollection
+}
+
+// NewRoleRepository creates a new RoleRepository.
+func NewRoleRepository(client *mongo.Client, dbName string) *RoleRepository {
+	return &RoleRepository{
+		Collection: NewTenantCollection(client.Database(dbName), RoleCollection),
+	}
+}
@@ -0,0 +1,456 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// SQLiteEmployeeRepository stores employees in an SQLite database file, for single-binary deployments and local
+// development. Like PostgresEmployeeRepository, it keeps each employee as a JSON document and translates criteria to
+// SQL on the document, checking the employees found against the criteria where they have no SQL equivalent. The
+// reporting lines are followed by a recursive query.
+//
+// The database must be opened by config.ConnectSQLite, so that concurrent transactions wait for each other.
+type SQLiteEmployeeRepository struct {
+	documentOperations
+	DB *sql.DB
+}
+
+// NewSQLiteEmployeeRepository creates a SQLiteEmployeeRepository and migrates the database to the current schema.
+func NewSQLiteEmployeeRepository(db *sql.DB) (*SQLiteEmployeeRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := migrateSQLite(ctx, db); err != nil {
+		return nil, err
+	}
+	r := &SQLiteEmployeeRepository{DB: db}
+	r.documentOperations = documentOperations{r}
+	return r, nil
+}
+
+// sqliteTransaction marks the context of a transaction with the transaction.
+type sqliteTransaction struct{}
+
+// sqliteConn is a database or a transaction.
+type sqliteConn interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// conn returns the transaction ctx belongs to, or the database.
+func (r *SQLiteEmployeeRepository) conn(ctx context.Context) sqliteConn {
+	if tx, ok := ctx.Value(sqliteTransaction{}).(*sql.Tx); ok {
+		return tx
+	}
+	return r.DB
+}
+
+// write runs fn in a transaction. Within the transaction ctx belongs to, it runs fn in a savepoint, so that a failed
+// write leaves that transaction as it was.
+func (r *SQLiteEmployeeRepository) write(ctx context.Context, fn func(conn sqliteConn) error) error {
+	if tx, ok := ctx.Value(sqliteTransaction{}).(*sql.Tx); ok {
+		if _, err := tx.ExecContext(ctx, `SAVEPOINT employee_write`); err != nil {
+			return err
+		}
+		done := false
+		defer func() {
+			ctx := context.WithoutCancel(ctx)
+			if !done {
+				tx.ExecContext(ctx, `ROLLBACK TO employee_write`)
+			}
+			tx.ExecContext(ctx, `RELEASE employee_write`)
+		}()
+		if err := fn(tx); err != nil {
+			return err
+		}
+		done = true
+		return nil
+	}
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// sqliteWriteError reports a write violating a unique constraint as a duplicate key error.
+func sqliteWriteError(err error, doc bson.M) error {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code() != sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return err
+	}
+	field := models.EmployeeRef.Email
+	if strings.Contains(err.Error(), "employees.id") {
+		field = models.EmployeeRef.ID
+	}
+	return duplicateKeyError(field, doc[field])
+}
+
+// loadSQLite returns the employees matching the criteria in insertion order, at most limit of them when limit > 0.
+func loadSQLite(ctx context.Context, db sqliteConn, criteria EmployeeCriteria, limit int) ([]storedEmployee, error) {
+	filter, err := documentFilterOf(criteria)
+	if err != nil {
+		return nil, err
+	}
+	var q sqliteQuery
+	cond, exact, err := q.criteria(criteria)
+	if err != nil {
+		return nil, err
+	}
+	query := "SELECT seq, doc FROM employees WHERE " + cond + " ORDER BY seq"
+	if exact && limit > 0 {
+		query += " LIMIT " + q.arg(limit)
+	}
+	return querySQLite(ctx, db, query, q.args, filter, limit)
+}
+
+// querySQLite runs a query returning seq and doc columns and keeps the rows matching the filter, at most limit of
+// them when limit > 0.
+func querySQLite(ctx context.Context, db sqliteConn, query string, args []any, filter documentFilter, limit int) ([]storedEmployee, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found []storedEmployee
+	for rows.Next() {
+		var row storedEmployee
+		var data []byte
+		if err := rows.Scan(&row.seq, &data); err != nil {
+			return nil, err
+		}
+		if row.doc, err = decodeEmployee(data); err != nil {
+			return nil, err
+		}
+		ok, err := filter.matches(row.doc)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		found = append(found, row)
+		if len(found) == limit {
+			break
+		}
+	}
+	return found, rows.Err()
+}
+
+// sqliteText returns the text of one of the stringFields, compared byte by byte as MongoDB does.
+func sqliteText(key string) string {
+	return `(doc ->> '$."` + key + `"')`
+}
+
+// storeSQLite writes doc to the row with the sequence number.
+func storeSQLite(ctx context.Context, db sqliteConn, seq int64, doc bson.M) error {
+	email, tenant, id, data, err := encodeEmployee(doc)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, `UPDATE employees SET email = ?, tenant = ?, id = ?, doc = ? WHERE seq = ?`, email, tenant, id, string(data), seq)
+	return sqliteWriteError(err, doc)
+}
+
+// insertSQLite adds doc as a new row.
+func insertSQLite(ctx context.Context, db sqliteConn, doc bson.M) error {
+	email, tenant, id, data, err := encodeEmployee(doc)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, `INSERT INTO employees (email, tenant, id, doc) VALUES (?, ?, ?, ?)`, email, tenant, id, string(data))
+	return sqliteWriteError(err, doc)
+}
+
+// Create inserts a new employee.
+func (r *SQLiteEmployeeRepository) Create(ctx context.Context, emp models.Employee) error {
+	doc, err := canonicalDocument(emp)
+	if err != nil {
+		return err
+	}
+	doc["_id"] = bson.NewObjectID()
+	return r.write(ctx, func(conn sqliteConn) error {
+		return insertSQLite(ctx, conn, doc)
+	})
+}
+
+// InsertMany inserts new employees, as EmployeeRepository.InsertMany does, in a single transaction.
+func (r *SQLiteEmployeeRepository) InsertMany(ctx context.Context, emps []models.Employee, ordered bool) error {
+	docs := make([]bson.M, len(emps))
+	for i, emp := range emps {
+		doc, err := canonicalDocument(emp)
+		if err != nil {
+			return err
+		}
+		doc["_id"] = bson.NewObjectID()
+		docs[i] = doc
+	}
+	var failures mongo.BulkWriteException
+	// A statement violating a unique constraint is undone alone, leaving the transaction to commit the others.
+	err := r.write(ctx, func(conn sqliteConn) error {
+		for i, doc := range docs {
+			if err := insertSQLite(ctx, conn, doc); err != nil {
+				if !bulkWriteFailure(&failures, i, err) {
+					return err
+				}
+				if ordered {
+					break
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return bulkWriteError(failures)
+}
+
+// load returns the employees matching the criteria, in insertion order, at most limit of them when limit > 0.
+func (r *SQLiteEmployeeRepository) load(ctx context.Context, criteria EmployeeCriteria, limit int) ([]bson.M, error) {
+	found, err := loadSQLite(ctx, r.conn(ctx), criteria, limit)
+	return docsOf(found), err
+}
+
+// List returns a page of the employees matching the criteria, without their password.
+func (r *SQLiteEmployeeRepository) List(ctx context.Context, criteria EmployeeCriteria, page Page) (employees []models.Employee, err error) {
+	find, err := resolveFindOptions([]options.Lister[options.FindOptions]{page.findOptions()})
+	if err != nil {
+		return nil, err
+	}
+	filter, err := documentFilterOf(criteria)
+	if err != nil {
+		return nil, err
+	}
+	var q sqliteQuery
+	cond, exact, err := q.criteria(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT seq, doc FROM employees WHERE " + cond
+	// The collations of SQLite differ from MongoDB's, so lists in a collation are sorted here.
+	if order, ok := orderBy(find.Sort, sqliteText); exact && ok && find.Collation == nil {
+		query += " ORDER BY " + order
+		// SQLite only takes an offset after a limit, -1 when there is none.
+		if find.Limit != nil && *find.Limit > 0 {
+			query += " LIMIT " + q.arg(*find.Limit)
+		} else if find.Skip != nil {
+			query += " LIMIT -1"
+		}
+		if find.Skip != nil {
+			query += " OFFSET " + q.arg(*find.Skip)
+		}
+		find.Sort, find.Skip, find.Limit = nil, nil, nil
+	} else {
+		query += " ORDER BY seq"
+	}
+	found, err := querySQLite(ctx, r.conn(ctx), query, q.args, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := applyFindOptions(docsOf(found), find)
+	if err != nil {
+		return nil, err
+	}
+	err = decodeAll(docs, &employees)
+	return employees, err
+}
+
+// Count returns the number of employees matching the criteria, counted by the database when they translate to SQL
+// exactly.
+func (r *SQLiteEmployeeRepository) Count(ctx context.Context, criteria EmployeeCriteria) (n int64, err error) {
+	var q sqliteQuery
+	cond, exact, err := q.criteria(criteria)
+	if err != nil {
+		return 0, err
+	}
+	if exact {
+		err = r.conn(ctx).QueryRowContext(ctx, "SELECT count(*) FROM employees WHERE "+cond, q.args...).Scan(&n)
+		return n, err
+	}
+	filter, err := documentFilterOf(criteria)
+	if err != nil {
+		return 0, err
+	}
+	found, err := querySQLite(ctx, r.conn(ctx), "SELECT seq, doc FROM employees WHERE "+cond+" ORDER BY seq", q.args, filter, 0)
+	return int64(len(found)), err
+}
+
+// change calls change on the employees matching the criteria and stores those it changed. Unlike MongoDB, a write
+// violating a unique index rolls back the writes before it.
+func (r *SQLiteEmployeeRepository) change(ctx context.Context, criteria EmployeeCriteria, limit int, change func(emp *models.Employee) bool) (changed []bson.M, err error) {
+	err = r.write(ctx, func(conn sqliteConn) error {
+		found, err := loadSQLite(ctx, conn, criteria, limit)
+		if err != nil {
+			return err
+		}
+		for _, row := range found {
+			doc, err := changeDocument(row.doc, change)
+			if err != nil {
+				return err
+			}
+			if doc == nil {
+				continue
+			}
+			if err := storeSQLite(ctx, conn, row.seq, doc); err != nil {
+				return err
+			}
+			changed = append(changed, doc)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// ReplaceEmployee replaces the stored employee having emp's email with emp, as EmployeeRepository.ReplaceEmployee
+// does.
+func (r *SQLiteEmployeeRepository) ReplaceEmployee(ctx context.Context, emp models.Employee, version int64, upsert bool) (inserted bool, err error) {
+	doc, err := canonicalDocument(emp)
+	if err != nil {
+		return false, err
+	}
+	err = r.write(ctx, func(conn sqliteConn) error {
+		found, err := loadSQLite(ctx, conn, replacedBy(emp, version), 1)
+		if err != nil {
+			return err
+		}
+		if len(found) > 0 {
+			doc["_id"] = found[0].doc["_id"]
+			return storeSQLite(ctx, conn, found[0].seq, doc)
+		}
+		if !upsert {
+			return mongo.ErrNoDocuments
+		}
+		doc["_id"] = bson.NewObjectID()
+		inserted = true
+		return insertSQLite(ctx, conn, doc)
+	})
+	return inserted && err == nil, err
+}
+
+// remove removes the employees matching the criteria.
+func (r *SQLiteEmployeeRepository) remove(ctx context.Context, criteria EmployeeCriteria) (deleted int64, err error) {
+	err = r.write(ctx, func(conn sqliteConn) error {
+		found, err := loadSQLite(ctx, conn, criteria, 0)
+		if err != nil {
+			return err
+		}
+		for _, row := range found {
+			if _, err := conn.ExecContext(ctx, `DELETE FROM employees WHERE seq = ?`, row.seq); err != nil {
+				return err
+			}
+		}
+		deleted = int64(len(found))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// walk follows the reporting lines from start through the employees matching through, in a recursive query served
+// by the indexes on the email and the manager. Where through has no exact SQL equivalent, the employees matching it
+// are loaded and walked here.
+func (r *SQLiteEmployeeRepository) walk(ctx context.Context, start bson.M, through EmployeeCriteria, up bool, maxDepth int) ([]reached, error) {
+	var q sqliteQuery
+	cond, exact, err := q.criteria(through)
+	if err != nil {
+		return nil, err
+	}
+	if !exact {
+		found, err := loadSQLite(ctx, r.conn(ctx), through, 0)
+		if err != nil {
+			return nil, err
+		}
+		return walkDocuments(start, docsOf(found), up, maxDepth), nil
+	}
+	// Each step connects the value of from, in the employees reached, to the value of to in the next ones.
+	startField, from, to := models.EmployeeRef.Email, "email", sqliteManager
+	if up {
+		startField, from, to = models.EmployeeRef.Manager, sqliteManager, "email"
+	}
+	startAt, ok := start[startField].(string)
+	if !ok {
+		return nil, nil
+	}
+	// The path lists the employees on the way, as ",seq,seq,", so that a stored cycle ends the walk.
+	limit := ""
+	if maxDepth > 0 {
+		limit = " AND walk.depth < " + q.arg(maxDepth)
+	}
+	query := fmt.Sprintf(`WITH RECURSIVE walk(walked, connect, depth, path) AS (
+	SELECT seq, %[1]s, 1, ',' || seq || ',' FROM employees WHERE %[2]s = %[3]s AND %[4]s
+	UNION ALL
+	SELECT employees.seq, %[1]s, walk.depth + 1, walk.path || employees.seq || ','
+	FROM walk JOIN employees ON %[2]s = walk.connect
+	WHERE %[4]s AND instr(walk.path, ',' || employees.seq || ',') = 0%[5]s
+)
+SELECT employees.doc, reached.depth FROM (SELECT walked, min(depth) AS depth FROM walk GROUP BY walked) AS reached
+JOIN employees ON employees.seq = reached.walked ORDER BY employees.seq`, from, to, q.arg(startAt), cond, limit)
+	rows, err := r.conn(ctx).QueryContext(ctx, query, q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var walked []reached
+	for rows.Next() {
+		var data []byte
+		var depth int
+		if err := rows.Scan(&data, &depth); err != nil {
+			return nil, err
+		}
+		doc, err := decodeEmployee(data)
+		if err != nil {
+			return nil, err
+		}
+		walked = append(walked, reached{doc: doc, depth: depth})
+	}
+	return walked, rows.Err()
+}
+
+// sqliteManager is the SQL of the manager of an employee, as the index on it is defined.
+const sqliteManager = `(doc ->> '$."manager"')`
+
+// InTransaction runs fn in a transaction, rolled back when fn fails. A transaction started within fn joins the one in
+// progress.
+func (r *SQLiteEmployeeRepository) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(sqliteTransaction{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(context.WithValue(ctx, sqliteTransaction{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
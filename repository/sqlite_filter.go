@@ -0,0 +1,378 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"modernc.org/sqlite"
+)
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("regexp", 2, sqliteRegexp)
+}
+
+// sqliteRegexps caches the regular expressions compiled by sqliteRegexp, since SQLite calls it once per row.
+var sqliteRegexps = struct {
+	sync.Mutex
+	compiled map[string]*regexp.Regexp
+}{compiled: map[string]*regexp.Regexp{}}
+
+// sqliteRegexp implements the REGEXP operator, which SQLite leaves to the application, with the regular expressions
+// MemoryEmployeeRepository matches. It is NULL unless both the value and the pattern are strings.
+func sqliteRegexp(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	pattern, ok := args[0].(string)
+	value, isString := args[1].(string)
+	if !ok || !isString {
+		return nil, nil
+	}
+	sqliteRegexps.Lock()
+	re, ok := sqliteRegexps.compiled[pattern]
+	if !ok {
+		var err error
+		if re, err = regexp.Compile(pattern); err != nil {
+			sqliteRegexps.Unlock()
+			return nil, err
+		}
+		// The patterns come from requests, so the cache starts over rather than grow without bound.
+		if len(sqliteRegexps.compiled) >= 100 {
+			sqliteRegexps.compiled = map[string]*regexp.Regexp{}
+		}
+		sqliteRegexps.compiled[pattern] = re
+	}
+	sqliteRegexps.Unlock()
+	return re.MatchString(value), nil
+}
+
+// sqliteQuery accumulates the arguments of a SQLite statement. Its placeholders are numbered, so that a condition
+// may use an argument more than once.
+type sqliteQuery struct {
+	args    []any
+	aliases int
+}
+
+// arg adds an argument and returns its placeholder.
+func (q *sqliteQuery) arg(v any) string {
+	q.args = append(q.args, v)
+	return "?" + strconv.Itoa(len(q.args))
+}
+
+// alias returns a new table name, so that nested subqueries tell their tables apart.
+func (q *sqliteQuery) alias() string {
+	q.aliases++
+	return "v" + strconv.Itoa(q.aliases)
+}
+
+// sqliteKey matches the field names spelled out in JSON paths; filters on other names are left to matches.
+var sqliteKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// sqliteField returns the SQL of a field of a JSON document, as JSON text, or NULL when it is missing. The name is
+// spelled out, so that the indexes on the field, such as the one on the birth date, apply.
+func sqliteField(doc, key string) string {
+	return doc + ` -> '$."` + key + `"'`
+}
+
+// sqliteDate returns the SQL of the date held by JSON text, in milliseconds since the epoch, or NULL when it holds
+// none. Dates are stored as {"$date": "<ISO 8601>"} from 1970 to 9999, and as {"$date": {"$numberLong": "<ms>"}}
+// otherwise.
+func sqliteDate(value string) string {
+	return fmt.Sprintf(`CASE json_type(%[1]s, '$."$date"') WHEN 'text' THEN CAST(round(unixepoch(%[1]s ->> '$."$date"', 'subsec') * 1000) AS INTEGER) WHEN 'object' THEN CAST(%[1]s ->> '$."$date"."$numberLong"' AS INTEGER) END`, value)
+}
+
+// sqliteValues returns the SQL of the values MongoDB compares a JSON value by, as a json_each table: the elements
+// of an array, or else the value itself.
+func sqliteValues(value string) string {
+	return fmt.Sprintf(`json_each(CASE json_type(%[1]s) WHEN 'array' THEN %[1]s ELSE '[' || (%[1]s) || ']' END)`, value)
+}
+
+// sqliteDocument returns the SQL of a value of a json_each table as JSON text when it is a document, or NULL.
+func sqliteDocument(v string) string {
+	return "(CASE " + v + ".type WHEN 'object' THEN " + v + ".value END)"
+}
+
+// some returns the condition that one of the values at a field of doc satisfies cond, a condition on a row of
+// sqliteValues. A dotted path runs through documents and arrays of them, as in MongoDB. It reports false when the
+// path has no SQL equivalent, as for array indexes.
+func (q *sqliteQuery) some(doc, key string, cond func(v string) string) (string, bool) {
+	var from []string
+	var v string
+	for _, part := range strings.Split(key, ".") {
+		if !sqliteKey.MatchString(part) {
+			return "", false
+		}
+		if v != "" {
+			doc = sqliteDocument(v)
+		}
+		v = q.alias()
+		from = append(from, sqliteValues(sqliteField(doc, part))+" AS "+v)
+	}
+	return "EXISTS (SELECT 1 FROM " + strings.Join(from, ", ") + " WHERE " + cond(v) + ")", true
+}
+
+// criteria translates employee criteria to a SQL condition on the doc column, exact unless some of their filter has
+// no SQL equivalent. The age is computed by documentFilter, in the time zone of each employee, from the employees
+// born in the range of BornAround, which the index on the birth date finds.
+func (q *sqliteQuery) criteria(c EmployeeCriteria) (cond string, exact bool, err error) {
+	filter, err := documentFilterOf(c)
+	if err != nil {
+		return "", false, err
+	}
+	cond, exact = q.where("doc", filter.filter)
+	if c.Age != nil {
+		exact = false
+		born := sqliteDate(sqliteField("doc", models.EmployeeRef.BirthDate))
+		after, by := bornBetween(c.Age.Years, c.Age.At)
+		cond += fmt.Sprintf(" AND %s > %s AND %s <= %s", born, q.arg(after.UnixMilli()), born, q.arg(by.UnixMilli()))
+	}
+	return cond, exact, nil
+}
+
+// kept returns a condition, dropping the arguments added since mark when the condition is empty, left out of the
+// statement, since SQLite expects every argument to be used.
+func (q *sqliteQuery) kept(mark int, c string) string {
+	if c == "" {
+		q.args = q.args[:mark]
+	}
+	return c
+}
+
+// where translates a filter on the JSON document doc to a SQL condition, as sqlQuery.where does.
+func (q *sqliteQuery) where(doc string, filter bson.M) (cond string, exact bool) {
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	exact = true
+	var conds []string
+	for _, key := range keys {
+		c, ok := q.clause(doc, key, filter[key])
+		exact = exact && ok
+		if c != "" {
+			conds = append(conds, c)
+		}
+	}
+	if len(conds) == 0 {
+		return "TRUE", exact
+	}
+	return strings.Join(conds, " AND "), exact
+}
+
+// clause translates a single key of a filter, returning an empty condition when it has no SQL equivalent.
+func (q *sqliteQuery) clause(doc, key string, cond any) (string, bool) {
+	switch key {
+	case "$and", "$or":
+		clauses, _ := cond.(bson.A)
+		exact := true
+		var conds []string
+		for _, clause := range clauses {
+			sub, _ := clause.(bson.M)
+			c, ok := q.where(doc, sub)
+			exact = exact && ok
+			conds = append(conds, "("+c+")")
+		}
+		if len(conds) == 0 {
+			return "", false
+		}
+		if key == "$and" {
+			return strings.Join(conds, " AND "), exact
+		}
+		return "(" + strings.Join(conds, " OR ") + ")", exact
+	}
+	if strings.HasPrefix(key, "$") {
+		return "", false
+	}
+
+	ops, isOps := isOperatorDocument(cond)
+	if !isOps {
+		mark := len(q.args)
+		c, ok := q.equals(doc, key, cond)
+		return q.kept(mark, c), ok
+	}
+	opNames := make([]string, 0, len(ops))
+	for op := range ops {
+		opNames = append(opNames, op)
+	}
+	sort.Strings(opNames)
+
+	exact := true
+	var conds []string
+	for _, op := range opNames {
+		if op == "$options" {
+			continue
+		}
+		mark := len(q.args)
+		c, ok := q.operator(doc, key, op, ops[op], ops)
+		exact = exact && ok
+		if c = q.kept(mark, c); c != "" {
+			conds = append(conds, c)
+		}
+	}
+	return strings.Join(conds, " AND "), exact
+}
+
+// scalar returns the condition that a row of sqliteValues equals a string, number or boolean, reporting false for
+// other values.
+func (q *sqliteQuery) scalar(value any) (func(v string) string, bool) {
+	switch t := value.(type) {
+	case string:
+		p := q.arg(t)
+		return func(v string) string { return v + ".type = 'text' AND " + v + ".atom = " + p }, true
+	case bool:
+		return func(v string) string { return v + ".type = '" + strconv.FormatBool(t) + "'" }, true
+	case int32, int64, float64:
+		p := q.arg(t)
+		return func(v string) string { return v + ".type IN ('integer', 'real') AND " + v + ".atom = " + p }, true
+	}
+	return nil, false
+}
+
+// equals translates the equality of a field with a value: the field holds the value, or is an array holding it.
+// Null also matches a missing top-level field. The email and the internal ID of an employee are looked up by their
+// columns, which are indexed.
+func (q *sqliteQuery) equals(doc, key string, value any) (string, bool) {
+	if value == nil {
+		if !sqliteKey.MatchString(key) {
+			return "", false
+		}
+		c, _ := q.some(doc, key, func(v string) string { return v + ".type = 'null'" })
+		return "(" + sqliteField(doc, key) + " IS NULL OR " + c + ")", true
+	}
+	if s, ok := value.(string); ok && doc == "doc" && (key == models.EmployeeRef.Email || key == models.EmployeeRef.ID) {
+		return key + " = " + q.arg(s), true
+	}
+	match, ok := q.scalar(value)
+	if !ok {
+		return "", false
+	}
+	return q.some(doc, key, match)
+}
+
+// notEquals negates equals, where a NULL result, as for a missing internal ID, counts as false.
+func (q *sqliteQuery) notEquals(doc, key string, value any) (string, bool) {
+	c, ok := q.equals(doc, key, value)
+	if !ok {
+		return "", false
+	}
+	return "NOT coalesce(" + c + ", FALSE)", true
+}
+
+// compares returns the condition that a row of sqliteValues compares with a value as MongoDB compares values of
+// the same type: strings byte by byte, numbers by value and dates by time. It reports false for other values.
+func (q *sqliteQuery) compares(comparison string, value any) (func(v string) string, bool) {
+	switch t := value.(type) {
+	case string:
+		p := q.arg(t)
+		return func(v string) string { return v + ".type = 'text' AND " + v + ".atom " + comparison + " " + p }, true
+	case int32, int64, float64:
+		p := q.arg(t)
+		return func(v string) string {
+			return v + ".type IN ('integer', 'real') AND " + v + ".atom " + comparison + " " + p
+		}, true
+	case bson.DateTime:
+		p := q.arg(int64(t))
+		return func(v string) string { return sqliteDate(sqliteDocument(v)) + " " + comparison + " " + p }, true
+	}
+	return nil, false
+}
+
+func (q *sqliteQuery) operator(doc, key, op string, arg any, ops bson.M) (string, bool) {
+	switch op {
+	case "$eq":
+		return q.equals(doc, key, arg)
+	case "$ne":
+		return q.notEquals(doc, key, arg)
+	case "$in", "$nin":
+		list, _ := arg.(bson.A)
+		var conds []string
+		for _, value := range list {
+			c, ok := q.equals(doc, key, value)
+			if !ok {
+				return "", false
+			}
+			conds = append(conds, c)
+		}
+		c := "FALSE"
+		if len(conds) > 0 {
+			c = "(" + strings.Join(conds, " OR ") + ")"
+		}
+		if op == "$nin" {
+			return "NOT coalesce(" + c + ", FALSE)", true
+		}
+		return c, true
+	case "$all":
+		list, _ := arg.(bson.A)
+		if len(list) == 0 {
+			return "FALSE", true
+		}
+		var conds []string
+		for _, value := range list {
+			c, ok := q.equals(doc, key, value)
+			if !ok || value == nil {
+				return "", false
+			}
+			conds = append(conds, c)
+		}
+		return strings.Join(conds, " AND "), true
+	case "$exists":
+		if strings.Contains(key, ".") || !sqliteKey.MatchString(key) {
+			return "", false
+		}
+		if truthy(arg) {
+			return sqliteField(doc, key) + " IS NOT NULL", true
+		}
+		return sqliteField(doc, key) + " IS NULL", true
+	case "$regex":
+		pattern, ok := arg.(string)
+		if !ok {
+			return "", false
+		}
+		switch ops["$options"] {
+		case nil, "":
+		case "i":
+			pattern = "(?i)" + pattern
+		default:
+			return "", false
+		}
+		// Patterns the memory store rejects are left to matches, which reports them.
+		if _, err := regexp.Compile(pattern); err != nil {
+			return "", false
+		}
+		p := q.arg(pattern)
+		return q.some(doc, key, func(v string) string { return v + ".type = 'text' AND " + v + ".atom REGEXP " + p })
+	case "$gt", "$gte", "$lt", "$lte":
+		comparison := map[string]string{"$gt": ">", "$gte": ">=", "$lt": "<", "$lte": "<="}[op]
+		compare, ok := q.compares(comparison, arg)
+		if !ok {
+			return "", false
+		}
+		return q.some(doc, key, compare)
+	case "$elemMatch":
+		// Only documents of conditions on the fields of the elements of a field translate, not operators on the
+		// elements themselves.
+		sub, ok := arg.(bson.M)
+		if _, isOps := isOperatorDocument(arg); !ok || isOps || strings.Contains(key, ".") {
+			return "", false
+		}
+		exact := false
+		cond, ok := q.some(doc, key, func(v string) string {
+			var c string
+			c, exact = q.where(sqliteDocument(v), sub)
+			return v + ".type = 'object' AND " + c
+		})
+		if !ok {
+			return "", false
+		}
+		// sqliteValues also yields a document held by the field itself, which $elemMatch does not match.
+		return "json_type(" + sqliteField(doc, key) + ") = 'array' AND " + cond, exact
+	}
+	return "", false
+}
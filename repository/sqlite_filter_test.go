@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// These tests run the SQL the SQLite store builds on a database file, which needs no server.
+
+// openSQLite returns a migrated SQLite store holding the documents, each given an email in turn.
+func openSQLite(t *testing.T, docs ...bson.M) *SQLiteEmployeeRepository {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "employees.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	repo, err := NewSQLiteEmployeeRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, doc := range docs {
+		doc[models.EmployeeRef.Email] = string(rune('a'+i)) + "@example.com"
+		if err := insertSQLite(context.Background(), db, doc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo
+}
+
+// sqliteDocuments have the shapes filters must tell apart: missing and null fields, arrays, nested documents,
+// numbers of both kinds and dates on both sides of 1970.
+var sqliteDocuments = []string{
+	`{"name": "Ann", "roles": ["admin", "user"], "level": 3, "address": {"city": "Tel Aviv"}, "birthDate": {"$date": "1990-05-01T00:00:00Z"}}`,
+	`{"name": "bob", "roles": "user", "level": 3.5, "address": [{"city": "Haifa"}, {"city": "Eilat"}], "manager": null}`,
+	`{"name": "Cy", "roles": [], "level": "3", "address": {"city": ["Acre", "Haifa"]}, "birthDate": {"$date": {"$numberLong": "-86400000"}}}`,
+	`{"roles": ["user", null], "active": true, "skills": [{"name": "go", "level": 4}, {"name": "sql", "level": 2}]}`,
+	`{"name": "Ëve", "active": false, "skills": {"name": "go", "level": 5}, "deletedAt": {"$date": "2024-01-02T03:04:05.678Z"}}`,
+}
+
+func TestSQLiteQuery_AgreesWithMatches(t *testing.T) {
+	docs := make([]bson.M, len(sqliteDocuments))
+	for i, doc := range sqliteDocuments {
+		if err := bson.UnmarshalExtJSON([]byte(doc), false, &docs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo := openSQLite(t, docs...)
+	ctx := context.Background()
+	stored, err := querySQLite(ctx, repo.DB, "SELECT seq, doc FROM employees ORDER BY seq", nil, documentFilter{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter bson.M
+		exact  bool
+	}{
+		{"equal string", bson.M{"name": "Ann"}, true},
+		{"equal array element", bson.M{"roles": "user"}, true},
+		{"equal number across kinds", bson.M{"level": 3.0}, true},
+		{"string is not number", bson.M{"level": "3"}, true},
+		{"equal boolean", bson.M{"active": false}, true},
+		{"null or missing", bson.M{"manager": nil}, true},
+		{"null in array", bson.M{"roles": nil}, true},
+		{"dotted through array", bson.M{"address.city": "Haifa"}, true},
+		{"email column", bson.M{"email": "b@example.com"}, true},
+		{"not equal", bson.M{"roles": bson.M{"$ne": "admin"}}, true},
+		{"in", bson.M{"name": bson.M{"$in": bson.A{"bob", "Cy"}}}, true},
+		{"not in", bson.M{"roles": bson.M{"$nin": bson.A{"admin", nil}}}, true},
+		{"all", bson.M{"roles": bson.M{"$all": bson.A{"admin", "user"}}}, true},
+		{"exists", bson.M{"birthDate": bson.M{"$exists": true}}, true},
+		{"not exists", bson.M{"name": bson.M{"$exists": false}}, true},
+		{"regex", bson.M{"name": bson.M{"$regex": "^[a-c]"}}, true},
+		{"regex in any case", bson.M{"name": bson.M{"$regex": "^ë", "$options": "i"}}, true},
+		{"regex through arrays", bson.M{"address.city": bson.M{"$regex": "^h", "$options": "i"}}, true},
+		{"number range", bson.M{"level": bson.M{"$gt": 3, "$lte": 4}}, true},
+		{"string range", bson.M{"name": bson.M{"$gte": "B"}}, true},
+		{"date range", bson.M{"birthDate": bson.M{"$lt": bson.NewDateTimeFromTime(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC))}}, true},
+		{"date with milliseconds", bson.M{"deletedAt": bson.M{"$lte": bson.NewDateTimeFromTime(time.Date(2024, 1, 2, 3, 4, 5, 678e6, time.UTC))}}, true},
+		{"elemMatch", bson.M{"skills": bson.M{"$elemMatch": bson.M{"name": "go", "level": bson.M{"$gte": 4}}}}, true},
+		{"or", bson.M{"$or": bson.A{bson.M{"active": true}, bson.M{"level": bson.M{"$lt": 3.5}}}}, true},
+		{"size", bson.M{"roles": bson.M{"$size": 0}}, false},
+		{"array index", bson.M{"roles.0": "admin"}, false},
+		{"inexact beside exact", bson.M{"roles": bson.M{"$size": 2}, "name": "Ann"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := filterOf(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want []int64
+			for _, row := range stored {
+				if ok, err := matches(row.doc, filter); err != nil {
+					t.Fatal(err)
+				} else if ok {
+					want = append(want, row.seq)
+				}
+			}
+
+			var q sqliteQuery
+			cond, exact := q.where("doc", filter)
+			if exact != tt.exact {
+				t.Errorf("expected exact %v, got %v for %s", tt.exact, exact, cond)
+			}
+			rows, err := repo.DB.QueryContext(ctx, "SELECT seq FROM employees WHERE "+cond+" ORDER BY seq", q.args...)
+			if err != nil {
+				t.Fatalf("failed to run %s: %v", cond, err)
+			}
+			defer rows.Close()
+			var got []int64
+			for rows.Next() {
+				var seq int64
+				if err := rows.Scan(&seq); err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, seq)
+			}
+			if err := rows.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			if exact && !slices.Equal(got, want) {
+				t.Errorf("expected the rows %v, got %v from %s", want, got, cond)
+			}
+			for _, seq := range want {
+				if !slices.Contains(got, seq) {
+					t.Errorf("expected the condition to hold for every matching row, missing %d from %v", seq, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSQLiteQueryCriteria_AgeUsesTheBirthDateIndex(t *testing.T) {
+	repo := openSQLite(t)
+	var q sqliteQuery
+	cond, exact, err := q.criteria(EmployeeCriteria{Age: &AgeCriterion{Years: 30, At: time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exact {
+		t.Errorf("expected the ages to be checked after the query, in the time zones of the employees")
+	}
+	rows, err := repo.DB.Query("EXPLAIN QUERY PLAN SELECT seq, doc FROM employees WHERE "+cond, q.args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var plan []string
+	for rows.Next() {
+		var id, parent, unused int
+		var detail string
+		if err := rows.Scan(&id, &parent, &unused, &detail); err != nil {
+			t.Fatal(err)
+		}
+		plan = append(plan, detail)
+	}
+	if !strings.Contains(strings.Join(plan, "\n"), "employees_birth_date") {
+		t.Errorf("expected the birth dates to be found by the index, got the plan %v", plan)
+	}
+}
+
+func TestSQLiteEmployeeRepository_ListsByCriteria(t *testing.T) {
+	repo := openSQLite(t)
+	ctx := context.Background()
+	at := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+	for _, emp := range []models.Employee{
+		{Email: "ann@example.com", Name: "Ann", Address: &models.Address{City: "Tel Aviv"}, BirthDate: time.Date(1996, time.May, 31, 0, 0, 0, 0, time.UTC)},
+		{Email: "bob@example.com", Name: "Bob", Address: &models.Address{City: "tel aviv"}, BirthDate: time.Date(1996, time.June, 2, 0, 0, 0, 0, time.UTC)},
+		{Email: "cy@example.com", Name: "Cy", Address: &models.Address{City: "Haifa"}, Skills: []models.Skill{{Name: "go", Level: 4}}},
+	} {
+		if err := repo.Create(ctx, emp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		criteria EmployeeCriteria
+		want     string
+	}{
+		{"city in any case", EmployeeCriteria{City: "TEL AVIV"}, "ann@example.com,bob@example.com"},
+		{"age", EmployeeCriteria{Age: &AgeCriterion{Years: 30, At: at}}, "ann@example.com"},
+		{"skill", EmployeeCriteria{Skill: "go", MinSkillLevel: 3}, "cy@example.com"},
+		{"paged", EmployeeCriteria{}, "bob@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := Page{Sort: []SortKey{{Field: models.EmployeeRef.Name}}}
+			if tt.name == "paged" {
+				page.Skip, page.Limit = 1, 1
+			}
+			employees, err := repo.List(ctx, tt.criteria, page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var emails []string
+			for _, emp := range employees {
+				emails = append(emails, emp.Email)
+			}
+			if got := strings.Join(emails, ","); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+			if n, err := repo.Count(ctx, tt.criteria); err != nil || (tt.name != "paged" && n != int64(len(employees))) {
+				t.Errorf("expected a count of %d, got %d (%v)", len(employees), n, err)
+			}
+		})
+	}
+}
+
+func TestSQLiteWalk_AgreesWithWalkDocuments(t *testing.T) {
+	// a <- b <- d <- e (soft-deleted) <- f, a <- c, and g <- h <- g in a cycle.
+	managers := []string{"", "a", "a", "b", "d", "e", "h", "g"}
+	docs := make([]bson.M, len(managers))
+	for i, manager := range managers {
+		docs[i] = bson.M{"name": string(rune('A' + i))}
+		if manager != "" {
+			docs[i][models.EmployeeRef.Manager] = manager + "@example.com"
+		}
+	}
+	docs[4][models.EmployeeRef.DeletedAt] = time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+	repo := openSQLite(t, docs...)
+	ctx := context.Background()
+	through := EmployeeCriteria{State: Active}
+	if _, exact, err := new(sqliteQuery).criteria(through); err != nil || !exact {
+		t.Fatalf("expected the walk to run in SQL, got %v (%v)", exact, err)
+	}
+	stored, err := loadSQLite(ctx, repo.DB, through, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// walked lists the emails reached, with their depth, in email order.
+	walked := func(found []reached) string {
+		var walked []string
+		for _, r := range found {
+			walked = append(walked, r.doc[models.EmployeeRef.Email].(string)+":"+string(rune('0'+r.depth)))
+		}
+		slices.Sort(walked)
+		return strings.Join(walked, ",")
+	}
+	for _, start := range docs {
+		for _, up := range []bool{false, true} {
+			for maxDepth := range 3 {
+				got, err := repo.walk(ctx, start, through, up, maxDepth)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := walkDocuments(start, docsOf(stored), up, maxDepth)
+				if walked(got) != walked(want) {
+					t.Errorf("walking from %s (up %v, max depth %d): SQLite reached %s, the documents %s",
+						start[models.EmployeeRef.Email], up, maxDepth, walked(got), walked(want))
+				}
+			}
+		}
+	}
+}
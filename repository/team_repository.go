@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// TeamCollection is the name of the collection holding teams.
+const TeamCollection = "teams"
+
+// TeamRepository encapsulates operations on the teams collection.
+type TeamRepository struct {
+	Collection *TenantCollection
+}
+
+// NewTeamRepository creates a new TeamRepository and ensures the indexes for finding the teams of an employee,
+// as a member or as the lead.
+func NewTeamRepository(client *mongo.Client, dbName string) (*TeamRepository, error) {
+	indexModels := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "members", Value: 1}}},
+		{Keys: bson.D{{Key: "lead", Value: 1}}},
+	}
+	coll := NewTenantCollection(client.Database(dbName), TeamCollection, indexModels...)
+	if err := coll.CreateIndexes(); err != nil {
+		slog.Warn("Failed to create indexes on teams", "error", err)
+		return nil, err
+	}
+
+	return &TeamRepository{
+		Collection: coll,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"WebMVCEmployees/logging"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// tenantKey marks a context with the tenant it is made for.
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx made for the tenant with the ID; tenant.NewContext is the way requests are made
+// for a tenant.
+func WithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// TenantOf returns the ID of the tenant ctx is made for, and false when it is made for none. A context made for the
+// empty ID reaches the employees belonging to no tenant only.
+func TenantOf(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey{}).(string)
+	return id, ok
+}
+
+// TenantDatabase names the database keeping the records of the tenant beside the database dbName. MongoDB tells
+// database names apart regardless of case and limits their length, so the tenant is named by a digest of its ID.
+func TenantDatabase(dbName, id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return dbName + "_" + hex.EncodeToString(sum[:8])
+}
+
+// TenantDatabases lists the databases keeping the records of tenants beside the database dbName.
+func TenantDatabases(ctx context.Context, client *mongo.Client, dbName string) ([]string, error) {
+	return client.ListDatabaseNames(ctx, bson.M{"name": bson.M{"$regex": "^" + regexp.QuoteMeta(dbName) + "_[0-9a-f]{16}$"}})
+}
+
+// tenantDatabase returns the database keeping the records of the tenant ctx is made for, or db for no tenant and for
+// the employees of no tenant.
+func tenantDatabase(ctx context.Context, db *mongo.Database) *mongo.Database {
+	id, ok := TenantOf(ctx)
+	if !ok || id == "" {
+		return db
+	}
+	return db.Client().Database(TenantDatabase(db.Name(), id))
+}
+
+// TenantCollection is a collection each tenant keeps in its own database, so that no query made for a tenant reaches
+// the records of another. Calls made for no tenant, such as those of deployments serving a single organization, reach
+// the collection in the database of the deployment.
+type TenantCollection struct {
+	coll    *mongo.Collection
+	indexes []mongo.IndexModel
+	// indexed holds the names of the databases whose collection has its indexes.
+	indexed sync.Map
+}
+
+// NewTenantCollection returns the collection with the name in each tenant's database. The indexes are created in the
+// database of a tenant the first time the tenant uses the collection, and by CreateIndexes in the database of the
+// deployment.
+func NewTenantCollection(db *mongo.Database, name string, indexes ...mongo.IndexModel) *TenantCollection {
+	return &TenantCollection{coll: db.Collection(name), indexes: indexes}
+}
+
+// CreateIndexes creates the indexes of the collection in the database of the deployment.
+func (c *TenantCollection) CreateIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return c.createIndexes(ctx, c.coll)
+}
+
+// In returns the collection of the tenant ctx is made for, creating its indexes the first time the tenant uses it.
+// It fails, rather than serve the tenant without the indexes keeping its records unique, when they cannot be created.
+func (c *TenantCollection) In(ctx context.Context) (*mongo.Collection, error) {
+	db := tenantDatabase(ctx, c.coll.Database())
+	if db.Name() == c.coll.Database().Name() {
+		return c.coll, nil
+	}
+	coll := db.Collection(c.coll.Name())
+	if _, ok := c.indexed.Load(db.Name()); !ok {
+		// The indexes are created outside any transaction of the caller, which cannot create them.
+		if err := c.createIndexes(mongo.NewSessionContext(ctx, nil), coll); err != nil {
+			logging.FromContext(ctx).Error("Failed to create the indexes of a tenant",
+				"collection", coll.Name(), "database", db.Name(), "error", err)
+			return nil, fmt.Errorf("failed to create the indexes of %s: %w", coll.Name(), err)
+		}
+	}
+	return coll, nil
+}
+
+// createIndexes creates the indexes on coll and remembers its database.
+func (c *TenantCollection) createIndexes(ctx context.Context, coll *mongo.Collection) error {
+	if len(c.indexes) > 0 {
+		if _, err := coll.Indexes().CreateMany(ctx, c.indexes); err != nil {
+			return err
+		}
+	}
+	c.indexed.Store(coll.Database().Name(), struct{}{})
+	return nil
+}
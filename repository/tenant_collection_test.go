@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestTenantDatabase_TellsTenantsApart(t *testing.T) {
+	names := map[string]bool{}
+	for _, id := range []string{"acme", "Acme", "globex", "a-very-long-tenant-id-that-takes-up-all-of-the-64-characters-xyz"} {
+		name := TenantDatabase("employees", id)
+		if names[name] {
+			t.Errorf("expected a database of its own for %s, got %s again", id, name)
+		}
+		names[name] = true
+		if len(name) != len("employees")+17 {
+			t.Errorf("expected a name of fixed length for %s, got %s", id, name)
+		}
+	}
+	if TenantDatabase("employees", "acme") != TenantDatabase("employees", "acme") {
+		t.Errorf("expected the same database for the same tenant")
+	}
+}
+
+func TestTenantCollection_In(t *testing.T) {
+	client, err := mongo.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Disconnect(context.Background())
+	coll := NewTenantCollection(client.Database("employees"), NoteCollection)
+
+	ctx := context.Background()
+	if got, err := coll.In(ctx); err != nil || got.Database().Name() != "employees" {
+		t.Errorf("expected the database of the deployment for no tenant, got %v (%v)", got, err)
+	}
+	acme := WithTenant(ctx, "acme")
+	got, err := coll.In(acme)
+	if err != nil {
+		t.Fatalf("expected the collection of the tenant, got %v", err)
+	}
+	if got.Database().Name() != TenantDatabase("employees", "acme") || got.Name() != NoteCollection {
+		t.Errorf("expected the collection in the database of the tenant, got %s.%s", got.Database().Name(), got.Name())
+	}
+	if id, ok := TenantOf(acme); !ok || id != "acme" {
+		t.Errorf("expected the tenant of the context, got %q (%v)", id, ok)
+	}
+}
+
+func TestTenantCollection_InFailsWithoutTheIndexes(t *testing.T) {
+	client, err := mongo.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Disconnect(context.Background())
+	coll := NewTenantCollection(client.Database("employees"), NoteCollection,
+		mongo.IndexModel{Keys: bson.D{{Key: "employee", Value: 1}}})
+
+	// A canceled request cannot create the indexes, so the tenant is not served without them.
+	ctx, cancel := context.WithCancel(WithTenant(context.Background(), "acme"))
+	cancel()
+	for range 2 {
+		if got, err := coll.In(ctx); err == nil {
+			t.Errorf("expected the indexes of the tenant to fail, got %s", got.Name())
+		}
+	}
+}
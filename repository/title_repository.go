@@ -0,0 +1,19 @@
+package repository
+
+import "go.mongodb.org/mongo-driver/v2/mongo"
+
+// TitleCollection is the name of the collection holding the titles catalog.
+const TitleCollection = "titles"
+
+// TitleRepository encapsulates operations on the titles collection.
+// Titles are keyed by name, so the _id index keeps names unique.
+type TitleRepository struct {
+	Collection *TenantCollection
+}
+
+// NewTitleRepository creates a new TitleRepository.
+func NewTitleRepository(client *mongo.Client, dbName string) *TitleRepository {
+	return &TitleRepository{
+		Collection: NewTenantCollection(client.Database(dbName), TitleCollection),
+	}
+}
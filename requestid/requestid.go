@@ -0,0 +1,46 @@
+// Package requestid identifies each request served, so that a client reporting a failure can be matched with the
+// log lines of the request. The ID is the one a client or a proxy gave the request, or else a new ULID.
+package requestid
+
+import (
+	"context"
+	"time"
+
+	"WebMVCEmployees/ulid"
+)
+
+// MaxLength is the length of the longest request ID accepted from a client.
+const MaxLength = 128
+
+// New returns a new request ID, a ULID, so that IDs sort by the time requests arrived.
+func New() string {
+	return ulid.New(time.Now())
+}
+
+// Valid reports whether id is acceptable as a request ID given by a client: 1 to MaxLength printable ASCII
+// characters, without spaces, so that it cannot break the log lines and headers it is written to.
+func Valid(id string) bool {
+	if id == "" || len(id) > MaxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// contextKey marks the context of a request with its ID.
+type contextKey struct{}
+
+// NewContext returns a copy of ctx for the request with the ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the ID of the request ctx is for, and false outside of requests, as in background jobs.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok
+}
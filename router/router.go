@@ -3,40 +3,590 @@ package router
 import (
 	"WebMVCEmployees/controllers"
 	_ "WebMVCEmployees/docs"
+	"WebMVCEmployees/inflight"
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/readonly"
+	"WebMVCEmployees/slo"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
+// options holds the optional components wired into the router.
+type options struct {
+	sloTracker       *slo.Tracker
+	inFlight         *inflight.Tracker
+	adminController  *controllers.AdminController
+	orgChart         *controllers.OrgChartController
+	photos           *controllers.PhotoController
+	notes            *controllers.NoteController
+	notesAuth        gin.HandlerFunc
+	documents        *controllers.DocumentController
+	certifications   *controllers.CertificationController
+	leave            *controllers.LeaveController
+	attendance       *controllers.AttendanceController
+	reviews          *controllers.ReviewController
+	reviewsAuth      gin.HandlerFunc
+	contacts         *controllers.EmergencyContactController
+	contactsAuth     gin.HandlerFunc
+	leaveAuth        gin.HandlerFunc
+	departments      *controllers.DepartmentController
+	teams            *controllers.TeamController
+	roles            *controllers.RoleController
+	titles           *controllers.TitleController
+	locations        *controllers.LocationController
+	compensation     *controllers.CompensationController
+	compensationAuth gin.HandlerFunc
+	idempotencyStore middleware.IdempotencyStore
+	readOnlyReplica  bool
+	readOnlyMode     *readonly.Switch
+	compression      *middleware.CompressionConfig
+	bodyLimit        int64
+	tenantHeader     string
+	operatorTenant   string
+	accessLogExclude []string
+	tracing          string
+	health           *controllers.HealthController
+}
+
+// Option configures an optional router component.
+type Option func(*options)
+
+// WithSLO records every request against the route objectives of the tracker.
+func WithSLO(tracker *slo.Tracker) Option {
+	return func(o *options) {
+		o.sloTracker = tracker
+	}
+}
+
+// WithAccessLogExclude leaves the requests to the paths, such as /metrics, out of the access log.
+func WithAccessLogExclude(paths ...string) Option {
+	return func(o *options) {
+		o.accessLogExclude = paths
+	}
+}
+
+// WithTracing opens a span for each request, as a child of the span of the caller's traceparent header if any, with
+// the tracer provider set by tracing.Setup. The spans name the service.
+func WithTracing(serviceName string) Option {
+	return func(o *options) {
+		o.tracing = serviceName
+	}
+}
+
+// WithHealth serves the liveness probe at /healthz and the readiness probe at /readyz.
+func WithHealth(c *controllers.HealthController) Option {
+	return func(o *options) {
+		o.health = c
+	}
+}
+
+// WithInFlight counts the requests in progress on every route.
+func WithInFlight(tracker *inflight.Tracker) Option {
+	return func(o *options) {
+		o.inFlight = tracker
+	}
+}
+
+// WithAdmin registers the operational endpoints under /admin.
+func WithAdmin(adminController *controllers.AdminController) Option {
+	return func(o *options) {
+		o.adminController = adminController
+	}
+}
+
+// WithOrgChart registers the organization snapshot endpoints under /orgchart.
+func WithOrgChart(orgChartController *controllers.OrgChartController) Option {
+	return func(o *options) {
+		o.orgChart = orgChartController
+	}
+}
+
+// WithPhotos registers the employee photo endpoints under /employees/{employeeEmail}/photo.
+func WithPhotos(photoController *controllers.PhotoController) Option {
+	return func(o *options) {
+		o.photos = photoController
+	}
+}
+
+// WithDocuments registers the document endpoints under /employees/{employeeEmail}/documents and the signed
+// download links under /documents.
+func WithDocuments(documentController *controllers.DocumentController) Option {
+	return func(o *options) {
+		o.documents = documentController
+	}
+}
+
+// WithNotes registers the HR notes endpoints under /employees/{employeeEmail}/notes.
+// Every request passes through authorize first, which lets only permitted callers through.
+func WithNotes(noteController *controllers.NoteController, authorize gin.HandlerFunc) Option {
+	return func(o *options) {
+		o.notes = noteController
+		o.notesAuth = authorize
+	}
+}
+
+// WithDepartments registers the department endpoints under /departments.
+func WithDepartments(departmentController *controllers.DepartmentController) Option {
+	return func(o *options) {
+		o.departments = departmentController
+	}
+}
+
+// WithTeams registers the team endpoints under /teams and GET /employees/{employeeEmail}/teams.
+func WithTeams(teamController *controllers.TeamController) Option {
+	return func(o *options) {
+		o.teams = teamController
+	}
+}
+
+// WithRoles registers the roles catalog endpoints under /roles.
+func WithRoles(roleController *controllers.RoleController) Option {
+	return func(o *options) {
+		o.roles = roleController
+	}
+}
+
+// WithTitles registers the job titles catalog endpoints under /titles.
+func WithTitles(titleController *controllers.TitleController) Option {
+	return func(o *options) {
+		o.titles = titleController
+	}
+}
+
+// WithLocations registers the office location endpoints under /locations.
+func WithLocations(locationController *controllers.LocationController) Option {
+	return func(o *options) {
+		o.locations = locationController
+	}
+}
+
+// WithCertifications registers the certification endpoints under /employees/{employeeEmail}/certifications,
+// and the certifications expiring across all employees under /certifications/expiring.
+func WithCertifications(certificationController *controllers.CertificationController) Option {
+	return func(o *options) {
+		o.certifications = certificationController
+	}
+}
+
+// WithLeave registers the leave request endpoints under /employees/{employeeEmail}/leave-requests and
+// /leave-requests, and the leave balances under /employees/{employeeEmail}/leave-balances. Submitting, deciding on
+// and cancelling a request pass through authenticate first, which identifies the caller.
+func WithLeave(leaveController *controllers.LeaveController, authenticate gin.HandlerFunc) Option {
+	return func(o *options) {
+		o.leave = leaveController
+		o.leaveAuth = authenticate
+	}
+}
+
+// WithAttendance registers the check-in and check-out endpoints under /employees/{employeeEmail}, and the
+// attendance summaries of an employee and of a manager's team.
+func WithAttendance(attendanceController *controllers.AttendanceController) Option {
+	return func(o *options) {
+		o.attendance = attendanceController
+	}
+}
+
+// WithReviews registers the performance review endpoints under /employees/{employeeEmail}/reviews.
+// Every request passes through authenticate first, which identifies the caller.
+func WithReviews(reviewController *controllers.ReviewController, authenticate gin.HandlerFunc) Option {
+	return func(o *options) {
+		o.reviews = reviewController
+		o.reviewsAuth = authenticate
+	}
+}
+
+// WithEmergencyContacts registers the emergency contact endpoints under /employees/{employeeEmail}/emergency-contacts.
+// Every request passes through authenticate first, which identifies the caller.
+func WithEmergencyContacts(contactController *controllers.EmergencyContactController, authenticate gin.HandlerFunc) Option {
+	return func(o *options) {
+		o.contacts = contactController
+		o.contactsAuth = authenticate
+	}
+}
+
+// WithCompensation registers the salary endpoints under /employees/{employeeEmail}/compensation.
+// Every request passes through authorize first, which lets only permitted callers through.
+func WithCompensation(compensationController *controllers.CompensationController, authorize gin.HandlerFunc) Option {
+	return func(o *options) {
+		o.compensation = compensationController
+		o.compensationAuth = authorize
+	}
+}
+
+// WithIdempotency replays stored responses for create requests retried with the same Idempotency-Key.
+func WithIdempotency(store middleware.IdempotencyStore) Option {
+	return func(o *options) {
+		o.idempotencyStore = store
+	}
+}
+
+// WithReadOnlyReplica serves only read endpoints; every mutation is rejected with 405.
+func WithReadOnlyReplica() Option {
+	return func(o *options) {
+		o.readOnlyReplica = true
+	}
+}
+
+// WithReadOnlyMode rejects every mutation with 503 while the switch is on.
+func WithReadOnlyMode(mode *readonly.Switch) Option {
+	return func(o *options) {
+		o.readOnlyMode = mode
+	}
+}
+
+// WithCompression compresses large responses for clients that accept gzip or brotli.
+func WithCompression(config middleware.CompressionConfig) Option {
+	return func(o *options) {
+		o.compression = &config
+	}
+}
+
+// WithBodyLimit rejects request bodies larger than limit bytes with 413, instead of the 1 MiB default.
+func WithBodyLimit(limit int64) Option {
+	return func(o *options) {
+		o.bodyLimit = limit
+	}
+}
+
+// WithTenants serves each data request for the tenant whose ID the header carries; requests without one get 400.
+// The admin routes are served for the operator tenant, the employees of no tenant when it is empty.
+func WithTenants(header, operatorTenant string) Option {
+	return func(o *options) {
+		o.tenantHeader = header
+		o.operatorTenant = operatorTenant
+	}
+}
+
+// readRoutesWithBody lists the read-only endpoints that use a non-GET method.
+var readRoutesWithBody = []string{
+	"POST /employees/query",
+	"POST /orgchart/diff",
+}
+
+// readOnlyModeRoutes are the mutations served in read-only mode, switching it off.
+var readOnlyModeRoutes = []string{
+	"PUT /admin/read-only",
+}
+
+// logLevelRoutes change the log level, which even read-only instances do.
+var logLevelRoutes = []string{
+	"PUT /admin/loglevel",
+}
+
+// monitoringRoutes serve the in-flight counts and are not counted themselves.
+var monitoringRoutes = []string{
+	"GET /metrics",
+	"GET /admin/inflight",
+}
+
 // SetupRouter initializes the Gin router with API routes and Swagger UI.
-func SetupRouter(empController *controllers.EmployeeController) *gin.Engine {
-	r := gin.Default()
+func SetupRouter(empController *controllers.EmployeeController, opts ...Option) *gin.Engine {
+	o := options{bodyLimit: middleware.DefaultBodyLimit}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// Requests are identified, traced unless left out of the access log, then logged as structured lines, including
+	// those recovered from a panic, rather than by gin.Default.
+	r := gin.New()
+	r.Use(middleware.RequestID())
+	if o.tracing != "" {
+		r.Use(otelgin.Middleware(o.tracing, otelgin.WithFilter(func(req *http.Request) bool {
+			return !slices.Contains(o.accessLogExclude, req.URL.Path)
+		})))
+	}
+	r.Use(middleware.Logging(slog.Default()), middleware.AccessLog(o.accessLogExclude...), gin.Recovery())
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(controllers.RouteNotFoundHandler)
+	r.NoMethod(controllers.MethodNotAllowedHandler)
+	if o.compression != nil {
+		r.Use(middleware.Compress(*o.compression))
+	}
+	if o.inFlight != nil {
+		r.Use(middleware.InFlight(o.inFlight, monitoringRoutes...))
+	}
+	if o.sloTracker != nil {
+		r.Use(middleware.SLO(o.sloTracker))
+	}
+	if o.readOnlyReplica {
+		r.Use(middleware.ReadOnlyReplica(slices.Concat(readRoutesWithBody, logLevelRoutes)...))
+	}
+	if o.readOnlyMode != nil {
+		r.Use(middleware.ReadOnlyMode(o.readOnlyMode, slices.Concat(readRoutesWithBody, readOnlyModeRoutes, logLevelRoutes)...))
+	}
+	r.Use(middleware.BodyLimit(o.bodyLimit))
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	if o.health != nil {
+		r.GET("/healthz", o.health.LivenessHandler)
+		r.GET("/readyz", o.health.ReadinessHandler)
+	}
+
+	// Create endpoints honor Idempotency-Key when a store is configured.
+	idempotent := func(ctx *gin.Context) { ctx.Next() }
+	if o.idempotencyStore != nil {
+		idempotent = middleware.Idempotency(o.idempotencyStore)
+	}
+	// Data endpoints are served for the tenant of the request when tenants are configured.
+	tenanted := func(ctx *gin.Context) { ctx.Next() }
+	// The routes administering the whole deployment are only open to its operators.
+	operators := func(ctx *gin.Context) { ctx.Next() }
+	if o.tenantHeader != "" {
+		tenanted = middleware.Tenant(o.tenantHeader)
+		operators = middleware.OperatorTenant(o.operatorTenant)
+	}
+
+	// Roles decide what callers may do, so only admins change them; likewise for removing records for good and for
+	// setting managers in bulk.
+	admin := middleware.RequireRoles(empController.Service, middleware.AdminRole)
 
-	employeeRoutes := r.Group("/employees")
+	employeeRoutes := r.Group("/employees", tenanted)
 	{
-		employeeRoutes.POST("", empController.CreateEmployeeHandler)
+		employeeRoutes.POST("", idempotent, empController.CreateEmployeeHandler)
 		employeeRoutes.DELETE("", empController.DeleteAllEmployeesHandler)
+		employeeRoutes.POST("/query", empController.QueryEmployeesByExampleHandler)
+		employeeRoutes.GET("/stats", empController.GetWorkforceStatsHandler)
+		employeeRoutes.GET("/trash", empController.ListTrashHandler)
+		employeeRoutes.GET("/by-id/:id", empController.GetEmployeeByIDHandler)
+		employeeRoutes.POST("/trash/purge", admin, empController.PurgeTrashHandler)
+		employeeRoutes.POST("/bulk", idempotent, empController.BulkCreateEmployeesHandler)
+		employeeRoutes.POST("/roles/bulk", admin, idempotent, empController.BulkAssignRolesHandler)
+		employeeRoutes.PUT("/manager/bulk", admin, idempotent, empController.BulkSetManagersHandler)
+		employeeRoutes.POST("/manager/reassign", empController.ReassignReportsHandler)
+		employeeRoutes.POST("/:employeeEmail/roles/grants", admin, empController.GrantTemporaryRoleHandler)
+		employeeRoutes.GET("/:employeeEmail/roles/effective", empController.GetEffectiveRolesHandler)
 		employeeRoutes.PUT("/:employeeEmail/manager", empController.SetManagerHandler)
 		employeeRoutes.GET("/:employeeEmail/manager", empController.GetManagerHandler)
 		employeeRoutes.DELETE("/:employeeEmail/manager", empController.RemoveManagerHandler)
+		employeeRoutes.GET("/:employeeEmail/managers", empController.GetManagerChainHandler)
+		employeeRoutes.GET("/:employeeEmail/reporting-lines", empController.GetReportingLinesHandler)
+		employeeRoutes.POST("/:employeeEmail/reporting-lines", empController.AddReportingLineHandler)
+		employeeRoutes.DELETE("/:employeeEmail/reporting-lines/:type/:managerEmail", empController.RemoveReportingLineHandler)
+		employeeRoutes.PUT("/:employeeEmail/delegation", empController.SetDelegationHandler)
+		employeeRoutes.GET("/:employeeEmail/delegation", empController.GetDelegationHandler)
+		employeeRoutes.DELETE("/:employeeEmail/delegation", empController.RemoveDelegationHandler)
+		employeeRoutes.GET("/:employeeEmail/approver", empController.GetApproverHandler)
 		employeeRoutes.GET("/:employeeEmail/subordinates", empController.GetSubordinatesHandler)
+		employeeRoutes.GET("/:employeeEmail/reports/count", empController.CountReportsHandler)
 		employeeRoutes.GET("/:employeeEmail", empController.GetEmployeeHandler)
+		employeeRoutes.PUT("/:employeeEmail", empController.ReplaceEmployeeHandler)
+		employeeRoutes.PUT("/:employeeEmail/email", empController.ChangeEmailHandler)
+		employeeRoutes.DELETE("/:employeeEmail", empController.DeleteEmployeeHandler)
+		employeeRoutes.POST("/:employeeEmail/restore", empController.RestoreEmployeeHandler)
+		employeeRoutes.POST("/:employeeEmail/terminate", empController.TerminateEmployeeHandler)
+		employeeRoutes.POST("/:employeeEmail/reactivate", empController.ReactivateEmployeeHandler)
+		employeeRoutes.POST("/:employeeEmail/leave", empController.StartLeaveHandler)
+
+		if o.photos != nil {
+			employeeRoutes.PUT("/:employeeEmail/photo", o.photos.SetPhotoHandler)
+			employeeRoutes.GET("/:employeeEmail/photo", o.photos.GetPhotoHandler)
+		}
+
+		if o.notes != nil {
+			employeeRoutes.POST("/:employeeEmail/notes", o.notesAuth, o.notes.AddNoteHandler)
+			employeeRoutes.GET("/:employeeEmail/notes", o.notesAuth, o.notes.ListNotesHandler)
+			employeeRoutes.DELETE("/:employeeEmail/notes/:noteId", o.notesAuth, o.notes.DeleteNoteHandler)
+		}
+
+		if o.documents != nil {
+			employeeRoutes.POST("/:employeeEmail/documents", o.documents.UploadDocumentHandler)
+			employeeRoutes.GET("/:employeeEmail/documents", o.documents.ListDocumentsHandler)
+		}
+
+		if o.certifications != nil {
+			employeeRoutes.POST("/:employeeEmail/certifications", o.certifications.AddCertificationHandler)
+			employeeRoutes.GET("/:employeeEmail/certifications", o.certifications.ListCertificationsHandler)
+			employeeRoutes.PUT("/:employeeEmail/certifications/:certificationId", o.certifications.UpdateCertificationHandler)
+			employeeRoutes.DELETE("/:employeeEmail/certifications/:certificationId", o.certifications.DeleteCertificationHandler)
+		}
+
+		if o.leave != nil {
+			employeeRoutes.POST("/:employeeEmail/leave-requests", o.leaveAuth, o.leave.SubmitLeaveRequestHandler)
+			employeeRoutes.GET("/:employeeEmail/leave-requests", o.leave.ListLeaveRequestsHandler)
+			employeeRoutes.GET("/:employeeEmail/leave-balances/:year", o.leave.GetLeaveBalanceHandler)
+		}
+
+		if o.attendance != nil {
+			employeeRoutes.POST("/:employeeEmail/checkin", o.attendance.CheckInHandler)
+			employeeRoutes.POST("/:employeeEmail/checkout", o.attendance.CheckOutHandler)
+			employeeRoutes.GET("/:employeeEmail/attendance", o.attendance.GetAttendanceHandler)
+			employeeRoutes.GET("/:employeeEmail/team/attendance", o.attendance.GetTeamAttendanceHandler)
+		}
+
+		if o.reviews != nil {
+			employeeRoutes.POST("/:employeeEmail/reviews", o.reviewsAuth, o.reviews.AddReviewHandler)
+			employeeRoutes.GET("/:employeeEmail/reviews", o.reviewsAuth, o.reviews.ListReviewsHandler)
+		}
+
+		if o.contacts != nil {
+			employeeRoutes.PUT("/:employeeEmail/emergency-contacts", o.contactsAuth, o.contacts.SetEmergencyContactsHandler)
+			employeeRoutes.GET("/:employeeEmail/emergency-contacts", o.contactsAuth, o.contacts.GetEmergencyContactsHandler)
+		}
+
+		if o.teams != nil {
+			employeeRoutes.GET("/:employeeEmail/teams", o.teams.ListEmployeeTeamsHandler)
+		}
+
+		if o.compensation != nil {
+			employeeRoutes.PUT("/:employeeEmail/compensation", o.compensationAuth, o.compensation.SetCompensationHandler)
+			employeeRoutes.GET("/:employeeEmail/compensation", o.compensationAuth, o.compensation.GetCompensationHandler)
+		}
 
 		// Separate filtering endpoints.
 		employeeRoutes.GET("", empController.ListEmployeesHandler)
 	}
 
+	reportRoutes := r.Group("/reports", tenanted)
+	{
+		reportRoutes.GET("/anniversaries", empController.GetAnniversariesHandler)
+		reportRoutes.GET("/headcount-by-costcenter", empController.GetHeadcountByCostCenterHandler)
+	}
+
+	if o.departments != nil {
+		departmentRoutes := r.Group("/departments", tenanted)
+		{
+			departmentRoutes.POST("", o.departments.CreateDepartmentHandler)
+			departmentRoutes.GET("", o.departments.ListDepartmentsHandler)
+			departmentRoutes.GET("/:name", o.departments.GetDepartmentHandler)
+			departmentRoutes.PUT("/:name", o.departments.UpdateDepartmentHandler)
+			departmentRoutes.DELETE("/:name", o.departments.DeleteDepartmentHandler)
+		}
+	}
+
+	if o.roles != nil {
+		roleRoutes := r.Group("/roles", tenanted)
+		{
+			roleRoutes.POST("", admin, o.roles.CreateRoleHandler)
+			roleRoutes.GET("", o.roles.ListRolesHandler)
+			roleRoutes.GET("/:name", o.roles.GetRoleHandler)
+			roleRoutes.PUT("/:name", admin, o.roles.UpdateRoleHandler)
+			roleRoutes.DELETE("/:name", admin, o.roles.DeleteRoleHandler)
+		}
+	}
+
+	if o.documents != nil {
+		r.GET("/documents/:documentId/download", tenanted, o.documents.DownloadDocumentHandler)
+	}
+
+	if o.certifications != nil {
+		r.GET("/certifications/expiring", tenanted, o.certifications.ListExpiringCertificationsHandler)
+	}
+
+	if o.leave != nil {
+		leaveRoutes := r.Group("/leave-requests", tenanted)
+		{
+			leaveRoutes.GET("/:requestId", o.leave.GetLeaveRequestHandler)
+			leaveRoutes.POST("/:requestId/approve", o.leaveAuth, o.leave.ApproveLeaveRequestHandler)
+			leaveRoutes.POST("/:requestId/reject", o.leaveAuth, o.leave.RejectLeaveRequestHandler)
+			leaveRoutes.POST("/:requestId/cancel", o.leaveAuth, o.leave.CancelLeaveRequestHandler)
+		}
+	}
+
+	if o.titles != nil {
+		titleRoutes := r.Group("/titles", tenanted)
+		{
+			titleRoutes.POST("", o.titles.CreateTitleHandler)
+			titleRoutes.GET("", o.titles.ListTitlesHandler)
+			titleRoutes.GET("/:name", o.titles.GetTitleHandler)
+			titleRoutes.PUT("/:name", o.titles.UpdateTitleHandler)
+			titleRoutes.DELETE("/:name", o.titles.DeleteTitleHandler)
+		}
+	}
+
+	if o.locations != nil {
+		locationRoutes := r.Group("/locations", tenanted)
+		{
+			locationRoutes.POST("", o.locations.CreateLocationHandler)
+			locationRoutes.GET("", o.locations.ListLocationsHandler)
+			locationRoutes.GET("/:name", o.locations.GetLocationHandler)
+			locationRoutes.PUT("/:name", o.locations.UpdateLocationHandler)
+			locationRoutes.DELETE("/:name", o.locations.DeleteLocationHandler)
+		}
+	}
+
+	if o.teams != nil {
+		teamRoutes := r.Group("/teams", tenanted)
+		{
+			teamRoutes.POST("", o.teams.CreateTeamHandler)
+			teamRoutes.GET("/:teamId", o.teams.GetTeamHandler)
+			teamRoutes.DELETE("/:teamId", o.teams.DeleteTeamHandler)
+			teamRoutes.PUT("/:teamId/members/:employeeEmail", o.teams.AddMemberHandler)
+			teamRoutes.DELETE("/:teamId/members/:employeeEmail", o.teams.RemoveMemberHandler)
+		}
+	}
+
+	if o.orgChart != nil {
+		orgChartRoutes := r.Group("/orgchart", tenanted)
+		{
+			orgChartRoutes.POST("/snapshots", o.orgChart.CreateSnapshotHandler)
+			orgChartRoutes.GET("/snapshots/:snapshotId", o.orgChart.GetSnapshotHandler)
+			orgChartRoutes.POST("/diff", o.orgChart.DiffHandler)
+			orgChartRoutes.GET("/export", o.orgChart.ExportHandler)
+		}
+	}
+
+	if o.adminController != nil {
+		// The audit log is kept per tenant, so it is read for the tenant of the request, who must be an admin of it.
+		r.GET("/admin/audit", tenanted, admin, o.adminController.AuditLogHandler)
+		adminRoutes := r.Group("/admin", operators, admin)
+		{
+			adminRoutes.GET("/slo", o.adminController.SLOHandler)
+			adminRoutes.GET("/clients", o.adminController.ListClientsHandler)
+			adminRoutes.GET("/clients/:language", o.adminController.DownloadClientHandler)
+			adminRoutes.GET("/inflight", o.adminController.InFlightHandler)
+			if o.adminController.ReadOnly != nil {
+				adminRoutes.GET("/read-only", o.adminController.GetReadOnlyModeHandler)
+				adminRoutes.PUT("/read-only", o.adminController.SetReadOnlyModeHandler)
+			}
+			if o.adminController.LogLevel != nil {
+				adminRoutes.GET("/loglevel", o.adminController.GetLogLevelHandler)
+				adminRoutes.PUT("/loglevel", o.adminController.SetLogLevelHandler)
+			}
+		}
+	}
+
 	return r
 }
 
-// SetupServer creates and returns an HTTP server configured with your router.
-func SetupServer(empController *controllers.EmployeeController) *http.Server {
-	router := SetupRouter(empController)
+// DefaultAddr is the address the server listens on unless told otherwise: port 8080 on every interface.
+const DefaultAddr = ":8080"
+
+// SetupServer creates and returns an HTTP server listening on addr, or DefaultAddr when addr is empty, configured
+// with your router.
+func SetupServer(addr string, empController *controllers.EmployeeController, opts ...Option) *http.Server {
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	router := SetupRouter(empController, opts...)
 	return &http.Server{
-		Addr:    ":8080", // You can parameterize this if needed.
+		Addr:    addr,
 		Handler: router,
 	}
 }
+
+// ParseAddr returns the address to listen on given as a host and port, such as "127.0.0.1:8080" to accept local
+// connections only, or as a port alone, such as "9090", on every interface. Port 0 lets the system choose a free port.
+func ParseAddr(addr string) (string, error) {
+	if !strings.Contains(addr, ":") {
+		addr = ":" + addr
+	}
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		return "", fmt.Errorf("invalid port %q", port)
+	}
+	return addr, nil
+}
@@ -0,0 +1,33 @@
+package router
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWithAccessLogExclude(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// The access log writes to the default logger.
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	r := SetupRouter(&controllers.EmployeeController{}, WithAccessLogExclude("/metrics"))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if strings.Contains(buf.String(), "Request served") {
+		t.Fatalf("expected /metrics not to be logged, got %s", buf.String())
+	}
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	if !strings.Contains(buf.String(), "Request served") || !strings.Contains(buf.String(), "path=/unknown") {
+		t.Errorf("expected /unknown to be logged, got %s", buf.String())
+	}
+}
@@ -0,0 +1,27 @@
+#!/usr/bin/env bash
+# Generates TypeScript and Java client bundles from the OpenAPI document compiled into the server.
+# Usage: scripts/generate-clients.sh [output-dir]   (default: build/clients)
+set -euo pipefail
+
+OUT_DIR="${1:-build/clients}"
+GENERATOR_IMAGE="${OPENAPI_GENERATOR_IMAGE:-openapitools/openapi-generator-cli:v7.12.0}"
+WORK_DIR="$(mktemp -d)"
+trap 'rm -rf "$WORK_DIR"' EXIT
+
+mkdir -p "$OUT_DIR"
+go run ./cmd/openapi > "$WORK_DIR/openapi.json"
+
+generate() {
+  local language="$1" generator="$2"
+  docker run --rm -v "$WORK_DIR:/local" "$GENERATOR_IMAGE" generate \
+    -i /local/openapi.json -g "$generator" -o "/local/$language"
+  (cd "$WORK_DIR/$language" && zip -qr "$WORK_DIR/$language.zip" .)
+  mv "$WORK_DIR/$language.zip" "$OUT_DIR/$language.zip"
+}
+
+generate typescript typescript-fetch
+generate java java
+
+# The server only serves bundles whose openapi.json matches its own document.
+cp "$WORK_DIR/openapi.json" "$OUT_DIR/openapi.json"
+echo "Client bundles written to $OUT_DIR"
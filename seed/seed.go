@@ -0,0 +1,134 @@
+// Package seed reads fixtures of employees, such as the demo organization embedded in the binary, for new
+// environments and demos to start with realistic data.
+package seed
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"WebMVCEmployees/models"
+)
+
+// Demo names the fixture embedded in the binary: a small organization of three teams under a CEO, with managers,
+// roles and skills.
+const Demo = "demo"
+
+//go:embed demo.json
+var demo []byte
+
+// Load reads the employees of source, either Demo or the path of a JSON or CSV file, in the order they are listed.
+func Load(source string) ([]models.Employee, error) {
+	if source == Demo {
+		return parseJSON(demo)
+	}
+	switch strings.ToLower(filepath.Ext(source)) {
+	case ".json":
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+		return parseJSON(data)
+	case ".csv":
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return parseCSV(f)
+	}
+	return nil, fmt.Errorf("seed %s: expected %q, a .json or a .csv file", source, Demo)
+}
+
+// parseJSON reads an array of employees in the format of the body of POST /employees.
+func parseJSON(data []byte) ([]models.Employee, error) {
+	var boundaries []models.NewEmployeeBoundary
+	if err := json.Unmarshal(data, &boundaries); err != nil {
+		return nil, err
+	}
+	emps := make([]models.Employee, len(boundaries))
+	for i, b := range boundaries {
+		emps[i] = b.ToEmployee()
+	}
+	return emps, nil
+}
+
+// csvColumns sets the field of an employee each CSV column holds.
+var csvColumns = map[string]func(emp *models.Employee, value string) error{
+	"email":         func(emp *models.Employee, value string) error { emp.Email = value; return nil },
+	"name":          func(emp *models.Employee, value string) error { emp.Name = value; return nil },
+	"preferredName": func(emp *models.Employee, value string) error { emp.PreferredName = value; return nil },
+	"pronouns":      func(emp *models.Employee, value string) error { emp.Pronouns = value; return nil },
+	"password":      func(emp *models.Employee, value string) error { emp.Password = value; return nil },
+	"birthdate": func(emp *models.Employee, value string) error {
+		parts := strings.Split(value, "-")
+		if len(parts) != 3 {
+			return fmt.Errorf("birthdate %q is not formatted as YYYY-MM-DD", value)
+		}
+		emp.Birthdate = models.Birthdate{Year: parts[0], Month: parts[1], Day: parts[2]}
+		return nil
+	},
+	"roles": func(emp *models.Employee, value string) error {
+		emp.Roles = []string{}
+		for _, role := range strings.Split(value, ";") {
+			if role = strings.TrimSpace(role); role != "" {
+				emp.Roles = append(emp.Roles, role)
+			}
+		}
+		return nil
+	},
+	"manager": func(emp *models.Employee, value string) error {
+		if value != "" {
+			emp.Manager = &value
+		}
+		return nil
+	},
+	"phone":      func(emp *models.Employee, value string) error { emp.Phone = value; return nil },
+	"department": func(emp *models.Employee, value string) error { emp.Department = value; return nil },
+	"hireDate":   func(emp *models.Employee, value string) error { emp.HireDate = value; return nil },
+	"title":      func(emp *models.Employee, value string) error { emp.Title = value; return nil },
+	"grade":      func(emp *models.Employee, value string) error { emp.Grade = value; return nil },
+	"location":   func(emp *models.Employee, value string) error { emp.Location = value; return nil },
+	"costCenter": func(emp *models.Employee, value string) error { emp.CostCenter = value; return nil },
+	"timezone":   func(emp *models.Employee, value string) error { emp.Timezone = value; return nil },
+	"locale":     func(emp *models.Employee, value string) error { emp.Locale = value; return nil },
+}
+
+// parseCSV reads a CSV file whose header names a column of csvColumns for each field, such as
+// email,name,password,birthdate,roles. Birthdates are formatted as YYYY-MM-DD and roles are separated by semicolons.
+func parseCSV(r io.Reader) ([]models.Employee, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range header {
+		if csvColumns[column] == nil {
+			return nil, fmt.Errorf("unknown CSV column %q", column)
+		}
+	}
+
+	var emps []models.Employee
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return emps, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		emp := models.Employee{Roles: []string{}}
+		for i, value := range record {
+			if err := csvColumns[header[i]](&emp, strings.TrimSpace(value)); err != nil {
+				line, _ := reader.FieldPos(i)
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		emps = append(emps, emp)
+	}
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+)
+
+// GetAnniversaries returns the employees whose work anniversary falls in the month of the current year, with the
+// years of service they complete, ordered by day of the month and then by email. Employees hired this year or
+// without a hire date have no anniversary yet. Anniversaries of hires on February 29 fall on that day even in
+// common years.
+func (s *EmployeeService) GetAnniversaries(ctx context.Context, month time.Month, now time.Time, page, size int) ([]models.Anniversary, error) {
+	anniversaries, err := s.Store.Anniversaries(ctx, repository.EmployeeCriteria{}, month, now.Year(),
+		repository.Page{Skip: int64((page - 1) * size), Limit: int64(size)})
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return anniversaries, nil
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// AttendanceService records when employees check in and out, and summarizes the time they worked.
+type AttendanceService struct {
+	Employees  EmployeeStore
+	Attendance *repository.AttendanceRepository
+}
+
+// NewAttendanceService creates a new AttendanceService using the provided repositories.
+func NewAttendanceService(employees EmployeeStore, attendance *repository.AttendanceRepository) *AttendanceService {
+	return &AttendanceService{
+		Employees:  employees,
+		Attendance: attendance,
+	}
+}
+
+// CheckIn opens an attendance record of the employee at now. It fails with 409 while the employee is checked in.
+func (s *AttendanceService) CheckIn(ctx context.Context, email string, now time.Time) (models.AttendanceRecord, error) {
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.AttendanceRecord{}, err
+	}
+	record := models.AttendanceRecord{
+		ID:       bson.NewObjectID().Hex(),
+		Employee: email,
+		CheckIn:  now,
+		Open:     true,
+	}
+	coll, err := s.Attendance.Collection.In(ctx)
+	if err != nil {
+		return models.AttendanceRecord{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.InsertOne(ctx, record); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.AttendanceRecord{}, errors.NewCodedError(http.StatusConflict, errors.CodeAlreadyCheckedIn, "employee is already checked in")
+		}
+		return models.AttendanceRecord{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return record, nil
+}
+
+// CheckOut closes the open attendance record of the employee at now. It fails with 409 unless the employee is
+// checked in.
+func (s *AttendanceService) CheckOut(ctx context.Context, email string, now time.Time) (models.AttendanceRecord, error) {
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.AttendanceRecord{}, err
+	}
+	var record models.AttendanceRecord
+	coll, err := s.Attendance.Collection.In(ctx)
+	if err != nil {
+		return models.AttendanceRecord{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOneAndUpdate(ctx,
+		bson.M{"employee": email, "open": true},
+		bson.M{"$set": bson.M{"checkOut": now}, "$unset": bson.M{"open": ""}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&record)
+	if err == mongo.ErrNoDocuments {
+		return models.AttendanceRecord{}, errors.NewCodedError(http.StatusConflict, errors.CodeNotCheckedIn, "employee is not checked in")
+	}
+	if err != nil {
+		return models.AttendanceRecord{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return record, nil
+}
+
+// GetAttendanceSummary returns the time the employee worked in the day or week, starting on Monday, containing date.
+// Records count toward the day they were checked in on, in UTC; open records count up to now.
+func (s *AttendanceService) GetAttendanceSummary(ctx context.Context, email, period string, date, now time.Time) (models.AttendanceSummary, error) {
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.AttendanceSummary{}, err
+	}
+	summaries, err := s.summarize(ctx, []string{email}, period, date, now)
+	if err != nil {
+		return models.AttendanceSummary{}, err
+	}
+	return summaries[0], nil
+}
+
+// GetTeamAttendance returns the attendance summaries of a page of the manager's direct reports, ordered by email.
+// Terminated employees are left out.
+func (s *AttendanceService) GetTeamAttendance(ctx context.Context, managerEmail, period string, date, now time.Time, page, size int) ([]models.AttendanceSummary, error) {
+	if err := ensureEmployee(ctx, s.Employees, managerEmail); err != nil {
+		return nil, err
+	}
+	reports, err := s.Employees.List(ctx, repository.EmployeeCriteria{Managers: []string{managerEmail}}, repository.Page{
+		Sort:  []repository.SortKey{{Field: models.EmployeeRef.Email}},
+		Skip:  int64((page - 1) * size),
+		Limit: int64(size),
+	})
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	emails := make([]string, len(reports))
+	for i, report := range reports {
+		emails[i] = report.Email
+	}
+	return s.summarize(ctx, emails, period, date, now)
+}
+
+// summarize returns the attendance summary of each employee, in order, for the period containing date.
+func (s *AttendanceService) summarize(ctx context.Context, emails []string, period string, date, now time.Time) ([]models.AttendanceSummary, error) {
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	days := 1
+	if period == models.AttendanceWeekly {
+		// Weekdays count from Sunday, so Monday is 1 and Sunday 0 goes back six days.
+		from = from.AddDate(0, 0, -(int(from.Weekday())+6)%7)
+		days = 7
+	}
+	to := from.AddDate(0, 0, days)
+
+	summaries := make([]models.AttendanceSummary, len(emails))
+	index := make(map[string]int, len(emails))
+	for i, email := range emails {
+		index[email] = i
+		summaries[i] = models.AttendanceSummary{
+			Employee: email,
+			Period:   period,
+			From:     from.Format(time.DateOnly),
+			To:       to.AddDate(0, 0, -1).Format(time.DateOnly),
+			Days:     make([]models.AttendanceDay, days),
+		}
+		for d := range summaries[i].Days {
+			summaries[i].Days[d].Date = from.AddDate(0, 0, d).Format(time.DateOnly)
+		}
+	}
+	if len(emails) == 0 {
+		return summaries, nil
+	}
+
+	filter := bson.M{"employee": bson.M{"$in": emails}, "checkIn": bson.M{"$gte": from, "$lt": to}}
+	coll, err := s.Attendance.Collection.In(ctx)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx, filter)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	var records []models.AttendanceRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	for _, record := range records {
+		summary := &summaries[index[record.Employee]]
+		day := &summary.Days[int(record.CheckIn.UTC().Sub(from)/(24*time.Hour))]
+		end := now
+		if record.CheckOut != nil {
+			end = *record.CheckOut
+		} else {
+			day.CheckedIn = true
+		}
+		minutes := int(end.Sub(record.CheckIn) / time.Minute)
+		day.Minutes += minutes
+		day.Records++
+		summary.TotalMinutes += minutes
+	}
+	return summaries, nil
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// Authenticate returns the effective roles of the employee with the given email and password, including the roles
+// implied through the catalog. Unknown credentials and terminated employees are not authenticated.
+func (s *EmployeeService) Authenticate(ctx context.Context, email, password string) ([]string, bool, error) {
+	emp, err := s.GetEmployee(ctx, email, password)
+	if httpErr, ok := err.(*errors.HTTPError); ok && httpErr.Code == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if emp.EmploymentStatus() == models.StatusTerminated {
+		return nil, false, nil
+	}
+	catalog, err := loadRoleCatalog(ctx, s.RoleCatalog)
+	if err != nil {
+		return nil, false, err
+	}
+	return catalog.implied(emp.Roles), true, nil
+}
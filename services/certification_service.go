@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CertificationService manages the certifications held by employees.
+type CertificationService struct {
+	Employees      EmployeeStore
+	Certifications *repository.CertificationRepository
+}
+
+// NewCertificationService creates a new CertificationService using the provided repositories.
+func NewCertificationService(employees EmployeeStore, certifications *repository.CertificationRepository) *CertificationService {
+	return &CertificationService{
+		Employees:      employees,
+		Certifications: certifications,
+	}
+}
+
+// AddCertification records a certification held by the employee.
+func (s *CertificationService) AddCertification(ctx context.Context, email string, req models.CertificationRequest, now time.Time) (models.Certification, error) {
+	if err := validateStruct(req); err != nil {
+		return models.Certification{}, err
+	}
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.Certification{}, err
+	}
+
+	cert := models.Certification{
+		ID:        bson.NewObjectID().Hex(),
+		Employee:  email,
+		Name:      req.Name,
+		Issuer:    req.Issuer,
+		ExpiresAt: req.ExpiresAt,
+		UpdatedAt: now,
+	}
+	coll, err := s.Certifications.Collection.In(ctx)
+	if err != nil {
+		return models.Certification{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.InsertOne(ctx, cert); err != nil {
+		return models.Certification{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return cert, nil
+}
+
+// ListCertifications returns a page of the employee's certifications: those that never expire, then the others
+// by expiry day.
+func (s *CertificationService) ListCertifications(ctx context.Context, email string, page, size int) ([]models.Certification, error) {
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return nil, err
+	}
+	return s.findCertifications(ctx, bson.M{"employee": email}, page, size)
+}
+
+// UpdateCertification replaces one of the employee's certifications, typically with the expiry of its renewal.
+func (s *CertificationService) UpdateCertification(ctx context.Context, email, id string, req models.CertificationRequest, now time.Time) (models.Certification, error) {
+	if err := validateStruct(req); err != nil {
+		return models.Certification{}, err
+	}
+	cert := models.Certification{
+		ID:        id,
+		Employee:  email,
+		Name:      req.Name,
+		Issuer:    req.Issuer,
+		ExpiresAt: req.ExpiresAt,
+		UpdatedAt: now,
+	}
+	coll, err := s.Certifications.Collection.In(ctx)
+	if err != nil {
+		return models.Certification{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.ReplaceOne(ctx, bson.M{"_id": id, "employee": email}, cert)
+	if err != nil {
+		return models.Certification{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.MatchedCount == 0 {
+		return models.Certification{}, errors.NewCodedError(http.StatusNotFound, errors.CodeCertificationNotFound, "certification not found")
+	}
+	return cert, nil
+}
+
+// DeleteCertification removes one of the employee's certifications.
+func (s *CertificationService) DeleteCertification(ctx context.Context, email, id string) error {
+	coll, err := s.Certifications.Collection.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.DeleteOne(ctx, bson.M{"_id": id, "employee": email})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.DeletedCount == 0 {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeCertificationNotFound, "certification not found")
+	}
+	return nil
+}
+
+// GetExpiringCertifications returns a page of the certifications of all employees expiring by now+within,
+// soonest first. Certifications that have already expired are included, since they are due for renewal as well.
+func (s *CertificationService) GetExpiringCertifications(ctx context.Context, within time.Duration, now time.Time, page, size int) ([]models.Certification, error) {
+	// Expiry days are formatted as YYYY-MM-DD, so they are compared as strings.
+	filter := bson.M{"expiresAt": bson.M{"$exists": true, "$lte": now.Add(within).Format(time.DateOnly)}}
+	return s.findCertifications(ctx, filter, page, size)
+}
+
+// findCertifications returns a page of the certifications matching the filter, ordered by expiry day.
+func (s *CertificationService) findCertifications(ctx context.Context, filter bson.M, page, size int) ([]models.Certification, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "expiresAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64((page - 1) * size)).
+		SetLimit(int64(size))
+	coll, err := s.Certifications.Collection.In(ctx)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer cursor.Close(ctx)
+
+	certifications := []models.Certification{}
+	if err := cursor.All(ctx, &certifications); err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return certifications, nil
+}
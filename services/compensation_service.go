@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CompensationService manages the salaries of employees.
+type CompensationService struct {
+	Employees    EmployeeStore
+	Compensation *repository.CompensationRepository
+}
+
+// NewCompensationService creates a new CompensationService using the provided repositories.
+func NewCompensationService(employees EmployeeStore, compensation *repository.CompensationRepository) *CompensationService {
+	return &CompensationService{
+		Employees:    employees,
+		Compensation: compensation,
+	}
+}
+
+// SetCompensation records the salary of the employee from req.EffectiveDate, replacing the salary recorded for
+// that same date, and returns the resulting compensation as of now.
+func (s *CompensationService) SetCompensation(ctx context.Context, email string, req models.CompensationRequest, recordedBy string, now time.Time) (models.Compensation, error) {
+	if err := validateStruct(req); err != nil {
+		return models.Compensation{}, err
+	}
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.Compensation{}, err
+	}
+
+	record := models.CompensationRecord{
+		Employee:      email,
+		Salary:        req.Salary,
+		Currency:      req.Currency,
+		EffectiveDate: req.EffectiveDate,
+		RecordedAt:    now,
+		RecordedBy:    recordedBy,
+	}
+	filter := bson.M{"employee": email, "effectiveDate": req.EffectiveDate}
+	coll, err := s.Compensation.Collection.In(ctx)
+	if err != nil {
+		return models.Compensation{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.ReplaceOne(ctx, filter, record, options.Replace().SetUpsert(true)); err != nil {
+		return models.Compensation{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return s.GetCompensation(ctx, email, now)
+}
+
+// GetCompensation returns the salary of the employee in effect at now, with every salary recorded for them.
+func (s *CompensationService) GetCompensation(ctx context.Context, email string, now time.Time) (models.Compensation, error) {
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.Compensation{}, err
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "effectiveDate", Value: -1}}).
+		SetProjection(bson.M{"_id": 0})
+	coll, err := s.Compensation.Collection.In(ctx)
+	if err != nil {
+		return models.Compensation{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx, bson.M{"employee": email}, findOptions)
+	if err != nil {
+		return models.Compensation{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer cursor.Close(ctx)
+
+	compensation := models.Compensation{History: []models.CompensationRecord{}}
+	if err := cursor.All(ctx, &compensation.History); err != nil {
+		return models.Compensation{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	// Effective dates sort chronologically, so the first one not after today is current.
+	today := now.Format(time.DateOnly)
+	for i, record := range compensation.History {
+		if record.EffectiveDate <= today {
+			compensation.Current = &compensation.History[i]
+			break
+		}
+	}
+	return compensation, nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+)
+
+// e164Pattern matches phone numbers in E.164 format: a plus sign and up to 15 digits, without a leading zero.
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// GetEmployeesByCountry returns employees whose address is in the given country, ordered by the given sort key.
+// The country code is matched regardless of case.
+func (s *EmployeeService) GetEmployeesByCountry(ctx context.Context, country string, page, size int, sortBy string) ([]models.Employee, error) {
+	return s.findEmployees(ctx, repository.EmployeeCriteria{Country: strings.ToUpper(country)}, page, size, sortBy)
+}
+
+// GetEmployeesByCity returns employees whose address is in the given city, ignoring case, ordered by the given sort key.
+func (s *EmployeeService) GetEmployeesByCity(ctx context.Context, city string, page, size int, sortBy string) ([]models.Employee, error) {
+	return s.findEmployees(ctx, repository.EmployeeCriteria{City: city}, page, size, sortBy)
+}
+
+// findEmployees returns a page of the employees matching the criteria, neither soft-deleted nor terminated, without their passwords.
+func (s *EmployeeService) findEmployees(ctx context.Context, criteria repository.EmployeeCriteria, page, size int, sortBy string) ([]models.Employee, error) {
+	criteria.State = repository.Listed
+	employees, err := s.Store.List(ctx, criteria, s.paged(sortBy, page, size))
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if employees == nil {
+		employees = []models.Employee{}
+	}
+	return employees, nil
+}
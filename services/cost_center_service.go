@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+)
+
+// validateCostCenter checks that the cost center an employee is charged to is one of the configured ones.
+func (s *EmployeeService) validateCostCenter(costCenter string) error {
+	if costCenter == "" || len(s.CostCenters) == 0 || slices.Contains(s.CostCenters, costCenter) {
+		return nil
+	}
+	return errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidCostCenter,
+		"costCenter must be one of ["+strings.Join(s.CostCenters, ", ")+"]")
+}
+
+// GetEmployeesByCostCenter returns the employees charged to the given cost center, ordered by the given sort key.
+func (s *EmployeeService) GetEmployeesByCostCenter(ctx context.Context, costCenter string, page, size int, sortBy string) ([]models.Employee, error) {
+	return s.findEmployees(ctx, repository.EmployeeCriteria{CostCenter: costCenter}, page, size, sortBy)
+}
+
+// GetHeadcountByCostCenter counts the employees on the payroll charged to each cost center, ordered by cost
+// center. Employees charged to none are counted under an empty cost center, listed first, and configured cost
+// centers nobody is charged to are listed with a headcount of 0.
+func (s *EmployeeService) GetHeadcountByCostCenter(ctx context.Context) ([]models.CostCenterHeadcount, error) {
+	headcounts, err := s.Store.HeadcountByCostCenter(ctx, repository.EmployeeCriteria{})
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	for _, costCenter := range s.CostCenters {
+		if !slices.ContainsFunc(headcounts, func(h models.CostCenterHeadcount) bool { return h.CostCenter == costCenter }) {
+			headcounts = append(headcounts, models.CostCenterHeadcount{CostCenter: costCenter})
+		}
+	}
+	slices.SortFunc(headcounts, func(a, b models.CostCenterHeadcount) int { return strings.Compare(a.CostCenter, b.CostCenter) })
+	return headcounts, nil
+}
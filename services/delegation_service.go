@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// SetDelegation hands a manager's duties to a delegate for the delegation window, replacing any earlier delegation.
+func (s *EmployeeService) SetDelegation(ctx context.Context, managerEmail string, delegation models.Delegation, now time.Time) (models.Employee, error) {
+	if delegation.Delegate == "" {
+		return models.Employee{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeDelegateNotFound, "delegate is required")
+	}
+	if delegation.Delegate == managerEmail {
+		return models.Employee{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeDelegateSelf, "a manager cannot delegate to themselves")
+	}
+	if !delegation.EndsAt.After(delegation.StartsAt) || !delegation.EndsAt.After(now) {
+		return models.Employee{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidDelegationWindow, "endsAt must be after startsAt and in the future")
+	}
+	count, err := s.Store.Count(ctx, repository.EmployeeCriteria{State: repository.Active, Emails: []string{delegation.Delegate}})
+	if err != nil {
+		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if count == 0 {
+		return models.Employee{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeDelegateNotFound, "delegate not found")
+	}
+
+	manager, err := s.Store.SetDelegation(ctx,
+		repository.EmployeeCriteria{State: repository.Active, Emails: []string{managerEmail}}, delegation)
+	if err == mongo.ErrNoDocuments {
+		return models.Employee{}, errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
+	if err != nil {
+		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	s.audit(ctx, models.AuditEntry{
+		Time:     now,
+		Action:   models.AuditDelegationSet,
+		Employee: managerEmail,
+		Details: map[string]string{
+			"delegate": delegation.Delegate,
+			"startsAt": delegation.StartsAt.UTC().Format(time.RFC3339),
+			"endsAt":   delegation.EndsAt.UTC().Format(time.RFC3339),
+		},
+	})
+	return manager, nil
+}
+
+// GetDelegation returns the delegation configured for a manager, whether or not it is currently active.
+func (s *EmployeeService) GetDelegation(ctx context.Context, managerEmail string) (models.Delegation, error) {
+	manager, err := s.Store.FindByEmail(ctx, managerEmail)
+	if err == mongo.ErrNoDocuments {
+		return models.Delegation{}, errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
+	if err != nil {
+		return models.Delegation{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if manager.Delegation == nil {
+		return models.Delegation{}, errors.NewCodedError(http.StatusNotFound, errors.CodeDelegationNotSet, "delegation not set")
+	}
+	return *manager.Delegation, nil
+}
+
+// RemoveDelegation ends a manager's delegation immediately.
+func (s *EmployeeService) RemoveDelegation(ctx context.Context, managerEmail string, now time.Time) error {
+	removed, err := s.Store.RemoveDelegation(ctx,
+		repository.EmployeeCriteria{State: repository.Active, Emails: []string{managerEmail}})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if removed == 0 {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeDelegationNotSet, "delegation not set")
+	}
+	s.audit(ctx, models.AuditEntry{Time: now, Action: models.AuditDelegationRemoved, Employee: managerEmail})
+	return nil
+}
+
+// ResolveApprover returns the employee who approves requests for employeeEmail at now: their manager,
+// or the manager's delegate while a delegation is active. Delegations are not followed transitively.
+func (s *EmployeeService) ResolveApprover(ctx context.Context, employeeEmail string, now time.Time) (models.Employee, error) {
+	manager, err := s.GetManager(ctx, employeeEmail)
+	if err != nil {
+		return models.Employee{}, err
+	}
+	if !manager.Delegation.ActiveAt(now) {
+		return manager, nil
+	}
+	delegate, err := s.Store.FindByEmail(ctx, manager.Delegation.Delegate)
+	if err == mongo.ErrNoDocuments {
+		// The delegate left; duties stay with the manager.
+		return manager, nil
+	}
+	if err != nil {
+		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return delegate, nil
+}
+
+// delegatorsOf returns the emails of managers whose duties are delegated to delegateEmail at now.
+func (s *EmployeeService) delegatorsOf(ctx context.Context, delegateEmail string, now time.Time) ([]string, error) {
+	criteria := repository.EmployeeCriteria{State: repository.Active, DelegatedTo: delegateEmail, DelegatedAt: now}
+	managers, err := s.Store.List(ctx, criteria, repository.Page{})
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	emails := make([]string, 0, len(managers))
+	for _, manager := range managers {
+		emails = append(emails, manager.Email)
+	}
+	return emails, nil
+}
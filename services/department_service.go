@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// DepartmentService manages the departments employees belong to.
+type DepartmentService struct {
+	Employees   EmployeeStore
+	Departments *repository.DepartmentRepository
+}
+
+// NewDepartmentService creates a new DepartmentService using the provided repositories.
+func NewDepartmentService(employees EmployeeStore, departments *repository.DepartmentRepository) *DepartmentService {
+	return &DepartmentService{
+		Employees:   employees,
+		Departments: departments,
+	}
+}
+
+// CreateDepartment stores a new department, failing with 409 when one with the same name exists.
+func (s *DepartmentService) CreateDepartment(ctx context.Context, dept models.Department) (models.Department, error) {
+	if err := validateStruct(dept); err != nil {
+		return models.Department{}, err
+	}
+	coll, err := s.Departments.Collection.In(ctx)
+	if err != nil {
+		return models.Department{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.InsertOne(ctx, dept); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Department{}, errors.NewCodedError(http.StatusConflict, errors.CodeDepartmentExists, "a department with this name already exists")
+		}
+		return models.Department{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return dept, nil
+}
+
+// GetDepartment returns the department with the given name.
+func (s *DepartmentService) GetDepartment(ctx context.Context, name string) (models.Department, error) {
+	var dept models.Department
+	coll, err := s.Departments.Collection.In(ctx)
+	if err != nil {
+		return models.Department{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOne(ctx, bson.M{"_id": name}).Decode(&dept)
+	if err == mongo.ErrNoDocuments {
+		return models.Department{}, errors.NewCodedError(http.StatusNotFound, errors.CodeDepartmentNotFound, "department not found")
+	}
+	if err != nil {
+		return models.Department{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return dept, nil
+}
+
+// ListDepartments returns a page of the departments ordered by name.
+func (s *DepartmentService) ListDepartments(ctx context.Context, page, size int) ([]models.Department, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetSkip(int64((page - 1) * size)).
+		SetLimit(int64(size))
+	coll, err := s.Departments.Collection.In(ctx)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer cursor.Close(ctx)
+
+	departments := []models.Department{}
+	if err := cursor.All(ctx, &departments); err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return departments, nil
+}
+
+// UpdateDepartment replaces the description of a department. The name cannot be changed, since employees
+// reference it; a body naming another department is rejected with 400.
+func (s *DepartmentService) UpdateDepartment(ctx context.Context, name string, dept models.Department) (models.Department, error) {
+	if dept.Name == "" {
+		dept.Name = name
+	}
+	if dept.Name != name {
+		return models.Department{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeDepartmentNameChange, "the name of a department cannot be changed")
+	}
+	if err := validateStruct(dept); err != nil {
+		return models.Department{}, err
+	}
+	coll, err := s.Departments.Collection.In(ctx)
+	if err != nil {
+		return models.Department{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.ReplaceOne(ctx, bson.M{"_id": name}, dept)
+	if err != nil {
+		return models.Department{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.MatchedCount == 0 {
+		return models.Department{}, errors.NewCodedError(http.StatusNotFound, errors.CodeDepartmentNotFound, "department not found")
+	}
+	return dept, nil
+}
+
+// DeleteDepartment removes a department. Departments still referenced by an employee, including
+// soft-deleted ones that may be restored, are kept and reported with 409.
+func (s *DepartmentService) DeleteDepartment(ctx context.Context, name string) error {
+	members, err := s.Employees.Count(ctx, repository.EmployeeCriteria{State: repository.AnyState, Department: name})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if members > 0 {
+		return errors.NewCodedError(http.StatusConflict, errors.CodeDepartmentInUse,
+			"department has "+strconv.FormatInt(members, 10)+" employees; move them to another department first")
+	}
+	coll, err := s.Departments.Collection.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.DeletedCount == 0 {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeDepartmentNotFound, "department not found")
+	}
+	return nil
+}
+
+// validateDepartment checks that the department an employee references exists.
+func (s *EmployeeService) validateDepartment(ctx context.Context, name string) error {
+	if name == "" || s.Departments == nil {
+		return nil
+	}
+	coll, err := s.Departments.Collection.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	count, err := coll.CountDocuments(ctx, bson.M{"_id": name})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if count == 0 {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeDepartmentNotFound, "department not found")
+	}
+	return nil
+}
+
+// GetEmployeesByDepartment returns the employees of the given department, ordered by the given sort key.
+func (s *EmployeeService) GetEmployeesByDepartment(ctx context.Context, department string, page, size int, sortBy string) ([]models.Employee, error) {
+	return s.findEmployees(ctx, repository.EmployeeCriteria{Department: department}, page, size, sortBy)
+}
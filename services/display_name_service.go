@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/repository"
+)
+
+// DeriveDisplayNames stores the display name of the employees written before display names were introduced, and
+// returns how many employees received one. Such employees have no preferred name, so they display their legal name.
+func (s *EmployeeService) DeriveDisplayNames(ctx context.Context) (int64, error) {
+	derived, err := s.Store.DeriveDisplayNames(ctx, repository.EmployeeCriteria{State: repository.AnyState})
+	if err != nil {
+		return 0, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return derived, nil
+}
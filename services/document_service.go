@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Defaults of the DocumentService.
+const (
+	// DefaultMaxDocumentBytes is the largest document accepted, in bytes, unless configured otherwise.
+	DefaultMaxDocumentBytes = 1 << 20
+	// DefaultDocumentURLTTL is how long a signed download link works, unless configured otherwise.
+	DefaultDocumentURLTTL = 15 * time.Minute
+)
+
+// documentTypes lists the accepted document content types.
+var documentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// DocumentCategories lists the accepted document categories.
+var DocumentCategories = []string{models.DocumentContract, models.DocumentID, models.DocumentOther}
+
+// DocumentService stores documents attached to employees and hands out signed links to download them.
+type DocumentService struct {
+	Employees EmployeeStore
+	Documents *repository.DocumentRepository
+	// MaxBytes is the largest document accepted, in bytes.
+	MaxBytes int64
+	// URLTTL is how long a signed download link works.
+	URLTTL time.Duration
+	// secret keys the signatures of the download links.
+	secret []byte
+}
+
+// NewDocumentService creates a new DocumentService using the provided repositories. Download links are signed
+// with secret, so they stop working when it changes.
+func NewDocumentService(employees EmployeeStore, documents *repository.DocumentRepository, secret []byte) *DocumentService {
+	return &DocumentService{
+		Employees: employees,
+		Documents: documents,
+		MaxBytes:  DefaultMaxDocumentBytes,
+		URLTTL:    DefaultDocumentURLTTL,
+		secret:    secret,
+	}
+}
+
+// signature returns the signature of a link to the document expiring at the given Unix time.
+func (s *DocumentService) signature(id string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(id + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// withDownloadURL returns the document with a signed download link expiring URLTTL after now.
+func (s *DocumentService) withDownloadURL(doc models.Document, now time.Time) models.Document {
+	expiresAt := now.Add(s.URLTTL).Truncate(time.Second)
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", s.signature(doc.ID, expiresAt.Unix()))
+	doc.DownloadURL = "/documents/" + doc.ID + "/download?" + query.Encode()
+	doc.DownloadURLExpiresAt = &expiresAt
+	return doc
+}
+
+// UploadDocument validates a PDF, JPEG or PNG file and stores it as a document of the employee.
+// The type is detected from the content.
+func (s *DocumentService) UploadDocument(ctx context.Context, email, name, category string, data []byte, now time.Time) (models.Document, error) {
+	if int64(len(data)) > s.MaxBytes {
+		return models.Document{}, errors.NewCodedError(http.StatusRequestEntityTooLarge, errors.CodeDocumentTooLarge,
+			fmt.Sprintf("document must not exceed %d bytes", s.MaxBytes))
+	}
+	contentType := http.DetectContentType(data)
+	if !documentTypes[contentType] {
+		return models.Document{}, errors.NewCodedError(http.StatusUnsupportedMediaType, errors.CodeDocumentUnsupportedType,
+			"document must be a PDF, JPEG or PNG file")
+	}
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.Document{}, err
+	}
+
+	doc := models.Document{Employee: email, Name: name, Category: category, ContentType: contentType}
+	doc, err := s.Documents.Save(ctx, doc, data)
+	if err != nil {
+		return models.Document{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return s.withDownloadURL(doc, now), nil
+}
+
+// ListDocuments returns a page of the employee's documents, newest first, each with a fresh download link.
+func (s *DocumentService) ListDocuments(ctx context.Context, email string, page, size int, now time.Time) ([]models.Document, error) {
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return nil, err
+	}
+	docs, err := s.Documents.List(ctx, email, (page-1)*size, size)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	for i := range docs {
+		docs[i] = s.withDownloadURL(docs[i], now)
+	}
+	return docs, nil
+}
+
+// DownloadDocument returns a document and its content for a signed link. A tampered link fails with 403
+// DOCUMENT_LINK_INVALID and an expired one with 403 DOCUMENT_LINK_EXPIRED.
+func (s *DocumentService) DownloadDocument(ctx context.Context, id string, expires int64, signature string, now time.Time) (models.Document, []byte, error) {
+	if !hmac.Equal([]byte(signature), []byte(s.signature(id, expires))) {
+		return models.Document{}, nil, errors.NewCodedError(http.StatusForbidden, errors.CodeDocumentLinkInvalid, "download link is invalid")
+	}
+	if now.Unix() >= expires {
+		return models.Document{}, nil, errors.NewCodedError(http.StatusForbidden, errors.CodeDocumentLinkExpired, "download link has expired")
+	}
+	doc, data, err := s.Documents.Load(ctx, id)
+	if err == mongo.ErrFileNotFound {
+		return models.Document{}, nil, errors.NewCodedError(http.StatusNotFound, errors.CodeDocumentNotFound, "document not found")
+	}
+	if err != nil {
+		return models.Document{}, nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return doc, data, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// EmergencyContactService manages the emergency contacts of employees.
+type EmergencyContactService struct {
+	Employees EmployeeStore
+	Contacts  *repository.EmergencyContactRepository
+	// AccessRoles lists the roles allowed to read and replace the contacts of every employee, besides the employee.
+	AccessRoles []string
+}
+
+// NewEmergencyContactService creates a new EmergencyContactService using the provided repositories.
+func NewEmergencyContactService(employees EmployeeStore, contacts *repository.EmergencyContactRepository) *EmergencyContactService {
+	return &EmergencyContactService{
+		Employees: employees,
+		Contacts:  contacts,
+	}
+}
+
+// authorize fails with 403 unless the caller is the employee or holds one of the access roles.
+func (s *EmergencyContactService) authorize(email, caller string, callerRoles []string) error {
+	if caller == email || slices.ContainsFunc(callerRoles, func(role string) bool { return slices.Contains(s.AccessRoles, role) }) {
+		return nil
+	}
+	return errors.NewCodedError(http.StatusForbidden, errors.CodeForbidden,
+		"emergency contacts are only available to the employee and HR")
+}
+
+// SetEmergencyContacts replaces the emergency contacts of the employee on behalf of the caller.
+func (s *EmergencyContactService) SetEmergencyContacts(ctx context.Context, email, caller string, callerRoles []string, req models.EmergencyContactsRequest, now time.Time) (models.EmergencyContacts, error) {
+	if err := validateStruct(req); err != nil {
+		return models.EmergencyContacts{}, err
+	}
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.EmergencyContacts{}, err
+	}
+	if err := s.authorize(email, caller, callerRoles); err != nil {
+		return models.EmergencyContacts{}, err
+	}
+
+	contacts := models.EmergencyContacts{
+		Employee:  email,
+		Contacts:  req.Contacts,
+		UpdatedAt: &now,
+		UpdatedBy: caller,
+	}
+	if contacts.Contacts == nil {
+		contacts.Contacts = []models.EmergencyContact{}
+	}
+	coll, err := s.Contacts.Collection.In(ctx)
+	if err != nil {
+		return models.EmergencyContacts{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.ReplaceOne(ctx, bson.M{"_id": email}, contacts, options.Replace().SetUpsert(true)); err != nil {
+		return models.EmergencyContacts{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return contacts, nil
+}
+
+// GetEmergencyContacts returns the emergency contacts of the employee to the caller; an employee whose contacts
+// were never set has none.
+func (s *EmergencyContactService) GetEmergencyContacts(ctx context.Context, email, caller string, callerRoles []string) (models.EmergencyContacts, error) {
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.EmergencyContacts{}, err
+	}
+	if err := s.authorize(email, caller, callerRoles); err != nil {
+		return models.EmergencyContacts{}, err
+	}
+
+	var contacts models.EmergencyContacts
+	coll, err := s.Contacts.Collection.In(ctx)
+	if err != nil {
+		return models.EmergencyContacts{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOne(ctx, bson.M{"_id": email}).Decode(&contacts)
+	if err == mongo.ErrNoDocuments {
+		return models.EmergencyContacts{Employee: email, Contacts: []models.EmergencyContact{}}, nil
+	}
+	if err != nil {
+		return models.EmergencyContacts{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return contacts, nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+	"WebMVCEmployees/ulid"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// newEmployeeID returns a new internal employee ID.
+func newEmployeeID(now time.Time) string {
+	return ulid.New(now)
+}
+
+// GetEmployeeByID returns the employee with the given internal ID; a malformed ID matches no employee.
+func (s *EmployeeService) GetEmployeeByID(ctx context.Context, id string) (models.Employee, error) {
+	if !ulid.Valid(id) {
+		return models.Employee{}, errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
+	emp, err := s.Store.FindOne(ctx, repository.EmployeeCriteria{State: repository.Active, ID: ulid.Normalize(id)})
+	if err == mongo.ErrNoDocuments {
+		return models.Employee{}, errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
+	if err != nil {
+		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return emp, nil
+}
+
+// AssignMissingIDs gives an internal ID to each employee created before IDs were introduced, and returns how many
+// employees received one.
+func (s *EmployeeService) AssignMissingIDs(ctx context.Context, now time.Time) (int64, error) {
+	employees, err := s.Store.List(ctx, repository.EmployeeCriteria{State: repository.AnyState, MissingID: true}, repository.Page{})
+	if err != nil {
+		return 0, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var assigned int64
+	for _, emp := range employees {
+		criteria := repository.EmployeeCriteria{State: repository.AnyState, Emails: []string{emp.Email}, MissingID: true}
+		matched, err := s.Store.AssignID(ctx, criteria, newEmployeeID(now))
+		if err != nil {
+			return assigned, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		if matched {
+			assigned++
+		}
+	}
+	return assigned, nil
+}
+
+// ChangeEmail changes the email address of an employee, keeping their internal ID. Managers, dotted lines,
+// delegations and the records of the other subsystems referring to the old email are moved to the new one in the
+// same transaction. When expectedVersion is non-zero, the change only applies while the employee is at that version.
+func (s *EmployeeService) ChangeEmail(ctx context.Context, employeeEmail, newEmail string, expectedVersion int64, now time.Time) (models.Employee, error) {
+	if err := validateStruct(models.EmailChange{Email: newEmail}); err != nil {
+		return models.Employee{}, err
+	}
+	emp, err := s.findEmployee(ctx, employeeEmail)
+	if err != nil {
+		return models.Employee{}, err
+	}
+	if expectedVersion != 0 && emp.Version != expectedVersion {
+		return models.Employee{}, errors.NewCodedError(http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch, "employee was modified by another request")
+	}
+	if newEmail == employeeEmail {
+		return emp, nil
+	}
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		criteria := repository.EmployeeCriteria{State: repository.Active, Emails: []string{employeeEmail}, Version: emp.Version}
+		matched, err := s.Store.ChangeEmail(ctx, criteria, newEmail)
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.NewCodedError(http.StatusConflict, errors.CodeEmployeeDuplicateEmail, "employee with this email already exists")
+		}
+		if err != nil {
+			return err
+		}
+		if !matched {
+			return errors.NewCodedError(http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch, "employee was modified by another request")
+		}
+		return s.Store.MoveEmailReferences(ctx, repository.EmployeeCriteria{State: repository.AnyState}, employeeEmail, newEmail)
+	})
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	s.audit(ctx, models.AuditEntry{
+		Time:     now,
+		Action:   models.AuditEmailChanged,
+		Employee: newEmail,
+		Details:  models.AuditDetails{"from": employeeEmail},
+	})
+	emp.Email = newEmail
+	emp.Version++
+	return emp, nil
+}
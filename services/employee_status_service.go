@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// statusTransitions lists the statuses each employment status may change to.
+var statusTransitions = map[string][]string{
+	models.StatusActive:     {models.StatusOnLeave, models.StatusTerminated},
+	models.StatusOnLeave:    {models.StatusActive, models.StatusTerminated},
+	models.StatusTerminated: {models.StatusActive},
+}
+
+// ChangeStatus moves an employee to the given employment status and records the transition.
+// Illegal transitions, such as terminating a terminated employee, fail with 409.
+func (s *EmployeeService) ChangeStatus(ctx context.Context, employeeEmail, status string, now time.Time) (models.Employee, error) {
+	emp, err := s.findEmployee(ctx, employeeEmail)
+	if err != nil {
+		return models.Employee{}, err
+	}
+	from := emp.EmploymentStatus()
+	if !slices.Contains(statusTransitions[from], status) {
+		return models.Employee{}, errors.NewCodedError(http.StatusConflict, errors.CodeInvalidStatusTransition,
+			"employee cannot change from "+from+" to "+status)
+	}
+
+	transition := models.StatusTransition{From: from, To: status, At: now}
+	// Matching the version read keeps a concurrent change from skipping the transition check.
+	criteria := repository.EmployeeCriteria{State: repository.Active, Emails: []string{employeeEmail}, Version: emp.Version}
+	updated, err := s.Store.SetStatus(ctx, criteria, transition)
+	if err == mongo.ErrNoDocuments {
+		return models.Employee{}, errors.NewCodedError(http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch, "employee was modified by another request")
+	}
+	if err != nil {
+		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	s.audit(ctx, models.AuditEntry{
+		Time:     now,
+		Action:   models.AuditStatusChanged,
+		Employee: employeeEmail,
+		Details:  models.AuditDetails{"from": from, "to": status},
+	})
+	return updated, nil
+}
+
+// GetEmployeesByStatus returns the employees with the given employment status, ordered by the given sort key.
+// Unlike the other listings, it can list terminated employees.
+func (s *EmployeeService) GetEmployeesByStatus(ctx context.Context, status string, page, size int, sortBy string) ([]models.Employee, error) {
+	criteria := repository.EmployeeCriteria{State: repository.Active, Status: status}
+	employees, err := s.Store.List(ctx, criteria, s.paged(sortBy, page, size))
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if employees == nil {
+		employees = []models.Employee{}
+	}
+	return employees, nil
+}
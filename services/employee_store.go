@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+)
+
+// EmployeeStore stores employees. EmployeeService depends on it rather than on a collection, so that storage can be
+// swapped or faked; repository.EmployeeRepository implements it over MongoDB, and the memory, SQLite and PostgreSQL
+// repositories without it.
+// Its operations are those of the service, in domain terms: they select the employees they apply to by
+// repository.EmployeeCriteria, and the operations changing several employees return how many they changed. Methods
+// returning a single employee return mongo.ErrNoDocuments when no employee matches, and a duplicate key error is
+// reported as the driver does, so mongo.IsDuplicateKeyError recognizes it.
+// Employees are read without their password, which only FindWithCredentials returns, for authentication.
+type EmployeeStore interface {
+	// Create inserts a new employee.
+	Create(ctx context.Context, emp models.Employee) error
+	// InsertMany inserts new employees at once. Unordered, every employee that can be inserted is; ordered, the
+	// inserts stop at the first one failing. The failed inserts, such as those of emails taken, are reported in a
+	// mongo.BulkWriteException whose write errors are indexed by position in emps.
+	InsertMany(ctx context.Context, emps []models.Employee, ordered bool) error
+	// FindByEmail returns the employee with the email unless it is soft-deleted.
+	FindByEmail(ctx context.Context, email string) (models.Employee, error)
+	// FindOne returns the first employee matching the criteria.
+	FindOne(ctx context.Context, criteria repository.EmployeeCriteria) (models.Employee, error)
+	// FindWithCredentials returns the first employee matching the criteria, password included.
+	FindWithCredentials(ctx context.Context, criteria repository.EmployeeCriteria) (models.Employee, error)
+	// List returns a page of the employees matching the criteria.
+	List(ctx context.Context, criteria repository.EmployeeCriteria, page repository.Page) ([]models.Employee, error)
+	// Count returns the number of employees matching the criteria.
+	Count(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error)
+
+	// ManagerChain returns the first employee matching the criteria followed by their manager, the manager's manager
+	// and so on, through the active employees of the tenant of the criteria; at most maxDepth managers when
+	// maxDepth > 0. The chain stops at the first employee without an active manager, or when it comes back to the
+	// employee. It is empty when no employee matches.
+	ManagerChain(ctx context.Context, criteria repository.EmployeeCriteria, maxDepth int) ([]models.Employee, error)
+	// Reports returns the direct and indirect reports of the first employee matching the criteria, through the
+	// active employees of the tenant of the criteria; only those at most maxDepth levels below when maxDepth > 0.
+	// They are ordered by depth, then as the page orders them, and paged.
+	Reports(ctx context.Context, criteria repository.EmployeeCriteria, maxDepth int, page repository.Page) ([]repository.Report, error)
+	// CountReports counts the direct and the indirect reports of the first employee matching the criteria.
+	CountReports(ctx context.Context, criteria repository.EmployeeCriteria) (models.ReportCounts, error)
+	// Anniversaries returns the work anniversaries falling in the month of the year of the employees matching the
+	// criteria, ordered by date and then by email, and paged; the sort of the page is not used.
+	Anniversaries(ctx context.Context, criteria repository.EmployeeCriteria, month time.Month, year int, page repository.Page) ([]models.Anniversary, error)
+	// HeadcountByCostCenter counts the employees matching the criteria charged to each cost center, in no particular
+	// order; those charged to none are counted under an empty cost center.
+	HeadcountByCostCenter(ctx context.Context, criteria repository.EmployeeCriteria) ([]models.CostCenterHeadcount, error)
+	// Workforce breaks the employees matching the criteria down by role, email domain, manager and age as of now.
+	Workforce(ctx context.Context, criteria repository.EmployeeCriteria, now time.Time) (repository.Workforce, error)
+
+	// SetManager makes manager the manager of the employees matching the criteria, dropping it from their dotted
+	// lines, or removes their manager when it is empty.
+	SetManager(ctx context.Context, criteria repository.EmployeeCriteria, manager string) (int64, error)
+	// AddRoles adds the roles the employees matching the criteria do not hold yet.
+	AddRoles(ctx context.Context, criteria repository.EmployeeCriteria, roles []string) (int64, error)
+	// GrantTemporaryRole grants the role of the grant to the first employee matching the criteria until it expires,
+	// extending a temporary grant of the role they hold already, and returns the updated employee.
+	GrantTemporaryRole(ctx context.Context, criteria repository.EmployeeCriteria, grant models.RoleGrant) (models.Employee, error)
+	// RevokeExpiredRole removes the role, and its grant, from the employees matching the criteria whose grant of the
+	// role expired at or before now.
+	RevokeExpiredRole(ctx context.Context, criteria repository.EmployeeCriteria, role string, now time.Time) (int64, error)
+	// SetDelegation sets the delegation of the first employee matching the criteria and returns the updated
+	// employee.
+	SetDelegation(ctx context.Context, criteria repository.EmployeeCriteria, delegation models.Delegation) (models.Employee, error)
+	// RemoveDelegation removes the delegation of the employees matching the criteria.
+	RemoveDelegation(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error)
+	// SetStatus applies the status transition to the first employee matching the criteria, recording it in their
+	// history, and returns the updated employee.
+	SetStatus(ctx context.Context, criteria repository.EmployeeCriteria, transition models.StatusTransition) (models.Employee, error)
+	// AddDottedLine adds the manager to the dotted lines of the employees matching the criteria.
+	AddDottedLine(ctx context.Context, criteria repository.EmployeeCriteria, manager string) (int64, error)
+	// RemoveDottedLine removes the manager from the dotted lines of the employees matching the criteria.
+	RemoveDottedLine(ctx context.Context, criteria repository.EmployeeCriteria, manager string) (int64, error)
+	// ChangeEmail changes the email of the first employee matching the criteria and reports whether one matched.
+	ChangeEmail(ctx context.Context, criteria repository.EmployeeCriteria, email string) (bool, error)
+	// AssignID sets the internal ID of the first employee matching the criteria and reports whether one matched.
+	AssignID(ctx context.Context, criteria repository.EmployeeCriteria, id string) (bool, error)
+	// DeriveDisplayNames sets the display name of the employees matching the criteria stored without one.
+	DeriveDisplayNames(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error)
+	// SoftDelete marks the employees matching the criteria as deleted at the time.
+	SoftDelete(ctx context.Context, criteria repository.EmployeeCriteria, at time.Time) (int64, error)
+	// Restore clears the deletion mark of the first employee matching the criteria and returns the restored
+	// employee.
+	Restore(ctx context.Context, criteria repository.EmployeeCriteria) (models.Employee, error)
+	// Delete removes the employees matching the criteria for good.
+	Delete(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error)
+	// ReplaceEmployee replaces the employee having emp's email with emp; see repository.EmployeeRepository.
+	ReplaceEmployee(ctx context.Context, emp models.Employee, version int64, upsert bool) (bool, error)
+	// MoveEmailReferences points every reference to the employee with the old email at the new email. The
+	// references held by employees only move for those matching the criteria.
+	MoveEmailReferences(ctx context.Context, criteria repository.EmployeeCriteria, from, to string) error
+
+	// InTransaction runs fn in a transaction, or without one where the storage has none.
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// MaxBulkItems caps the number of items accepted by a single bulk request.
+const MaxBulkItems = 1000
+
+// runBulk applies op to each of the n items and collects a multi-status report.
+// In abort mode, processing stops at the first failure and the remaining items are reported as skipped.
+// Items applied before the failure are not rolled back.
+func runBulk(n int, mode string, op func(i int) (string, error)) models.BulkResponse {
+	resp := models.BulkResponse{Results: make([]models.BulkItemResult, 0, n)}
+	aborted := false
+	for i := 0; i < n; i++ {
+		if aborted {
+			resp.Skipped++
+			resp.Results = append(resp.Results, models.BulkItemResult{
+				Index:  i,
+				Status: http.StatusFailedDependency,
+				Code:   errors.CodeBulkSkipped,
+				Error:  "skipped after an earlier failure",
+			})
+			continue
+		}
+		email, err := op(i)
+		result := models.BulkItemResult{Index: i, Email: email, Status: http.StatusOK}
+		if err != nil {
+			result.Status = http.StatusInternalServerError
+			result.Code = errors.CodeInternal
+			result.Error = err.Error()
+			if httpErr, ok := err.(*errors.HTTPError); ok {
+				result.Status = httpErr.Code
+				result.Code = httpErr.ErrorCode
+				result.Error = httpErr.Msg
+			}
+			resp.Failed++
+			aborted = mode == models.BulkModeAbort
+		} else {
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp
+}
+
+// validateBulkRequest checks the item count and the requested error handling mode.
+func validateBulkRequest(n int, mode string) error {
+	if n == 0 {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeBulkEmpty, "at least one item is required")
+	}
+	if n > MaxBulkItems {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeBulkTooLarge, "too many items in bulk request")
+	}
+	if mode != models.BulkModeContinue && mode != models.BulkModeAbort {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeBulkInvalidMode, "mode must be either continue or abort")
+	}
+	return nil
+}
+
+// BulkCreateEmployees creates every employee in the list and reports the outcome of each. The employees are
+// inserted in batches rather than one by one.
+func (s *EmployeeService) BulkCreateEmployees(ctx context.Context, emps []models.Employee, mode string) (models.BulkResponse, error) {
+	if err := validateBulkRequest(len(emps), mode); err != nil {
+		return models.BulkResponse{}, err
+	}
+	errs := s.createEmployees(ctx, emps, mode == models.BulkModeAbort)
+	return runBulk(len(emps), mode, func(i int) (string, error) {
+		return emps[i].Email, errs[i]
+	}), nil
+}
+
+// createEmployees creates the employees as CreateEmployee does, but inserts them through Store.InsertMany, a batch
+// at a time, and returns the error of each employee, nil for those created. An employee whose manager comes before
+// it in the list is validated once its manager is stored. Ordered, the employees stop being created at the first
+// one failing; the errors of those after it are left nil.
+func (s *EmployeeService) createEmployees(ctx context.Context, emps []models.Employee, ordered bool) []error {
+	errs := make([]error, len(emps))
+	var batch []models.Employee
+	var indexes []int
+	pending := make(map[string]bool)
+	failed := false
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		failures := make(map[int]error)
+		inserted := len(batch)
+		err := s.Store.InsertMany(ctx, batch, ordered)
+		var bulkErr mongo.BulkWriteException
+		switch {
+		case err == nil:
+		case stderrors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil:
+			for _, writeErr := range bulkErr.WriteErrors {
+				failures[writeErr.Index] = createError(mongo.WriteException{WriteErrors: []mongo.WriteError{writeErr.WriteError}})
+				inserted = min(inserted, writeErr.Index)
+			}
+		default:
+			// Which employees were inserted is unknown, so all of them are reported as failed.
+			for j := range batch {
+				failures[j] = createError(err)
+			}
+			inserted = 0
+		}
+		for j, emp := range batch {
+			if err, ok := failures[j]; ok {
+				errs[indexes[j]] = err
+				failed = true
+				continue
+			}
+			if ordered && j > inserted {
+				continue
+			}
+			emp.Password = ""
+			s.Hooks.AfterCreate(ctx, emp)
+		}
+		batch, indexes = batch[:0], indexes[:0]
+		clear(pending)
+	}
+
+	for i, emp := range emps {
+		if ordered && failed {
+			break
+		}
+		// The manager is validated against the store, so it must be stored first.
+		if emp.Manager != nil && pending[*emp.Manager] {
+			if flush(); ordered && failed {
+				break
+			}
+		}
+		if err := s.prepareEmployee(ctx, &emp); err != nil {
+			if ordered {
+				// The employees before this one are created, as if created one by one.
+				if flush(); !failed {
+					errs[i] = err
+				}
+				return errs
+			}
+			errs[i] = err
+			continue
+		}
+		batch = append(batch, emp)
+		indexes = append(indexes, i)
+		pending[emp.Email] = true
+	}
+	flush()
+	return errs
+}
+
+// BulkAssignRoles adds roles to each employee in the list and reports the outcome of each.
+func (s *EmployeeService) BulkAssignRoles(ctx context.Context, assignments []models.RoleAssignment, mode string) (models.BulkResponse, error) {
+	if err := validateBulkRequest(len(assignments), mode); err != nil {
+		return models.BulkResponse{}, err
+	}
+	return runBulk(len(assignments), mode, func(i int) (string, error) {
+		return assignments[i].Email, s.AddRoles(ctx, assignments[i].Email, assignments[i].Roles)
+	}), nil
+}
+
+// BulkSetManagers sets the manager of each employee in the list and reports the outcome of each.
+func (s *EmployeeService) BulkSetManagers(ctx context.Context, assignments []models.ManagerAssignment, mode string) (models.BulkResponse, error) {
+	if err := validateBulkRequest(len(assignments), mode); err != nil {
+		return models.BulkResponse{}, err
+	}
+	return runBulk(len(assignments), mode, func(i int) (string, error) {
+		return assignments[i].Email, s.SetManager(ctx, assignments[i].Email, assignments[i].Manager, 0)
+	}), nil
+}
+
+// AddRoles adds the given roles to an employee, ignoring roles the employee already has.
+func (s *EmployeeService) AddRoles(ctx context.Context, employeeEmail string, roles []string) error {
+	if len(roles) == 0 {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeRolesRequired, "at least one role is required")
+	}
+	for _, role := range roles {
+		if role == "" {
+			return errors.NewCodedError(http.StatusBadRequest, errors.CodeRolesRequired, "roles cannot be empty")
+		}
+	}
+	matched, err := s.Store.AddRoles(ctx, activeEmployee(employeeEmail), roles)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if matched == 0 {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
+	return nil
+}
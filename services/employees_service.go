@@ -2,322 +2,316 @@ package services
 
 import (
 	"context"
+	"crypto/subtle"
 	"net/http"
-	"net/mail"
-	"sort"
-	"strconv"
+	"slices"
+	"strings"
 	"time"
 
 	"WebMVCEmployees/errors"
+	"WebMVCEmployees/hooks"
 	"WebMVCEmployees/models"
 	"WebMVCEmployees/repository"
 
-	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 // EmployeeService provides business logic for managing employees.
 type EmployeeService struct {
-	Repo *repository.EmployeeRepository
+	// Store holds the employees.
+	Store EmployeeStore
+	// Hooks are the plugin hooks run around employee operations.
+	Hooks *hooks.Registry
+	// Audit records changes to employees. Nil disables auditing.
+	Audit AuditLog
+	// MetadataKeys lists the metadata keys employees may carry. Empty accepts any well-formed key.
+	MetadataKeys []string
+	// Departments holds the departments employees may belong to. Nil accepts any department.
+	Departments *repository.DepartmentRepository
+	// Titles holds the job titles employees may hold and their grades. Nil accepts any title and grade.
+	Titles *repository.TitleRepository
+	// Locations holds the offices employees may work from. Nil accepts any location.
+	Locations *repository.LocationRepository
+	// CostCenters lists the cost centers employees may be charged to. Empty accepts any cost center.
+	CostCenters []string
+	// ManagerRoles lists the roles that allow an employee to manage others; a manager must hold one of them.
+	// Empty lets any employee be a manager.
+	ManagerRoles []string
+	// RoleCatalog holds the roles catalog, whose parents imply roles when filtering by role and checking manager
+	// roles. Nil resolves roles exactly as employees hold them.
+	RoleCatalog *repository.RoleRepository
+	// Collation orders the employee lists, e.g. case-insensitively in the rules of a language, and applies to the
+	// string comparisons of their filters as well. Nil compares strings byte by byte.
+	Collation *options.Collation
 }
 
-// NewEmployeeService creates a new EmployeeService using the provided repository.
-func NewEmployeeService(repo *repository.EmployeeRepository) *EmployeeService {
+// AuditLog records changes to employees.
+type AuditLog interface {
+	Record(ctx context.Context, entry models.AuditEntry) error
+}
+
+// NewEmployeeService creates a new EmployeeService using the provided store and the default hook registry.
+func NewEmployeeService(store EmployeeStore) *EmployeeService {
 	return &EmployeeService{
-		Repo: repo,
+		Store: store,
+		Hooks: hooks.Default,
 	}
 }
 
-func (s *EmployeeService) CreateEmployee(ctx context.Context, emp models.Employee) (models.Employee, error) {
-	// Basic validations:
-	if emp.Email == "" || emp.Name == "" {
-		return models.Employee{}, errors.NewHTTPError(http.StatusBadRequest, "email and name are required")
-	}
-	if err := validateEmail(emp.Email); err != nil {
-		return models.Employee{}, errors.NewHTTPError(http.StatusBadRequest, "invalid email format")
+// hookError converts an error returned by a plugin hook into an HTTPError.
+// Plugins may return an HTTPError to choose the status and code; other errors are reported as 400 REJECTED_BY_PLUGIN.
+func hookError(err error) error {
+	if _, ok := err.(*errors.HTTPError); ok {
+		return err
 	}
+	return errors.NewCodedError(http.StatusBadRequest, errors.CodeRejectedByPlugin, err.Error())
+}
 
-	// Validate birthdate using the separate helper function.
-	if err := validateBirthdate(emp.Birthdate); err != nil {
-		return models.Employee{}, err
-	}
-	// Validate password using the helper function.
-	if err := validatePassword(emp.Password); err != nil {
+func (s *EmployeeService) CreateEmployee(ctx context.Context, emp models.Employee) (models.Employee, error) {
+	if err := s.prepareEmployee(ctx, &emp); err != nil {
 		return models.Employee{}, err
 	}
-	if emp.Manager != nil {
-		if err := s.validateManager(ctx, *emp.Manager); err != nil {
-			return models.Employee{}, err
-		}
-	}
-	// Insert the new employee into MongoDB.
-	_, err := s.Repo.Collection.InsertOne(ctx, emp)
-	if err != nil {
-		if mongo.IsDuplicateKeyError(err) {
-			return models.Employee{}, errors.NewHTTPError(http.StatusConflict, "employee with this email already exists")
-		}
-		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	// Insert the new employee into the store.
+	if err := s.Store.Create(ctx, emp); err != nil {
+		return models.Employee{}, createError(err)
 	}
 
 	// Remove the password before returning the response.
 	emp.Password = ""
+	s.Hooks.AfterCreate(ctx, emp)
 	return emp, nil
 }
 
-// validateEmail checks if the provided email is valid.
-func validateEmail(email string) error {
-	_, err := mail.ParseAddress(email)
-	return err
+// prepareEmployee validates a new employee and sets the fields the service maintains, ready to be inserted.
+func (s *EmployeeService) prepareEmployee(ctx context.Context, emp *models.Employee) error {
+	if err := s.validateEmployee(ctx, *emp); err != nil {
+		return err
+	}
+	// Let plugins validate or enrich the employee.
+	if err := s.Hooks.BeforeCreate(ctx, emp); err != nil {
+		return hookError(err)
+	}
+	// Every employee starts at version 1; each update increments it.
+	emp.Version = 1
+	// The internal ID never changes, unlike the email.
+	emp.ID = newEmployeeID(time.Now())
+	emp.DisplayName = emp.ResolveDisplayName()
+	emp.BirthDate, _ = emp.Birthdate.Date()
+	// Temporary roles and delegations can only be set through their endpoints, which record them in the audit log.
+	emp.TemporaryRoles = nil
+	emp.Delegation = nil
+	emp.DeletedAt = nil
+	return nil
 }
 
-// validateBirthdate checks that the birthdate fields are of correct length and numeric.
-func validateBirthdate(birthdate models.Birthdate) error {
-	// Check lengths.
-	if len(birthdate.Day) != 2 {
-		return errors.NewHTTPError(http.StatusBadRequest, "birthdate day must be two digits")
+// createError reports the failure of the store to insert an employee.
+func createError(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return errors.NewCodedError(http.StatusConflict, errors.CodeEmployeeDuplicateEmail, "employee with this email already exists")
 	}
-	if len(birthdate.Month) != 2 {
-		return errors.NewHTTPError(http.StatusBadRequest, "birthdate month must be two digits")
+	return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+}
+
+// validateEmployee checks the fields of an employee about to be stored.
+func (s *EmployeeService) validateEmployee(ctx context.Context, emp models.Employee) error {
+	if err := validateStruct(emp); err != nil {
+		return err
 	}
-	if len(birthdate.Year) != 4 {
-		return errors.NewHTTPError(http.StatusBadRequest, "birthdate year must be four digits")
+	if err := s.validateMetadata(emp.Metadata); err != nil {
+		return err
 	}
-
-	// Convert to integers.
-	day, err := strconv.Atoi(birthdate.Day)
-	if err != nil {
-		return errors.NewHTTPError(http.StatusBadRequest, "birthdate day must be numeric")
+	if emp.Manager != nil {
+		if err := s.validateManager(ctx, *emp.Manager); err != nil {
+			return err
+		}
+		if err := s.checkManagerCycle(ctx, emp.Email, *emp.Manager); err != nil {
+			return err
+		}
 	}
-	month, err := strconv.Atoi(birthdate.Month)
-	if err != nil {
-		return errors.NewHTTPError(http.StatusBadRequest, "birthdate month must be numeric")
+	if err := s.validateDepartment(ctx, emp.Department); err != nil {
+		return err
 	}
-	year, err := strconv.Atoi(birthdate.Year)
-	if err != nil {
-		return errors.NewHTTPError(http.StatusBadRequest, "birthdate year must be numeric")
+	if err := s.validateTitle(ctx, emp.Title, emp.Grade); err != nil {
+		return err
 	}
-
-	// Create a time.Time object from the birthdate.
-	birthDate := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
-	// Ensure the birthdate is not in the future.
-	if birthDate.After(time.Now().UTC()) {
-		return errors.NewHTTPError(http.StatusBadRequest, "birthdate cannot be in the future")
+	if err := s.validateCostCenter(emp.CostCenter); err != nil {
+		return err
 	}
-
-	return nil
+	return s.validateLocation(ctx, emp.Location)
 }
 
-func validatePassword(password string) error {
-	if len(password) < 3 {
-		return errors.NewHTTPError(http.StatusBadRequest, "password must be at least 3 characters")
+// ValidateManager checks if the manager with the given email exists and holds one of the manager roles.
+func (s *EmployeeService) validateManager(ctx context.Context, managerEmail string) error {
+	if managerEmail == "" {
+		return nil // No manager to validate
 	}
+	manager, err := s.findManager(ctx, managerEmail)
+	if err != nil {
+		return err
+	}
+	return s.checkManagerRole(ctx, manager)
+}
 
-	hasDigit := false
-	hasUpper := false
-	for _, ch := range password {
-		if ch >= '0' && ch <= '9' {
-			hasDigit = true
-		}
-		if ch >= 'A' && ch <= 'Z' {
-			hasUpper = true
+// findManager loads the manager with the given email, answering 400 when there is no such employee.
+func (s *EmployeeService) findManager(ctx context.Context, managerEmail string) (models.Employee, error) {
+	manager, err := s.Store.FindByEmail(ctx, managerEmail)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Employee{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeManagerNotFound, "manager not found")
 		}
+		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
-	if !hasDigit || !hasUpper {
-		return errors.NewHTTPError(http.StatusBadRequest, "password must contain at least one digit and one uppercase letter")
-	}
-	return nil
+	return manager, nil
 }
 
-// ValidateManager checks if the manager with the given email exists.
-func (s *EmployeeService) validateManager(ctx context.Context, managerEmail string) error {
-	if managerEmail == "" {
-		return nil // No manager to validate
+// checkManagerRole fails with 422 when ManagerRoles is set and the manager holds none of those roles,
+// directly or through a role implying one of them.
+// The message names the manager, the required roles and the roles the manager holds.
+func (s *EmployeeService) checkManagerRole(ctx context.Context, manager models.Employee) error {
+	if len(s.ManagerRoles) == 0 {
+		return nil
 	}
-	var manager models.Employee
-	err := s.Repo.Collection.FindOne(ctx, bson.M{models.EmployeeRef.Email: managerEmail}).Decode(&manager)
+	catalog, err := loadRoleCatalog(ctx, s.RoleCatalog)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return errors.NewHTTPError(http.StatusBadRequest, "manager not found")
+		return err
+	}
+	for _, role := range catalog.implied(manager.Roles) {
+		if slices.Contains(s.ManagerRoles, role) {
+			return nil
 		}
-		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
-	return nil
+	return errors.NewCodedError(http.StatusUnprocessableEntity, errors.CodeManagerRoleMissing,
+		"manager "+manager.Email+" must hold one of the roles ["+strings.Join(s.ManagerRoles, ", ")+
+			"] but holds ["+strings.Join(manager.Roles, ", ")+"]")
 }
 
 // GetEmployee retrieves an employee by email and password.
 // It returns an error if no matching employee is found.
 func (s *EmployeeService) GetEmployee(ctx context.Context, email, password string) (models.Employee, error) {
-	var emp models.Employee
-	filter := bson.M{models.EmployeeRef.Email: email, models.EmployeeRef.Password: password}
-	err := s.Repo.Collection.FindOne(ctx, filter).Decode(&emp)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return models.Employee{}, errors.NewHTTPError(http.StatusNotFound, "employee not found")
-		}
+	emp, err := s.Store.FindWithCredentials(ctx, activeEmployee(email))
+	if err != nil && err != mongo.ErrNoDocuments {
 		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+	// A wrong password is reported as an unknown employee, and compared in constant time.
+	if err == mongo.ErrNoDocuments || subtle.ConstantTimeCompare([]byte(emp.Password), []byte(password)) != 1 {
+		return models.Employee{}, errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
 	// Do not expose the password in the response.
 	emp.Password = ""
 	return emp, nil
 }
 
-// GetAllEmployees returns all employees with pagination.
-func (s *EmployeeService) GetAllEmployees(ctx context.Context, page, size int) ([]models.Employee, error) {
-	skip := int64((page - 1) * size)
-	limit := int64(size)
-	findOptions := options.Find().SetSort(bson.D{{Key: models.EmployeeRef.Email, Value: 1}}).SetSkip(skip).SetLimit(limit)
-	cursor, err := s.Repo.Collection.Find(ctx, bson.M{}, findOptions)
+// GetAllEmployees returns all employees but the terminated ones with pagination, ordered by the given sort key.
+func (s *EmployeeService) GetAllEmployees(ctx context.Context, page, size int, sortBy string) ([]models.Employee, error) {
+	employees, err := s.Store.List(ctx, repository.EmployeeCriteria{}, s.paged(sortBy, page, size))
 	if err != nil {
 		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
-	defer cursor.Close(ctx)
-
-	var employees []models.Employee
-	if err = cursor.All(ctx, &employees); err != nil {
-		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
 	// Ensure employees is not nil.
 	if employees == nil {
 		employees = []models.Employee{}
 	}
-	// Remove passwords from all employee responses.
-	for i := range employees {
-		employees[i].Password = ""
-	}
 	return employees, nil
 }
 
-// GetEmployeesByEmailDomain returns employees whose email domain matches exactly.
-func (s *EmployeeService) GetEmployeesByEmailDomain(ctx context.Context, domain string, page, size int) ([]models.Employee, error) {
-	filter := bson.M{models.EmployeeRef.Email: bson.M{"$regex": "@" + domain + "$", "$options": "i"}}
-	skip := int64((page - 1) * size)
-	limit := int64(size)
-	findOptions := options.Find().SetSkip(skip).SetLimit(limit)
-	cursor, err := s.Repo.Collection.Find(ctx, filter, findOptions)
+// GetEmployeesByEmailDomain returns employees whose email domain matches exactly, ordered by the given sort key.
+func (s *EmployeeService) GetEmployeesByEmailDomain(ctx context.Context, domain string, page, size int, sortBy string) ([]models.Employee, error) {
+	employees, err := s.Store.List(ctx, repository.EmployeeCriteria{EmailDomain: domain}, s.paged(sortBy, page, size))
 	if err != nil {
 		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
-	defer cursor.Close(ctx)
-
-	var employees []models.Employee
-	if err = cursor.All(ctx, &employees); err != nil {
-		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
 	// Ensure employees is not nil.
 	if employees == nil {
 		employees = []models.Employee{}
 	}
 
-	for i := range employees {
-		employees[i].Password = ""
-	}
 	return employees, nil
 }
 
-// GetEmployeesByRole returns employees having a specific role.
-func (s *EmployeeService) GetEmployeesByRole(ctx context.Context, role string, page, size int) ([]models.Employee, error) {
-	filter := bson.M{models.EmployeeRef.Roles: role}
-	skip := int64((page - 1) * size)
-	limit := int64(size)
-	findOptions := options.Find().SetSort(bson.D{{Key: models.EmployeeRef.Email, Value: 1}}).SetSkip(skip).SetLimit(limit)
-	cursor, err := s.Repo.Collection.Find(ctx, filter, findOptions)
+// GetEmployeesByRole returns employees having a specific role, directly or through a role implying it,
+// ordered by the given sort key.
+func (s *EmployeeService) GetEmployeesByRole(ctx context.Context, role string, page, size int, sortBy string) ([]models.Employee, error) {
+	roles, err := s.rolesImplying(ctx, role)
 	if err != nil {
-		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return nil, err
 	}
-	defer cursor.Close(ctx)
-
-	var employees []models.Employee
-	if err = cursor.All(ctx, &employees); err != nil {
+	criteria := repository.EmployeeCriteria{Roles: [][]string{roles}}
+	employees, err := s.Store.List(ctx, criteria, s.paged(sortBy, page, size))
+	if err != nil {
 		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 	// Ensure employees is not nil.
 	if employees == nil {
 		employees = []models.Employee{}
 	}
-	for i := range employees {
-		employees[i].Password = ""
-	}
 	return employees, nil
 }
 
-// GetEmployeesByAge returns employees whose age in years equals the specified value.
-// Assumes that the current date is provided as a Unix timestamp.
-func (s *EmployeeService) GetEmployeesByAge(ctx context.Context, ageInYears int, currentUnix int64, page, size int) ([]models.Employee, error) {
-	cursor, err := s.Repo.Collection.Find(ctx, bson.M{})
-	if err != nil {
-		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	defer cursor.Close(ctx)
-
-	var employees []models.Employee
-	if err = cursor.All(ctx, &employees); err != nil {
-		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+// GetEmployeesByExample returns employees matching every non-empty field of the example, with pagination.
+// Roles in the example must all be held by a matching employee, directly or through roles implying them.
+func (s *EmployeeService) GetEmployeesByExample(ctx context.Context, example models.Employee, page, size int, sortBy string) ([]models.Employee, error) {
+	if example.Password != "" {
+		return nil, errors.NewCodedError(http.StatusBadRequest, errors.CodePasswordCriterion, "password cannot be used as a search criterion")
 	}
-
-	var filtered []models.Employee
-	now := time.Unix(currentUnix, 0)
-	for _, emp := range employees {
-		bYear, err := strconv.Atoi(emp.Birthdate.Year)
-		if err != nil {
-			continue
-		}
-		bMonth, err := strconv.Atoi(emp.Birthdate.Month)
-		if err != nil {
-			continue
+	// Keys become field paths of the filter, so only well-formed ones are accepted.
+	for key := range example.Metadata {
+		if err := validateMetadataKey(key, nil); err != nil {
+			return nil, err
 		}
-		bDay, err := strconv.Atoi(emp.Birthdate.Day)
+	}
+	criteria := repository.EmployeeCriteria{State: repository.Active, Example: &example}
+	for _, role := range example.Roles {
+		roles, err := s.rolesImplying(ctx, role)
 		if err != nil {
-			continue
-		}
-		birthDate := time.Date(bYear, time.Month(bMonth), bDay, 0, 0, 0, 0, time.UTC)
-		calculatedAge := now.Year() - birthDate.Year()
-		if now.YearDay() < birthDate.YearDay() {
-			calculatedAge--
-		}
-		if calculatedAge == ageInYears {
-			emp.Password = ""
-			filtered = append(filtered, emp)
+			return nil, err
 		}
+		criteria.Roles = append(criteria.Roles, roles)
 	}
-
-	// Sort filtered employees by birth date (ascending order)
-	sort.Slice(filtered, func(i, j int) bool {
-		// Convert the birthdates to time.Time for comparison.
-		byear, _ := strconv.Atoi(filtered[i].Birthdate.Year)
-		bmonth, _ := strconv.Atoi(filtered[i].Birthdate.Month)
-		bday, _ := strconv.Atoi(filtered[i].Birthdate.Day)
-		dateI := time.Date(byear, time.Month(bmonth), bday, 0, 0, 0, 0, time.UTC)
-
-		byearJ, _ := strconv.Atoi(filtered[j].Birthdate.Year)
-		bmonthJ, _ := strconv.Atoi(filtered[j].Birthdate.Month)
-		bdayJ, _ := strconv.Atoi(filtered[j].Birthdate.Day)
-		dateJ := time.Date(byearJ, time.Month(bmonthJ), bdayJ, 0, 0, 0, 0, time.UTC)
-
-		return dateI.Before(dateJ)
-	})
-
-	// Ensure filtered is an empty slice (not nil) if no records found.
-	if filtered == nil {
-		filtered = []models.Employee{}
+	example.Roles = nil
+	employees, err := s.Store.List(ctx, criteria, s.paged(sortBy, page, size))
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	// Ensure employees is not nil.
+	if employees == nil {
+		employees = []models.Employee{}
 	}
+	return employees, nil
+}
 
-	// Apply pagination to the filtered slice.
-	start := (page - 1) * size
-	if start > len(filtered) {
-		return []models.Employee{}, nil
+// GetEmployeesByAge returns employees whose age in years equals the specified value, as of the current date in the
+// time zone of each employee.
+// Assumes that the current date is provided as a Unix timestamp. Employees are ordered by birth date
+// unless a sort key is given.
+func (s *EmployeeService) GetEmployeesByAge(ctx context.Context, ageInYears int, currentUnix int64, page, size int, sortBy string) ([]models.Employee, error) {
+	paged := s.paged(sortBy, page, size)
+	byBirthDate := []repository.SortKey{{Field: models.EmployeeRef.BirthDate}, {Field: models.EmployeeRef.Email}}
+	if sortBy == "" {
+		paged.Sort = byBirthDate
+	} else if paged.Sort[0].Field != models.EmployeeRef.Email {
+		// Employees tied on the sort key stay ordered by birth date; emails are unique, so they leave no ties.
+		paged.Sort = append(paged.Sort[:1], byBirthDate...)
+	}
+	criteria := repository.EmployeeCriteria{Age: &repository.AgeCriterion{Years: ageInYears, At: time.Unix(currentUnix, 0)}}
+	employees, err := s.Store.List(ctx, criteria, paged)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
-	end := start + size
-	if end > len(filtered) {
-		end = len(filtered)
+	if employees == nil {
+		employees = []models.Employee{}
 	}
-
-	return filtered[start:end], nil
+	return employees, nil
 }
 
-// DeleteAllEmployees deletes all employee documents from the collection.
-func (s *EmployeeService) DeleteAllEmployees(ctx context.Context) error {
-	_, err := s.Repo.Collection.DeleteMany(ctx, bson.M{})
+// DeleteAllEmployees soft-deletes every employee, or removes all employee documents when hard is set.
+func (s *EmployeeService) DeleteAllEmployees(ctx context.Context, hard bool) error {
+	var err error
+	if hard {
+		_, err = s.Store.Delete(ctx, repository.EmployeeCriteria{State: repository.AnyState})
+	} else {
+		_, err = s.Store.SoftDelete(ctx, repository.EmployeeCriteria{State: repository.Active}, time.Now().UTC())
+	}
 	if err != nil {
 		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -327,78 +321,116 @@ func (s *EmployeeService) DeleteAllEmployees(ctx context.Context) error {
 // Bonus: Manager relationship endpoints
 
 // SetManager sets or updates the manager for an employee.
-func (s *EmployeeService) SetManager(ctx context.Context, employeeEmail string, managerEmail string) error {
-	var emp models.Employee
-	err := s.Repo.Collection.FindOne(ctx, bson.M{models.EmployeeRef.Email: employeeEmail}).Decode(&emp)
+// When expectedVersion is non-zero, the update only applies if the employee is still at that version.
+func (s *EmployeeService) SetManager(ctx context.Context, employeeEmail string, managerEmail string, expectedVersion int64) error {
+	emp, err := s.Store.FindByEmail(ctx, employeeEmail)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return errors.NewHTTPError(http.StatusNotFound, "employee not found")
+			return errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
 		}
 		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 	if err := s.validateManager(ctx, managerEmail); err != nil {
 		return err
 	}
-	_, err = s.Repo.Collection.UpdateOne(ctx, bson.M{models.EmployeeRef.Email: employeeEmail},
-		bson.M{"$set": bson.M{models.EmployeeRef.Manager: managerEmail}})
+	if emp.Manager != nil && *emp.Manager == managerEmail {
+		// The manager is already assigned; leave the version alone so caches stay valid.
+		if expectedVersion != 0 && emp.Version != expectedVersion {
+			return errors.NewCodedError(http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch, "employee was modified by another request")
+		}
+		return nil
+	}
+	if err := s.checkManagerCycle(ctx, employeeEmail, managerEmail); err != nil {
+		return err
+	}
+	if err := s.Hooks.BeforeManagerSet(ctx, employeeEmail, managerEmail); err != nil {
+		return hookError(err)
+	}
+	// A dotted-line manager promoted to primary manager is no longer a dotted line.
+	criteria := activeEmployee(employeeEmail)
+	criteria.Version = expectedVersion
+	updated, err := s.Store.SetManager(ctx, criteria, managerEmail)
 	if err != nil {
 		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+	if updated == 0 {
+		return errors.NewCodedError(http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch, "employee was modified by another request")
+	}
+	s.Hooks.AfterManagerSet(ctx, employeeEmail, managerEmail)
 	return nil
 }
 
 // GetManager retrieves the manager for a given employee.
 func (s *EmployeeService) GetManager(ctx context.Context, employeeEmail string) (models.Employee, error) {
-	var emp models.Employee
-	err := s.Repo.Collection.FindOne(ctx, bson.M{models.EmployeeRef.Email: employeeEmail}).Decode(&emp)
+	emp, err := s.Store.FindByEmail(ctx, employeeEmail)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return models.Employee{}, errors.NewHTTPError(http.StatusNotFound, "employee not found")
+			return models.Employee{}, errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
 		}
 		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 	if emp.Manager == nil {
-		return models.Employee{}, errors.NewHTTPError(http.StatusNotFound, "manager not set")
+		return models.Employee{}, errors.NewCodedError(http.StatusNotFound, errors.CodeManagerNotSet, "manager not set")
 	}
-	var manager models.Employee
-	err = s.Repo.Collection.FindOne(ctx, bson.M{models.EmployeeRef.Email: *emp.Manager}).Decode(&manager)
+	manager, err := s.Store.FindByEmail(ctx, *emp.Manager)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return models.Employee{}, errors.NewHTTPError(http.StatusNotFound, "manager not found")
+			return models.Employee{}, errors.NewCodedError(http.StatusNotFound, errors.CodeManagerNotFound, "manager not found")
 		}
 		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
-	manager.Password = ""
 	return manager, nil
 }
 
-// GetSubordinates returns employees managed by the given managerEmail, with pagination.
-func (s *EmployeeService) GetSubordinates(ctx context.Context, managerEmail string, page, size int) ([]models.Employee, error) {
-	filter := bson.M{models.EmployeeRef.Manager: managerEmail}
-	skip := int64((page - 1) * size)
-	limit := int64(size)
-	findOptions := options.Find().SetSort(bson.D{{Key: models.EmployeeRef.Email, Value: 1}}).SetSkip(skip).SetLimit(limit)
-	cursor, err := s.Repo.Collection.Find(ctx, filter, findOptions)
+// ManagerOf returns the email of the employee's manager, or an empty email when the employee has none or does not
+// exist.
+func (s *EmployeeService) ManagerOf(ctx context.Context, employeeEmail string) (string, error) {
+	emp, err := s.Store.FindByEmail(ctx, employeeEmail)
+	if err == mongo.ErrNoDocuments || err == nil && emp.Manager == nil {
+		return "", nil
+	}
 	if err != nil {
-		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return "", errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
-	defer cursor.Close(ctx)
-	var subordinates []models.Employee
-	if err = cursor.All(ctx, &subordinates); err != nil {
-		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	return *emp.Manager, nil
+}
+
+// GetSubordinates returns employees managed by the given managerEmail, with pagination, ordered by the given sort key.
+// During a delegation window, the delegate also sees the subordinates of the delegating manager.
+func (s *EmployeeService) GetSubordinates(ctx context.Context, managerEmail string, page, size int, sortBy string) ([]models.Employee, error) {
+	managers, err := s.delegatorsOf(ctx, managerEmail, time.Now().UTC())
+	if err != nil {
+		return nil, err
 	}
-	for i := range subordinates {
-		subordinates[i].Password = ""
+	criteria := repository.EmployeeCriteria{State: repository.Active, Managers: append(managers, managerEmail)}
+	subordinates, err := s.Store.List(ctx, criteria, s.paged(sortBy, page, size))
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 	return subordinates, nil
 }
 
 // RemoveManager unsets the manager for an employee.
 func (s *EmployeeService) RemoveManager(ctx context.Context, employeeEmail string) error {
-	_, err := s.Repo.Collection.UpdateOne(ctx, bson.M{models.EmployeeRef.Email: employeeEmail},
-		bson.M{"$unset": bson.M{models.EmployeeRef.Manager: ""}})
+	if err := s.Hooks.BeforeManagerRemove(ctx, employeeEmail); err != nil {
+		return hookError(err)
+	}
+	_, err := s.Store.SetManager(ctx, activeEmployee(employeeEmail), "")
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	s.Hooks.AfterManagerRemove(ctx, employeeEmail)
+	return nil
+}
+
+// ensureEmployee fails with 404 unless the employee exists.
+func ensureEmployee(ctx context.Context, employees EmployeeStore, email string) error {
+	count, err := employees.Count(ctx, repository.EmployeeCriteria{State: repository.Active, Emails: []string{email}})
 	if err != nil {
 		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+	if count == 0 {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
 	return nil
 }
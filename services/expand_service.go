@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+)
+
+// ExpandManagers embeds the manager of each employee, resolved with a single query for the whole page.
+// Managers that no longer exist are left out.
+func (s *EmployeeService) ExpandManagers(ctx context.Context, employees []models.Employee) ([]models.ExpandedEmployee, error) {
+	var emails []string
+	seen := make(map[string]bool)
+	for _, emp := range employees {
+		if emp.Manager != nil && !seen[*emp.Manager] {
+			seen[*emp.Manager] = true
+			emails = append(emails, *emp.Manager)
+		}
+	}
+
+	managers := make(map[string]models.Employee, len(emails))
+	if len(emails) > 0 {
+		found, err := s.Store.List(ctx, repository.EmployeeCriteria{State: repository.Active, Emails: emails}, repository.Page{})
+		if err != nil {
+			return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		for _, manager := range found {
+			managers[manager.Email] = manager
+		}
+	}
+
+	expanded := make([]models.ExpandedEmployee, len(employees))
+	for i, emp := range employees {
+		expanded[i].EmployeeResponse = models.NewEmployeeResponse(emp)
+		if emp.Manager == nil {
+			continue
+		}
+		if manager, ok := managers[*emp.Manager]; ok {
+			response := models.NewEmployeeResponse(manager)
+			expanded[i].Manager = &response
+		}
+	}
+	return expanded, nil
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// DefaultLeaveAllowance is the number of days off per year an employee is entitled to unless configured otherwise.
+const DefaultLeaveAllowance = 20
+
+// LeaveService manages the time off requested by employees, the decisions of their approvers and the yearly
+// balances the requests are charged to.
+type LeaveService struct {
+	Employees *EmployeeService
+	Leave     *repository.LeaveRepository
+	// Allowance is the number of days off per year each employee is entitled to.
+	Allowance int
+	// Weekend lists the days that are not charged to the balance.
+	Weekend []time.Weekday
+}
+
+// NewLeaveService creates a new LeaveService with the default allowance and a Saturday and Sunday weekend.
+func NewLeaveService(employees *EmployeeService, leave *repository.LeaveRepository) *LeaveService {
+	return &LeaveService{
+		Employees: employees,
+		Leave:     leave,
+		Allowance: DefaultLeaveAllowance,
+		Weekend:   []time.Weekday{time.Saturday, time.Sunday},
+	}
+}
+
+// SubmitLeaveRequest records a request for time off by the employee, who must be the caller. The request is
+// pending until the employee's approver, their manager or the manager's delegate, decides on it, and its days are
+// held against the balance of its year meanwhile.
+func (s *LeaveService) SubmitLeaveRequest(ctx context.Context, email, caller string, req models.LeaveRequestBoundary, now time.Time) (models.LeaveRequest, error) {
+	if caller != email {
+		return models.LeaveRequest{}, errors.NewCodedError(http.StatusForbidden, errors.CodeForbidden, "time off can only be requested by the employee")
+	}
+	if err := validateStruct(req); err != nil {
+		return models.LeaveRequest{}, err
+	}
+	start, _ := time.Parse(time.DateOnly, req.StartDate)
+	end, _ := time.Parse(time.DateOnly, req.EndDate)
+	if end.Before(start) {
+		return models.LeaveRequest{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidLeaveDates, "endDate cannot be before startDate")
+	}
+	if end.Year() != start.Year() {
+		return models.LeaveRequest{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidLeaveDates,
+			"startDate and endDate must be in the same year; request each year separately")
+	}
+	days := s.workingDays(start, end)
+	if days == 0 {
+		return models.LeaveRequest{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidLeaveDates, "the requested dates include no working day")
+	}
+	approver, err := s.Employees.ResolveApprover(ctx, email, now)
+	if err != nil {
+		return models.LeaveRequest{}, err
+	}
+	if err := s.checkOverlap(ctx, email, req.StartDate, req.EndDate); err != nil {
+		return models.LeaveRequest{}, err
+	}
+
+	leave := models.LeaveRequest{
+		ID:        bson.NewObjectID().Hex(),
+		Employee:  email,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Year:      start.Year(),
+		Days:      days,
+		Reason:    req.Reason,
+		Status:    models.LeavePending,
+		Approver:  approver.Email,
+		CreatedAt: now,
+	}
+	if err := s.reserve(ctx, email, leave.Year, days); err != nil {
+		return models.LeaveRequest{}, err
+	}
+	coll, err := s.Leave.Requests.In(ctx)
+	if err != nil {
+		return models.LeaveRequest{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.InsertOne(ctx, leave); err != nil {
+		s.charge(ctx, leave, bson.M{"pending": -days})
+		return models.LeaveRequest{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return leave, nil
+}
+
+// ListLeaveRequests returns a page of the employee's leave requests, latest start date first.
+func (s *LeaveService) ListLeaveRequests(ctx context.Context, email string, page, size int) ([]models.LeaveRequest, error) {
+	if err := ensureEmployee(ctx, s.Employees.Store, email); err != nil {
+		return nil, err
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "startDate", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((page - 1) * size)).
+		SetLimit(int64(size))
+	coll, err := s.Leave.Requests.In(ctx)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx, bson.M{"employee": email}, findOptions)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer cursor.Close(ctx)
+
+	requests := []models.LeaveRequest{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return requests, nil
+}
+
+// GetLeaveRequest returns the leave request with the given id.
+func (s *LeaveService) GetLeaveRequest(ctx context.Context, id string) (models.LeaveRequest, error) {
+	var leave models.LeaveRequest
+	coll, err := s.Leave.Requests.In(ctx)
+	if err != nil {
+		return models.LeaveRequest{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOne(ctx, bson.M{"_id": id}).Decode(&leave)
+	if err == mongo.ErrNoDocuments {
+		return models.LeaveRequest{}, errors.NewCodedError(http.StatusNotFound, errors.CodeLeaveRequestNotFound, "leave request not found")
+	}
+	if err != nil {
+		return models.LeaveRequest{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return leave, nil
+}
+
+// DecideLeaveRequest approves or rejects a pending leave request. The caller must be the current approver of the
+// employee, resolved from their manager and the manager's delegation at now. Approved days move from pending to
+// used in the balance; rejected days are released.
+func (s *LeaveService) DecideLeaveRequest(ctx context.Context, id, caller string, approve bool, decision models.LeaveDecisionBoundary, now time.Time) (models.LeaveRequest, error) {
+	if err := validateStruct(decision); err != nil {
+		return models.LeaveRequest{}, err
+	}
+	leave, err := s.pendingRequest(ctx, id)
+	if err != nil {
+		return models.LeaveRequest{}, err
+	}
+	approver, err := s.Employees.ResolveApprover(ctx, leave.Employee, now)
+	if err != nil {
+		return models.LeaveRequest{}, err
+	}
+	if caller != approver.Email {
+		return models.LeaveRequest{}, errors.NewCodedError(http.StatusForbidden, errors.CodeForbidden,
+			"only "+approver.Email+" can decide on the time off of "+leave.Employee)
+	}
+
+	status, change := models.LeaveRejected, bson.M{"pending": -leave.Days}
+	if approve {
+		status, change = models.LeaveApproved, bson.M{"pending": -leave.Days, "used": leave.Days}
+	}
+	set := bson.M{"status": status, "approver": caller, "decidedAt": now}
+	if decision.Comment != "" {
+		set["comment"] = decision.Comment
+	}
+	return s.close(ctx, leave, set, change)
+}
+
+// CancelLeaveRequest withdraws a pending leave request of the caller and releases its days.
+func (s *LeaveService) CancelLeaveRequest(ctx context.Context, id, caller string, now time.Time) (models.LeaveRequest, error) {
+	leave, err := s.pendingRequest(ctx, id)
+	if err != nil {
+		return models.LeaveRequest{}, err
+	}
+	if caller != leave.Employee {
+		return models.LeaveRequest{}, errors.NewCodedError(http.StatusForbidden, errors.CodeForbidden, "time off can only be cancelled by the employee")
+	}
+	return s.close(ctx, leave, bson.M{"status": models.LeaveCancelled, "decidedAt": now}, bson.M{"pending": -leave.Days})
+}
+
+// GetLeaveBalance returns the balance of the employee for the year. A year without requests has the full allowance.
+func (s *LeaveService) GetLeaveBalance(ctx context.Context, email string, year int) (models.LeaveBalance, error) {
+	if err := ensureEmployee(ctx, s.Employees.Store, email); err != nil {
+		return models.LeaveBalance{}, err
+	}
+	balance := models.LeaveBalance{Employee: email, Year: year, Allowance: s.Allowance}
+	coll, err := s.Leave.Balances.In(ctx)
+	if err != nil {
+		return models.LeaveBalance{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOne(ctx, bson.M{"_id": balanceID(email, year)}).Decode(&balance)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return models.LeaveBalance{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	balance.Remaining = balance.Allowance - balance.Used - balance.Pending
+	return balance, nil
+}
+
+// pendingRequest returns the leave request with the given id, failing with 409 unless it is pending.
+func (s *LeaveService) pendingRequest(ctx context.Context, id string) (models.LeaveRequest, error) {
+	leave, err := s.GetLeaveRequest(ctx, id)
+	if err != nil {
+		return models.LeaveRequest{}, err
+	}
+	if leave.Status != models.LeavePending {
+		return models.LeaveRequest{}, errors.NewCodedError(http.StatusConflict, errors.CodeLeaveNotPending, "leave request is already "+leave.Status)
+	}
+	return leave, nil
+}
+
+// close moves a pending leave request out of the pending status with the given fields, then applies the change to
+// the balance. It fails with 409 when another decision got there first.
+func (s *LeaveService) close(ctx context.Context, leave models.LeaveRequest, set, change bson.M) (models.LeaveRequest, error) {
+	var closed models.LeaveRequest
+	coll, err := s.Leave.Requests.In(ctx)
+	if err != nil {
+		return models.LeaveRequest{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOneAndUpdate(ctx,
+		bson.M{"_id": leave.ID, "status": models.LeavePending},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&closed)
+	if err == mongo.ErrNoDocuments {
+		return models.LeaveRequest{}, errors.NewCodedError(http.StatusConflict, errors.CodeLeaveNotPending, "leave request is no longer pending")
+	}
+	if err != nil {
+		return models.LeaveRequest{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if err := s.charge(ctx, leave, change); err != nil {
+		return models.LeaveRequest{}, err
+	}
+	return closed, nil
+}
+
+// reserve holds days of the employee's balance for the year, creating the balance with the allowance on first use.
+// It fails with 409 when fewer days remain.
+func (s *LeaveService) reserve(ctx context.Context, email string, year, days int) error {
+	id := balanceID(email, year)
+	coll, err := s.Leave.Balances.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	_, err = coll.UpdateOne(ctx, bson.M{"_id": id},
+		bson.M{"$setOnInsert": bson.M{"employee": email, "year": year, "allowance": s.Allowance, "used": 0, "pending": 0}},
+		options.UpdateOne().SetUpsert(true))
+	// A concurrent request may have created the balance first.
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.UpdateOne(ctx,
+		bson.M{"_id": id, "$expr": bson.M{"$lte": bson.A{bson.M{"$add": bson.A{"$used", "$pending", days}}, "$allowance"}}},
+		bson.M{"$inc": bson.M{"pending": days}})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.MatchedCount == 0 {
+		balance, err := s.GetLeaveBalance(ctx, email, year)
+		if err != nil {
+			return err
+		}
+		return errors.NewCodedError(http.StatusConflict, errors.CodeLeaveBalanceExceeded,
+			fmt.Sprintf("requested %d days but only %d remain in %d", days, max(balance.Remaining, 0), year))
+	}
+	return nil
+}
+
+// charge applies the change to the balance the leave request is charged to.
+func (s *LeaveService) charge(ctx context.Context, leave models.LeaveRequest, change bson.M) error {
+	coll, err := s.Leave.Balances.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	_, err = coll.UpdateOne(ctx, bson.M{"_id": balanceID(leave.Employee, leave.Year)}, bson.M{"$inc": change})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// checkOverlap fails with 409 when a pending or approved request of the employee shares a day with the dates.
+func (s *LeaveService) checkOverlap(ctx context.Context, email, startDate, endDate string) error {
+	// Dates are formatted as YYYY-MM-DD, so they are compared as strings.
+	filter := bson.M{
+		"employee":  email,
+		"status":    bson.M{"$in": bson.A{models.LeavePending, models.LeaveApproved}},
+		"startDate": bson.M{"$lte": endDate},
+		"endDate":   bson.M{"$gte": startDate},
+	}
+	var existing models.LeaveRequest
+	coll, err := s.Leave.Requests.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOne(ctx, filter).Decode(&existing)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return errors.NewCodedError(http.StatusConflict, errors.CodeLeaveOverlap,
+		"the dates overlap the "+existing.Status+" request "+existing.ID+" from "+existing.StartDate+" to "+existing.EndDate)
+}
+
+// workingDays counts the days from start to end, both included, that are not weekend days.
+func (s *LeaveService) workingDays(start, end time.Time) int {
+	days := 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if !slices.Contains(s.Weekend, day.Weekday()) {
+			days++
+		}
+	}
+	return days
+}
+
+// balanceID identifies the balance of the employee for the year.
+func balanceID(email string, year int) string {
+	return email + "/" + strconv.Itoa(year)
+}
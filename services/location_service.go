@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// LocationService manages the offices employees work from.
+type LocationService struct {
+	Employees EmployeeStore
+	Locations *repository.LocationRepository
+}
+
+// NewLocationService creates a new LocationService using the provided repositories.
+func NewLocationService(employees EmployeeStore, locations *repository.LocationRepository) *LocationService {
+	return &LocationService{
+		Employees: employees,
+		Locations: locations,
+	}
+}
+
+// CreateLocation adds an office, failing with 409 when one with the same name exists.
+func (s *LocationService) CreateLocation(ctx context.Context, location models.Location) (models.Location, error) {
+	if err := validateStruct(location); err != nil {
+		return models.Location{}, err
+	}
+	coll, err := s.Locations.Collection.In(ctx)
+	if err != nil {
+		return models.Location{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.InsertOne(ctx, location); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Location{}, errors.NewCodedError(http.StatusConflict, errors.CodeLocationExists, "a location with this name already exists")
+		}
+		return models.Location{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return location, nil
+}
+
+// GetLocation returns the office with the given name.
+func (s *LocationService) GetLocation(ctx context.Context, name string) (models.Location, error) {
+	var location models.Location
+	coll, err := s.Locations.Collection.In(ctx)
+	if err != nil {
+		return models.Location{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOne(ctx, bson.M{"_id": name}).Decode(&location)
+	if err == mongo.ErrNoDocuments {
+		return models.Location{}, errors.NewCodedError(http.StatusNotFound, errors.CodeLocationNotFound, "location not found")
+	}
+	if err != nil {
+		return models.Location{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return location, nil
+}
+
+// ListLocations returns a page of the offices ordered by name.
+func (s *LocationService) ListLocations(ctx context.Context, page, size int) ([]models.Location, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetSkip(int64((page - 1) * size)).
+		SetLimit(int64(size))
+	coll, err := s.Locations.Collection.In(ctx)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer cursor.Close(ctx)
+
+	locations := []models.Location{}
+	if err := cursor.All(ctx, &locations); err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return locations, nil
+}
+
+// UpdateLocation replaces the address and time zone of an office. The name cannot be changed, since employees
+// reference it; a body naming another location is rejected with 400.
+func (s *LocationService) UpdateLocation(ctx context.Context, name string, location models.Location) (models.Location, error) {
+	if location.Name == "" {
+		location.Name = name
+	}
+	if location.Name != name {
+		return models.Location{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeLocationNameChange, "the name of a location cannot be changed")
+	}
+	if err := validateStruct(location); err != nil {
+		return models.Location{}, err
+	}
+	coll, err := s.Locations.Collection.In(ctx)
+	if err != nil {
+		return models.Location{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.ReplaceOne(ctx, bson.M{"_id": name}, location)
+	if err != nil {
+		return models.Location{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.MatchedCount == 0 {
+		return models.Location{}, errors.NewCodedError(http.StatusNotFound, errors.CodeLocationNotFound, "location not found")
+	}
+	return location, nil
+}
+
+// DeleteLocation removes an office. Offices still assigned to an employee, including soft-deleted ones that may
+// be restored, are kept and reported with 409.
+func (s *LocationService) DeleteLocation(ctx context.Context, name string) error {
+	assigned, err := s.Employees.Count(ctx, repository.EmployeeCriteria{State: repository.AnyState, Location: name})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if assigned > 0 {
+		return errors.NewCodedError(http.StatusConflict, errors.CodeLocationInUse,
+			"location is assigned to "+strconv.FormatInt(assigned, 10)+" employees; move them first")
+	}
+	coll, err := s.Locations.Collection.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.DeletedCount == 0 {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeLocationNotFound, "location not found")
+	}
+	return nil
+}
+
+// validateLocation checks that the office an employee works from exists.
+func (s *EmployeeService) validateLocation(ctx context.Context, name string) error {
+	if name == "" || s.Locations == nil {
+		return nil
+	}
+	coll, err := s.Locations.Collection.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	count, err := coll.CountDocuments(ctx, bson.M{"_id": name})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if count == 0 {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeLocationNotFound, "location not found")
+	}
+	return nil
+}
+
+// GetEmployeesByLocation returns the employees working from the given office, ordered by the given sort key.
+func (s *EmployeeService) GetEmployeesByLocation(ctx context.Context, location string, page, size int, sortBy string) ([]models.Employee, error) {
+	return s.findEmployees(ctx, repository.EmployeeCriteria{Location: location}, page, size, sortBy)
+}
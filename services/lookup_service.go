@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+)
+
+// MaxLookupEmails caps the number of emails fetched by a single multi-get.
+const MaxLookupEmails = 100
+
+// GetEmployeesByEmails fetches the employees with the emails of the query in a single round trip.
+// The results follow the order of the emails; emails without an employee are reported as not found.
+func (s *EmployeeService) GetEmployeesByEmails(ctx context.Context, query models.EmployeeQuery) ([]models.EmployeeLookup, error) {
+	if query.Password != "" || repository.HasExampleFields(query.ToEmployee()) {
+		return nil, errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidCriteria, "emails cannot be combined with example fields")
+	}
+	if len(query.Emails) > MaxLookupEmails {
+		return nil, errors.NewCodedError(http.StatusBadRequest, errors.CodeLookupTooLarge, "too many emails in a single query")
+	}
+	lookups := make([]models.EmployeeLookup, len(query.Emails))
+	if len(query.Emails) == 0 {
+		return lookups, nil
+	}
+
+	employees, err := s.Store.List(ctx, repository.EmployeeCriteria{State: repository.Active, Emails: query.Emails}, repository.Page{})
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	byEmail := make(map[string]models.Employee, len(employees))
+	for _, emp := range employees {
+		byEmail[emp.Email] = emp
+	}
+
+	for i, email := range query.Emails {
+		lookups[i] = models.EmployeeLookup{Email: email}
+		if emp, found := byEmail[email]; found {
+			response := models.NewEmployeeResponse(emp)
+			lookups[i].Found, lookups[i].Employee = true, &response
+		}
+	}
+	return lookups, nil
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// managerChain returns the employee with the given email followed by their manager, the manager's manager and so on.
+// With maxDepth > 0, at most maxDepth managers above the employee are returned. The walk stops at the first employee
+// without an existing manager, or when it comes back to the employee.
+func (s *EmployeeService) managerChain(ctx context.Context, email string, maxDepth int) ([]models.Employee, error) {
+	chain, err := s.Store.ManagerChain(ctx, activeEmployee(email), maxDepth)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return chain, nil
+}
+
+// GetManagerChain returns the managers above an employee, starting with their direct manager.
+// With depth > 0, at most depth managers are returned.
+func (s *EmployeeService) GetManagerChain(ctx context.Context, employeeEmail string, depth int) ([]models.Employee, error) {
+	chain, err := s.managerChain(ctx, employeeEmail, depth)
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) == 0 {
+		return nil, errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
+	return chain[1:], nil
+}
+
+// checkManagerCycle fails with 409 when making managerEmail the manager of employeeEmail would close a cycle,
+// that is when employeeEmail is one of the managers above managerEmail. The message lists the cycle, starting and
+// ending with the employee. An employee named as their own manager is rejected with 400 instead.
+func (s *EmployeeService) checkManagerCycle(ctx context.Context, employeeEmail, managerEmail string) error {
+	if managerEmail == "" {
+		return nil
+	}
+	if managerEmail == employeeEmail {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeManagerSelf, "an employee cannot be their own manager")
+	}
+	chain, err := s.managerChain(ctx, managerEmail, 0)
+	if err != nil {
+		return err
+	}
+	path := []string{employeeEmail}
+	for _, manager := range chain {
+		path = append(path, manager.Email)
+		if manager.Email == employeeEmail {
+			return errors.NewCodedError(http.StatusConflict, errors.CodeManagerCycle,
+				"manager would create a cycle: "+strings.Join(path, " -> "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// Limits applied to employee metadata, enforced by the validate tag of models.Employee.
+const (
+	// MaxMetadataEntries is the most attributes an employee can carry.
+	MaxMetadataEntries = 32
+	// MaxMetadataValueLength is the longest attribute value, in characters.
+	MaxMetadataValueLength = 256
+)
+
+// metadataKeyPattern restricts keys to identifiers, which are safe as Mongo field names and XML element names.
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,63}$`)
+
+// validateMetadataKey checks that key is well formed and, when allowed is not empty, listed in it.
+func validateMetadataKey(key string, allowed []string) error {
+	if !metadataKeyPattern.MatchString(key) {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidMetadata,
+			fmt.Sprintf("metadata key %q must start with a letter and contain only letters, digits and underscores", key))
+	}
+	if len(allowed) > 0 && !slices.Contains(allowed, key) {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidMetadata, fmt.Sprintf("metadata key %q is not allowed", key))
+	}
+	return nil
+}
+
+// validateMetadata checks that the metadata keys are allowed. Their format, the number of entries and
+// the value lengths are checked by the validate tag of models.Employee.
+func (s *EmployeeService) validateMetadata(metadata models.Metadata) error {
+	for key := range metadata {
+		if err := validateMetadataKey(key, s.MetadataKeys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// MaxNoteLength is the longest note accepted, in characters.
+const MaxNoteLength = 10000
+
+// NoteService manages the HR notes attached to employees.
+type NoteService struct {
+	Employees EmployeeStore
+	Notes     *repository.NoteRepository
+}
+
+// NewNoteService creates a new NoteService using the provided repositories.
+func NewNoteService(employees EmployeeStore, notes *repository.NoteRepository) *NoteService {
+	return &NoteService{
+		Employees: employees,
+		Notes:     notes,
+	}
+}
+
+// AddNote attaches a note written by author, the signed-in caller, to the employee.
+func (s *NoteService) AddNote(ctx context.Context, email, author string, req models.NoteRequest, now time.Time) (models.Note, error) {
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return models.Note{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeNoteRequiredFields, "text is required")
+	}
+	if utf8.RuneCountInString(text) > MaxNoteLength {
+		return models.Note{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeNoteTooLong,
+			fmt.Sprintf("text must not exceed %d characters", MaxNoteLength))
+	}
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.Note{}, err
+	}
+
+	note := models.Note{
+		ID:        bson.NewObjectID().Hex(),
+		Employee:  email,
+		Author:    author,
+		Text:      text,
+		CreatedAt: now,
+	}
+	coll, err := s.Notes.Collection.In(ctx)
+	if err != nil {
+		return models.Note{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.InsertOne(ctx, note); err != nil {
+		return models.Note{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return note, nil
+}
+
+// ListNotes returns a page of the employee's notes, newest first.
+func (s *NoteService) ListNotes(ctx context.Context, email string, page, size int) ([]models.Note, error) {
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return nil, err
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((page - 1) * size)).
+		SetLimit(int64(size))
+	coll, err := s.Notes.Collection.In(ctx)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx, bson.M{"employee": email}, findOptions)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer cursor.Close(ctx)
+
+	notes := []models.Note{}
+	if err := cursor.All(ctx, &notes); err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return notes, nil
+}
+
+// DeleteNote removes one of the employee's notes.
+func (s *NoteService) DeleteNote(ctx context.Context, email, noteID string) error {
+	coll, err := s.Notes.Collection.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.DeleteOne(ctx, bson.M{"_id": noteID, "employee": email})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.DeletedCount == 0 {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeNoteNotFound, "note not found")
+	}
+	return nil
+}
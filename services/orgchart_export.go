@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"WebMVCEmployees/models"
+)
+
+// Text formats the reporting graph can be exported in.
+const (
+	// ExportFormatDOT is the Graphviz DOT language.
+	ExportFormatDOT = "dot"
+	// ExportFormatMermaid is a Mermaid flowchart.
+	ExportFormatMermaid = "mermaid"
+)
+
+// Export renders a snapshot, or the live organization for "current", in the given text format.
+func (s *OrgChartService) Export(ctx context.Context, snapshotID, format string) (string, error) {
+	snapshot, err := s.GetSnapshot(ctx, snapshotID)
+	if err != nil {
+		return "", err
+	}
+	if format == ExportFormatMermaid {
+		return RenderMermaid(snapshot), nil
+	}
+	return RenderDOT(snapshot), nil
+}
+
+// graphNodes returns the employees of a snapshot followed by the managers it references without listing them,
+// so every edge of the graph has both ends declared.
+func graphNodes(snapshot models.OrgSnapshot) []models.OrgNode {
+	nodes := append([]models.OrgNode{}, snapshot.Nodes...)
+	listed := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		listed[node.Email] = true
+	}
+	for _, node := range snapshot.Nodes {
+		if manager := managerOf(node); manager != "" && !listed[manager] {
+			listed[manager] = true
+			nodes = append(nodes, models.OrgNode{Email: manager})
+		}
+	}
+	return nodes
+}
+
+// RenderDOT renders the reporting graph of a snapshot in the Graphviz DOT language, with an edge from each
+// manager to each of their reports.
+func RenderDOT(snapshot models.OrgSnapshot) string {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace
+	var b strings.Builder
+	b.WriteString("digraph orgchart {\n")
+	b.WriteString("  rankdir=TB;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, node := range graphNodes(snapshot) {
+		// The label is quoted first, so its line break stays a DOT escape.
+		label := quote(node.Name) + `\n` + quote(node.Email)
+		if node.Name == "" {
+			label = quote(node.Email)
+		}
+		fmt.Fprintf(&b, "  \"%s\" [label=\"%s\"];\n", quote(node.Email), label)
+	}
+	for _, node := range snapshot.Nodes {
+		if manager := managerOf(node); manager != "" {
+			fmt.Fprintf(&b, "  \"%s\" -> \"%s\";\n", quote(manager), quote(node.Email))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// RenderMermaid renders the reporting graph of a snapshot as a top-down Mermaid flowchart, with an edge from
+// each manager to each of their reports. Nodes are numbered, since emails are not valid Mermaid identifiers.
+func RenderMermaid(snapshot models.OrgSnapshot) string {
+	escape := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace
+	nodes := graphNodes(snapshot)
+	ids := make(map[string]string, len(nodes))
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+	for i, node := range nodes {
+		ids[node.Email] = fmt.Sprintf("n%d", i)
+		label := escape(node.Email)
+		if node.Name != "" {
+			label = escape(node.Name) + "<br/>" + label
+		}
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[node.Email], label)
+	}
+	for _, node := range snapshot.Nodes {
+		if manager := managerOf(node); manager != "" {
+			fmt.Fprintf(&b, "  %s --> %s\n", ids[manager], ids[node.Email])
+		}
+	}
+	return b.String()
+}
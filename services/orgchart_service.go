@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// OrgChartService captures and compares snapshots of the organization's reporting structure.
+type OrgChartService struct {
+	Employees EmployeeStore
+	Snapshots *repository.OrgSnapshotRepository
+}
+
+// NewOrgChartService creates a new OrgChartService using the provided repositories.
+func NewOrgChartService(employees EmployeeStore, snapshots *repository.OrgSnapshotRepository) *OrgChartService {
+	return &OrgChartService{
+		Employees: employees,
+		Snapshots: snapshots,
+	}
+}
+
+// CreateSnapshot stores the current reporting structure under a new identifier.
+func (s *OrgChartService) CreateSnapshot(ctx context.Context, label string) (models.OrgSnapshot, error) {
+	snapshot, err := s.currentSnapshot(ctx)
+	if err != nil {
+		return models.OrgSnapshot{}, err
+	}
+	snapshot.ID = bson.NewObjectID().Hex()
+	snapshot.Label = label
+	coll, err := s.Snapshots.Collection.In(ctx)
+	if err != nil {
+		return models.OrgSnapshot{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.InsertOne(ctx, snapshot); err != nil {
+		return models.OrgSnapshot{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return snapshot, nil
+}
+
+// GetSnapshot returns a stored snapshot, or the live organization for "current".
+func (s *OrgChartService) GetSnapshot(ctx context.Context, id string) (models.OrgSnapshot, error) {
+	if id == models.CurrentSnapshotID {
+		return s.currentSnapshot(ctx)
+	}
+	var snapshot models.OrgSnapshot
+	coll, err := s.Snapshots.Collection.In(ctx)
+	if err != nil {
+		return models.OrgSnapshot{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOne(ctx, bson.M{"_id": id}).Decode(&snapshot)
+	if err == mongo.ErrNoDocuments {
+		return models.OrgSnapshot{}, errors.NewCodedError(http.StatusNotFound, errors.CodeSnapshotNotFound, "snapshot "+id+" not found")
+	}
+	if err != nil {
+		return models.OrgSnapshot{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return snapshot, nil
+}
+
+// Diff compares the snapshot identified by req.From with req.To, or with the uploaded req.Snapshot.
+func (s *OrgChartService) Diff(ctx context.Context, req models.OrgDiffRequest) (models.OrgDiff, error) {
+	if req.From == "" {
+		return models.OrgDiff{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeSnapshotInvalid, "from is required")
+	}
+	if (req.To == "") == (req.Snapshot == nil) {
+		return models.OrgDiff{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeSnapshotInvalid, "exactly one of to or snapshot is required")
+	}
+	from, err := s.GetSnapshot(ctx, req.From)
+	if err != nil {
+		return models.OrgDiff{}, err
+	}
+	var to models.OrgSnapshot
+	if req.Snapshot != nil {
+		if err := validateSnapshot(*req.Snapshot); err != nil {
+			return models.OrgDiff{}, err
+		}
+		to = *req.Snapshot
+	} else if to, err = s.GetSnapshot(ctx, req.To); err != nil {
+		return models.OrgDiff{}, err
+	}
+	return DiffSnapshots(from, to), nil
+}
+
+// currentSnapshot reads the live reporting structure.
+func (s *OrgChartService) currentSnapshot(ctx context.Context) (models.OrgSnapshot, error) {
+	employees, err := s.Employees.List(ctx, repository.EmployeeCriteria{State: repository.Active},
+		repository.Page{Sort: []repository.SortKey{{Field: models.EmployeeRef.Email}}})
+	if err != nil {
+		return models.OrgSnapshot{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	nodes := make([]models.OrgNode, len(employees))
+	for i, emp := range employees {
+		nodes[i] = models.OrgNode{Email: emp.Email, Name: emp.Name, Manager: emp.Manager}
+	}
+	return models.OrgSnapshot{
+		CreatedAt: time.Now().UTC(),
+		Nodes:     nodes,
+	}, nil
+}
+
+// validateSnapshot checks that an uploaded snapshot lists every employee once.
+func validateSnapshot(snapshot models.OrgSnapshot) error {
+	seen := make(map[string]bool, len(snapshot.Nodes))
+	for _, node := range snapshot.Nodes {
+		if node.Email == "" {
+			return errors.NewCodedError(http.StatusBadRequest, errors.CodeSnapshotInvalid, "every snapshot node requires an email")
+		}
+		if seen[node.Email] {
+			return errors.NewCodedError(http.StatusBadRequest, errors.CodeSnapshotInvalid, "employee "+node.Email+" appears more than once in the snapshot")
+		}
+		seen[node.Email] = true
+	}
+	return nil
+}
+
+// DiffSnapshots returns the structural changes from one snapshot to another.
+// Every list is sorted by email so the result is stable.
+func DiffSnapshots(from, to models.OrgSnapshot) models.OrgDiff {
+	diff := models.OrgDiff{
+		Added:       []models.OrgNode{},
+		Removed:     []models.OrgNode{},
+		Moved:       []models.OrgMove{},
+		NewManagers: []string{},
+	}
+	before := make(map[string]models.OrgNode, len(from.Nodes))
+	for _, node := range from.Nodes {
+		before[node.Email] = node
+	}
+	after := make(map[string]models.OrgNode, len(to.Nodes))
+	for _, node := range to.Nodes {
+		after[node.Email] = node
+	}
+
+	for _, node := range to.Nodes {
+		previous, ok := before[node.Email]
+		if !ok {
+			diff.Added = append(diff.Added, node)
+			continue
+		}
+		if managerOf(previous) != managerOf(node) {
+			diff.Moved = append(diff.Moved, models.OrgMove{
+				Email:       node.Email,
+				FromManager: previous.Manager,
+				ToManager:   node.Manager,
+			})
+		}
+	}
+	for _, node := range from.Nodes {
+		if _, ok := after[node.Email]; !ok {
+			diff.Removed = append(diff.Removed, node)
+		}
+	}
+
+	managersBefore := managers(from)
+	for manager := range managers(to) {
+		if !managersBefore[manager] {
+			diff.NewManagers = append(diff.NewManagers, manager)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Email < diff.Added[j].Email })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Email < diff.Removed[j].Email })
+	sort.Slice(diff.Moved, func(i, j int) bool { return diff.Moved[i].Email < diff.Moved[j].Email })
+	sort.Strings(diff.NewManagers)
+	return diff
+}
+
+// managerOf returns the node's manager email, or "" if it has none.
+func managerOf(node models.OrgNode) string {
+	if node.Manager == nil {
+		return ""
+	}
+	return *node.Manager
+}
+
+// managers returns the set of employees with at least one direct report.
+func managers(snapshot models.OrgSnapshot) map[string]bool {
+	result := make(map[string]bool)
+	for _, node := range snapshot.Nodes {
+		if manager := managerOf(node); manager != "" {
+			result[manager] = true
+		}
+	}
+	return result
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Register the GIF decoder.
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Limits applied to uploaded photos.
+const (
+	// MaxPhotoBytes is the largest photo accepted, in bytes.
+	MaxPhotoBytes = 1 << 20
+	// MaxPhotoDimension is the largest width or height accepted, in pixels. It keeps a small,
+	// highly compressed file from expanding into a huge image when decoded.
+	MaxPhotoDimension = 4096
+	// ThumbnailSize is the largest width or height of a thumbnail, in pixels.
+	ThumbnailSize = 128
+)
+
+// photoTypes lists the accepted photo content types.
+var photoTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// PhotoService stores employee profile photos along with a thumbnail for directory listings.
+type PhotoService struct {
+	Employees EmployeeStore
+	Photos    *repository.PhotoRepository
+}
+
+// NewPhotoService creates a new PhotoService using the provided repositories.
+func NewPhotoService(employees EmployeeStore, photos *repository.PhotoRepository) *PhotoService {
+	return &PhotoService{
+		Employees: employees,
+		Photos:    photos,
+	}
+}
+
+// SetPhoto validates a JPEG, PNG or GIF photo and stores it for the employee with a generated thumbnail,
+// replacing any previous photo.
+func (s *PhotoService) SetPhoto(ctx context.Context, email string, data []byte) error {
+	if len(data) > MaxPhotoBytes {
+		return errors.NewCodedError(http.StatusRequestEntityTooLarge, errors.CodePhotoTooLarge,
+			fmt.Sprintf("photo must not exceed %d bytes", MaxPhotoBytes))
+	}
+	contentType := http.DetectContentType(data)
+	if !photoTypes[contentType] {
+		return errors.NewCodedError(http.StatusUnsupportedMediaType, errors.CodePhotoUnsupportedType, "photo must be a JPEG, PNG or GIF image")
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodePhotoInvalid, "photo cannot be decoded")
+	}
+	if config.Width > MaxPhotoDimension || config.Height > MaxPhotoDimension {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodePhotoInvalid,
+			fmt.Sprintf("photo must not exceed %dx%d pixels", MaxPhotoDimension, MaxPhotoDimension))
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodePhotoInvalid, "photo cannot be decoded")
+	}
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return err
+	}
+
+	thumbnail, err := encodeThumbnail(img, contentType)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if err := s.Photos.Save(ctx, email, models.PhotoOriginal, models.Photo{ContentType: contentType, Data: data}); err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if err := s.Photos.Save(ctx, email, models.PhotoThumbnail, thumbnail); err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// GetPhoto returns the employee's photo, or its thumbnail when variant is models.PhotoThumbnail.
+func (s *PhotoService) GetPhoto(ctx context.Context, email, variant string) (models.Photo, error) {
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.Photo{}, err
+	}
+	photo, err := s.Photos.Load(ctx, email, variant)
+	if err == mongo.ErrFileNotFound {
+		return models.Photo{}, errors.NewCodedError(http.StatusNotFound, errors.CodePhotoNotFound, "employee has no photo")
+	}
+	if err != nil {
+		return models.Photo{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return photo, nil
+}
+
+// encodeThumbnail scales img down to fit within ThumbnailSize pixels. JPEG photos keep their format;
+// PNG and GIF photos are encoded as PNG to preserve transparency.
+func encodeThumbnail(img image.Image, contentType string) (models.Photo, error) {
+	thumbnail := scaleDown(img, ThumbnailSize)
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: 85}); err != nil {
+			return models.Photo{}, err
+		}
+		return models.Photo{ContentType: contentType, Data: buf.Bytes()}, nil
+	}
+	if err := png.Encode(&buf, thumbnail); err != nil {
+		return models.Photo{}, err
+	}
+	return models.Photo{ContentType: "image/png", Data: buf.Bytes()}, nil
+}
+
+// scaleDown shrinks img, preserving its aspect ratio, so neither side exceeds size pixels.
+// Each target pixel averages the source pixels it covers. Smaller images are returned unchanged.
+func scaleDown(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= size && h <= size {
+		return img
+	}
+	tw, th := size, h*size/w
+	if h > w {
+		tw, th = w*size/h, size
+	}
+	tw, th = max(tw, 1), max(th, 1)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := bounds.Min.Y+y*h/th, bounds.Min.Y+(y+1)*h/th
+		for x := 0; x < tw; x++ {
+			x0, x1 := bounds.Min.X+x*w/tw, bounds.Min.X+(x+1)*w/tw
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			if n == 0 {
+				continue
+			}
+			// Average in premultiplied space, then convert back to straight alpha.
+			c := color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)}
+			dst.Set(x, y, c)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"slices"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// ReplaceEmployee replaces the employee with the given email by emp. With upsert, a missing employee
+// is created instead; it reports whether the employee was created. When expectedVersion is non-zero,
+// the replacement only applies if the employee is still at that version.
+// The internal ID, temporary roles, delegations, dotted reporting lines and the employment status are kept, since
+// they are managed through their own endpoints.
+func (s *EmployeeService) ReplaceEmployee(ctx context.Context, email string, emp models.Employee, expectedVersion int64, upsert bool) (models.Employee, bool, error) {
+	if emp.Email == "" {
+		emp.Email = email
+	} else if emp.Email != email {
+		return models.Employee{}, false, errors.NewCodedError(http.StatusBadRequest, errors.CodeEmailMismatch, "email in the body does not match the URL")
+	}
+	if err := s.validateEmployee(ctx, emp); err != nil {
+		return models.Employee{}, false, err
+	}
+	emp.DeletedAt = nil
+
+	existing, err := s.Store.FindByEmail(ctx, email)
+	missing := err == mongo.ErrNoDocuments
+	switch {
+	case missing:
+		if !upsert {
+			return models.Employee{}, false, errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+		}
+		if expectedVersion != 0 {
+			return models.Employee{}, false, errors.NewCodedError(http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch, "employee was modified by another request")
+		}
+		// Let plugins validate or enrich the employee, as for any other creation.
+		if err := s.Hooks.BeforeCreate(ctx, &emp); err != nil {
+			return models.Employee{}, false, hookError(err)
+		}
+		emp.Version = 1
+		emp.ID = newEmployeeID(time.Now())
+		emp.TemporaryRoles = nil
+		emp.Delegation = nil
+		emp.DottedLineManagers = nil
+		emp.Status = ""
+		emp.StatusHistory = nil
+	case err != nil:
+		return models.Employee{}, false, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	default:
+		if expectedVersion != 0 && expectedVersion != existing.Version {
+			return models.Employee{}, false, errors.NewCodedError(http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch, "employee was modified by another request")
+		}
+		emp.Version = existing.Version + 1
+		emp.ID = existing.ID
+		if emp.ID == "" {
+			emp.ID = newEmployeeID(time.Now())
+		}
+		emp.TemporaryRoles = existing.TemporaryRoles
+		emp.Delegation = existing.Delegation
+		emp.DottedLineManagers = existing.DottedLineManagers
+		emp.Status = existing.Status
+		emp.StatusHistory = existing.StatusHistory
+		// Active temporary roles stay in Roles until they are revoked.
+		for _, grant := range existing.TemporaryRoles {
+			if !slices.Contains(emp.Roles, grant.Role) {
+				emp.Roles = append(emp.Roles, grant.Role)
+			}
+		}
+	}
+
+	emp.DisplayName = emp.ResolveDisplayName()
+	emp.BirthDate, _ = emp.Birthdate.Date()
+	// A soft-deleted employee is matched and overwritten by the upsert, which recreates it.
+	created, err := s.Store.ReplaceEmployee(ctx, emp, existing.Version, upsert)
+	if err != nil {
+		// The employee changed or was created concurrently since it was read.
+		if err == mongo.ErrNoDocuments || mongo.IsDuplicateKeyError(err) {
+			return models.Employee{}, false, errors.NewCodedError(http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch, "employee was modified by another request")
+		}
+		return models.Employee{}, false, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	created = created || missing
+	emp.Password = ""
+	if created {
+		s.Hooks.AfterCreate(ctx, emp)
+	}
+	return emp, created, nil
+}
+
+// RolesChanged reports whether replacing the employee with the given email by one holding roles would change the
+// roles it was granted, or, for a missing employee, grant any. Active temporary roles are kept by a replacement, so
+// leaving them out does not change the roles.
+func (s *EmployeeService) RolesChanged(ctx context.Context, email string, roles []string) (bool, error) {
+	existing, err := s.Store.FindByEmail(ctx, email)
+	if err == mongo.ErrNoDocuments {
+		return len(roles) > 0, nil
+	}
+	if err != nil {
+		return false, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	kept := slices.Clone(roles)
+	for _, grant := range existing.TemporaryRoles {
+		kept = append(kept, grant.Role)
+	}
+	current := slices.Clone(existing.Roles)
+	slices.Sort(kept)
+	slices.Sort(current)
+	return !slices.Equal(slices.Compact(kept), slices.Compact(current)), nil
+}
+
+// PasswordChanged reports whether replacing the employee with the given email by one with password would change its
+// password. A missing employee has no password to change.
+func (s *EmployeeService) PasswordChanged(ctx context.Context, email, password string) (bool, error) {
+	existing, err := s.Store.FindWithCredentials(ctx, activeEmployee(email))
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return subtle.ConstantTimeCompare([]byte(existing.Password), []byte(password)) != 1, nil
+}
+
+// ManagerChanged reports whether replacing the employee with the given email by one reporting to manager would change
+// whom it reports to. A missing employee reports to no one yet.
+func (s *EmployeeService) ManagerChanged(ctx context.Context, email string, manager *string) (bool, error) {
+	existing, err := s.Store.FindByEmail(ctx, email)
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return (existing.Manager == nil) != (manager == nil) || manager != nil && *manager != *existing.Manager, nil
+}
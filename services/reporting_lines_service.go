@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// findEmployee returns the active employee with the given email, failing with 404 when there is none.
+func (s *EmployeeService) findEmployee(ctx context.Context, email string) (models.Employee, error) {
+	emp, err := s.Store.FindByEmail(ctx, email)
+	if err == mongo.ErrNoDocuments {
+		return models.Employee{}, errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
+	if err != nil {
+		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return emp, nil
+}
+
+// GetReportingLines returns the primary reporting line of an employee, if any, followed by the dotted ones.
+func (s *EmployeeService) GetReportingLines(ctx context.Context, employeeEmail string) ([]models.ReportingLine, error) {
+	emp, err := s.findEmployee(ctx, employeeEmail)
+	if err != nil {
+		return nil, err
+	}
+	return emp.ReportingLines(), nil
+}
+
+// AddReportingLine adds a reporting line to an employee. A primary line replaces the manager, exactly like
+// SetManager. A dotted line may not duplicate the primary one; adding an existing dotted line changes nothing.
+// Dotted lines are not part of the hierarchy, so they are not checked for cycles.
+func (s *EmployeeService) AddReportingLine(ctx context.Context, employeeEmail string, line models.ReportingLine) error {
+	if err := validateStruct(line); err != nil {
+		return err
+	}
+	if line.Type == models.ReportingPrimary {
+		return s.SetManager(ctx, employeeEmail, line.Manager, 0)
+	}
+
+	emp, err := s.findEmployee(ctx, employeeEmail)
+	if err != nil {
+		return err
+	}
+	if line.Manager == employeeEmail {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeManagerSelf, "an employee cannot be their own manager")
+	}
+	if emp.Manager != nil && *emp.Manager == line.Manager {
+		return errors.NewCodedError(http.StatusConflict, errors.CodeReportingLineExists, "the manager is already the primary manager of the employee")
+	}
+	if slices.Contains(emp.DottedLineManagers, line.Manager) {
+		return nil
+	}
+	// Dotted lines are informal, so their managers need not hold a manager role.
+	if _, err := s.findManager(ctx, line.Manager); err != nil {
+		return err
+	}
+	_, err = s.Store.AddDottedLine(ctx, activeEmployee(employeeEmail), line.Manager)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// RemoveReportingLine removes a reporting line from an employee, failing with 404 when the employee has no such line.
+// Removing the primary line is the same as RemoveManager.
+func (s *EmployeeService) RemoveReportingLine(ctx context.Context, employeeEmail string, line models.ReportingLine) error {
+	if err := validateStruct(line); err != nil {
+		return err
+	}
+	emp, err := s.findEmployee(ctx, employeeEmail)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(emp.ReportingLines(), line) {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeReportingLineNotFound, "the employee has no such reporting line")
+	}
+	if line.Type == models.ReportingPrimary {
+		return s.RemoveManager(ctx, employeeEmail)
+	}
+	_, err = s.Store.RemoveDottedLine(ctx, activeEmployee(employeeEmail), line.Manager)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+)
+
+// Policies for the direct reports of a deleted employee.
+const (
+	// ReportsBlock refuses to delete an employee who still has direct reports.
+	ReportsBlock = "block"
+	// ReportsOrphan clears the manager of the reports.
+	ReportsOrphan = "orphan"
+	// ReportsReassign moves the reports to another manager.
+	ReportsReassign = "reassign"
+)
+
+// ReportsHandling says what happens to the direct reports of a deleted employee.
+type ReportsHandling struct {
+	// Policy is one of ReportsBlock, ReportsOrphan or ReportsReassign; empty means ReportsBlock.
+	Policy string
+	// ReassignTo is the new manager of the reports under ReportsReassign.
+	ReassignTo string
+}
+
+// inTransaction runs fn in a transaction of the store. fn returns the store errors as they are, so the store can
+// tell a missing transaction support from a failed write; they are converted to 500 responses afterwards.
+func (s *EmployeeService) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := s.Store.InTransaction(ctx, fn)
+	if _, ok := err.(*errors.HTTPError); err != nil && !ok {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return err
+}
+
+// deleteWithReports deletes an employee and applies the policy to their direct reports in a single transaction.
+// Dotted reporting lines to the employee are removed in the same transaction.
+// Under ReportsReassign, a report chosen as the new manager takes the place of the employee and reports to the
+// employee's own manager.
+func (s *EmployeeService) deleteWithReports(ctx context.Context, emp models.Employee, hard bool, reports ReportsHandling, now time.Time) (int64, error) {
+	reportsOf := repository.EmployeeCriteria{State: repository.Active, Managers: []string{emp.Email}}
+	count, err := s.Store.Count(ctx, reportsOf)
+	if err != nil {
+		return 0, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	newManager := ""
+	switch {
+	case count == 0:
+	case reports.Policy == ReportsOrphan:
+	case reports.Policy == ReportsReassign:
+		if reports.ReassignTo == emp.Email {
+			return 0, errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidQuery, "reports cannot be reassigned to the deleted employee")
+		}
+		if err := s.validateManager(ctx, reports.ReassignTo); err != nil {
+			return 0, err
+		}
+		if err := s.checkReassignment(ctx, emp.Email, reports.ReassignTo); err != nil {
+			return 0, err
+		}
+		newManager = reports.ReassignTo
+	default:
+		return 0, errors.NewCodedError(http.StatusConflict, errors.CodeEmployeeHasReports,
+			"employee manages "+strconv.FormatInt(count, 10)+" employees; orphan or reassign their reports first")
+	}
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if count > 0 {
+			others := reportsOf
+			if reports.Policy == ReportsReassign {
+				// The new manager, when one of the reports, moves up to the employee's place.
+				promoted := reportsOf
+				promoted.Emails = []string{reports.ReassignTo}
+				above := ""
+				if emp.Manager != nil {
+					above = *emp.Manager
+				}
+				if _, err := s.Store.SetManager(ctx, promoted, above); err != nil {
+					return err
+				}
+				others.ExceptEmail = reports.ReassignTo
+			}
+			if _, err := s.Store.SetManager(ctx, others, newManager); err != nil {
+				return err
+			}
+		}
+
+		// Dotted lines never block a delete; they are simply dropped.
+		if _, err := s.Store.RemoveDottedLine(ctx, repository.EmployeeCriteria{State: repository.Active}, emp.Email); err != nil {
+			return err
+		}
+
+		var deleted int64
+		var err error
+		if hard {
+			deleted, err = s.Store.Delete(ctx, repository.EmployeeCriteria{State: repository.AnyState, Emails: []string{emp.Email}})
+		} else {
+			deleted, err = s.Store.SoftDelete(ctx, activeEmployee(emp.Email), now)
+		}
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+		}
+		return nil
+	})
+	return count, err
+}
+
+// checkReassignment fails with 409 when the new manager reports, directly or not, to one of the reports
+// of the employee being deleted, which would close a cycle once the reports move under them.
+func (s *EmployeeService) checkReassignment(ctx context.Context, employeeEmail, newManager string) error {
+	chain, err := s.managerChain(ctx, newManager, 0)
+	if err != nil {
+		return err
+	}
+	for _, manager := range chain {
+		if manager.Manager == nil || *manager.Manager != employeeEmail {
+			continue
+		}
+		// A direct report taking over is promoted and cannot close a cycle.
+		if manager.Email == newManager {
+			return nil
+		}
+		return s.checkManagerCycle(ctx, manager.Email, newManager)
+	}
+	return nil
+}
+
+// ReassignReports moves every direct report of the manager from to the manager to, in one transaction, and returns
+// how many employees moved. A direct report chosen as the new manager stays under from and leads the others.
+func (s *EmployeeService) ReassignReports(ctx context.Context, reassignment models.ManagerReassignment) (int64, error) {
+	if err := validateStruct(reassignment); err != nil {
+		return 0, err
+	}
+	from, to := reassignment.From, reassignment.To
+	if from == to {
+		return 0, errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidPayload, "from and to must be different managers")
+	}
+	if err := ensureEmployee(ctx, s.Store, from); err != nil {
+		return 0, err
+	}
+	if err := s.validateManager(ctx, to); err != nil {
+		return 0, err
+	}
+	if err := s.checkReassignment(ctx, from, to); err != nil {
+		return 0, err
+	}
+
+	criteria := repository.EmployeeCriteria{State: repository.Active, Managers: []string{from}, ExceptEmail: to}
+	reports, err := s.Store.List(ctx, criteria, repository.Page{})
+	if err != nil {
+		return 0, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if len(reports) == 0 {
+		return 0, nil
+	}
+	emails := make([]string, len(reports))
+	for i, report := range reports {
+		if err := s.Hooks.BeforeManagerSet(ctx, report.Email, to); err != nil {
+			return 0, hookError(err)
+		}
+		emails[i] = report.Email
+	}
+
+	// Only the reports checked above move; employees assigned to from in the meantime stay.
+	moving := repository.EmployeeCriteria{State: repository.Active, Managers: []string{from}, Emails: emails}
+	var moved int64
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		moved, err = s.Store.SetManager(ctx, moving, to)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, email := range emails {
+		s.Hooks.AfterManagerSet(ctx, email, to)
+	}
+	return moved, nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ReviewService manages the performance reviews managers write about their reports.
+type ReviewService struct {
+	Employees *EmployeeService
+	Reviews   *repository.ReviewRepository
+	// ReaderRoles lists the roles allowed to read the reviews of every employee, besides the employee and their
+	// manager.
+	ReaderRoles []string
+}
+
+// NewReviewService creates a new ReviewService using the provided service and repository.
+func NewReviewService(employees *EmployeeService, reviews *repository.ReviewRepository) *ReviewService {
+	return &ReviewService{
+		Employees: employees,
+		Reviews:   reviews,
+	}
+}
+
+// AddReview records a review of the employee written by the caller, who must be the employee's manager.
+// An employee has at most one review per period.
+func (s *ReviewService) AddReview(ctx context.Context, email, caller string, req models.ReviewRequest, now time.Time) (models.Review, error) {
+	if err := validateStruct(req); err != nil {
+		return models.Review{}, err
+	}
+	if req.PeriodEnd < req.PeriodStart {
+		return models.Review{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidReviewPeriod, "periodEnd cannot be before periodStart")
+	}
+	emp, err := s.Employees.findEmployee(ctx, email)
+	if err != nil {
+		return models.Review{}, err
+	}
+	if emp.Manager == nil || *emp.Manager != caller {
+		return models.Review{}, errors.NewCodedError(http.StatusForbidden, errors.CodeForbidden, "only the manager of "+email+" can review them")
+	}
+
+	review := models.Review{
+		ID:          bson.NewObjectID().Hex(),
+		Employee:    email,
+		Reviewer:    caller,
+		PeriodStart: req.PeriodStart,
+		PeriodEnd:   req.PeriodEnd,
+		Rating:      req.Rating,
+		Comments:    req.Comments,
+		CreatedAt:   now,
+	}
+	coll, err := s.Reviews.Collection.In(ctx)
+	if err != nil {
+		return models.Review{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.InsertOne(ctx, review); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Review{}, errors.NewCodedError(http.StatusConflict, errors.CodeReviewExists, "the employee already has a review for this period")
+		}
+		return models.Review{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return review, nil
+}
+
+// ListReviews returns a page of the employee's reviews, latest period first. Reviews are visible to the employee,
+// to their current manager and to callers holding one of the reader roles; anyone else is refused with 403.
+func (s *ReviewService) ListReviews(ctx context.Context, email, caller string, callerRoles []string, page, size int) ([]models.Review, error) {
+	emp, err := s.Employees.findEmployee(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	isManager := emp.Manager != nil && *emp.Manager == caller
+	isReader := slices.ContainsFunc(callerRoles, func(role string) bool { return slices.Contains(s.ReaderRoles, role) })
+	if caller != email && !isManager && !isReader {
+		return nil, errors.NewCodedError(http.StatusForbidden, errors.CodeForbidden,
+			"reviews are only visible to the employee, their manager and HR")
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "periodEnd", Value: -1}, {Key: "periodStart", Value: -1}}).
+		SetSkip(int64((page - 1) * size)).
+		SetLimit(int64(size))
+	coll, err := s.Reviews.Collection.In(ctx)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx, bson.M{"employee": email}, findOptions)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer cursor.Close(ctx)
+
+	reviews := []models.Review{}
+	if err := cursor.All(ctx, &reviews); err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return reviews, nil
+}
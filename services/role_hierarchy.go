@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// roleCatalog maps role names to their entries in the roles catalog.
+type roleCatalog map[string]models.Role
+
+// loadRoleCatalog reads the whole roles catalog; a nil repository yields an empty catalog, in which no role
+// implies another.
+func loadRoleCatalog(ctx context.Context, roles *repository.RoleRepository) (roleCatalog, error) {
+	catalog := roleCatalog{}
+	if roles == nil {
+		return catalog, nil
+	}
+	coll, err := roles.Collection.In(ctx)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	var entries []models.Role
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	for _, role := range entries {
+		catalog[role.Name] = role
+	}
+	return catalog, nil
+}
+
+// ancestors returns the parent of the role, the parent's parent and so on. The walk stops at a role missing from
+// the catalog or at a role already visited.
+func (c roleCatalog) ancestors(role string) []string {
+	var chain []string
+	for parent := c[role].Parent; parent != "" && parent != role && !slices.Contains(chain, parent); parent = c[parent].Parent {
+		chain = append(chain, parent)
+	}
+	return chain
+}
+
+// implied returns the roles followed by every role they imply, without duplicates.
+func (c roleCatalog) implied(roles []string) []string {
+	effective := []string{}
+	for _, role := range roles {
+		if !slices.Contains(effective, role) {
+			effective = append(effective, role)
+		}
+	}
+	for _, role := range roles {
+		for _, parent := range c.ancestors(role) {
+			if !slices.Contains(effective, parent) {
+				effective = append(effective, parent)
+			}
+		}
+	}
+	return effective
+}
+
+// implying returns the role and every role implying it, that is its descendants in the catalog, sorted by name.
+func (c roleCatalog) implying(role string) []string {
+	roles := []string{role}
+	for name := range c {
+		if name != role && slices.Contains(c.ancestors(name), role) {
+			roles = append(roles, name)
+		}
+	}
+	slices.Sort(roles[1:])
+	return roles
+}
+
+// permissions returns the permissions granted by the roles, in order and without duplicates.
+func (c roleCatalog) permissions(roles []string) []string {
+	permissions := []string{}
+	for _, role := range roles {
+		for _, permission := range c[role].Permissions {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
+
+// GetEffectiveRoles returns the roles an employee holds, including those implied through the catalog,
+// and the permissions they grant.
+func (s *EmployeeService) GetEffectiveRoles(ctx context.Context, employeeEmail string) (models.EffectiveRoles, error) {
+	emp, err := s.findEmployee(ctx, employeeEmail)
+	if err != nil {
+		return models.EffectiveRoles{}, err
+	}
+	catalog, err := loadRoleCatalog(ctx, s.RoleCatalog)
+	if err != nil {
+		return models.EffectiveRoles{}, err
+	}
+	roles := catalog.implied(emp.Roles)
+	return models.EffectiveRoles{Roles: roles, Permissions: catalog.permissions(roles)}, nil
+}
+
+// rolesImplying returns the role and the roles implying it, any of which an employee holding the role has.
+func (s *EmployeeService) rolesImplying(ctx context.Context, role string) ([]string, error) {
+	catalog, err := loadRoleCatalog(ctx, s.RoleCatalog)
+	if err != nil {
+		return nil, err
+	}
+	return catalog.implying(role), nil
+}
+
+// checkRoleParent fails with 400 when the parent of the role is not in the catalog, and with 409 when the role
+// would end up implying itself.
+func (s *RoleService) checkRoleParent(ctx context.Context, role models.Role) error {
+	if role.Parent == "" {
+		return nil
+	}
+	catalog, err := loadRoleCatalog(ctx, s.Roles)
+	if err != nil {
+		return err
+	}
+	if role.Parent == role.Name {
+		return errors.NewCodedError(http.StatusConflict, errors.CodeRoleCycle, "a role cannot be its own parent")
+	}
+	if _, ok := catalog[role.Parent]; !ok {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeRoleParentNotFound, "parent role not found")
+	}
+	if slices.Contains(catalog.ancestors(role.Parent), role.Name) {
+		return errors.NewCodedError(http.StatusConflict, errors.CodeRoleCycle, "role "+role.Name+" would imply itself through "+role.Parent)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// RoleService manages the roles catalog.
+type RoleService struct {
+	Roles *repository.RoleRepository
+}
+
+// NewRoleService creates a new RoleService using the provided repository.
+func NewRoleService(roles *repository.RoleRepository) *RoleService {
+	return &RoleService{
+		Roles: roles,
+	}
+}
+
+// CreateRole adds a role to the catalog, failing with 409 when one with the same name exists.
+func (s *RoleService) CreateRole(ctx context.Context, role models.Role) (models.Role, error) {
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+	if err := validateStruct(role); err != nil {
+		return models.Role{}, err
+	}
+	if err := s.checkRoleParent(ctx, role); err != nil {
+		return models.Role{}, err
+	}
+	coll, err := s.Roles.Collection.In(ctx)
+	if err != nil {
+		return models.Role{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.InsertOne(ctx, role); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Role{}, errors.NewCodedError(http.StatusConflict, errors.CodeRoleExists, "a role with this name already exists")
+		}
+		return models.Role{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return role, nil
+}
+
+// GetRole returns the role with the given name.
+func (s *RoleService) GetRole(ctx context.Context, name string) (models.Role, error) {
+	var role models.Role
+	coll, err := s.Roles.Collection.In(ctx)
+	if err != nil {
+		return models.Role{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOne(ctx, bson.M{"_id": name}).Decode(&role)
+	if err == mongo.ErrNoDocuments {
+		return models.Role{}, errors.NewCodedError(http.StatusNotFound, errors.CodeRoleNotFound, "role not found")
+	}
+	if err != nil {
+		return models.Role{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return role, nil
+}
+
+// ListRoles returns a page of the roles ordered by name.
+func (s *RoleService) ListRoles(ctx context.Context, page, size int) ([]models.Role, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetSkip(int64((page - 1) * size)).
+		SetLimit(int64(size))
+	coll, err := s.Roles.Collection.In(ctx)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer cursor.Close(ctx)
+
+	roles := []models.Role{}
+	if err := cursor.All(ctx, &roles); err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return roles, nil
+}
+
+// UpdateRole replaces the description and permissions of a role. The name cannot be changed, since employees
+// hold it; a body naming another role is rejected with 400.
+func (s *RoleService) UpdateRole(ctx context.Context, name string, role models.Role) (models.Role, error) {
+	if role.Name == "" {
+		role.Name = name
+	}
+	if role.Name != name {
+		return models.Role{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeRoleNameChange, "the name of a role cannot be changed")
+	}
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+	if err := validateStruct(role); err != nil {
+		return models.Role{}, err
+	}
+	if err := s.checkRoleParent(ctx, role); err != nil {
+		return models.Role{}, err
+	}
+	coll, err := s.Roles.Collection.In(ctx)
+	if err != nil {
+		return models.Role{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.ReplaceOne(ctx, bson.M{"_id": name}, role)
+	if err != nil {
+		return models.Role{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.MatchedCount == 0 {
+		return models.Role{}, errors.NewCodedError(http.StatusNotFound, errors.CodeRoleNotFound, "role not found")
+	}
+	return role, nil
+}
+
+// DeleteRole removes a role from the catalog. Employees holding the role keep it; roles implying it are kept
+// and reported with 409.
+func (s *RoleService) DeleteRole(ctx context.Context, name string) error {
+	coll, err := s.Roles.Collection.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	children, err := coll.CountDocuments(ctx, bson.M{"parent": name})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if children > 0 {
+		return errors.NewCodedError(http.StatusConflict, errors.CodeRoleHasChildren,
+			"role is the parent of "+strconv.FormatInt(children, 10)+" roles; change their parent first")
+	}
+	res, err := coll.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.DeletedCount == 0 {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeRoleNotFound, "role not found")
+	}
+	return nil
+}
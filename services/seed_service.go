@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// SeedEmployees creates the employees of a fixture as CreateEmployee does, inserting them in batches, and skips those
+// whose email is taken, so seeding again leaves the store as it is. Employees must come after their manager. It
+// returns how many employees were created and skipped; employees failing otherwise are not created either, and the
+// first of them is returned as the error.
+func (s *EmployeeService) SeedEmployees(ctx context.Context, emps []models.Employee) (created, skipped int, err error) {
+	for i, createErr := range s.createEmployees(ctx, emps, false) {
+		if httpErr, ok := createErr.(*errors.HTTPError); ok && httpErr.ErrorCode == errors.CodeEmployeeDuplicateEmail {
+			skipped++
+			continue
+		}
+		if createErr != nil {
+			if err == nil {
+				err = fmt.Errorf("employee %s: %w", emps[i].Email, createErr)
+			}
+			continue
+		}
+		created++
+	}
+	return created, skipped, err
+}
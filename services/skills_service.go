@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+)
+
+// GetEmployeesBySkill returns the employees having the named skill at minLevel or above, ordered by the given sort key.
+func (s *EmployeeService) GetEmployeesBySkill(ctx context.Context, skill string, minLevel, page, size int, sortBy string) ([]models.Employee, error) {
+	return s.findEmployees(ctx, repository.EmployeeCriteria{Skill: skill, MinSkillLevel: minLevel}, page, size, sortBy)
+}
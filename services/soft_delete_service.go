@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// activeEmployee selects the employee with the email unless it is soft-deleted.
+func activeEmployee(email string) repository.EmployeeCriteria {
+	return repository.EmployeeCriteria{State: repository.Active, Emails: []string{email}}
+}
+
+// DeleteEmployee soft-deletes an employee, hiding it from every query until it is restored.
+// With hard, the employee document is removed for good, even if it was already soft-deleted.
+// The direct reports of the employee are handled by the given policy, in the same transaction as the delete.
+func (s *EmployeeService) DeleteEmployee(ctx context.Context, employeeEmail string, hard bool, reports ReportsHandling, now time.Time) error {
+	criteria := activeEmployee(employeeEmail)
+	if hard {
+		criteria.State = repository.AnyState
+	}
+	emp, err := s.Store.FindOne(ctx, criteria)
+	if err == mongo.ErrNoDocuments {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	count, err := s.deleteWithReports(ctx, emp, hard, reports, now)
+	if err != nil {
+		return err
+	}
+	details := map[string]string{"hard": strconv.FormatBool(hard)}
+	if count > 0 {
+		details["reports"] = strconv.FormatInt(count, 10)
+		details["reportsPolicy"] = reports.Policy
+		if reports.Policy == ReportsReassign {
+			details["reassignTo"] = reports.ReassignTo
+		}
+	}
+	s.audit(ctx, models.AuditEntry{
+		Time:     now,
+		Action:   models.AuditEmployeeDeleted,
+		Employee: employeeEmail,
+		Details:  details,
+	})
+	return nil
+}
+
+// RestoreEmployee brings back a soft-deleted employee.
+func (s *EmployeeService) RestoreEmployee(ctx context.Context, employeeEmail string, now time.Time) (models.Employee, error) {
+	emp, err := s.Store.Restore(ctx, repository.EmployeeCriteria{State: repository.Deleted, Emails: []string{employeeEmail}})
+	if err == mongo.ErrNoDocuments {
+		count, err := s.Store.Count(ctx, repository.EmployeeCriteria{State: repository.AnyState, Emails: []string{employeeEmail}})
+		if err != nil {
+			return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		if count > 0 {
+			return models.Employee{}, errors.NewCodedError(http.StatusConflict, errors.CodeEmployeeNotDeleted, "employee is not deleted")
+		}
+		return models.Employee{}, errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
+	if err != nil {
+		return models.Employee{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	s.audit(ctx, models.AuditEntry{Time: now, Action: models.AuditEmployeeRestored, Employee: employeeEmail})
+	return emp, nil
+}
+
+// GetDeletedEmployees returns a page of the soft-deleted employees, ordered by the given sort key.
+func (s *EmployeeService) GetDeletedEmployees(ctx context.Context, page, size int, sortBy string) ([]models.Employee, error) {
+	employees, err := s.Store.List(ctx, repository.EmployeeCriteria{State: repository.Deleted}, s.paged(sortBy, page, size))
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if employees == nil {
+		employees = []models.Employee{}
+	}
+	return employees, nil
+}
+
+// PurgeDeletedEmployees permanently removes the employees soft-deleted at or before the given time
+// and returns how many were removed.
+func (s *EmployeeService) PurgeDeletedEmployees(ctx context.Context, before, now time.Time) (int64, error) {
+	// Read the emails first so each purge is recorded in the audit log.
+	purged, err := s.Store.List(ctx, repository.EmployeeCriteria{State: repository.Deleted, DeletedBy: before}, repository.Page{})
+	if err != nil {
+		return 0, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if len(purged) == 0 {
+		return 0, nil
+	}
+	emails := make([]string, len(purged))
+	for i, emp := range purged {
+		emails[i] = emp.Email
+	}
+
+	criteria := repository.EmployeeCriteria{State: repository.Deleted, DeletedBy: before, Emails: emails}
+	purgedCount, err := s.Store.Delete(ctx, criteria)
+	if err != nil {
+		return 0, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	for _, emp := range purged {
+		s.audit(tenantContext(ctx, emp), models.AuditEntry{Time: now, Action: models.AuditEmployeePurged, Employee: emp.Email})
+	}
+	return purgedCount, nil
+}
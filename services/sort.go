@@ -0,0 +1,72 @@
+package services
+
+import (
+	"sort"
+	"strings"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+)
+
+// sortField is an employee field the list endpoints can be ordered by.
+type sortField struct {
+	// key is the stored field name.
+	key string
+}
+
+// sortFields maps the accepted sort keys to employee fields.
+var sortFields = map[string]sortField{
+	"email": {key: models.EmployeeRef.Email},
+	// Names sort by the display name, which falls back to the legal name.
+	"name": {key: models.EmployeeRef.DisplayName},
+	// Seniority orders by hire date, so the longest-serving employees come first.
+	"seniority": {key: models.EmployeeRef.HireDate},
+}
+
+// SortKeys lists the accepted sort keys; prefix one with "-" to sort in descending order.
+func SortKeys() []string {
+	keys := make([]string, 0, len(sortFields))
+	for key := range sortFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValidSort reports whether s is empty or an accepted sort key, optionally prefixed with "-".
+func ValidSort(s string) bool {
+	if s == "" {
+		return true
+	}
+	_, ok := sortFields[strings.TrimPrefix(s, "-")]
+	return ok
+}
+
+// parseSort returns the field and direction of a sort key. The empty key sorts by email.
+func parseSort(s string) (sortField, int) {
+	direction := 1
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		s, direction = rest, -1
+	}
+	field, ok := sortFields[s]
+	if !ok {
+		return sortFields["email"], 1
+	}
+	return field, direction
+}
+
+// paged returns the page numbered page, of size employees, of a list ordered by a sort key in the collation of the
+// service.
+func (s *EmployeeService) paged(sortBy string, page, size int) repository.Page {
+	return repository.Page{Sort: sortOrder(sortBy), Collation: s.Collation, Skip: int64((page - 1) * size), Limit: int64(size)}
+}
+
+// sortOrder converts a sort key into the keys to sort by. Ties are broken by email so pages are stable.
+func sortOrder(s string) []repository.SortKey {
+	field, direction := parseSort(s)
+	order := []repository.SortKey{{Field: field.key, Descending: direction == -1}}
+	if field.key != models.EmployeeRef.Email {
+		order = append(order, repository.SortKey{Field: models.EmployeeRef.Email})
+	}
+	return order
+}
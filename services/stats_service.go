@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+)
+
+// ageBoundaries are the lower bounds of the age buckets, followed by an upper bound no employee reaches.
+var ageBoundaries = []int{0, 20, 30, 40, 50, 60, 200}
+
+// ageBucketLabels names the buckets delimited by ageBoundaries.
+var ageBucketLabels = []string{"<20", "20-29", "30-39", "40-49", "50-59", "60+"}
+
+// ageBucket returns the index of the age bucket of age. Birthdates in the future are counted with the youngest, and
+// implausible ages with the oldest.
+func ageBucket(age int) int {
+	i := 0
+	for i < len(ageBucketLabels)-1 && max(age, 0) >= ageBoundaries[i+1] {
+		i++
+	}
+	return i
+}
+
+// GetWorkforceStats computes the headcount of the employees on the payroll as of now, broken down by role,
+// email domain and manager, with their average age and age distribution. Employees without a birth date, stored only
+// for birthdates naming a day of the calendar, are left out of the age figures only.
+func (s *EmployeeService) GetWorkforceStats(ctx context.Context, now time.Time) (models.WorkforceStats, error) {
+	workforce, err := s.Store.Workforce(ctx, repository.EmployeeCriteria{}, now)
+	if err != nil {
+		return models.WorkforceStats{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	stats := models.WorkforceStats{
+		Headcount:       workforce.Headcount,
+		ByRole:          []models.StatCount{},
+		ByEmailDomain:   []models.StatCount{},
+		ByManager:       []models.StatCount{},
+		AgeDistribution: make([]models.AgeBucket, len(ageBucketLabels)),
+	}
+	for i, label := range ageBucketLabels {
+		stats.AgeDistribution[i].Label = label
+	}
+	if workforce.ByRole != nil {
+		stats.ByRole = workforce.ByRole
+	}
+	if workforce.ByDomain != nil {
+		stats.ByEmailDomain = workforce.ByDomain
+	}
+	if workforce.ByManager != nil {
+		stats.ByManager = workforce.ByManager
+	}
+	var aged, years int64
+	for age, count := range workforce.Ages {
+		aged += count
+		years += int64(age) * count
+		stats.AgeDistribution[ageBucket(age)].Count += count
+	}
+	if aged > 0 {
+		stats.AverageAge = float64(years) / float64(aged)
+	}
+	return stats, nil
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// GetAllSubordinates returns the direct and indirect reports of a manager with their depth below the manager. With
+// maxDepth > 0, only reports at most maxDepth levels below are returned. Results are ordered by depth, then by the
+// given sort key, and paginated.
+func (s *EmployeeService) GetAllSubordinates(ctx context.Context, managerEmail string, maxDepth, page, size int, sortBy string) ([]models.Subordinate, error) {
+	if err := ensureEmployee(ctx, s.Store, managerEmail); err != nil {
+		return nil, err
+	}
+	reports, err := s.Store.Reports(ctx, activeEmployee(managerEmail), maxDepth, s.paged(sortBy, page, size))
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	subordinates := make([]models.Subordinate, len(reports))
+	for i, report := range reports {
+		subordinates[i] = models.Subordinate{
+			EmployeeResponse: models.NewEmployeeResponse(report.Employee),
+			Depth:            report.Depth,
+		}
+	}
+	return subordinates, nil
+}
+
+// CountReports returns the number of direct reports of a manager and the number of all their reports,
+// direct and indirect.
+func (s *EmployeeService) CountReports(ctx context.Context, managerEmail string) (models.ReportCounts, error) {
+	counts, err := s.Store.CountReports(ctx, activeEmployee(managerEmail))
+	if err == mongo.ErrNoDocuments {
+		return models.ReportCounts{}, errors.NewCodedError(http.StatusNotFound, errors.CodeEmployeeNotFound, "employee not found")
+	}
+	if err != nil {
+		return models.ReportCounts{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return counts, nil
+}
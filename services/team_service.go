@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// TeamService manages teams and their membership. Leads and members must be existing employees.
+type TeamService struct {
+	Employees EmployeeStore
+	Teams     *repository.TeamRepository
+}
+
+// NewTeamService creates a new TeamService using the provided repositories.
+func NewTeamService(employees EmployeeStore, teams *repository.TeamRepository) *TeamService {
+	return &TeamService{
+		Employees: employees,
+		Teams:     teams,
+	}
+}
+
+// CreateTeam stores a new team led by an existing employee, with the given initial members.
+func (s *TeamService) CreateTeam(ctx context.Context, req models.TeamRequest) (models.Team, error) {
+	if err := validateStruct(req); err != nil {
+		return models.Team{}, err
+	}
+	if err := ensureEmployee(ctx, s.Employees, req.Lead); err != nil {
+		if httpErr, ok := err.(*errors.HTTPError); ok && httpErr.ErrorCode == errors.CodeEmployeeNotFound {
+			return models.Team{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeTeamLeadNotFound, "team lead not found")
+		}
+		return models.Team{}, err
+	}
+	members, err := s.existingMembers(ctx, req.Members)
+	if err != nil {
+		return models.Team{}, err
+	}
+
+	team := models.Team{
+		ID:      bson.NewObjectID().Hex(),
+		Name:    strings.TrimSpace(req.Name),
+		Lead:    req.Lead,
+		Members: members,
+	}
+	coll, err := s.Teams.Collection.In(ctx)
+	if err != nil {
+		return models.Team{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.InsertOne(ctx, team); err != nil {
+		return models.Team{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return team, nil
+}
+
+// existingMembers returns the distinct emails, sorted, failing with 400 when some of them are not employees.
+func (s *TeamService) existingMembers(ctx context.Context, emails []string) ([]string, error) {
+	unique := map[string]bool{}
+	for _, email := range emails {
+		unique[email] = true
+	}
+	members := make([]string, 0, len(unique))
+	for email := range unique {
+		members = append(members, email)
+	}
+	sort.Strings(members)
+	if len(members) == 0 {
+		return members, nil
+	}
+
+	found, err := s.Employees.List(ctx, repository.EmployeeCriteria{State: repository.Active, Emails: members}, repository.Page{})
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	for _, emp := range found {
+		delete(unique, emp.Email)
+	}
+	if len(unique) > 0 {
+		var missing []string
+		for _, email := range members {
+			if unique[email] {
+				missing = append(missing, email)
+			}
+		}
+		return nil, errors.NewCodedError(http.StatusBadRequest, errors.CodeEmployeeNotFound, "employees not found: "+strings.Join(missing, ", "))
+	}
+	return members, nil
+}
+
+// GetTeam returns the team with the given identifier.
+func (s *TeamService) GetTeam(ctx context.Context, id string) (models.Team, error) {
+	var team models.Team
+	coll, err := s.Teams.Collection.In(ctx)
+	if err != nil {
+		return models.Team{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOne(ctx, bson.M{"_id": id}).Decode(&team)
+	if err == mongo.ErrNoDocuments {
+		return models.Team{}, errors.NewCodedError(http.StatusNotFound, errors.CodeTeamNotFound, "team not found")
+	}
+	if err != nil {
+		return models.Team{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return team, nil
+}
+
+// DeleteTeam removes a team. The employees themselves are not affected.
+func (s *TeamService) DeleteTeam(ctx context.Context, id string) error {
+	coll, err := s.Teams.Collection.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.DeletedCount == 0 {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeTeamNotFound, "team not found")
+	}
+	return nil
+}
+
+// AddMember adds an existing employee to a team and returns the updated team. Adding a member twice has no effect.
+func (s *TeamService) AddMember(ctx context.Context, id, email string) (models.Team, error) {
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return models.Team{}, err
+	}
+	return s.updateMembers(ctx, bson.M{"_id": id}, bson.M{"$addToSet": bson.M{"members": email}},
+		errors.CodeTeamNotFound, "team not found")
+}
+
+// RemoveMember removes an employee from a team and returns the updated team.
+func (s *TeamService) RemoveMember(ctx context.Context, id, email string) (models.Team, error) {
+	if _, err := s.GetTeam(ctx, id); err != nil {
+		return models.Team{}, err
+	}
+	return s.updateMembers(ctx, bson.M{"_id": id, "members": email}, bson.M{"$pull": bson.M{"members": email}},
+		errors.CodeTeamMemberNotFound, "employee is not a member of the team")
+}
+
+// updateMembers applies update to the team matching filter and returns the updated team,
+// failing with 404 and the given code and message when none matches.
+func (s *TeamService) updateMembers(ctx context.Context, filter, update bson.M, code, msg string) (models.Team, error) {
+	var team models.Team
+	coll, err := s.Teams.Collection.In(ctx)
+	if err != nil {
+		return models.Team{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&team)
+	if err == mongo.ErrNoDocuments {
+		return models.Team{}, errors.NewCodedError(http.StatusNotFound, code, msg)
+	}
+	if err != nil {
+		return models.Team{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return team, nil
+}
+
+// GetEmployeeTeams returns the teams an employee leads or belongs to, ordered by name.
+func (s *TeamService) GetEmployeeTeams(ctx context.Context, email string) ([]models.Team, error) {
+	if err := ensureEmployee(ctx, s.Employees, email); err != nil {
+		return nil, err
+	}
+	coll, err := s.Teams.Collection.In(ctx)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx,
+		bson.M{"$or": bson.A{bson.M{"lead": email}, bson.M{"members": email}}},
+		options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer cursor.Close(ctx)
+
+	teams := []models.Team{}
+	if err := cursor.All(ctx, &teams); err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return teams, nil
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+)
+
+// GetEmployeesByTenure returns the employees who have worked between minYears and maxYears full years as of now,
+// ordered by the given sort key. A negative maxYears sets no upper bound. Employees without a hire date never match.
+// Hire dates are formatted as YYYY-MM-DD, so the bounds are compared as strings.
+func (s *EmployeeService) GetEmployeesByTenure(ctx context.Context, minYears, maxYears int, now time.Time, page, size int, sortBy string) ([]models.Employee, error) {
+	criteria := repository.EmployeeCriteria{HiredBy: now.AddDate(-minYears, 0, 0).Format(time.DateOnly)}
+	if maxYears >= 0 {
+		// Hired after this day, the employee has not yet completed maxYears+1 years.
+		criteria.HiredAfter = now.AddDate(-maxYears-1, 0, 0).Format(time.DateOnly)
+	}
+	return s.findEmployees(ctx, criteria, page, size, sortBy)
+}
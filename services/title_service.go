@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// TitleService manages the catalog of job titles employees may hold.
+type TitleService struct {
+	Employees EmployeeStore
+	Titles    *repository.TitleRepository
+}
+
+// NewTitleService creates a new TitleService using the provided repositories.
+func NewTitleService(employees EmployeeStore, titles *repository.TitleRepository) *TitleService {
+	return &TitleService{
+		Employees: employees,
+		Titles:    titles,
+	}
+}
+
+// CreateTitle adds a job title to the catalog, failing with 409 when one with the same name exists.
+func (s *TitleService) CreateTitle(ctx context.Context, title models.JobTitle) (models.JobTitle, error) {
+	if err := validateStruct(title); err != nil {
+		return models.JobTitle{}, err
+	}
+	coll, err := s.Titles.Collection.In(ctx)
+	if err != nil {
+		return models.JobTitle{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if _, err := coll.InsertOne(ctx, title); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.JobTitle{}, errors.NewCodedError(http.StatusConflict, errors.CodeTitleExists, "a title with this name already exists")
+		}
+		return models.JobTitle{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return title, nil
+}
+
+// GetTitle returns the job title with the given name.
+func (s *TitleService) GetTitle(ctx context.Context, name string) (models.JobTitle, error) {
+	var title models.JobTitle
+	coll, err := s.Titles.Collection.In(ctx)
+	if err != nil {
+		return models.JobTitle{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOne(ctx, bson.M{"_id": name}).Decode(&title)
+	if err == mongo.ErrNoDocuments {
+		return models.JobTitle{}, errors.NewCodedError(http.StatusNotFound, errors.CodeTitleNotFound, "title not found")
+	}
+	if err != nil {
+		return models.JobTitle{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return title, nil
+}
+
+// ListTitles returns a page of the job titles ordered by name.
+func (s *TitleService) ListTitles(ctx context.Context, page, size int) ([]models.JobTitle, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetSkip(int64((page - 1) * size)).
+		SetLimit(int64(size))
+	coll, err := s.Titles.Collection.In(ctx)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	cursor, err := coll.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer cursor.Close(ctx)
+
+	titles := []models.JobTitle{}
+	if err := cursor.All(ctx, &titles); err != nil {
+		return nil, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return titles, nil
+}
+
+// UpdateTitle replaces the description and grades of a job title. The name cannot be changed, since employees
+// reference it; a body naming another title is rejected with 400. Employees keep their grade when it is removed.
+func (s *TitleService) UpdateTitle(ctx context.Context, name string, title models.JobTitle) (models.JobTitle, error) {
+	if title.Name == "" {
+		title.Name = name
+	}
+	if title.Name != name {
+		return models.JobTitle{}, errors.NewCodedError(http.StatusBadRequest, errors.CodeTitleNameChange, "the name of a title cannot be changed")
+	}
+	if err := validateStruct(title); err != nil {
+		return models.JobTitle{}, err
+	}
+	coll, err := s.Titles.Collection.In(ctx)
+	if err != nil {
+		return models.JobTitle{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.ReplaceOne(ctx, bson.M{"_id": name}, title)
+	if err != nil {
+		return models.JobTitle{}, errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.MatchedCount == 0 {
+		return models.JobTitle{}, errors.NewCodedError(http.StatusNotFound, errors.CodeTitleNotFound, "title not found")
+	}
+	return title, nil
+}
+
+// DeleteTitle removes a job title. Titles still held by an employee, including soft-deleted ones that may be
+// restored, are kept and reported with 409.
+func (s *TitleService) DeleteTitle(ctx context.Context, name string) error {
+	holders, err := s.Employees.Count(ctx, repository.EmployeeCriteria{State: repository.AnyState, Title: name})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if holders > 0 {
+		return errors.NewCodedError(http.StatusConflict, errors.CodeTitleInUse,
+			"title is held by "+strconv.FormatInt(holders, 10)+" employees; change their title first")
+	}
+	coll, err := s.Titles.Collection.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	res, err := coll.DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if res.DeletedCount == 0 {
+		return errors.NewCodedError(http.StatusNotFound, errors.CodeTitleNotFound, "title not found")
+	}
+	return nil
+}
+
+// validateTitle checks the title and grade of an employee against the titles catalog: the title must be listed,
+// and the grade must be one of the title's grades when it has some.
+func (s *EmployeeService) validateTitle(ctx context.Context, title, grade string) error {
+	if title == "" || s.Titles == nil {
+		return nil
+	}
+	var entry models.JobTitle
+	coll, err := s.Titles.Collection.In(ctx)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	err = coll.FindOne(ctx, bson.M{"_id": title}).Decode(&entry)
+	if err == mongo.ErrNoDocuments {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeTitleNotFound, "title not found")
+	}
+	if err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if grade != "" && len(entry.Grades) > 0 && !slices.Contains(entry.Grades, grade) {
+		return errors.NewCodedError(http.StatusBadRequest, errors.CodeInvalidGrade,
+			"grade of "+title+" must be one of ["+strings.Join(entry.Grades, ", ")+"]")
+	}
+	return nil
+}
+
+// GetEmployeesByTitle returns the employees holding the given job title, ordered by the given sort key.
+func (s *EmployeeService) GetEmployeesByTitle(ctx context.Context, title string, page, size int, sortBy string) ([]models.Employee, error) {
+	return s.findEmployees(ctx, repository.EmployeeCriteria{Title: title}, page, size, sortBy)
+}
+
+// GetEmployeesByGrade returns the employees at the given grade, ordered by the given sort key.
+func (s *EmployeeService) GetEmployeesByGrade(ctx context.Context, grade string, page, size int, sortBy string) ([]models.Employee, error) {
+	return s.findEmployees(ctx, repository.EmployeeCriteria{Grade: grade}, page, size, sortBy)
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/go-playground/validator/v10/non-standard/validators"
+)
+
+// validate checks the `validate` tags of the models. It is built once since it caches the parsed tags.
+var validate = newValidator()
+
+// newValidator returns a validator naming fields by their JSON names, with the custom rules of the models registered.
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(fieldName)
+	for tag, fn := range map[string]validator.Func{
+		"calendardate":   validCalendarDate,
+		"birthdate":      validBirthdate,
+		"password":       validPassword,
+		"phone":          validPhone,
+		"metadatakey":    validMetadataKey,
+		"notblank":       validators.NotBlank,
+		"pastdate":       validPastDate,
+		"afterbirthdate": validAfterBirthdate,
+	} {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			panic(err)
+		}
+	}
+	return v
+}
+
+// fieldName returns the JSON name of a field, or its Go name with a lower-case initial for fields never serialized.
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		runes := []rune(field.Name)
+		runes[0] = unicode.ToLower(runes[0])
+		return string(runes)
+	}
+	return name
+}
+
+// validCalendarDate rejects birthdates that are not a day of the calendar, such as 30/02, as parsed by
+// Birthdate.Date. Dates with a part that is not a number pass, so the tags of their parts report them.
+func validCalendarDate(fl validator.FieldLevel) bool {
+	birthdate, ok := fl.Field().Interface().(models.Birthdate)
+	if !ok {
+		return false
+	}
+	_, ok = birthdate.Date()
+	return ok || !birthdate.Numeric()
+}
+
+// validBirthdate rejects birthdates in the future. Dates Birthdate.Date cannot parse pass, so calendardate and the
+// tags of their parts report them.
+func validBirthdate(fl validator.FieldLevel) bool {
+	birthdate, ok := fl.Field().Interface().(models.Birthdate)
+	if !ok {
+		return false
+	}
+	date, ok := birthdate.Date()
+	return !ok || !date.After(time.Now().UTC())
+}
+
+// validPastDate rejects YYYY-MM-DD dates after today. Malformed dates pass, so the datetime rule reports them.
+func validPastDate(fl validator.FieldLevel) bool {
+	date, err := time.Parse(time.DateOnly, fl.Field().String())
+	return err != nil || !date.After(time.Now().UTC())
+}
+
+// validAfterBirthdate rejects YYYY-MM-DD dates before the birthdate of the employee holding them.
+// Malformed dates and birthdates pass, so their own rules report them.
+func validAfterBirthdate(fl validator.FieldLevel) bool {
+	emp, ok := fl.Parent().Interface().(models.Employee)
+	if !ok {
+		return true
+	}
+	date, err := time.Parse(time.DateOnly, fl.Field().String())
+	if err != nil {
+		return true
+	}
+	birthdate, ok := emp.Birthdate.Date()
+	return !ok || !date.Before(birthdate)
+}
+
+// validPassword requires at least one digit and one uppercase letter.
+func validPassword(fl validator.FieldLevel) bool {
+	password := fl.Field().String()
+	return strings.ContainsAny(password, "0123456789") && strings.ContainsAny(password, "ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+}
+
+// validPhone accepts phone numbers in E.164 format.
+func validPhone(fl validator.FieldLevel) bool {
+	return e164Pattern.MatchString(fl.Field().String())
+}
+
+// validMetadataKey accepts the metadata keys that are safe as Mongo field names and XML element names.
+func validMetadataKey(fl validator.FieldLevel) bool {
+	return metadataKeyPattern.MatchString(fl.Field().String())
+}
+
+// validationCodes maps a failed rule, as "field/tag", or a whole top-level field to its error code.
+var validationCodes = map[string]string{
+	"email/required":      errors.CodeEmployeeRequiredFields,
+	"name/required":       errors.CodeEmployeeRequiredFields,
+	"email":               errors.CodeInvalidEmail,
+	"birthdate":           errors.CodeInvalidBirthdate,
+	"birthdate/birthdate": errors.CodeBirthdateInFuture,
+	"password":            errors.CodePasswordTooWeak,
+	"password/min":        errors.CodePasswordTooShort,
+	"phone":               errors.CodeInvalidPhone,
+	"address":             errors.CodeInvalidAddress,
+	"metadata":            errors.CodeInvalidMetadata,
+	"hireDate":            errors.CodeInvalidHireDate,
+	"costCenter":          errors.CodeInvalidCostCenter,
+	"skills":              errors.CodeInvalidSkills,
+	"timezone":            errors.CodeInvalidTimezone,
+	"locale":              errors.CodeInvalidLocale,
+}
+
+// validateStruct checks the `validate` tags of obj. The first failed rule is reported as a 400
+// whose message starts with the path of the field, e.g. "birthdate.day must be numeric".
+func validateStruct(obj interface{}) error {
+	err := validate.Struct(obj)
+	var failures validator.ValidationErrors
+	if !stderrors.As(err, &failures) {
+		return err
+	}
+	failure := failures[0]
+	// Namespaces start with the name of the validated type, e.g. "Employee.birthdate.day".
+	_, path, _ := strings.Cut(failure.Namespace(), ".")
+	field, _, _ := strings.Cut(path, ".")
+	field, _, _ = strings.Cut(field, "[")
+
+	code, ok := validationCodes[field+"/"+failure.Tag()]
+	if !ok {
+		code, ok = validationCodes[field]
+	}
+	if !ok {
+		code = errors.CodeInvalidPayload
+	}
+	return errors.NewCodedError(http.StatusBadRequest, code, path+" "+ruleMessage(failure))
+}
+
+// ruleMessage describes the rule a field failed.
+func ruleMessage(failure validator.FieldError) string {
+	switch failure.Tag() {
+	case "required", "notblank":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "len":
+		return "must have exactly " + failure.Param() + " characters"
+	case "number":
+		return "must be numeric"
+	case "min":
+		if failure.Kind() == reflect.Int {
+			return "must be at least " + failure.Param()
+		}
+		return "must be at least " + failure.Param() + " characters"
+	case "max":
+		if failure.Kind() == reflect.Map || failure.Kind() == reflect.Slice {
+			return "must not have more than " + failure.Param() + " entries"
+		}
+		if failure.Kind() == reflect.Int {
+			return "must not exceed " + failure.Param()
+		}
+		return "must not exceed " + failure.Param() + " characters"
+	case "calendardate":
+		return "must be a day of the calendar"
+	case "birthdate":
+		return "cannot be in the future"
+	case "password":
+		return "must contain at least one digit and one uppercase letter"
+	case "phone":
+		return "must be in E.164 format, e.g. +972501234567"
+	case "iso3166_1_alpha2":
+		return "must be a two-letter ISO 3166-1 code such as IL"
+	case "datetime":
+		return "must be a date formatted as YYYY-MM-DD"
+	case "pastdate":
+		return "cannot be in the future"
+	case "afterbirthdate":
+		return "cannot be before the birthdate"
+	case "gt":
+		return "must be greater than " + failure.Param()
+	case "timezone":
+		return "must be an IANA time zone such as Asia/Jerusalem"
+	case "bcp47_language_tag":
+		return "must be a BCP 47 language tag such as he-IL"
+	case "iso4217":
+		return "must be an ISO 4217 currency code such as USD"
+	case "unique":
+		return "must not repeat a " + strings.ToLower(failure.Param())
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(failure.Param(), " ", ", ")
+	case "metadatakey":
+		return "must start with a letter and contain only letters, digits and underscores"
+	default:
+		return fmt.Sprintf("failed the %s rule", failure.Tag())
+	}
+}
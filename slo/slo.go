@@ -0,0 +1,229 @@
+package slo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Duration is a time.Duration that is written as a Go duration string (e.g. "300ms") in JSON and XML.
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string such as "300ms".
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON writes the duration as a string such as "300ms".
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// Objective defines the latency and availability targets of a single route.
+type Objective struct {
+	// Route is the HTTP method followed by the route template, e.g. "GET /employees/:employeeEmail".
+	Route string `json:"route" xml:"route"`
+	// LatencyThreshold is the duration under which a request counts as fast.
+	LatencyThreshold Duration `json:"latencyThreshold" xml:"latencyThreshold"`
+	// LatencyTarget is the fraction of requests that must be faster than LatencyThreshold, e.g. 0.99.
+	LatencyTarget float64 `json:"latencyTarget" xml:"latencyTarget"`
+	// AvailabilityTarget is the fraction of requests that must not fail with a 5xx status, e.g. 0.999.
+	AvailabilityTarget float64 `json:"availabilityTarget" xml:"availabilityTarget"`
+}
+
+// LoadObjectives reads the route objectives from a JSON file containing an array of Objective.
+func LoadObjectives(path string) ([]Objective, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var objectives []Objective
+	if err := json.Unmarshal(data, &objectives); err != nil {
+		return nil, fmt.Errorf("invalid SLO configuration %s: %w", path, err)
+	}
+	for _, o := range objectives {
+		if o.Route == "" || o.LatencyThreshold <= 0 {
+			return nil, fmt.Errorf("invalid SLO configuration %s: route and latencyThreshold are required", path)
+		}
+		if o.LatencyTarget <= 0 || o.LatencyTarget >= 1 || o.AvailabilityTarget <= 0 || o.AvailabilityTarget >= 1 {
+			return nil, fmt.Errorf("invalid SLO configuration %s: targets for %s must be between 0 and 1", path, o.Route)
+		}
+	}
+	return objectives, nil
+}
+
+// windows are the evaluation windows reported for every route, in minutes.
+var windows = []struct {
+	name    string
+	minutes int64
+}{
+	{"5m", 5},
+	{"1h", 60},
+}
+
+// bucketCount is the number of one-minute buckets kept per route; it must cover the longest window.
+const bucketCount = 60
+
+type bucket struct {
+	minute int64
+	total  int64
+	errors int64
+	slow   int64
+}
+
+type route struct {
+	objective Objective
+	buckets   [bucketCount]bucket
+}
+
+// WindowStatus reports SLO compliance of a route over one evaluation window.
+type WindowStatus struct {
+	Window               string  `json:"window" xml:"window"`
+	Requests             int64   `json:"requests" xml:"requests"`
+	Availability         float64 `json:"availability" xml:"availability"`
+	LatencyCompliance    float64 `json:"latencyCompliance" xml:"latencyCompliance"`
+	AvailabilityBurnRate float64 `json:"availabilityBurnRate" xml:"availabilityBurnRate"`
+	LatencyBurnRate      float64 `json:"latencyBurnRate" xml:"latencyBurnRate"`
+}
+
+// RouteStatus reports the objective and current compliance of a route.
+type RouteStatus struct {
+	Objective Objective      `json:"objective" xml:"objective"`
+	Windows   []WindowStatus `json:"windows" xml:"windows>window"`
+	// OutOfBudget is true when the route burns its error budget faster than allowed over the last hour.
+	OutOfBudget bool `json:"outOfBudget" xml:"outOfBudget"`
+}
+
+// Tracker records request outcomes per route and evaluates them against their objectives.
+// It is safe for concurrent use.
+type Tracker struct {
+	mu     sync.Mutex
+	routes map[string]*route
+	now    func() time.Time
+}
+
+// NewTracker creates a Tracker for the given objectives.
+func NewTracker(objectives []Objective) *Tracker {
+	t := &Tracker{
+		routes: make(map[string]*route, len(objectives)),
+		now:    time.Now,
+	}
+	for _, o := range objectives {
+		t.routes[o.Route] = &route{objective: o}
+	}
+	return t
+}
+
+// Record registers a request to the given route. Routes without an objective are ignored.
+func (t *Tracker) Record(routeKey string, latency time.Duration, status int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.routes[routeKey]
+	if !ok {
+		return
+	}
+	minute := t.now().Unix() / 60
+	b := &r.buckets[minute%bucketCount]
+	if b.minute != minute {
+		*b = bucket{minute: minute}
+	}
+	b.total++
+	if status >= http.StatusInternalServerError {
+		b.errors++
+	}
+	if latency > time.Duration(r.objective.LatencyThreshold) {
+		b.slow++
+	}
+}
+
+// Status returns the compliance of every tracked route, sorted by route.
+func (t *Tracker) Status() []RouteStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	minute := t.now().Unix() / 60
+	statuses := make([]RouteStatus, 0, len(t.routes))
+	for _, r := range t.routes {
+		status := RouteStatus{Objective: r.objective}
+		for _, w := range windows {
+			ws := r.window(minute, w.minutes)
+			ws.Window = w.name
+			status.Windows = append(status.Windows, ws)
+		}
+		longest := status.Windows[len(status.Windows)-1]
+		status.OutOfBudget = longest.AvailabilityBurnRate > 1 || longest.LatencyBurnRate > 1
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Objective.Route < statuses[j].Objective.Route
+	})
+	return statuses
+}
+
+// window aggregates the buckets of the last n minutes, including the current one.
+func (r *route) window(currentMinute, n int64) WindowStatus {
+	var total, errs, slow int64
+	for _, b := range r.buckets {
+		if b.minute > currentMinute-n && b.minute <= currentMinute {
+			total += b.total
+			errs += b.errors
+			slow += b.slow
+		}
+	}
+	ws := WindowStatus{Requests: total, Availability: 1, LatencyCompliance: 1}
+	if total > 0 {
+		ws.Availability = 1 - float64(errs)/float64(total)
+		ws.LatencyCompliance = 1 - float64(slow)/float64(total)
+	}
+	// The burn rate is the observed failure ratio divided by the failure ratio the objective allows.
+	ws.AvailabilityBurnRate = (1 - ws.Availability) / (1 - r.objective.AvailabilityTarget)
+	ws.LatencyBurnRate = (1 - ws.LatencyCompliance) / (1 - r.objective.LatencyTarget)
+	return ws
+}
+
+var (
+	burnRateDesc = prometheus.NewDesc("slo_burn_rate",
+		"Error budget burn rate per route, SLI and window (1 means the budget is consumed exactly on time).",
+		[]string{"route", "sli", "window"}, nil)
+	complianceDesc = prometheus.NewDesc("slo_compliance",
+		"Fraction of good requests per route, SLI and window.",
+		[]string{"route", "sli", "window"}, nil)
+)
+
+// Describe implements prometheus.Collector.
+func (t *Tracker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- burnRateDesc
+	ch <- complianceDesc
+}
+
+// Collect implements prometheus.Collector.
+func (t *Tracker) Collect(ch chan<- prometheus.Metric) {
+	for _, status := range t.Status() {
+		route := status.Objective.Route
+		for _, w := range status.Windows {
+			ch <- prometheus.MustNewConstMetric(burnRateDesc, prometheus.GaugeValue, w.AvailabilityBurnRate, route, "availability", w.Window)
+			ch <- prometheus.MustNewConstMetric(burnRateDesc, prometheus.GaugeValue, w.LatencyBurnRate, route, "latency", w.Window)
+			ch <- prometheus.MustNewConstMetric(complianceDesc, prometheus.GaugeValue, w.Availability, route, "availability", w.Window)
+			ch <- prometheus.MustNewConstMetric(complianceDesc, prometheus.GaugeValue, w.LatencyCompliance, route, "latency", w.Window)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package tenant
+
+import (
+	"context"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+	"WebMVCEmployees/services"
+)
+
+// EmployeeStore scopes an EmployeeStore to the tenant of the context of each call: employees are created in the
+// tenant, and only those of the tenant are found, counted, updated, deleted and walked through. Calls made for the empty
+// tenant ID reach the employees belonging to no tenant. Calls made for no tenant, such as those of the background jobs
+// and of the startup maintenance, reach the employees of every tenant.
+// It must wrap the cache, if any, so that lookups are cached per tenant.
+type EmployeeStore struct {
+	Store services.EmployeeStore
+}
+
+// NewEmployeeStore scopes store to the tenant of each call.
+func NewEmployeeStore(store services.EmployeeStore) *EmployeeStore {
+	return &EmployeeStore{Store: store}
+}
+
+// scopeCriteria returns the criteria narrowed down to the tenant of ctx.
+func scopeCriteria(ctx context.Context, criteria repository.EmployeeCriteria) repository.EmployeeCriteria {
+	if id, ok := FromContext(ctx); ok {
+		criteria.Tenant, criteria.NoTenant = id, id == ""
+	}
+	return criteria
+}
+
+// Create inserts a new employee in the tenant of ctx.
+func (s *EmployeeStore) Create(ctx context.Context, emp models.Employee) error {
+	if id, ok := FromContext(ctx); ok {
+		emp.TenantID = id
+	}
+	return s.Store.Create(ctx, emp)
+}
+
+// InsertMany inserts new employees in the tenant of ctx; see services.EmployeeStore.
+func (s *EmployeeStore) InsertMany(ctx context.Context, emps []models.Employee, ordered bool) error {
+	if id, ok := FromContext(ctx); ok {
+		emps = append([]models.Employee(nil), emps...)
+		for i := range emps {
+			emps[i].TenantID = id
+		}
+	}
+	return s.Store.InsertMany(ctx, emps, ordered)
+}
+
+// FindByEmail returns the employee of the tenant with the email unless it is soft-deleted.
+func (s *EmployeeStore) FindByEmail(ctx context.Context, email string) (models.Employee, error) {
+	if _, ok := FromContext(ctx); !ok {
+		return s.Store.FindByEmail(ctx, email)
+	}
+	criteria := repository.EmployeeCriteria{State: repository.Active, Emails: []string{email}}
+	return s.Store.FindOne(ctx, scopeCriteria(ctx, criteria))
+}
+
+// FindOne returns the first employee of the tenant matching the criteria.
+func (s *EmployeeStore) FindOne(ctx context.Context, criteria repository.EmployeeCriteria) (models.Employee, error) {
+	return s.Store.FindOne(ctx, scopeCriteria(ctx, criteria))
+}
+
+// FindWithCredentials returns the first employee of the tenant matching the criteria, password included.
+func (s *EmployeeStore) FindWithCredentials(ctx context.Context, criteria repository.EmployeeCriteria) (models.Employee, error) {
+	return s.Store.FindWithCredentials(ctx, scopeCriteria(ctx, criteria))
+}
+
+// List returns a page of the employees of the tenant matching the criteria.
+func (s *EmployeeStore) List(ctx context.Context, criteria repository.EmployeeCriteria, page repository.Page) ([]models.Employee, error) {
+	return s.Store.List(ctx, scopeCriteria(ctx, criteria), page)
+}
+
+// Count returns the number of employees of the tenant matching the criteria.
+func (s *EmployeeStore) Count(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error) {
+	return s.Store.Count(ctx, scopeCriteria(ctx, criteria))
+}
+
+// ManagerChain returns the first employee of the tenant matching the criteria followed by the managers above them,
+// found among the employees of the tenant; see services.EmployeeStore.
+func (s *EmployeeStore) ManagerChain(ctx context.Context, criteria repository.EmployeeCriteria, maxDepth int) ([]models.Employee, error) {
+	return s.Store.ManagerChain(ctx, scopeCriteria(ctx, criteria), maxDepth)
+}
+
+// Reports returns the reports of the first employee of the tenant matching the criteria, found among the employees
+// of the tenant; see services.EmployeeStore.
+func (s *EmployeeStore) Reports(ctx context.Context, criteria repository.EmployeeCriteria, maxDepth int, page repository.Page) ([]repository.Report, error) {
+	return s.Store.Reports(ctx, scopeCriteria(ctx, criteria), maxDepth, page)
+}
+
+// CountReports counts the reports of the first employee of the tenant matching the criteria.
+func (s *EmployeeStore) CountReports(ctx context.Context, criteria repository.EmployeeCriteria) (models.ReportCounts, error) {
+	return s.Store.CountReports(ctx, scopeCriteria(ctx, criteria))
+}
+
+// Anniversaries returns the work anniversaries of the employees of the tenant matching the criteria; see
+// services.EmployeeStore.
+func (s *EmployeeStore) Anniversaries(ctx context.Context, criteria repository.EmployeeCriteria, month time.Month, year int, page repository.Page) ([]models.Anniversary, error) {
+	return s.Store.Anniversaries(ctx, scopeCriteria(ctx, criteria), month, year, page)
+}
+
+// HeadcountByCostCenter counts the employees of the tenant matching the criteria charged to each cost center.
+func (s *EmployeeStore) HeadcountByCostCenter(ctx context.Context, criteria repository.EmployeeCriteria) ([]models.CostCenterHeadcount, error) {
+	return s.Store.HeadcountByCostCenter(ctx, scopeCriteria(ctx, criteria))
+}
+
+// Workforce breaks the employees of the tenant matching the criteria down; see services.EmployeeStore.
+func (s *EmployeeStore) Workforce(ctx context.Context, criteria repository.EmployeeCriteria, now time.Time) (repository.Workforce, error) {
+	return s.Store.Workforce(ctx, scopeCriteria(ctx, criteria), now)
+}
+
+// SetManager sets or removes the manager of the employees of the tenant matching the criteria.
+func (s *EmployeeStore) SetManager(ctx context.Context, criteria repository.EmployeeCriteria, manager string) (int64, error) {
+	return s.Store.SetManager(ctx, scopeCriteria(ctx, criteria), manager)
+}
+
+// AddRoles adds the roles to the employees of the tenant matching the criteria.
+func (s *EmployeeStore) AddRoles(ctx context.Context, criteria repository.EmployeeCriteria, roles []string) (int64, error) {
+	return s.Store.AddRoles(ctx, scopeCriteria(ctx, criteria), roles)
+}
+
+// GrantTemporaryRole grants the role of the grant to the first employee of the tenant matching the criteria.
+func (s *EmployeeStore) GrantTemporaryRole(ctx context.Context, criteria repository.EmployeeCriteria, grant models.RoleGrant) (models.Employee, error) {
+	return s.Store.GrantTemporaryRole(ctx, scopeCriteria(ctx, criteria), grant)
+}
+
+// RevokeExpiredRole removes the expired role from the employees of the tenant matching the criteria.
+func (s *EmployeeStore) RevokeExpiredRole(ctx context.Context, criteria repository.EmployeeCriteria, role string, now time.Time) (int64, error) {
+	return s.Store.RevokeExpiredRole(ctx, scopeCriteria(ctx, criteria), role, now)
+}
+
+// SetDelegation sets the delegation of the first employee of the tenant matching the criteria.
+func (s *EmployeeStore) SetDelegation(ctx context.Context, criteria repository.EmployeeCriteria, delegation models.Delegation) (models.Employee, error) {
+	return s.Store.SetDelegation(ctx, scopeCriteria(ctx, criteria), delegation)
+}
+
+// RemoveDelegation removes the delegation of the employees of the tenant matching the criteria.
+func (s *EmployeeStore) RemoveDelegation(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error) {
+	return s.Store.RemoveDelegation(ctx, scopeCriteria(ctx, criteria))
+}
+
+// SetStatus applies the status transition to the first employee of the tenant matching the criteria.
+func (s *EmployeeStore) SetStatus(ctx context.Context, criteria repository.EmployeeCriteria, transition models.StatusTransition) (models.Employee, error) {
+	return s.Store.SetStatus(ctx, scopeCriteria(ctx, criteria), transition)
+}
+
+// AddDottedLine adds the manager to the dotted lines of the employees of the tenant matching the criteria.
+func (s *EmployeeStore) AddDottedLine(ctx context.Context, criteria repository.EmployeeCriteria, manager string) (int64, error) {
+	return s.Store.AddDottedLine(ctx, scopeCriteria(ctx, criteria), manager)
+}
+
+// RemoveDottedLine removes the manager from the dotted lines of the employees of the tenant matching the criteria.
+func (s *EmployeeStore) RemoveDottedLine(ctx context.Context, criteria repository.EmployeeCriteria, manager string) (int64, error) {
+	return s.Store.RemoveDottedLine(ctx, scopeCriteria(ctx, criteria), manager)
+}
+
+// ChangeEmail changes the email of the first employee of the tenant matching the criteria.
+func (s *EmployeeStore) ChangeEmail(ctx context.Context, criteria repository.EmployeeCriteria, email string) (bool, error) {
+	return s.Store.ChangeEmail(ctx, scopeCriteria(ctx, criteria), email)
+}
+
+// AssignID sets the internal ID of the first employee of the tenant matching the criteria.
+func (s *EmployeeStore) AssignID(ctx context.Context, criteria repository.EmployeeCriteria, id string) (bool, error) {
+	return s.Store.AssignID(ctx, scopeCriteria(ctx, criteria), id)
+}
+
+// DeriveDisplayNames sets the missing display names of the employees of the tenant matching the criteria.
+func (s *EmployeeStore) DeriveDisplayNames(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error) {
+	return s.Store.DeriveDisplayNames(ctx, scopeCriteria(ctx, criteria))
+}
+
+// SoftDelete marks the employees of the tenant matching the criteria as deleted at the time.
+func (s *EmployeeStore) SoftDelete(ctx context.Context, criteria repository.EmployeeCriteria, at time.Time) (int64, error) {
+	return s.Store.SoftDelete(ctx, scopeCriteria(ctx, criteria), at)
+}
+
+// Restore clears the deletion mark of the first employee of the tenant matching the criteria.
+func (s *EmployeeStore) Restore(ctx context.Context, criteria repository.EmployeeCriteria) (models.Employee, error) {
+	return s.Store.Restore(ctx, scopeCriteria(ctx, criteria))
+}
+
+// Delete removes the employees of the tenant matching the criteria for good.
+func (s *EmployeeStore) Delete(ctx context.Context, criteria repository.EmployeeCriteria) (int64, error) {
+	return s.Store.Delete(ctx, scopeCriteria(ctx, criteria))
+}
+
+// ReplaceEmployee replaces the employee of the tenant having emp's email with emp; see
+// repository.EmployeeRepository.
+func (s *EmployeeStore) ReplaceEmployee(ctx context.Context, emp models.Employee, version int64, upsert bool) (bool, error) {
+	if id, ok := FromContext(ctx); ok {
+		emp.TenantID = id
+	}
+	return s.Store.ReplaceEmployee(ctx, emp, version, upsert)
+}
+
+// InTransaction runs fn in a transaction of the store.
+func (s *EmployeeStore) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.Store.InTransaction(ctx, fn)
+}
+
+// MoveEmailReferences points the references to the employee of the tenant with the old email at the new email,
+// among the employees of the tenant matching the criteria.
+func (s *EmployeeStore) MoveEmailReferences(ctx context.Context, criteria repository.EmployeeCriteria, from, to string) error {
+	return s.Store.MoveEmailReferences(ctx, scopeCriteria(ctx, criteria), from, to)
+}
@@ -0,0 +1,32 @@
+// Package tenant tells apart the organizations, the tenants, served by one deployment. Each request is made for a
+// tenant, carried by its context, and the employees it reaches are scoped to that tenant, so that no organization
+// sees or changes the employees of another, nor the records of the other subsystems.
+package tenant
+
+import (
+	"context"
+	"regexp"
+
+	"WebMVCEmployees/repository"
+)
+
+// validID matches the tenant IDs accepted: letters, digits, dashes and underscores, 64 at most.
+var validID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Valid reports whether id is acceptable as the ID of a tenant.
+func Valid(id string) bool {
+	return validID.MatchString(id)
+}
+
+// NewContext returns a copy of ctx made for the tenant with the ID. Made for the empty ID, ctx reaches the employees
+// belonging to no tenant only, such as the operators of the deployment. The repositories keep the records of the other
+// subsystems, and the audit log, in a database of the tenant.
+func NewContext(ctx context.Context, id string) context.Context {
+	return repository.WithTenant(ctx, id)
+}
+
+// FromContext returns the ID of the tenant ctx is made for, and false when it is made for none, as the background
+// jobs are.
+func FromContext(ctx context.Context) (string, bool) {
+	return repository.TenantOf(ctx)
+}
@@ -0,0 +1,161 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/clients"
+	"WebMVCEmployees/docs"
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/inflight"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/slo"
+)
+
+func TestE2E_AdminSLO_TracksRequests(t *testing.T) {
+	resp, err := http.Get(testServer.URL + "/employees?page=1&size=1")
+	if err != nil {
+		t.Fatalf("failed to GET employees: %v", err)
+	}
+	resp.Body.Close()
+
+	resp, err = getAsAdmin(testServer.URL + "/admin/slo")
+	if err != nil {
+		t.Fatalf("failed to GET /admin/slo: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var statuses []slo.RouteStatus
+	if err := json.NewDecoder(resp.Body).Decode(&statuses); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Objective.Route != "GET /employees" {
+		t.Fatalf("expected the GET /employees objective, got %+v", statuses)
+	}
+	if statuses[0].Windows[0].Requests < 1 {
+		t.Errorf("expected at least one tracked request, got %d", statuses[0].Windows[0].Requests)
+	}
+}
+
+func TestE2E_AdminClients_ServesCurrentBundles(t *testing.T) {
+	spec := filepath.Join(clientsDir, clients.SpecFile)
+	if err := os.WriteFile(spec, []byte(docs.SwaggerInfo.ReadDoc()), 0o644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(clientsDir, "typescript.zip"), []byte("PK-bundle"), 0o644); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+
+	resp, err := getAsAdmin(testServer.URL + "/admin/clients")
+	if err != nil {
+		t.Fatalf("failed to GET /admin/clients: %v", err)
+	}
+	var bundles []clients.Bundle
+	if err := json.NewDecoder(resp.Body).Decode(&bundles); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	resp.Body.Close()
+	if len(bundles) != 1 || bundles[0].Language != "typescript" || !bundles[0].Current {
+		t.Fatalf("expected one current typescript bundle, got %+v", bundles)
+	}
+
+	resp, err = getAsAdmin(testServer.URL + "/admin/clients/typescript")
+	if err != nil {
+		t.Fatalf("failed to download bundle: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "PK-bundle" {
+		t.Fatalf("expected the bundle contents, got %d %q", resp.StatusCode, body)
+	}
+
+	resp, err = getAsAdmin(testServer.URL + "/admin/clients/java")
+	if err != nil {
+		t.Fatalf("failed to GET missing bundle: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404 for a missing bundle, got %d", resp.StatusCode)
+	}
+
+	// Bundles generated from another API version must not be served.
+	if err := os.WriteFile(spec, []byte(`{"swagger":"2.0","paths":{}}`), 0o644); err != nil {
+		t.Fatalf("failed to write stale spec: %v", err)
+	}
+	resp, err = getAsAdmin(testServer.URL + "/admin/clients/typescript")
+	if err != nil {
+		t.Fatalf("failed to GET stale bundle: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("expected status 409 for a stale bundle, got %d", resp.StatusCode)
+	}
+}
+
+func TestE2E_AdminInFlight_ReportsIdleInstance(t *testing.T) {
+	resp, err := http.Get(testServer.URL + "/employees?page=1&size=1")
+	if err != nil {
+		t.Fatalf("failed to GET employees: %v", err)
+	}
+	resp.Body.Close()
+
+	resp, err = getAsAdmin(testServer.URL + "/admin/inflight")
+	if err != nil {
+		t.Fatalf("failed to GET /admin/inflight: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var report inflight.Report
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	// The report request itself is not counted and the earlier request has completed.
+	if report.Requests != 0 || len(report.Routes) != 0 || report.MongoOperations != 0 {
+		t.Errorf("expected an idle instance, got %+v", report)
+	}
+}
+
+func TestE2E_Admin_RequiresAdminRole(t *testing.T) {
+	email := "someone@admin.example.com"
+	createEmployee(t, newTestEmployee(email, "Developer"))
+
+	for _, path := range []string{"/admin/slo", "/admin/clients", "/admin/audit", "/admin/inflight"} {
+		expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+path, nil), http.StatusUnauthorized, errors.CodeUnauthorized)
+		expectErrorCode(t, doJSONAs(t, http.MethodGet, testServer.URL+path, nil, email, "Test1"), http.StatusForbidden, errors.CodeForbidden)
+	}
+}
+
+func TestE2E_AdminAudit_DefaultsPagination(t *testing.T) {
+	resp := doJSONAsAdmin(t, http.MethodGet, testServer.URL+"/admin/audit", nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 without page and size, got %d", resp.StatusCode)
+	}
+	var entries []models.AuditEntry
+	if err := decodeJSON(resp, &entries); err != nil {
+		t.Fatalf("failed to decode audit log: %v", err)
+	}
+	if len(entries) > 10 {
+		t.Errorf("expected at most the default 10 entries, got %d", len(entries))
+	}
+
+	invalid := doJSONAsAdmin(t, http.MethodGet, testServer.URL+"/admin/audit?page=0&size=abc", nil)
+	defer invalid.Body.Close()
+	var errResp models.ErrorResponse
+	if err := decodeJSON(invalid, &errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if invalid.StatusCode != http.StatusBadRequest || !strings.Contains(errResp.Error, "page") || !strings.Contains(errResp.Error, "size") {
+		t.Errorf("expected one 400 naming page and size, got %d with %q", invalid.StatusCode, errResp.Error)
+	}
+}
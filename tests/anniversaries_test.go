@@ -0,0 +1,64 @@
+package controllers_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// hiredOn returns a test employee hired on the given day of a year.
+func hiredOn(email string, year int, monthDay string) models.NewEmployeeBoundary {
+	emp := newTestEmployee(email, "Developer")
+	emp.HireDate = fmt.Sprintf("%04d-%s", year, monthDay)
+	return emp
+}
+
+func TestE2E_Anniversaries(t *testing.T) {
+	year := time.Now().UTC().Year()
+	veteran, regular := "veteran@anniversaries.example.com", "regular@anniversaries.example.com"
+	createEmployee(t, hiredOn(regular, year-3, "01-20"))
+	createEmployee(t, hiredOn(veteran, year-10, "01-05"))
+	createEmployee(t, hiredOn("newcomer@anniversaries.example.com", year, "01-01"))
+	createEmployee(t, hiredOn("february@anniversaries.example.com", year-2, "02-10"))
+
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/reports/anniversaries?month=01&size=100", nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var all []models.Anniversary
+	if err := decodeJSON(resp, &all); err != nil {
+		t.Fatalf("failed to decode anniversaries: %v", err)
+	}
+	var anniversaries []models.Anniversary
+	for _, a := range all {
+		if strings.HasSuffix(a.Email, "@anniversaries.example.com") {
+			anniversaries = append(anniversaries, a)
+		}
+	}
+
+	// Anniversaries are ordered by day, and employees hired this year have none yet.
+	expected := []models.Anniversary{
+		{Email: veteran, Date: fmt.Sprintf("%04d-01-05", year), YearsOfService: 10},
+		{Email: regular, Date: fmt.Sprintf("%04d-01-20", year), YearsOfService: 3},
+	}
+	if len(anniversaries) != len(expected) {
+		t.Fatalf("expected anniversaries of %s and %s, got %+v", veteran, regular, anniversaries)
+	}
+	for i, want := range expected {
+		got := anniversaries[i]
+		if got.Email != want.Email || got.Date != want.Date || got.YearsOfService != want.YearsOfService {
+			t.Errorf("expected anniversary %+v at %d, got %+v", want, i, got)
+		}
+	}
+
+	for _, month := range []string{"13", "0", "june"} {
+		expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/reports/anniversaries?month="+month, nil),
+			http.StatusBadRequest, errors.CodeInvalidQuery)
+	}
+}
@@ -0,0 +1,84 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// getAttendance returns the attendance summary of the employee for the period containing today.
+func getAttendance(t *testing.T, email, period string) models.AttendanceSummary {
+	t.Helper()
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/"+email+"/attendance?period="+period, nil)
+	defer resp.Body.Close()
+	var summary models.AttendanceSummary
+	if err := decodeJSON(resp, &summary); err != nil {
+		t.Fatalf("failed to decode attendance summary: %v", err)
+	}
+	return summary
+}
+
+func TestE2E_Attendance(t *testing.T) {
+	boss, dev, peer := "boss@attendance.example.com", "dev@attendance.example.com", "peer@attendance.example.com"
+	createEmployee(t, newTestEmployee(boss, "Manager"))
+	createEmployee(t, newTestEmployee(dev, "Developer"))
+	createEmployee(t, newTestEmployee(peer, "Developer"))
+	setManager(t, dev, boss)
+	setManager(t, peer, boss)
+	checkIn, checkOut := testServer.URL+"/employees/"+dev+"/checkin", testServer.URL+"/employees/"+dev+"/checkout"
+
+	expectErrorCode(t, doJSON(t, http.MethodPost, checkOut, nil), http.StatusConflict, errors.CodeNotCheckedIn)
+	for i := 0; i < 2; i++ {
+		resp := doJSON(t, http.MethodPost, checkIn, nil)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected status 201 checking in, got %d", resp.StatusCode)
+		}
+		expectErrorCode(t, doJSON(t, http.MethodPost, checkIn, nil), http.StatusConflict, errors.CodeAlreadyCheckedIn)
+
+		resp = doJSON(t, http.MethodPost, checkOut, nil)
+		defer resp.Body.Close()
+		var record models.AttendanceRecord
+		if err := decodeJSON(resp, &record); err != nil {
+			t.Fatalf("failed to decode attendance record: %v", err)
+		}
+		if record.CheckOut == nil || record.CheckOut.Before(record.CheckIn) {
+			t.Errorf("unexpected attendance record %+v", record)
+		}
+	}
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/employees/"+peer+"/checkin", nil)
+	resp.Body.Close()
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	daily := getAttendance(t, dev, "day")
+	if daily.From != today || daily.To != today || len(daily.Days) != 1 || daily.Days[0].Records != 2 || daily.Days[0].CheckedIn {
+		t.Errorf("unexpected daily summary %+v", daily)
+	}
+	weekly := getAttendance(t, dev, "week")
+	monday, _ := time.Parse(time.DateOnly, weekly.From)
+	if len(weekly.Days) != 7 || monday.Weekday() != time.Monday || weekly.From > today || weekly.To < today {
+		t.Errorf("unexpected weekly summary %+v", weekly)
+	}
+
+	resp = doJSON(t, http.MethodGet, testServer.URL+"/employees/"+boss+"/team/attendance?period=week", nil)
+	defer resp.Body.Close()
+	var team []models.AttendanceSummary
+	if err := decodeJSON(resp, &team); err != nil {
+		t.Fatalf("failed to decode team attendance: %v", err)
+	}
+	if len(team) != 2 || team[0].Employee != dev || team[1].Employee != peer {
+		t.Fatalf("expected the attendance of %s and %s, got %+v", dev, peer, team)
+	}
+	checkedIn := false
+	for _, day := range team[1].Days {
+		checkedIn = checkedIn || day.CheckedIn
+	}
+	if !checkedIn {
+		t.Errorf("expected %s to be checked in, got %+v", peer, team[1])
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/"+dev+"/attendance?period=month", nil),
+		http.StatusBadRequest, errors.CodeInvalidQuery)
+}
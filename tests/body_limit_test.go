@@ -0,0 +1,44 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/middleware"
+	"WebMVCEmployees/models"
+)
+
+// oversizedBulkBody returns a bulk import larger than the default body limit.
+func oversizedBulkBody(t *testing.T) []byte {
+	t.Helper()
+	emp := newTestEmployee("huge@limit.example.com", "Developer")
+	emp.Name = strings.Repeat("x", int(middleware.DefaultBodyLimit))
+	body, err := json.Marshal([]models.NewEmployeeBoundary{emp})
+	if err != nil {
+		t.Fatalf("failed to marshal payload: %v", err)
+	}
+	return body
+}
+
+func TestE2E_BodyLimit_ContentLength(t *testing.T) {
+	resp, err := http.Post(testServer.URL+"/employees/bulk", "application/json", bytes.NewReader(oversizedBulkBody(t)))
+	if err != nil {
+		t.Fatalf("failed to send POST request: %v", err)
+	}
+	expectErrorCode(t, resp, http.StatusRequestEntityTooLarge, errors.CodePayloadTooLarge)
+}
+
+func TestE2E_BodyLimit_Chunked(t *testing.T) {
+	// Hiding the length makes the client stream the body, so the limit applies while reading.
+	body := io.MultiReader(bytes.NewReader(oversizedBulkBody(t)))
+	resp, err := http.Post(testServer.URL+"/employees", "application/json", body)
+	if err != nil {
+		t.Fatalf("failed to send POST request: %v", err)
+	}
+	expectErrorCode(t, resp, http.StatusRequestEntityTooLarge, errors.CodePayloadTooLarge)
+}
@@ -0,0 +1,61 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_BulkCreateEmployees_MultiStatus(t *testing.T) {
+	invalid := newTestEmployee("bulk2@bulk.example.com", "Developer")
+	invalid.Password = "weak"
+	emps := []models.NewEmployeeBoundary{
+		newTestEmployee("bulk1@bulk.example.com", "Developer"),
+		invalid,
+		newTestEmployee("bulk3@bulk.example.com", "Developer"),
+	}
+
+	resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/bulk", emps)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusMultiStatus {
+		t.Fatalf("expected status 207, got %d", resp.StatusCode)
+	}
+	var report models.BulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if report.Succeeded != 2 || report.Failed != 1 || report.Skipped != 0 {
+		t.Errorf("expected 2 succeeded and 1 failed, got %+v", report)
+	}
+	if len(report.Results) != 3 || report.Results[1].Status != http.StatusBadRequest {
+		t.Errorf("expected item 1 to fail with 400, got %+v", report.Results)
+	}
+}
+
+func TestE2E_BulkAssignRoles_Abort(t *testing.T) {
+	createEmployee(t, newTestEmployee("bulkroles1@bulk.example.com", "Developer"))
+	createEmployee(t, newTestEmployee("bulkroles2@bulk.example.com", "Developer"))
+	assignments := []models.RoleAssignment{
+		{Email: "bulkroles1@bulk.example.com", Roles: []string{"Reviewer"}},
+		{Email: "missing@bulk.example.com", Roles: []string{"Reviewer"}},
+		{Email: "bulkroles2@bulk.example.com", Roles: []string{"Reviewer"}},
+	}
+
+	resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/roles/bulk?mode=abort", assignments)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusMultiStatus {
+		t.Fatalf("expected status 207, got %d", resp.StatusCode)
+	}
+	var report models.BulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if report.Succeeded != 1 || report.Failed != 1 || report.Skipped != 1 {
+		t.Errorf("expected 1 succeeded, 1 failed and 1 skipped, got %+v", report)
+	}
+	if report.Results[1].Status != http.StatusNotFound {
+		t.Errorf("expected item 1 to fail with 404, got %d", report.Results[1].Status)
+	}
+}
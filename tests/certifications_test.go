@@ -0,0 +1,97 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// addCertification records a certification of the employee and fails the test unless it is created.
+func addCertification(t *testing.T, email string, req models.CertificationRequest) models.Certification {
+	t.Helper()
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/employees/"+email+"/certifications", req)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201 adding a certification, got %d", resp.StatusCode)
+	}
+	var cert models.Certification
+	if err := decodeJSON(resp, &cert); err != nil {
+		t.Fatalf("failed to decode certification: %v", err)
+	}
+	return cert
+}
+
+// getCertifications returns the certifications listed at the given URL.
+func getCertifications(t *testing.T, url string) []models.Certification {
+	t.Helper()
+	resp := doJSON(t, http.MethodGet, url, nil)
+	defer resp.Body.Close()
+	var certs []models.Certification
+	if err := decodeJSON(resp, &certs); err != nil {
+		t.Fatalf("failed to decode certifications: %v", err)
+	}
+	return certs
+}
+
+// certificationIDs returns the identifiers of the certifications, in order.
+func certificationIDs(certs []models.Certification) []string {
+	ids := make([]string, len(certs))
+	for i, cert := range certs {
+		ids[i] = cert.ID
+	}
+	return ids
+}
+
+func TestE2E_Certifications(t *testing.T) {
+	ann, bob := "ann@certifications.example.com", "bob@certifications.example.com"
+	createEmployee(t, newTestEmployee(ann, "Developer"))
+	createEmployee(t, newTestEmployee(bob, "Developer"))
+	day := func(days int) string { return time.Now().UTC().AddDate(0, 0, days).Format(time.DateOnly) }
+
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/employees/"+ann+"/certifications",
+		models.CertificationRequest{Name: "CKA", Issuer: "CNCF", ExpiresAt: "30/06/2026"}), http.StatusBadRequest, errors.CodeInvalidPayload)
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/employees/nobody@certifications.example.com/certifications",
+		models.CertificationRequest{Name: "CKA", Issuer: "CNCF"}), http.StatusNotFound, errors.CodeEmployeeNotFound)
+
+	lapsed := addCertification(t, ann, models.CertificationRequest{Name: "First Aid", Issuer: "Red Cross", ExpiresAt: day(-10)})
+	soon := addCertification(t, bob, models.CertificationRequest{Name: "CKA", Issuer: "CNCF", ExpiresAt: day(30)})
+	later := addCertification(t, ann, models.CertificationRequest{Name: "AWS SAA", Issuer: "AWS", ExpiresAt: day(200)})
+	forever := addCertification(t, ann, models.CertificationRequest{Name: "PMP Lifetime", Issuer: "PMI"})
+
+	owned := certificationIDs(getCertifications(t, testServer.URL+"/employees/"+ann+"/certifications"))
+	if want := []string{forever.ID, lapsed.ID, later.ID}; len(owned) != 3 || owned[0] != want[0] || owned[1] != want[1] || owned[2] != want[2] {
+		t.Errorf("expected %s's certifications %v, got %v", ann, want, owned)
+	}
+
+	// Expiring certifications of every employee, soonest first, including lapsed ones.
+	expiring := certificationIDs(getCertifications(t, testServer.URL+"/certifications/expiring?within=90d&size=100"))
+	if len(expiring) != 2 || expiring[0] != lapsed.ID || expiring[1] != soon.ID {
+		t.Errorf("expected %v expiring within 90 days, got %v", []string{lapsed.ID, soon.ID}, expiring)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/certifications/expiring?within=soon", nil),
+		http.StatusBadRequest, errors.CodeInvalidQuery)
+
+	// A renewal moves the expiry out of the window.
+	renewURL := testServer.URL + "/employees/" + ann + "/certifications/" + lapsed.ID
+	resp := doJSON(t, http.MethodPut, renewURL, models.CertificationRequest{Name: "First Aid", Issuer: "Red Cross", ExpiresAt: day(700)})
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 renewing the certification, got %d", resp.StatusCode)
+	}
+	expiring = certificationIDs(getCertifications(t, testServer.URL+"/certifications/expiring?size=100"))
+	if len(expiring) != 1 || expiring[0] != soon.ID {
+		t.Errorf("expected only %s expiring after the renewal, got %v", soon.ID, expiring)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPut, testServer.URL+"/employees/"+bob+"/certifications/"+lapsed.ID,
+		models.CertificationRequest{Name: "First Aid", Issuer: "Red Cross"}), http.StatusNotFound, errors.CodeCertificationNotFound)
+
+	resp = doJSON(t, http.MethodDelete, renewURL, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 deleting the certification, got %d", resp.StatusCode)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodDelete, renewURL, nil), http.StatusNotFound, errors.CodeCertificationNotFound)
+}
@@ -0,0 +1,126 @@
+package controllers_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_Compensation_RestrictedToHR(t *testing.T) {
+	hr, dev := "hr@compensation.example.com", "dev@compensation.example.com"
+	createEmployee(t, newTestEmployee(hr, "HR"))
+	createEmployee(t, newTestEmployee(dev, "Developer"))
+	url := testServer.URL + "/employees/" + dev + "/compensation"
+	raise := models.CompensationRequest{Salary: 120000, Currency: "ILS", EffectiveDate: "2024-01-01"}
+
+	expectErrorCode(t, doJSON(t, http.MethodGet, url, nil), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSONAs(t, http.MethodGet, url, nil, hr, "Wrong1"), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSONAs(t, http.MethodPut, url, raise, dev, "Test1"), http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSONAs(t, http.MethodPut, url, models.CompensationRequest{Salary: 1, Currency: "XYZ", EffectiveDate: "2024-01-01"}, hr, "Test1"),
+		http.StatusBadRequest, errors.CodeInvalidPayload)
+
+	for _, req := range []models.CompensationRequest{
+		raise,
+		{Salary: 100000, Currency: "ILS", EffectiveDate: "2023-01-01"},
+		{Salary: 150000, Currency: "ILS", EffectiveDate: time.Now().UTC().AddDate(1, 0, 0).Format(time.DateOnly)},
+	} {
+		resp := doJSONAs(t, http.MethodPut, url, req, hr, "Test1")
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200 setting the salary, got %d", resp.StatusCode)
+		}
+	}
+
+	resp := doJSONAs(t, http.MethodGet, url, nil, hr, "Test1")
+	defer resp.Body.Close()
+	var compensation models.Compensation
+	if err := decodeJSON(resp, &compensation); err != nil {
+		t.Fatalf("failed to decode compensation: %v", err)
+	}
+	if compensation.Current == nil || compensation.Current.Salary != 120000 || compensation.Current.RecordedBy != hr {
+		t.Errorf("expected the 2024 salary to be current, got %+v", compensation.Current)
+	}
+	if len(compensation.History) != 3 || compensation.History[2].EffectiveDate != "2023-01-01" {
+		t.Errorf("expected 3 salaries, latest first, got %+v", compensation.History)
+	}
+
+	// The salary is never part of the employee.
+	empResp := doJSON(t, http.MethodGet, testServer.URL+"/employees/"+dev+"?password=Test1", nil)
+	defer empResp.Body.Close()
+	body, err := io.ReadAll(empResp.Body)
+	if err != nil {
+		t.Fatalf("failed to read employee: %v", err)
+	}
+	if strings.Contains(string(body), "salary") {
+		t.Errorf("employee response exposes the salary: %s", body)
+	}
+}
+
+func TestE2E_Compensation_SelfRegisteredAdminForbidden(t *testing.T) {
+	intruder := "intruder@compensation.example.com"
+	victim := "victim@compensation.example.com"
+	createEmployee(t, newTestEmployee(victim, "Developer"))
+	url := testServer.URL + "/employees/" + victim + "/compensation"
+
+	// Anyone may sign up, but not with roles.
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/employees", newTestEmployee(intruder, "Admin", "HR")),
+		http.StatusUnauthorized, errors.CodeUnauthorized)
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/employees", newTestEmployee(intruder))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 signing up without roles, got %d", resp.StatusCode)
+	}
+
+	// Nor grant themselves roles afterwards.
+	promoted := newTestEmployee(intruder, "Admin")
+	expectErrorCode(t, doJSON(t, http.MethodPut, testServer.URL+"/employees/"+intruder, promoted), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSONAs(t, http.MethodPut, testServer.URL+"/employees/"+intruder, promoted, intruder, "Test1"),
+		http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, testServer.URL+"/employees/bulk", []models.NewEmployeeBoundary{newTestEmployee("accomplice@compensation.example.com", "Admin")}, intruder, "Test1"),
+		http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, testServer.URL+"/employees/roles/bulk", []models.RoleAssignment{{Email: intruder, Roles: []string{"Admin"}}}, intruder, "Test1"),
+		http.StatusForbidden, errors.CodeForbidden)
+
+	expectErrorCode(t, doJSONAs(t, http.MethodGet, url, nil, intruder, "Test1"), http.StatusForbidden, errors.CodeForbidden)
+}
+
+func TestE2E_Compensation_AnonymousReplaceKeepsCredentials(t *testing.T) {
+	victim := "hr@takeover.compensation.example.com"
+	intruder := "intruder@takeover.compensation.example.com"
+	createEmployee(t, newTestEmployee(victim, "HR"))
+	createEmployee(t, newTestEmployee(intruder))
+	url := testServer.URL + "/employees/" + victim
+
+	// Sending the current roles with a new password must not let anyone but the employee and admins in.
+	hijacked := newTestEmployee(victim, "HR")
+	hijacked.Password = "Stolen1"
+	expectErrorCode(t, doJSON(t, http.MethodPut, url, hijacked), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSONAs(t, http.MethodPut, url, hijacked, intruder, "Test1"), http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSONAs(t, http.MethodGet, url+"/compensation", nil, victim, "Stolen1"), http.StatusUnauthorized, errors.CodeUnauthorized)
+
+	// The rest of the record is still replaced by anyone, the password unchanged.
+	renamed := newTestEmployee(victim, "HR")
+	renamed.Name = "Renamed HR"
+	resp := doJSON(t, http.MethodPut, url, renamed)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 replacing without changing the password, got %d", resp.StatusCode)
+	}
+
+	// The employee changes their own password.
+	resp = doJSONAs(t, http.MethodPut, url, hijacked, victim, "Test1")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 changing one's own password, got %d", resp.StatusCode)
+	}
+	resp = doJSONAs(t, http.MethodGet, url+"/compensation", nil, victim, "Stolen1")
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		t.Errorf("expected the new password accepted once the employee changed it")
+	}
+}
@@ -0,0 +1,96 @@
+package controllers_test
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+
+	"WebMVCEmployees/models"
+
+	"github.com/andybalholm/brotli"
+)
+
+// getCompressed lists the employees of a domain with an explicit Accept-Encoding,
+// which disables the transparent decompression of the HTTP client.
+func getCompressed(t *testing.T, domain, acceptEncoding string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, testServer.URL+"/employees?criteria=byEmailDomain&value="+domain+"&page=1&size=50", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to GET employees: %v", err)
+	}
+	return resp
+}
+
+// createEmployees creates enough employees in a domain for their listing to exceed the minimum size.
+func createEmployees(t *testing.T, domain string, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		createEmployee(t, newTestEmployee(fmt.Sprintf("packed%d@%s", i, domain), "Developer"))
+	}
+}
+
+func TestE2E_Compression_LargeListing(t *testing.T) {
+	createEmployees(t, "gzip.example.com", 20)
+
+	for _, encoding := range []string{"gzip", "br"} {
+		resp := getCompressed(t, "gzip.example.com", encoding)
+		if got := resp.Header.Get("Content-Encoding"); got != encoding {
+			resp.Body.Close()
+			t.Fatalf("expected Content-Encoding %q, got %q", encoding, got)
+		}
+		if vary := resp.Header.Values("Vary"); !slices.Contains(vary, "Accept-Encoding") {
+			t.Errorf("expected Vary to include Accept-Encoding, got %v", vary)
+		}
+		var body io.Reader = brotli.NewReader(resp.Body)
+		if encoding == "gzip" {
+			gz, err := gzip.NewReader(resp.Body)
+			if err != nil {
+				resp.Body.Close()
+				t.Fatalf("invalid gzip stream: %v", err)
+			}
+			body = gz
+		}
+		var employees []models.EmployeeResponse
+		err := json.NewDecoder(body).Decode(&employees)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("failed to decode %s response: %v", encoding, err)
+		}
+		if len(employees) != 20 {
+			t.Errorf("expected 20 employees, got %d", len(employees))
+		}
+	}
+}
+
+func TestE2E_Compression_SmallResponseUncompressed(t *testing.T) {
+	createEmployee(t, newTestEmployee("single@smallgzip.example.com", "Developer"))
+
+	resp := getCompressed(t, "smallgzip.example.com", "gzip")
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Encoding"); got != "" {
+		t.Errorf("expected a response below the minimum size to be sent as is, got Content-Encoding %q", got)
+	}
+	var employees []models.EmployeeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&employees); err != nil || len(employees) != 1 {
+		t.Errorf("expected one plain JSON employee, got %v (%v)", employees, err)
+	}
+}
+
+func TestE2E_Compression_NotAccepted(t *testing.T) {
+	createEmployees(t, "identity.example.com", 20)
+
+	resp := getCompressed(t, "identity.example.com", "identity")
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Encoding"); got != "" {
+		t.Errorf("expected no Content-Encoding, got %q", got)
+	}
+}
@@ -0,0 +1,50 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_ContactDetails_FilterByCountryAndCity(t *testing.T) {
+	telAviv := newTestEmployee("tlv@contact.example.com", "Developer")
+	telAviv.Phone = "+972501234567"
+	telAviv.Address = &models.Address{Street: "Mivtza Kadesh 38", City: "Tel Aviv", Country: "IL"}
+	haifa := newTestEmployee("haifa@contact.example.com", "Developer")
+	haifa.Address = &models.Address{City: "Haifa", Country: "IL"}
+	paris := newTestEmployee("paris@contact.example.com", "Developer")
+	paris.Address = &models.Address{City: "Paris", Country: "FR"}
+	for _, emp := range []models.NewEmployeeBoundary{telAviv, haifa, paris} {
+		createEmployee(t, emp)
+	}
+
+	israel := getEmployees(t, testServer.URL+"/employees?criteria=byCountry&value=il&sort=email")
+	if len(israel) != 2 || israel[0].Email != haifa.Email || israel[1].Email != telAviv.Email {
+		t.Errorf("expected the two employees in IL, got %+v", israel)
+	}
+
+	city := getEmployees(t, testServer.URL+"/employees?criteria=byCity&value=tel%20aviv")
+	if len(city) != 1 || city[0].Email != telAviv.Email {
+		t.Fatalf("expected only %s in Tel Aviv, got %+v", telAviv.Email, city)
+	}
+	if city[0].Phone != telAviv.Phone || city[0].Address == nil || city[0].Address.Street != "Mivtza Kadesh 38" {
+		t.Errorf("expected the contact details to be returned, got %+v", city[0])
+	}
+
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees?criteria=byCity", nil),
+		http.StatusBadRequest, errors.CodeMissingParameter)
+}
+
+func TestE2E_ContactDetails_Validation(t *testing.T) {
+	emp := newTestEmployee("invalid@contact.example.com", "Developer")
+	emp.Phone = "050-1234567"
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeInvalidPhone)
+
+	emp.Phone = ""
+	emp.Address = &models.Address{City: "Tel Aviv", Country: "Israel"}
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeInvalidAddress)
+	emp.Address = &models.Address{Country: "IL"}
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeInvalidAddress)
+}
@@ -0,0 +1,58 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// chargedTo returns a test employee charged to the cost center.
+func chargedTo(email, costCenter string) models.NewEmployeeBoundary {
+	emp := newTestEmployee(email, "Developer")
+	emp.CostCenter = costCenter
+	return emp
+}
+
+func TestE2E_CostCenters(t *testing.T) {
+	service := testEmployeeController.Service
+	service.CostCenters = []string{"CCT-1100", "CCT-1200", "CCT-1300"}
+	defer func() { service.CostCenters = nil }()
+
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", chargedTo("invalid@costcenters.example.com", "CCT-9999")),
+		http.StatusBadRequest, errors.CodeInvalidCostCenter)
+	first, second, other := "first@costcenters.example.com", "second@costcenters.example.com", "other@costcenters.example.com"
+	createEmployee(t, chargedTo(first, "CCT-1100"))
+	createEmployee(t, chargedTo(second, "CCT-1100"))
+	createEmployee(t, chargedTo(other, "CCT-1200"))
+
+	charged := getEmployees(t, testServer.URL+"/employees?criteria=byCostCenter&value=CCT-1100")
+	if len(charged) != 2 || charged[0].Email != first || charged[1].Email != second || charged[0].CostCenter != "CCT-1100" {
+		t.Errorf("expected %s and %s charged to CCT-1100, got %+v", first, second, charged)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees?criteria=byCostCenter", nil),
+		http.StatusBadRequest, errors.CodeMissingParameter)
+
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/reports/headcount-by-costcenter", nil)
+	defer resp.Body.Close()
+	var headcounts []models.CostCenterHeadcount
+	if err := decodeJSON(resp, &headcounts); err != nil {
+		t.Fatalf("failed to decode headcounts: %v", err)
+	}
+	counts := map[string]int64{}
+	for _, h := range headcounts {
+		counts[h.CostCenter] = h.Headcount
+	}
+	// Configured cost centers nobody is charged to are reported too.
+	if len(headcounts) != len(counts) || counts["CCT-1100"] != 2 || counts["CCT-1200"] != 1 {
+		t.Errorf("unexpected headcounts %+v", headcounts)
+	}
+	if count, ok := counts["CCT-1300"]; !ok || count != 0 {
+		t.Errorf("expected CCT-1300 with no employees, got %+v", headcounts)
+	}
+
+	// Without a configured list any cost center is accepted.
+	service.CostCenters = nil
+	createEmployee(t, chargedTo("free@costcenters.example.com", "CCT-9999"))
+}
@@ -0,0 +1,52 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"WebMVCEmployees/controllers"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/router"
+)
+
+func TestE2E_CreateEmployee_CreatedWithLocation(t *testing.T) {
+	controller := &controllers.EmployeeController{Service: testEmployeeController.Service, CreatedWithLocation: true}
+	server := httptest.NewServer(router.SetupRouter(controller))
+	defer server.Close()
+
+	resp := doJSONAsAdmin(t, http.MethodPost, server.URL+"/employees", newTestEmployee("newhire@created.example.com", "Developer"))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", resp.StatusCode)
+	}
+	location := resp.Header.Get("Location")
+	if location != "/employees/newhire@created.example.com" {
+		t.Fatalf("unexpected Location %q", location)
+	}
+
+	// The Location must point at the new employee.
+	resp, err := http.Get(server.URL + location + "?password=Test1")
+	if err != nil {
+		t.Fatalf("failed to GET %s: %v", location, err)
+	}
+	defer resp.Body.Close()
+	var emp models.EmployeeResponse
+	if err := decodeJSON(resp, &emp); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	if emp.Email != "newhire@created.example.com" {
+		t.Errorf("expected the created employee, got %+v", emp)
+	}
+}
+
+func TestE2E_CreateEmployee_LegacyStatus(t *testing.T) {
+	resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", newTestEmployee("legacy@created.example.com", "Developer"))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200 without the flag, got %d", resp.StatusCode)
+	}
+	if location := resp.Header.Get("Location"); location != "" {
+		t.Errorf("expected no Location without the flag, got %q", location)
+	}
+}
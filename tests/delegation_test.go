@@ -0,0 +1,107 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// getEmployees decodes a list of employees from a GET endpoint.
+func getEmployees(t *testing.T, url string) []models.EmployeeResponse {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("failed to GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	var employees []models.EmployeeResponse
+	if err := decodeJSON(resp, &employees); err != nil {
+		t.Fatalf("failed to decode employees: %v", err)
+	}
+	return employees
+}
+
+// getApprover returns the email of the current approver of an employee.
+func getApprover(t *testing.T, email string) string {
+	t.Helper()
+	resp, err := http.Get(testServer.URL + "/employees/" + email + "/approver")
+	if err != nil {
+		t.Fatalf("failed to GET approver: %v", err)
+	}
+	defer resp.Body.Close()
+	var approver models.EmployeeResponse
+	if err := decodeJSON(resp, &approver); err != nil {
+		t.Fatalf("failed to decode approver: %v", err)
+	}
+	return approver.Email
+}
+
+func TestE2E_Delegation_ResolvesToDelegateDuringWindow(t *testing.T) {
+	manager := "manager@delegation.example.com"
+	deputy := "deputy@delegation.example.com"
+	report := "report@delegation.example.com"
+	createEmployee(t, newTestEmployee(manager, "Lead"))
+	createEmployee(t, newTestEmployee(deputy, "Lead"))
+	createEmployee(t, newTestEmployee(report, "Developer"))
+	resp := doJSONAsAdmin(t, http.MethodPut, testServer.URL+"/employees/"+report+"/manager", models.ManagerEmailBoundary{Email: manager})
+	resp.Body.Close()
+
+	now := time.Now().UTC()
+	resp = doJSONAs(t, http.MethodPut, testServer.URL+"/employees/"+manager+"/delegation",
+		models.Delegation{Delegate: deputy, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}, manager, "Test1")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 for delegation, got %d", resp.StatusCode)
+	}
+
+	subordinates := getEmployees(t, testServer.URL+"/employees/"+deputy+"/subordinates?page=1&size=10")
+	if len(subordinates) != 1 || subordinates[0].Email != report {
+		t.Errorf("expected the delegate to see %s, got %+v", report, subordinates)
+	}
+	if approver := getApprover(t, report); approver != deputy {
+		t.Errorf("expected %s to approve during the delegation, got %s", deputy, approver)
+	}
+
+	// An upcoming delegation does not take effect yet.
+	resp = doJSONAs(t, http.MethodPut, testServer.URL+"/employees/"+manager+"/delegation",
+		models.Delegation{Delegate: deputy, StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)}, manager, "Test1")
+	resp.Body.Close()
+	if approver := getApprover(t, report); approver != manager {
+		t.Errorf("expected %s to approve outside the delegation window, got %s", manager, approver)
+	}
+	if subordinates := getEmployees(t, testServer.URL+"/employees/"+deputy+"/subordinates?page=1&size=10"); len(subordinates) != 0 {
+		t.Errorf("expected no subordinates for the delegate outside the window, got %+v", subordinates)
+	}
+
+	resp = doJSONAs(t, http.MethodDelete, testServer.URL+"/employees/"+manager+"/delegation", nil, manager, "Test1")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200 when removing the delegation, got %d", resp.StatusCode)
+	}
+	resp, err := http.Get(testServer.URL + "/employees/" + manager + "/delegation")
+	if err != nil {
+		t.Fatalf("failed to GET delegation: %v", err)
+	}
+	expectErrorCode(t, resp, http.StatusNotFound, errors.CodeDelegationNotSet)
+}
+
+func TestE2E_Delegation_InvalidRequests(t *testing.T) {
+	manager := "self@delegation.example.com"
+	createEmployee(t, newTestEmployee(manager, "Lead"))
+	now := time.Now().UTC()
+
+	expectErrorCode(t, doJSONAs(t, http.MethodPut, testServer.URL+"/employees/"+manager+"/delegation",
+		models.Delegation{Delegate: manager, StartsAt: now, EndsAt: now.Add(time.Hour)}, manager, "Test1"),
+		http.StatusBadRequest, errors.CodeDelegateSelf)
+
+	expectErrorCode(t, doJSONAs(t, http.MethodPut, testServer.URL+"/employees/"+manager+"/delegation",
+		models.Delegation{Delegate: "missing@delegation.example.com", StartsAt: now, EndsAt: now.Add(time.Hour)}, manager, "Test1"),
+		http.StatusBadRequest, errors.CodeDelegateNotFound)
+
+	expectErrorCode(t, doJSONAs(t, http.MethodPut, testServer.URL+"/employees/"+manager+"/delegation",
+		models.Delegation{Delegate: "deputy@delegation.example.com", StartsAt: now, EndsAt: now.Add(-time.Hour)}, manager, "Test1"),
+		http.StatusBadRequest, errors.CodeInvalidDelegationWindow)
+}
@@ -0,0 +1,65 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_Departments(t *testing.T) {
+	rnd := models.Department{Name: "R&D", Description: "Research and development"}
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/departments", rnd)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201 creating the department, got %d", resp.StatusCode)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/departments", rnd), http.StatusConflict, errors.CodeDepartmentExists)
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/departments", models.Department{Name: " "}),
+		http.StatusBadRequest, errors.CodeInvalidPayload)
+	deptURL := testServer.URL + "/departments/" + url.PathEscape(rnd.Name)
+
+	// Only the description can change.
+	resp = doJSON(t, http.MethodPut, deptURL, models.Department{Description: "Builds the product"})
+	defer resp.Body.Close()
+	var updated models.Department
+	if err := decodeJSON(resp, &updated); err != nil {
+		t.Fatalf("failed to decode department: %v", err)
+	}
+	if updated != (models.Department{Name: rnd.Name, Description: "Builds the product"}) {
+		t.Errorf("unexpected updated department %+v", updated)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPut, deptURL, models.Department{Name: "Sales"}),
+		http.StatusBadRequest, errors.CodeDepartmentNameChange)
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/departments/Sales", nil),
+		http.StatusNotFound, errors.CodeDepartmentNotFound)
+
+	// Employees may only reference existing departments.
+	emp := newTestEmployee("dev@departments.example.com", "Developer")
+	emp.Department = "Sales"
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeDepartmentNotFound)
+	emp.Department = rnd.Name
+	createEmployee(t, emp)
+	createEmployee(t, newTestEmployee("other@departments.example.com", "Developer"))
+
+	members := getEmployees(t, testServer.URL+"/employees?criteria=byDepartment&value="+url.QueryEscape(rnd.Name))
+	if len(members) != 1 || members[0].Email != emp.Email || members[0].Department != rnd.Name {
+		t.Errorf("expected only %s in %s, got %+v", emp.Email, rnd.Name, members)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees?criteria=byDepartment", nil),
+		http.StatusBadRequest, errors.CodeMissingParameter)
+
+	// A department with employees is kept.
+	expectErrorCode(t, doJSON(t, http.MethodDelete, deptURL, nil), http.StatusConflict, errors.CodeDepartmentInUse)
+	emp.Department = ""
+	putResp := putEmployee(t, testServer.URL+"/employees/"+emp.Email, emp, "")
+	putResp.Body.Close()
+	resp = doJSON(t, http.MethodDelete, deptURL, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 deleting the department, got %d", resp.StatusCode)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, deptURL, nil), http.StatusNotFound, errors.CodeDepartmentNotFound)
+}
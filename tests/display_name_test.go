@@ -0,0 +1,73 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/models"
+)
+
+// queryEmployees posts an example to /employees/query and returns the matching employees.
+func queryEmployees(t *testing.T, query string, example map[string]interface{}) []models.EmployeeResponse {
+	t.Helper()
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/employees/query"+query, example)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 querying employees, got %d", resp.StatusCode)
+	}
+	var employees []models.EmployeeResponse
+	if err := decodeJSON(resp, &employees); err != nil {
+		t.Fatalf("failed to decode employees: %v", err)
+	}
+	return employees
+}
+
+func TestE2E_DisplayName(t *testing.T) {
+	preferred := newTestEmployee("zed@names.example.com", "Developer")
+	preferred.Name, preferred.PreferredName, preferred.Pronouns = "Zedekiah Smith", "Al", "they/them"
+	legal := newTestEmployee("bob@names.example.com", "Developer")
+	legal.Name = "Bob Jones"
+	createEmployee(t, preferred)
+	createEmployee(t, legal)
+
+	employees := getEmployees(t, testServer.URL+"/employees?criteria=byEmailDomain&value=names.example.com&sort=name")
+	if len(employees) != 2 {
+		t.Fatalf("expected 2 employees, got %d", len(employees))
+	}
+	// Al sorts before Bob by display name, although Zedekiah comes after Bob by legal name.
+	if employees[0].Email != preferred.Email || employees[0].DisplayName != "Al" || employees[0].Name != "Zedekiah Smith" ||
+		employees[0].Pronouns != "they/them" {
+		t.Errorf("unexpected first employee: %+v", employees[0])
+	}
+	if employees[1].DisplayName != "Bob Jones" || employees[1].PreferredName != "" {
+		t.Errorf("expected the legal name as the display name, got %+v", employees[1])
+	}
+	if descending := getEmployees(t, testServer.URL+"/employees?criteria=byEmailDomain&value=names.example.com&sort=-name"); descending[0].Email != legal.Email {
+		t.Errorf("expected %s first in descending order, got %s", legal.Email, descending[0].Email)
+	}
+
+	// A name search matches the display name and falls back to the legal name.
+	for _, name := range []string{"Al", "Zedekiah Smith"} {
+		if found := queryEmployees(t, "", map[string]interface{}{"name": name}); len(found) != 1 || found[0].Email != preferred.Email {
+			t.Errorf("expected searching for %q to find %s, got %+v", name, preferred.Email, found)
+		}
+	}
+	if found := queryEmployees(t, "", map[string]interface{}{"name": "Bob Jones"}); len(found) != 1 || found[0].Email != legal.Email {
+		t.Errorf("expected searching for Bob Jones to find %s, got %+v", legal.Email, found)
+	}
+
+	// Dropping the preferred name brings the legal name back.
+	preferred.PreferredName = ""
+	resp := putEmployee(t, testServer.URL+"/employees/"+preferred.Email, preferred, "")
+	defer resp.Body.Close()
+	var replaced models.EmployeeResponse
+	if err := decodeJSON(resp, &replaced); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	if replaced.DisplayName != "Zedekiah Smith" {
+		t.Errorf("expected the legal name once the preferred name is dropped, got %q", replaced.DisplayName)
+	}
+	if found := queryEmployees(t, "", map[string]interface{}{"name": "Al"}); len(found) != 0 {
+		t.Errorf("expected no employee displayed as Al, got %+v", found)
+	}
+}
@@ -0,0 +1,95 @@
+package controllers_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/services"
+)
+
+// testContract is the start of a PDF file, enough for its type to be detected.
+var testContract = []byte("%PDF-1.7\n1 0 obj << /Type /Catalog >> endobj\ntrailer << /Root 1 0 R >>\n%%EOF\n")
+
+// postDocument uploads data as a document of the employee.
+func postDocument(t *testing.T, email, query string, data []byte) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, testServer.URL+"/employees/"+email+"/documents?"+query, bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/pdf")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to upload document: %v", err)
+	}
+	return resp
+}
+
+func TestE2E_Documents(t *testing.T) {
+	email := "signed@documents.example.com"
+	createEmployee(t, newTestEmployee(email, "Developer"))
+
+	resp := postDocument(t, email, "name=contract.pdf&category=contract", testContract)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201 uploading the document, got %d", resp.StatusCode)
+	}
+	var doc models.Document
+	if err := decodeJSON(resp, &doc); err != nil {
+		t.Fatalf("failed to decode document: %v", err)
+	}
+	if doc.Name != "contract.pdf" || doc.Category != models.DocumentContract || doc.ContentType != "application/pdf" ||
+		doc.Size != int64(len(testContract)) || doc.DownloadURL == "" {
+		t.Errorf("unexpected document: %+v", doc)
+	}
+
+	// The signed link serves the content without credentials.
+	download, err := http.Get(testServer.URL + doc.DownloadURL)
+	if err != nil {
+		t.Fatalf("failed to download document: %v", err)
+	}
+	defer download.Body.Close()
+	content, _ := io.ReadAll(download.Body)
+	if download.StatusCode != http.StatusOK || download.Header.Get("Content-Type") != "application/pdf" || !bytes.Equal(content, testContract) {
+		t.Fatalf("expected the uploaded document back, got status %d, type %s and %d bytes",
+			download.StatusCode, download.Header.Get("Content-Type"), len(content))
+	}
+	if disposition := download.Header.Get("Content-Disposition"); !strings.Contains(disposition, "contract.pdf") {
+		t.Errorf("expected the file name in Content-Disposition, got %q", disposition)
+	}
+
+	// Tampering with the link or letting it expire refuses the download.
+	tampered := strings.Replace(doc.DownloadURL, "expires=", "expires=9", 1)
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+tampered, nil), http.StatusForbidden, errors.CodeDocumentLinkInvalid)
+	testDocumentService.URLTTL = -time.Minute
+	resp = doJSON(t, http.MethodGet, testServer.URL+"/employees/"+email+"/documents", nil)
+	testDocumentService.URLTTL = 15 * time.Minute
+	defer resp.Body.Close()
+	var docs []models.Document
+	if err := decodeJSON(resp, &docs); err != nil {
+		t.Fatalf("failed to decode documents: %v", err)
+	}
+	if len(docs) != 1 || docs[0].ID != doc.ID {
+		t.Fatalf("expected the uploaded document listed, got %+v", docs)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+docs[0].DownloadURL, nil), http.StatusForbidden, errors.CodeDocumentLinkExpired)
+
+	expectErrorCode(t, postDocument(t, email, "name=notes.txt", []byte("plain text")),
+		http.StatusUnsupportedMediaType, errors.CodeDocumentUnsupportedType)
+	// Documents are bounded by their own limit as well as by the limit on every request body.
+	expectErrorCode(t, postDocument(t, email, "name=huge.pdf", append(bytes.Clone(testContract), make([]byte, 1<<20)...)),
+		http.StatusRequestEntityTooLarge, errors.CodePayloadTooLarge)
+	testDocumentService.MaxBytes = 16
+	expectErrorCode(t, postDocument(t, email, "name=contract.pdf", testContract), http.StatusRequestEntityTooLarge, errors.CodeDocumentTooLarge)
+	testDocumentService.MaxBytes = services.DefaultMaxDocumentBytes
+	expectErrorCode(t, postDocument(t, email, "name=../contract.pdf", testContract), http.StatusBadRequest, errors.CodeInvalidQuery)
+	expectErrorCode(t, postDocument(t, email, "name=contract.pdf&category=resume", testContract), http.StatusBadRequest, errors.CodeInvalidQuery)
+	expectErrorCode(t, postDocument(t, "nobody@documents.example.com", "name=contract.pdf", testContract),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+}
@@ -0,0 +1,74 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_EmergencyContacts(t *testing.T) {
+	dev, boss, hr := "dev@contacts.example.com", "boss@contacts.example.com", "hr@contacts.example.com"
+	createEmployee(t, newTestEmployee(dev, "Developer"))
+	createEmployee(t, newTestEmployee(boss, "Manager"))
+	createEmployee(t, newTestEmployee(hr, "HR"))
+	setManager(t, dev, boss)
+	password := newTestEmployee(dev).Password
+	contactsURL := testServer.URL + "/employees/" + dev + "/emergency-contacts"
+	spouse := models.EmergencyContact{Name: "Dana Dev", Relationship: "Spouse", Phone: "+972501234567"}
+
+	// Nobody has contacts until they are set.
+	resp := doJSONAs(t, http.MethodGet, contactsURL, nil, dev, password)
+	defer resp.Body.Close()
+	var contacts models.EmergencyContacts
+	if err := decodeJSON(resp, &contacts); err != nil {
+		t.Fatalf("failed to decode contacts: %v", err)
+	}
+	if len(contacts.Contacts) != 0 || contacts.Employee != dev {
+		t.Errorf("expected no contacts for %s, got %+v", dev, contacts)
+	}
+
+	for _, invalid := range []models.EmergencyContact{
+		{Name: " ", Relationship: "Spouse", Phone: "+972501234567"},
+		{Name: "Dana Dev", Relationship: "", Phone: "+972501234567"},
+		{Name: "Dana Dev", Relationship: "Spouse", Phone: "050-1234567"},
+	} {
+		expectErrorCode(t, doJSONAs(t, http.MethodPut, contactsURL,
+			models.EmergencyContactsRequest{Contacts: []models.EmergencyContact{invalid}}, dev, password),
+			http.StatusBadRequest, errors.CodeInvalidPayload)
+	}
+
+	resp = doJSONAs(t, http.MethodPut, contactsURL, models.EmergencyContactsRequest{Contacts: []models.EmergencyContact{spouse}}, dev, password)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 setting contacts, got %d", resp.StatusCode)
+	}
+
+	// HR reads and replaces the contacts too.
+	mother := models.EmergencyContact{Name: "Miri Dev", Relationship: "Mother", Phone: "+972521234567"}
+	resp = doJSONAs(t, http.MethodPut, contactsURL, models.EmergencyContactsRequest{Contacts: []models.EmergencyContact{spouse, mother}}, hr, password)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 setting contacts as HR, got %d", resp.StatusCode)
+	}
+	for _, reader := range []string{dev, hr} {
+		resp := doJSONAs(t, http.MethodGet, contactsURL, nil, reader, password)
+		defer resp.Body.Close()
+		var contacts models.EmergencyContacts
+		if err := decodeJSON(resp, &contacts); err != nil {
+			t.Fatalf("failed to decode contacts: %v", err)
+		}
+		if len(contacts.Contacts) != 2 || contacts.Contacts[0] != spouse || contacts.Contacts[1] != mother || contacts.UpdatedBy != hr {
+			t.Errorf("unexpected contacts read by %s: %+v", reader, contacts)
+		}
+	}
+
+	// Not even the manager may access them, and they never show on the employee.
+	expectErrorCode(t, doJSON(t, http.MethodGet, contactsURL, nil), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSONAs(t, http.MethodGet, contactsURL, nil, boss, password), http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSONAs(t, http.MethodPut, contactsURL, models.EmergencyContactsRequest{}, boss, password),
+		http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSONAs(t, http.MethodGet, testServer.URL+"/employees/nobody@contacts.example.com/emergency-contacts", nil, hr, password),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+}
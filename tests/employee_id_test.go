@@ -0,0 +1,80 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// getEmployeeByID returns the employee with the given internal ID and fails the test unless it is found.
+func getEmployeeByID(t *testing.T, id string) models.EmployeeResponse {
+	t.Helper()
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/by-id/"+id, nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 getting employee %s, got %d", id, resp.StatusCode)
+	}
+	var emp models.EmployeeResponse
+	if err := decodeJSON(resp, &emp); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	return emp
+}
+
+func TestE2E_EmployeeID_ChangeEmail(t *testing.T) {
+	boss, dev, peer := "boss@rename.example.com", "jane.smith@rename.example.com", "peer@rename.example.com"
+	createEmployee(t, newTestEmployee(boss, "Manager"))
+	createEmployee(t, newTestEmployee(dev, "Developer"))
+	createEmployee(t, newTestEmployee(peer, "Developer"))
+	setManager(t, dev, boss)
+	setManager(t, peer, dev)
+	addNote(t, dev, "Joined the platform team")
+
+	chain := getEmployees(t, testServer.URL+"/employees/"+peer+"/managers")
+	if len(chain) != 2 {
+		t.Fatalf("expected 2 managers above %s, got %d", peer, len(chain))
+	}
+	id := chain[0].ID
+	if len(id) != 26 {
+		t.Fatalf("expected a 26-character ULID, got %q", id)
+	}
+	if got := getEmployeeByID(t, id); got.Email != dev {
+		t.Fatalf("expected ID %s to belong to %s, got %s", id, dev, got.Email)
+	}
+
+	// The new email must be valid and free.
+	url := testServer.URL + "/employees/" + dev + "/email"
+	expectErrorCode(t, doJSON(t, http.MethodPut, url, models.EmailChange{Email: "not-an-email"}), http.StatusBadRequest, errors.CodeInvalidEmail)
+	expectErrorCode(t, doJSON(t, http.MethodPut, url, models.EmailChange{Email: peer}), http.StatusConflict, errors.CodeEmployeeDuplicateEmail)
+	expectErrorCode(t, doJSON(t, http.MethodPut, testServer.URL+"/employees/nobody@rename.example.com/email",
+		models.EmailChange{Email: "somebody@rename.example.com"}), http.StatusNotFound, errors.CodeEmployeeNotFound)
+
+	married := "jane.doe@rename.example.com"
+	resp := doJSON(t, http.MethodPut, url, models.EmailChange{Email: married})
+	defer resp.Body.Close()
+	var changed models.EmployeeResponse
+	if err := decodeJSON(resp, &changed); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || changed.Email != married || changed.ID != id {
+		t.Fatalf("expected %s to keep ID %s, got status %d and %+v", married, id, resp.StatusCode, changed)
+	}
+
+	// The ID still finds the employee, now under the new email, and relationships follow it.
+	if got := getEmployeeByID(t, id); got.Email != married || got.Manager == nil || *got.Manager != boss {
+		t.Errorf("unexpected employee by ID after the change: %+v", got)
+	}
+	expectManagers(t, peer, married, boss)
+	if notes := listNotes(t, married, ""); len(notes) != 1 || notes[0].Employee != married {
+		t.Errorf("expected the note to follow the employee, got %+v", notes)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/"+dev+"/managers", nil),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/by-id/01ARZ3NDEKTSV4RRFFQ69G5FAV", nil),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/by-id/not-a-ulid", nil),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+}
@@ -0,0 +1,83 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// changeStatus posts a status transition and returns the updated employee.
+func changeStatus(t *testing.T, employee, action string) models.EmployeeResponse {
+	t.Helper()
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/employees/"+employee+"/"+action, nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 from %s of %s, got %d", action, employee, resp.StatusCode)
+	}
+	var emp models.EmployeeResponse
+	if err := decodeJSON(resp, &emp); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	return emp
+}
+
+func TestE2E_EmployeeStatusLifecycle(t *testing.T) {
+	ann, bob := "ann@lifecycle.example.com", "bob@lifecycle.example.com"
+	createEmployee(t, newTestEmployee(ann, "Developer"))
+	createEmployee(t, newTestEmployee(bob, "Developer"))
+	domainURL := testServer.URL + "/employees?criteria=byEmailDomain&value=lifecycle.example.com"
+
+	// A reactivation needs an employee who is not active.
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/employees/"+ann+"/reactivate", nil),
+		http.StatusConflict, errors.CodeInvalidStatusTransition)
+	if emp := changeStatus(t, ann, "leave"); emp.Status != models.StatusOnLeave {
+		t.Errorf("expected status on_leave, got %s", emp.Status)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/employees/"+ann+"/leave", nil),
+		http.StatusConflict, errors.CodeInvalidStatusTransition)
+
+	emp := changeStatus(t, ann, "terminate")
+	if emp.Status != models.StatusTerminated || len(emp.StatusHistory) != 2 {
+		t.Fatalf("expected a terminated employee with 2 transitions, got %s with %+v", emp.Status, emp.StatusHistory)
+	}
+	if last := emp.StatusHistory[1]; last.From != models.StatusOnLeave || last.To != models.StatusTerminated || last.At.IsZero() {
+		t.Errorf("unexpected transition %+v", last)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/employees/"+ann+"/leave", nil),
+		http.StatusConflict, errors.CodeInvalidStatusTransition)
+
+	// Terminated employees are left out of listings unless asked for.
+	if listed := getEmployees(t, domainURL); len(listed) != 1 || listed[0].Email != bob {
+		t.Errorf("expected only %s listed, got %+v", bob, listed)
+	}
+	terminated := getEmployees(t, testServer.URL+"/employees?criteria=byStatus&value=terminated&size=100")
+	if !containsEmployee(terminated, ann) || containsEmployee(terminated, bob) {
+		t.Errorf("expected %s among the terminated employees, got %+v", ann, terminated)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees?criteria=byStatus&value=retired", nil),
+		http.StatusBadRequest, errors.CodeInvalidCriteria)
+
+	// Replacing the employee keeps the status.
+	putResp := putEmployee(t, testServer.URL+"/employees/"+ann, newTestEmployee(ann, "Developer", "Writer"), "")
+	putResp.Body.Close()
+	if emp := changeStatus(t, ann, "reactivate"); emp.Status != models.StatusActive || len(emp.StatusHistory) != 3 {
+		t.Errorf("expected an active employee with 3 transitions, got %s with %+v", emp.Status, emp.StatusHistory)
+	}
+	if listed := getEmployees(t, domainURL); len(listed) != 2 {
+		t.Errorf("expected both employees listed after the reactivation, got %+v", listed)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/employees/nobody@lifecycle.example.com/terminate", nil),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+}
+
+// containsEmployee reports whether the list holds the employee with the given email.
+func containsEmployee(employees []models.EmployeeResponse, email string) bool {
+	for _, emp := range employees {
+		if emp.Email == email {
+			return true
+		}
+	}
+	return false
+}
@@ -13,31 +13,42 @@ import (
 	"testing"
 	"time"
 
+	"WebMVCEmployees/clients"
 	"WebMVCEmployees/config"
 	"WebMVCEmployees/controllers"
+	"WebMVCEmployees/docs"
+	"WebMVCEmployees/inflight"
+	"WebMVCEmployees/middleware"
 	"WebMVCEmployees/models"
 	"WebMVCEmployees/repository"
 	"WebMVCEmployees/router"
 	"WebMVCEmployees/services"
+	"WebMVCEmployees/slo"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
-
-	docker "github.com/docker/docker/client"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-// checkDocker pings the Docker daemon to verify it's running.
-func checkDocker() error {
-	cli, err := docker.NewClientWithOpts(docker.FromEnv, docker.WithAPIVersionNegotiation())
-	if err != nil {
-		return err
-	}
-	_, err = cli.Ping(context.Background())
-	return err
-}
-
 var testServer *httptest.Server
 
+// clientsDir holds the client bundles served by /admin/clients.
+var clientsDir string
+
+// testDocumentService lets tests shorten the lifetime of download links.
+var testDocumentService *services.DocumentService
+
+// testEmployeeController is shared by tests that start additional servers with other router options.
+var testEmployeeController *controllers.EmployeeController
+
+// testMongoClient and testMongoDB let tests open collections of their own; testMongoCollection names the employee
+// collection of the API under test.
+var testMongoClient *mongo.Client
+var testMongoDB string
+var testMongoCollection string
+
 // TestMain is executed before any tests run.
 func TestMain(m *testing.M) {
 	// Load environment variables from .env.test.
@@ -45,18 +56,15 @@ func TestMain(m *testing.M) {
 		log.Println("No .env.test file found, continuing with system environment variables")
 	}
 
-	// Validate that Docker is running.
-	if err := checkDocker(); err != nil {
-		log.Println("Docker does not appear to be running. Please ensure Docker is installed and started.")
-		os.Exit(1)
-	}
-
 	// Set Gin to test mode.
 	gin.SetMode(gin.TestMode)
 
-	// Start the MongoDB container using docker-compose if it's not running.
-	if err := config.StartContainers(); err != nil {
-		log.Fatal("Failed to start MongoDB container:", err)
+	// Start the MongoDB container through Docker unless it is running; one started here is removed after the tests.
+	containerCtx, containerCancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	mongoContainer, err := config.StartMongoContainer(containerCtx)
+	containerCancel()
+	if err != nil {
+		log.Fatal("Failed to start the MongoDB container: ", err)
 	}
 
 	// Retrieve MongoDB connection settings from environment variables.
@@ -74,11 +82,13 @@ func TestMain(m *testing.M) {
 	}
 
 	// Connect to MongoDB using our config method.
-	client, ctx, cancel, err := config.ConnectMongo(mongoURL)
+	inFlight := inflight.NewTracker()
+	client, _, cancel, err := config.ConnectMongo(config.MongoConfig{URL: mongoURL}, options.Client().SetMonitor(inFlight.CommandMonitor()))
 	if err != nil {
 		panic("failed to connect to mongo: " + err.Error())
 	}
 	defer cancel()
+	testMongoClient, testMongoDB, testMongoCollection = client, mongoDB, mongoCollection
 
 	// Initialize the EmployeeRepository.
 	repo, err := repository.NewEmployeeRepository(client, mongoDB, mongoCollection)
@@ -86,12 +96,127 @@ func TestMain(m *testing.M) {
 		log.Fatal("Failed to create employee repository:", err)
 	}
 
+	// Initialize the IdempotencyRepository used to replay retried create requests.
+	idempotencyRepo, err := repository.NewIdempotencyRepository(client, mongoDB, time.Hour)
+	if err != nil {
+		log.Fatal("Failed to create idempotency repository:", err)
+	}
+
 	// Create the EmployeeService using the repository.
 	empService := services.NewEmployeeService(repo)
+	empService.Hooks = testHooks()
+	auditRepo := repository.NewAuditRepository(client, mongoDB)
+	empService.Audit = auditRepo
+	departmentRepo := repository.NewDepartmentRepository(client, mongoDB)
+	empService.Departments = departmentRepo
+	roleRepo := repository.NewRoleRepository(client, mongoDB)
+	empService.RoleCatalog = roleRepo
+	titleRepo := repository.NewTitleRepository(client, mongoDB)
+	empService.Titles = titleRepo
+	locationRepo := repository.NewLocationRepository(client, mongoDB)
+	empService.Locations = locationRepo
 	empController := controllers.NewEmployeeController(empService)
+	testEmployeeController = empController
+
+	// Only admins may give employees roles through the API, so the admin the tests sign in as is created directly.
+	admin := newTestEmployee(testAdminEmail, middleware.AdminRole)
+	admin.Password = testAdminPassword
+	if _, err := empService.CreateEmployee(context.Background(), admin.ToEmployee()); err != nil {
+		log.Fatal("Failed to create the test admin:", err)
+	}
+
+	orgChartService := services.NewOrgChartService(repo, repository.NewOrgSnapshotRepository(client, mongoDB))
+	orgChartController := controllers.NewOrgChartController(orgChartService)
+	photoController := controllers.NewPhotoController(services.NewPhotoService(repo, repository.NewPhotoRepository(client, mongoDB)))
+	documentRepo, err := repository.NewDocumentRepository(client, mongoDB)
+	if err != nil {
+		log.Fatal("Failed to create document repository:", err)
+	}
+	testDocumentService = services.NewDocumentService(repo, documentRepo, []byte("test-secret"))
+	documentController := controllers.NewDocumentController(testDocumentService)
+	noteRepo, err := repository.NewNoteRepository(client, mongoDB)
+	if err != nil {
+		log.Fatal("Failed to create note repository:", err)
+	}
+	noteController := controllers.NewNoteController(services.NewNoteService(repo, noteRepo))
+	certificationRepo, err := repository.NewCertificationRepository(client, mongoDB)
+	if err != nil {
+		log.Fatal("Failed to create certification repository:", err)
+	}
+	certificationController := controllers.NewCertificationController(services.NewCertificationService(repo, certificationRepo))
+	departmentController := controllers.NewDepartmentController(services.NewDepartmentService(repo, departmentRepo))
+	compensationRepo, err := repository.NewCompensationRepository(client, mongoDB)
+	if err != nil {
+		log.Fatal("Failed to create compensation repository:", err)
+	}
+	compensationController := controllers.NewCompensationController(services.NewCompensationService(repo, compensationRepo))
+	roleController := controllers.NewRoleController(services.NewRoleService(roleRepo))
+	titleController := controllers.NewTitleController(services.NewTitleService(repo, titleRepo))
+	locationController := controllers.NewLocationController(services.NewLocationService(repo, locationRepo))
+	leaveRepo, err := repository.NewLeaveRepository(client, mongoDB)
+	if err != nil {
+		log.Fatal("Failed to create leave repository:", err)
+	}
+	leaveController := controllers.NewLeaveController(services.NewLeaveService(empService, leaveRepo))
+	attendanceRepo, err := repository.NewAttendanceRepository(client, mongoDB)
+	if err != nil {
+		log.Fatal("Failed to create attendance repository:", err)
+	}
+	attendanceController := controllers.NewAttendanceController(services.NewAttendanceService(repo, attendanceRepo))
+	reviewRepo, err := repository.NewReviewRepository(client, mongoDB)
+	if err != nil {
+		log.Fatal("Failed to create review repository:", err)
+	}
+	reviewService := services.NewReviewService(empService, reviewRepo)
+	reviewService.ReaderRoles = []string{"HR", "Admin"}
+	reviewController := controllers.NewReviewController(reviewService)
+	contactService := services.NewEmergencyContactService(repo, repository.NewEmergencyContactRepository(client, mongoDB))
+	contactService.AccessRoles = []string{"HR"}
+	contactController := controllers.NewEmergencyContactController(contactService)
+	teamRepo, err := repository.NewTeamRepository(client, mongoDB)
+	if err != nil {
+		log.Fatal("Failed to create team repository:", err)
+	}
+	teamController := controllers.NewTeamController(services.NewTeamService(repo, teamRepo))
+
+	// Track a single route objective so the SLO endpoint can be exercised.
+	sloTracker := slo.NewTracker([]slo.Objective{{
+		Route:              "GET /employees",
+		LatencyThreshold:   slo.Duration(time.Second),
+		LatencyTarget:      0.99,
+		AvailabilityTarget: 0.999,
+	}})
+	// Serve client bundles from a scratch directory the tests populate.
+	clientsDir, err = os.MkdirTemp("", "clients")
+	if err != nil {
+		log.Fatal("Failed to create client bundle directory:", err)
+	}
+	clientCatalog := clients.NewCatalog(clientsDir, docs.SwaggerInfo.ReadDoc)
+	adminController := controllers.NewAdminController(sloTracker, clientCatalog, auditRepo, inFlight, nil)
 
 	// Setup the router.
-	r := router.SetupRouter(empController)
+	r := router.SetupRouter(empController,
+		router.WithSLO(sloTracker),
+		router.WithInFlight(inFlight),
+		router.WithAdmin(adminController),
+		router.WithIdempotency(idempotencyRepo),
+		router.WithOrgChart(orgChartController),
+		router.WithPhotos(photoController),
+		router.WithDocuments(documentController),
+		router.WithNotes(noteController, middleware.RequireRoles(empService, "HR", "Admin")),
+		router.WithCertifications(certificationController),
+		router.WithDepartments(departmentController),
+		router.WithTeams(teamController),
+		router.WithRoles(roleController),
+		router.WithTitles(titleController),
+		router.WithLocations(locationController),
+		router.WithAttendance(attendanceController),
+		router.WithReviews(reviewController, middleware.RequireSignIn(empService)),
+		router.WithEmergencyContacts(contactController, middleware.RequireSignIn(empService)),
+		router.WithLeave(leaveController, middleware.RequireSignIn(empService)),
+		router.WithCompensation(compensationController, middleware.RequireRoles(empService, "HR", "Admin")),
+		router.WithCompression(middleware.DefaultCompressionConfig()),
+	)
 
 	// Launch the test server once for all tests.
 	testServer = httptest.NewServer(r)
@@ -101,15 +226,19 @@ func TestMain(m *testing.M) {
 
 	// Clean up the test server.
 	testServer.Close()
+	os.RemoveAll(clientsDir)
 
-	// Clean up the MongoDB database before disconnecting.
-	err = config.CleanMongoDB(client, mongoDB, ctx)
+	// Clean up the MongoDB database before disconnecting. The context of ConnectMongo has expired by now.
+	err = config.CleanMongoDB(client, mongoDB, context.Background())
 	if err != nil {
 		log.Printf("Error cleaning MongoDB: %v", err)
 	}
 
-	// Disconnect from MongoDB.
-	config.DisconnectMongo(client, ctx)
+	// Disconnect from MongoDB and stop the container if it was started above.
+	client.Disconnect(context.Background())
+	if err := mongoContainer.Stop(context.Background()); err != nil {
+		log.Printf("Error stopping the MongoDB container: %v", err)
+	}
 
 	// Exit with the proper code.
 	os.Exit(code)
@@ -117,7 +246,7 @@ func TestMain(m *testing.M) {
 
 func TestE2E_CreateEmployee(t *testing.T) {
 	// Create a new employee payload.
-	newEmployee := models.Employee{
+	newEmployee := models.NewEmployeeBoundary{
 		Email: "test2@example.com",
 		Name:  "Test User",
 		Birthdate: models.Birthdate{
@@ -130,7 +259,7 @@ func TestE2E_CreateEmployee(t *testing.T) {
 		Password: "Test1",
 	}
 	body, _ := json.Marshal(newEmployee)
-	resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("failed to send POST request: %v", err)
 	}
@@ -154,7 +283,7 @@ func TestE2E_CreateEmployee(t *testing.T) {
 
 func TestE2E_CreateEmployee_InvalidPassword(t *testing.T) {
 	// Invalid password: "aaa" does not meet the requirement.
-	newEmployee := models.Employee{
+	newEmployee := models.NewEmployeeBoundary{
 		Email: "invalidpassword@example.com",
 		Name:  "Invalid Password User",
 		Birthdate: models.Birthdate{
@@ -167,7 +296,7 @@ func TestE2E_CreateEmployee_InvalidPassword(t *testing.T) {
 		Password: "aaa",
 	}
 	body, _ := json.Marshal(newEmployee)
-	resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("failed to send POST request: %v", err)
 	}
@@ -182,7 +311,7 @@ func TestE2E_CreateEmployee_InvalidPassword(t *testing.T) {
 
 func TestE2E_CreateEmployee_InvalidBirthdate(t *testing.T) {
 	// Invalid birthdate: Day provided as "3" instead of "03".
-	newEmployee := models.Employee{
+	newEmployee := models.NewEmployeeBoundary{
 		Email: "invalidbirthday@example.com",
 		Name:  "Invalid Birthday User",
 		Birthdate: models.Birthdate{
@@ -195,7 +324,7 @@ func TestE2E_CreateEmployee_InvalidBirthdate(t *testing.T) {
 		Password: "Test1",
 	}
 	body, _ := json.Marshal(newEmployee)
-	resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("failed to send POST request: %v", err)
 	}
@@ -209,7 +338,7 @@ func TestE2E_CreateEmployee_InvalidBirthdate(t *testing.T) {
 }
 func TestE2E_CreateEmployee_PasswordTooShort(t *testing.T) {
 	// Invalid password: "T1" is only 2 characters.
-	newEmployee := models.Employee{
+	newEmployee := models.NewEmployeeBoundary{
 		Email: "passwordtooshort@example.com",
 		Name:  "Password Too Short",
 		Birthdate: models.Birthdate{
@@ -222,7 +351,7 @@ func TestE2E_CreateEmployee_PasswordTooShort(t *testing.T) {
 		Password: "T1",
 	}
 	body, _ := json.Marshal(newEmployee)
-	resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("failed to send POST request: %v", err)
 	}
@@ -237,7 +366,7 @@ func TestE2E_CreateEmployee_PasswordTooShort(t *testing.T) {
 
 func TestE2E_CreateEmployee_PasswordNoDigit(t *testing.T) {
 	// Invalid password: "Test" has no digit.
-	newEmployee := models.Employee{
+	newEmployee := models.NewEmployeeBoundary{
 		Email: "passwordnodigit@example.com",
 		Name:  "Password No Digit",
 		Birthdate: models.Birthdate{
@@ -250,7 +379,7 @@ func TestE2E_CreateEmployee_PasswordNoDigit(t *testing.T) {
 		Password: "Test",
 	}
 	body, _ := json.Marshal(newEmployee)
-	resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("failed to send POST request: %v", err)
 	}
@@ -265,7 +394,7 @@ func TestE2E_CreateEmployee_PasswordNoDigit(t *testing.T) {
 
 func TestE2E_CreateEmployee_PasswordNoUpperCase(t *testing.T) {
 	// Invalid password: "test1" has a digit but no uppercase letter.
-	newEmployee := models.Employee{
+	newEmployee := models.NewEmployeeBoundary{
 		Email: "passwordnouppercase@example.com",
 		Name:  "Password No UpperCase",
 		Birthdate: models.Birthdate{
@@ -278,7 +407,7 @@ func TestE2E_CreateEmployee_PasswordNoUpperCase(t *testing.T) {
 		Password: "test1",
 	}
 	body, _ := json.Marshal(newEmployee)
-	resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("failed to send POST request: %v", err)
 	}
@@ -293,7 +422,7 @@ func TestE2E_CreateEmployee_PasswordNoUpperCase(t *testing.T) {
 
 func TestE2E_GetEmployee_Success(t *testing.T) {
 	// First, create an employee using the POST endpoint.
-	newEmployee := models.Employee{
+	newEmployee := models.NewEmployeeBoundary{
 		Email: "loginSuccess@example.com",
 		Name:  "Login Success User",
 		Birthdate: models.Birthdate{
@@ -309,7 +438,7 @@ func TestE2E_GetEmployee_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to marshal employee: %v", err)
 	}
-	resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("failed to create employee: %v", err)
 	}
@@ -367,7 +496,7 @@ func TestE2E_GetEmployee_NotFound(t *testing.T) {
 }
 func TestGetEmployeeHandler_PasswordNotExposed(t *testing.T) {
 	// First, create an employee with a known password.
-	newEmployee := models.Employee{
+	newEmployee := models.NewEmployeeBoundary{
 		Email: "testpass@example.com",
 		Name:  "Test Password User",
 		Birthdate: models.Birthdate{
@@ -383,7 +512,7 @@ func TestGetEmployeeHandler_PasswordNotExposed(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to marshal employee: %v", err)
 	}
-	resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("Failed to create employee: %v", err)
 	}
@@ -427,7 +556,7 @@ func TestE2E_ListEmployees_Pagination(t *testing.T) {
 	// First, create 10 employees.
 	totalEmployees := 10
 	for i := 1; i <= totalEmployees; i++ {
-		emp := models.Employee{
+		emp := models.NewEmployeeBoundary{
 			Email: fmt.Sprintf("employee%d@example.com", i),
 			Name:  fmt.Sprintf("Employee %d", i),
 			Birthdate: models.Birthdate{
@@ -443,7 +572,7 @@ func TestE2E_ListEmployees_Pagination(t *testing.T) {
 		if err != nil {
 			t.Fatalf("failed to marshal employee %d: %v", i, err)
 		}
-		resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+		resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 		if err != nil {
 			t.Fatalf("failed to create employee %d: %v", i, err)
 		}
@@ -465,7 +594,7 @@ func TestE2E_ListEmployees_Pagination(t *testing.T) {
 		t.Fatalf("expected status 200 for page 1, got %d", resp.StatusCode)
 	}
 
-	var employees []models.EmployeeResponse
+	var employees []employeeBody
 	if err := json.NewDecoder(resp.Body).Decode(&employees); err != nil {
 		t.Fatalf("failed to decode page 1 response: %v", err)
 	}
@@ -512,7 +641,7 @@ func TestE2E_ListEmployees_Pagination(t *testing.T) {
 }
 func TestE2E_CreateEmployee_InvalidEmail(t *testing.T) {
 	// Create an employee with an invalid email (missing '@').
-	newEmployee := models.Employee{
+	newEmployee := models.NewEmployeeBoundary{
 		Email: "invalidemail", // invalid format
 		Name:  "Invalid Email User",
 		Birthdate: models.Birthdate{
@@ -528,7 +657,7 @@ func TestE2E_CreateEmployee_InvalidEmail(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to marshal employee: %v", err)
 	}
-	resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("failed to send POST request: %v", err)
 	}
@@ -544,7 +673,7 @@ func TestE2E_CreateEmployee_InvalidEmail(t *testing.T) {
 }
 func TestE2E_CreateEmployee_DuplicateEmail(t *testing.T) {
 	// Create a new employee payload.
-	duplicateEmployee := models.Employee{
+	duplicateEmployee := models.NewEmployeeBoundary{
 		Email: "duplicate@example.com",
 		Name:  "Duplicate Email User",
 		Birthdate: models.Birthdate{
@@ -561,7 +690,7 @@ func TestE2E_CreateEmployee_DuplicateEmail(t *testing.T) {
 		t.Fatalf("failed to marshal employee: %v", err)
 	}
 	// First attempt: should succeed.
-	resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("failed to send POST request for first employee: %v", err)
 	}
@@ -572,7 +701,7 @@ func TestE2E_CreateEmployee_DuplicateEmail(t *testing.T) {
 	}
 
 	// Second attempt: should fail with conflict.
-	resp2, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp2, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("failed to send POST request for duplicate employee: %v", err)
 	}
@@ -588,7 +717,7 @@ func TestE2E_CreateEmployee_DuplicateEmail(t *testing.T) {
 // TestE2E_ListEmployees_ByEmailDomain tests GET /employees?criteria=byEmailDomain&value={domain}&page={page}&size={size}
 func TestE2E_ListEmployees_ByEmailDomain(t *testing.T) {
 	// Create employees with different email domains.
-	employees := []models.Employee{
+	employees := []models.NewEmployeeBoundary{
 		{
 			Email: "alice@other1.com",
 			Name:  "Alice",
@@ -627,7 +756,7 @@ func TestE2E_ListEmployees_ByEmailDomain(t *testing.T) {
 	// Insert all employees.
 	for _, emp := range employees {
 		body, _ := json.Marshal(emp)
-		resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+		resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 		if err != nil {
 			t.Fatalf("failed to create employee %s: %v", emp.Email, err)
 		}
@@ -649,7 +778,7 @@ func TestE2E_ListEmployees_ByEmailDomain(t *testing.T) {
 		t.Fatalf("expected status 200, got %d", resp.StatusCode)
 	}
 
-	var results []models.EmployeeResponse
+	var results []employeeBody
 	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
@@ -672,7 +801,7 @@ func TestE2E_ListEmployees_ByEmailDomain(t *testing.T) {
 // TestE2E_ListEmployees_ByRole tests GET /employees?criteria=byRole&value={role}&page={page}&size={size}
 func TestE2E_ListEmployees_ByRole(t *testing.T) {
 	// Create employees with different roles.
-	employees := []models.Employee{
+	employees := []models.NewEmployeeBoundary{
 		{
 			Email: "dave@example.com",
 			Name:  "Dave",
@@ -711,7 +840,7 @@ func TestE2E_ListEmployees_ByRole(t *testing.T) {
 	// Insert all employees.
 	for _, emp := range employees {
 		body, _ := json.Marshal(emp)
-		resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+		resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 		if err != nil {
 			t.Fatalf("failed to create employee %s: %v", emp.Email, err)
 		}
@@ -733,7 +862,7 @@ func TestE2E_ListEmployees_ByRole(t *testing.T) {
 		t.Fatalf("expected status 200, got %d", resp.StatusCode)
 	}
 
-	var results []models.EmployeeResponse
+	var results []employeeBody
 	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
@@ -759,7 +888,7 @@ func TestE2E_ListEmployees_ByAge(t *testing.T) {
 
 	// --- Create Employee: Exactly 30 years old ---
 	// We choose January 1 so that the birthday has already passed this year.
-	emp30 := models.Employee{
+	emp30 := models.NewEmployeeBoundary{
 		Email: "age30@example.com",
 		Name:  "Age 30 User",
 		Birthdate: models.Birthdate{
@@ -774,7 +903,7 @@ func TestE2E_ListEmployees_ByAge(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to marshal employee age 30: %v", err)
 	}
-	resp30, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body30))
+	resp30, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body30))
 	if err != nil {
 		t.Fatalf("failed to create employee age 30: %v", err)
 	}
@@ -787,7 +916,7 @@ func TestE2E_ListEmployees_ByAge(t *testing.T) {
 	// To simulate an employee who is one day shy of turning 30,
 	// we set the birthday to tomorrow with a birth year such that the computed age is 29.
 	tomorrow := now.Add(24 * time.Hour)
-	emp29 := models.Employee{
+	emp29 := models.NewEmployeeBoundary{
 		Email: "age29@example.com",
 		Name:  "Age 29 User",
 		Birthdate: models.Birthdate{
@@ -802,7 +931,7 @@ func TestE2E_ListEmployees_ByAge(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to marshal employee age 29: %v", err)
 	}
-	resp29, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body29))
+	resp29, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body29))
 	if err != nil {
 		t.Fatalf("failed to create employee age 29: %v", err)
 	}
@@ -812,7 +941,7 @@ func TestE2E_ListEmployees_ByAge(t *testing.T) {
 	}
 
 	// --- Create Employee: Exactly 31 years old ---
-	emp31 := models.Employee{
+	emp31 := models.NewEmployeeBoundary{
 		Email: "age31@example.com",
 		Name:  "Age 31 User",
 		Birthdate: models.Birthdate{
@@ -827,7 +956,7 @@ func TestE2E_ListEmployees_ByAge(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to marshal employee age 31: %v", err)
 	}
-	resp31, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body31))
+	resp31, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body31))
 	if err != nil {
 		t.Fatalf("failed to create employee age 31: %v", err)
 	}
@@ -847,7 +976,7 @@ func TestE2E_ListEmployees_ByAge(t *testing.T) {
 		t.Fatalf("expected status 200 for age 30 search, got %d", resp.StatusCode)
 	}
 
-	var results []models.EmployeeResponse
+	var results []employeeBody
 	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
 		t.Fatalf("failed to decode response for age 30 search: %v", err)
 	}
@@ -869,6 +998,44 @@ func TestE2E_ListEmployees_ByAge(t *testing.T) {
 
 	t.Log("TestE2E_ListEmployees_ByAge passed: only the employee exactly 30 years old is returned")
 }
+
+func TestE2E_ListEmployees_ByAge_NoSuchDay(t *testing.T) {
+	// An employee written around the API, born on a day the calendar does not have, has no birth date stored.
+	now := time.Now().UTC()
+	birthdate := models.Birthdate{Day: "30", Month: "02", Year: fmt.Sprintf("%d", now.Year()-41)}
+	employees := testMongoClient.Database(testMongoDB).Collection(testMongoCollection)
+	email := "feb30@age.example.com"
+	if _, err := employees.InsertOne(context.Background(), bson.M{
+		"email": email, "name": "No Such Day", "password": "Test1", "birthdate": birthdate,
+	}); err != nil {
+		t.Fatalf("failed to insert the employee: %v", err)
+	}
+	t.Cleanup(func() { employees.DeleteOne(context.Background(), bson.M{"email": email}) })
+
+	// The age the parts would give, were 30/02 moved to 02/03 as MongoDB's $dateFromParts does.
+	rolledOver := time.Date(now.Year()-41, time.February, 30, 0, 0, 0, 0, time.UTC)
+	age := now.Year() - rolledOver.Year()
+	if now.YearDay() < rolledOver.YearDay() {
+		age--
+	}
+	resp, err := http.Get(fmt.Sprintf("%s/employees?criteria=byAge&value=%d&page=1&size=100", testServer.URL, age))
+	if err != nil {
+		t.Fatalf("failed to GET employees by age: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var results []employeeBody
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		t.Fatalf("failed to decode the employees: %v", err)
+	}
+	for _, emp := range results {
+		if emp.Email == email {
+			t.Errorf("expected an employee born on 30/02 to have no age, got %d", age)
+		}
+	}
+}
 func TestE2E_CreateEmployee_FutureBirthdate(t *testing.T) {
 	// Calculate a future birthdate (e.g., tomorrow's date).
 	futureDate := time.Now().Add(24 * time.Hour)
@@ -877,7 +1044,7 @@ func TestE2E_CreateEmployee_FutureBirthdate(t *testing.T) {
 	month := fmt.Sprintf("%02d", int(futureDate.Month()))
 	year := fmt.Sprintf("%d", futureDate.Year())
 
-	newEmployee := models.Employee{
+	newEmployee := models.NewEmployeeBoundary{
 		Email: "futurebirthday@example.com",
 		Name:  "Future Birthday User",
 		Birthdate: models.Birthdate{
@@ -894,7 +1061,7 @@ func TestE2E_CreateEmployee_FutureBirthdate(t *testing.T) {
 		t.Fatalf("failed to marshal employee: %v", err)
 	}
 
-	resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
 	if err != nil {
 		t.Fatalf("failed to send POST request: %v", err)
 	}
@@ -913,7 +1080,7 @@ func TestE2E_CreateEmployee_FutureBirthdate(t *testing.T) {
 // TestE2E_SetAndGetManager tests setting a manager for an employee and retrieving it.
 func TestE2E_SetAndGetManager(t *testing.T) {
 	// First, create an employee and a manager.
-	employee := models.Employee{
+	employee := models.NewEmployeeBoundary{
 		Email: "employeeM1@example.com",
 		Name:  "Employee One",
 		Birthdate: models.Birthdate{
@@ -924,7 +1091,7 @@ func TestE2E_SetAndGetManager(t *testing.T) {
 		Roles:    []string{"Developer"},
 		Password: "Test1",
 	}
-	manager := models.Employee{
+	manager := models.NewEmployeeBoundary{
 		Email: "manager1@example.com",
 		Name:  "Manager One",
 		Birthdate: models.Birthdate{
@@ -938,7 +1105,7 @@ func TestE2E_SetAndGetManager(t *testing.T) {
 
 	// Create employee.
 	bodyEmp, _ := json.Marshal(employee)
-	respEmp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyEmp))
+	respEmp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyEmp))
 	if err != nil {
 		t.Fatalf("failed to create employee: %v", err)
 	}
@@ -949,7 +1116,7 @@ func TestE2E_SetAndGetManager(t *testing.T) {
 
 	// Create manager.
 	bodyMgr, _ := json.Marshal(manager)
-	respMgr, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyMgr))
+	respMgr, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyMgr))
 	if err != nil {
 		t.Fatalf("failed to create manager: %v", err)
 	}
@@ -967,6 +1134,7 @@ func TestE2E_SetAndGetManager(t *testing.T) {
 		t.Fatalf("failed to create PUT request: %v", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(testAdminEmail, testAdminPassword)
 	client := &http.Client{}
 	putResp, err := client.Do(req)
 	if err != nil {
@@ -1002,7 +1170,7 @@ func TestE2E_SetAndGetManager(t *testing.T) {
 // TestE2E_GetSubordinates tests retrieving subordinates for a manager.
 func TestE2E_GetSubordinates(t *testing.T) {
 	// Create a manager.
-	manager := models.Employee{
+	manager := models.NewEmployeeBoundary{
 		Email: "manager2@example.com",
 		Name:  "Manager Two",
 		Birthdate: models.Birthdate{
@@ -1014,7 +1182,7 @@ func TestE2E_GetSubordinates(t *testing.T) {
 		Password: "Test1",
 	}
 	bodyMgr, _ := json.Marshal(manager)
-	respMgr, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyMgr))
+	respMgr, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyMgr))
 	if err != nil {
 		t.Fatalf("failed to create manager: %v", err)
 	}
@@ -1026,7 +1194,7 @@ func TestE2E_GetSubordinates(t *testing.T) {
 	// Create two employees and set their manager to the above manager.
 	subordinateEmails := []string{"sub1@example.com", "sub2@example.com"}
 	for _, email := range subordinateEmails {
-		emp := models.Employee{
+		emp := models.NewEmployeeBoundary{
 			Email: email,
 			Name:  "Subordinate " + email,
 			Birthdate: models.Birthdate{
@@ -1038,7 +1206,7 @@ func TestE2E_GetSubordinates(t *testing.T) {
 			Password: "Test1",
 		}
 		bodyEmp, _ := json.Marshal(emp)
-		resp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyEmp))
+		resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyEmp))
 		if err != nil {
 			t.Fatalf("failed to create subordinate %s: %v", email, err)
 		}
@@ -1056,6 +1224,7 @@ func TestE2E_GetSubordinates(t *testing.T) {
 			t.Fatalf("failed to create PUT request for subordinate %s: %v", email, err)
 		}
 		req.Header.Set("Content-Type", "application/json")
+		req.SetBasicAuth(testAdminEmail, testAdminPassword)
 		client := &http.Client{}
 		putResp, err := client.Do(req)
 		if err != nil {
@@ -1078,7 +1247,7 @@ func TestE2E_GetSubordinates(t *testing.T) {
 		t.Fatalf("expected status 200 for getting subordinates, got %d", getResp.StatusCode)
 	}
 
-	var subs []models.EmployeeResponse
+	var subs []employeeBody
 	if err := json.NewDecoder(getResp.Body).Decode(&subs); err != nil {
 		t.Fatalf("failed to decode subordinates response: %v", err)
 	}
@@ -1098,7 +1267,7 @@ func TestE2E_GetSubordinates(t *testing.T) {
 // TestE2E_DeleteManager tests disconnecting the manager relationship.
 func TestE2E_DeleteManager(t *testing.T) {
 	// Create an employee and a manager, then set the manager relationship.
-	employee := models.Employee{
+	employee := models.NewEmployeeBoundary{
 		Email: "employeeM2@example.com",
 		Name:  "Employee Two",
 		Birthdate: models.Birthdate{
@@ -1109,7 +1278,7 @@ func TestE2E_DeleteManager(t *testing.T) {
 		Roles:    []string{"Developer"},
 		Password: "Test1",
 	}
-	manager := models.Employee{
+	manager := models.NewEmployeeBoundary{
 		Email: "manager3@example.com",
 		Name:  "Manager Three",
 		Birthdate: models.Birthdate{
@@ -1123,7 +1292,7 @@ func TestE2E_DeleteManager(t *testing.T) {
 
 	// Create employee.
 	bodyEmp, _ := json.Marshal(employee)
-	respEmp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyEmp))
+	respEmp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyEmp))
 	if err != nil {
 		t.Fatalf("failed to create employee: %v", err)
 	}
@@ -1134,7 +1303,7 @@ func TestE2E_DeleteManager(t *testing.T) {
 
 	// Create manager.
 	bodyMgr, _ := json.Marshal(manager)
-	respMgr, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyMgr))
+	respMgr, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyMgr))
 	if err != nil {
 		t.Fatalf("failed to create manager: %v", err)
 	}
@@ -1152,6 +1321,7 @@ func TestE2E_DeleteManager(t *testing.T) {
 		t.Fatalf("failed to create PUT request: %v", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(testAdminEmail, testAdminPassword)
 	client := &http.Client{}
 	putResp, err := client.Do(req)
 	if err != nil {
@@ -1168,6 +1338,7 @@ func TestE2E_DeleteManager(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to create DELETE request: %v", err)
 	}
+	delReq.SetBasicAuth(testAdminEmail, testAdminPassword)
 	delResp, err := client.Do(delReq)
 	if err != nil {
 		t.Fatalf("failed to send DELETE request: %v", err)
@@ -1195,7 +1366,7 @@ func TestE2E_DeleteManager(t *testing.T) {
 // including any relationships (like manager settings).
 func TestE2E_DeleteAllEmployees(t *testing.T) {
 	// Create a new employee.
-	employee := models.Employee{
+	employee := models.NewEmployeeBoundary{
 		Email: "deleteTestEmployee@example.com",
 		Name:  "Delete Test Employee",
 		Birthdate: models.Birthdate{
@@ -1208,7 +1379,7 @@ func TestE2E_DeleteAllEmployees(t *testing.T) {
 	}
 
 	// Create a manager.
-	manager := models.Employee{
+	manager := models.NewEmployeeBoundary{
 		Email: "deleteTestManager@example.com",
 		Name:  "Delete Test Manager",
 		Birthdate: models.Birthdate{
@@ -1222,7 +1393,7 @@ func TestE2E_DeleteAllEmployees(t *testing.T) {
 
 	// Create employee.
 	bodyEmp, _ := json.Marshal(employee)
-	respEmp, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyEmp))
+	respEmp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyEmp))
 	if err != nil {
 		t.Fatalf("failed to create employee: %v", err)
 	}
@@ -1233,7 +1404,7 @@ func TestE2E_DeleteAllEmployees(t *testing.T) {
 
 	// Create manager.
 	bodyMgr, _ := json.Marshal(manager)
-	respMgr, err := http.Post(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyMgr))
+	respMgr, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(bodyMgr))
 	if err != nil {
 		t.Fatalf("failed to create manager: %v", err)
 	}
@@ -1251,6 +1422,7 @@ func TestE2E_DeleteAllEmployees(t *testing.T) {
 		t.Fatalf("failed to create PUT request for setting manager: %v", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(testAdminEmail, testAdminPassword)
 	client := &http.Client{}
 	putResp, err := client.Do(req)
 	if err != nil {
@@ -1288,4 +1460,9 @@ func TestE2E_DeleteAllEmployees(t *testing.T) {
 	} else {
 		t.Log("TestE2E_DeleteAllEmployees passed: employee no longer exists")
 	}
+
+	// The tests that follow still sign in as the test admin.
+	if _, err := testEmployeeController.Service.RestoreEmployee(context.Background(), testAdminEmail, time.Now().UTC()); err != nil {
+		t.Fatalf("failed to restore the test admin: %v", err)
+	}
 }
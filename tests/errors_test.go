@@ -0,0 +1,49 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// expectErrorCode fails the test unless the response has the given status and error code.
+func expectErrorCode(t *testing.T, resp *http.Response, status int, code string) {
+	t.Helper()
+	defer resp.Body.Close()
+	if resp.StatusCode != status {
+		t.Errorf("expected status %d, got %d", status, resp.StatusCode)
+	}
+	var errResp models.ErrorResponse
+	if err := decodeJSON(resp, &errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Code != code {
+		t.Errorf("expected error code %s, got %q (%s)", code, errResp.Code, errResp.Error)
+	}
+}
+
+func TestE2E_ErrorCodes(t *testing.T) {
+	emp := newTestEmployee("duplicate@codes.example.com", "Developer")
+	createEmployee(t, emp)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp),
+		http.StatusConflict, errors.CodeEmployeeDuplicateEmail)
+
+	future := newTestEmployee("future@codes.example.com", "Developer")
+	future.Birthdate.Year = "2999"
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", future),
+		http.StatusBadRequest, errors.CodeBirthdateInFuture)
+
+	resp, err := http.Get(testServer.URL + "/employees?page=0&size=10")
+	if err != nil {
+		t.Fatalf("failed to GET employees: %v", err)
+	}
+	expectErrorCode(t, resp, http.StatusBadRequest, errors.CodeInvalidPagination)
+
+	resp, err = http.Get(testServer.URL + "/employees/missing@codes.example.com/manager")
+	if err != nil {
+		t.Fatalf("failed to GET manager: %v", err)
+	}
+	expectErrorCode(t, resp, http.StatusNotFound, errors.CodeEmployeeNotFound)
+}
@@ -0,0 +1,72 @@
+package controllers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestE2E_GetEmployee_ETagNotModified(t *testing.T) {
+	emp := newTestEmployee("etag@etag.example.com", "Developer")
+	createEmployee(t, emp)
+
+	getURL := testServer.URL + "/employees/" + emp.Email + "?password=" + emp.Password
+	resp, err := http.Get(getURL)
+	if err != nil {
+		t.Fatalf("failed to send GET request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag header on GET /employees/{email}")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, getURL, nil)
+	req.Header.Set("If-None-Match", etag)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send conditional GET request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("expected status 304 for matching If-None-Match, got %d", resp.StatusCode)
+	}
+}
+
+func TestE2E_SetManager_IfMatchVersion(t *testing.T) {
+	manager := newTestEmployee("ifmatchmgr@etag.example.com", "Lead")
+	emp := newTestEmployee("ifmatch@etag.example.com", "Developer")
+	createEmployee(t, manager)
+	createEmployee(t, emp)
+
+	putURL := testServer.URL + "/employees/" + emp.Email + "/manager"
+	req, _ := http.NewRequest(http.MethodPut, putURL, strings.NewReader(`{"email":"`+manager.Email+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(testAdminEmail, testAdminPassword)
+	req.Header.Set("If-Match", `"1"`)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send PUT request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 for matching If-Match, got %d", resp.StatusCode)
+	}
+
+	// The employee is now at version 2, so a second update based on version 1 must fail.
+	req, _ = http.NewRequest(http.MethodPut, putURL, strings.NewReader(`{"email":"`+manager.Email+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(testAdminEmail, testAdminPassword)
+	req.Header.Set("If-Match", `"1"`)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send PUT request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("expected status 412 for stale If-Match, got %d", resp.StatusCode)
+	}
+}
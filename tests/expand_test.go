@@ -0,0 +1,73 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_ExpandManager(t *testing.T) {
+	manager := newTestEmployee("lead@expand.example.com", "Lead")
+	report := newTestEmployee("report@expand.example.com", "Developer")
+	createEmployee(t, manager)
+	createEmployee(t, report)
+	resp := doJSONAsAdmin(t, http.MethodPut, testServer.URL+"/employees/"+report.Email+"/manager", models.ManagerEmailBoundary{Email: manager.Email})
+	resp.Body.Close()
+
+	resp = doJSON(t, http.MethodGet, testServer.URL+"/employees/"+report.Email+"?password="+report.Password+"&expand=manager", nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var expanded models.ExpandedEmployee
+	if err := decodeJSON(resp, &expanded); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	if expanded.Manager == nil || expanded.Manager.Email != manager.Email || expanded.Manager.Name != manager.Name {
+		t.Fatalf("expected the manager to be embedded, got %+v", expanded.Manager)
+	}
+
+	// The entity tag covers the embedded manager.
+	etag := resp.Header.Get("ETag")
+	req, _ := http.NewRequest(http.MethodGet, testServer.URL+"/employees/"+report.Email+"?password="+report.Password+"&expand=manager", nil)
+	req.Header.Set("If-None-Match", etag)
+	cached, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send conditional GET: %v", err)
+	}
+	cached.Body.Close()
+	if cached.StatusCode != http.StatusNotModified {
+		t.Errorf("expected status 304 for a matching ETag, got %d", cached.StatusCode)
+	}
+
+	list := doJSON(t, http.MethodGet, testServer.URL+"/employees?criteria=byEmailDomain&value=expand.example.com&expand=manager", nil)
+	defer list.Body.Close()
+	var employees []models.ExpandedEmployee
+	if err := decodeJSON(list, &employees); err != nil {
+		t.Fatalf("failed to decode employees: %v", err)
+	}
+	if len(employees) != 2 {
+		t.Fatalf("expected 2 employees, got %d", len(employees))
+	}
+	for _, emp := range employees {
+		switch emp.Email {
+		case manager.Email:
+			if emp.Manager != nil {
+				t.Errorf("expected no manager for %s, got %+v", emp.Email, emp.Manager)
+			}
+		case report.Email:
+			if emp.Manager == nil || emp.Manager.Email != manager.Email {
+				t.Errorf("expected the manager of %s to be embedded, got %+v", emp.Email, emp.Manager)
+			}
+		}
+	}
+}
+
+func TestE2E_ExpandInvalid(t *testing.T) {
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees?expand=manager,teams", nil),
+		http.StatusBadRequest, errors.CodeInvalidQuery)
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/someone@expand.example.com?password=Test1&expand=boss", nil),
+		http.StatusBadRequest, errors.CodeInvalidQuery)
+}
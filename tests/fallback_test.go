@@ -0,0 +1,31 @@
+package controllers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/errors"
+)
+
+func TestE2E_UnknownRoute(t *testing.T) {
+	resp, err := http.Get(testServer.URL + "/no/such/route")
+	if err != nil {
+		t.Fatalf("failed to send GET request: %v", err)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("expected a JSON error, got Content-Type %q", ct)
+	}
+	expectErrorCode(t, resp, http.StatusNotFound, errors.CodeRouteNotFound)
+}
+
+func TestE2E_MethodNotAllowed(t *testing.T) {
+	resp := doJSON(t, http.MethodPatch, testServer.URL+"/employees", nil)
+	allow := resp.Header.Get("Allow")
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete} {
+		if !strings.Contains(allow, method) {
+			t.Errorf("expected Allow to list %s, got %q", method, allow)
+		}
+	}
+	expectErrorCode(t, resp, http.StatusMethodNotAllowed, errors.CodeMethodNotAllowed)
+}
@@ -0,0 +1,60 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/controllers"
+	"WebMVCEmployees/health"
+	"WebMVCEmployees/router"
+)
+
+func TestE2E_Health_ReadinessFailsWithItsDependencies(t *testing.T) {
+	checker := health.NewChecker(100 * time.Millisecond)
+	checker.Add("mongo", func(ctx context.Context) error { return testMongoClient.Ping(ctx, nil) })
+	server := httptest.NewServer(router.SetupRouter(testEmployeeController, router.WithHealth(controllers.NewHealthController(checker))))
+	defer server.Close()
+
+	getReport := func(path string) (int, health.Report) {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("failed to GET %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		var report health.Report
+		json.NewDecoder(resp.Body).Decode(&report)
+		return resp.StatusCode, report
+	}
+
+	status, report := getReport("/readyz")
+	if status != http.StatusOK || !report.Ready() || len(report.Checks) != 1 {
+		t.Fatalf("expected status 200 with the mongo check passing, got %d with %+v", status, report)
+	}
+
+	// A dependency hanging fails the probe once the timeout expires.
+	checker.Add("hanging", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	checker.Add("broken", func(context.Context) error { return stderrors.New("connection refused") })
+	status, report = getReport("/readyz")
+	if status != http.StatusServiceUnavailable || report.Status != health.StatusFailing {
+		t.Fatalf("expected status 503 with failing checks, got %d with %+v", status, report)
+	}
+	for i, want := range []string{health.StatusOK, health.StatusFailing, health.StatusFailing} {
+		if report.Checks[i].Status != want {
+			t.Errorf("expected the check %s to be %s, got %+v", report.Checks[i].Name, want, report.Checks[i])
+		}
+	}
+
+	status, report = getReport("/healthz")
+	if status != http.StatusOK || report.Status != health.StatusOK {
+		t.Errorf("expected the liveness probe to pass whatever the dependencies, got %d with %+v", status, report)
+	}
+}
@@ -0,0 +1,117 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/models"
+)
+
+// The admin the tests sign in as to give employees roles. TestMain creates it through the service, since only admins
+// may do so through the API.
+const (
+	testAdminEmail    = "admin@tests.example.com"
+	testAdminPassword = "Admin1"
+)
+
+// newTestEmployee returns a valid employee payload with the given email and roles.
+func newTestEmployee(email string, roles ...string) models.NewEmployeeBoundary {
+	return models.NewEmployeeBoundary{
+		Email: email,
+		Name:  "Test " + email,
+		Birthdate: models.Birthdate{
+			Day:   "01",
+			Month: "01",
+			Year:  "1990",
+		},
+		Roles:    roles,
+		Password: "Test1",
+	}
+}
+
+// employeeBody is an employee response decoded together with any password the server would leak.
+type employeeBody struct {
+	models.EmployeeResponse
+	Password string `json:"password" xml:"password"`
+}
+
+// createEmployee posts the employee as the test admin and fails the test unless it was created.
+func createEmployee(t *testing.T, emp models.NewEmployeeBoundary) {
+	t.Helper()
+	body, _ := json.Marshal(emp)
+	resp, err := postAsAdmin(testServer.URL+"/employees", "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("failed to create employee %s: %v", emp.Email, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("failed to create employee %s, status: %d", emp.Email, resp.StatusCode)
+	}
+}
+
+// doJSON sends a request with an optional JSON body and returns the response.
+func doJSON(t *testing.T, method, url string, payload interface{}) *http.Response {
+	t.Helper()
+	return doJSONAs(t, method, url, payload, "", "")
+}
+
+// doJSONAsAdmin is doJSON signed in as the test admin.
+func doJSONAsAdmin(t *testing.T, method, url string, payload interface{}) *http.Response {
+	t.Helper()
+	return doJSONAs(t, method, url, payload, testAdminEmail, testAdminPassword)
+}
+
+// doJSONAs is doJSON signed in with the Basic credentials of an employee; an empty email sends none.
+func doJSONAs(t *testing.T, method, url string, payload interface{}, email, password string) *http.Response {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatalf("failed to encode payload: %v", err)
+		}
+	}
+	req, err := http.NewRequest(method, url, &body)
+	if err != nil {
+		t.Fatalf("failed to create %s request: %v", method, err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if email != "" {
+		req.SetBasicAuth(email, password)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send %s request: %v", method, err)
+	}
+	return resp
+}
+
+// postAsAdmin is http.Post signed in as the test admin.
+func postAsAdmin(url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.SetBasicAuth(testAdminEmail, testAdminPassword)
+	return http.DefaultClient.Do(req)
+}
+
+// getAsAdmin is http.Get signed in as the test admin.
+func getAsAdmin(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(testAdminEmail, testAdminPassword)
+	return http.DefaultClient.Do(req)
+}
+
+// decodeJSON decodes the response body into v.
+func decodeJSON(resp *http.Response, v interface{}) error {
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,51 @@
+package controllers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/hooks"
+	"WebMVCEmployees/models"
+)
+
+// testHooks returns the hook registry used by the test server: it rejects employees
+// from the blocked.example.com domain and tags employees from hooks.example.com.
+func testHooks() *hooks.Registry {
+	r := hooks.NewRegistry()
+	r.OnBeforeCreate(func(ctx context.Context, emp *models.Employee) error {
+		if strings.HasSuffix(emp.Email, "@blocked.example.com") {
+			return fmt.Errorf("employees from blocked.example.com are not allowed")
+		}
+		if strings.HasSuffix(emp.Email, "@hooks.example.com") {
+			emp.Roles = append(emp.Roles, "HookTagged")
+		}
+		return nil
+	})
+	return r
+}
+
+func TestE2E_Hooks_BeforeCreateRejects(t *testing.T) {
+	resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", newTestEmployee("someone@blocked.example.com", "Developer"))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status 400 from the rejecting hook, got %d", resp.StatusCode)
+	}
+}
+
+func TestE2E_Hooks_BeforeCreateEnriches(t *testing.T) {
+	createEmployee(t, newTestEmployee("someone@hooks.example.com", "Developer"))
+
+	example := map[string]interface{}{"email": "someone@hooks.example.com", "roles": []string{"HookTagged"}}
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/employees/query?page=1&size=10", example)
+	defer resp.Body.Close()
+	var results []models.EmployeeResponse
+	if err := decodeJSON(resp, &results); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("expected the hook to add the HookTagged role, got %+v", results)
+	}
+}
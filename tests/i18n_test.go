@@ -0,0 +1,59 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// getErrorIn requests a missing employee with the given Accept-Language header and returns the error response.
+func getErrorIn(t *testing.T, acceptLanguage string) (models.ErrorResponse, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, testServer.URL+"/employees/nobody@i18n.example.com?password=Test1", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept-Language", acceptLanguage)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", resp.StatusCode)
+	}
+	var errResp models.ErrorResponse
+	if err := decodeJSON(resp, &errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	return errResp, resp.Header.Get("Content-Language")
+}
+
+func TestE2E_LocalizedErrors(t *testing.T) {
+	errResp, lang := getErrorIn(t, "he-IL,en;q=0.8")
+	if lang != "he" || errResp.Error != errors.DefaultCatalog["he"][errors.CodeEmployeeNotFound] {
+		t.Errorf("expected the Hebrew message, got %q in %q", errResp.Error, lang)
+	}
+	if errResp.Code != errors.CodeEmployeeNotFound {
+		t.Errorf("expected the code to stay %s, got %s", errors.CodeEmployeeNotFound, errResp.Code)
+	}
+
+	// Languages without a translation fall back to English.
+	errResp, lang = getErrorIn(t, "fr")
+	if lang != "en" || errResp.Error != "employee not found" {
+		t.Errorf("expected the English message, got %q in %q", errResp.Error, lang)
+	}
+}
+
+func TestE2E_LocalizedErrors_CustomTranslator(t *testing.T) {
+	previous := errors.Translations
+	errors.Translations = errors.Catalog{"fr": {errors.CodeEmployeeNotFound: "employé introuvable"}}
+	defer func() { errors.Translations = previous }()
+
+	errResp, lang := getErrorIn(t, "fr-CA")
+	if lang != "fr" || errResp.Error != "employé introuvable" {
+		t.Errorf("expected the French message, got %q in %q", errResp.Error, lang)
+	}
+}
@@ -0,0 +1,47 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestE2E_CreateEmployee_IdempotencyKeyReplay(t *testing.T) {
+	emp := newTestEmployee("idempotent@idem.example.com", "Developer")
+	body, _ := json.Marshal(emp)
+
+	send := func(payload []byte) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, testServer.URL+"/employees", bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "create-idempotent-employee")
+		req.SetBasicAuth(testAdminEmail, testAdminPassword)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to send POST request: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	first := send(body)
+	if first.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 for the first request, got %d", first.StatusCode)
+	}
+
+	// A retry must replay the original response instead of reporting a duplicate email.
+	retry := send(body)
+	if retry.StatusCode != http.StatusOK {
+		t.Fatalf("expected the replayed status 200, got %d", retry.StatusCode)
+	}
+	if retry.Header.Get("Idempotent-Replayed") != "true" {
+		t.Error("expected the Idempotent-Replayed header on the retry")
+	}
+
+	// Reusing the key with another payload is rejected.
+	emp.Name = "Someone Else"
+	other, _ := json.Marshal(emp)
+	if resp := send(other); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422 for a reused key, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,155 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// decodeLeaveRequest decodes the leave request of the response, failing the test unless it has the status.
+func decodeLeaveRequest(t *testing.T, resp *http.Response, status int) models.LeaveRequest {
+	t.Helper()
+	defer resp.Body.Close()
+	if resp.StatusCode != status {
+		t.Fatalf("expected status %d, got %d", status, resp.StatusCode)
+	}
+	var leave models.LeaveRequest
+	if err := decodeJSON(resp, &leave); err != nil {
+		t.Fatalf("failed to decode leave request: %v", err)
+	}
+	return leave
+}
+
+// expectLeaveBalance checks the used, pending and remaining days of the employee's balance for the year.
+func expectLeaveBalance(t *testing.T, email, year string, used, pending, remaining int) {
+	t.Helper()
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/"+email+"/leave-balances/"+year, nil)
+	defer resp.Body.Close()
+	var balance models.LeaveBalance
+	if err := decodeJSON(resp, &balance); err != nil {
+		t.Fatalf("failed to decode leave balance: %v", err)
+	}
+	if balance.Used != used || balance.Pending != pending || balance.Remaining != remaining {
+		t.Errorf("expected %d used, %d pending and %d remaining days, got %+v", used, pending, remaining, balance)
+	}
+}
+
+func TestE2E_LeaveRequests(t *testing.T) {
+	boss, dev, peer := "boss@leave.example.com", "dev@leave.example.com", "peer@leave.example.com"
+	createEmployee(t, newTestEmployee(boss, "Manager"))
+	createEmployee(t, newTestEmployee(dev, "Developer"))
+	createEmployee(t, newTestEmployee(peer, "Developer"))
+	setManager(t, dev, boss)
+	password := newTestEmployee(dev).Password
+	submitURL := testServer.URL + "/employees/" + dev + "/leave-requests"
+	week := models.LeaveRequestBoundary{StartDate: "2030-03-04", EndDate: "2030-03-10", Reason: "Vacation"}
+
+	// Requests are submitted by the signed-in employee only.
+	expectErrorCode(t, doJSON(t, http.MethodPost, submitURL, week), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, submitURL, week, peer, password), http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, testServer.URL+"/employees/"+boss+"/leave-requests", week, boss, password),
+		http.StatusNotFound, errors.CodeManagerNotSet)
+
+	// The weekend is not charged.
+	vacation := decodeLeaveRequest(t, doJSONAs(t, http.MethodPost, submitURL, week, dev, password), http.StatusCreated)
+	if vacation.Status != models.LeavePending || vacation.Days != 5 || vacation.Year != 2030 || vacation.Approver != boss {
+		t.Errorf("unexpected leave request %+v", vacation)
+	}
+	expectLeaveBalance(t, dev, "2030", 0, 5, 15)
+
+	for _, req := range []models.LeaveRequestBoundary{
+		{StartDate: "2030-03-08", EndDate: "2030-03-04"},
+		{StartDate: "2030-12-30", EndDate: "2031-01-03"},
+		{StartDate: "2030-03-09", EndDate: "2030-03-10"},
+	} {
+		expectErrorCode(t, doJSONAs(t, http.MethodPost, submitURL, req, dev, password), http.StatusBadRequest, errors.CodeInvalidLeaveDates)
+	}
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, submitURL, models.LeaveRequestBoundary{StartDate: "2030-03-08", EndDate: "2030-03-11"}, dev, password),
+		http.StatusConflict, errors.CodeLeaveOverlap)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, submitURL, models.LeaveRequestBoundary{StartDate: "2030-04-01", EndDate: "2030-05-31"}, dev, password),
+		http.StatusConflict, errors.CodeLeaveBalanceExceeded)
+
+	// Only the manager decides.
+	approveURL := testServer.URL + "/leave-requests/" + vacation.ID + "/approve"
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, approveURL, nil, dev, password), http.StatusForbidden, errors.CodeForbidden)
+	approved := decodeLeaveRequest(t, doJSONAs(t, http.MethodPost, approveURL, models.LeaveDecisionBoundary{Comment: "Enjoy!"}, boss, password), http.StatusOK)
+	if approved.Status != models.LeaveApproved || approved.Comment != "Enjoy!" || approved.DecidedAt == nil {
+		t.Errorf("unexpected approved request %+v", approved)
+	}
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, approveURL, nil, boss, password), http.StatusConflict, errors.CodeLeaveNotPending)
+	expectLeaveBalance(t, dev, "2030", 5, 0, 15)
+
+	// Rejected and cancelled requests release their days.
+	dayOff := decodeLeaveRequest(t, doJSONAs(t, http.MethodPost, submitURL,
+		models.LeaveRequestBoundary{StartDate: "2030-03-11", EndDate: "2030-03-12"}, dev, password), http.StatusCreated)
+	rejected := decodeLeaveRequest(t, doJSONAs(t, http.MethodPost, testServer.URL+"/leave-requests/"+dayOff.ID+"/reject", nil, boss, password), http.StatusOK)
+	if rejected.Status != models.LeaveRejected {
+		t.Errorf("expected the request to be rejected, got %+v", rejected)
+	}
+	dayOff = decodeLeaveRequest(t, doJSONAs(t, http.MethodPost, submitURL,
+		models.LeaveRequestBoundary{StartDate: "2030-03-11", EndDate: "2030-03-12"}, dev, password), http.StatusCreated)
+	expectLeaveBalance(t, dev, "2030", 5, 2, 13)
+	cancelURL := testServer.URL + "/leave-requests/" + dayOff.ID + "/cancel"
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, cancelURL, nil, boss, password), http.StatusForbidden, errors.CodeForbidden)
+	decodeLeaveRequest(t, doJSONAs(t, http.MethodPost, cancelURL, nil, dev, password), http.StatusOK)
+	expectLeaveBalance(t, dev, "2030", 5, 0, 15)
+	expectLeaveBalance(t, dev, "2031", 0, 0, 20)
+
+	resp := doJSON(t, http.MethodGet, submitURL, nil)
+	defer resp.Body.Close()
+	var requests []models.LeaveRequest
+	if err := decodeJSON(resp, &requests); err != nil {
+		t.Fatalf("failed to decode leave requests: %v", err)
+	}
+	if len(requests) != 3 {
+		t.Errorf("expected 3 leave requests, got %+v", requests)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/leave-requests/missing", nil), http.StatusNotFound, errors.CodeLeaveRequestNotFound)
+}
+
+func TestE2E_LeaveRequests_ApproverOnlyChangedByTheManagerOrAnAdmin(t *testing.T) {
+	boss, dev, accomplice := "boss@leave-lines.example.com", "dev@leave-lines.example.com", "accomplice@leave-lines.example.com"
+	createEmployee(t, newTestEmployee(boss, "Manager"))
+	createEmployee(t, newTestEmployee(dev, "Developer"))
+	createEmployee(t, newTestEmployee(accomplice, "Developer"))
+	setManager(t, dev, boss)
+	password := newTestEmployee(dev).Password
+	managerURL := testServer.URL + "/employees/" + dev + "/manager"
+	toAccomplice := models.ManagerEmailBoundary{Email: accomplice}
+
+	// Neither the employee nor anyone else but the manager can make an accomplice their approver.
+	expectErrorCode(t, doJSON(t, http.MethodPut, managerURL, toAccomplice), http.StatusUnauthorized, errors.CodeUnauthorized)
+	for _, caller := range []string{dev, accomplice} {
+		expectErrorCode(t, doJSONAs(t, http.MethodPut, managerURL, toAccomplice, caller, password), http.StatusForbidden, errors.CodeForbidden)
+		expectErrorCode(t, doJSONAs(t, http.MethodDelete, managerURL, nil, caller, password), http.StatusForbidden, errors.CodeForbidden)
+		expectErrorCode(t, doJSONAs(t, http.MethodPost, testServer.URL+"/employees/"+dev+"/reporting-lines",
+			models.ReportingLine{Manager: accomplice, Type: models.ReportingPrimary}, caller, password), http.StatusForbidden, errors.CodeForbidden)
+		expectErrorCode(t, doJSONAs(t, http.MethodPut, testServer.URL+"/employees/"+boss+"/delegation",
+			models.Delegation{Delegate: accomplice, StartsAt: time.Now().UTC(), EndsAt: time.Now().UTC().Add(time.Hour)}, caller, password),
+			http.StatusForbidden, errors.CodeForbidden)
+	}
+	replacement := newTestEmployee(dev, "Developer")
+	replacement.Manager = &accomplice
+	expectErrorCode(t, doJSONAs(t, http.MethodPut, testServer.URL+"/employees/"+dev, replacement, dev, password),
+		http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, testServer.URL+"/employees/manager/reassign",
+		models.ManagerReassignment{From: boss, To: accomplice}, accomplice, password), http.StatusForbidden, errors.CodeForbidden)
+	expectManagers(t, dev, boss)
+
+	leave := decodeLeaveRequest(t, doJSONAs(t, http.MethodPost, testServer.URL+"/employees/"+dev+"/leave-requests",
+		models.LeaveRequestBoundary{StartDate: "2030-06-03", EndDate: "2030-06-07"}, dev, password), http.StatusCreated)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, testServer.URL+"/leave-requests/"+leave.ID+"/approve", nil, accomplice, password),
+		http.StatusForbidden, errors.CodeForbidden)
+
+	// The manager hands the employee over, and is no longer the one deciding.
+	resp := doJSONAs(t, http.MethodPut, managerURL, toAccomplice, boss, password)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the manager to hand the employee over, got %d", resp.StatusCode)
+	}
+	expectErrorCode(t, doJSONAs(t, http.MethodDelete, managerURL, nil, boss, password), http.StatusForbidden, errors.CodeForbidden)
+	expectManagers(t, dev, accomplice)
+}
@@ -0,0 +1,88 @@
+package controllers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_ListQuery_Defaults(t *testing.T) {
+	createEmployee(t, newTestEmployee("alice@defaults.example.com", "Developer"))
+	createEmployee(t, newTestEmployee("bob@defaults.example.com", "Developer"))
+
+	// Without page and size, the first page of 10 is returned.
+	resp, err := http.Get(testServer.URL + "/employees?criteria=byEmailDomain&value=defaults.example.com")
+	if err != nil {
+		t.Fatalf("failed to GET employees: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var employees []models.EmployeeResponse
+	if err := decodeJSON(resp, &employees); err != nil {
+		t.Fatalf("failed to decode employees: %v", err)
+	}
+	if len(employees) != 2 {
+		t.Errorf("expected 2 employees, got %d", len(employees))
+	}
+}
+
+func TestE2E_ListQuery_Sort(t *testing.T) {
+	for _, name := range []string{"Ann", "Zoe", "Max"} {
+		emp := newTestEmployee(strings.ToLower(name)+"@sorted.example.com", "Developer")
+		emp.Name = name
+		createEmployee(t, emp)
+	}
+
+	resp, err := http.Get(testServer.URL + "/employees?criteria=byEmailDomain&value=sorted.example.com&sort=-name")
+	if err != nil {
+		t.Fatalf("failed to GET employees: %v", err)
+	}
+	defer resp.Body.Close()
+	var employees []models.EmployeeResponse
+	if err := decodeJSON(resp, &employees); err != nil {
+		t.Fatalf("failed to decode employees: %v", err)
+	}
+	var names []string
+	for _, emp := range employees {
+		names = append(names, emp.Name)
+	}
+	if strings.Join(names, ",") != "Zoe,Max,Ann" {
+		t.Errorf("expected employees sorted by descending name, got %v", names)
+	}
+}
+
+func TestE2E_ListQuery_AggregatedErrors(t *testing.T) {
+	resp, err := http.Get(testServer.URL + "/employees?page=0&size=abc&sort=salary&criteria=byAge&value=old")
+	if err != nil {
+		t.Fatalf("failed to GET employees: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", resp.StatusCode)
+	}
+	var errResp models.ErrorResponse
+	if err := decodeJSON(resp, &errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Code != errors.CodeInvalidQuery {
+		t.Errorf("expected code %s, got %s", errors.CodeInvalidQuery, errResp.Code)
+	}
+	for _, param := range []string{"page", "size", "sort", "age"} {
+		if !strings.Contains(errResp.Error, param) {
+			t.Errorf("expected the error to mention %s, got %q", param, errResp.Error)
+		}
+	}
+}
+
+func TestE2E_ListQuery_SubordinatesSharePagination(t *testing.T) {
+	resp, err := http.Get(testServer.URL + "/employees/nobody@defaults.example.com/subordinates?size=0")
+	if err != nil {
+		t.Fatalf("failed to GET subordinates: %v", err)
+	}
+	expectErrorCode(t, resp, http.StatusBadRequest, errors.CodeInvalidPagination)
+}
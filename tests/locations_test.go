@@ -0,0 +1,80 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_Locations(t *testing.T) {
+	office := models.Location{
+		Name:     "Locations HQ",
+		Address:  models.Address{Street: "Mivtza Kadesh 38", City: "Tel Aviv", Country: "IL"},
+		Timezone: "Asia/Jerusalem",
+	}
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/locations", office)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201 creating the location, got %d", resp.StatusCode)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/locations", office), http.StatusConflict, errors.CodeLocationExists)
+	for _, invalid := range []models.Location{
+		{Name: "Locations Nowhere", Address: office.Address, Timezone: "Mars/Olympus"},
+		{Name: "Locations Nowhere", Address: office.Address},
+	} {
+		expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/locations", invalid), http.StatusBadRequest, errors.CodeInvalidTimezone)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/locations",
+		models.Location{Name: " ", Address: office.Address, Timezone: "Asia/Jerusalem"}), http.StatusBadRequest, errors.CodeInvalidPayload)
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/locations",
+		models.Location{Name: "Locations Nowhere", Address: models.Address{City: "Tel Aviv", Country: "Israel"}, Timezone: "Asia/Jerusalem"}),
+		http.StatusBadRequest, errors.CodeInvalidAddress)
+	locationURL := testServer.URL + "/locations/" + url.PathEscape(office.Name)
+
+	// The address and time zone can change, but not the name.
+	moved := office
+	moved.Name, moved.Address.City, moved.Timezone = "", "Haifa", "Asia/Tel_Aviv"
+	resp = doJSON(t, http.MethodPut, locationURL, moved)
+	defer resp.Body.Close()
+	var updated models.Location
+	if err := decodeJSON(resp, &updated); err != nil {
+		t.Fatalf("failed to decode location: %v", err)
+	}
+	if updated.Name != office.Name || updated.Address.City != "Haifa" || updated.Timezone != "Asia/Tel_Aviv" {
+		t.Errorf("unexpected updated location %+v", updated)
+	}
+	moved.Name = "Locations Branch"
+	expectErrorCode(t, doJSON(t, http.MethodPut, locationURL, moved), http.StatusBadRequest, errors.CodeLocationNameChange)
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/locations/Locations%20Branch", nil),
+		http.StatusNotFound, errors.CodeLocationNotFound)
+
+	// Employees may only work from existing locations, and are listed by them.
+	emp := newTestEmployee("dev@locations.example.com", "Developer")
+	emp.Location = "Locations Branch"
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeLocationNotFound)
+	emp.Location = office.Name
+	createEmployee(t, emp)
+	createEmployee(t, newTestEmployee("remote@locations.example.com", "Developer"))
+
+	seated := getEmployees(t, testServer.URL+"/employees?criteria=byLocation&value="+url.QueryEscape(office.Name))
+	if len(seated) != 1 || seated[0].Email != emp.Email || seated[0].Location != office.Name {
+		t.Errorf("expected only %s at %s, got %+v", emp.Email, office.Name, seated)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees?criteria=byLocation", nil),
+		http.StatusBadRequest, errors.CodeMissingParameter)
+
+	// A location employees work from is kept.
+	expectErrorCode(t, doJSON(t, http.MethodDelete, locationURL, nil), http.StatusConflict, errors.CodeLocationInUse)
+	emp.Location = ""
+	putResp := putEmployee(t, testServer.URL+"/employees/"+emp.Email, emp, "")
+	putResp.Body.Close()
+	resp = doJSON(t, http.MethodDelete, locationURL, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 deleting the location, got %d", resp.StatusCode)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, locationURL, nil), http.StatusNotFound, errors.CodeLocationNotFound)
+}
@@ -0,0 +1,53 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"WebMVCEmployees/controllers"
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/router"
+)
+
+func TestE2E_LogLevel_ChangesAtRuntime(t *testing.T) {
+	admin := controllers.NewAdminController(nil, nil, nil, nil, nil)
+	admin.LogLevel = new(slog.LevelVar)
+	server := httptest.NewServer(router.SetupRouter(testEmployeeController, router.WithAdmin(admin)))
+	defer server.Close()
+
+	resp := doJSONAsAdmin(t, http.MethodPut, server.URL+"/admin/loglevel", models.LogLevel{Level: "debug"})
+	var level models.LogLevel
+	json.NewDecoder(resp.Body).Decode(&level)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || level.Level != "debug" {
+		t.Fatalf("expected status 200 with the debug level, got %d with %q", resp.StatusCode, level.Level)
+	}
+	if admin.LogLevel.Level() != slog.LevelDebug {
+		t.Errorf("expected the debug level to be set, got %s", admin.LogLevel.Level())
+	}
+
+	resp, err := getAsAdmin(server.URL + "/admin/loglevel")
+	if err != nil {
+		t.Fatalf("failed to GET the log level: %v", err)
+	}
+	json.NewDecoder(resp.Body).Decode(&level)
+	resp.Body.Close()
+	if level.Level != "debug" {
+		t.Errorf("expected the debug level to be reported, got %q", level.Level)
+	}
+
+	resp = doJSONAsAdmin(t, http.MethodPut, server.URL+"/admin/loglevel", models.LogLevel{Level: "loud"})
+	var errResp models.ErrorResponse
+	json.NewDecoder(resp.Body).Decode(&errResp)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest || errResp.Code != errors.CodeInvalidLogLevel {
+		t.Errorf("expected status 400 with %s for an unknown level, got %d with %s", errors.CodeInvalidLogLevel, resp.StatusCode, errResp.Code)
+	}
+	if admin.LogLevel.Level() != slog.LevelDebug {
+		t.Errorf("expected the level left unchanged, got %s", admin.LogLevel.Level())
+	}
+}
@@ -0,0 +1,53 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_MultiGet_PreservesOrderAndMarksMisses(t *testing.T) {
+	createEmployee(t, newTestEmployee("first@multiget.example.com", "Developer"))
+	createEmployee(t, newTestEmployee("second@multiget.example.com", "Lead"))
+
+	emails := []string{"second@multiget.example.com", "missing@multiget.example.com", "first@multiget.example.com"}
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/employees/query", map[string]interface{}{"emails": emails})
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var lookups []struct {
+		models.EmployeeLookup
+		Employee *employeeBody `json:"employee"`
+	}
+	if err := decodeJSON(resp, &lookups); err != nil {
+		t.Fatalf("failed to decode lookups: %v", err)
+	}
+	if len(lookups) != len(emails) {
+		t.Fatalf("expected %d results, got %d", len(emails), len(lookups))
+	}
+	for i, email := range emails {
+		if lookups[i].Email != email {
+			t.Errorf("result %d: expected email %s, got %s", i, email, lookups[i].Email)
+		}
+	}
+	if !lookups[0].Found || lookups[0].Employee == nil || lookups[0].Employee.Roles[0] != "Lead" {
+		t.Errorf("expected the second employee first, got %+v", lookups[0])
+	}
+	if lookups[1].Found || lookups[1].Employee != nil {
+		t.Errorf("expected the unknown email to be marked as not found, got %+v", lookups[1])
+	}
+	if lookups[2].Employee == nil || lookups[2].Employee.Password != "" {
+		t.Errorf("expected the first employee without password, got %+v", lookups[2])
+	}
+}
+
+func TestE2E_MultiGet_RejectsExampleFields(t *testing.T) {
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/employees/query", map[string]interface{}{
+		"emails": []string{"first@multiget.example.com"},
+		"name":   "First",
+	})
+	expectErrorCode(t, resp, http.StatusBadRequest, errors.CodeInvalidCriteria)
+}
@@ -0,0 +1,39 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+)
+
+func TestE2E_ManagerChain(t *testing.T) {
+	ceo, vp, lead, dev := "ceo@chain.example.com", "vp@chain.example.com", "lead@chain.example.com", "dev@chain.example.com"
+	for _, email := range []string{ceo, vp, lead, dev} {
+		createEmployee(t, newTestEmployee(email, "Developer"))
+	}
+	setManager(t, vp, ceo)
+	setManager(t, lead, vp)
+	setManager(t, dev, lead)
+
+	expectChain := func(url string, want ...string) {
+		t.Helper()
+		chain := getEmployees(t, url)
+		if len(chain) != len(want) {
+			t.Fatalf("expected %d managers, got %d", len(want), len(chain))
+		}
+		for i, email := range want {
+			if chain[i].Email != email {
+				t.Errorf("manager %d: expected %s, got %s", i, email, chain[i].Email)
+			}
+		}
+	}
+	expectChain(testServer.URL+"/employees/"+dev+"/managers", lead, vp, ceo)
+	expectChain(testServer.URL+"/employees/"+dev+"/managers?depth=2", lead, vp)
+	expectChain(testServer.URL + "/employees/" + ceo + "/managers")
+
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/"+dev+"/managers?depth=0", nil),
+		http.StatusBadRequest, errors.CodeInvalidQuery)
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/missing@chain.example.com/managers", nil),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+}
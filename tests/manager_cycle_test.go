@@ -0,0 +1,83 @@
+package controllers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// setManager makes manager the manager of employee and fails the test unless it succeeded.
+func setManager(t *testing.T, employee, manager string) {
+	t.Helper()
+	resp := doJSONAsAdmin(t, http.MethodPut, testServer.URL+"/employees/"+employee+"/manager", models.ManagerEmailBoundary{Email: manager})
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("failed to set the manager of %s, status: %d", employee, resp.StatusCode)
+	}
+}
+
+func TestE2E_ManagerCycle_Rejected(t *testing.T) {
+	a, b, c := "a@cycle.example.com", "b@cycle.example.com", "c@cycle.example.com"
+	for _, email := range []string{a, b, c} {
+		createEmployee(t, newTestEmployee(email, "Developer"))
+	}
+	setManager(t, b, a)
+	setManager(t, c, b)
+
+	resp := doJSONAsAdmin(t, http.MethodPut, testServer.URL+"/employees/"+a+"/manager", models.ManagerEmailBoundary{Email: c})
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", resp.StatusCode)
+	}
+	var errResp models.ErrorResponse
+	if err := decodeJSON(resp, &errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Code != errors.CodeManagerCycle {
+		t.Errorf("expected error code %s, got %s", errors.CodeManagerCycle, errResp.Code)
+	}
+	if want := strings.Join([]string{a, c, b, a}, " -> "); !strings.HasSuffix(errResp.Error, want) {
+		t.Errorf("expected the cycle %q in the message, got %q", want, errResp.Error)
+	}
+
+	// Replacing the employee cannot introduce the cycle either.
+	replacement := newTestEmployee(a, "Developer")
+	replacement.Manager = &b
+	expectErrorCode(t, putEmployee(t, testServer.URL+"/employees/"+a, replacement, ""), http.StatusConflict, errors.CodeManagerCycle)
+}
+
+// employeeVersion returns the current version of an employee.
+func employeeVersion(t *testing.T, emp models.NewEmployeeBoundary) int64 {
+	t.Helper()
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/"+emp.Email+"?password="+emp.Password, nil)
+	defer resp.Body.Close()
+	var current models.EmployeeResponse
+	if err := decodeJSON(resp, &current); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	return current.Version
+}
+
+func TestE2E_SetManager_SelfAndNoOp(t *testing.T) {
+	emp, manager := newTestEmployee("emp@noop.example.com", "Developer"), newTestEmployee("boss@noop.example.com", "Developer")
+	createEmployee(t, emp)
+	createEmployee(t, manager)
+	url := testServer.URL + "/employees/" + emp.Email + "/manager"
+
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPut, url, models.ManagerEmailBoundary{Email: emp.Email}),
+		http.StatusBadRequest, errors.CodeManagerSelf)
+	self := newTestEmployee(emp.Email, "Developer")
+	self.Manager = &self.Email
+	expectErrorCode(t, putEmployee(t, testServer.URL+"/employees/"+emp.Email, self, ""), http.StatusBadRequest, errors.CodeManagerSelf)
+
+	setManager(t, emp.Email, manager.Email)
+	version := employeeVersion(t, emp)
+	// Assigning the same manager again succeeds without changing the employee.
+	setManager(t, emp.Email, manager.Email)
+	if got := employeeVersion(t, emp); got != version {
+		t.Errorf("expected version %d after a no-op update, got %d", version, got)
+	}
+}
@@ -0,0 +1,51 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_ManagerRoles_Enforced(t *testing.T) {
+	boss, dev, peer := "boss@manager-roles.example.com", "dev@manager-roles.example.com", "peer@manager-roles.example.com"
+	createEmployee(t, newTestEmployee(boss, "Manager"))
+	createEmployee(t, newTestEmployee(dev, "Developer"))
+	createEmployee(t, newTestEmployee(peer, "Developer"))
+
+	service := testEmployeeController.Service
+	service.ManagerRoles = []string{"Manager", "Director"}
+	defer func() { service.ManagerRoles = nil }()
+
+	url := testServer.URL + "/employees/" + peer + "/manager"
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPut, url, models.ManagerEmailBoundary{Email: dev}),
+		http.StatusUnprocessableEntity, errors.CodeManagerRoleMissing)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/"+peer+"/reporting-lines",
+		models.ReportingLine{Manager: dev, Type: models.ReportingPrimary}), http.StatusUnprocessableEntity, errors.CodeManagerRoleMissing)
+	expectManagers(t, peer)
+
+	// Creating an employee under a manager without the role is rejected as well.
+	intern := newTestEmployee("intern@manager-roles.example.com", "Intern")
+	intern.Manager = &dev
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", intern),
+		http.StatusUnprocessableEntity, errors.CodeManagerRoleMissing)
+
+	// Dotted lines need no manager role.
+	resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/"+peer+"/reporting-lines",
+		models.ReportingLine{Manager: dev, Type: models.ReportingDotted})
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 adding a dotted line, got %d", resp.StatusCode)
+	}
+
+	setManager(t, dev, boss)
+	intern.Manager = &boss
+	createEmployee(t, intern)
+	expectManagers(t, intern.Email, boss)
+
+	// Without a policy any employee can manage.
+	service.ManagerRoles = nil
+	setManager(t, peer, dev)
+	expectManagers(t, peer, dev, boss)
+}
@@ -0,0 +1,66 @@
+package controllers_test
+
+import (
+	"encoding/xml"
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_Metadata(t *testing.T) {
+	emp := newTestEmployee("badge@metadata.example.com", "Developer")
+	emp.Metadata = models.Metadata{"badgeNumber": "B-17", "parkingSpot": "P2"}
+	createEmployee(t, emp)
+	createEmployee(t, newTestEmployee("plain@metadata.example.com", "Developer"))
+
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/"+emp.Email+"?password="+emp.Password, nil)
+	defer resp.Body.Close()
+	var fetched models.EmployeeResponse
+	if err := decodeJSON(resp, &fetched); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	if fetched.Metadata["badgeNumber"] != "B-17" || fetched.Metadata["parkingSpot"] != "P2" {
+		t.Errorf("expected the metadata to be stored, got %v", fetched.Metadata)
+	}
+
+	// Metadata entries can be used as example criteria.
+	query := doJSON(t, http.MethodPost, testServer.URL+"/employees/query",
+		models.NewEmployeeBoundary{Metadata: models.Metadata{"parkingSpot": "P2"}})
+	defer query.Body.Close()
+	var matches []models.EmployeeResponse
+	if err := decodeJSON(query, &matches); err != nil {
+		t.Fatalf("failed to decode employees: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Email != emp.Email {
+		t.Errorf("expected only %s to match, got %+v", emp.Email, matches)
+	}
+
+	xmlResp := doXML(t, http.MethodGet, testServer.URL+"/employees/"+emp.Email+"?password="+emp.Password, "")
+	defer xmlResp.Body.Close()
+	var fromXML models.EmployeeResponse
+	if err := xml.NewDecoder(xmlResp.Body).Decode(&fromXML); err != nil {
+		t.Fatalf("failed to decode XML employee: %v", err)
+	}
+	if fromXML.Metadata["badgeNumber"] != "B-17" {
+		t.Errorf("expected the metadata in XML, got %v", fromXML.Metadata)
+	}
+}
+
+func TestE2E_Metadata_Validation(t *testing.T) {
+	emp := newTestEmployee("invalid@metadata.example.com", "Developer")
+	emp.Metadata = models.Metadata{"badge.number": "B-1"}
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeInvalidMetadata)
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/employees/query", models.NewEmployeeBoundary{Metadata: models.Metadata{"$where": "1"}}),
+		http.StatusBadRequest, errors.CodeInvalidMetadata)
+
+	// Organizations can restrict the keys employees may carry.
+	service := testEmployeeController.Service
+	service.MetadataKeys = []string{"badgeNumber"}
+	defer func() { service.MetadataKeys = nil }()
+	emp.Metadata = models.Metadata{"shoeSize": "42"}
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeInvalidMetadata)
+	emp.Metadata = models.Metadata{"badgeNumber": "B-2"}
+	createEmployee(t, emp)
+}
@@ -0,0 +1,151 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// newTestMigrator returns a migrator recording the migrations in a collection of its own, dropped after the test.
+func newTestMigrator(t *testing.T, migrations ...repository.MongoMigration) *repository.MongoMigrator {
+	db := testMongoClient.Database(testMongoDB)
+	migrator := &repository.MongoMigrator{
+		Employees:  db.Collection("migrations_test_employees"),
+		Collection: db.Collection("migrations_test"),
+		Migrations: migrations,
+	}
+	t.Cleanup(func() { migrator.Collection.Drop(context.Background()) })
+	return migrator
+}
+
+// countingMigration returns a migration counting its runs in runs.
+func countingMigration(version string, runs *atomic.Int32) repository.MongoMigration {
+	return repository.MongoMigration{Version: version, Up: func(ctx context.Context, employees *mongo.Collection) error {
+		runs.Add(1)
+		return nil
+	}}
+}
+
+func TestMigrationsApplyOnce(t *testing.T) {
+	var first, second atomic.Int32
+	migrator := newTestMigrator(t, countingMigration("0001_first", &first), countingMigration("0002_second", &second))
+	ctx := context.Background()
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil || !reflect.DeepEqual(pending, []string{"0001_first", "0002_second"}) {
+		t.Fatalf("expected both migrations pending, got %v (%v)", pending, err)
+	}
+	applied, err := migrator.Up(ctx)
+	if err != nil || !reflect.DeepEqual(applied, []string{"0001_first", "0002_second"}) {
+		t.Fatalf("expected both migrations applied, got %v (%v)", applied, err)
+	}
+	applied, err = migrator.Up(ctx)
+	if err != nil || len(applied) != 0 {
+		t.Fatalf("expected no migration applied again, got %v (%v)", applied, err)
+	}
+	if first.Load() != 1 || second.Load() != 1 {
+		t.Fatalf("expected each migration to run once, got %d and %d", first.Load(), second.Load())
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("failed to read the status: %v", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt.IsZero() {
+			t.Fatalf("expected %s to be recorded as applied", status.Version)
+		}
+	}
+}
+
+func TestMigrationsStopAtFailure(t *testing.T) {
+	var runs atomic.Int32
+	failing := errors.New("backfill failed")
+	broken := repository.MongoMigration{Version: "0002_broken", Up: func(ctx context.Context, employees *mongo.Collection) error {
+		return failing
+	}}
+	migrator := newTestMigrator(t, countingMigration("0001_first", &runs), broken, countingMigration("0003_third", &runs))
+	ctx := context.Background()
+
+	applied, err := migrator.Up(ctx)
+	if !errors.Is(err, failing) || !reflect.DeepEqual(applied, []string{"0001_first"}) {
+		t.Fatalf("expected the first migration applied before the failure, got %v (%v)", applied, err)
+	}
+	pending, err := migrator.Pending(ctx)
+	if err != nil || !reflect.DeepEqual(pending, []string{"0002_broken", "0003_third"}) {
+		t.Fatalf("expected the failed migration and the next one pending, got %v (%v)", pending, err)
+	}
+
+	// Once fixed, the failed migration and those after it apply.
+	migrator.Migrations[1] = countingMigration("0002_broken", &runs)
+	applied, err = migrator.Up(ctx)
+	if err != nil || !reflect.DeepEqual(applied, []string{"0002_broken", "0003_third"}) {
+		t.Fatalf("expected the remaining migrations applied, got %v (%v)", applied, err)
+	}
+}
+
+func TestMigrationsConcurrentInstances(t *testing.T) {
+	var runs atomic.Int32
+	first := newTestMigrator(t, countingMigration("0001_first", &runs), countingMigration("0002_second", &runs))
+	second := *first
+
+	var wg sync.WaitGroup
+	for _, migrator := range []*repository.MongoMigrator{first, &second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := migrator.Up(context.Background()); err != nil {
+				t.Errorf("failed to migrate: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if runs.Load() != 2 {
+		t.Fatalf("expected each migration to run once across instances, got %d runs", runs.Load())
+	}
+}
+
+func TestBirthDateMigration(t *testing.T) {
+	db := testMongoClient.Database(testMongoDB)
+	migrator := repository.NewMongoMigrator(db.Collection("birth_date_migration_employees"))
+	migrator.Collection = db.Collection("birth_date_migrations")
+	t.Cleanup(func() {
+		migrator.Employees.Drop(context.Background())
+		migrator.Collection.Drop(context.Background())
+	})
+	ctx := context.Background()
+
+	// Employees written before birth dates were stored, one of them with a birthdate the calendar does not have.
+	if _, err := migrator.Employees.InsertMany(ctx, []bson.M{
+		{"email": "old@m.example.com", "birthdate": bson.M{"day": "29", "month": "02", "year": "1992"}},
+		{"email": "nonsense@m.example.com", "birthdate": bson.M{"day": "31", "month": "02", "year": "1990"}},
+		{"email": "numeric@m.example.com", "birthdate": bson.M{"day": 1, "month": 2, "year": 1990}},
+	}); err != nil {
+		t.Fatalf("failed to insert the employees: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	var migrated models.Employee
+	if err := migrator.Employees.FindOne(ctx, bson.M{"email": "old@m.example.com"}).Decode(&migrated); err != nil {
+		t.Fatalf("failed to read the migrated employee: %v", err)
+	}
+	if want := time.Date(1992, time.February, 29, 0, 0, 0, 0, time.UTC); !migrated.BirthDate.Equal(want) {
+		t.Fatalf("expected the birth date %v, got %v", want, migrated.BirthDate)
+	}
+	n, err := migrator.Employees.CountDocuments(ctx, bson.M{models.EmployeeRef.BirthDate: bson.M{"$exists": true}})
+	if err != nil || n != 1 {
+		t.Fatalf("expected only the readable birthdate migrated, got %d (%v)", n, err)
+	}
+}
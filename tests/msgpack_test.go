@@ -0,0 +1,54 @@
+package controllers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/models"
+
+	"github.com/ugorji/go/codec"
+)
+
+func TestE2E_MessagePack_ListEmployees(t *testing.T) {
+	createEmployee(t, newTestEmployee("packed@msgpack.example.com", "Developer"))
+
+	req, err := http.NewRequest(http.MethodGet, testServer.URL+"/employees?criteria=byEmailDomain&value=msgpack.example.com&page=1&size=10", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", "application/msgpack")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to GET employees: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/msgpack") {
+		t.Fatalf("expected a MessagePack response, got %q", ct)
+	}
+
+	var employees []models.EmployeeResponse
+	if err := codec.NewDecoder(resp.Body, &codec.MsgpackHandle{}).Decode(&employees); err != nil {
+		t.Fatalf("failed to decode MessagePack response: %v", err)
+	}
+	if len(employees) != 1 || employees[0].Email != "packed@msgpack.example.com" || employees[0].Roles[0] != "Developer" {
+		t.Errorf("unexpected employees %+v", employees)
+	}
+}
+
+func TestE2E_MessagePack_NotOfferedForSingleResources(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, testServer.URL+"/admin/inflight", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", "application/msgpack")
+	req.SetBasicAuth(testAdminEmail, testAdminPassword)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to GET /admin/inflight: %v", err)
+	}
+	resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("expected a JSON fallback, got %q", ct)
+	}
+}
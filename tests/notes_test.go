@@ -0,0 +1,102 @@
+package controllers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// addNote posts a note signed in as the test admin and fails the test unless it was created.
+func addNote(t *testing.T, email, text string) models.Note {
+	t.Helper()
+	resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/"+email+"/notes", models.NoteRequest{Text: text})
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201 adding a note, got %d", resp.StatusCode)
+	}
+	var note models.Note
+	if err := decodeJSON(resp, &note); err != nil {
+		t.Fatalf("failed to decode note: %v", err)
+	}
+	return note
+}
+
+// listNotes returns a page of the employee's notes.
+func listNotes(t *testing.T, email, query string) []models.Note {
+	t.Helper()
+	resp := doJSONAsAdmin(t, http.MethodGet, testServer.URL+"/employees/"+email+"/notes"+query, nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 listing notes, got %d", resp.StatusCode)
+	}
+	var notes []models.Note
+	if err := decodeJSON(resp, &notes); err != nil {
+		t.Fatalf("failed to decode notes: %v", err)
+	}
+	return notes
+}
+
+func TestE2E_Notes(t *testing.T) {
+	emp := newTestEmployee("noted@notes.example.com", "Developer")
+	createEmployee(t, emp)
+	hr := newTestEmployee("hr@notes.example.com", "HR")
+	createEmployee(t, hr)
+	url := testServer.URL + "/employees/" + emp.Email + "/notes"
+
+	// Only HR and admins read and write notes.
+	expectErrorCode(t, doJSON(t, http.MethodPost, url, models.NoteRequest{Text: "Anonymous"}), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSON(t, http.MethodGet, url, nil), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, url, models.NoteRequest{Text: "About myself"}, emp.Email, emp.Password),
+		http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSONAs(t, http.MethodGet, url, nil, emp.Email, emp.Password), http.StatusForbidden, errors.CodeForbidden)
+
+	// The signed-in caller is recorded as the author.
+	resp := doJSONAs(t, http.MethodPost, testServer.URL+"/employees/"+hr.Email+"/notes", models.NoteRequest{Text: "Onboarded"},
+		hr.Email, hr.Password)
+	defer resp.Body.Close()
+	var onboarded models.Note
+	if err := decodeJSON(resp, &onboarded); err != nil || onboarded.Author != hr.Email {
+		t.Fatalf("expected a note written by %s, got %+v (%v)", hr.Email, onboarded, err)
+	}
+	first := addNote(t, emp.Email, "First review")
+	if first.ID == "" || first.Author != testAdminEmail || first.Employee != emp.Email || first.CreatedAt.IsZero() {
+		t.Errorf("unexpected note %+v", first)
+	}
+	second := addNote(t, emp.Email, "Second review")
+
+	notes := listNotes(t, emp.Email, "")
+	if len(notes) != 2 || notes[0].ID != second.ID || notes[1].ID != first.ID {
+		t.Fatalf("expected both notes newest first, got %+v", notes)
+	}
+	if page := listNotes(t, emp.Email, "?page=2&size=1"); len(page) != 1 || page[0].ID != first.ID {
+		t.Errorf("expected the first note on page 2, got %+v", page)
+	}
+
+	expectErrorCode(t, doJSON(t, http.MethodDelete, url+"/"+first.ID, nil), http.StatusUnauthorized, errors.CodeUnauthorized)
+	resp = doJSONAsAdmin(t, http.MethodDelete, url+"/"+first.ID, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 deleting a note, got %d", resp.StatusCode)
+	}
+	if notes := listNotes(t, emp.Email, ""); len(notes) != 1 || notes[0].ID != second.ID {
+		t.Errorf("expected only the second note to remain, got %+v", notes)
+	}
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodDelete, url+"/"+first.ID, nil), http.StatusNotFound, errors.CodeNoteNotFound)
+}
+
+func TestE2E_Notes_Validation(t *testing.T) {
+	emp := newTestEmployee("checked@notes.example.com", "Developer")
+	createEmployee(t, emp)
+	url := testServer.URL + "/employees/" + emp.Email + "/notes"
+
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, url, models.NoteRequest{Text: "  "}),
+		http.StatusBadRequest, errors.CodeNoteRequiredFields)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, url, models.NoteRequest{Text: strings.Repeat("x", 10001)}),
+		http.StatusBadRequest, errors.CodeNoteTooLong)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/nobody@notes.example.com/notes",
+		models.NoteRequest{Text: "Hello"}), http.StatusNotFound, errors.CodeEmployeeNotFound)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodGet, url+"?page=0", nil), http.StatusBadRequest, errors.CodeInvalidPagination)
+}
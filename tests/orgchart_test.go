@@ -0,0 +1,171 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// diffOrgChart posts a diff request and decodes the result.
+func diffOrgChart(t *testing.T, req models.OrgDiffRequest) models.OrgDiff {
+	t.Helper()
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/orgchart/diff", req)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 for diff, got %d", resp.StatusCode)
+	}
+	var diff models.OrgDiff
+	if err := decodeJSON(resp, &diff); err != nil {
+		t.Fatalf("failed to decode diff: %v", err)
+	}
+	return diff
+}
+
+func containsNode(nodes []models.OrgNode, email string) bool {
+	for _, node := range nodes {
+		if node.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+func TestE2E_OrgChartDiff_SnapshotAgainstCurrent(t *testing.T) {
+	boss := "boss@orgchart.example.com"
+	moved := "moved@orgchart.example.com"
+	createEmployee(t, newTestEmployee(boss, "Lead"))
+	createEmployee(t, newTestEmployee(moved, "Developer"))
+
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/orgchart/snapshots", models.OrgSnapshotRequest{Label: "before reorg"})
+	var snapshot models.OrgSnapshot
+	if err := decodeJSON(resp, &snapshot); err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || snapshot.ID == "" {
+		t.Fatalf("expected a created snapshot, got %d %+v", resp.StatusCode, snapshot)
+	}
+
+	resp = doJSONAsAdmin(t, http.MethodPut, testServer.URL+"/employees/"+moved+"/manager", models.ManagerEmailBoundary{Email: boss})
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("failed to set manager, status: %d", resp.StatusCode)
+	}
+	added := "added@orgchart.example.com"
+	createEmployee(t, newTestEmployee(added, "Developer"))
+
+	diff := diffOrgChart(t, models.OrgDiffRequest{From: snapshot.ID, To: models.CurrentSnapshotID})
+	if !containsNode(diff.Added, added) {
+		t.Errorf("expected %s to be added, got %+v", added, diff.Added)
+	}
+	var found bool
+	for _, move := range diff.Moved {
+		if move.Email == moved {
+			found = move.FromManager == nil && move.ToManager != nil && *move.ToManager == boss
+		}
+	}
+	if !found {
+		t.Errorf("expected %s to move to %s, got %+v", moved, boss, diff.Moved)
+	}
+	found = false
+	for _, manager := range diff.NewManagers {
+		found = found || manager == boss
+	}
+	if !found {
+		t.Errorf("expected %s to be a new manager, got %v", boss, diff.NewManagers)
+	}
+}
+
+func TestE2E_OrgChartDiff_UploadedSnapshot(t *testing.T) {
+	kept := "kept@orgdiff.example.com"
+	removed := "removed@orgdiff.example.com"
+	createEmployee(t, newTestEmployee(kept, "Developer"))
+	createEmployee(t, newTestEmployee(removed, "Developer"))
+
+	planned := models.OrgSnapshot{Nodes: []models.OrgNode{{Email: kept, Name: "Kept"}}}
+	diff := diffOrgChart(t, models.OrgDiffRequest{From: models.CurrentSnapshotID, Snapshot: &planned})
+	if !containsNode(diff.Removed, removed) || containsNode(diff.Removed, kept) {
+		t.Errorf("expected only %s to be removed among the test employees, got %+v", removed, diff.Removed)
+	}
+
+	// The same snapshot can be uploaded as a file.
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("from", models.CurrentSnapshotID)
+	file, _ := form.CreateFormFile("snapshot", "reorg.json")
+	json.NewEncoder(file).Encode(planned)
+	form.Close()
+	resp, err := http.Post(testServer.URL+"/orgchart/diff", form.FormDataContentType(), &body)
+	if err != nil {
+		t.Fatalf("failed to upload snapshot: %v", err)
+	}
+	defer resp.Body.Close()
+	var fileDiff models.OrgDiff
+	if err := decodeJSON(resp, &fileDiff); err != nil {
+		t.Fatalf("failed to decode diff: %v", err)
+	}
+	if !containsNode(fileDiff.Removed, removed) {
+		t.Errorf("expected %s to be removed, got %+v", removed, fileDiff.Removed)
+	}
+}
+
+func TestE2E_OrgChartDiff_InvalidRequests(t *testing.T) {
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/orgchart/diff", models.OrgDiffRequest{From: "missing", To: models.CurrentSnapshotID})
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404 for an unknown snapshot, got %d", resp.StatusCode)
+	}
+
+	resp = doJSON(t, http.MethodPost, testServer.URL+"/orgchart/diff", models.OrgDiffRequest{From: models.CurrentSnapshotID})
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status 400 without a second snapshot, got %d", resp.StatusCode)
+	}
+}
+
+// exportOrgChart returns the diagram and media type of GET /orgchart/export with the given query.
+func exportOrgChart(t *testing.T, query string) (string, string) {
+	t.Helper()
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/orgchart/export"+query, nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 exporting the org chart, got %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read diagram: %v", err)
+	}
+	return string(body), resp.Header.Get("Content-Type")
+}
+
+func TestE2E_OrgChartExport(t *testing.T) {
+	boss, dev := "boss@export.example.com", "dev@export.example.com"
+	createEmployee(t, newTestEmployee(boss, "Developer"))
+	createEmployee(t, newTestEmployee(dev, "Developer"))
+	setManager(t, dev, boss)
+
+	dot, contentType := exportOrgChart(t, "")
+	if !strings.HasPrefix(contentType, "text/vnd.graphviz") {
+		t.Errorf("expected a Graphviz media type, got %s", contentType)
+	}
+	for _, want := range []string{"digraph orgchart {", `"` + dev + `" [label="Test ` + dev + `\n` + dev + `"];`, `"` + boss + `" -> "` + dev + `";`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("expected the DOT output to contain %s, got:\n%s", want, dot)
+		}
+	}
+
+	mermaid, _ := exportOrgChart(t, "?format=mermaid&snapshot=current")
+	if !strings.HasPrefix(mermaid, "flowchart TD\n") || !strings.Contains(mermaid, "Test "+dev+"<br/>"+dev) || !strings.Contains(mermaid, " --> ") {
+		t.Errorf("unexpected Mermaid output:\n%s", mermaid)
+	}
+
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/orgchart/export?format=svg", nil), http.StatusBadRequest, errors.CodeInvalidQuery)
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/orgchart/export?snapshot=missing", nil), http.StatusNotFound, errors.CodeSnapshotNotFound)
+}
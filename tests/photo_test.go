@@ -0,0 +1,99 @@
+package controllers_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+)
+
+// testPhoto returns a PNG image of the given size.
+func testPhoto(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode photo: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// putPhoto uploads data as the photo of the employee.
+func putPhoto(t *testing.T, email string, data []byte) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPut, testServer.URL+"/employees/"+email+"/photo", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "image/png")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to upload photo: %v", err)
+	}
+	return resp
+}
+
+func TestE2E_Photo_UploadAndDownload(t *testing.T) {
+	emp := newTestEmployee("avatar@photo.example.com", "Developer")
+	createEmployee(t, emp)
+	url := testServer.URL + "/employees/" + emp.Email + "/photo"
+
+	expectErrorCode(t, doJSON(t, http.MethodGet, url, nil), http.StatusNotFound, errors.CodePhotoNotFound)
+
+	photo := testPhoto(t, 300, 150)
+	resp := putPhoto(t, emp.Email, photo)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 uploading the photo, got %d", resp.StatusCode)
+	}
+
+	resp = doJSON(t, http.MethodGet, url, nil)
+	defer resp.Body.Close()
+	original, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "image/png" || !bytes.Equal(original, photo) {
+		t.Fatalf("expected the uploaded photo back, got status %d, type %s and %d bytes",
+			resp.StatusCode, resp.Header.Get("Content-Type"), len(original))
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("If-None-Match", resp.Header.Get("ETag"))
+	cached, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send conditional GET: %v", err)
+	}
+	cached.Body.Close()
+	if cached.StatusCode != http.StatusNotModified {
+		t.Errorf("expected status 304 for a matching ETag, got %d", cached.StatusCode)
+	}
+
+	thumbResp := doJSON(t, http.MethodGet, url+"?variant=thumbnail", nil)
+	defer thumbResp.Body.Close()
+	thumbnail, err := png.Decode(thumbResp.Body)
+	if err != nil {
+		t.Fatalf("failed to decode thumbnail: %v", err)
+	}
+	if size := thumbnail.Bounds().Size(); size.X != 128 || size.Y != 64 {
+		t.Errorf("expected a 128x64 thumbnail, got %v", size)
+	}
+}
+
+func TestE2E_Photo_Validation(t *testing.T) {
+	emp := newTestEmployee("invalid@photo.example.com", "Developer")
+	createEmployee(t, emp)
+
+	expectErrorCode(t, putPhoto(t, emp.Email, []byte("not an image")), http.StatusUnsupportedMediaType, errors.CodePhotoUnsupportedType)
+	expectErrorCode(t, putPhoto(t, emp.Email, testPhoto(t, 5000, 1)), http.StatusBadRequest, errors.CodePhotoInvalid)
+	expectErrorCode(t, putPhoto(t, "nobody@photo.example.com", testPhoto(t, 10, 10)), http.StatusNotFound, errors.CodeEmployeeNotFound)
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/"+emp.Email+"/photo?variant=huge", nil),
+		http.StatusBadRequest, errors.CodeInvalidQuery)
+}
@@ -0,0 +1,45 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_QueryEmployeesByExample(t *testing.T) {
+	older := newTestEmployee("example1@query.example.com", "QueryLead", "QueryDev")
+	older.Birthdate.Year = "1980"
+	younger := newTestEmployee("example2@query.example.com", "QueryLead")
+	other := newTestEmployee("example3@query.example.com", "QueryDev")
+	other.Birthdate.Year = "1980"
+	for _, emp := range []models.NewEmployeeBoundary{older, younger, other} {
+		createEmployee(t, emp)
+	}
+
+	example := map[string]interface{}{
+		"roles":     []string{"QueryLead"},
+		"birthdate": map[string]string{"year": "1980"},
+	}
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/employees/query?page=1&size=10", example)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var results []models.EmployeeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(results) != 1 || results[0].Email != older.Email {
+		t.Fatalf("expected only %s to match, got %+v", older.Email, results)
+	}
+
+	// The password must never be usable as a criterion.
+	resp = doJSON(t, http.MethodPost, testServer.URL+"/employees/query?page=1&size=10", map[string]string{"password": "Test1"})
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status 400 when querying by password, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,91 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/controllers"
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/readonly"
+	"WebMVCEmployees/router"
+)
+
+func TestE2E_ReadOnlyReplica_RejectsMutations(t *testing.T) {
+	replica := httptest.NewServer(router.SetupRouter(testEmployeeController, router.WithReadOnlyReplica()))
+	defer replica.Close()
+
+	resp := doJSON(t, http.MethodPost, replica.URL+"/employees", newTestEmployee("replica@readonly.example.com", "Developer"))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405 for POST on a replica, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Allow") == "" {
+		t.Error("expected an Allow header on the 405 response")
+	}
+
+	resp, err := http.Get(replica.URL + "/employees?page=1&size=1")
+	if err != nil {
+		t.Fatalf("failed to GET employees from the replica: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200 for GET on a replica, got %d", resp.StatusCode)
+	}
+
+	resp = doJSON(t, http.MethodPost, replica.URL+"/employees/query?page=1&size=1", map[string]string{})
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200 for the read-only query endpoint on a replica, got %d", resp.StatusCode)
+	}
+}
+
+func TestE2E_ReadOnlyMode_RejectsMutationsUntilSwitchedOff(t *testing.T) {
+	mode := readonly.NewSwitch()
+	admin := controllers.NewAdminController(nil, nil, nil, nil, mode)
+	server := httptest.NewServer(router.SetupRouter(testEmployeeController, router.WithAdmin(admin), router.WithReadOnlyMode(mode)))
+	defer server.Close()
+
+	resp := doJSONAsAdmin(t, http.MethodPut, server.URL+"/admin/read-only", models.ReadOnlyModeRequest{Enabled: true, Reason: "Migrating the employee records"})
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 switching the read-only mode on, got %d", resp.StatusCode)
+	}
+
+	resp = doJSONAsAdmin(t, http.MethodPost, server.URL+"/employees", newTestEmployee("mode@readonly.example.com", "Developer"))
+	var errResp models.ErrorResponse
+	json.NewDecoder(resp.Body).Decode(&errResp)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || errResp.Code != errors.CodeReadOnlyMode {
+		t.Errorf("expected status 503 with %s for POST in read-only mode, got %d with %s", errors.CodeReadOnlyMode, resp.StatusCode, errResp.Code)
+	}
+	if !strings.Contains(errResp.Error, "Migrating the employee records") {
+		t.Errorf("expected the reason in the error, got %q", errResp.Error)
+	}
+
+	resp, err := http.Get(server.URL + "/employees?page=1&size=1")
+	if err != nil {
+		t.Fatalf("failed to GET employees in read-only mode: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200 for GET in read-only mode, got %d", resp.StatusCode)
+	}
+
+	resp = doJSONAsAdmin(t, http.MethodPut, server.URL+"/admin/read-only", models.ReadOnlyModeRequest{Enabled: false})
+	var state readonly.State
+	json.NewDecoder(resp.Body).Decode(&state)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || state.Enabled || state.Since == nil {
+		t.Fatalf("expected the read-only mode switched off, got %d with %+v", resp.StatusCode, state)
+	}
+
+	resp = doJSONAsAdmin(t, http.MethodPost, server.URL+"/employees", newTestEmployee("mode@readonly.example.com", "Developer"))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		t.Errorf("expected the employee created once the read-only mode is off, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,78 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// expectLines fails the test unless the response is 200 with exactly the given reporting lines.
+func expectLines(t *testing.T, resp *http.Response, want ...models.ReportingLine) {
+	t.Helper()
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var lines []models.ReportingLine
+	if err := decodeJSON(resp, &lines); err != nil {
+		t.Fatalf("failed to decode reporting lines: %v", err)
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected reporting lines %v, got %v", want, lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d: expected %v, got %v", i, want[i], lines[i])
+		}
+	}
+}
+
+func TestE2E_ReportingLines(t *testing.T) {
+	emp := newTestEmployee("emp@matrix.example.com", "Developer")
+	boss, projectLead := "boss@matrix.example.com", "pl@matrix.example.com"
+	createEmployee(t, emp)
+	createEmployee(t, newTestEmployee(boss, "Developer"))
+	createEmployee(t, newTestEmployee(projectLead, "Developer"))
+	setManager(t, emp.Email, boss)
+	url := testServer.URL + "/employees/" + emp.Email + "/reporting-lines"
+	primary := models.ReportingLine{Manager: boss, Type: models.ReportingPrimary}
+	dotted := models.ReportingLine{Manager: projectLead, Type: models.ReportingDotted}
+
+	expectLines(t, doJSONAsAdmin(t, http.MethodPost, url, dotted), primary, dotted)
+	// Adding the same line again changes nothing.
+	expectLines(t, doJSONAsAdmin(t, http.MethodPost, url, dotted), primary, dotted)
+
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, url, models.ReportingLine{Manager: boss, Type: models.ReportingDotted}),
+		http.StatusConflict, errors.CodeReportingLineExists)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, url, models.ReportingLine{Manager: emp.Email, Type: models.ReportingDotted}),
+		http.StatusBadRequest, errors.CodeManagerSelf)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, url, models.ReportingLine{Manager: "nobody@matrix.example.com", Type: models.ReportingDotted}),
+		http.StatusBadRequest, errors.CodeManagerNotFound)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, url, models.ReportingLine{Manager: boss, Type: "solid"}),
+		http.StatusBadRequest, errors.CodeInvalidPayload)
+
+	// The employee representation lists the lines next to the primary manager.
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/"+emp.Email+"?password="+emp.Password, nil)
+	var current models.EmployeeResponse
+	if err := decodeJSON(resp, &current); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	resp.Body.Close()
+	if current.Manager == nil || *current.Manager != boss || len(current.ReportingLines) != 2 {
+		t.Errorf("unexpected manager %v and reporting lines %v", current.Manager, current.ReportingLines)
+	}
+
+	// Promoting the dotted-line manager to primary drops the dotted line.
+	expectLines(t, doJSONAsAdmin(t, http.MethodPost, url, models.ReportingLine{Manager: projectLead, Type: models.ReportingPrimary}),
+		models.ReportingLine{Manager: projectLead, Type: models.ReportingPrimary})
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodDelete, url+"/dotted/"+boss, nil), http.StatusNotFound, errors.CodeReportingLineNotFound)
+	expectLines(t, doJSONAsAdmin(t, http.MethodPost, url, models.ReportingLine{Manager: boss, Type: models.ReportingDotted}),
+		models.ReportingLine{Manager: projectLead, Type: models.ReportingPrimary}, models.ReportingLine{Manager: boss, Type: models.ReportingDotted})
+	expectLines(t, doJSONAsAdmin(t, http.MethodDelete, url+"/primary/"+projectLead, nil), models.ReportingLine{Manager: boss, Type: models.ReportingDotted})
+
+	// The single-manager API only sees the primary line.
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/"+emp.Email+"/manager", nil),
+		http.StatusNotFound, errors.CodeManagerNotSet)
+}
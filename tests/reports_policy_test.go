@@ -0,0 +1,105 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// expectManagers fails the test unless the manager chain of the employee is exactly want.
+func expectManagers(t *testing.T, employee string, want ...string) {
+	t.Helper()
+	chain := getEmployees(t, testServer.URL+"/employees/"+employee+"/managers")
+	if len(chain) != len(want) {
+		t.Fatalf("expected %d managers above %s, got %d", len(want), employee, len(chain))
+	}
+	for i, email := range want {
+		if chain[i].Email != email {
+			t.Errorf("manager %d of %s: expected %s, got %s", i, employee, email, chain[i].Email)
+		}
+	}
+}
+
+func TestE2E_DeleteManager_ReportsPolicy(t *testing.T) {
+	top, boss := "top@reports.example.com", "boss@reports.example.com"
+	lead, dev, intern := "lead@reports.example.com", "dev@reports.example.com", "intern@reports.example.com"
+	for _, email := range []string{top, boss, lead, dev, intern} {
+		createEmployee(t, newTestEmployee(email, "Developer"))
+	}
+	setManager(t, boss, top)
+	setManager(t, lead, boss)
+	setManager(t, dev, boss)
+	setManager(t, intern, lead)
+	url := testServer.URL + "/employees/" + boss
+
+	// By default a manager with reports is not deleted.
+	expectErrorCode(t, doJSON(t, http.MethodDelete, url, nil), http.StatusConflict, errors.CodeEmployeeHasReports)
+	expectErrorCode(t, doJSON(t, http.MethodDelete, url+"?reports=later", nil), http.StatusBadRequest, errors.CodeInvalidQuery)
+	expectErrorCode(t, doJSON(t, http.MethodDelete, url+"?reports=reassign", nil), http.StatusBadRequest, errors.CodeInvalidQuery)
+	expectErrorCode(t, doJSON(t, http.MethodDelete, url+"?reports=orphan&reassignTo="+top, nil), http.StatusBadRequest, errors.CodeInvalidQuery)
+	expectErrorCode(t, doJSON(t, http.MethodDelete, url+"?reassignTo=nobody@reports.example.com", nil),
+		http.StatusBadRequest, errors.CodeManagerNotFound)
+	// The lead would end up managed by their own intern.
+	expectErrorCode(t, doJSON(t, http.MethodDelete, url+"?reassignTo="+intern, nil), http.StatusConflict, errors.CodeManagerCycle)
+	expectManagers(t, dev, boss, top)
+
+	// The lead takes over: they report to the top manager and the other reports move under them.
+	resp := doJSON(t, http.MethodDelete, url+"?reassignTo="+lead, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 reassigning the reports, got %d", resp.StatusCode)
+	}
+	expectManagers(t, lead, top)
+	expectManagers(t, dev, lead, top)
+	expectManagers(t, intern, lead, top)
+
+	// Orphaned reports no longer have a manager.
+	resp = doJSONAsAdmin(t, http.MethodDelete, testServer.URL+"/employees/"+lead+"?reports=orphan&hard=true", nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 orphaning the reports, got %d", resp.StatusCode)
+	}
+	expectManagers(t, dev)
+	expectManagers(t, intern)
+}
+
+func TestE2E_ReassignReports(t *testing.T) {
+	oldBoss, newBoss := "old@reorg.example.com", "new@reorg.example.com"
+	lead, dev, intern := "lead@reorg.example.com", "dev@reorg.example.com", "intern@reorg.example.com"
+	for _, email := range []string{oldBoss, newBoss, lead, dev, intern} {
+		createEmployee(t, newTestEmployee(email, "Developer"))
+	}
+	setManager(t, lead, oldBoss)
+	setManager(t, dev, oldBoss)
+	setManager(t, intern, lead)
+	url := testServer.URL + "/employees/manager/reassign"
+
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, url, models.ManagerReassignment{From: oldBoss}),
+		http.StatusBadRequest, errors.CodeInvalidPayload)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, url, models.ManagerReassignment{From: oldBoss, To: oldBoss}),
+		http.StatusBadRequest, errors.CodeInvalidPayload)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, url, models.ManagerReassignment{From: "nobody@reorg.example.com", To: newBoss}),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, url, models.ManagerReassignment{From: oldBoss, To: "nobody@reorg.example.com"}),
+		http.StatusBadRequest, errors.CodeManagerNotFound)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, url, models.ManagerReassignment{From: oldBoss, To: intern}),
+		http.StatusConflict, errors.CodeManagerCycle)
+
+	resp := doJSONAsAdmin(t, http.MethodPost, url, models.ManagerReassignment{From: oldBoss, To: newBoss})
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var result map[string]int64
+	if err := decodeJSON(resp, &result); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if result["reassigned"] != 2 {
+		t.Errorf("expected 2 reassigned employees, got %d", result["reassigned"])
+	}
+	expectManagers(t, lead, newBoss)
+	expectManagers(t, dev, newBoss)
+	expectManagers(t, intern, lead, newBoss)
+}
@@ -0,0 +1,43 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/ulid"
+)
+
+func TestE2E_RequestID_PropagatedOrGenerated(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, testServer.URL+"/no-such-route", nil)
+	req.Header.Set("X-Request-ID", "support-ticket-42")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send the request: %v", err)
+	}
+	var errResp models.ErrorResponse
+	json.NewDecoder(resp.Body).Decode(&errResp)
+	resp.Body.Close()
+	if got := resp.Header.Get("X-Request-ID"); got != "support-ticket-42" {
+		t.Errorf("expected the request ID echoed in the header, got %q", got)
+	}
+	if errResp.RequestID != "support-ticket-42" {
+		t.Errorf("expected the request ID in the error response, got %q", errResp.RequestID)
+	}
+
+	resp, err = http.Get(testServer.URL + "/no-such-route")
+	if err != nil {
+		t.Fatalf("failed to send the request: %v", err)
+	}
+	errResp = models.ErrorResponse{}
+	json.NewDecoder(resp.Body).Decode(&errResp)
+	resp.Body.Close()
+	generated := resp.Header.Get("X-Request-ID")
+	if !ulid.Valid(generated) {
+		t.Errorf("expected a generated ULID request ID, got %q", generated)
+	}
+	if errResp.RequestID != generated {
+		t.Errorf("expected the error response to carry %q, got %q", generated, errResp.RequestID)
+	}
+}
@@ -0,0 +1,62 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_Reviews(t *testing.T) {
+	boss, dev, peer, hr := "boss@reviews.example.com", "dev@reviews.example.com", "peer@reviews.example.com", "hr@reviews.example.com"
+	createEmployee(t, newTestEmployee(boss, "Manager"))
+	createEmployee(t, newTestEmployee(dev, "Developer"))
+	createEmployee(t, newTestEmployee(peer, "Developer"))
+	createEmployee(t, newTestEmployee(hr, "HR"))
+	setManager(t, dev, boss)
+	password := newTestEmployee(dev).Password
+	reviewsURL := testServer.URL + "/employees/" + dev + "/reviews"
+	h1 := models.ReviewRequest{PeriodStart: "2025-01-01", PeriodEnd: "2025-06-30", Rating: 4, Comments: "Solid half."}
+
+	// Only the manager writes reviews.
+	expectErrorCode(t, doJSON(t, http.MethodPost, reviewsURL, h1), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, reviewsURL, h1, dev, password), http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, reviewsURL, h1, hr, password), http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, reviewsURL,
+		models.ReviewRequest{PeriodStart: "2025-06-30", PeriodEnd: "2025-01-01", Rating: 4}, boss, password),
+		http.StatusBadRequest, errors.CodeInvalidReviewPeriod)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, reviewsURL,
+		models.ReviewRequest{PeriodStart: "2025-01-01", PeriodEnd: "2025-06-30", Rating: 6}, boss, password),
+		http.StatusBadRequest, errors.CodeInvalidPayload)
+
+	for _, req := range []models.ReviewRequest{h1, {PeriodStart: "2025-07-01", PeriodEnd: "2025-12-31", Rating: 5}} {
+		resp := doJSONAs(t, http.MethodPost, reviewsURL, req, boss, password)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected status 201 adding a review, got %d", resp.StatusCode)
+		}
+	}
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, reviewsURL, h1, boss, password), http.StatusConflict, errors.CodeReviewExists)
+
+	// The employee, their manager and HR read the reviews, latest period first; peers do not.
+	for _, reader := range []string{dev, boss, hr} {
+		resp := doJSONAs(t, http.MethodGet, reviewsURL, nil, reader, password)
+		defer resp.Body.Close()
+		var reviews []models.Review
+		if err := decodeJSON(resp, &reviews); err != nil {
+			t.Fatalf("failed to decode reviews: %v", err)
+		}
+		if len(reviews) != 2 || reviews[0].PeriodEnd != "2025-12-31" || reviews[1].Comments != h1.Comments || reviews[1].Reviewer != boss {
+			t.Errorf("unexpected reviews read by %s: %+v", reader, reviews)
+		}
+	}
+	expectErrorCode(t, doJSONAs(t, http.MethodGet, reviewsURL, nil, peer, password), http.StatusForbidden, errors.CodeForbidden)
+
+	// A peer cannot become the manager, whether the employee or the peer asks, to read the reviews.
+	for _, caller := range []string{dev, peer} {
+		expectErrorCode(t, doJSONAs(t, http.MethodPut, testServer.URL+"/employees/"+dev+"/manager",
+			models.ManagerEmailBoundary{Email: peer}, caller, password), http.StatusForbidden, errors.CodeForbidden)
+	}
+	expectErrorCode(t, doJSONAs(t, http.MethodGet, reviewsURL, nil, peer, password), http.StatusForbidden, errors.CodeForbidden)
+}
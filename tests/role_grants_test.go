@@ -0,0 +1,109 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_TemporaryRoleGrant_RevokedAfterExpiry(t *testing.T) {
+	email := "grant@grants.example.com"
+	createEmployee(t, newTestEmployee(email, "Developer"))
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+
+	resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/"+email+"/roles/grants",
+		models.RoleGrantRequest{Role: "Auditor", ExpiresAt: expiresAt})
+	var emp models.EmployeeResponse
+	if err := decodeJSON(resp, &emp); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if !slices.Contains(emp.Roles, "Auditor") || len(emp.TemporaryRoles) != 1 || !emp.TemporaryRoles[0].ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("expected a temporary Auditor role until %v, got %+v", expiresAt, emp)
+	}
+
+	// Nothing has expired yet.
+	revoked, err := testEmployeeController.Service.RevokeExpiredRoles(context.Background(), time.Now().UTC())
+	if err != nil {
+		t.Fatalf("failed to revoke expired roles: %v", err)
+	}
+	if revoked != 0 {
+		t.Errorf("expected no revocations before expiry, got %d", revoked)
+	}
+
+	// Run the scheduled job as if the expiry had passed.
+	revoked, err = testEmployeeController.Service.RevokeExpiredRoles(context.Background(), expiresAt.Add(time.Second))
+	if err != nil {
+		t.Fatalf("failed to revoke expired roles: %v", err)
+	}
+	if revoked < 1 {
+		t.Fatalf("expected the grant to be revoked, got %d revocations", revoked)
+	}
+
+	resp = doJSON(t, http.MethodPost, testServer.URL+"/employees/query?page=1&size=1", map[string]string{"email": email})
+	var employees []models.EmployeeResponse
+	if err := decodeJSON(resp, &employees); err != nil {
+		t.Fatalf("failed to decode employees: %v", err)
+	}
+	resp.Body.Close()
+	if len(employees) != 1 || slices.Contains(employees[0].Roles, "Auditor") || len(employees[0].TemporaryRoles) != 0 {
+		t.Errorf("expected the Auditor role to be removed, got %+v", employees)
+	}
+
+	resp, err = getAsAdmin(testServer.URL + "/admin/audit?page=1&size=10&employee=" + email)
+	if err != nil {
+		t.Fatalf("failed to GET audit log: %v", err)
+	}
+	var entries []models.AuditEntry
+	if err := decodeJSON(resp, &entries); err != nil {
+		t.Fatalf("failed to decode audit log: %v", err)
+	}
+	resp.Body.Close()
+	if len(entries) != 2 || entries[0].Action != models.AuditRoleRevoked || entries[1].Action != models.AuditRoleGranted {
+		t.Errorf("expected a revocation after a grant in the audit log, got %+v", entries)
+	}
+}
+
+func TestE2E_TemporaryRoleGrant_InvalidRequests(t *testing.T) {
+	email := "permanent@grants.example.com"
+	createEmployee(t, newTestEmployee(email, "Developer"))
+
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/"+email+"/roles/grants",
+		models.RoleGrantRequest{Role: "Developer", ExpiresAt: time.Now().Add(time.Hour)}),
+		http.StatusConflict, errors.CodeRoleAlreadyHeld)
+
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/"+email+"/roles/grants",
+		models.RoleGrantRequest{Role: "Auditor", ExpiresAt: time.Now().Add(-time.Hour)}),
+		http.StatusBadRequest, errors.CodeGrantExpiryInPast)
+
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/missing@grants.example.com/roles/grants",
+		models.RoleGrantRequest{Role: "Auditor", ExpiresAt: time.Now().Add(time.Hour)}),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+}
+
+func TestE2E_TemporaryRoleGrant_RequiresAdmin(t *testing.T) {
+	email := "self@grants.example.com"
+	createEmployee(t, newTestEmployee(email, "Developer"))
+	url := testServer.URL + "/employees/" + email + "/roles/grants"
+	grant := models.RoleGrantRequest{Role: "Admin", ExpiresAt: time.Now().Add(time.Hour)}
+
+	expectErrorCode(t, doJSON(t, http.MethodPost, url, grant), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSONAs(t, http.MethodPost, url, grant, email, "Test1"), http.StatusForbidden, errors.CodeForbidden)
+
+	// Neither attempt granted anything.
+	roles, ok, err := testEmployeeController.Service.Authenticate(context.Background(), email, "Test1")
+	if err != nil || !ok {
+		t.Fatalf("failed to authenticate %s: %v", email, err)
+	}
+	if slices.Contains(roles, "Admin") {
+		t.Errorf("expected no Admin role, got %v", roles)
+	}
+}
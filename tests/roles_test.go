@@ -0,0 +1,117 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_RolesCatalog(t *testing.T) {
+	dev := models.Role{Name: "Catalog Developer", Description: "Builds the product", Permissions: []string{"code:write"}}
+	resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/roles", dev)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201 creating the role, got %d", resp.StatusCode)
+	}
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/roles", dev), http.StatusConflict, errors.CodeRoleExists)
+	// Roles may imply others, so only admins change the catalog.
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/roles", models.Role{Name: "Catalog Admin", Parent: "Admin"}),
+		http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/roles", models.Role{Name: "Catalog Tester", Permissions: []string{" "}}),
+		http.StatusBadRequest, errors.CodeInvalidPayload)
+	roleURL := testServer.URL + "/roles/Catalog%20Developer"
+
+	// Only the description and permissions can change.
+	resp = doJSONAsAdmin(t, http.MethodPut, roleURL, models.Role{Permissions: []string{"code:write", "deploy:staging"}})
+	defer resp.Body.Close()
+	var updated models.Role
+	if err := decodeJSON(resp, &updated); err != nil {
+		t.Fatalf("failed to decode role: %v", err)
+	}
+	if updated.Name != dev.Name || updated.Description != "" || !slices.Equal(updated.Permissions, []string{"code:write", "deploy:staging"}) {
+		t.Errorf("unexpected updated role %+v", updated)
+	}
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPut, roleURL, models.Role{Name: "Catalog Tester"}),
+		http.StatusBadRequest, errors.CodeRoleNameChange)
+
+	// The catalog lists the known roles for pickers.
+	listResp := doJSON(t, http.MethodGet, testServer.URL+"/roles?size=100", nil)
+	defer listResp.Body.Close()
+	var roles []models.Role
+	if err := decodeJSON(listResp, &roles); err != nil {
+		t.Fatalf("failed to decode roles: %v", err)
+	}
+	if !slices.ContainsFunc(roles, func(r models.Role) bool { return r.Name == dev.Name }) {
+		t.Errorf("expected %s in the catalog, got %+v", dev.Name, roles)
+	}
+
+	resp = doJSONAsAdmin(t, http.MethodDelete, roleURL, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 deleting the role, got %d", resp.StatusCode)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, roleURL, nil), http.StatusNotFound, errors.CodeRoleNotFound)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodDelete, roleURL, nil), http.StatusNotFound, errors.CodeRoleNotFound)
+}
+
+// createRole adds a role to the catalog and fails the test unless it is created.
+func createRole(t *testing.T, role models.Role) {
+	t.Helper()
+	resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/roles", role)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("failed to create role %s, status: %d", role.Name, resp.StatusCode)
+	}
+}
+
+func TestE2E_RoleHierarchy(t *testing.T) {
+	lead, senior, dev := "Hierarchy Lead", "Hierarchy Senior", "Hierarchy Developer"
+	createRole(t, models.Role{Name: dev, Permissions: []string{"code:write"}})
+	createRole(t, models.Role{Name: senior, Parent: dev, Permissions: []string{"code:review", "code:write"}})
+	createRole(t, models.Role{Name: lead, Parent: senior, Permissions: []string{"team:manage"}})
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/roles", models.Role{Name: "Hierarchy Intern", Parent: "Hierarchy Nobody"}),
+		http.StatusBadRequest, errors.CodeRoleParentNotFound)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPut, testServer.URL+"/roles/"+url.PathEscape(dev), models.Role{Parent: lead}),
+		http.StatusConflict, errors.CodeRoleCycle)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodDelete, testServer.URL+"/roles/"+url.PathEscape(dev), nil),
+		http.StatusConflict, errors.CodeRoleHasChildren)
+
+	ann, bob := "ann@role-hierarchy.example.com", "bob@role-hierarchy.example.com"
+	createEmployee(t, newTestEmployee(ann, lead))
+	createEmployee(t, newTestEmployee(bob, dev))
+
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/"+ann+"/roles/effective", nil)
+	defer resp.Body.Close()
+	var effective models.EffectiveRoles
+	if err := decodeJSON(resp, &effective); err != nil {
+		t.Fatalf("failed to decode effective roles: %v", err)
+	}
+	if !slices.Equal(effective.Roles, []string{lead, senior, dev}) {
+		t.Errorf("unexpected effective roles %v", effective.Roles)
+	}
+	if !slices.Equal(effective.Permissions, []string{"team:manage", "code:review", "code:write"}) {
+		t.Errorf("unexpected permissions %v", effective.Permissions)
+	}
+
+	// Filtering by a role finds the employees holding roles that imply it.
+	developers := getEmployees(t, testServer.URL+"/employees?criteria=byRole&value="+url.QueryEscape(dev))
+	if len(developers) != 2 || developers[0].Email != ann || developers[1].Email != bob {
+		t.Errorf("expected %s and %s as developers, got %+v", ann, bob, developers)
+	}
+	seniors := getEmployees(t, testServer.URL+"/employees?criteria=byRole&value="+url.QueryEscape(senior))
+	if len(seniors) != 1 || seniors[0].Email != ann {
+		t.Errorf("expected only %s as senior, got %+v", ann, seniors)
+	}
+
+	// A role implying a manager role allows managing.
+	service := testEmployeeController.Service
+	service.ManagerRoles = []string{senior}
+	defer func() { service.ManagerRoles = nil }()
+	setManager(t, bob, ann)
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPut, testServer.URL+"/employees/"+ann+"/manager", models.ManagerEmailBoundary{Email: bob}),
+		http.StatusUnprocessableEntity, errors.CodeManagerRoleMissing)
+}
@@ -0,0 +1,57 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// documentValidationFailure is the server error code of a write rejected by the collection validator.
+const documentValidationFailure = 121
+
+func TestEmployeeSchemaRejectsOutOfBandWrites(t *testing.T) {
+	repo, err := repository.NewEmployeeRepository(testMongoClient, testMongoDB, "schema_validation")
+	if err != nil {
+		t.Fatalf("failed to create the repository: %v", err)
+	}
+	t.Cleanup(func() { repo.Collection.Drop(context.Background()) })
+	ctx := context.Background()
+
+	valid := models.Employee{
+		Email:     "valid@s.example.com",
+		Name:      "Valid",
+		Password:  "Passw0rd",
+		Birthdate: models.Birthdate{Day: "01", Month: "02", Year: "1990"},
+		Roles:     []string{"Dev"},
+		Version:   1,
+	}
+	if err := repo.Create(ctx, valid); err != nil {
+		t.Fatalf("expected a valid employee to be stored, got %v", err)
+	}
+
+	for name, doc := range map[string]bson.M{
+		"missing birthdate": {"email": "a@s.example.com", "name": "A", "password": "x"},
+		"malformed email":   {"email": "not-an-email", "name": "A", "password": "x", "birthdate": bson.M{"day": "01", "month": "02", "year": "1990"}},
+		"one-digit day":     {"email": "a@s.example.com", "name": "A", "password": "x", "birthdate": bson.M{"day": "1", "month": "02", "year": "1990"}},
+		"numeric year":      {"email": "a@s.example.com", "name": "A", "password": "x", "birthdate": bson.M{"day": "01", "month": "02", "year": 1990}},
+		"roles not a list":  {"email": "a@s.example.com", "name": "A", "password": "x", "birthdate": bson.M{"day": "01", "month": "02", "year": "1990"}, "roles": "Dev"},
+	} {
+		_, err := repo.Collection.InsertOne(ctx, doc)
+		var serverErr mongo.ServerError
+		if !errors.As(err, &serverErr) || !serverErr.HasErrorCode(documentValidationFailure) {
+			t.Errorf("%s: expected the document to be rejected by the validator, got %v", name, err)
+		}
+	}
+
+	_, err = repo.Collection.UpdateOne(ctx, bson.M{"email": valid.Email}, bson.M{"$unset": bson.M{"name": ""}})
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) || !serverErr.HasErrorCode(documentValidationFailure) {
+		t.Fatalf("expected an update removing the name to be rejected, got %v", err)
+	}
+}
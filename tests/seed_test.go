@@ -0,0 +1,67 @@
+package controllers_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"WebMVCEmployees/repository"
+	"WebMVCEmployees/seed"
+	"WebMVCEmployees/services"
+)
+
+func TestSeedDemoIsIdempotent(t *testing.T) {
+	emps, err := seed.Load(seed.Demo)
+	if err != nil {
+		t.Fatalf("failed to load the demo fixture: %v", err)
+	}
+	empService := services.NewEmployeeService(repository.NewMemoryEmployeeRepository())
+	ctx := context.Background()
+
+	created, skipped, err := empService.SeedEmployees(ctx, emps)
+	if err != nil || created != len(emps) || skipped != 0 {
+		t.Fatalf("expected %d employees created, got %d created and %d skipped (%v)", len(emps), created, skipped, err)
+	}
+	created, skipped, err = empService.SeedEmployees(ctx, emps)
+	if err != nil || created != 0 || skipped != len(emps) {
+		t.Fatalf("expected every employee skipped when seeding again, got %d created and %d skipped (%v)", created, skipped, err)
+	}
+}
+
+func TestSeedCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "employees.csv")
+	fixture := "email,name,password,birthdate,roles,manager\n" +
+		"boss@s.example.com,Boss,Passw0rD,1980-01-02,Manager;Admin,\n" +
+		"report@s.example.com,Report,Passw0rD,1990-03-04,Dev,boss@s.example.com\n"
+	if err := os.WriteFile(path, []byte(fixture), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emps, err := seed.Load(path)
+	if err != nil {
+		t.Fatalf("failed to load the CSV fixture: %v", err)
+	}
+	if len(emps) != 2 || len(emps[0].Roles) != 2 || emps[1].Manager == nil || emps[1].Birthdate.Month != "03" {
+		t.Fatalf("unexpected employees read from the CSV fixture: %+v", emps)
+	}
+
+	empService := services.NewEmployeeService(repository.NewMemoryEmployeeRepository())
+	created, _, err := empService.SeedEmployees(context.Background(), emps)
+	if err != nil || created != 2 {
+		t.Fatalf("expected 2 employees created, got %d (%v)", created, err)
+	}
+	report, err := empService.GetEmployee(context.Background(), "report@s.example.com", "Passw0rD")
+	if err != nil || *report.Manager != "boss@s.example.com" {
+		t.Fatalf("expected the report under their manager, got %+v (%v)", report, err)
+	}
+}
+
+func TestSeedRejectsUnknownColumn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "employees.csv")
+	if err := os.WriteFile(path, []byte("email,salary\na@s.example.com,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := seed.Load(path); err == nil {
+		t.Fatal("expected an unknown column to be rejected")
+	}
+}
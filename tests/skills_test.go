@@ -0,0 +1,63 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// employeeWithSkills returns a test employee having the given skills.
+func employeeWithSkills(email string, skills ...models.Skill) models.NewEmployeeBoundary {
+	emp := newTestEmployee(email, "Developer")
+	emp.Skills = skills
+	return emp
+}
+
+func TestE2E_Skills(t *testing.T) {
+	for _, skills := range [][]models.Skill{
+		{{Name: "SkillsGo", Level: 0}},
+		{{Name: "SkillsGo", Level: 6}},
+		{{Name: " ", Level: 3}},
+		{{Name: "SkillsGo", Level: 2}, {Name: "SkillsGo", Level: 4}},
+	} {
+		expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", employeeWithSkills("invalid@skills.example.com", skills...)),
+			http.StatusBadRequest, errors.CodeInvalidSkills)
+	}
+
+	expert, novice, other := "expert@skills.example.com", "novice@skills.example.com", "other@skills.example.com"
+	createEmployee(t, employeeWithSkills(expert, models.Skill{Name: "SkillsGo", Level: 5}, models.Skill{Name: "SkillsSQL", Level: 3}))
+	createEmployee(t, employeeWithSkills(novice, models.Skill{Name: "SkillsGo", Level: 1}))
+	createEmployee(t, employeeWithSkills(other, models.Skill{Name: "SkillsJava", Level: 5}))
+
+	gophers := getEmployees(t, testServer.URL+"/employees?criteria=bySkill&value=SkillsGo")
+	if len(gophers) != 2 || !containsEmployee(gophers, expert) || !containsEmployee(gophers, novice) {
+		t.Errorf("expected %s and %s to know SkillsGo, got %+v", expert, novice, gophers)
+	}
+	proficient := getEmployees(t, testServer.URL+"/employees?criteria=bySkill&value=SkillsGo&minLevel=3")
+	if len(proficient) != 1 || proficient[0].Email != expert || len(proficient[0].Skills) != 2 {
+		t.Errorf("expected only %s with SkillsGo at level 3, got %+v", expert, proficient)
+	}
+	// The level must be held in the named skill, not in another one.
+	if none := getEmployees(t, testServer.URL+"/employees?criteria=bySkill&value=SkillsSQL&minLevel=5"); len(none) != 0 {
+		t.Errorf("expected nobody with SkillsSQL at level 5, got %+v", none)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees?criteria=bySkill", nil),
+		http.StatusBadRequest, errors.CodeMissingParameter)
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees?criteria=bySkill&value=SkillsGo&minLevel=9", nil),
+		http.StatusBadRequest, errors.CodeInvalidCriteria)
+
+	// Query by example requires every skill of the example.
+	var query models.EmployeeQuery
+	query.Skills = []models.Skill{{Name: "SkillsGo", Level: 2}, {Name: "SkillsSQL", Level: 1}}
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/employees/query", query)
+	defer resp.Body.Close()
+	var matches []models.EmployeeResponse
+	if err := decodeJSON(resp, &matches); err != nil {
+		t.Fatalf("failed to decode employees: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Email != expert {
+		t.Errorf("expected only %s to match the skills, got %+v", expert, matches)
+	}
+}
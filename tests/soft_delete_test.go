@@ -0,0 +1,154 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// listDomain returns the employees of GET /employees filtered by the given email domain.
+func listDomain(t *testing.T, domain string) []models.EmployeeResponse {
+	t.Helper()
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/employees?criteria=byEmailDomain&value="+domain, nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 listing %s, got %d", domain, resp.StatusCode)
+	}
+	var employees []models.EmployeeResponse
+	if err := decodeJSON(resp, &employees); err != nil {
+		t.Fatalf("failed to decode employees: %v", err)
+	}
+	return employees
+}
+
+func TestE2E_SoftDeleteAndRestore(t *testing.T) {
+	emp := newTestEmployee("gone@softdelete.example.com", "Developer")
+	createEmployee(t, emp)
+	url := testServer.URL + "/employees/" + emp.Email
+
+	resp := doJSON(t, http.MethodDelete, url, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 deleting the employee, got %d", resp.StatusCode)
+	}
+
+	// A soft-deleted employee is hidden from reads and lists.
+	expectErrorCode(t, doJSON(t, http.MethodGet, url+"?password="+emp.Password, nil), http.StatusNotFound, errors.CodeEmployeeNotFound)
+	if employees := listDomain(t, "softdelete.example.com"); len(employees) != 0 {
+		t.Errorf("expected no listed employees, got %d", len(employees))
+	}
+	// Deleting it again finds nothing.
+	expectErrorCode(t, doJSON(t, http.MethodDelete, url, nil), http.StatusNotFound, errors.CodeEmployeeNotFound)
+
+	resp = doJSON(t, http.MethodPost, url+"/restore", nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 restoring the employee, got %d", resp.StatusCode)
+	}
+	var restored models.EmployeeResponse
+	if err := decodeJSON(resp, &restored); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	if restored.Email != emp.Email || restored.DeletedAt != nil || restored.Version != 3 {
+		t.Errorf("unexpected restored employee %+v", restored)
+	}
+
+	getResp := doJSON(t, http.MethodGet, url+"?password="+emp.Password, nil)
+	getResp.Body.Close()
+	if getResp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200 reading the restored employee, got %d", getResp.StatusCode)
+	}
+	if employees := listDomain(t, "softdelete.example.com"); len(employees) != 1 {
+		t.Errorf("expected the restored employee to be listed, got %d employees", len(employees))
+	}
+
+	expectErrorCode(t, doJSON(t, http.MethodPost, url+"/restore", nil), http.StatusConflict, errors.CodeEmployeeNotDeleted)
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/employees/nobody@softdelete.example.com/restore", nil),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+}
+
+func TestE2E_HardDelete(t *testing.T) {
+	emp := newTestEmployee("purged@harddelete.example.com", "Developer")
+	createEmployee(t, emp)
+	url := testServer.URL + "/employees/" + emp.Email
+
+	expectErrorCode(t, doJSON(t, http.MethodDelete, url+"?hard=maybe", nil), http.StatusBadRequest, errors.CodeInvalidQuery)
+
+	// Only admins delete for good.
+	expectErrorCode(t, doJSON(t, http.MethodDelete, url+"?hard=true", nil), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSONAs(t, http.MethodDelete, url+"?hard=true", nil, emp.Email, emp.Password), http.StatusForbidden, errors.CodeForbidden)
+	expectErrorCode(t, doJSON(t, http.MethodDelete, testServer.URL+"/employees?hard=true", nil), http.StatusUnauthorized, errors.CodeUnauthorized)
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/employees/trash/purge?olderThan=0d", nil), http.StatusUnauthorized, errors.CodeUnauthorized)
+
+	// Hard delete also removes an employee that is already soft-deleted.
+	resp := doJSON(t, http.MethodDelete, url, nil)
+	resp.Body.Close()
+	resp = doJSONAsAdmin(t, http.MethodDelete, url+"?hard=true", nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 hard-deleting the employee, got %d", resp.StatusCode)
+	}
+
+	expectErrorCode(t, doJSON(t, http.MethodPost, url+"/restore", nil), http.StatusNotFound, errors.CodeEmployeeNotFound)
+	// The email is free again.
+	createEmployee(t, emp)
+}
+
+func TestE2E_TrashListAndPurge(t *testing.T) {
+	for _, email := range []string{"a@trash.example.com", "b@trash.example.com"} {
+		createEmployee(t, newTestEmployee(email, "Developer"))
+		resp := doJSON(t, http.MethodDelete, testServer.URL+"/employees/"+email, nil)
+		resp.Body.Close()
+	}
+
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/trash?size=100", nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 listing the trash, got %d", resp.StatusCode)
+	}
+	var trash []models.EmployeeResponse
+	if err := decodeJSON(resp, &trash); err != nil {
+		t.Fatalf("failed to decode trash: %v", err)
+	}
+	found := 0
+	for _, emp := range trash {
+		if emp.DeletedAt == nil {
+			t.Errorf("expected %s to carry deletedAt", emp.Email)
+		}
+		if emp.Email == "a@trash.example.com" || emp.Email == "b@trash.example.com" {
+			found++
+		}
+	}
+	if found != 2 {
+		t.Errorf("expected both deleted employees in the trash, found %d", found)
+	}
+
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/trash/purge?olderThan=soon", nil),
+		http.StatusBadRequest, errors.CodeInvalidQuery)
+
+	// Freshly deleted employees are kept by the default retention.
+	var result map[string]int64
+	purge := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/trash/purge", nil)
+	if err := decodeJSON(purge, &result); err != nil {
+		t.Fatalf("failed to decode purge result: %v", err)
+	}
+	purge.Body.Close()
+	restore := doJSON(t, http.MethodPost, testServer.URL+"/employees/a@trash.example.com/restore", nil)
+	restore.Body.Close()
+	if restore.StatusCode != http.StatusOK {
+		t.Errorf("expected the recently deleted employee to survive the purge, restore answered %d", restore.StatusCode)
+	}
+
+	purge = doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees/trash/purge?olderThan=0d", nil)
+	defer purge.Body.Close()
+	if err := decodeJSON(purge, &result); err != nil {
+		t.Fatalf("failed to decode purge result: %v", err)
+	}
+	if result["purged"] < 1 {
+		t.Errorf("expected the remaining deleted employee to be purged, got %d", result["purged"])
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/employees/b@trash.example.com/restore", nil),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+}
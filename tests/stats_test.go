@@ -0,0 +1,68 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/models"
+)
+
+// statCount returns the count reported for the name, or 0 when it is missing.
+func statCount(counts []models.StatCount, name string) int64 {
+	for _, count := range counts {
+		if count.Name == name {
+			return count.Count
+		}
+	}
+	return 0
+}
+
+func TestE2E_WorkforceStats(t *testing.T) {
+	boss, dev, analyst := "boss@stats.example.com", "dev@stats.example.com", "analyst@stats.example.com"
+	createEmployee(t, newTestEmployee(boss, "StatsLead"))
+	createEmployee(t, newTestEmployee(dev, "StatsAnalyst", "StatsDeveloper"))
+	createEmployee(t, newTestEmployee(analyst, "StatsAnalyst"))
+	setManager(t, dev, boss)
+	setManager(t, analyst, boss)
+
+	resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/stats", nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var stats models.WorkforceStats
+	if err := decodeJSON(resp, &stats); err != nil {
+		t.Fatalf("failed to decode stats: %v", err)
+	}
+
+	if stats.Headcount < 3 {
+		t.Errorf("expected a headcount of at least 3, got %d", stats.Headcount)
+	}
+	if got := statCount(stats.ByEmailDomain, "stats.example.com"); got != 3 {
+		t.Errorf("expected 3 employees in stats.example.com, got %d", got)
+	}
+	if got := statCount(stats.ByRole, "StatsAnalyst"); got != 2 {
+		t.Errorf("expected 2 StatsAnalyst employees, got %d", got)
+	}
+	if got := statCount(stats.ByRole, "StatsDeveloper"); got != 1 {
+		t.Errorf("expected 1 StatsDeveloper employee, got %d", got)
+	}
+	if got := statCount(stats.ByManager, boss); got != 2 {
+		t.Errorf("expected 2 reports for %s, got %d", boss, got)
+	}
+	if stats.AverageAge <= 0 {
+		t.Errorf("expected a positive average age, got %f", stats.AverageAge)
+	}
+
+	// Every employee falls in exactly one age range.
+	if len(stats.AgeDistribution) != 6 {
+		t.Fatalf("expected 6 age ranges, got %+v", stats.AgeDistribution)
+	}
+	var bucketed int64
+	for _, bucket := range stats.AgeDistribution {
+		bucketed += bucket.Count
+	}
+	if bucketed != stats.Headcount {
+		t.Errorf("expected the age ranges to add up to %d, got %d", stats.Headcount, bucketed)
+	}
+}
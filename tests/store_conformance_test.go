@@ -0,0 +1,804 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/config"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+	"WebMVCEmployees/services"
+	"WebMVCEmployees/tenant"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// storeFactory opens empty employee stores of one kind for the conformance tests.
+type storeFactory struct {
+	name string
+	// transactional is false for the standalone MongoDB server of docker-compose, which runs transactions without
+	// one, so their writes are not rolled back.
+	transactional bool
+	open          func(t *testing.T) services.EmployeeStore
+}
+
+// storeFactories lists every EmployeeStore implementation. PostgreSQL is included when POSTGRES_URL is set.
+func storeFactories() []storeFactory {
+	factories := []storeFactory{
+		{name: "mongo", open: func(t *testing.T) services.EmployeeStore {
+			collection := "conformance_" + strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+			repo, err := repository.NewEmployeeRepository(testMongoClient, testMongoDB, collection)
+			if err != nil {
+				t.Fatalf("failed to create the MongoDB store: %v", err)
+			}
+			t.Cleanup(func() { repo.Collection.Drop(context.Background()) })
+			return repo
+		}},
+		{name: "memory", transactional: true, open: func(t *testing.T) services.EmployeeStore {
+			return repository.NewMemoryEmployeeRepository()
+		}},
+		{name: "sqlite", transactional: true, open: func(t *testing.T) services.EmployeeStore {
+			db, err := config.ConnectSQLite(filepath.Join(t.TempDir(), "employees.db"))
+			if err != nil {
+				t.Fatalf("failed to open SQLite: %v", err)
+			}
+			t.Cleanup(func() { db.Close() })
+			repo, err := repository.NewSQLiteEmployeeRepository(db)
+			if err != nil {
+				t.Fatalf("failed to create the SQLite store: %v", err)
+			}
+			return repo
+		}},
+	}
+	if postgresURL := os.Getenv("POSTGRES_URL"); postgresURL != "" {
+		factories = append(factories, storeFactory{name: "postgres", transactional: true, open: func(t *testing.T) services.EmployeeStore {
+			pool, err := config.ConnectPostgres(postgresURL)
+			if err != nil {
+				t.Fatalf("failed to connect to PostgreSQL: %v", err)
+			}
+			t.Cleanup(pool.Close)
+			repo, err := repository.NewPostgresEmployeeRepository(pool)
+			if err != nil {
+				t.Fatalf("failed to create the PostgreSQL store: %v", err)
+			}
+			if _, err := pool.Exec(context.Background(), "TRUNCATE employees"); err != nil {
+				t.Fatalf("failed to empty the employees table: %v", err)
+			}
+			return repo
+		}})
+	}
+	return factories
+}
+
+// storeConformanceCases are run against every store, each with an empty store.
+var storeConformanceCases = []struct {
+	name string
+	run  func(t *testing.T, ctx context.Context, store services.EmployeeStore, factory storeFactory)
+}{
+	{"CreateAndFind", testStoreCreateAndFind},
+	{"UniqueKeys", testStoreUniqueKeys},
+	{"InsertMany", testStoreInsertMany},
+	{"ListFilterSortPage", testStoreList},
+	{"Count", testStoreCount},
+	{"Updates", testStoreUpdates},
+	{"Roles", testStoreRoles},
+	{"ReplaceEmployee", testStoreReplace},
+	{"Delete", testStoreDelete},
+	{"Hierarchy", testStoreHierarchy},
+	{"Statistics", testStoreStatistics},
+	{"Collation", testStoreCollation},
+	{"Transactions", testStoreTransactions},
+	{"MoveEmailReferences", testStoreMoveEmailReferences},
+	{"Tenants", testStoreTenants},
+}
+
+// TestEmployeeStoreConformance checks that the MongoDB, in-memory, SQLite and PostgreSQL stores behave alike.
+func TestEmployeeStoreConformance(t *testing.T) {
+	for _, factory := range storeFactories() {
+		t.Run(factory.name, func(t *testing.T) {
+			for _, c := range storeConformanceCases {
+				t.Run(c.name, func(t *testing.T) {
+					ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+					defer cancel()
+					c.run(t, ctx, factory.open(t), factory)
+				})
+			}
+		})
+	}
+}
+
+// storeEmployee returns an employee to store, with the given email and roles.
+func storeEmployee(email string, roles ...string) models.Employee {
+	emp := models.Employee{
+		Email:     email,
+		Name:      "Conformance " + email,
+		Password:  "secret",
+		Birthdate: models.Birthdate{Day: "01", Month: "01", Year: "1990"},
+		Roles:     roles,
+		Version:   1,
+	}
+	emp.BirthDate, _ = emp.Birthdate.Date()
+	return emp
+}
+
+// everyEmployee matches the stored employees, soft-deleted and terminated ones included.
+var everyEmployee = repository.EmployeeCriteria{State: repository.AnyState}
+
+// activeEmployee selects the employee with the email unless it is soft-deleted.
+func activeEmployee(email string) repository.EmployeeCriteria {
+	return repository.EmployeeCriteria{State: repository.Active, Emails: []string{email}}
+}
+
+// byEmail orders a list by email.
+var byEmail = repository.Page{Sort: []repository.SortKey{{Field: models.EmployeeRef.Email}}}
+
+// mustCreate stores the employees, failing the test on error.
+func mustCreate(t *testing.T, ctx context.Context, store services.EmployeeStore, employees ...models.Employee) {
+	t.Helper()
+	for _, emp := range employees {
+		if err := store.Create(ctx, emp); err != nil {
+			t.Fatalf("failed to create %s: %v", emp.Email, err)
+		}
+	}
+}
+
+// emailsOf returns the emails of the employees, in order.
+func emailsOf(employees []models.Employee) string {
+	emails := make([]string, len(employees))
+	for i, emp := range employees {
+		emails[i] = emp.Email
+	}
+	return strings.Join(emails, ",")
+}
+
+func testStoreCreateAndFind(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	emp := storeEmployee("find@example.com", "Developer", "Admin")
+	emp.ID = "01J9Z3K7Q8X2M4N6P8R0S2T4V6"
+	manager := "boss@example.com"
+	emp.Manager = &manager
+	emp.Metadata = models.Metadata{"badgeNumber": "B-17"}
+	emp.Skills = []models.Skill{{Name: "Go", Level: 4}}
+	mustCreate(t, ctx, store, emp)
+
+	found, err := store.FindByEmail(ctx, emp.Email)
+	if err != nil {
+		t.Fatalf("FindByEmail failed: %v", err)
+	}
+	if found.ID != emp.ID || found.Name != emp.Name || found.Password != "" || found.Version != 1 ||
+		found.Manager == nil || *found.Manager != manager || strings.Join(found.Roles, ",") != "Developer,Admin" ||
+		found.Metadata["badgeNumber"] != "B-17" || len(found.Skills) != 1 || found.Skills[0].Level != 4 {
+		t.Errorf("expected the stored employee back, got %+v", found)
+	}
+
+	if found, err := store.FindOne(ctx, repository.EmployeeCriteria{State: repository.AnyState, ID: emp.ID}); err != nil || found.Password != "" {
+		t.Errorf("expected FindOne by ID to leave the password out, got %+v (%v)", found, err)
+	}
+	if found, err := store.FindWithCredentials(ctx, activeEmployee(emp.Email)); err != nil || found.Password != emp.Password {
+		t.Errorf("expected the password read with the credentials, got %q (%v)", found.Password, err)
+	}
+	if employees, err := store.List(ctx, everyEmployee, repository.Page{}); err != nil || len(employees) != 1 || employees[0].Password != "" {
+		t.Errorf("expected List to leave the password out, got %+v (%v)", employees, err)
+	}
+	updated, err := store.SetStatus(ctx, activeEmployee(emp.Email), models.StatusTransition{From: models.StatusActive, To: models.StatusOnLeave, At: time.Now()})
+	if err != nil || updated.Password != "" {
+		t.Errorf("expected SetStatus to leave the password out, got %+v (%v)", updated, err)
+	}
+	if _, err := store.FindByEmail(ctx, "missing@example.com"); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected ErrNoDocuments for a missing employee, got %v", err)
+	}
+
+	// Soft-deleted employees are not found by email.
+	if _, err := store.SoftDelete(ctx, activeEmployee(emp.Email), time.Now()); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
+	if _, err := store.FindByEmail(ctx, emp.Email); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected ErrNoDocuments for a soft-deleted employee, got %v", err)
+	}
+	if _, err := store.FindWithCredentials(ctx, activeEmployee(emp.Email)); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected ErrNoDocuments for the credentials of a soft-deleted employee, got %v", err)
+	}
+}
+
+func testStoreUniqueKeys(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	first := storeEmployee("unique@example.com")
+	first.ID = "01J9Z3K7Q8X2M4N6P8R0S2T4V7"
+	mustCreate(t, ctx, store, first, storeEmployee("noid1@example.com"), storeEmployee("noid2@example.com"))
+
+	if err := store.Create(ctx, storeEmployee("unique@example.com")); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("expected a duplicate key error for a taken email, got %v", err)
+	}
+	sameID := storeEmployee("other@example.com")
+	sameID.ID = first.ID
+	if err := store.Create(ctx, sameID); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("expected a duplicate key error for a taken ID, got %v", err)
+	}
+	if _, err := store.ChangeEmail(ctx, activeEmployee("noid1@example.com"), "noid2@example.com"); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("expected a duplicate key error for a change to a taken email, got %v", err)
+	}
+	if _, err := store.AssignID(ctx, activeEmployee("noid1@example.com"), first.ID); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("expected a duplicate key error for a taken ID assigned, got %v", err)
+	}
+	if assigned, err := store.AssignID(ctx, repository.EmployeeCriteria{Emails: []string{"noid1@example.com"}, MissingID: true}, "01J9Z3K7Q8X2M4N6P8R0S2T4V8"); err != nil || !assigned {
+		t.Errorf("expected the ID assigned, got %v (%v)", assigned, err)
+	}
+	if assigned, err := store.AssignID(ctx, repository.EmployeeCriteria{Emails: []string{"noid1@example.com"}, MissingID: true}, "01J9Z3K7Q8X2M4N6P8R0S2T4V9"); err != nil || assigned {
+		t.Errorf("expected no ID assigned twice, got %v (%v)", assigned, err)
+	}
+	if n, err := store.Count(ctx, everyEmployee); err != nil || n != 3 {
+		t.Errorf("expected 3 employees after the failed writes, got %d (%v)", n, err)
+	}
+}
+
+func testStoreList(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	terminated := storeEmployee("d@other.org", "Developer")
+	terminated.Status = models.StatusTerminated
+	younger := storeEmployee("e@other.org", "Admin")
+	younger.Birthdate.Year = "2000"
+	younger.BirthDate, _ = younger.Birthdate.Date()
+	mustCreate(t, ctx, store,
+		storeEmployee("c@example.com", "Developer"),
+		storeEmployee("a@example.com", "Admin"),
+		storeEmployee("b@EXAMPLE.com", "Developer", "Admin"),
+		terminated,
+		younger,
+	)
+	byEmailDescending := repository.Page{Sort: []repository.SortKey{{Field: models.EmployeeRef.Email, Descending: true}}}
+	at := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		criteria repository.EmployeeCriteria
+		page     repository.Page
+		want     string
+	}{
+		{"role", repository.EmployeeCriteria{State: repository.AnyState, Roles: [][]string{{"Developer"}}}, byEmail, "b@EXAMPLE.com,c@example.com,d@other.org"},
+		{"domain", repository.EmployeeCriteria{State: repository.AnyState, EmailDomain: "example.com"}, byEmailDescending, "c@example.com,b@EXAMPLE.com,a@example.com"},
+		{"not terminated", repository.EmployeeCriteria{}, byEmail, "a@example.com,b@EXAMPLE.com,c@example.com,e@other.org"},
+		{"page", everyEmployee, repository.Page{Sort: byEmail.Sort, Skip: 1, Limit: 2}, "b@EXAMPLE.com,c@example.com"},
+		{"roles and", repository.EmployeeCriteria{State: repository.AnyState, Roles: [][]string{{"Developer"}, {"Admin"}}}, byEmail, "b@EXAMPLE.com"},
+		{"emails", repository.EmployeeCriteria{State: repository.AnyState, Emails: []string{"a@example.com", "missing@example.com"}}, byEmail, "a@example.com"},
+		{"age", repository.EmployeeCriteria{Age: &repository.AgeCriterion{Years: 36, At: at}}, byEmail, "a@example.com,b@EXAMPLE.com,c@example.com"},
+		{"younger", repository.EmployeeCriteria{Age: &repository.AgeCriterion{Years: 26, At: at}}, byEmail, "e@other.org"},
+	}
+	for _, c := range cases {
+		employees, err := store.List(ctx, c.criteria, c.page)
+		if err != nil {
+			t.Fatalf("%s: List failed: %v", c.name, err)
+		}
+		if got := emailsOf(employees); got != c.want {
+			t.Errorf("%s: expected %s, got %s", c.name, c.want, got)
+		}
+	}
+}
+
+func testStoreInsertMany(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	// Small batches, so the failures of several batches are reported together.
+	if repo, ok := store.(*repository.EmployeeRepository); ok {
+		repo.BatchSize = 2
+	}
+	mustCreate(t, ctx, store, storeEmployee("taken@example.com"))
+	emps := []models.Employee{
+		storeEmployee("a@example.com"),
+		storeEmployee("taken@example.com"),
+		storeEmployee("b@example.com"),
+		storeEmployee("a@example.com"),
+		storeEmployee("c@example.com"),
+	}
+
+	err := store.InsertMany(ctx, emps, false)
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || len(bulkErr.WriteErrors) != 2 ||
+		bulkErr.WriteErrors[0].Index != 1 || bulkErr.WriteErrors[1].Index != 3 || !mongo.IsDuplicateKeyError(err) {
+		t.Fatalf("expected the employees at 1 and 3 to be reported as duplicates, got %v", err)
+	}
+	employees, err := store.List(ctx, everyEmployee, byEmail)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got := emailsOf(employees); got != "a@example.com,b@example.com,c@example.com,taken@example.com" {
+		t.Errorf("expected every other employee inserted, got %s", got)
+	}
+
+	// Ordered, the inserts stop at the first failure.
+	err = store.InsertMany(ctx, []models.Employee{storeEmployee("d@example.com"), storeEmployee("b@example.com"), storeEmployee("e@example.com")}, true)
+	if !errors.As(err, &bulkErr) || len(bulkErr.WriteErrors) != 1 || bulkErr.WriteErrors[0].Index != 1 {
+		t.Fatalf("expected the employee at 1 to be reported as a duplicate, got %v", err)
+	}
+	if n, err := store.Count(ctx, repository.EmployeeCriteria{Emails: []string{"d@example.com", "e@example.com"}}); err != nil || n != 1 {
+		t.Errorf("expected only the employee before the failure inserted, got %d (%v)", n, err)
+	}
+}
+
+func testStoreCollation(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	mustCreate(t, ctx, store, storeEmployee("b@example.com"), storeEmployee("C@example.com"), storeEmployee("a@example.com"))
+	caseInsensitive := &options.Collation{Locale: "en", Strength: 2}
+
+	employees, err := store.List(ctx, everyEmployee, repository.Page{Sort: byEmail.Sort, Collation: caseInsensitive})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got := emailsOf(employees); got != "a@example.com,b@example.com,C@example.com" {
+		t.Errorf("expected the emails sorted case-insensitively, got %s", got)
+	}
+
+	// The reports of a manager are ordered in the collation of the page too.
+	manager := "boss@example.com"
+	mustCreate(t, ctx, store, storeEmployee(manager))
+	if _, err := store.SetManager(ctx, repository.EmployeeCriteria{Emails: []string{"a@example.com", "b@example.com", "C@example.com"}}, manager); err != nil {
+		t.Fatalf("SetManager failed: %v", err)
+	}
+	descending := []repository.SortKey{{Field: models.EmployeeRef.Email, Descending: true}}
+	reports, err := store.Reports(ctx, activeEmployee(manager), 0, repository.Page{Sort: descending, Collation: caseInsensitive})
+	if err != nil {
+		t.Fatalf("Reports failed: %v", err)
+	}
+	if got := emailsOf(employeesOf(reports)); got != "C@example.com,b@example.com,a@example.com" {
+		t.Errorf("expected the reports sorted case-insensitively in reverse, got %s", got)
+	}
+}
+
+func testStoreCount(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	mustCreate(t, ctx, store, storeEmployee("x@example.com", "Developer"), storeEmployee("y@example.com", "Admin"), storeEmployee("z@example.com", "Developer"))
+	if n, err := store.Count(ctx, repository.EmployeeCriteria{Roles: [][]string{{"Developer"}}}); err != nil || n != 2 {
+		t.Errorf("expected 2 developers, got %d (%v)", n, err)
+	}
+	if n, err := store.Count(ctx, repository.EmployeeCriteria{Emails: []string{"x@example.com", "missing@example.com"}}); err != nil || n != 1 {
+		t.Errorf("expected 1 employee, got %d (%v)", n, err)
+	}
+}
+
+func testStoreUpdates(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	emp := storeEmployee("report@example.com", "Developer")
+	emp.DottedLineManagers = []string{"lead@example.com", "other@example.com"}
+	mustCreate(t, ctx, store, emp, storeEmployee("peer@example.com", "Developer"), storeEmployee("admin@example.com", "Admin"))
+
+	// A stale version does not match.
+	atVersion := func(version int64) repository.EmployeeCriteria {
+		criteria := activeEmployee(emp.Email)
+		criteria.Version = version
+		return criteria
+	}
+	if updated, err := store.SetManager(ctx, atVersion(7), "lead@example.com"); err != nil || updated != 0 {
+		t.Errorf("expected no update at a stale version, got %d (%v)", updated, err)
+	}
+	if updated, err := store.SetManager(ctx, atVersion(1), "lead@example.com"); err != nil || updated != 1 {
+		t.Fatalf("expected the manager to be updated, got %d (%v)", updated, err)
+	}
+	found, err := store.FindByEmail(ctx, emp.Email)
+	if err != nil {
+		t.Fatalf("FindByEmail failed: %v", err)
+	}
+	if found.Manager == nil || *found.Manager != "lead@example.com" || found.Version != 2 || strings.Join(found.DottedLineManagers, ",") != "other@example.com" {
+		t.Errorf("expected the manager set and dropped from the dotted lines, got %+v", found)
+	}
+	if updated, err := store.SetManager(ctx, activeEmployee(emp.Email), ""); err != nil || updated != 1 {
+		t.Errorf("expected the manager removed, got %d (%v)", updated, err)
+	}
+	if found, err := store.FindByEmail(ctx, emp.Email); err != nil || found.Manager != nil || found.Version != 3 {
+		t.Errorf("expected no manager left, got %+v (%v)", found, err)
+	}
+
+	// Dotted lines are added once, and only the employees having one count as updated when it is removed.
+	developers := repository.EmployeeCriteria{Roles: [][]string{{"Developer"}}}
+	for range 2 {
+		if updated, err := store.AddDottedLine(ctx, developers, "other@example.com"); err != nil || updated != 2 {
+			t.Errorf("expected 2 developers updated, got %d (%v)", updated, err)
+		}
+	}
+	if found, err := store.FindByEmail(ctx, "peer@example.com"); err != nil || strings.Join(found.DottedLineManagers, ",") != "other@example.com" {
+		t.Errorf("expected the dotted line added once, got %+v (%v)", found, err)
+	}
+	if updated, err := store.RemoveDottedLine(ctx, repository.EmployeeCriteria{}, "other@example.com"); err != nil || updated != 2 {
+		t.Errorf("expected the dotted line removed from 2 employees, got %d (%v)", updated, err)
+	}
+
+	delegation := models.Delegation{Delegate: "peer@example.com", StartsAt: time.Now().UTC().Truncate(time.Millisecond), EndsAt: time.Now().UTC().Add(time.Hour).Truncate(time.Millisecond)}
+	delegated, err := store.SetDelegation(ctx, activeEmployee("admin@example.com"), delegation)
+	if err != nil || delegated.Delegation == nil || delegated.Delegation.Delegate != "peer@example.com" || delegated.Version != 2 {
+		t.Errorf("expected the delegation set, got %+v (%v)", delegated, err)
+	}
+	if _, err := store.SetDelegation(ctx, activeEmployee("missing@example.com"), delegation); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected ErrNoDocuments for a missing employee, got %v", err)
+	}
+	for _, want := range []int64{1, 0} {
+		if removed, err := store.RemoveDelegation(ctx, repository.EmployeeCriteria{}); err != nil || removed != want {
+			t.Errorf("expected %d delegations removed, got %d (%v)", want, removed, err)
+		}
+	}
+
+	at := time.Now().UTC().Truncate(time.Millisecond)
+	transition := models.StatusTransition{From: models.StatusActive, To: models.StatusTerminated, At: at}
+	terminated, err := store.SetStatus(ctx, activeEmployee("peer@example.com"), transition)
+	if err != nil || terminated.Status != models.StatusTerminated || len(terminated.StatusHistory) != 1 || !terminated.StatusHistory[0].At.Equal(at) {
+		t.Errorf("expected the status changed and recorded, got %+v (%v)", terminated, err)
+	}
+
+	if changed, err := store.ChangeEmail(ctx, activeEmployee("admin@example.com"), "root@example.com"); err != nil || !changed {
+		t.Errorf("expected the email changed, got %v (%v)", changed, err)
+	}
+	if changed, err := store.ChangeEmail(ctx, activeEmployee("admin@example.com"), "root@example.com"); err != nil || changed {
+		t.Errorf("expected no employee left with the old email, got %v (%v)", changed, err)
+	}
+	if _, err := store.FindByEmail(ctx, "root@example.com"); err != nil {
+		t.Errorf("expected the employee found by the new email, got %v", err)
+	}
+}
+
+func testStoreRoles(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	mustCreate(t, ctx, store, storeEmployee("dev@example.com", "Developer"), storeEmployee("admin@example.com", "Admin"))
+
+	if updated, err := store.AddRoles(ctx, repository.EmployeeCriteria{}, []string{"Developer", "Reviewer"}); err != nil || updated != 2 {
+		t.Errorf("expected 2 employees updated, got %d (%v)", updated, err)
+	}
+	if found, err := store.FindByEmail(ctx, "dev@example.com"); err != nil || strings.Join(found.Roles, ",") != "Developer,Reviewer" {
+		t.Errorf("expected only the roles not held added, got %+v (%v)", found, err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	granted, err := store.GrantTemporaryRole(ctx, activeEmployee("dev@example.com"), models.RoleGrant{Role: "Auditor", ExpiresAt: now.Add(time.Hour), GrantedAt: now})
+	if err != nil || strings.Join(granted.Roles, ",") != "Developer,Reviewer,Auditor" || len(granted.TemporaryRoles) != 1 {
+		t.Fatalf("expected the role granted, got %+v (%v)", granted, err)
+	}
+	// Granting it again extends the grant.
+	extended, err := store.GrantTemporaryRole(ctx, activeEmployee("dev@example.com"), models.RoleGrant{Role: "Auditor", ExpiresAt: now.Add(2 * time.Hour), GrantedAt: now})
+	if err != nil || len(extended.Roles) != 3 || len(extended.TemporaryRoles) != 1 || !extended.TemporaryRoles[0].ExpiresAt.Equal(now.Add(2*time.Hour)) {
+		t.Fatalf("expected the grant extended, got %+v (%v)", extended, err)
+	}
+	if _, err := store.GrantTemporaryRole(ctx, activeEmployee("missing@example.com"), models.RoleGrant{Role: "Auditor", ExpiresAt: now, GrantedAt: now}); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected ErrNoDocuments for a missing employee, got %v", err)
+	}
+
+	// Only the grants expired by then are revoked.
+	if revoked, err := store.RevokeExpiredRole(ctx, everyEmployee, "Auditor", now.Add(time.Hour)); err != nil || revoked != 0 {
+		t.Errorf("expected no grant revoked before it expires, got %d (%v)", revoked, err)
+	}
+	if revoked, err := store.RevokeExpiredRole(ctx, everyEmployee, "Auditor", now.Add(2*time.Hour)); err != nil || revoked != 1 {
+		t.Errorf("expected the expired grant revoked, got %d (%v)", revoked, err)
+	}
+	found, err := store.FindByEmail(ctx, "dev@example.com")
+	if err != nil || strings.Join(found.Roles, ",") != "Developer,Reviewer" || len(found.TemporaryRoles) != 0 {
+		t.Errorf("expected the role and its grant removed, got %+v (%v)", found, err)
+	}
+}
+
+func testStoreReplace(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	mustCreate(t, ctx, store, storeEmployee("replace@example.com", "Developer"))
+
+	replacement := storeEmployee("replace@example.com", "Admin")
+	replacement.Version = 2
+	if _, err := store.ReplaceEmployee(ctx, replacement, 5, false); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected ErrNoDocuments at a stale version, got %v", err)
+	}
+	if inserted, err := store.ReplaceEmployee(ctx, replacement, 1, false); err != nil || inserted {
+		t.Fatalf("expected the employee replaced, got %v (%v)", inserted, err)
+	}
+	found, err := store.FindByEmail(ctx, replacement.Email)
+	if err != nil || strings.Join(found.Roles, ",") != "Admin" || found.Version != 2 {
+		t.Errorf("expected the replacement stored, got %+v (%v)", found, err)
+	}
+
+	if _, err := store.ReplaceEmployee(ctx, storeEmployee("new@example.com"), 0, false); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected ErrNoDocuments without upsert, got %v", err)
+	}
+	if inserted, err := store.ReplaceEmployee(ctx, storeEmployee("new@example.com"), 0, true); err != nil || !inserted {
+		t.Errorf("expected the employee inserted, got %v (%v)", inserted, err)
+	}
+	if n, err := store.Count(ctx, everyEmployee); err != nil || n != 2 {
+		t.Errorf("expected 2 employees, got %d (%v)", n, err)
+	}
+}
+
+func testStoreDelete(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	mustCreate(t, ctx, store, storeEmployee("d1@example.com", "Temp"), storeEmployee("d2@example.com", "Temp"), storeEmployee("d3@example.com", "Temp"), storeEmployee("keep@example.com"))
+	temps := repository.EmployeeCriteria{State: repository.AnyState, Roles: [][]string{{"Temp"}}}
+
+	at := time.Now().UTC().Truncate(time.Millisecond)
+	if deleted, err := store.SoftDelete(ctx, repository.EmployeeCriteria{Roles: [][]string{{"Temp"}}}, at); err != nil || deleted != 3 {
+		t.Errorf("expected 3 employees soft-deleted, got %d (%v)", deleted, err)
+	}
+	if n, err := store.Count(ctx, repository.EmployeeCriteria{State: repository.Deleted, DeletedBy: at}); err != nil || n != 3 {
+		t.Errorf("expected 3 employees deleted by then, got %d (%v)", n, err)
+	}
+	restored, err := store.Restore(ctx, repository.EmployeeCriteria{State: repository.Deleted, Emails: []string{"d1@example.com"}})
+	if err != nil || restored.DeletedAt != nil || restored.Version != 3 {
+		t.Errorf("expected the employee restored, got %+v (%v)", restored, err)
+	}
+	if _, err := store.Restore(ctx, repository.EmployeeCriteria{State: repository.Deleted, Emails: []string{"d1@example.com"}}); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected ErrNoDocuments for an employee not deleted, got %v", err)
+	}
+
+	if n, err := store.Delete(ctx, repository.EmployeeCriteria{State: repository.Deleted}); err != nil || n != 2 {
+		t.Errorf("expected the 2 soft-deleted employees deleted, got %d (%v)", n, err)
+	}
+	if n, err := store.Delete(ctx, temps); err != nil || n != 1 {
+		t.Errorf("expected the restored employee deleted, got %d (%v)", n, err)
+	}
+	if n, err := store.Delete(ctx, temps); err != nil || n != 0 {
+		t.Errorf("expected nothing left to delete, got %d (%v)", n, err)
+	}
+	employees, err := store.List(ctx, everyEmployee, repository.Page{})
+	if err != nil || emailsOf(employees) != "keep@example.com" {
+		t.Errorf("expected only keep@example.com left, got %s (%v)", emailsOf(employees), err)
+	}
+}
+
+// employeesOf returns the employees of the reports, in order.
+func employeesOf(reports []repository.Report) []models.Employee {
+	employees := make([]models.Employee, len(reports))
+	for i, report := range reports {
+		employees[i] = report.Employee
+	}
+	return employees
+}
+
+// reporting returns an employee to store reporting to manager.
+func reporting(email, manager string) models.Employee {
+	emp := storeEmployee(email)
+	emp.Manager = &manager
+	return emp
+}
+
+func testStoreHierarchy(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	// ceo <- vp <- (lead <- dev, left <- gone), with a cycle between loop1 and loop2.
+	mustCreate(t, ctx, store,
+		storeEmployee("ceo@example.com"),
+		reporting("vp@example.com", "ceo@example.com"),
+		reporting("lead@example.com", "vp@example.com"),
+		reporting("left@example.com", "vp@example.com"),
+		reporting("dev@example.com", "lead@example.com"),
+		reporting("gone@example.com", "left@example.com"),
+		reporting("loop1@example.com", "loop2@example.com"),
+		reporting("loop2@example.com", "loop1@example.com"),
+	)
+	// The walk goes through active employees only.
+	if _, err := store.SoftDelete(ctx, activeEmployee("left@example.com"), time.Now()); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
+
+	chain, err := store.ManagerChain(ctx, activeEmployee("dev@example.com"), 0)
+	if err != nil || emailsOf(chain) != "dev@example.com,lead@example.com,vp@example.com,ceo@example.com" || chain[1].Password != "" {
+		t.Errorf("expected the chain up to the CEO, got %s (%v)", emailsOf(chain), err)
+	}
+	if chain, err := store.ManagerChain(ctx, activeEmployee("dev@example.com"), 2); err != nil || emailsOf(chain) != "dev@example.com,lead@example.com,vp@example.com" {
+		t.Errorf("expected the chain cut at 2 managers, got %s (%v)", emailsOf(chain), err)
+	}
+	if chain, err := store.ManagerChain(ctx, activeEmployee("gone@example.com"), 0); err != nil || emailsOf(chain) != "gone@example.com" {
+		t.Errorf("expected the chain stopped at a soft-deleted manager, got %s (%v)", emailsOf(chain), err)
+	}
+	if chain, err := store.ManagerChain(ctx, activeEmployee("loop1@example.com"), 0); err != nil || emailsOf(chain) != "loop1@example.com,loop2@example.com" {
+		t.Errorf("expected the chain stopped when it comes back, got %s (%v)", emailsOf(chain), err)
+	}
+	if chain, err := store.ManagerChain(ctx, activeEmployee("missing@example.com"), 0); err != nil || len(chain) != 0 {
+		t.Errorf("expected no chain for a missing employee, got %s (%v)", emailsOf(chain), err)
+	}
+
+	reports, err := store.Reports(ctx, activeEmployee("ceo@example.com"), 0, byEmail)
+	if err != nil || emailsOf(employeesOf(reports)) != "vp@example.com,lead@example.com,dev@example.com" {
+		t.Fatalf("expected the reports by depth then email, got %s (%v)", emailsOf(employeesOf(reports)), err)
+	}
+	if reports[0].Depth != 1 || reports[1].Depth != 2 || reports[2].Depth != 3 || reports[2].Password != "" {
+		t.Errorf("expected the depths 1, 2 and 3 without passwords, got %+v", reports)
+	}
+	paged := repository.Page{Sort: byEmail.Sort, Skip: 1, Limit: 1}
+	if reports, err := store.Reports(ctx, activeEmployee("ceo@example.com"), 0, paged); err != nil || emailsOf(employeesOf(reports)) != "lead@example.com" {
+		t.Errorf("expected the second report alone, got %s (%v)", emailsOf(employeesOf(reports)), err)
+	}
+	if reports, err := store.Reports(ctx, activeEmployee("ceo@example.com"), 2, byEmail); err != nil || emailsOf(employeesOf(reports)) != "vp@example.com,lead@example.com" {
+		t.Errorf("expected the reports 2 levels down, got %s (%v)", emailsOf(employeesOf(reports)), err)
+	}
+	if reports, err := store.Reports(ctx, activeEmployee("loop1@example.com"), 0, byEmail); err != nil || emailsOf(employeesOf(reports)) != "loop2@example.com" {
+		t.Errorf("expected the employee left out of their own reports, got %s (%v)", emailsOf(employeesOf(reports)), err)
+	}
+
+	if counts, err := store.CountReports(ctx, activeEmployee("vp@example.com")); err != nil || counts.Direct != 1 || counts.Total != 2 {
+		t.Errorf("expected 1 direct and 2 reports in all, got %+v (%v)", counts, err)
+	}
+	if counts, err := store.CountReports(ctx, activeEmployee("loop2@example.com")); err != nil || counts.Direct != 1 || counts.Total != 1 {
+		t.Errorf("expected the cycle counted once, got %+v (%v)", counts, err)
+	}
+	if _, err := store.CountReports(ctx, activeEmployee("missing@example.com")); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected ErrNoDocuments for a missing employee, got %v", err)
+	}
+}
+
+func testStoreStatistics(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	hired := func(emp models.Employee, hireDate, costCenter string) models.Employee {
+		emp.HireDate, emp.CostCenter = hireDate, costCenter
+		return emp
+	}
+	terminated := hired(storeEmployee("t@other.org", "Developer"), "2020-06-01", "CC-1")
+	terminated.Status = models.StatusTerminated
+	manager := "c@example.com"
+	mustCreate(t, ctx, store,
+		hired(storeEmployee("a@example.com", "Developer", "Admin"), "2019-06-15", "CC-1"),
+		hired(reporting("b@other.org", manager), "2021-06-03", "CC-2"),
+		hired(storeEmployee(manager, "Developer"), "2026-06-10", ""),
+		hired(reporting("d@example.com", manager), "2018-07-01", "CC-1"),
+		terminated,
+	)
+	if _, err := store.AddRoles(ctx, activeEmployee("b@other.org"), []string{"Sales"}); err != nil {
+		t.Fatalf("AddRoles failed: %v", err)
+	}
+
+	// Those hired in the year have no anniversary yet.
+	anniversaries, err := store.Anniversaries(ctx, repository.EmployeeCriteria{}, time.June, 2026, repository.Page{})
+	if err != nil || len(anniversaries) != 2 {
+		t.Fatalf("expected 2 anniversaries, got %+v (%v)", anniversaries, err)
+	}
+	if a := anniversaries[0]; a.Email != "b@other.org" || a.Date != "2026-06-03" || a.YearsOfService != 5 || a.HireDate != "2021-06-03" {
+		t.Errorf("expected the anniversary of b@other.org first, got %+v", a)
+	}
+	if a := anniversaries[1]; a.Email != "a@example.com" || a.Date != "2026-06-15" || a.YearsOfService != 7 {
+		t.Errorf("expected the anniversary of a@example.com next, got %+v", a)
+	}
+	if anniversaries, err := store.Anniversaries(ctx, repository.EmployeeCriteria{}, time.June, 2026, repository.Page{Skip: 1, Limit: 1}); err != nil || len(anniversaries) != 1 || anniversaries[0].Email != "a@example.com" {
+		t.Errorf("expected the second anniversary alone, got %+v (%v)", anniversaries, err)
+	}
+
+	headcounts, err := store.HeadcountByCostCenter(ctx, repository.EmployeeCriteria{})
+	if err != nil {
+		t.Fatalf("HeadcountByCostCenter failed: %v", err)
+	}
+	byCostCenter := map[string]int64{}
+	for _, headcount := range headcounts {
+		byCostCenter[headcount.CostCenter] = headcount.Headcount
+	}
+	if len(byCostCenter) != 3 || byCostCenter["CC-1"] != 2 || byCostCenter["CC-2"] != 1 || byCostCenter[""] != 1 {
+		t.Errorf("expected CC-1: 2, CC-2: 1 and none: 1, got %+v", headcounts)
+	}
+
+	workforce, err := store.Workforce(ctx, repository.EmployeeCriteria{}, time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Workforce failed: %v", err)
+	}
+	if workforce.Headcount != 4 {
+		t.Errorf("expected a headcount of 4, got %d", workforce.Headcount)
+	}
+	wantCounts := func(name string, got []models.StatCount, want string) {
+		parts := make([]string, len(got))
+		for i, count := range got {
+			parts[i] = count.Name + ":" + strconv.FormatInt(count.Count, 10)
+		}
+		if strings.Join(parts, ",") != want {
+			t.Errorf("expected %s %s, got %s", name, want, strings.Join(parts, ","))
+		}
+	}
+	wantCounts("by role", workforce.ByRole, "Developer:2,Admin:1,Sales:1")
+	wantCounts("by domain", workforce.ByDomain, "example.com:3,other.org:1")
+	wantCounts("by manager", workforce.ByManager, "c@example.com:2")
+	if len(workforce.Ages) != 1 || workforce.Ages[36] != 4 {
+		t.Errorf("expected the 4 employees aged 36, got %+v", workforce.Ages)
+	}
+}
+
+func testStoreTransactions(t *testing.T, ctx context.Context, store services.EmployeeStore, factory storeFactory) {
+	err := store.InTransaction(ctx, func(ctx context.Context) error {
+		if err := store.Create(ctx, storeEmployee("tx1@example.com")); err != nil {
+			return err
+		}
+		// A transaction started within one joins it.
+		return store.InTransaction(ctx, func(ctx context.Context) error {
+			return store.Create(ctx, storeEmployee("tx2@example.com"))
+		})
+	})
+	if err != nil {
+		t.Fatalf("InTransaction failed: %v", err)
+	}
+	if n, err := store.Count(ctx, everyEmployee); err != nil || n != 2 {
+		t.Fatalf("expected the writes of the transaction committed, got %d (%v)", n, err)
+	}
+	if !factory.transactional {
+		return
+	}
+
+	failure := errors.New("failure")
+	err = store.InTransaction(ctx, func(ctx context.Context) error {
+		if err := store.Create(ctx, storeEmployee("tx3@example.com")); err != nil {
+			return err
+		}
+		// A failed write leaves the transaction usable.
+		if err := store.Create(ctx, storeEmployee("tx1@example.com")); !mongo.IsDuplicateKeyError(err) {
+			t.Errorf("expected a duplicate key error, got %v", err)
+		}
+		if _, err := store.FindByEmail(ctx, "tx3@example.com"); err != nil {
+			t.Errorf("expected the transaction to see its own write, got %v", err)
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected the error of fn, got %v", err)
+	}
+	if _, err := store.FindByEmail(ctx, "tx3@example.com"); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected the write of the failed transaction rolled back, got %v", err)
+	}
+}
+
+func testStoreMoveEmailReferences(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	manager := "old@example.com"
+	report := storeEmployee("report@example.com")
+	report.Manager = &manager
+	dotted := storeEmployee("dotted@example.com")
+	dotted.DottedLineManagers = []string{"someone@example.com", manager}
+	mustCreate(t, ctx, store, storeEmployee(manager), report, dotted)
+
+	if err := store.MoveEmailReferences(ctx, everyEmployee, manager, "new@example.com"); err != nil {
+		t.Fatalf("MoveEmailReferences failed: %v", err)
+	}
+	found, err := store.FindByEmail(ctx, report.Email)
+	if err != nil || found.Manager == nil || *found.Manager != "new@example.com" || found.Version != 2 {
+		t.Errorf("expected the manager moved, got %+v (%v)", found, err)
+	}
+	found, err = store.FindByEmail(ctx, dotted.Email)
+	if err != nil || strings.Join(found.DottedLineManagers, ",") != "someone@example.com,new@example.com" {
+		t.Errorf("expected the dotted line moved, got %+v (%v)", found, err)
+	}
+}
+
+func testStoreTenants(t *testing.T, ctx context.Context, store services.EmployeeStore, _ storeFactory) {
+	scoped := tenant.NewEmployeeStore(store)
+	acme, globex := tenant.NewContext(ctx, "acme"), tenant.NewContext(ctx, "globex")
+	manager := "boss@example.com"
+	report := storeEmployee("report@example.com")
+	report.Manager = &manager
+	mustCreate(t, acme, scoped, storeEmployee(manager), report)
+	// The same emails are free in another tenant.
+	mustCreate(t, globex, scoped, storeEmployee(manager), storeEmployee("other@example.com"))
+	if err := scoped.Create(acme, storeEmployee(manager)); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("expected a duplicate email within the tenant rejected, got %v", err)
+	}
+
+	found, err := scoped.FindByEmail(acme, manager)
+	if err != nil || found.TenantID != "acme" {
+		t.Errorf("expected the employee of the tenant found, got %+v (%v)", found, err)
+	}
+	if _, err := scoped.FindByEmail(globex, report.Email); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected the employee of another tenant not found, got %v", err)
+	}
+	employees, err := scoped.List(globex, everyEmployee, byEmail)
+	if err != nil || emailsOf(employees) != "boss@example.com,other@example.com" {
+		t.Errorf("expected the employees of the tenant listed, got %s (%v)", emailsOf(employees), err)
+	}
+	if n, err := scoped.Count(ctx, repository.EmployeeCriteria{State: repository.AnyState, Emails: []string{manager}}); err != nil || n != 2 {
+		t.Errorf("expected both tenants counted without a tenant, got %d (%v)", n, err)
+	}
+
+	// Reporting lines are followed within the tenant.
+	if reports, err := scoped.Reports(globex, activeEmployee(manager), 0, byEmail); err != nil || len(reports) != 0 {
+		t.Errorf("expected no reports found in another tenant, got %s (%v)", emailsOf(employeesOf(reports)), err)
+	}
+	if reports, err := scoped.Reports(acme, activeEmployee(manager), 0, byEmail); err != nil || emailsOf(employeesOf(reports)) != report.Email {
+		t.Errorf("expected the report of the tenant found, got %s (%v)", emailsOf(employeesOf(reports)), err)
+	}
+
+	// Writes stay within the tenant.
+	if err := scoped.MoveEmailReferences(globex, everyEmployee, manager, "new@example.com"); err != nil {
+		t.Fatalf("MoveEmailReferences failed: %v", err)
+	}
+	if found, err := scoped.FindByEmail(acme, report.Email); err != nil || found.Manager == nil || *found.Manager != manager {
+		t.Errorf("expected the references of another tenant unchanged, got %+v (%v)", found, err)
+	}
+	if deleted, err := scoped.Delete(globex, everyEmployee); err != nil || deleted != 2 {
+		t.Errorf("expected the 2 employees of the tenant deleted, got %d (%v)", deleted, err)
+	}
+	if n, err := scoped.Count(acme, everyEmployee); err != nil || n != 2 {
+		t.Errorf("expected the employees of another tenant kept, got %d (%v)", n, err)
+	}
+
+	// The empty tenant ID reaches the employees of no tenant, such as the operators of the deployment.
+	operators := tenant.NewContext(ctx, "")
+	mustCreate(t, ctx, scoped, storeEmployee(manager, "Admin"))
+	found, err = scoped.FindWithCredentials(operators, activeEmployee(manager))
+	if err != nil || found.TenantID != "" || len(found.Roles) != 1 {
+		t.Errorf("expected the employee of no tenant found, got %+v (%v)", found, err)
+	}
+	if n, err := scoped.Count(operators, everyEmployee); err != nil || n != 1 {
+		t.Errorf("expected the employee of no tenant counted alone, got %d (%v)", n, err)
+	}
+}
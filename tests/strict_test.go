@@ -0,0 +1,64 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/negotiate"
+)
+
+// withExtraFields returns the employee as a JSON object with additional fields.
+func withExtraFields(t *testing.T, emp models.NewEmployeeBoundary, extra map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	body, _ := json.Marshal(emp)
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("failed to convert employee: %v", err)
+	}
+	for key, value := range extra {
+		payload[key] = value
+	}
+	return payload
+}
+
+func TestE2E_StrictDecoding_RejectsUnknownFields(t *testing.T) {
+	payload := withExtraFields(t, newTestEmployee("typo@strict.example.com", "Developer"), map[string]interface{}{
+		"nmae":      "Typo",
+		"birthdate": map[string]string{"day": "01", "month": "01", "year": "1990", "yaer": "1990"},
+	})
+	resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", payload)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", resp.StatusCode)
+	}
+	var errResp models.ErrorResponse
+	if err := decodeJSON(resp, &errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Code != errors.CodeUnknownFields {
+		t.Errorf("expected code %s, got %s", errors.CodeUnknownFields, errResp.Code)
+	}
+	for _, field := range []string{"nmae", "birthdate.yaer"} {
+		if !strings.Contains(errResp.Error, field) {
+			t.Errorf("expected the error to name %q, got %q", field, errResp.Error)
+		}
+	}
+}
+
+func TestE2E_StrictDecoding_Disabled(t *testing.T) {
+	negotiate.StrictDecoding = false
+	defer func() { negotiate.StrictDecoding = true }()
+
+	payload := withExtraFields(t, newTestEmployee("lenient@strict.example.com", "Developer"), map[string]interface{}{
+		"nickname": "Len",
+	})
+	resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", payload)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected unknown fields to be ignored, got status %d", resp.StatusCode)
+	}
+}
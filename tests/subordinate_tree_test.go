@@ -0,0 +1,96 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_Subordinates_Recursive(t *testing.T) {
+	head, lead, dev, intern := "head@tree.example.com", "lead@tree.example.com", "dev@tree.example.com", "intern@tree.example.com"
+	for _, email := range []string{head, lead, dev, intern} {
+		createEmployee(t, newTestEmployee(email, "Developer"))
+	}
+	setManager(t, lead, head)
+	setManager(t, dev, lead)
+	setManager(t, intern, dev)
+
+	getTree := func(query string) []models.Subordinate {
+		t.Helper()
+		resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/"+head+"/subordinates?recursive=true"+query, nil)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", resp.StatusCode)
+		}
+		var tree []models.Subordinate
+		if err := decodeJSON(resp, &tree); err != nil {
+			t.Fatalf("failed to decode subordinates: %v", err)
+		}
+		return tree
+	}
+
+	tree := getTree("")
+	want := []struct {
+		email string
+		depth int
+	}{{lead, 1}, {dev, 2}, {intern, 3}}
+	if len(tree) != len(want) {
+		t.Fatalf("expected %d subordinates, got %d", len(want), len(tree))
+	}
+	for i, w := range want {
+		if tree[i].Email != w.email || tree[i].Depth != w.depth {
+			t.Errorf("subordinate %d: expected %s at depth %d, got %s at depth %d", i, w.email, w.depth, tree[i].Email, tree[i].Depth)
+		}
+	}
+
+	if tree := getTree("&depth=2"); len(tree) != 2 {
+		t.Errorf("expected 2 subordinates within depth 2, got %d", len(tree))
+	}
+	if tree := getTree("&page=2&size=2"); len(tree) != 1 || tree[0].Email != intern {
+		t.Errorf("expected only the intern on the second page, got %+v", tree)
+	}
+
+	if direct := getEmployees(t, testServer.URL+"/employees/"+head+"/subordinates"); len(direct) != 1 {
+		t.Errorf("expected only the direct report without recursive, got %d", len(direct))
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/"+head+"/subordinates?recursive=maybe", nil),
+		http.StatusBadRequest, errors.CodeInvalidQuery)
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/missing@tree.example.com/subordinates?recursive=true", nil),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+}
+
+func TestE2E_CountReports(t *testing.T) {
+	head, lead, qa, dev, intern := "head@span.example.com", "lead@span.example.com", "qa@span.example.com", "dev@span.example.com", "intern@span.example.com"
+	for _, email := range []string{head, lead, qa, dev, intern} {
+		createEmployee(t, newTestEmployee(email, "Developer"))
+	}
+	setManager(t, lead, head)
+	setManager(t, qa, head)
+	setManager(t, dev, lead)
+	setManager(t, intern, dev)
+
+	countReports := func(email string) models.ReportCounts {
+		t.Helper()
+		resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/"+email+"/reports/count", nil)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200 counting the reports of %s, got %d", email, resp.StatusCode)
+		}
+		var counts models.ReportCounts
+		if err := decodeJSON(resp, &counts); err != nil {
+			t.Fatalf("failed to decode report counts: %v", err)
+		}
+		return counts
+	}
+	if counts := countReports(head); counts != (models.ReportCounts{Direct: 2, Total: 4}) {
+		t.Errorf("unexpected counts for the head %+v", counts)
+	}
+	if counts := countReports(intern); counts != (models.ReportCounts{}) {
+		t.Errorf("expected no reports for the intern, got %+v", counts)
+	}
+
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees/nobody@span.example.com/reports/count", nil),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+}
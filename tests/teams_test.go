@@ -0,0 +1,87 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// teamMembers sends a membership change and returns the members of the updated team.
+func teamMembers(t *testing.T, method, url string) []string {
+	t.Helper()
+	resp := doJSON(t, method, url, nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 from %s %s, got %d", method, url, resp.StatusCode)
+	}
+	var team models.Team
+	if err := decodeJSON(resp, &team); err != nil {
+		t.Fatalf("failed to decode team: %v", err)
+	}
+	return team.Members
+}
+
+func TestE2E_Teams(t *testing.T) {
+	lead, dev, qa := "lead@teams.example.com", "dev@teams.example.com", "qa@teams.example.com"
+	for _, email := range []string{lead, dev, qa} {
+		createEmployee(t, newTestEmployee(email, "Developer"))
+	}
+	url := testServer.URL + "/teams"
+
+	expectErrorCode(t, doJSON(t, http.MethodPost, url, models.TeamRequest{Name: "Platform", Lead: "nobody@teams.example.com"}),
+		http.StatusBadRequest, errors.CodeTeamLeadNotFound)
+	expectErrorCode(t, doJSON(t, http.MethodPost, url, models.TeamRequest{Name: "Platform", Lead: lead, Members: []string{dev, "ghost@teams.example.com"}}),
+		http.StatusBadRequest, errors.CodeEmployeeNotFound)
+
+	resp := doJSON(t, http.MethodPost, url, models.TeamRequest{Name: "Platform", Lead: lead, Members: []string{dev, dev}})
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201 creating the team, got %d", resp.StatusCode)
+	}
+	var team models.Team
+	if err := decodeJSON(resp, &team); err != nil {
+		t.Fatalf("failed to decode team: %v", err)
+	}
+	if team.ID == "" || team.Lead != lead || len(team.Members) != 1 || team.Members[0] != dev {
+		t.Errorf("unexpected team %+v", team)
+	}
+	teamURL := url + "/" + team.ID
+
+	if members := teamMembers(t, http.MethodPut, teamURL+"/members/"+qa); len(members) != 2 {
+		t.Errorf("expected 2 members after adding %s, got %v", qa, members)
+	}
+	// Adding a member again changes nothing.
+	if members := teamMembers(t, http.MethodPut, teamURL+"/members/"+qa); len(members) != 2 {
+		t.Errorf("expected 2 members after adding %s again, got %v", qa, members)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPut, teamURL+"/members/ghost@teams.example.com", nil),
+		http.StatusNotFound, errors.CodeEmployeeNotFound)
+	expectErrorCode(t, doJSON(t, http.MethodPut, url+"/missing/members/"+qa, nil), http.StatusNotFound, errors.CodeTeamNotFound)
+
+	// The teams of an employee include the ones they lead.
+	for _, email := range []string{lead, qa} {
+		resp := doJSON(t, http.MethodGet, testServer.URL+"/employees/"+email+"/teams", nil)
+		var teams []models.Team
+		if err := decodeJSON(resp, &teams); err != nil {
+			t.Fatalf("failed to decode teams: %v", err)
+		}
+		resp.Body.Close()
+		if len(teams) != 1 || teams[0].ID != team.ID {
+			t.Errorf("expected %s to be in team %s, got %+v", email, team.ID, teams)
+		}
+	}
+
+	if members := teamMembers(t, http.MethodDelete, teamURL+"/members/"+qa); len(members) != 1 {
+		t.Errorf("expected 1 member after removing %s, got %v", qa, members)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodDelete, teamURL+"/members/"+qa, nil), http.StatusNotFound, errors.CodeTeamMemberNotFound)
+
+	del := doJSON(t, http.MethodDelete, teamURL, nil)
+	del.Body.Close()
+	if del.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 deleting the team, got %d", del.StatusCode)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, teamURL, nil), http.StatusNotFound, errors.CodeTeamNotFound)
+}
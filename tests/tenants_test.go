@@ -0,0 +1,124 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/controllers"
+	"WebMVCEmployees/inflight"
+	"WebMVCEmployees/models"
+	"WebMVCEmployees/repository"
+	"WebMVCEmployees/router"
+	"WebMVCEmployees/services"
+	"WebMVCEmployees/tenant"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestTenants_NotesStayWithinTheTenant(t *testing.T) {
+	ctx := context.Background()
+	repo, err := repository.NewEmployeeRepository(testMongoClient, testMongoDB, "tenant_notes_employees")
+	if err != nil {
+		t.Fatalf("failed to create the MongoDB store: %v", err)
+	}
+	store := tenant.NewEmployeeStore(repo)
+	notes, err := repository.NewNoteRepository(testMongoClient, testMongoDB)
+	if err != nil {
+		t.Fatalf("failed to create the note repository: %v", err)
+	}
+	service := services.NewNoteService(store, notes)
+	acme, globex := tenant.NewContext(ctx, "acme-notes"), tenant.NewContext(ctx, "globex-notes")
+	t.Cleanup(func() {
+		for _, id := range []string{"acme-notes", "globex-notes"} {
+			testMongoClient.Database(repository.TenantDatabase(testMongoDB, id)).Drop(ctx)
+		}
+		repo.Collection.Drop(ctx)
+	})
+
+	// Both tenants have an employee with the email, so only the notes tell them apart.
+	email := "noted@tenants.example.com"
+	for _, tenantCtx := range []context.Context{acme, globex} {
+		if err := store.Create(tenantCtx, storeEmployee(email)); err != nil {
+			t.Fatalf("failed to create the employee: %v", err)
+		}
+	}
+	note, err := service.AddNote(acme, email, "hr@tenants.example.com", models.NoteRequest{Text: "Acme only"}, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("failed to add a note: %v", err)
+	}
+
+	if found, err := service.ListNotes(globex, email, 1, 10); err != nil || len(found) != 0 {
+		t.Errorf("expected no notes of another tenant, got %+v (%v)", found, err)
+	}
+	if found, err := service.ListNotes(ctx, email, 1, 10); err == nil && len(found) != 0 {
+		t.Errorf("expected no notes of a tenant without one, got %+v", found)
+	}
+	if err := service.DeleteNote(globex, email, note.ID); err == nil {
+		t.Errorf("expected the note of another tenant not found")
+	}
+	if found, err := service.ListNotes(acme, email, 1, 10); err != nil || len(found) != 1 || found[0].ID != note.ID {
+		t.Errorf("expected the note of the tenant listed, got %+v (%v)", found, err)
+	}
+	deployment, err := notes.Collection.In(ctx)
+	if err != nil {
+		t.Fatalf("failed to get the notes of the deployment: %v", err)
+	}
+	if n, err := deployment.CountDocuments(ctx, bson.M{"_id": note.ID}); err != nil || n != 0 {
+		t.Errorf("expected the note kept out of the database of the deployment, got %d (%v)", n, err)
+	}
+}
+
+func TestTenants_AdminRoutesOnlyOpenToTheOperators(t *testing.T) {
+	ctx := context.Background()
+	repo, err := repository.NewEmployeeRepository(testMongoClient, testMongoDB, "tenant_admin_employees")
+	if err != nil {
+		t.Fatalf("failed to create the MongoDB store: %v", err)
+	}
+	t.Cleanup(func() { repo.Collection.Drop(ctx) })
+	store := tenant.NewEmployeeStore(repo)
+	empService := services.NewEmployeeService(store)
+	adminController := controllers.NewAdminController(nil, nil, nil, inflight.NewTracker(), nil)
+	server := httptest.NewServer(router.SetupRouter(controllers.NewEmployeeController(empService),
+		router.WithTenants("X-Tenant-ID", ""), router.WithAdmin(adminController)))
+	defer server.Close()
+
+	// The operator, of no tenant, shares the email with an admin of a tenant.
+	operator := storeEmployee("ops@tenants.example.com", "Admin")
+	tenantAdmin := storeEmployee(operator.Email, "Admin")
+	tenantAdmin.Password = "tenant"
+	acme := tenant.NewContext(ctx, "acme-admin")
+	mustCreate(t, ctx, store, operator)
+	mustCreate(t, acme, store, tenantAdmin, storeEmployee("admin@tenants.example.com", "Admin"))
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		status   int
+	}{
+		{"operator", operator.Email, operator.Password, http.StatusOK},
+		{"admin of a tenant sharing the email", tenantAdmin.Email, tenantAdmin.Password, http.StatusUnauthorized},
+		{"admin of a tenant", "admin@tenants.example.com", "secret", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/admin/inflight", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.SetBasicAuth(tt.email, tt.password)
+			req.Header.Set("X-Tenant-ID", "acme-admin")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("failed to GET /admin/inflight: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// employeeHiredYearsAgo returns a test employee hired the given number of years and a month ago.
+func employeeHiredYearsAgo(email string, years int) models.NewEmployeeBoundary {
+	emp := newTestEmployee(email, "Developer")
+	emp.HireDate = time.Now().UTC().AddDate(-years, -1, 0).Format(time.DateOnly)
+	return emp
+}
+
+func TestE2E_HireDateAndTenure(t *testing.T) {
+	emp := newTestEmployee("invalid@tenure.example.com", "Developer")
+	for _, hireDate := range []string{"2020/01/31", time.Now().UTC().AddDate(0, 0, 2).Format(time.DateOnly), "1985-06-01"} {
+		emp.HireDate = hireDate
+		expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeInvalidHireDate)
+	}
+
+	junior, mid, senior := "junior@tenure.example.com", "mid@tenure.example.com", "senior@tenure.example.com"
+	createEmployee(t, employeeHiredYearsAgo(senior, 6))
+	createEmployee(t, employeeHiredYearsAgo(junior, 1))
+	createEmployee(t, employeeHiredYearsAgo(mid, 3))
+	createEmployee(t, newTestEmployee("unknown@tenure.example.com", "Developer"))
+
+	tenured := getEmployees(t, testServer.URL+"/employees?criteria=byTenure&min=2&max=5&size=100")
+	if !containsEmployee(tenured, mid) || containsEmployee(tenured, junior) || containsEmployee(tenured, senior) {
+		t.Errorf("expected only %s between 2 and 5 years, got %+v", mid, tenured)
+	}
+	veterans := getEmployees(t, testServer.URL+"/employees?criteria=byTenure&min=5&size=100")
+	if !containsEmployee(veterans, senior) || containsEmployee(veterans, mid) {
+		t.Errorf("expected %s with at least 5 years, got %+v", senior, veterans)
+	}
+
+	// Sorting by seniority puts the longest-serving employees first.
+	var order []string
+	for _, e := range getEmployees(t, testServer.URL+"/employees?criteria=byTenure&min=0&sort=seniority&size=100") {
+		if e.Email == junior || e.Email == mid || e.Email == senior {
+			order = append(order, e.Email)
+		}
+	}
+	if len(order) != 3 || order[0] != senior || order[1] != mid || order[2] != junior {
+		t.Errorf("expected %s, %s, %s by seniority, got %v", senior, mid, junior, order)
+	}
+
+	for _, query := range []string{"", "&min=-1", "&min=4&max=2", "&max=many"} {
+		resp := doJSON(t, http.MethodGet, testServer.URL+"/employees?criteria=byTenure"+query, nil)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400 for byTenure%s, got %d", query, resp.StatusCode)
+		}
+	}
+}
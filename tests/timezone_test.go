@@ -0,0 +1,72 @@
+package controllers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"WebMVCEmployees/errors"
+)
+
+// agedEmails returns the emails among the given ones of the employees listed with the age.
+func agedEmails(t *testing.T, age int, emails ...string) []string {
+	t.Helper()
+	var found []string
+	for _, emp := range getEmployees(t, fmt.Sprintf("%s/employees?criteria=byAge&value=%d&page=1&size=100", testServer.URL, age)) {
+		for _, email := range emails {
+			if emp.Email == email {
+				found = append(found, email)
+			}
+		}
+	}
+	return found
+}
+
+func TestE2E_Timezone_Age(t *testing.T) {
+	// Kiritimati is 25 hours ahead of Pago Pago, so its date is always at least a day later.
+	kiritimati, _ := time.LoadLocation("Pacific/Kiritimati")
+	today := time.Now().In(kiritimati)
+	if today.YearDay() == 1 {
+		t.Skip("Pago Pago is still in the previous year")
+	}
+	// 28 years back keeps leap years aligned.
+	birthdate := today.AddDate(-28, 0, 0)
+
+	ahead, behind := "ahead@timezones.example.com", "behind@timezones.example.com"
+	for email, zone := range map[string]string{ahead: "Pacific/Kiritimati", behind: "Pacific/Pago_Pago"} {
+		emp := newTestEmployee(email, "Developer")
+		emp.Birthdate.Day, emp.Birthdate.Month, emp.Birthdate.Year = birthdate.Format("02"), birthdate.Format("01"), birthdate.Format("2006")
+		emp.Timezone, emp.Locale = zone, "en-US"
+		createEmployee(t, emp)
+	}
+
+	// The birthday has come in Kiritimati but not yet in Pago Pago.
+	if found := agedEmails(t, 28, ahead, behind); len(found) != 1 || found[0] != ahead {
+		t.Errorf("expected only %s to be 28, got %v", ahead, found)
+	}
+	if found := agedEmails(t, 27, ahead, behind); len(found) != 1 || found[0] != behind {
+		t.Errorf("expected only %s to be 27, got %v", behind, found)
+	}
+}
+
+func TestE2E_Timezone_LocaleValidated(t *testing.T) {
+	emp := newTestEmployee("zoned@timezones.example.com", "Developer")
+	emp.Timezone = "Mars/Olympus"
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeInvalidTimezone)
+	emp.Timezone, emp.Locale = "Asia/Jerusalem", "klingon"
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeInvalidLocale)
+
+	emp.Locale = "he-IL"
+	createEmployee(t, emp)
+	employees := getEmployees(t, testServer.URL+"/employees?criteria=byEmailDomain&value=timezones.example.com&size=50")
+	for _, listed := range employees {
+		if listed.Email == emp.Email {
+			if listed.Timezone != "Asia/Jerusalem" || listed.Locale != "he-IL" {
+				t.Errorf("expected the time zone and locale to be stored, got %+v", listed)
+			}
+			return
+		}
+	}
+	t.Errorf("expected %s to be listed", emp.Email)
+}
@@ -0,0 +1,77 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_Titles(t *testing.T) {
+	engineer := models.JobTitle{Name: "Titles Engineer", Description: "Builds the product", Grades: []string{"TL3", "TL4"}}
+	resp := doJSON(t, http.MethodPost, testServer.URL+"/titles", engineer)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201 creating the title, got %d", resp.StatusCode)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/titles", engineer), http.StatusConflict, errors.CodeTitleExists)
+	expectErrorCode(t, doJSON(t, http.MethodPost, testServer.URL+"/titles", models.JobTitle{Name: " "}),
+		http.StatusBadRequest, errors.CodeInvalidPayload)
+	titleURL := testServer.URL + "/titles/" + url.PathEscape(engineer.Name)
+
+	// Only the description and grades can change.
+	resp = doJSON(t, http.MethodPut, titleURL, models.JobTitle{Grades: []string{"TL3", "TL4", "TL5"}})
+	defer resp.Body.Close()
+	var updated models.JobTitle
+	if err := decodeJSON(resp, &updated); err != nil {
+		t.Fatalf("failed to decode title: %v", err)
+	}
+	if updated.Name != engineer.Name || updated.Description != "" || !slices.Equal(updated.Grades, []string{"TL3", "TL4", "TL5"}) {
+		t.Errorf("unexpected updated title %+v", updated)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodPut, titleURL, models.JobTitle{Name: "Titles Manager"}),
+		http.StatusBadRequest, errors.CodeTitleNameChange)
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/titles/Titles%20Manager", nil),
+		http.StatusNotFound, errors.CodeTitleNotFound)
+
+	// Employees may only hold titles of the catalog, at one of their grades.
+	emp := newTestEmployee("dev@titles.example.com", "Developer")
+	emp.Title = "Titles Manager"
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeTitleNotFound)
+	emp.Title, emp.Grade = engineer.Name, "TL9"
+	expectErrorCode(t, doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp), http.StatusBadRequest, errors.CodeInvalidGrade)
+	emp.Grade = "TL4"
+	createEmployee(t, emp)
+	peer := newTestEmployee("peer@titles.example.com", "Developer")
+	peer.Title, peer.Grade = engineer.Name, "TL5"
+	createEmployee(t, peer)
+	createEmployee(t, newTestEmployee("other@titles.example.com", "Developer"))
+
+	holders := getEmployees(t, testServer.URL+"/employees?criteria=byTitle&value="+url.QueryEscape(engineer.Name))
+	if len(holders) != 2 || holders[0].Email != emp.Email || holders[1].Email != peer.Email {
+		t.Errorf("expected %s and %s to hold %s, got %+v", emp.Email, peer.Email, engineer.Name, holders)
+	}
+	graded := getEmployees(t, testServer.URL+"/employees?criteria=byGrade&value=TL4")
+	if len(graded) != 1 || graded[0].Email != emp.Email || graded[0].Title != engineer.Name || graded[0].Grade != "TL4" {
+		t.Errorf("expected only %s at grade TL4, got %+v", emp.Email, graded)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, testServer.URL+"/employees?criteria=byGrade", nil),
+		http.StatusBadRequest, errors.CodeMissingParameter)
+
+	// A title held by employees is kept.
+	expectErrorCode(t, doJSON(t, http.MethodDelete, titleURL, nil), http.StatusConflict, errors.CodeTitleInUse)
+	for _, holder := range []models.NewEmployeeBoundary{emp, peer} {
+		holder.Title, holder.Grade = "", ""
+		putResp := putEmployee(t, testServer.URL+"/employees/"+holder.Email, holder, "")
+		putResp.Body.Close()
+	}
+	resp = doJSON(t, http.MethodDelete, titleURL, nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 deleting the title, got %d", resp.StatusCode)
+	}
+	expectErrorCode(t, doJSON(t, http.MethodGet, titleURL, nil), http.StatusNotFound, errors.CodeTitleNotFound)
+}
@@ -0,0 +1,78 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// putEmployee sends PUT /employees/{email} as the test admin, with an optional If-Match header.
+func putEmployee(t *testing.T, url string, emp models.NewEmployeeBoundary, ifMatch string) *http.Response {
+	t.Helper()
+	body, _ := json.Marshal(emp)
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(testAdminEmail, testAdminPassword)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to PUT employee: %v", err)
+	}
+	return resp
+}
+
+func TestE2E_ReplaceEmployee_Upsert(t *testing.T) {
+	emp := newTestEmployee("synced@upsert.example.com", "Developer")
+	url := testServer.URL + "/employees/" + emp.Email
+
+	// Without upsert, a missing employee is not created.
+	expectErrorCode(t, putEmployee(t, url, emp, ""), http.StatusNotFound, errors.CodeEmployeeNotFound)
+
+	resp := putEmployee(t, url+"?upsert=true", emp, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status 201 when upserting a new employee, got %d", resp.StatusCode)
+	}
+	if etag := resp.Header.Get("ETag"); etag != `"1"` {
+		t.Errorf("expected ETag \"1\", got %s", etag)
+	}
+
+	// The same request replaces the employee the second time.
+	emp.Name = "Synced Again"
+	emp.Roles = []string{"Lead"}
+	resp = putEmployee(t, url+"?upsert=true", emp, "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 when upserting an existing employee, got %d", resp.StatusCode)
+	}
+	var replaced employeeBody
+	if err := decodeJSON(resp, &replaced); err != nil {
+		t.Fatalf("failed to decode employee: %v", err)
+	}
+	if replaced.Name != "Synced Again" || len(replaced.Roles) != 1 || replaced.Roles[0] != "Lead" || replaced.Version != 2 {
+		t.Errorf("unexpected replaced employee %+v", replaced)
+	}
+	if replaced.Password != "" {
+		t.Error("expected the password to be omitted")
+	}
+}
+
+func TestE2E_ReplaceEmployee_Conflicts(t *testing.T) {
+	emp := newTestEmployee("stale@upsert.example.com", "Developer")
+	createEmployee(t, emp)
+	url := testServer.URL + "/employees/" + emp.Email
+
+	expectErrorCode(t, putEmployee(t, url, emp, `"7"`), http.StatusPreconditionFailed, errors.CodeEmployeeVersionMismatch)
+
+	other := newTestEmployee("other@upsert.example.com", "Developer")
+	expectErrorCode(t, putEmployee(t, url, other, ""), http.StatusBadRequest, errors.CodeEmailMismatch)
+}
@@ -0,0 +1,57 @@
+package controllers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+func TestE2E_ValidationErrorsReferenceFieldPaths(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(*models.NewEmployeeBoundary)
+		code   string
+		path   string
+	}{
+		{"missing name", func(e *models.NewEmployeeBoundary) { e.Name = "" }, errors.CodeEmployeeRequiredFields, "name"},
+		{"short day", func(e *models.NewEmployeeBoundary) { e.Birthdate.Day = "1" }, errors.CodeInvalidBirthdate, "birthdate.day"},
+		{"no such day", func(e *models.NewEmployeeBoundary) {
+			e.Birthdate = models.Birthdate{Day: "31", Month: "02", Year: "1990"}
+		}, errors.CodeInvalidBirthdate, "birthdate"},
+		{"no February 30", func(e *models.NewEmployeeBoundary) {
+			e.Birthdate = models.Birthdate{Day: "30", Month: "02", Year: "2023"}
+		}, errors.CodeInvalidBirthdate, "birthdate"},
+		{"short password", func(e *models.NewEmployeeBoundary) { e.Password = "A1" }, errors.CodePasswordTooShort, "password"},
+		{"weak password", func(e *models.NewEmployeeBoundary) { e.Password = "weak1" }, errors.CodePasswordTooWeak, "password"},
+		{"unknown country", func(e *models.NewEmployeeBoundary) {
+			e.Address = &models.Address{City: "Tel Aviv", Country: "XX"}
+		}, errors.CodeInvalidAddress, "address.country"},
+		{"bad metadata key", func(e *models.NewEmployeeBoundary) {
+			e.Metadata = models.Metadata{"9lives": "yes"}
+		}, errors.CodeInvalidMetadata, "metadata[9lives]"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			emp := newTestEmployee("paths@validation.example.com", "Developer")
+			tc.modify(&emp)
+			resp := doJSONAsAdmin(t, http.MethodPost, testServer.URL+"/employees", emp)
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d", resp.StatusCode)
+			}
+			var errResp models.ErrorResponse
+			if err := decodeJSON(resp, &errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if errResp.Code != tc.code {
+				t.Errorf("expected error code %s, got %s", tc.code, errResp.Code)
+			}
+			if !strings.HasPrefix(errResp.Error, tc.path+" ") {
+				t.Errorf("expected the message to start with %q, got %q", tc.path, errResp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package controllers_test
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+
+	"WebMVCEmployees/errors"
+	"WebMVCEmployees/models"
+)
+
+// doXML sends an XML body and asks for an XML response, signed in as the test admin.
+func doXML(t *testing.T, method, url, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to create %s request: %v", method, err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/xml")
+	}
+	req.Header.Set("Accept", "application/xml")
+	req.SetBasicAuth(testAdminEmail, testAdminPassword)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send %s request: %v", method, err)
+	}
+	return resp
+}
+
+func TestE2E_XML_CreateAndGetEmployee(t *testing.T) {
+	body := `<Employee>
+		<email>xml@xml.example.com</email>
+		<name>Xml Consumer</name>
+		<password>Test1</password>
+		<birthdate><day>01</day><month>01</month><year>1990</year></birthdate>
+		<roles><role>Developer</role><role>Legacy</role></roles>
+	</Employee>`
+	resp := doXML(t, http.MethodPost, testServer.URL+"/employees", body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 for an XML create, got %d", resp.StatusCode)
+	}
+
+	resp = doXML(t, http.MethodGet, testServer.URL+"/employees/xml@xml.example.com?password=Test1", "")
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/xml") {
+		t.Fatalf("expected an XML response, got %q", ct)
+	}
+	var emp employeeBody
+	if err := xml.NewDecoder(resp.Body).Decode(&emp); err != nil {
+		t.Fatalf("failed to decode XML employee: %v", err)
+	}
+	if emp.Name != "Xml Consumer" || len(emp.Roles) != 2 || emp.Password != "" {
+		t.Errorf("unexpected employee %+v", emp)
+	}
+}
+
+func TestE2E_XML_ListAndErrors(t *testing.T) {
+	resp := doXML(t, http.MethodGet, testServer.URL+"/employees?page=1&size=2", "")
+	var list struct {
+		Employees []employeeBody `xml:"Employee"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode XML list: %v", err)
+	}
+	resp.Body.Close()
+	if len(list.Employees) == 0 {
+		t.Error("expected employees in the XML list")
+	}
+
+	resp = doXML(t, http.MethodGet, testServer.URL+"/employees?page=0&size=2", "")
+	defer resp.Body.Close()
+	var errResp models.ErrorResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode XML error: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest || errResp.Code != errors.CodeInvalidPagination {
+		t.Errorf("expected a 400 %s error, got %d %+v", errors.CodeInvalidPagination, resp.StatusCode, errResp)
+	}
+}
@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName names the tracer of the MongoDB spans.
+const tracerName = "WebMVCEmployees/tracing"
+
+// commandKey identifies a command among those in progress: request IDs are only unique per connection.
+type commandKey struct {
+	connectionID string
+	requestID    int64
+}
+
+// CommandMonitor returns a MongoDB command monitor opening a client span for each command, such as
+// "employees.find", as a child of the span of the context the command runs with. The span records the database, the
+// collection and the server, but not the command, which holds employee data; failed commands are marked as errors.
+func CommandMonitor() *event.CommandMonitor {
+	tracer := otel.Tracer(tracerName)
+	var spans sync.Map
+	finished := func(e event.CommandFinishedEvent, err error) {
+		value, ok := spans.LoadAndDelete(commandKey{e.ConnectionID, e.RequestID})
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			collection := commandCollection(e)
+			name := e.CommandName
+			if collection != "" {
+				name = collection + "." + e.CommandName
+			}
+			attrs := []attribute.KeyValue{
+				semconv.DBSystemMongoDB,
+				semconv.DBNamespace(e.DatabaseName),
+				semconv.DBOperationName(e.CommandName),
+			}
+			if collection != "" {
+				attrs = append(attrs, semconv.DBCollectionName(collection))
+			}
+			// The connection ID is the address of the server followed by the number of the connection, such as
+			// localhost:27017[-3].
+			addr := e.ConnectionID
+			if i := strings.LastIndex(addr, "[-"); i >= 0 {
+				addr = addr[:i]
+			}
+			if host, port, err := net.SplitHostPort(addr); err == nil {
+				attrs = append(attrs, semconv.ServerAddress(host))
+				if n, err := strconv.Atoi(port); err == nil {
+					attrs = append(attrs, semconv.ServerPort(n))
+				}
+			}
+			_, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+			spans.Store(commandKey{e.ConnectionID, e.RequestID}, span)
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) { finished(e.CommandFinishedEvent, nil) },
+		Failed:    func(_ context.Context, e *event.CommandFailedEvent) { finished(e.CommandFinishedEvent, e.Failure) },
+	}
+}
+
+// commandCollection returns the collection the command runs on, the value of its first element, or "" for commands
+// on the database, such as ping.
+func commandCollection(e *event.CommandStartedEvent) string {
+	element, err := e.Command.IndexErr(0)
+	if err != nil {
+		return ""
+	}
+	collection, ok := element.Value().StringValueOK()
+	if !ok {
+		return ""
+	}
+	return collection
+}
@@ -0,0 +1,164 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans records the spans ended during the test, restoring the tracer provider afterwards.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+// runCommand reports the command to the monitor as the driver does, run with ctx on the connection, failing with
+// failure unless nil.
+func runCommand(ctx context.Context, monitor *event.CommandMonitor, requestID int64, command bson.D, failure error) {
+	raw, err := bson.Marshal(command)
+	if err != nil {
+		panic(err)
+	}
+	finished := event.CommandFinishedEvent{
+		CommandName:  command[0].Key,
+		DatabaseName: "employees_db",
+		RequestID:    requestID,
+		ConnectionID: "localhost:27017[-3]",
+	}
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command:      raw,
+		DatabaseName: finished.DatabaseName,
+		CommandName:  finished.CommandName,
+		RequestID:    requestID,
+		ConnectionID: finished.ConnectionID,
+	})
+	if failure != nil {
+		monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: finished, Failure: failure})
+		return
+	}
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: finished, Reply: bson.Raw{}})
+}
+
+func TestCommandMonitor_SpansOfTheRequest(t *testing.T) {
+	recorder := recordSpans(t)
+	monitor := CommandMonitor()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(otelgin.Middleware("webmvc-employees"))
+	r.GET("/employees/:employeeEmail", func(ctx *gin.Context) {
+		runCommand(ctx.Request.Context(), monitor, 1, bson.D{
+			{Key: "find", Value: "employees"},
+			{Key: "filter", Value: bson.D{{Key: "email", Value: "jane.doe@example.com"}}},
+		}, nil)
+		runCommand(ctx.Request.Context(), monitor, 2, bson.D{
+			{Key: "update", Value: "employees"},
+			{Key: "updates", Value: bson.A{bson.D{{Key: "q", Value: bson.D{{Key: "email", Value: "jane.doe@example.com"}}}}}},
+		}, errors.New("E11000 duplicate key error"))
+		ctx.Status(http.StatusOK)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/employees/jane.doe@example.com", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	find, update, request := spans[0], spans[1], spans[2]
+	if request.SpanKind() != trace.SpanKindServer {
+		t.Fatalf("expected the request span last, got %s", request.Name())
+	}
+	for _, span := range []sdktrace.ReadOnlySpan{find, update} {
+		if span.Parent().SpanID() != request.SpanContext().SpanID() || span.SpanContext().TraceID() != request.SpanContext().TraceID() {
+			t.Errorf("expected %s to be a child of the request span", span.Name())
+		}
+		if span.SpanKind() != trace.SpanKindClient {
+			t.Errorf("expected %s to be a client span, got %s", span.Name(), span.SpanKind())
+		}
+	}
+	if find.Name() != "employees.find" || update.Name() != "employees.update" {
+		t.Errorf("expected employees.find and employees.update, got %s and %s", find.Name(), update.Name())
+	}
+
+	attrs := map[string]string{}
+	for _, attr := range find.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	want := map[string]string{
+		"db.system":          "mongodb",
+		"db.namespace":       "employees_db",
+		"db.operation.name":  "find",
+		"db.collection.name": "employees",
+		"server.address":     "localhost",
+		"server.port":        "27017",
+	}
+	for key, value := range want {
+		if attrs[key] != value {
+			t.Errorf("expected %s %q, got %q", key, value, attrs[key])
+		}
+	}
+
+	if find.Status().Code != codes.Unset || update.Status().Code != codes.Error {
+		t.Errorf("expected only the failed command to be an error, got %v and %v", find.Status().Code, update.Status().Code)
+	}
+}
+
+func TestCommandMonitor_LeavesOutTheCommand(t *testing.T) {
+	recorder := recordSpans(t)
+	monitor := CommandMonitor()
+	runCommand(context.Background(), monitor, 1, bson.D{
+		{Key: "insert", Value: "employees"},
+		{Key: "documents", Value: bson.A{bson.D{{Key: "email", Value: "jane.doe@example.com"}, {Key: "salary", Value: 123456}}}},
+	}, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	for _, attr := range spans[0].Attributes() {
+		if attr.Key == "db.query.text" || attr.Key == "db.statement" {
+			t.Errorf("expected the command not to be recorded, got %s", attr.Key)
+		}
+		if value := attr.Value.Emit(); strings.Contains(value, "jane.doe") || strings.Contains(value, "123456") {
+			t.Errorf("expected no employee data, got %s=%s", attr.Key, value)
+		}
+	}
+	if len(spans[0].Events()) != 0 {
+		t.Errorf("expected no events, got %v", spans[0].Events())
+	}
+}
+
+func TestCommandMonitor_DatabaseCommands(t *testing.T) {
+	recorder := recordSpans(t)
+	monitor := CommandMonitor()
+	runCommand(context.Background(), monitor, 1, bson.D{{Key: "ping", Value: 1}}, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "ping" {
+		t.Fatalf("expected a ping span, got %v", spans)
+	}
+	for _, attr := range spans[0].Attributes() {
+		if attr.Key == "db.collection.name" {
+			t.Errorf("expected no collection, got %s", attr.Value.Emit())
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Package ulid generates Universally Unique Lexicographically Sortable Identifiers: 26 characters of Crockford's
+// base32 encoding a 48-bit millisecond timestamp followed by 80 random bits, so identifiers sort by creation time.
+package ulid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"strings"
+	"time"
+)
+
+// Length is the number of characters of an identifier.
+const Length = 26
+
+// alphabet is Crockford's base32 alphabet, which leaves out I, L, O and U.
+const alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// New returns a new identifier for the time t.
+func New(t time.Time) string {
+	var data [16]byte
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(t.UnixMilli()))
+	copy(data[:6], ms[2:])
+	// crypto/rand never fails on the supported platforms.
+	_, _ = rand.Read(data[6:])
+	return encode(data)
+}
+
+// encode writes the 128 bits of data as 26 base32 characters, the first of which holds only 3 bits.
+func encode(data [16]byte) string {
+	hi := binary.BigEndian.Uint64(data[:8])
+	lo := binary.BigEndian.Uint64(data[8:])
+	var out [Length]byte
+	for i := Length - 1; i >= 0; i-- {
+		out[i] = alphabet[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// Valid reports whether s is a well-formed identifier. Lowercase letters are accepted, as Crockford's
+// encoding is case-insensitive; Normalize maps them to the canonical form.
+func Valid(s string) bool {
+	if len(s) != Length || s[0] > '7' {
+		return false
+	}
+	for _, c := range strings.ToUpper(s) {
+		if !strings.ContainsRune(alphabet, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// Normalize returns the canonical, uppercase form of an identifier.
+func Normalize(s string) string {
+	return strings.ToUpper(s)
+}